@@ -20,12 +20,12 @@ var addCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) error {
 		modulePath := args[0]
 
-		cfg, projectRoot, err := project.LoadConfig()
-		if err!= nil {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
 			return err
 		}
 
-		fmt.Printf("📦 Adding dependency: %s\n", modulePath)
+		logger.Printf("📦 Adding dependency: %s\n", modulePath)
 		// Execute 'go get' to download the dependency and update go.mod/go.sum.
 		err = runner.ExecuteCommand(projectRoot, "go", "get", modulePath)
 		if err!= nil {
@@ -42,13 +42,8 @@ var addCmd = &cobra.Command{
 			version = "latest"
 		}
 
-		if cfg.Dependencies == nil {
-			cfg.Dependencies = make(map[string]string)
-		}
-		cfg.Dependencies[moduleName] = version
-
-		// Save the updated configuration back to goforge.yml.
-		err = project.SaveConfig(projectRoot, cfg)
+		// Record it in goforge.yml itself, leaving the files it builds on alone.
+		err = project.SetConfigValue(projectRoot, []string{"dependencies", moduleName}, version)
 		if err!= nil {
 			return fmt.Errorf("failed to update goforge.yml: %w", err)
 		}
@@ -58,7 +53,7 @@ var addCmd = &cobra.Command{
 			logger.Warn("Failed to tidy go modules: %v", err)
 		}
 
-		fmt.Printf("✅ Successfully added '%s' and updated goforge.yml.\n", modulePath)
+		logger.Printf("✅ Successfully added '%s' and updated goforge.yml.\n", modulePath)
 		return nil
 	},
 }
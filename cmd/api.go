@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// apiCmd represents the command to generate handlers from an OpenAPI document.
+var apiCmd = &cobra.Command{
+	Use:   "api --from <openapi.yaml>",
+	Short: "Generate models, handler stubs and routes from an OpenAPI spec",
+	Long: `Generates the HTTP layer of an OpenAPI 3 document (YAML or JSON) into
+internal/adapters/http/api:
+
+  models.gen.go        Structs for the schemas, parameters and bodies
+  routes.gen.go        RegisterRoutes, binding and validating requests
+  <tag>_handler.go     A Handler method stub per operation
+
+The models and routes are regenerated on every run. Stubs are only added
+for operations missing from openapi-map.yml, the mapping file recording
+which handler implements each operation, so implemented handlers are
+never overwritten.
+
+Examples:
+  goforge g api --from openapi.yaml
+  goforge g api --from api/openapi.json --path internal/transport/api`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+
+		return scaffold.GenerateAPI(from, componentOptions(cmd))
+	},
+}
+
+func init() {
+	apiCmd.Flags().String("from", "openapi.yaml", "OpenAPI 3 document to generate from")
+}
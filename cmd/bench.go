@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/bench"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// benchCmd represents the command to run the project's benchmarks.
+var benchCmd = &cobra.Command{
+	Use:   "bench [packages...]",
+	Short: "Run the benchmarks and compare them with a baseline",
+	Long: `Runs go test -bench on the given packages, ./... by default, without
+the tests, and lists the results by package as they arrive: the time,
+memory, and allocations per operation (-benchmem is on unless --no-mem).
+
+--save stores the results as a baseline under ` + bench.BaselineDir + `, and
+--compare compares the run with it: benchstat, installed on first use,
+prints the statistics, and benchmarks slower than the baseline by more
+than --threshold percent are listed and fail the command. Use --count 5
+or more on both runs for numbers benchstat can trust.
+
+Examples:
+  goforge bench
+  goforge bench ./internal/parser --bench 'Parse'
+  goforge bench --count 6 --save
+  goforge bench --count 6 --compare
+  goforge bench --compare --baseline v1.2 --threshold 5`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+
+		options := bench.Options{Packages: args}
+		options.Bench, _ = cmd.Flags().GetString("bench")
+		options.Count, _ = cmd.Flags().GetInt("count")
+		options.Benchtime, _ = cmd.Flags().GetString("benchtime")
+		options.CPU, _ = cmd.Flags().GetString("cpu")
+		options.NoMem, _ = cmd.Flags().GetBool("no-mem")
+		save, _ := cmd.Flags().GetBool("save")
+		compare, _ := cmd.Flags().GetBool("compare")
+		baseline, _ := cmd.Flags().GetString("baseline")
+		threshold, _ := cmd.Flags().GetFloat64("threshold")
+
+		// Fail before the benchmarks run, not after
+		var baselineResults []bench.Result
+		if compare {
+			if baselineResults, err = bench.LoadBaseline(projectRoot, baseline); err != nil {
+				return err
+			}
+		}
+
+		pkg := ""
+		options.OnResult = func(r bench.Result) {
+			if r.Package != pkg {
+				pkg = r.Package
+				logger.Printf("\n📦 %s\n", pkg)
+			}
+			printBenchResult(r)
+		}
+
+		logger.Printf("⏱️  Running go %s\n", strings.Join(bench.Args(options), " "))
+		report, err := bench.Run(projectRoot, options)
+		if err != nil {
+			return err
+		}
+		if report.Failed {
+			logger.Println("\n❌ Benchmarks failed:")
+			printTestOutput(report.Output)
+			return fmt.Errorf("benchmarks failed")
+		}
+		if len(report.Results) == 0 {
+			logger.Warn("No benchmarks matched '%s'", options.Bench)
+			return nil
+		}
+
+		if compare {
+			if err := compareBenchmarks(projectRoot, baseline, baselineResults, report, threshold); err != nil {
+				return err
+			}
+		}
+		if save {
+			path, err := bench.SaveBaseline(projectRoot, baseline, report.Raw)
+			if err != nil {
+				return err
+			}
+			logger.Success("💾 Saved the results as baseline '%s' in %s", baseline, path)
+		}
+		return nil
+	},
+}
+
+// printBenchResult prints the line of a benchmark result.
+func printBenchResult(r bench.Result) {
+	name := r.Name
+	if r.Procs != 1 {
+		name += fmt.Sprintf("-%d", r.Procs)
+	}
+	var metrics []string
+	for _, unit := range r.Units {
+		metrics = append(metrics, fmt.Sprintf("%12s", formatMetric(r.Metrics[unit], unit)))
+	}
+	fmt.Printf("  %-32s %10d  %s\n", name, r.Iterations, strings.Join(metrics, "  "))
+}
+
+// formatMetric returns a value per operation in a readable unit, e.g.
+// 1.25µs/op or 4.0KiB/op.
+func formatMetric(value float64, unit string) string {
+	switch unit {
+	case "ns/op":
+		return formatNanoseconds(value) + "/op"
+	case "B/op":
+		units := []string{"B", "KiB", "MiB", "GiB"}
+		i := 0
+		for value >= 1024 && i < len(units)-1 {
+			value /= 1024
+			i++
+		}
+		if i == 0 {
+			return fmt.Sprintf("%.0f%s/op", value, units[i])
+		}
+		return fmt.Sprintf("%.1f%s/op", value, units[i])
+	case "allocs/op":
+		return fmt.Sprintf("%.0f allocs/op", value)
+	}
+	return fmt.Sprintf("%g %s", value, unit)
+}
+
+// formatNanoseconds returns a duration given in nanoseconds with the
+// largest fitting unit.
+func formatNanoseconds(ns float64) string {
+	switch {
+	case ns >= 1e9:
+		return fmt.Sprintf("%.2fs", ns/1e9)
+	case ns >= 1e6:
+		return fmt.Sprintf("%.2fms", ns/1e6)
+	case ns >= 1e3:
+		return fmt.Sprintf("%.2fµs", ns/1e3)
+	}
+	return fmt.Sprintf("%.2fns", ns)
+}
+
+// compareBenchmarks prints the comparison of a run with the baseline and
+// fails when a benchmark got slower than threshold percent.
+func compareBenchmarks(projectRoot, baseline string, baselineResults []bench.Result, report *bench.Report, threshold float64) error {
+	changes := bench.Compare(baselineResults, report.Results)
+	if len(changes) == 0 {
+		logger.Warn("No benchmark of this run is in baseline '%s'", baseline)
+		return nil
+	}
+
+	logger.Printf("\n📊 Compared with baseline '%s':\n\n", baseline)
+	stats, err := bench.BenchstatReport(projectRoot, baseline, report.Raw)
+	if err != nil {
+		// Fall back to the means, which is all the regression check needs
+		logger.Warn("No benchstat report: %v", err)
+		for _, c := range changes {
+			printBenchChange(c)
+		}
+	} else {
+		fmt.Println(stats)
+	}
+
+	regressions := bench.Regressions(changes, threshold)
+	if len(regressions) == 0 {
+		logger.Printf("✅ No benchmark is slower than baseline '%s' by more than %g%%.\n", baseline, threshold)
+		return nil
+	}
+	logger.Printf("\n🐢 Slower than baseline '%s' by more than %g%%:\n", baseline, threshold)
+	for _, c := range regressions {
+		printBenchChange(c)
+	}
+	return fmt.Errorf("%d benchmarks regressed against baseline '%s'", len(regressions), baseline)
+}
+
+// printBenchChange prints the mean time per operation of a benchmark
+// before and now.
+func printBenchChange(c bench.Change) {
+	fmt.Printf("  %-48s %10s → %-10s %+.1f%%\n", c.Key, formatNanoseconds(c.Old), formatNanoseconds(c.New), c.Delta)
+}
+
+func init() {
+	benchCmd.Flags().String("bench", ".", "Regular expression selecting the benchmarks, as go test -bench")
+	benchCmd.Flags().Int("count", 0, "Run each benchmark this many times")
+	benchCmd.Flags().String("benchtime", "", "Time or iterations per benchmark, e.g. 2s or 1000x")
+	benchCmd.Flags().String("cpu", "", "GOMAXPROCS values to run with, e.g. 1,4")
+	benchCmd.Flags().Bool("no-mem", false, "Leave out the memory statistics of -benchmem")
+	benchCmd.Flags().Bool("save", false, "Store the results as the baseline")
+	benchCmd.Flags().Bool("compare", false, "Compare the results with the baseline and fail on regressions")
+	benchCmd.Flags().String("baseline", bench.DefaultBaseline, "Name of the baseline to save or compare with")
+	benchCmd.Flags().Float64("threshold", 10, "Percent a benchmark may get slower before --compare fails")
+}
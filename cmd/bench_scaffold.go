@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// benchScaffoldCmd is a maintainer tool comparing the scaffolder's
+// sequential and parallel file generation across template sizes.
+var benchScaffoldCmd = &cobra.Command{
+	Use:    "bench-scaffold",
+	Short:  "Benchmark sequential vs parallel template generation",
+	Hidden: true,
+	Long: `Renders synthetic templates of several sizes into temporary directories,
+sequentially and with the parallel worker pool, and reports the median
+time of each. Use it to check the file-count threshold in generateFiles
+and to measure the effect of performance changes.
+
+Examples:
+  goforge bench-scaffold
+  goforge bench-scaffold --sizes 5,10,20,50 --iterations 20`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sizes, _ := cmd.Flags().GetIntSlice("sizes")
+		iterations, _ := cmd.Flags().GetInt("iterations")
+
+		logger.Info("⏱️  Benchmarking generation (%d iterations per size)...", iterations)
+		results, err := scaffold.BenchmarkGeneration(sizes, iterations)
+		if err != nil {
+			return fmt.Errorf("benchmark failed: %w", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(w, "FILES\tSEQUENTIAL\tPARALLEL\tSPEEDUP\t")
+		for _, r := range results {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%.2fx\t\n", r.Files, r.Sequential.Round(time.Microsecond), r.Parallel.Round(time.Microsecond), r.Speedup())
+		}
+		return w.Flush()
+	},
+}
+
+func init() {
+	benchScaffoldCmd.Flags().IntSlice("sizes", []int{5, 10, 25, 100, 500}, "Template sizes (number of files) to benchmark")
+	benchScaffoldCmd.Flags().Int("iterations", 10, "Runs per size; the median is reported")
+}
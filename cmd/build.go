@@ -1,11 +1,18 @@
 package cmd
 
 import (
+	"archive/zip"
 	"fmt"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/globs"
+	"github.com/night-slayer18/goforge/internal/logger"
 	"github.com/night-slayer18/goforge/internal/project"
 	"github.com/night-slayer18/goforge/internal/runner"
 	"github.com/spf13/cobra"
@@ -16,69 +23,320 @@ var buildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Build the Go application binary",
 	Long: `Compiles the Go application into an executable binary and copies any assets
-specified in the 'build.assets' section of goforge.yml to the output directory.`,
+specified in the 'build.assets' section of goforge.yml to the output directory.
+
+Other settings in the build section of goforge.yml:
+
+  main         package to compile (./cmd/server)
+  output_dir   output directory (dist)
+  binary_name  name of the binary (the project name)
+  ldflags      linker flags; {version}, {commit} and {date} are replaced with
+               the git tag, the short commit hash and the build time
+  tags         build tags
+  env          environment for go build, e.g. GOOS: linux
+  archive      zip to also pack the binary and assets into
+               <output_dir>/<project_name>.zip
+
+In a go.work workspace, --service builds one service from anywhere in the
+workspace and --all-services builds each of them with its own settings.
+
+With --output json the binary, the copied assets, the archive, and the
+time taken are printed as JSON, for each service with --all-services.
+
+Examples:
+  goforge build
+  goforge build --service api
+  goforge build --all-services`,
+	Annotations: map[string]string{jsonAnnotation: "true"},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		cfg, projectRoot, err := project.LoadConfig()
-		if err!= nil {
+		if all, _ := cmd.Flags().GetBool("all-services"); all {
+			if cmd.Flags().Changed("service") {
+				return fmt.Errorf("--service and --all-services cannot be used together")
+			}
+			results, err := buildAllServices()
+			reportJSON(cmd, map[string][]*buildResult{"services": results})
 			return err
 		}
 
-		outputDir := filepath.Join(projectRoot, "dist")
-		binaryName := cfg.ProjectName
-		if binaryName == "" {
-			binaryName = filepath.Base(projectRoot)
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		result, err := buildProject(cfg, projectRoot)
+		if result != nil {
+			reportJSON(cmd, result)
 		}
-		outputPath := filepath.Join(outputDir, binaryName)
+		return err
+	},
+}
 
-		fmt.Printf("🏗️  Building project '%s'...\n", cfg.ProjectName)
+// buildResult is what 'goforge build --output json' reports of a build.
+type buildResult struct {
+	Project  string   `json:"project"`
+	Binary   string   `json:"binary"`
+	Assets   []string `json:"assets,omitempty"`
+	Archive  string   `json:"archive,omitempty"`
+	Duration int64    `json:"duration_ms"`
+	Error    string   `json:"error,omitempty"`
+}
 
-		// Ensure output directory exists.
-		if err := os.MkdirAll(outputDir, os.ModePerm); err!= nil {
-			return fmt.Errorf("failed to create output directory: %w", err)
+// buildAllServices builds every service of the workspace the current
+// directory belongs to, going on after failures and reporting them at the
+// end.
+func buildAllServices() ([]*buildResult, error) {
+	ws, err := project.FindWorkspace(".")
+	if err != nil {
+		return nil, err
+	}
+	if ws == nil {
+		return nil, fmt.Errorf("--all-services needs a go.work workspace, but there is none in this directory or any parent")
+	}
+	services, err := ws.Services()
+	if err != nil {
+		return nil, err
+	}
+	if len(services) == 0 {
+		return nil, fmt.Errorf("the workspace at %s has no services with a goforge.yml", ws.Root)
+	}
+
+	var results []*buildResult
+	var failed []string
+	for i, service := range services {
+		if i > 0 {
+			fmt.Println()
 		}
+		logger.Printf("📦 Service %s (%s)\n", service.Name, service.Dir)
+		serviceRoot := filepath.Join(ws.Root, filepath.FromSlash(service.Dir))
+		result, err := buildProject(service.Config, serviceRoot)
+		if err != nil {
+			logger.Error("Building %s failed: %v", service.Name, err)
+			failed = append(failed, service.Name)
+		}
+		if result != nil {
+			results = append(results, result)
+		}
+	}
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%d of %d services failed to build: %s", len(failed), len(services), strings.Join(failed, ", "))
+	}
+	logger.Printf("\n✨ Built %d services.\n", len(services))
+	return results, nil
+}
 
-		// Build the binary.
-		err = runner.ExecuteCommand(projectRoot, "go", "build", "-o", outputPath, "./cmd/server")
-		if err!= nil {
-			return fmt.Errorf("go build failed: %w", err)
+// buildProject builds the binary of the project at projectRoot, as
+// configured by the build section of its goforge.yml, and returns what
+// it built. Once the build started, the result is returned with an error
+// too, holding it.
+func buildProject(cfg *project.Config, projectRoot string) (*buildResult, error) {
+	build := cfg.Build
+	if build == nil {
+		build = &project.BuildConfig{}
+	}
+	if build.Archive != "" && build.Archive != "zip" {
+		return nil, fmt.Errorf("unsupported build.archive '%s' (use zip)", build.Archive)
+	}
+	start := time.Now()
+
+	outputDir := filepath.Join(projectRoot, "dist")
+	if build.OutputDir != "" {
+		outputDir = filepath.Join(projectRoot, build.OutputDir)
+	}
+	projectName := cfg.ProjectName
+	if projectName == "" {
+		projectName = filepath.Base(projectRoot)
+	}
+	binaryName := buildBinaryName(cfg, projectRoot)
+	outputPath := filepath.Join(outputDir, binaryName)
+	result := &buildResult{Project: projectName, Binary: outputPath}
+	fail := func(err error) (*buildResult, error) {
+		result.Duration = time.Since(start).Milliseconds()
+		result.Error = err.Error()
+		return result, err
+	}
+
+	logger.Printf("🏗️  Building project '%s'...\n", cfg.ProjectName)
+
+	// Ensure output directory exists.
+	if err := os.MkdirAll(outputDir, os.ModePerm); err!= nil {
+		return fail(fmt.Errorf("failed to create output directory: %w", err))
+	}
+
+	// Build the binary.
+	mainPackage := "./cmd/server"
+	if build.Main != "" {
+		mainPackage = build.Main
+	}
+	buildArgs := []string{"build", "-o", outputPath}
+	if len(build.Tags) > 0 {
+		buildArgs = append(buildArgs, "-tags", strings.Join(build.Tags, ","))
+	}
+	if build.LDFlags != "" {
+		buildArgs = append(buildArgs, "-ldflags", expandBuildInfo(projectRoot, build.LDFlags))
+	}
+	opts := runner.DefaultOptions()
+	opts.Dir = projectRoot
+	for key, value := range build.Env {
+		opts.Env = append(opts.Env, key+"="+value)
+	}
+	err := runner.ExecuteCommandWithOptions("go", append(buildArgs, mainPackage), opts)
+	if err!= nil {
+		return fail(exitcode.Errorf(exitcode.Build, "go build failed: %w", err))
+	}
+	logger.Printf("✅ Binary created at: %s\n", outputPath)
+
+	// Handle assets defined in goforge.yml.
+	var assets []string
+	if len(build.Assets) > 0 {
+		logger.Println("📦 Copying assets...")
+		assets = copyAssets(projectRoot, outputDir, build.Assets)
+		result.Assets = assets
+	}
+
+	if build.Archive == "zip" {
+		archivePath := filepath.Join(outputDir, projectName+".zip")
+		if err := writeZip(archivePath, outputDir, append([]string{binaryName}, assets...)); err != nil {
+			return fail(fmt.Errorf("failed to create %s: %w", archivePath, err))
 		}
-		fmt.Printf("✅ Binary created at: %s\n", outputPath)
+		logger.Printf("🗜️  Archive created at: %s\n", archivePath)
+		result.Archive = archivePath
+	}
 
-		// Handle assets defined in goforge.yml.
-		if cfg.Build != nil && len(cfg.Build.Assets) > 0 {
-			fmt.Println("📦 Copying assets...")
-			for _, assetPath := range cfg.Build.Assets {
-				sourcePath := filepath.Join(projectRoot, assetPath)
+	logger.Println("\n✨ Build complete.")
+	result.Duration = time.Since(start).Milliseconds()
+	return result, nil
+}
 
-				info, err := os.Stat(sourcePath)
-				if os.IsNotExist(err) {
-					fmt.Printf("  - Asset not found, skipping: %s\n", assetPath)
-					continue
-				}
-				if err != nil {
-					fmt.Printf("  - Error accessing asset %s: %v\n", assetPath, err)
-					continue
-				}
+// buildBinaryName returns the name of the binary 'goforge build' writes:
+// build.binary_name, else the project name.
+func buildBinaryName(cfg *project.Config, projectRoot string) string {
+	if cfg.Build != nil && cfg.Build.BinaryName != "" {
+		return cfg.Build.BinaryName
+	}
+	if cfg.ProjectName != "" {
+		return cfg.ProjectName
+	}
+	return filepath.Base(projectRoot)
+}
 
-				destPath := filepath.Join(outputDir, assetPath)
+func init() {
+	serviceFlag(buildCmd.Flags())
+	buildCmd.Flags().Bool("all-services", false, "Build every service of the go.work workspace")
+}
 
-				if info.IsDir() {
-					err = copyDir(sourcePath, destPath)
-				} else {
-					err = copyFile(sourcePath, destPath)
-				}
+// expandBuildInfo replaces the {version}, {commit} and {date} placeholders
+// in ldflags. Outside a git repository the version is "dev" and the commit
+// "none".
+func expandBuildInfo(projectRoot, ldflags string) string {
+	git := func(fallback string, args ...string) string {
+		out, err := exec.Command("git", append([]string{"-C", projectRoot}, args...)...).Output()
+		if err != nil || strings.TrimSpace(string(out)) == "" {
+			return fallback
+		}
+		return strings.TrimSpace(string(out))
+	}
 
-				if err != nil {
-					fmt.Printf("  - Failed to copy asset %s: %v\n", assetPath, err)
-				} else {
-					fmt.Printf("  - Copied: %s\n", assetPath)
-				}
+	replacements := map[string]func() string{
+		"{version}": func() string { return git("dev", "describe", "--tags", "--always", "--dirty") },
+		"{commit}":  func() string { return git("none", "rev-parse", "--short", "HEAD") },
+		"{date}":    func() string { return time.Now().UTC().Format(time.RFC3339) },
+	}
+	for placeholder, value := range replacements {
+		if strings.Contains(ldflags, placeholder) {
+			ldflags = strings.ReplaceAll(ldflags, placeholder, value())
+		}
+	}
+	return ldflags
+}
+
+// copyAssets copies the files selected by the build.assets patterns into
+// outputDir, keeping their project-relative paths, and returns those paths.
+// Plain directory entries include everything below them; "!pattern" entries
+// exclude files.
+func copyAssets(projectRoot, outputDir string, assets []string) []string {
+	patterns := make([]string, 0, len(assets))
+	for _, asset := range assets {
+		if !globs.HasMeta(asset) {
+			info, err := os.Stat(filepath.Join(projectRoot, asset))
+			if os.IsNotExist(err) {
+				fmt.Printf("  - Asset not found, skipping: %s\n", asset)
+				continue
+			}
+			if err != nil {
+				fmt.Printf("  - Error accessing asset %s: %v\n", asset, err)
+				continue
+			}
+			if info.IsDir() {
+				asset = strings.TrimSuffix(asset, "/") + "/**"
 			}
 		}
+		patterns = append(patterns, asset)
+	}
 
-		fmt.Println("\n✨ Build complete.")
+	files, err := globs.Glob(projectRoot, patterns...)
+	if err != nil {
+		fmt.Printf("  - Failed to resolve assets: %v\n", err)
 		return nil
-	},
+	}
+
+	var copied []string
+	for _, src := range files {
+		info, err := os.Stat(src)
+		if err != nil || info.IsDir() {
+			continue
+		}
+
+		relPath, _ := filepath.Rel(projectRoot, src)
+		if err := copyFile(src, filepath.Join(outputDir, relPath)); err != nil {
+			fmt.Printf("  - Failed to copy asset %s: %v\n", relPath, err)
+		} else {
+			fmt.Printf("  - Copied: %s\n", relPath)
+			copied = append(copied, relPath)
+		}
+	}
+	return copied
+}
+
+// writeZip packs the files, given relative to dir, into a zip archive,
+// keeping their permissions so binaries stay executable.
+func writeZip(archivePath, dir string, files []string) error {
+	out, err := os.Create(archivePath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	archive := zip.NewWriter(out)
+	for _, file := range files {
+		src := filepath.Join(dir, file)
+		info, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(file)
+		header.Method = zip.Deflate
+
+		w, err := archive.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		in, err := os.Open(src)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(w, in)
+		in.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return err
+	}
+	return out.Close()
 }
 
 func copyFile(src, dst string) error {
@@ -110,23 +368,3 @@ func copyFile(src, dst string) error {
 	}
 	return os.Chmod(dst, sourceInfo.Mode())
 }
-
-func copyDir(src, dst string) error {
-	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-
-		relPath, err := filepath.Rel(src, path)
-		if err != nil {
-			return err
-		}
-		destPath := filepath.Join(dst, relPath)
-
-		if info.IsDir() {
-			return os.MkdirAll(destPath, info.Mode())
-		}
-
-		return copyFile(path, destPath)
-	})
-}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// defaultCheckSteps run when check.steps in goforge.yml is empty.
+var defaultCheckSteps = []string{"vet", "lint", "test", "build", "audit"}
+
+// govulncheck reports the known vulnerabilities the code reaches, for the
+// audit step.
+var govulncheck = runner.Tool{Name: "govulncheck", Package: "golang.org/x/vuln/cmd/govulncheck", Version: "v1.1.4"}
+
+// builtinCheckSteps are the commands of the steps without a script of the
+// same name; {goforge} stands for the running goforge binary.
+var builtinCheckSteps = map[string]string{
+	"vet":   "go vet ./...",
+	"fmt":   "{goforge} fmt --check",
+	"lint":  "{goforge} lint",
+	"test":  "{goforge} test",
+	"build": "{goforge} build",
+	"audit": "{govulncheck} ./...",
+}
+
+// checkStep is a step of 'goforge check' and its outcome.
+type checkStep struct {
+	name    string
+	command string
+	status  string // passed, failed, or skipped
+	elapsed time.Duration
+}
+
+// checkCmd represents the command to run the project's quality gate.
+var checkCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Run vet, lint, test, build, and audit as one quality gate",
+	Long: `Runs the project's checks in sequence and ends with a table of their
+results, failing when any step failed: the one command to run in CI.
+
+The steps are those of check.steps in goforge.yml, by default:
+
+  vet     go vet ./...
+  lint    goforge lint
+  test    goforge test
+  build   goforge build
+  audit   govulncheck ./..., installed on first use
+
+'fmt' (goforge fmt --check) is available as a step too. A script of
+goforge.yml with the name of a step replaces its command, and any other
+script can be a step as well:
+
+  check:
+    steps: [fmt, vet, lint, test:race, build, audit]
+
+Examples:
+  goforge check
+  goforge check --fail-fast
+  goforge check --skip audit`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		skip, _ := cmd.Flags().GetStringSlice("skip")
+
+		steps, err := checkSteps(cfg, skip)
+		if err != nil {
+			return err
+		}
+
+		failed := 0
+		for i, step := range steps {
+			if failFast && failed > 0 {
+				step.status = "skipped"
+				continue
+			}
+			fmt.Printf("\n▶️  [%d/%d] %s: %s\n", i+1, len(steps), step.name, displayCommand(step.command))
+
+			start := time.Now()
+			err := runCheckStep(projectRoot, step)
+			step.elapsed = time.Since(start)
+			step.status = "passed"
+			if err != nil {
+				logger.Error("❌ %s failed: %v", step.name, err)
+				step.status = "failed"
+				failed++
+			}
+		}
+
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 3, ' ', 0)
+		fmt.Fprintln(w, "STEP\tRESULT\tTIME\tCOMMAND")
+		for _, step := range steps {
+			elapsed := "-"
+			if step.status != "skipped" {
+				elapsed = formatElapsed(step.elapsed)
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", step.name, step.status, elapsed, displayCommand(step.command))
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if failed > 0 {
+			return fmt.Errorf("%d of %d checks failed", failed, len(steps))
+		}
+		logger.Println("\n✅ All checks passed.")
+		return nil
+	},
+}
+
+// checkSteps returns the steps to run: check.steps from goforge.yml, or
+// defaultCheckSteps, without those in skip. Unknown steps are an error.
+func checkSteps(cfg *project.Config, skip []string) ([]*checkStep, error) {
+	names := defaultCheckSteps
+	if cfg.Check != nil && len(cfg.Check.Steps) > 0 {
+		names = cfg.Check.Steps
+	}
+	for _, name := range skip {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("cannot skip '%s': it is not a check step (steps: %s)", name, strings.Join(names, ", "))
+		}
+	}
+
+	var steps []*checkStep
+	for _, name := range names {
+		if slices.Contains(skip, name) {
+			continue
+		}
+		command, ok := cfg.Scripts[name]
+		if !ok {
+			command, ok = builtinCheckSteps[name]
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown check step '%s' in check.steps: use %s, or the name of a script", name, strings.Join(slices.Sorted(maps.Keys(builtinCheckSteps)), ", "))
+		}
+		steps = append(steps, &checkStep{name: name, command: command})
+	}
+	if len(steps) == 0 {
+		return nil, fmt.Errorf("no check steps left to run")
+	}
+	return steps, nil
+}
+
+// runCheckStep runs the command of a step in the project root.
+func runCheckStep(projectRoot string, step *checkStep) error {
+	command := step.command
+	if strings.Contains(command, "{goforge}") {
+		executable, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		command = strings.ReplaceAll(command, "{goforge}", shellQuote(executable))
+	}
+	if strings.Contains(command, "{govulncheck}") {
+		toolPath, err := runner.EnsureTool(govulncheck)
+		if err != nil {
+			return err
+		}
+		command = strings.ReplaceAll(command, "{govulncheck}", shellQuote(toolPath))
+	}
+
+	opts := runner.DefaultOptions()
+	opts.Timeout = 0
+	opts.ShowCommand = false
+	return runner.ExecuteScriptWithOptions(projectRoot, command, opts)
+}
+
+// displayCommand returns a step's command as shown to the user.
+func displayCommand(command string) string {
+	command = strings.ReplaceAll(command, "{goforge}", "goforge")
+	return strings.ReplaceAll(command, "{govulncheck}", "govulncheck")
+}
+
+func init() {
+	checkCmd.Flags().Bool("fail-fast", false, "Stop at the first failing step")
+	checkCmd.Flags().StringSlice("skip", nil, "Steps to leave out, e.g. --skip audit,build")
+}
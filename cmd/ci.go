@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// ciCmd represents the command to generate a CI pipeline.
+var ciCmd = &cobra.Command{
+	Use:   "ci <github|gitlab|circleci>",
+	Short: "Generate a CI pipeline that builds, tests, and lints the project",
+	Long: `Generates the pipeline of a CI system:
+
+  github      .github/workflows/ci.yml (GitHub Actions)
+  gitlab      .gitlab-ci.yml
+  circleci    .circleci/config.yml
+
+It installs goforge and runs 'goforge check', so it follows check.steps
+and the scripts in goforge.yml. Jobs run on the project's Go version and the latest release,
+with the module and build caches kept between runs.
+
+'goforge new --ci <system>' writes the same pipeline into new projects.
+
+Examples:
+  goforge g ci github
+  goforge g ci gitlab --force`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateCI(args[0], componentOptions(cmd))
+	},
+}
@@ -1,12 +1,11 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"path/filepath"
 
+	"github.com/night-slayer18/goforge/internal/globs"
 	"github.com/night-slayer18/goforge/internal/logger"
-	"github.com/night-slayer18/goforge/internal/project"
 	"github.com/night-slayer18/goforge/internal/runner"
 	"github.com/spf13/cobra"
 )
@@ -22,12 +21,9 @@ This includes:
   • test cache
   • go module cache (with --all flag)`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		logger.SetVerbose(verbose)
-
-		_, projectRoot, err := project.LoadConfig()
+		_, projectRoot, err := requireProject(cmd)
 		if err != nil {
-			return fmt.Errorf("command must be run from a goforge project: %w", err)
+			return err
 		}
 
 		all, _ := cmd.Flags().GetBool("all")
@@ -50,7 +46,7 @@ func cleanProject(projectRoot string, all, dryRun bool) error {
 	var removed []string
 
 	for _, pattern := range filesToRemove {
-		matches, err := filepath.Glob(filepath.Join(projectRoot, pattern))
+		matches, err := globs.Glob(projectRoot, pattern)
 		if err != nil {
 			logger.Debug("Error globbing %s: %v", pattern, err)
 			continue
@@ -107,5 +103,4 @@ func cleanProject(projectRoot string, all, dryRun bool) error {
 func init() {
 	cleanCmd.Flags().BoolP("all", "a", false, "Also clean Go module cache")
 	cleanCmd.Flags().BoolP("dry-run", "n", false, "Show what would be removed without actually removing")
-	cleanCmd.Flags().BoolP("verbose", "v", false, "Enable verbose logging")
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// commandCmd represents the command to generate a CLI subcommand.
+var commandCmd = &cobra.Command{
+	Use:   "command <name>",
+	Short: "Generate a cobra subcommand for the project's CLI",
+	Long: `Generates a cobra subcommand next to the project's root command and
+registers it by adding rootCmd.AddCommand(new<Name>Cmd()) to the init
+function of the file declaring the root command.
+
+The root command is the package-level rootCmd (or the only package-level
+*cobra.Command) in internal/cli or cmd/cli. A project without one gets
+internal/cli/root.go and cmd/cli/main.go.
+
+Examples:
+  goforge g command import-users
+  goforge g command migrate-data`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateCommand(args[0], componentOptions(cmd))
+	},
+}
@@ -0,0 +1,481 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/updatecheck"
+	"github.com/night-slayer18/goforge/internal/userconfig"
+	"github.com/night-slayer18/goforge/internal/utils"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"golang.org/x/mod/semver"
+)
+
+// runFunc is the signature of a cobra RunE.
+type runFunc func(cmd *cobra.Command, args []string) error
+
+// commandMiddleware wraps a command's run function with a concern shared by
+// every command, so each RunE only holds the command's own logic.
+type commandMiddleware func(next runFunc) runFunc
+
+// commandMiddlewares are applied to every command, outermost first.
+var commandMiddlewares = []commandMiddleware{
+	recoverPanics,
+	changeDirectory,
+	emitJSON,
+	applyOutputFlags,
+	applyUserConfig,
+	notifyUpdate,
+	selectService,
+	timeCommand,
+	loadProject,
+	checkPolicy,
+	checkStrict,
+	checkWorkspace,
+}
+
+// wrappedAnnotation marks commands whose run function is already wrapped.
+const wrappedAnnotation = "goforge:wrapped"
+
+// wrapCommands applies commandMiddlewares to cmd and its subcommands.
+// Commands without a run function, such as 'templates', are left alone.
+func wrapCommands(cmd *cobra.Command) {
+	if cmd.Run != nil && cmd.RunE == nil {
+		run := cmd.Run
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			run(cmd, args)
+			return nil
+		}
+		cmd.Run = nil
+	}
+
+	if cmd.RunE == nil && cmd.HasParent() && cmd.HasSubCommands() {
+		// A mistyped subcommand fails with a suggestion instead of
+		// printing the help of the command; cobra only does that for the
+		// root command
+		cmd.RunE = func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmd.Help()
+			}
+			return unknownSubcommand(cmd, args[0])
+		}
+	}
+
+	if cmd.RunE != nil && cmd.Annotations[wrappedAnnotation] == "" {
+		if validArgs := cmd.Args; validArgs != nil {
+			// Wrong arguments exit with the status of invalid input
+			cmd.Args = func(cmd *cobra.Command, args []string) error {
+				return exitcode.Wrap(exitcode.Validation, validArgs(cmd, args))
+			}
+		}
+		run := runFunc(cmd.RunE)
+		if preRun := cmd.PreRunE; preRun != nil {
+			// Run the pre-run hook inside the middleware too
+			main := run
+			run = func(cmd *cobra.Command, args []string) error {
+				if err := preRun(cmd, args); err != nil {
+					return err
+				}
+				return main(cmd, args)
+			}
+			cmd.PreRunE = nil
+		}
+		for i := len(commandMiddlewares) - 1; i >= 0; i-- {
+			run = commandMiddlewares[i](run)
+		}
+		cmd.RunE = run
+		if cmd.Annotations == nil {
+			cmd.Annotations = make(map[string]string)
+		}
+		cmd.Annotations[wrappedAnnotation] = "true"
+	}
+
+	for _, sub := range cmd.Commands() {
+		wrapCommands(sub)
+	}
+}
+
+// unknownSubcommand is the error for name, which is not a subcommand of
+// cmd, suggesting the subcommand a typo most likely meant.
+func unknownSubcommand(cmd *cobra.Command, name string) error {
+	var names []string
+	for _, sub := range cmd.Commands() {
+		if sub.IsAvailableCommand() {
+			names = append(names, sub.Name())
+		}
+	}
+	if suggestion := utils.Closest(name, names); suggestion != "" {
+		return exitcode.Errorf(exitcode.Validation, "unknown command '%s' for '%s', did you mean '%s'?", name, cmd.CommandPath(), suggestion)
+	}
+	return exitcode.Errorf(exitcode.Validation, "unknown command '%s' for '%s'\n\nRun '%s --help' for its commands", name, cmd.CommandPath(), cmd.CommandPath())
+}
+
+// recoverPanics turns a panic into an error instead of a crash, logging the
+// stack trace in verbose mode. Flags and arguments were valid by the time
+// it runs, so errors from here on no longer print the usage text.
+func recoverPanics(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				logger.Debug("%s", debug.Stack())
+				err = fmt.Errorf("'%s' crashed: %v\n\nRun it again with --verbose for the stack trace, and attach 'goforge support-bundle' when reporting it", cmd.CommandPath(), recovered)
+			}
+		}()
+		return next(cmd, args)
+	}
+}
+
+// changeDirectory runs the command as if goforge was started in the
+// directory given with -C.
+func changeDirectory(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		if dir, _ := cmd.Flags().GetString("chdir"); dir != "" {
+			if err := os.Chdir(dir); err != nil {
+				return fmt.Errorf("cannot change to directory %s: %w", dir, err)
+			}
+		}
+		return next(cmd, args)
+	}
+}
+
+// applyOutputFlags sets the log level from --log-level, --verbose, or
+// --quiet, the log format from --log-format, and turns colors off for --no-color, --plain,
+// and the NO_COLOR variable, and emoji off for --plain. Commands given
+// --output json or --json log only errors unless a level is given, so
+// their output stays machine-readable. Commands read the level back with
+// logger.Verbose rather than their own flags.
+func applyOutputFlags(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		quiet, _ := cmd.Flags().GetBool("quiet")
+		asJSON := wantsJSON(cmd)
+		if verbose && quiet {
+			return exitcode.Errorf(exitcode.Validation, "--verbose and --quiet cannot be used together")
+		}
+		levelFlag := cmd.Flags().Lookup("log-level")
+		if levelFlag != nil && levelFlag.Changed && (verbose || quiet) {
+			return exitcode.Errorf(exitcode.Validation, "--log-level cannot be used with --verbose or --quiet")
+		}
+		name, _ := cmd.Flags().GetString("log-format")
+		format, err := logger.ParseFormat(name)
+		if err != nil {
+			return exitcode.Wrap(exitcode.Validation, err)
+		}
+		logger.SetFormat(format)
+
+		noColor, _ := cmd.Flags().GetBool("no-color")
+		plain, _ := cmd.Flags().GetBool("plain")
+		if noColor || plain || os.Getenv("NO_COLOR") != "" {
+			color.NoColor = true
+		}
+		if plain {
+			logger.SetEmoji(false)
+		}
+
+		switch {
+		case verbose:
+			logger.SetLevel(logger.DEBUG)
+		case levelFlag != nil && levelFlag.Changed:
+			level, err := logger.ParseLevel(levelFlag.Value.String())
+			if err != nil {
+				return exitcode.Wrap(exitcode.Validation, err)
+			}
+			logger.SetLevel(level)
+		case asJSON:
+			logger.SetLevel(logger.ERROR)
+		case quiet:
+			logger.SetLevel(logger.WARN)
+		default:
+			logger.SetLevel(logger.INFO)
+		}
+		return next(cmd, args)
+	}
+}
+
+// applyUserConfig applies the user's defaults from the user config: plain
+// output without colors or emoji, and the conflict mode of the generators
+// unless --on-conflict is given. The config only turns colors and emoji
+// off, so it cannot undo --no-color or --plain. A broken user config is
+// reported, not fatal.
+func applyUserConfig(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		cfg, err := userconfig.Load()
+		if err != nil {
+			logger.Warn("⚠️  Ignoring the user config: %v", err)
+			return next(cmd, args)
+		}
+
+		if cfg.Color != nil && !*cfg.Color {
+			color.NoColor = true
+		}
+		if cfg.Emoji != nil && !*cfg.Emoji {
+			logger.SetEmoji(false)
+		}
+		if flag := cmd.Flags().Lookup("on-conflict"); flag != nil && !flag.Changed && cfg.OnConflict != "" {
+			if err := flag.Value.Set(cfg.OnConflict); err != nil {
+				return err
+			}
+		}
+		return next(cmd, args)
+	}
+}
+
+// updateLookupWait bounds how long a finished command waits for the
+// release lookup running alongside it.
+const updateLookupWait = 2 * time.Second
+
+// notifyUpdate prints a one-line hint after the command when a newer
+// goforge release exists. Releases are looked up at most once a day,
+// alongside the command. The hint goes to stderr and is left out for
+// --json and --quiet, off a terminal, and when the user config sets
+// update_check to false or GOFORGE_NO_UPDATE_CHECK is set.
+func notifyUpdate(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		if !wantsUpdateHint(cmd) {
+			return next(cmd, args)
+		}
+
+		latest, fresh := updatecheck.Cached()
+		var lookup chan string
+		if !fresh {
+			lookup = make(chan string, 1)
+			go func() {
+				latest, err := updatecheck.Lookup()
+				if err != nil {
+					logger.Debug("Looking up the latest goforge release failed: %v", err)
+				}
+				lookup <- latest
+			}()
+		}
+
+		err := next(cmd, args)
+		if lookup != nil {
+			select {
+			case latest = <-lookup:
+			case <-time.After(updateLookupWait):
+				return err
+			}
+		}
+		if updatecheck.Newer(version, latest) {
+			fmt.Fprint(os.Stderr, logger.Plain(fmt.Sprintf("\n💡 goforge %s is available (you have %s); upgrade with: go install %s@latest\n",
+				latest, version, updatecheck.Module)))
+		}
+		return err
+	}
+}
+
+// wantsUpdateHint reports whether the update hint may be shown for cmd.
+func wantsUpdateHint(cmd *cobra.Command) bool {
+	if !semver.IsValid("v"+strings.TrimPrefix(version, "v")) || updatecheck.Disabled() {
+		return false
+	}
+	if quiet, _ := cmd.Flags().GetBool("quiet"); quiet {
+		return false
+	}
+	if wantsJSON(cmd) {
+		return false
+	}
+	if offline, _ := cmd.Flags().GetBool("offline"); offline {
+		return false
+	}
+	if info, err := os.Stderr.Stat(); err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	cfg, err := userconfig.Load()
+	return err != nil || cfg.UpdateCheck == nil || *cfg.UpdateCheck
+}
+
+// serviceFlag adds --service (-w), selecting the workspace service the
+// command runs in, to cmd; persistent flags reach its subcommands too.
+func serviceFlag(flags *pflag.FlagSet) {
+	flags.StringP("service", "w", "",
+		"Run in this service of the go.work workspace (its name or directory)")
+}
+
+// selectService runs the command in the workspace service given with
+// --service, as if goforge was started in its directory.
+func selectService(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("service")
+		if name == "" {
+			return next(cmd, args)
+		}
+
+		ws, err := project.FindWorkspace(".")
+		if err != nil {
+			return err
+		}
+		if ws == nil {
+			return fmt.Errorf("--service needs a go.work workspace, but there is none in this directory or any parent")
+		}
+		service, err := ws.FindService(name)
+		if err != nil {
+			return err
+		}
+		dir := filepath.Join(ws.Root, filepath.FromSlash(service.Dir))
+		if err := os.Chdir(dir); err != nil {
+			return fmt.Errorf("cannot change to service %s: %w", service.Dir, err)
+		}
+		logger.Debug("Running in service '%s' at %s", service.Name, dir)
+		return next(cmd, args)
+	}
+}
+
+// timeCommand reports how long the command took in verbose mode.
+func timeCommand(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		start := time.Now()
+		err := next(cmd, args)
+		logger.Debug("⏱️  %s finished in %s", cmd.CommandPath(), time.Since(start).Round(time.Millisecond))
+		return err
+	}
+}
+
+// projectKey is the context key of the loaded project.
+type projectKey struct{}
+
+// loadedProject is goforge.yml as read before the command ran.
+type loadedProject struct {
+	cfg  *project.Config
+	root string
+	err  error
+}
+
+// loadProject reads goforge.yml once, before the command runs, so the
+// command and the policy check share it. Outside a project the error is
+// kept for commands that need one.
+func loadProject(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		cfg, root, err := project.LoadConfig()
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		cmd.SetContext(context.WithValue(ctx, projectKey{}, &loadedProject{cfg: cfg, root: root, err: err}))
+		return next(cmd, args)
+	}
+}
+
+// requireProject returns the project the command runs in, or an error
+// explaining that the command must be run inside one.
+func requireProject(cmd *cobra.Command) (*project.Config, string, error) {
+	loaded, ok := cmd.Context().Value(projectKey{}).(*loadedProject)
+	if !ok {
+		cfg, root, err := project.LoadConfig()
+		loaded = &loadedProject{cfg: cfg, root: root, err: err}
+	}
+	if loaded.err != nil {
+		return nil, "", exitcode.Errorf(exitcode.Config, "command must be run from the root of a goforge project: %w", loaded.err)
+	}
+	return loaded.cfg, loaded.root, nil
+}
+
+// checkPolicy enforces the policy section of goforge.yml: the oldest
+// goforge release allowed in the project and the commands it denies.
+func checkPolicy(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		loaded, _ := cmd.Context().Value(projectKey{}).(*loadedProject)
+		if loaded == nil || loaded.err != nil || loaded.cfg.Policy == nil {
+			return next(cmd, args)
+		}
+		policy := loaded.cfg.Policy
+
+		if policy.MinVersion != "" && semver.IsValid("v"+strings.TrimPrefix(version, "v")) {
+			required := "v" + strings.TrimPrefix(policy.MinVersion, "v")
+			if semver.Compare("v"+strings.TrimPrefix(version, "v"), required) < 0 {
+				return exitcode.Errorf(exitcode.Config, "this project requires goforge %s or newer (policy.min_version in goforge.yml); you have %s", required, version)
+			}
+		}
+
+		path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+		for _, denied := range policy.Deny {
+			denied = strings.Join(strings.Fields(denied), " ")
+			if path == denied || strings.HasPrefix(path, denied+" ") {
+				return exitcode.Errorf(exitcode.Config, "'goforge %s' is not allowed in this project (policy.deny in goforge.yml)", path)
+			}
+		}
+		return next(cmd, args)
+	}
+}
+
+// checkStrict stops commands while goforge.yml has unknown keys, when it
+// sets strict: true. The config commands still run, to fix them.
+func checkStrict(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		loaded, _ := cmd.Context().Value(projectKey{}).(*loadedProject)
+		if loaded == nil || loaded.err != nil || !loaded.cfg.Strict {
+			return next(cmd, args)
+		}
+		if path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "); path == "config" || strings.HasPrefix(path, "config ") {
+			return next(cmd, args)
+		}
+
+		problems, err := project.ValidateProject(loaded.root)
+		if err != nil {
+			return err
+		}
+		var unknown []string
+		for _, problem := range problems {
+			if problem.Unknown {
+				unknown = append(unknown, fmt.Sprintf("  %s:%s", problem.File, problem))
+			}
+		}
+		if len(unknown) > 0 {
+			return exitcode.Errorf(exitcode.Config, "goforge.yml has unknown keys, which strict mode does not allow:\n%s\n\nFix them, or remove strict: true from goforge.yml to ignore them", strings.Join(unknown, "\n"))
+		}
+		return next(cmd, args)
+	}
+}
+
+// serviceCommands work on one module, so they cannot run at the root of a
+// workspace; their subcommands inherit this.
+var serviceCommands = map[string]bool{
+	"build":    true,
+	"generate": true,
+}
+
+// checkWorkspace stops service commands run at the root of a go.work
+// workspace, whose goforge.yml has a workspace section, and points to the
+// services to run them in instead.
+func checkWorkspace(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		loaded, _ := cmd.Context().Value(projectKey{}).(*loadedProject)
+		if loaded == nil || loaded.err != nil || loaded.cfg.Workspace == nil {
+			return next(cmd, args)
+		}
+		top := cmd
+		for top.HasParent() && top.Parent() != cmd.Root() {
+			top = top.Parent()
+		}
+		if all, _ := cmd.Flags().GetBool("all-services"); all || !serviceCommands[top.Name()] {
+			return next(cmd, args)
+		}
+
+		msg := fmt.Sprintf("'%s' works on a single service, not the workspace root", cmd.CommandPath())
+		ws, err := project.FindWorkspace(loaded.root)
+		if err != nil || ws == nil {
+			return fmt.Errorf("%s; run it from a service directory", msg)
+		}
+		services, err := ws.Services()
+		if err != nil || len(services) == 0 {
+			return fmt.Errorf("%s; run it from a service directory", msg)
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s; select a service, e.g.:\n  goforge %s --service %s\n\nServices:", msg, strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "), services[0].Name)
+		for _, service := range services {
+			fmt.Fprintf(&b, "\n  %-16s %s", service.Name, service.Dir)
+		}
+		return fmt.Errorf("%s", b.String())
+	}
+}
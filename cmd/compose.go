@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+
+	"github.com/night-slayer18/goforge/internal/compose"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// composeCmd groups the commands running the project's docker-compose.yml.
+var composeCmd = &cobra.Command{
+	Use:   "compose",
+	Short: "Start and stop the services of docker-compose.yml",
+	Long: `Runs the backing services of the project's docker-compose.yml, such as
+the database, cache, and broker, with 'docker compose'.
+
+'goforge compose up' waits until the services are healthy, then writes
+their connection variables (DATABASE_URL, DATABASE_HOST, DATABASE_PORT,
+REDIS_ADDR, BROKER_URL, ...) with the ports published on this machine to
+.goforge/compose.env. 'goforge run', 'goforge dev', 'goforge watch',
+'goforge migrate', and 'goforge db' export them until 'goforge compose
+down'; variables already set in the environment win.
+
+Examples:
+  goforge compose up
+  goforge compose up db
+  goforge compose logs -f db
+  goforge compose ps
+  goforge compose down --volumes`,
+}
+
+// composeUpCmd starts the services and records how to reach them.
+var composeUpCmd = &cobra.Command{
+	Use:   "up [service...]",
+	Short: "Start the services, wait until healthy, and record their connection variables",
+	Long: `Starts the given services, or every backing service: those running a
+published image, leaving out the application built from the project and
+services behind a profile. --all starts the application too.
+
+Examples:
+  goforge compose up
+  goforge compose up db redis
+  goforge compose up --all --timeout 120`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		p, err := compose.Load(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		all, _ := cmd.Flags().GetBool("all")
+		services := args
+		for _, name := range services {
+			if _, ok := p.Services[name]; !ok {
+				return fmt.Errorf("no service '%s' in %s", name, p.File)
+			}
+		}
+		if len(services) == 0 && !all {
+			services = p.BackingServices()
+			if len(services) == 0 {
+				return fmt.Errorf("%s has no backing services; start them all with --all", p.File)
+			}
+		}
+
+		timeout, _ := cmd.Flags().GetInt("timeout")
+		logger.Info("🐳 Starting %s", serviceList(services))
+		if err := p.Up(services, timeout); err != nil {
+			return fmt.Errorf("%w; see why with: goforge compose logs", err)
+		}
+
+		if len(services) == 0 {
+			services = slices.Sorted(maps.Keys(p.Services))
+		}
+		env, err := p.Env(services)
+		if err != nil {
+			return err
+		}
+		if err := compose.SaveEnv(projectRoot, env); err != nil {
+			return fmt.Errorf("failed to write %s: %w", compose.EnvFile, err)
+		}
+
+		logger.Success("✅ Services are healthy")
+		if len(env) > 0 {
+			logger.Info("")
+			logger.Info("🔌 Connection variables, saved to %s:", compose.EnvFile)
+			for _, key := range slices.Sorted(maps.Keys(env)) {
+				logger.Info("   %s=%s", key, env[key])
+			}
+		}
+		return nil
+	},
+}
+
+// composeDownCmd stops the services and forgets their variables.
+var composeDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Stop and remove the services",
+	Long: `Stops and removes the containers of every service and the connection
+variables of 'goforge compose up'. --volumes also deletes the volumes,
+so databases start empty next time.
+
+Examples:
+  goforge compose down
+  goforge compose down --volumes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		p, err := compose.Load(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		composeArgs := []string{"down"}
+		if volumes, _ := cmd.Flags().GetBool("volumes"); volumes {
+			composeArgs = append(composeArgs, "--volumes")
+		}
+		if err := p.Compose(composeArgs...); err != nil {
+			return err
+		}
+		return compose.RemoveEnv(projectRoot)
+	},
+}
+
+// composeLogsCmd shows the output of the services.
+var composeLogsCmd = &cobra.Command{
+	Use:   "logs [service...]",
+	Short: "Show the output of the services",
+	Long: `Shows the output of the given services, or of all of them; --follow
+keeps streaming it until interrupted.
+
+Examples:
+  goforge compose logs
+  goforge compose logs -f db`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		p, err := compose.Load(projectRoot)
+		if err != nil {
+			return err
+		}
+
+		composeArgs := []string{"logs"}
+		if follow, _ := cmd.Flags().GetBool("follow"); follow {
+			composeArgs = append(composeArgs, "--follow")
+		}
+		if tail, _ := cmd.Flags().GetString("tail"); tail != "" {
+			composeArgs = append(composeArgs, "--tail", tail)
+		}
+		return p.Compose(append(composeArgs, args...)...)
+	},
+}
+
+// composePsCmd lists the services and their state.
+var composePsCmd = &cobra.Command{
+	Use:   "ps",
+	Short: "List the services with their state and ports",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		p, err := compose.Load(projectRoot)
+		if err != nil {
+			return err
+		}
+		return p.Compose("ps", "--all")
+	},
+}
+
+// serviceList names services for messages; none means all of them.
+func serviceList(services []string) string {
+	switch len(services) {
+	case 0:
+		return "all services"
+	case 1:
+		return "service " + services[0]
+	}
+	return fmt.Sprintf("services %v", services)
+}
+
+// exportComposeEnv exports the connection variables 'goforge compose up'
+// recorded, leaving those already set alone.
+func exportComposeEnv(projectRoot string) error {
+	env, err := compose.LoadEnv(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", compose.EnvFile, err)
+	}
+	for key, value := range env {
+		if _, set := os.LookupEnv(key); set {
+			continue
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return fmt.Errorf("failed to export %s: %w", key, err)
+		}
+	}
+	if len(env) > 0 {
+		logger.Debug("Exported the connection variables of %s", compose.EnvFile)
+	}
+	return nil
+}
+
+func init() {
+	composeUpCmd.Flags().Bool("all", false, "Start every service, the application included")
+	composeUpCmd.Flags().Int("timeout", 60, "Seconds to wait for the services to become healthy")
+	composeDownCmd.Flags().Bool("volumes", false, "Also delete the volumes of the services")
+	composeLogsCmd.Flags().BoolP("follow", "f", false, "Keep streaming the output")
+	composeLogsCmd.Flags().String("tail", "", "Number of lines to show from the end of each log")
+
+	composeCmd.AddCommand(composeUpCmd, composeDownCmd, composeLogsCmd, composePsCmd)
+}
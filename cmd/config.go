@@ -0,0 +1,651 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/night-slayer18/goforge/internal/userconfig"
+	"github.com/night-slayer18/goforge/internal/validation"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configCmd groups the commands that read and change settings.
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Read and change the project's or your own settings",
+	Long: `Reads and changes the settings of goforge.yml, or with --global your own
+defaults for every project, kept in ~/.config/goforge/config.yml (the user
+config directory of your system).
+
+Project settings are addressed by their dotted path, e.g. docker.port, and
+changed without losing the comments and key order of goforge.yml. Global settings are:
+
+  author         Copyright holder in the LICENSE of new projects
+  license        License of new projects (MIT, Apache-2.0, GPL-3.0, none)
+  module_prefix  Module path prefix, so 'goforge new app' is <prefix>/app
+  template       Project template of 'goforge new'
+  skip_git       Create projects without a git repository
+  on_conflict    Conflict mode of 'goforge generate'
+  color          Colored output
+  emoji          Emoji in log messages
+  telemetry      Anonymous usage statistics, see 'goforge telemetry'
+
+Flags always take precedence over the global settings.
+
+'goforge config validate' checks goforge.yml against its JSON Schema, which
+'goforge config schema' prints or links for editor completion.
+
+Examples:
+  goforge config --global set module_prefix github.com/myorg
+  goforge config --global set emoji false
+  goforge config --global list
+  goforge config set docker.port 8080
+  goforge config set build.assets config/default.yml web/static
+  goforge config get template.version
+  goforge config unset docker.port
+  goforge config validate
+  goforge config convert --to json`,
+}
+
+// configSetCmd changes a setting.
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>...",
+	Short: "Change a setting",
+	Long: `Changes a setting, keeping the comments and key order of the file.
+
+Project keys are dotted paths into goforge.yml; the keys of dependencies,
+scripts, and the other free-form mappings may contain dots themselves.
+Values are typed by the schema of goforge.yml, and lists such as
+build.assets take one value per argument. Keys and values the schema does
+not allow are rejected and the file left as it was.
+
+Examples:
+  goforge config set scripts.dev "go run ./cmd/server"
+  goforge config set dependencies.github.com/google/uuid latest
+  goforge config set build.assets config/default.yml web/static
+  goforge config set dev.rollback true
+  goforge config --global set emoji false`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, values := args[0], args[1:]
+		if global, _ := cmd.Flags().GetBool("global"); global {
+			if len(values) > 1 {
+				return fmt.Errorf("%s takes a single value", key)
+			}
+			value, err := checkGlobalSetting(key, values[0])
+			if err != nil {
+				return err
+			}
+			if err := userconfig.Set(key, value); err != nil {
+				return err
+			}
+			file, _ := userconfig.Path()
+			logger.Success("✅ Set %s to %s in %s", key, value, file)
+			return nil
+		}
+
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		if err := setProjectSetting(projectRoot, key, values); err != nil {
+			return err
+		}
+		logger.Success("✅ Set %s to %s in %s", key, strings.Join(values, ", "), filepath.Base(project.ConfigPath(projectRoot)))
+		return nil
+	},
+}
+
+// configGetCmd prints a setting.
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print a setting",
+	Long: `Prints a setting: a value as is, a list one item per line, and a mapping
+as YAML.
+
+Examples:
+  goforge config get go_version
+  goforge config get build.assets
+  goforge config get scripts`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if global, _ := cmd.Flags().GetBool("global"); global {
+			settings, err := loadSettings(cmd)
+			if err != nil {
+				return err
+			}
+			value, ok := settings[key]
+			if !ok {
+				return fmt.Errorf("'%s' is not set", key)
+			}
+			fmt.Println(value)
+			return nil
+		}
+
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		node, err := project.LookupConfigValue(projectRoot, projectKeyPath(key))
+		if err != nil {
+			return err
+		}
+		if node != nil && node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node == nil || node.Tag == "!!null" {
+			return fmt.Errorf("'%s' is not set", key)
+		}
+		return printSetting(node)
+	},
+}
+
+// configUnsetCmd removes a setting.
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset <key>",
+	Short: "Remove a setting, so its default applies",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key := args[0]
+		if global, _ := cmd.Flags().GetBool("global"); global {
+			removed, err := userconfig.Unset(key)
+			if err != nil {
+				return err
+			}
+			if !removed {
+				return fmt.Errorf("'%s' is not set", key)
+			}
+			file, _ := userconfig.Path()
+			logger.Success("✅ Removed %s from %s", key, file)
+			return nil
+		}
+
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		removed := false
+		err = editProjectConfig(projectRoot, key, func(file string) error {
+			keyPath := projectKeyPath(key)
+			if removed, err = project.UnsetYAMLValue(file, keyPath); err != nil {
+				return err
+			}
+			// A value inherited from an extended or included file is dropped with null
+			inherited, err := project.LookupConfigValue(projectRoot, keyPath)
+			if err != nil || inherited == nil {
+				return err
+			}
+			removed = true
+			return project.SetYAMLValue(file, keyPath, "null", "!!null")
+		})
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("'%s' is not set", key)
+		}
+		logger.Success("✅ Removed %s from %s", key, filepath.Base(project.ConfigPath(projectRoot)))
+		return nil
+	},
+}
+
+// configListCmd prints every setting.
+var configListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "Print every setting",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		settings, err := loadSettings(cmd)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, key := range slices.Sorted(maps.Keys(settings)) {
+			fmt.Fprintf(w, "%s\t%s\n", key, settings[key])
+		}
+		return w.Flush()
+	},
+}
+
+// configValidateCmd checks goforge.yml against the schema.
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check goforge.yml for unknown keys, wrong types, and missing settings",
+	Long: `Checks goforge.yml against its JSON Schema and reports, with their lines,
+unknown keys (typos goforge would silently ignore), values of the wrong
+type, values outside their allowed set, and missing required keys.
+
+Files goforge.yml extends or includes are merged first, and problems are
+reported in the file they are in. Keys starting with x- are left for
+plugins and other tools. The command
+fails when there is a problem, so it can run in CI or a pre-commit hook.
+Unknown keys are only warned about, with the key they most likely meant,
+unless --strict is given or goforge.yml sets strict: true; in strict mode
+every other command refuses to run while there are any, too.
+
+Examples:
+  goforge config validate
+  goforge config validate --strict`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if global, _ := cmd.Flags().GetBool("global"); global {
+			return fmt.Errorf("validate checks goforge.yml; --global is not supported")
+		}
+		projectRoot, err := project.FindRoot()
+		if err != nil {
+			return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+		}
+		problems, err := project.ValidateProject(projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to load goforge.yml: %w", err)
+		}
+		strict, _ := cmd.Flags().GetBool("strict")
+		if cfg, err := project.LoadConfigFrom(projectRoot); err == nil && cfg.Strict {
+			strict = true
+		}
+
+		failed := 0
+		for _, problem := range problems {
+			if problem.Unknown && !strict {
+				logger.Warn("⚠️  %s:%s", problem.File, problem)
+				continue
+			}
+			logger.Error("%s:%s", problem.File, problem)
+			failed++
+		}
+		switch {
+		case failed == 1:
+			return exitcode.Errorf(exitcode.Config, "goforge.yml has 1 problem")
+		case failed > 1:
+			return exitcode.Errorf(exitcode.Config, "goforge.yml has %d problems", failed)
+		case len(problems) > 0:
+			logger.Info("💡 goforge ignores unknown keys; use --strict or set strict: true to fail on them")
+		default:
+			logger.Success("✅ goforge.yml is valid")
+		}
+		return nil
+	},
+}
+
+// configConvertCmd rewrites goforge.yml in another format.
+var configConvertCmd = &cobra.Command{
+	Use:   "convert --to yaml|json|toml",
+	Short: "Rewrite goforge.yml as goforge.json or goforge.toml, or back",
+	Long: `Rewrites the project's configuration in another format and removes the
+old file. goforge reads the first of goforge.yml, goforge.yaml,
+goforge.json, and goforge.toml it finds, and edits each in its own format.
+
+Only the file itself is converted: files it extends or includes keep their
+format, and environment variable placeholders are kept as written.
+Comments are lost, as JSON has none and TOML is rewritten with its keys
+sorted; TOML cannot hold null values either.
+
+Examples:
+  goforge config convert --to json
+  goforge config convert --to yaml`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if global, _ := cmd.Flags().GetBool("global"); global {
+			return fmt.Errorf("convert rewrites goforge.yml; --global is not supported")
+		}
+		format, _ := cmd.Flags().GetString("to")
+		if format == "yml" {
+			format = "yaml"
+		}
+		if format == "" {
+			return fmt.Errorf("--to is required (use %s)", strings.Join(project.Formats, ", "))
+		}
+		if !slices.Contains(project.Formats, format) {
+			return fmt.Errorf("unknown format '%s' (use %s)", format, strings.Join(project.Formats, ", "))
+		}
+		projectRoot, err := project.FindRoot()
+		if err != nil {
+			return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+		}
+
+		from := project.ConfigPath(projectRoot)
+		if project.FormatOf(from) == format {
+			logger.Info("%s is already in %s", filepath.Base(from), format)
+			return nil
+		}
+		to, err := project.ConvertConfig(projectRoot, format)
+		if err != nil {
+			return err
+		}
+		logger.Success("✅ Converted %s to %s", filepath.Base(from), filepath.Base(to))
+		if project.FormatOf(from) == "yaml" {
+			logger.Info("💡 Comments of %s were not carried over", filepath.Base(from))
+		}
+		return nil
+	},
+}
+
+// schemaFile is where 'goforge config schema --write' puts the schema.
+const schemaFile = ".goforge/goforge.schema.json"
+
+// schemaModeline points the YAML language server, and with it most
+// editors, to the schema of goforge.yml.
+const schemaModeline = "# yaml-language-server: $schema="
+
+// configSchemaCmd prints or links the JSON Schema of goforge.yml.
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of goforge.yml, or link it for editors",
+	Long: `Prints the JSON Schema of goforge.yml. With --write it is written to
+` + schemaFile + ` instead, and goforge.yml gets a comment pointing the YAML
+language server (VS Code's YAML extension, Neovim, Helix, JetBrains IDEs)
+to it, for completion and inline errors:
+
+  ` + schemaModeline + schemaFile + `
+
+Run it again after updating goforge to refresh the schema.
+
+Examples:
+  goforge config schema > goforge.schema.json
+  goforge config schema --write`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if write, _ := cmd.Flags().GetBool("write"); !write {
+			_, err := os.Stdout.Write(project.Schema())
+			return err
+		}
+		projectRoot, err := project.FindRoot()
+		if err != nil {
+			return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+		}
+		target := filepath.Join(projectRoot, filepath.FromSlash(schemaFile))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, project.Schema(), 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", schemaFile, err)
+		}
+		configPath := project.ConfigPath(projectRoot)
+		switch project.FormatOf(configPath) {
+		case "json":
+			// Editors read the schema of a JSON file from its $schema key
+			if err := project.SetYAMLValue(configPath, []string{"$schema"}, filepath.ToSlash(schemaFile), "!!str"); err != nil {
+				return err
+			}
+		case "toml":
+			logger.Success("✅ Wrote %s", schemaFile)
+			logger.Info("💡 TOML has no standard way to link it; point your editor's TOML extension to it")
+			return nil
+		default:
+			if err := linkSchema(configPath, schemaFile); err != nil {
+				return err
+			}
+		}
+		logger.Success("✅ Wrote %s and linked it from %s", schemaFile, filepath.Base(configPath))
+		return nil
+	},
+}
+
+// linkSchema adds the schema modeline for schemaPath to the top of the
+// YAML file, or updates the one it has.
+func linkSchema(file, schemaPath string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", filepath.Base(file), err)
+	}
+	modeline := schemaModeline + schemaPath
+	lines := strings.SplitAfter(string(data), "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), schemaModeline) {
+			lines[i] = modeline + "\n"
+			return os.WriteFile(file, []byte(strings.Join(lines, "")), 0644)
+		}
+	}
+	return os.WriteFile(file, []byte(modeline+"\n"+string(data)), 0644)
+}
+
+// checkGlobalSetting validates value for the global setting key and
+// returns it in its canonical spelling.
+func checkGlobalSetting(key, value string) (string, error) {
+	if _, err := userconfig.LookupKey(key); err != nil {
+		return "", err
+	}
+	switch key {
+	case "license":
+		return scaffold.NormalizeLicense(value)
+	case "template":
+		if _, err := scaffold.ProjectTemplateManifest(value); err != nil {
+			return "", err
+		}
+	case "on_conflict":
+		if !scaffold.ValidConflictMode(value) {
+			return "", fmt.Errorf("unknown conflict mode '%s' (use prompt, skip, overwrite or merge)", value)
+		}
+	case "module_prefix":
+		value = strings.TrimSuffix(value, "/")
+		if err := validation.NewProjectValidator().ValidateModulePath(value + "/app"); err != nil {
+			return "", fmt.Errorf("invalid module path prefix '%s': %w", value, err)
+		}
+	}
+	return value, nil
+}
+
+// setProjectSetting sets the dotted key of goforge.yml to values, typed by
+// the schema: a list takes every value, anything else a single one. Keys
+// the schema does not know get a boolean, number, or string by their
+// spelling.
+func setProjectSetting(projectRoot, key string, values []string) error {
+	keyPath, typ, err := project.ConfigKey(key)
+	if err != nil {
+		return err
+	}
+	if typ == "object" {
+		return fmt.Errorf("%s is a mapping; set its keys instead, e.g. %s.<name>", key, key)
+	}
+	if typ != "array" && len(values) > 1 {
+		return fmt.Errorf("%s takes a single value; only lists take several", key)
+	}
+
+	return editProjectConfig(projectRoot, key, func(file string) error {
+		if typ == "array" {
+			return project.SetYAMLList(file, keyPath, values)
+		}
+		value, tag, err := typedSetting(key, typ, values[0])
+		if err != nil {
+			return err
+		}
+		return project.SetYAMLValue(file, keyPath, value, tag)
+	})
+}
+
+// typedSetting returns value in its canonical spelling with the YAML tag
+// of the schema type typ.
+func typedSetting(key, typ, value string) (string, string, error) {
+	switch typ {
+	case "string":
+		return value, "!!str", nil
+	case "integer":
+		if _, err := strconv.Atoi(value); err != nil {
+			return "", "", fmt.Errorf("%s takes a whole number, not '%s'", key, value)
+		}
+		return value, "!!int", nil
+	case "number":
+		if _, err := strconv.Atoi(value); err == nil {
+			return value, "!!int", nil
+		}
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return "", "", fmt.Errorf("%s takes a number, not '%s'", key, value)
+		}
+		return value, "!!float", nil
+	case "boolean":
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", "", fmt.Errorf("%s must be true or false, not '%s'", key, value)
+		}
+		return strconv.FormatBool(parsed), "!!bool", nil
+	}
+
+	if value == "true" || value == "false" {
+		return value, "!!bool", nil
+	} else if _, err := strconv.Atoi(value); err == nil {
+		return value, "!!int", nil
+	}
+	return value, "!!str", nil
+}
+
+// editProjectConfig runs edit on the project's configuration file and
+// keeps the result only if goforge can still read the file and it has no
+// schema problems it did not have before; otherwise the file is restored.
+func editProjectConfig(projectRoot, key string, edit func(file string) error) error {
+	file := project.ConfigPath(projectRoot)
+	name := filepath.Base(file)
+	original, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	restore := func(cause error) error {
+		if restoreErr := os.WriteFile(file, original, 0644); restoreErr != nil {
+			return fmt.Errorf("failed to restore %s: %w", name, restoreErr)
+		}
+		return cause
+	}
+
+	before, err := project.ValidateProject(projectRoot)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	if err := edit(file); err != nil {
+		return restore(err)
+	}
+	if _, err := project.LoadConfigFrom(projectRoot); err != nil {
+		return restore(fmt.Errorf("cannot change %s: %w", key, err))
+	}
+	after, err := project.ValidateProject(projectRoot)
+	if err != nil {
+		return restore(err)
+	}
+	for _, problem := range after {
+		if !slices.ContainsFunc(before, func(old project.Problem) bool {
+			return old.Path == problem.Path && old.Message == problem.Message
+		}) {
+			return restore(fmt.Errorf("cannot change %s: %s", key, problemText(problem)))
+		}
+	}
+	return nil
+}
+
+// problemText describes a schema problem without its position.
+func problemText(problem project.Problem) string {
+	if problem.Path == "" {
+		return problem.Message
+	}
+	return problem.Path + ": " + problem.Message
+}
+
+// projectKeyPath splits a dotted key of goforge.yml as the schema does,
+// or at every dot for keys it does not know.
+func projectKeyPath(key string) []string {
+	if keyPath, _, err := project.ConfigKey(key); err == nil {
+		return keyPath
+	}
+	return strings.Split(key, ".")
+}
+
+// printSetting prints a scalar as is, a list of scalars one per line, and
+// anything else as YAML.
+func printSetting(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		fmt.Println(node.Value)
+		return nil
+	case yaml.SequenceNode:
+		if !slices.ContainsFunc(node.Content, func(item *yaml.Node) bool { return item.Kind != yaml.ScalarNode }) {
+			for _, item := range node.Content {
+				fmt.Println(item.Value)
+			}
+			return nil
+		}
+	}
+	data, err := yaml.Marshal(node)
+	if err != nil {
+		return err
+	}
+	_, err = os.Stdout.Write(data)
+	return err
+}
+
+// loadSettings returns the settings of the user config with --global, of
+// goforge.yml otherwise, by dotted key.
+func loadSettings(cmd *cobra.Command) (map[string]string, error) {
+	settings := map[string]string{}
+	if global, _ := cmd.Flags().GetBool("global"); global {
+		cfg, err := userconfig.Load()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range userconfig.Keys {
+			if value := cfg.Get(key.Name); value != "" {
+				settings[key.Name] = value
+			}
+		}
+		return settings, nil
+	}
+
+	_, projectRoot, err := requireProject(cmd)
+	if err != nil {
+		return nil, err
+	}
+	data, err := project.ReadConfigData(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read goforge.yml: %w", err)
+	}
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse goforge.yml: %w", err)
+	}
+	flattenSettings(settings, "", doc)
+	return settings, nil
+}
+
+// flattenSettings adds the scalars and lists under value to settings,
+// keyed by their dotted path below prefix.
+func flattenSettings(settings map[string]string, prefix string, value any) {
+	mapping, ok := value.(map[string]any)
+	if !ok {
+		if value != nil {
+			settings[prefix] = fmt.Sprint(value)
+		}
+		return
+	}
+	for key, child := range mapping {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		flattenSettings(settings, key, child)
+	}
+}
+
+func init() {
+	configCmd.PersistentFlags().Bool("global", false,
+		"Use your own defaults in the user config instead of goforge.yml")
+
+	configValidateCmd.Flags().Bool("strict", false, "Fail on unknown keys as well")
+	configConvertCmd.Flags().String("to", "", "Format to convert to: yaml, json, or toml")
+	configSchemaCmd.Flags().Bool("write", false, "Write the schema to "+schemaFile+" and link it from goforge.yml")
+
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configUnsetCmd)
+	configCmd.AddCommand(configListCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configSchemaCmd)
+	configCmd.AddCommand(configConvertCmd)
+}
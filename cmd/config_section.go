@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// configSectionCmd represents the command to generate a typed config section.
+var configSectionCmd = &cobra.Command{
+	Use:   "config <name>",
+	Short: "Generate a typed config struct synced with config/default.yml",
+	Long: `Generates internal/config/<name>.go with a struct for the <name> section of
+config/default.yml, its defaults, and environment variable bindings
+(e.g. PAYMENT_API_KEY for payment.api_key). The first section also creates
+internal/config/config.go with Load.
+
+Keys already in the section become fields, with types inferred from their
+values. Keys given with --field that the section lacks are added to
+config/default.yml. Run the command again after editing the section to
+bring the struct back in sync.
+
+Field types: string, int, int64, bool, float64, duration, []string, []int
+and map[string]string.
+
+Examples:
+  goforge g config payment --field api_key --field timeout:duration=30s --field retries:int=3
+  goforge g config database                 # struct for the existing section`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fields, _ := cmd.Flags().GetStringArray("field")
+
+		return scaffold.GenerateConfig(args[0], scaffold.ConfigOptions{
+			Fields: fields,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	configSectionCmd.Flags().StringArray("field", nil, "Key of the section as name:type[=default], e.g. timeout:duration=30s (repeatable)")
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// consumerCmd represents the command to generate message consumers from an
+// AsyncAPI document.
+var consumerCmd = &cobra.Command{
+	Use:   "consumer --from <asyncapi.yaml>",
+	Short: "Generate Kafka or NATS consumers from an AsyncAPI spec",
+	Long: `Generates a consumer for every channel the application receives messages
+from in an AsyncAPI 2 ('publish' operations) or 3 ('receive' operations)
+document, into internal/adapters/messaging:
+
+  messages.gen.go        Structs for the message payloads
+  subscriptions.gen.go   The channels, decoding payloads for their handlers
+  <channel>_consumer.go  A Consumers method stub per channel
+  codec.go               Codecs per content type (JSON; register others)
+  kafka.go / nats.go     Runs the subscriptions on the broker
+
+and cmd/consumer, which runs them with the messaging section of
+config/default.yml. The broker follows the protocol of the document's
+servers unless --broker is given.
+
+The structs and subscriptions are regenerated on every run; stubs are only
+added for handlers the package does not declare yet.
+
+Examples:
+  goforge g consumer --from asyncapi.yaml
+  goforge g consumer --from events/asyncapi.yml --broker nats`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		broker, _ := cmd.Flags().GetString("broker")
+
+		return scaffold.GenerateConsumer(from, scaffold.ConsumerOptions{Broker: broker}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	consumerCmd.Flags().String("from", "asyncapi.yaml", "AsyncAPI 2 or 3 document to generate from")
+	consumerCmd.Flags().String("broker", "", "Broker to consume from: kafka or nats (default: from the spec's servers)")
+}
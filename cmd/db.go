@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/night-slayer18/goforge/internal/database"
+	"github.com/night-slayer18/goforge/internal/interactive"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/runner"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// dbCmd groups the commands working on the project's database.
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Open and reset the development database",
+	Long: `Works on the database 'goforge migrate' migrates: --database, else
+$DATABASE_URL, else migrations.database_url of goforge.yml. PostgreSQL,
+MySQL, MongoDB, and SQLite URLs are supported, through their psql, mysql,
+mongosh, and sqlite3 clients.
+
+Examples:
+  goforge db console
+  goforge db reset
+  goforge db reset --yes --no-seed`,
+}
+
+// dbConsoleCmd opens the database's client.
+var dbConsoleCmd = &cobra.Command{
+	Use:   "console",
+	Short: "Open psql, mysql, mongosh, or sqlite3 on the database",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, db, _, err := loadDatabase(cmd)
+		if err != nil {
+			return err
+		}
+		logger.Info("🗄️  Connecting to %s", db.Redacted())
+		return db.Console(projectRoot)
+	},
+}
+
+// dbResetCmd rebuilds the database from the migrations and seeds.
+var dbResetCmd = &cobra.Command{
+	Use:   "reset",
+	Short: "Drop, recreate, migrate, and seed the database",
+	Long: `Drops the database, creates it again, applies every migration with
+'goforge migrate up', and runs the db:seed script when goforge.yml has
+one. All data in the database is lost, so it asks first unless --yes is
+given.
+
+Examples:
+  goforge db reset
+  goforge db reset --yes --no-seed`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, db, migrations, err := loadDatabase(cmd)
+		if err != nil {
+			return err
+		}
+		cfg, _, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		noSeed, _ := cmd.Flags().GetBool("no-seed")
+
+		if yes, _ := cmd.Flags().GetBool("yes"); !yes {
+			if !interactive.IsInteractiveTerminal() {
+				return fmt.Errorf("resetting %s deletes all its data; confirm with --yes", db.Redacted())
+			}
+			answer, err := interactive.PromptChoice(fmt.Sprintf("Delete all data in %s and rebuild it?", db.Redacted()), []string{"yes", "no"}, "no")
+			if err != nil {
+				return err
+			}
+			if answer != "yes" {
+				logger.Info("Reset cancelled")
+				return nil
+			}
+		}
+
+		logger.Info("🗑️  Recreating %s...", db.Name)
+		if err := db.Recreate(projectRoot); err != nil {
+			return fmt.Errorf("failed to recreate the database: %w", err)
+		}
+
+		logger.Info("🗄️  Migrating up with %s...", migrations.Engine)
+		if err := scaffold.RunMigrations(projectRoot, migrations, "up", 0); err != nil {
+			return fmt.Errorf("migrate up failed: %w", err)
+		}
+
+		seed, ok := cfg.Scripts["db:seed"]
+		switch {
+		case noSeed:
+		case !ok:
+			logger.Info("No db:seed script in goforge.yml; skipped seeding (add one with: goforge g seeder <name>)")
+		default:
+			logger.Info("🌱 Seeding: %s", seed)
+			if err := runner.ExecuteScript(projectRoot, seed); err != nil {
+				return fmt.Errorf("seeding failed: %w", err)
+			}
+		}
+
+		logger.Success("✅ Reset %s", db.Name)
+		return nil
+	},
+}
+
+// loadDatabase returns the project root, its database, and the migration
+// settings it was found in.
+func loadDatabase(cmd *cobra.Command) (string, *database.Database, scaffold.Migrations, error) {
+	projectRoot, migrations, err := loadMigrations(cmd)
+	if err != nil {
+		return "", nil, migrations, err
+	}
+	if migrations.DatabaseURL == "" {
+		return "", nil, migrations, fmt.Errorf("no database configured: set migrations.database_url in goforge.yml or DATABASE_URL")
+	}
+	db, err := database.Parse(migrations.DatabaseURL)
+	if err != nil {
+		return "", nil, migrations, err
+	}
+	return projectRoot, db, migrations, nil
+}
+
+func init() {
+	dbCmd.PersistentFlags().String("database", "", "Database URL (default: $DATABASE_URL, then migrations.database_url)")
+	dbResetCmd.Flags().BoolP("yes", "y", false, "Reset without asking")
+	dbResetCmd.Flags().Bool("no-seed", false, "Leave out the db:seed script")
+
+	dbCmd.AddCommand(dbConsoleCmd)
+	dbCmd.AddCommand(dbResetCmd)
+}
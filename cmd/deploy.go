@@ -0,0 +1,223 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/deploy"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/plugins"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// deployBuildDir receives the build the ssh provider copies to the server.
+const deployBuildDir = ".goforge/deploy"
+
+// deployCmd ships the project with the provider of goforge.yml.
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy the project with the provider configured in goforge.yml",
+	Long: `Deploys the project with the provider of the deploy section of
+goforge.yml:
+
+  fly          flyctl deploy, building the Dockerfile or deploying deploy.fly.image
+  render       a deploy of deploy.render.service_id through the Render API,
+               authenticated by RENDER_API_KEY
+  kubernetes   kubectl apply of deploy.kubernetes.manifests, then the image
+               is set and the rollout awaited
+  helm         helm upgrade --install of deploy.helm.chart, waiting for it
+  ssh          the binary, built for the server, is copied into a new
+               release directory over SSH and its systemd unit restarted
+
+{version} in images and Helm values is replaced with the release version,
+'git describe' of HEAD unless --version is given. --dry-run prints the
+steps without running them; 'goforge deploy rollback' goes back to the
+previous release.
+
+Any other provider name runs the goforge-deploy-<name> plugin on PATH with
+'deploy --version <version>' (and --dry-run), or 'rollback [--to <release>]';
+it gets the project context like any plugin and reads its settings from
+deploy.<name> in goforge.yml.
+
+Example goforge.yml:
+
+  deploy:
+    provider: ssh
+    ssh:
+      host: deploy@example.com
+      dir: /opt/myapp
+      service: myapp
+
+Examples:
+  goforge deploy --dry-run
+  goforge deploy
+  goforge deploy --version v1.4.0
+  goforge deploy rollback`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		releaseVersion, _ := cmd.Flags().GetString("version")
+		if releaseVersion == "" {
+			releaseVersion = expandBuildInfo(projectRoot, "{version}")
+		}
+
+		if plugin := deployPlugin(cfg); plugin != nil {
+			pluginArgs := []string{"deploy", "--version", releaseVersion}
+			if dryRun {
+				pluginArgs = append(pluginArgs, "--dry-run")
+			}
+			return runDeployPlugin(plugin, projectRoot, pluginArgs)
+		}
+
+		provider, err := deploy.New(cfg, projectRoot)
+		if err != nil {
+			return err
+		}
+		steps, err := provider.Deploy(deployRelease(cfg, projectRoot, releaseVersion))
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			logger.Info("🔍 Deploying %s with %s would:", releaseVersion, cfg.Deploy.Provider)
+			deploy.Print(steps)
+			return nil
+		}
+
+		logger.Info("🚢 Deploying %s with %s", releaseVersion, cfg.Deploy.Provider)
+		if err := deploy.Execute(projectRoot, steps); err != nil {
+			return fmt.Errorf("deploy failed: %w", err)
+		}
+		logger.Success("✅ Deployed %s", releaseVersion)
+		return nil
+	},
+}
+
+// deployRollbackCmd goes back to an earlier release.
+var deployRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Go back to the previous release",
+	Long: `Goes back to the release before the live one, or to the one --to names:
+
+  fly          a release number (v12) or an image, which is redeployed
+  render       a deploy ID (dep-...)
+  kubernetes   a revision of the deployment
+  helm         a revision of the release
+  ssh          a release directory under <dir>/releases
+
+Examples:
+  goforge deploy rollback --dry-run
+  goforge deploy rollback
+  goforge deploy rollback --to 3`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		to, _ := cmd.Flags().GetString("to")
+
+		if plugin := deployPlugin(cfg); plugin != nil {
+			pluginArgs := []string{"rollback"}
+			if to != "" {
+				pluginArgs = append(pluginArgs, "--to", to)
+			}
+			if dryRun {
+				pluginArgs = append(pluginArgs, "--dry-run")
+			}
+			return runDeployPlugin(plugin, projectRoot, pluginArgs)
+		}
+
+		provider, err := deploy.New(cfg, projectRoot)
+		if err != nil {
+			return err
+		}
+		steps, err := provider.Rollback(to)
+		if err != nil {
+			return err
+		}
+		if dryRun {
+			logger.Info("🔍 Rolling back with %s would:", cfg.Deploy.Provider)
+			deploy.Print(steps)
+			return nil
+		}
+
+		logger.Info("🔙 Rolling back with %s", cfg.Deploy.Provider)
+		if err := deploy.Execute(projectRoot, steps); err != nil {
+			return fmt.Errorf("rollback failed: %w", err)
+		}
+		logger.Success("✅ Rolled back")
+		return nil
+	},
+}
+
+// deployPlugin returns the plugin providing deploy.provider, or nil for
+// the built-in providers.
+func deployPlugin(cfg *project.Config) *plugins.Plugin {
+	if cfg.Deploy == nil || cfg.Deploy.Provider == "" {
+		return nil
+	}
+	return deploy.FindPlugin(cfg.Deploy.Provider)
+}
+
+// runDeployPlugin runs a provider plugin with args.
+func runDeployPlugin(plugin *plugins.Plugin, projectRoot string, args []string) error {
+	ctx, err := plugins.NewContext(version, projectRoot)
+	if err != nil {
+		return err
+	}
+	logger.Debug("Running deploy plugin %s", plugin.Path)
+	if err := plugin.Run(ctx, args); err != nil {
+		return fmt.Errorf("%s failed: %w", filepath.Base(plugin.Path), err)
+	}
+	return nil
+}
+
+// deployRelease describes the release to deploy. Its build goes to
+// deployBuildDir, leaving the output of 'goforge build' alone.
+func deployRelease(cfg *project.Config, projectRoot, releaseVersion string) deploy.Release {
+	dir := filepath.Join(projectRoot, filepath.FromSlash(deployBuildDir))
+	return deploy.Release{
+		Version: releaseVersion,
+		Dir:     dir,
+		Binary:  buildBinaryName(cfg, projectRoot),
+		Build: func(goos, goarch string) error {
+			if err := os.RemoveAll(dir); err != nil {
+				return err
+			}
+			build := project.BuildConfig{}
+			if cfg.Build != nil {
+				build = *cfg.Build
+			}
+			build.OutputDir, build.Archive = deployBuildDir, ""
+			build.Env = maps.Clone(build.Env)
+			if build.Env == nil {
+				build.Env = map[string]string{}
+			}
+			build.Env["GOOS"], build.Env["GOARCH"] = goos, goarch
+			if _, set := build.Env["CGO_ENABLED"]; !set {
+				build.Env["CGO_ENABLED"] = "0" // no C libraries to match on the server
+			}
+
+			target := *cfg
+			target.Build = &build
+			_, err := buildProject(&target, projectRoot)
+			return err
+		},
+	}
+}
+
+func init() {
+	deployCmd.PersistentFlags().Bool("dry-run", false, "Print the steps without running them")
+	deployCmd.Flags().String("version", "", "Version of the release (default: git describe of HEAD)")
+	deployRollbackCmd.Flags().String("to", "", "Release to go back to (default: the one before the live release)")
+
+	deployCmd.AddCommand(deployRollbackCmd)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/fakes"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+	"github.com/spf13/cobra"
+)
+
+// Default ports used when a fake is enabled without an explicit port.
+const (
+	defaultSMTPFakePort    = 1025
+	defaultS3FakePort      = 9000
+	defaultWebhookFakePort = 9100
+)
+
+// devCmd runs the development script, optionally alongside local service fakes.
+var devCmd = &cobra.Command{
+	Use:   "dev [script-name]",
+	Short: "Run the development script, optionally with local service fakes",
+	Long: `Runs the 'dev' script (or the given script) from goforge.yml.
+
+With --with-fakes, GoForge first starts lightweight local stand-ins for
+external services and exports their endpoints to the application:
+
+  smtp     SMTP sink that stores mail as .eml files   (SMTP_HOST, SMTP_PORT)
+  s3       Path-style S3-compatible object store       (S3_ENDPOINT, AWS_*)
+  webhook  HTTP catcher that records every request     (WEBHOOK_URL)
+
+Fakes are declared under 'dev.fakes' in goforge.yml. If none are declared,
+all of them are started on their default ports. Captured data is written
+to .goforge/fakes/.
+
+Examples:
+  goforge dev                     # Same as 'goforge run dev'
+  goforge dev --with-fakes        # Start fakes, then run 'dev'
+  goforge dev --with-fakes -w     # Start fakes and use watch mode`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+
+		scriptName := "dev"
+		if len(args) > 0 {
+			scriptName = args[0]
+		}
+
+		script, exists := cfg.Scripts[scriptName]
+		if !exists {
+			return scriptNotFound(scriptName, cfg.Scripts)
+		}
+
+		if err := exportComposeEnv(projectRoot); err != nil {
+			return err
+		}
+		withFakes, _ := cmd.Flags().GetBool("with-fakes")
+		if withFakes {
+			manager := fakes.NewManager(buildFakeServices(projectRoot, cfg)...)
+			if err := manager.Start(); err != nil {
+				return err
+			}
+			defer manager.Stop()
+
+			if err := manager.Export(); err != nil {
+				return err
+			}
+
+			env := manager.Env()
+			logger.Info("🧪 Local fakes running:")
+			for _, key := range manager.EnvKeys() {
+				logger.Info("   %s=%s", key, env[key])
+			}
+			logger.Info("")
+		}
+
+		watch, _ := cmd.Flags().GetBool("watch")
+		if watch {
+			logFile, _ := cmd.Flags().GetString("log-file")
+			return runWatchMode(projectRoot, scriptName, script, logger.Verbose(), logFile, cfg)
+		}
+
+		logger.Info("▶️  Running script '%s': %s", scriptName, script)
+		return runner.ExecuteScript(projectRoot, script)
+	},
+}
+
+// buildFakeServices creates the fakes declared in goforge.yml, or all of
+// them with default ports when nothing is declared.
+func buildFakeServices(projectRoot string, cfg *project.Config) []fakes.Service {
+	dataDir := fakes.DataDir(projectRoot)
+
+	var fc *project.FakesConfig
+	if cfg.Dev != nil {
+		fc = cfg.Dev.Fakes
+	}
+	if fc == nil {
+		fc = &project.FakesConfig{
+			SMTP:    &project.FakeServiceConfig{},
+			S3:      &project.FakeServiceConfig{},
+			Webhook: &project.FakeServiceConfig{},
+		}
+	}
+
+	var services []fakes.Service
+	if fc.SMTP != nil {
+		services = append(services, fakes.NewSMTPSink(portOrDefault(fc.SMTP.Port, defaultSMTPFakePort), filepath.Join(dataDir, "mail")))
+	}
+	if fc.S3 != nil {
+		services = append(services, fakes.NewS3Store(portOrDefault(fc.S3.Port, defaultS3FakePort), filepath.Join(dataDir, "s3"), fc.S3.Buckets))
+	}
+	if fc.Webhook != nil {
+		services = append(services, fakes.NewWebhookCatcher(portOrDefault(fc.Webhook.Port, defaultWebhookFakePort), filepath.Join(dataDir, "webhooks")))
+	}
+	return services
+}
+
+func portOrDefault(port, fallback int) int {
+	if port == 0 {
+		return fallback
+	}
+	return port
+}
+
+func init() {
+	devCmd.Flags().Bool("with-fakes", false, "Start local SMTP/S3/webhook fakes before running the script")
+	devCmd.Flags().BoolP("watch", "w", false, "Run the script in watch mode")
+	devCmd.Flags().String("log-file", "", "With --watch, also write the output to this rotated log file (default: dev.log_file)")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// devcontainerCmd represents the command to generate a dev container.
+var devcontainerCmd = &cobra.Command{
+	Use:   "devcontainer",
+	Short: "Generate a dev container with Go, goforge, and the project's services",
+	Long: `Generates the files to develop the project in a container, with VS Code's
+Dev Containers extension, GitHub Codespaces, or the devcontainer CLI:
+
+  .devcontainer/devcontainer.json     the Go toolchain of the project's Go
+                                      version, goforge installed on creation,
+                                      and the Go extension
+  .devcontainer/docker-compose.yml    the workspace next to the project's
+                                      database and Redis, when it uses them
+
+The database (PostgreSQL, MySQL, or MongoDB) follows the project's
+dependencies; its connection is passed as DATABASE_* variables. The port
+of the docker section of goforge.yml is forwarded.
+
+--vscode also writes .vscode/settings.json (format on save, organized
+imports) and .vscode/extensions.json recommending the extensions for the
+project's tools, such as templ, buf, and GraphQL.
+
+Examples:
+  goforge g devcontainer
+  goforge g devcontainer --vscode`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		vscode, _ := cmd.Flags().GetBool("vscode")
+
+		return scaffold.GenerateDevcontainer(scaffold.DevcontainerOptions{
+			VSCode: vscode,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	devcontainerCmd.Flags().Bool("vscode", false, "Also write VS Code settings and extension recommendations to .vscode/")
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// dockerCmd represents the command to generate the Docker artifacts.
+var dockerCmd = &cobra.Command{
+	Use:   "docker",
+	Short: "Generate a Dockerfile, .dockerignore, and docker-compose.yml",
+	Long: `Generates the files to build and run the project with Docker:
+
+  Dockerfile            multi-stage build of build.main into a small
+                        runtime image, with the assets of build.assets
+  .dockerignore         keeps VCS, build output, and secrets out of the
+                        build context
+  docker-compose.yml    the application next to its database, plus a
+                        'dev' service that runs the sources under
+                        'goforge watch' for hot reload
+
+Images, the exposed port, and the environment come from the docker section
+of goforge.yml. The database (PostgreSQL, MySQL, or MongoDB) follows the
+project's dependencies; its connection is passed as DATABASE_* variables.
+
+'goforge new --docker' writes the same files into new projects.
+
+Examples:
+  goforge g docker
+  goforge g docker --force
+  docker compose up --build
+  docker compose --profile dev up dev`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateDocker(componentOptions(cmd))
+	},
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// dockerfileCmd represents the command to generate a standalone Dockerfile.
+var dockerfileCmd = &cobra.Command{
+	Use:   "dockerfile",
+	Short: "Generate an optimized multi-stage Dockerfile from goforge.yml",
+	Long: `Generates a multi-stage Dockerfile for an existing project, built from
+what goforge.yml says about it:
+
+  entrypoints   build.main, named build.binary_name, runs by default; the
+                other main packages under cmd/ are built next to it, e.g.
+                /app/worker for cmd/worker
+  Go version    go_version picks the golang build image, unless
+                docker.base_image names another
+  build         build.tags and build.ldflags, with {version}, {commit},
+                and {date} taken from the VERSION, COMMIT, and BUILD_DATE
+                build arguments; CGO_ENABLED=1 in build.env builds on
+                Debian for a glibc runtime
+  assets        build.assets are copied next to the binaries
+  runtime       docker.env and docker.port
+
+Modules and the build cache are kept in cache mounts, static binaries are
+cross-compiled on the build machine for multi-platform images, and the
+runtime image runs as a non-root user. --base picks it:
+
+  distroless    gcr.io/distroless/static-debian12:nonroot (the default)
+  alpine        alpine:3 with CA certificates and time zones
+  debian        debian:bookworm-slim with CA certificates and time zones
+
+Unlike 'goforge g docker', it writes only the Dockerfile.
+
+Examples:
+  goforge g dockerfile
+  goforge g dockerfile --base alpine --force
+  docker buildx build --platform linux/amd64,linux/arm64 -t myapp .`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		base, _ := cmd.Flags().GetString("base")
+
+		return scaffold.GenerateDockerfile(scaffold.DockerfileOptions{Base: base}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	dockerfileCmd.Flags().String("base", scaffold.DockerBaseDistroless,
+		"Runtime base image: "+strings.Join(scaffold.DockerBases, ", "))
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/apidocs"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// docsCmd groups the documentation commands.
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate documentation for the project",
+}
+
+// docsOpenAPICmd builds an OpenAPI spec from the handler annotations.
+var docsOpenAPICmd = &cobra.Command{
+	Use:   "openapi",
+	Short: "Generate an OpenAPI spec from annotated handlers",
+	Long: `Builds an OpenAPI 3 document from the swaggo-style annotations in handler
+comments, as inserted by 'goforge g handler':
+
+  // @Summary  Get a user
+  // @Tags     users
+  // @Param    id path int true "User ID"
+  // @Success  200 {object} domain.User
+  // @Failure  404 {object} map[string]string
+  // @Router   /users/{id} [get]
+
+@title, @version, @description and @BasePath above main describe the API.
+Types named in annotations become schemas, following json and binding tags.
+
+--ui writes the spec into internal/adapters/http/docs instead, next to a
+route serving it with Swagger UI. --serve previews the spec in Swagger UI
+locally, rebuilding it on every reload.
+
+Examples:
+  goforge docs openapi
+  goforge docs openapi --ui
+  goforge docs openapi --serve --port 8088`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		dirs, _ := cmd.Flags().GetStringArray("dir")
+		options := apidocs.Options{ProjectName: cfg.ProjectName, ModulePath: cfg.ModuleName, Dirs: dirs}
+
+		output, _ := cmd.Flags().GetString("output")
+		if ui, _ := cmd.Flags().GetBool("ui"); ui {
+			dir, err := scaffold.GenerateSwaggerUI(scaffold.GenerateOptions{OnConflict: scaffold.ConflictSkip})
+			if err != nil {
+				return err
+			}
+			uiSpec := filepath.Join(dir, scaffold.DocsSpecFile)
+			if cmd.Flags().Changed("output") && filepath.Clean(output) != uiSpec {
+				return fmt.Errorf("the Swagger UI route embeds %s, so --output cannot be combined with --ui", uiSpec)
+			}
+			output = uiSpec
+		}
+
+		result, err := apidocs.Build(projectRoot, options)
+		if err != nil {
+			return err
+		}
+		for _, warning := range result.Warnings {
+			logger.Warn("⚠️  %s", warning)
+		}
+		if result.Operations == 0 {
+			logger.Warn("⚠️  No annotated handlers found; document them with @Router and friends (see 'goforge docs openapi --help')")
+		}
+		if err := apidocs.Write(result.Document, filepath.Join(projectRoot, output)); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+		logger.Success("✅ Documented %d operation(s) in %s", result.Operations, output)
+
+		if serve, _ := cmd.Flags().GetBool("serve"); serve {
+			port, _ := cmd.Flags().GetInt("port")
+			addr := fmt.Sprintf("localhost:%d", port)
+			logger.Info("📚 Swagger UI at http://%s (Ctrl+C to stop)", addr)
+			return http.ListenAndServe(addr, apidocs.Handler(func() (*apidocs.Document, error) {
+				result, err := apidocs.Build(projectRoot, options)
+				if err != nil {
+					return nil, err
+				}
+				return result.Document, nil
+			}))
+		}
+		return nil
+	},
+}
+
+func init() {
+	docsOpenAPICmd.Flags().StringP("output", "o", "docs/openapi.json", "Where to write the spec, relative to the project root")
+	docsOpenAPICmd.Flags().StringArray("dir", nil, "Directory to scan for annotations (repeatable; default: the whole project)")
+	docsOpenAPICmd.Flags().Bool("ui", false, "Add a route serving the spec with Swagger UI and write the spec next to it")
+	docsOpenAPICmd.Flags().Bool("serve", false, "Preview the spec in Swagger UI, rebuilding it on every reload")
+	docsOpenAPICmd.Flags().Int("port", 8088, "Port for --serve")
+
+	docsCmd.AddCommand(docsOpenAPICmd)
+}
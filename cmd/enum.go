@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/night-slayer18/goforge/internal/validation"
+	"github.com/spf13/cobra"
+)
+
+// enumCmd represents the command to generate a typed enum.
+var enumCmd = &cobra.Command{
+	Use:   "enum <name> <value,...>",
+	Short: "Generate a typed enum with String, JSON marshaling and validation",
+	Long: `Generates a typed enum in internal/domain: constants for each value,
+String, Parse<Name>, IsValid, <Name>Values, and MarshalJSON/UnmarshalJSON
+encoding values by name, with a test file covering them. The zero value
+is not a valid value, so unset fields are caught.
+
+Values are given comma-separated or as separate arguments.
+
+Examples:
+  goforge g enum order-status pending,paid,shipped,cancelled
+  goforge g enum role admin editor viewer`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := scaffold.GenerateEnum(args[0], args[1:], componentOptions(cmd))
+		if err != nil && validation.Report(err) {
+			return exitcode.Errorf(exitcode.Validation, "invalid values for enum %s", args[0])
+		}
+		return err
+	},
+}
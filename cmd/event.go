@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// eventCmd represents the command to generate an event and its listener.
+var eventCmd = &cobra.Command{
+	Use:   "event <name>",
+	Short: "Generate an event type and a listener for the event bus",
+	Long: `Generates an event type and a listener in internal/events, and subscribes
+the listener in internal/events/listeners.go. The first event also creates
+internal/platform/eventbus, an in-process bus delivering events to their
+listeners synchronously (Publish) or in the background (PublishAsync).
+
+Run the command again with --listener to add another listener to an
+existing event.
+
+Examples:
+  goforge g event user-registered
+  goforge g event user-registered --listener send-welcome-email
+  goforge g event order-shipped`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		listener, _ := cmd.Flags().GetString("listener")
+
+		return scaffold.GenerateEvent(args[0], scaffold.EventOptions{
+			Listener: listener,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	eventCmd.Flags().String("listener", "", "Name of the listener (defaults to the event name)")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func TestValidationExitCodes(t *testing.T) {
+	root := t.TempDir()
+	config := "project_name: app\nmodule_path: example.com/app\ngo_version: \"1.24\"\n"
+	if err := os.WriteFile(filepath.Join(root, "goforge.yml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(root)
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("GOFORGE_NO_UPDATE_CHECK", "1")
+
+	wrapCommands(rootCmd)
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+
+	tests := [][]string{
+		// Commands and flags
+		{"bulid"},
+		{"migrate", "craete"},
+		{"info", "--bogus"},
+
+		// Output flags
+		{"clean", "--output", "json"},
+		{"info", "--output", "xml"},
+		{"info", "--log-level", "loud"},
+		{"info", "--log-format", "xml"},
+		{"info", "-v", "-q"},
+		{"info", "-v", "--log-level", "debug"},
+
+		// Generators
+		{"generate", "handler", "user", "--on-conflict", "bogus"},
+		{"generate", "handler", "user", "--path", "../out"},
+		{"generate", "handler", "Bad Name"},
+		{"generate", "handler", "1abc"},
+		{"generate", "enum", "Bad", "x", "y"},
+
+		{"test", "--format", "xml"},
+	}
+	for _, args := range tests {
+		_, err := execute(args)
+		if code := exitcode.Of(err); code != exitcode.Validation {
+			t.Errorf("goforge %s: exit status %d (%v), want %d", strings.Join(args, " "), code, err, exitcode.Validation)
+		}
+		resetFlags(rootCmd)
+	}
+}
+
+// resetFlags sets the flags changed by a run back to their defaults, as
+// cobra keeps them between runs.
+func resetFlags(cmd *cobra.Command) {
+	reset := func(flag *pflag.Flag) {
+		if flag.Changed {
+			flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		}
+	}
+	cmd.Flags().VisitAll(reset)
+	cmd.PersistentFlags().VisitAll(reset)
+	for _, sub := range cmd.Commands() {
+		resetFlags(sub)
+	}
+}
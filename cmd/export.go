@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/globs"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/spf13/cobra"
+)
+
+// airMarker starts the .air.toml files written by 'goforge export air',
+// which are replaced without --force.
+const airMarker = "# Generated by 'goforge export air'"
+
+const airHeader = airMarker + " from goforge.yml.\n" +
+	"# Change dev.watch, dev.ignore, and the scripts there and export again\n" +
+	"# instead of editing this file.\n\n"
+
+// airTmpDir receives the binaries air builds.
+const airTmpDir = ".goforge/air"
+
+// airConfig is the part of air's configuration goforge.yml determines.
+type airConfig struct {
+	Root   string   `toml:"root"`
+	TmpDir string   `toml:"tmp_dir"`
+	Build  airBuild `toml:"build"`
+	Misc   airMisc  `toml:"misc"`
+}
+
+type airBuild struct {
+	Cmd           string   `toml:"cmd"`
+	Bin           string   `toml:"bin,omitempty"`
+	FullBin       string   `toml:"full_bin,omitempty"`
+	ArgsBin       []string `toml:"args_bin,omitempty"`
+	PreCmd        []string `toml:"pre_cmd,omitempty"`
+	IncludeExt    []string `toml:"include_ext"`
+	IncludeDir    []string `toml:"include_dir"`
+	IncludeFile   []string `toml:"include_file"`
+	ExcludeDir    []string `toml:"exclude_dir"`
+	ExcludeRegex  []string `toml:"exclude_regex"`
+	Delay         int      `toml:"delay"`
+	StopOnError   bool     `toml:"stop_on_error"`
+	SendInterrupt bool     `toml:"send_interrupt"`
+	KillDelay     string   `toml:"kill_delay"`
+}
+
+type airMisc struct {
+	CleanOnExit bool `toml:"clean_on_exit"`
+}
+
+// exportCmd writes the configuration of other tools from goforge.yml.
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export goforge.yml settings to the configuration of other tools",
+	Long: `Writes the configuration of other tools from goforge.yml, so teammates
+using them stay in step with it. goforge.yml remains the source of truth:
+export again after changing it.`,
+}
+
+// exportAirCmd writes an .air.toml from the watch settings.
+var exportAirCmd = &cobra.Command{
+	Use:   "air [script-name]",
+	Short: "Write an .air.toml matching 'goforge watch'",
+	Long: `Writes an .air.toml for air (github.com/air-verse/air) that watches and
+restarts the project like 'goforge watch' does:
+
+  dev.watch       include_ext, include_dir, and include_file
+  dev.ignore      exclude_dir and exclude_regex
+  dev.on_change   pre_cmd
+  scripts.dev     the build command and binary; 'go run [flags] <package>
+                  [args]' scripts are built into .goforge/air, any other
+                  script runs as is after a compile check
+
+Without dev.watch or dev.ignore, the defaults of watch mode are exported.
+air has no globs, so patterns it cannot express are reported and left
+out. dev.rollback and dev.live_reload only apply to 'goforge watch'.
+
+An .air.toml written by this command is replaced; use --force to replace
+one written by hand.
+
+Examples:
+  goforge export air
+  goforge export air api      # restart the 'api' script instead of 'dev'
+  goforge export air -o -     # print instead of writing .air.toml`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		scriptName := "dev"
+		if len(args) > 0 {
+			scriptName = args[0]
+		}
+		script, exists := cfg.Scripts[scriptName]
+		if !exists {
+			return scriptNotFound(scriptName, cfg.Scripts)
+		}
+
+		config, skipped := airConfigFor(cfg, projectRoot, script)
+		for _, pattern := range skipped {
+			logger.Warn("⚠️  air cannot express '%s'; it is left out", pattern)
+		}
+		content, err := toml.Marshal(config)
+		if err != nil {
+			return err
+		}
+		content = append([]byte(airHeader), content...)
+
+		output, _ := cmd.Flags().GetString("output")
+		if output == "-" {
+			_, err := os.Stdout.Write(content)
+			return err
+		}
+		target := output
+		if !filepath.IsAbs(target) {
+			target = filepath.Join(projectRoot, target)
+		}
+		force, _ := cmd.Flags().GetBool("force")
+		if existing, err := os.ReadFile(target); err == nil && !force && !bytes.HasPrefix(existing, []byte(airMarker)) {
+			return fmt.Errorf("%s exists and was not written by 'goforge export air'; use --force to replace it", output)
+		}
+		if err := os.WriteFile(target, content, 0o644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+
+		logger.Success("✅ Wrote %s for the '%s' script", output, scriptName)
+		logger.Info("")
+		logger.Info("📋 Next steps:")
+		logger.Info("   1. Run air in the project root (install: go install github.com/air-verse/air@latest)")
+		logger.Info("   2. Export again after changing dev or scripts.%s in goforge.yml", scriptName)
+		return nil
+	},
+}
+
+// airConfigFor translates the watch settings and script into air's
+// configuration, returning the patterns air cannot express.
+func airConfigFor(cfg *project.Config, projectRoot, script string) (airConfig, []string) {
+	watch, ignore := defaultWatchPatterns, defaultIgnorePatterns
+	var onChange []string
+	if cfg.Dev != nil {
+		if len(cfg.Dev.Watch) > 0 {
+			watch = cfg.Dev.Watch
+		}
+		if len(cfg.Dev.Ignore) > 0 {
+			ignore = cfg.Dev.Ignore
+		}
+		onChange = cfg.Dev.OnChange
+	}
+
+	build := airBuild{
+		PreCmd:        onChange,
+		IncludeExt:    []string{},
+		IncludeDir:    []string{},
+		IncludeFile:   []string{},
+		ExcludeDir:    []string{},
+		ExcludeRegex:  []string{},
+		Delay:         int(watchDebounce.Milliseconds()),
+		StopOnError:   true,
+		SendInterrupt: true, // watch mode stops the process with SIGTERM first
+		KillDelay:     "3s",
+	}
+	if bk := NewBuildKeeper(projectRoot, script); bk != nil {
+		binary := path.Base(filepath.ToSlash(bk.pkg))
+		if binary == "." || binary == "/" {
+			binary = filepath.Base(projectRoot)
+		}
+		bin := path.Join(airTmpDir, binary)
+		build.Cmd = strings.Join(slices.Concat([]string{"go", "build", "-o", "./" + bin}, bk.buildFlags, []string{bk.pkg}), " ")
+		build.Bin = "./" + bin
+		build.ArgsBin = bk.args
+	} else {
+		build.Cmd = "go build ./..."
+		build.FullBin = script
+	}
+
+	skipped := airWatch(&build, watch)
+	skipped = append(skipped, airIgnore(&build, ignore)...)
+	return airConfig{
+		Root:   ".",
+		TmpDir: airTmpDir,
+		Build:  build,
+		Misc:   airMisc{CleanOnExit: true},
+	}, skipped
+}
+
+// airWatch translates dev.watch. Patterns of the form [dir/]**/*.ext or
+// dir/*.ext become extensions, and literal paths files; the directories
+// are only restricted when every pattern starts with a literal one.
+func airWatch(build *airBuild, patterns []string) (skipped []string) {
+	var dirs []string
+	everyInDir := true
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			skipped = append(skipped, pattern)
+			continue
+		}
+		for _, expanded := range globs.Expand(pattern) {
+			segments := strings.Split(strings.Trim(path.Clean(expanded), "/"), "/")
+			last, parents := segments[len(segments)-1], segments[:len(segments)-1]
+			switch ext, ok := strings.CutPrefix(last, "*."); {
+			case ok && !globs.HasMeta(ext):
+				build.IncludeExt = appendUnique(build.IncludeExt, ext)
+			case !globs.HasMeta(expanded):
+				build.IncludeFile = appendUnique(build.IncludeFile, expanded)
+				continue
+			default:
+				skipped = append(skipped, expanded)
+				continue
+			}
+			if len(parents) > 0 && !globs.HasMeta(parents[0]) {
+				dirs = appendUnique(dirs, parents[0])
+			} else {
+				everyInDir = false
+			}
+		}
+	}
+	if everyInDir {
+		build.IncludeDir = append(build.IncludeDir, dirs...)
+	}
+	return skipped
+}
+
+// airIgnore translates dev.ignore: dir/** becomes an excluded directory,
+// any other pattern a regular expression. air matches those against the
+// full path, so they are anchored at a separator instead of the root.
+func airIgnore(build *airBuild, patterns []string) (skipped []string) {
+	for _, pattern := range patterns {
+		if strings.HasPrefix(pattern, "!") {
+			skipped = append(skipped, pattern)
+			continue
+		}
+		for _, expanded := range globs.Expand(pattern) {
+			if dir, ok := strings.CutSuffix(path.Clean(expanded), "/**"); ok && !globs.HasMeta(dir) {
+				build.ExcludeDir = appendUnique(build.ExcludeDir, dir)
+				continue
+			}
+			expression := "(^|/)" + strings.TrimPrefix(globs.Regexp(expanded), "^")
+			build.ExcludeRegex = appendUnique(build.ExcludeRegex, expression)
+		}
+	}
+	return skipped
+}
+
+// appendUnique appends value unless list already holds it.
+func appendUnique(list []string, value string) []string {
+	if slices.Contains(list, value) {
+		return list
+	}
+	return append(list, value)
+}
+
+func init() {
+	exportAirCmd.Flags().StringP("output", "o", ".air.toml", "File to write, relative to the project root; - prints it")
+	exportAirCmd.Flags().BoolP("force", "f", false, "Replace an .air.toml not written by goforge")
+
+	exportCmd.AddCommand(exportAirCmd)
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// fixtureCmd represents the command to generate a test data builder.
+var fixtureCmd = &cobra.Command{
+	Use:   "fixture <model>",
+	Short: "Generate a test data builder for a domain model",
+	Long: `Generates a builder for a domain model in internal/testutil, reading the
+model's fields from internal/domain. New<Model>() starts from valid
+defaults that are unique per builder (emails, IDs, slugs), with a With
+method per field and Build returning the model:
+
+  user := testutil.NewUser().WithName("Ada").Build()
+
+The first fixture also adds testutil.Sequence and testutil.Now, the fixed
+time fixtures use.
+
+Examples:
+  goforge g fixture user
+  goforge g fixture order-item`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateFixture(args[0], componentOptions(cmd))
+	},
+}
@@ -3,8 +3,10 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/night-slayer18/goforge/internal/exitcode"
 	"github.com/night-slayer18/goforge/internal/interactive"
 	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/night-slayer18/goforge/internal/validation"
 	"github.com/spf13/cobra"
 )
 
@@ -17,10 +19,44 @@ var generateCmd = &cobra.Command{
 Available components:
   handler     Generate HTTP handlers for API endpoints
   service     Generate application services for business logic  
-  repository  Generate repository implementations for data access
+  repository  Generate repository implementations for data access (--with sqlc|squirrel)
   model       Generate domain models/entities
-  middleware  Generate HTTP middleware components
+  models      Generate models, ports, and repositories from the database (--from-db)
+  middleware  Generate HTTP middleware components (--preset cors|jwt|ratelimit|requestid|recovery)
   port        Generate port interfaces for clean architecture
+  ratelimiter Generate a shared rate limiting package (token bucket / sliding window)
+  oidc        Generate OpenID Connect login (Keycloak, Auth0, Google)
+  mock        Generate mocks for a port interface (mockgen or mockery)
+  job         Generate a scheduled background job and worker
+  grpc        Generate a gRPC service with its .proto definition
+  api         Generate models, handler stubs and routes from an OpenAPI spec
+  consumer    Generate Kafka or NATS consumers from an AsyncAPI spec
+  resolver    Generate a GraphQL schema and gqlgen resolvers
+  command     Generate a cobra subcommand and register it in the root command
+  config      Generate a typed config struct synced with config/default.yml
+  enum        Generate a typed enum with String, JSON marshaling and tests
+  event       Generate an event and listener for the in-process event bus
+  seeder      Generate a database seeder and register it with cmd/seed
+  fixture     Generate a test data builder for a domain model
+  ci          Generate a CI pipeline for GitHub Actions, GitLab CI or CircleCI
+  docker      Generate a Dockerfile, .dockerignore, and docker-compose.yml
+  dockerfile  Generate an optimized multi-stage Dockerfile from goforge.yml
+  devcontainer
+              Generate a dev container with Go, goforge, and the database
+  makefile    Generate a Makefile delegating to goforge and the scripts
+  taskfile    Generate a Taskfile.yml delegating to goforge and the scripts
+  systemd     Generate a systemd unit running the deployed binary
+  procfile    Generate a Procfile running the built binary
+  hooks       Install git hooks running fmt and lint on commit, tests on push
+  healthcheck-client
+              Generate a dependency probe registry with /health endpoints
+
+Custom components:
+  Any <type>.go.tpl in .goforge/templates becomes a generator. An optional
+  <type>.yml next to it sets 'description', 'dir', 'file' (e.g. "%s_usecase.go"),
+  and 'variables' the template reads as {{.Vars.<name>}} (set with --var key=value).
+  Generators from installed template packs are used as <pack>/<type>
+  (see 'goforge templates add').
 
 Examples:
   goforge generate handler user
@@ -29,13 +65,35 @@ Examples:
   goforge g model order
   goforge g middleware cors
   goforge g port notification
+  goforge g org/consumer events
+
+  # Group components by sub-domain (creates handler/admin/user_handler.go)
+  goforge g handler admin/user
+
+  # Custom output location
+  goforge g handler user --path internal/transport/http
+
+  # Regenerating existing files
+  goforge g handler user --on-conflict merge
+  goforge g handler user --force
   
+  # Several entities at once from a spec file (all or nothing)
+  goforge generate --from entities.yml --on-conflict skip
+
   # Interactive mode
   goforge generate --interactive
   goforge g -i`,
-	Aliases: []string{"g"},
-	Args:    cobra.MaximumNArgs(2), // Allow 0, 1, or 2 args for interactive mode
+	Annotations: map[string]string{jsonAnnotation: "true"},
+	Aliases:     []string{"g"},
+	Args:        cobra.MaximumNArgs(2), // Allow 0, 1, or 2 args for interactive mode
 	RunE: func(cmd *cobra.Command, args []string) error {
+		if from, _ := cmd.Flags().GetString("from"); from != "" {
+			if len(args) > 0 {
+				return fmt.Errorf("--from cannot be combined with a component type or name")
+			}
+			return generateBatch(cmd, from)
+		}
+
 		interactiveFlag, _ := cmd.Flags().GetBool("interactive")
 		
 		var componentType, name string
@@ -60,7 +118,7 @@ Examples:
 		
 		if useInteractive {
 			// Use interactive mode
-			wizard := interactive.NewComponentWizard()
+			wizard := interactive.NewComponentWizard().WithComponents(wizardComponents())
 			options, err := wizard.RunComponentCreationWizard()
 			if err != nil {
 				return fmt.Errorf("interactive session failed: %w", err)
@@ -74,21 +132,200 @@ Examples:
 			name = args[1]
 		}
 		
-		// Generate the component (same for both modes)
-		return scaffold.GenerateComponent(componentType, name)
+		// Generate the component; the wizard also asks for template variables
+		return runGenerate(cmd, componentType, name, useInteractive)
 	},
 }
 
+// wizardComponents lists the built-in components plus the project's custom
+// generators for the interactive wizard.
+func wizardComponents() []interactive.Template {
+	specs := scaffold.Components()
+	if projectRoot := currentProjectRoot(); projectRoot != "" {
+		custom, err := scaffold.CustomComponents(projectRoot)
+		if err == nil {
+			specs = append(specs, custom...)
+		}
+	}
+
+	var components []interactive.Template
+	index := make(map[string]int)
+	for _, spec := range specs {
+		component := interactive.Template{Name: spec.Type, Description: spec.Description}
+		if i, ok := index[spec.Type]; ok {
+			components[i] = component // custom template overrides the built-in
+			continue
+		}
+		index[spec.Type] = len(components)
+		components = append(components, component)
+	}
+	return components
+}
+
+// generateComponent reads the shared generate flags and scaffolds a component.
+func generateComponent(cmd *cobra.Command, componentType, name string) error {
+	return runGenerate(cmd, componentType, name, false)
+}
+
+// runGenerate scaffolds a component, prompting for its template variables
+// when askVars is set.
+func runGenerate(cmd *cobra.Command, componentType, name string, askVars bool) error {
+	var variables []scaffold.TemplateVariable
+	if askVars {
+		var err error
+		variables, err = scaffold.ComponentVariables(componentType)
+		if err != nil {
+			return err
+		}
+	}
+	vars, err := templateVars(cmd, variables, askVars)
+	if err != nil {
+		return err
+	}
+
+	options := componentOptions(cmd)
+	options.Vars = vars
+	return scaffold.GenerateComponentWithOptions(componentType, name, options)
+}
+
+// generateBatch generates every entity described in a spec file.
+func generateBatch(cmd *cobra.Command, file string) error {
+	spec, err := scaffold.LoadBatchSpec(file)
+	if err != nil {
+		if validation.Report(err) {
+			return exitcode.Errorf(exitcode.Validation, "invalid batch spec %s", file)
+		}
+		return err
+	}
+
+	return scaffold.GenerateBatch(spec, componentOptions(cmd))
+}
+
+// componentOptions returns the generate options of cmd's --on-conflict,
+// --force, and --path flags, shared by every generator.
+func componentOptions(cmd *cobra.Command) scaffold.GenerateOptions {
+	onConflict, _ := cmd.Flags().GetString("on-conflict")
+	if force, _ := cmd.Flags().GetBool("force"); force {
+		onConflict = scaffold.ConflictOverwrite
+	}
+	path, _ := cmd.Flags().GetString("path")
+	return scaffold.GenerateOptions{OnConflict: onConflict, Path: path}
+}
+
+// variableQuestion asks for a template variable: choices are selected,
+// booleans confirmed, and other values typed in.
+func variableQuestion(v scaffold.TemplateVariable) interactive.Question {
+	prompt := v.Prompt
+	if prompt == "" {
+		prompt = v.Name
+	}
+	question := interactive.Question{
+		Key:         v.Name,
+		Prompt:      "🧩 " + prompt,
+		Description: v.Description,
+		Default:     v.Default,
+	}
+	switch v.Type {
+	case scaffold.VarChoice:
+		question.Kind = interactive.Select
+		for _, choice := range v.Choices {
+			question.Options = append(question.Options, interactive.Template{Name: choice})
+		}
+	case scaffold.VarBool:
+		question.Kind = interactive.Confirm
+		if value, err := v.Parse(v.Default); err == nil {
+			question.Default = fmt.Sprint(value)
+		}
+	default:
+		question.Validate = func(input string) error {
+			if input == "" && v.Required {
+				return fmt.Errorf("%s is required", v.Name)
+			}
+			return v.Validate(input)
+		}
+	}
+	return question
+}
+
+// templateVars reads the --var flags and, when ask is set, prompts for
+// each declared variable not given on the command line.
+func templateVars(cmd *cobra.Command, variables []scaffold.TemplateVariable, ask bool) (map[string]string, error) {
+	pairs, _ := cmd.Flags().GetStringArray("var")
+	values, err := scaffold.ParseVarFlags(pairs)
+	if err != nil {
+		return nil, err
+	}
+	if !ask {
+		return values, nil
+	}
+
+	var questions []interactive.Question
+	for _, v := range variables {
+		if _, ok := values[v.Name]; !ok {
+			questions = append(questions, variableQuestion(v))
+		}
+	}
+	if len(questions) == 0 {
+		return values, nil
+	}
+	answers, err := interactive.Ask("", func(interactive.Answers) []interactive.Question {
+		return questions
+	})
+	if err != nil {
+		return nil, err
+	}
+	for name, answer := range answers {
+		values[name] = answer
+	}
+	return values, nil
+}
+
 func init() {
 	// Add interactive flag to generate command
 	generateCmd.Flags().BoolP("interactive", "i", false, 
 		"Use interactive mode for component generation")
+	generateCmd.Flags().String("from", "",
+		"Generate every entity described in a YAML spec file in one transactional run")
+	generateCmd.PersistentFlags().String("on-conflict", scaffold.ConflictPrompt,
+		"What to do when a file already exists (prompt, skip, overwrite, merge)")
+	generateCmd.PersistentFlags().String("path", "",
+		"Output directory relative to the project root (overrides goforge.yml layout)")
+	generateCmd.PersistentFlags().StringArray("var", nil,
+		"Set a template variable declared in the generator's manifest (key=value, repeatable)")
+	generateCmd.PersistentFlags().BoolP("force", "f", false,
+		"Overwrite existing files (protected '// goforge:keep' regions are preserved)")
+	serviceFlag(generateCmd.PersistentFlags())
 	
 	// Register all component-specific generation commands as subcommands of 'generate'.
 	generateCmd.AddCommand(handlerCmd)
 	generateCmd.AddCommand(serviceCmd)
 	generateCmd.AddCommand(repositoryCmd)
 	generateCmd.AddCommand(modelCmd)
+	generateCmd.AddCommand(modelsCmd)
 	generateCmd.AddCommand(middlewareCmd)
 	generateCmd.AddCommand(portCmd)
+	generateCmd.AddCommand(ratelimiterCmd)
+	generateCmd.AddCommand(oidcCmd)
+	generateCmd.AddCommand(mockCmd)
+	generateCmd.AddCommand(jobCmd)
+	generateCmd.AddCommand(healthcheckClientCmd)
+	generateCmd.AddCommand(eventCmd)
+	generateCmd.AddCommand(grpcCmd)
+	generateCmd.AddCommand(resolverCmd)
+	generateCmd.AddCommand(commandCmd)
+	generateCmd.AddCommand(configSectionCmd)
+	generateCmd.AddCommand(enumCmd)
+	generateCmd.AddCommand(apiCmd)
+	generateCmd.AddCommand(consumerCmd)
+	generateCmd.AddCommand(seederCmd)
+	generateCmd.AddCommand(fixtureCmd)
+	generateCmd.AddCommand(ciCmd)
+	generateCmd.AddCommand(dockerCmd)
+	generateCmd.AddCommand(dockerfileCmd)
+	generateCmd.AddCommand(devcontainerCmd)
+	generateCmd.AddCommand(makefileCmd)
+	generateCmd.AddCommand(taskfileCmd)
+	generateCmd.AddCommand(systemdCmd)
+	generateCmd.AddCommand(procfileCmd)
+	generateCmd.AddCommand(hooksCmd)
 }
\ No newline at end of file
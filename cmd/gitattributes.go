@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/night-slayer18/goforge/internal/testrun"
+	"github.com/night-slayer18/goforge/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var gitattributesCmd = &cobra.Command{
+	Use:   "gitattributes",
+	Short: "Maintain .gitattributes and .gitignore entries for goforge-managed paths",
+	Long: `Writes a goforge-managed block into .gitattributes and .gitignore so that
+build outputs, caches, and generated files are handled consistently across
+projects.
+
+Only the section between the '# >>> goforge managed >>>' markers is
+rewritten; anything else in these files is left untouched.
+
+Examples:
+  goforge gitattributes            # Update both files
+  goforge gitattributes --check    # Exit non-zero if the files are out of date (CI)`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+
+		check, _ := cmd.Flags().GetBool("check")
+
+		files := map[string][]string{
+			".gitattributes": gitAttributeLines(cfg),
+			".gitignore":     gitIgnoreLines(cfg),
+		}
+
+		var stale []string
+		for _, name := range []string{".gitattributes", ".gitignore"} {
+			path := filepath.Join(projectRoot, name)
+			existing, err := os.ReadFile(path)
+			if err != nil && !os.IsNotExist(err) {
+				return fmt.Errorf("failed to read %s: %w", name, err)
+			}
+
+			updated := utils.ReplaceManagedBlock(string(existing), files[name])
+			if updated == string(existing) {
+				logger.Info("✔️  %s is up to date", name)
+				continue
+			}
+
+			if check {
+				stale = append(stale, name)
+				continue
+			}
+
+			if err := os.WriteFile(path, []byte(updated), 0644); err != nil {
+				return fmt.Errorf("failed to write %s: %w", name, err)
+			}
+			logger.Success("✅ Updated %s", name)
+		}
+
+		if len(stale) > 0 {
+			return fmt.Errorf("out of date: %v\n\nRun 'goforge gitattributes' to update them", stale)
+		}
+		return nil
+	},
+}
+
+// gitAttributeLines returns the managed .gitattributes entries.
+func gitAttributeLines(cfg *project.Config) []string {
+	lines := []string{
+		"# Collapse generated files in diffs and exclude them from language stats",
+		".goforge/generated/** linguist-generated=true -diff",
+		"# go.sum only ever gains lines; union-merge avoids needless conflicts",
+		"go.sum linguist-generated=true merge=union",
+	}
+	for _, dir := range scaffold.GeneratedDirs(cfg) {
+		lines = append(lines, fmt.Sprintf("%s/** linguist-generated=true", dir))
+	}
+	return lines
+}
+
+// gitIgnoreLines returns the managed .gitignore entries: the build
+// output and test artifacts where goforge.yml puts them, and goforge's
+// local state. The generated code directories are committed, so they are
+// only marked in .gitattributes.
+func gitIgnoreLines(cfg *project.Config) []string {
+	outputDir := "dist"
+	if cfg.Build != nil && cfg.Build.OutputDir != "" {
+		outputDir = cfg.Build.OutputDir
+	}
+	reports := []string{testrun.DefaultReportFile(testrun.FormatJUnit), testrun.DefaultReportFile(testrun.FormatJSON)}
+	if cfg.Test != nil && cfg.Test.ReportFile != "" {
+		reports = []string{cfg.Test.ReportFile}
+	} else if cfg.Test != nil && cfg.Test.ReportFormat != "" {
+		reports = []string{testrun.DefaultReportFile(cfg.Test.ReportFormat)}
+	}
+
+	lines := []string{"# Build output"}
+	lines = appendIgnored(lines, outputDir+"/")
+	lines = append(lines, "# Coverage and test artifacts")
+	lines = appendIgnored(lines, testrun.CoverProfile, testrun.CoverHTML)
+	lines = appendIgnored(lines, reports...)
+	return append(lines,
+		"*.test",
+		"# Local goforge state",
+		"/.goforge/compose.env",
+		"/.goforge/deploy/",
+		"/.goforge/fakes/",
+		"/.goforge/watch/",
+	)
+}
+
+// appendIgnored appends project-relative paths to lines anchored at the
+// project root, leaving out those outside of it.
+func appendIgnored(lines []string, paths ...string) []string {
+	for _, p := range paths {
+		dir := strings.HasSuffix(p, "/")
+		p = path.Clean(filepath.ToSlash(p))
+		if path.IsAbs(p) || p == "." || p == ".." || strings.HasPrefix(p, "../") {
+			continue
+		}
+		if dir {
+			p += "/"
+		}
+		lines = append(lines, "/"+p)
+	}
+	return lines
+}
+
+func init() {
+	gitattributesCmd.Flags().Bool("check", false, "Fail if the managed entries are out of date instead of writing them")
+}
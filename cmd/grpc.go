@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// grpcCmd represents the command to generate a gRPC service.
+var grpcCmd = &cobra.Command{
+	Use:   "grpc <name>",
+	Short: "Generate a gRPC service with its .proto definition",
+	Long: `Generates a gRPC service:
+
+  api/proto/<name>/v1/<name>.proto     Service definition with CRUD methods
+  internal/adapters/grpc/<name>_server.go
+                                       Server implementation skeleton
+
+and registers the server in cmd/grpc/main.go (cmd/server/main.go in
+projects created with 'goforge new -t grpc'). The first service also
+creates cmd/grpc (with the health service, reflection and graceful
+shutdown), buf.yaml and buf.gen.yaml, and the 'grpc' and 'proto:generate'
+scripts. Go code is generated into gen/proto with buf; run
+'goforge proto generate' after editing .proto files.
+
+Examples:
+  goforge g grpc user
+  goforge g grpc order-item`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateGRPC(args[0], componentOptions(cmd))
+	},
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/spf13/cobra"
+)
+
+// healthCmd runs the project's dependency probes.
+var healthCmd = &cobra.Command{
+	Use:   "health [probe...]",
+	Short: "Check the project's dependencies with its health probes",
+	Long: `Runs cmd/healthcheck, which checks the probes registered in the health
+package: the same ones behind the server's /health/ready endpoint. Give
+probe names to check only those. The command fails when a critical probe
+is down, so it can gate deployments and CI jobs.
+
+Generate the probe registry first with 'goforge generate healthcheck-client'.
+
+Examples:
+  goforge health
+  goforge health postgres redis
+  goforge health --json --timeout 5s`,
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(filepath.Join(projectRoot, "cmd", "healthcheck", "main.go")); err != nil {
+			return fmt.Errorf("cmd/healthcheck not found\n\nGenerate the probe registry with: goforge generate healthcheck-client")
+		}
+
+		runArgs := []string{"run", "./cmd/healthcheck"}
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			runArgs = append(runArgs, "-json")
+		}
+		if list, _ := cmd.Flags().GetBool("list"); list {
+			runArgs = append(runArgs, "-list")
+		}
+		if cmd.Flags().Changed("timeout") {
+			timeout, _ := cmd.Flags().GetDuration("timeout")
+			runArgs = append(runArgs, "-timeout="+timeout.String())
+		}
+		runArgs = append(runArgs, args...)
+
+		logger.Debug("go %v", runArgs)
+		run := exec.Command("go", runArgs...)
+		run.Dir = projectRoot
+		run.Stdout = os.Stdout
+		run.Stderr = os.Stderr
+		return run.Run()
+	},
+}
+
+func init() {
+	healthCmd.Flags().Bool("json", false, "Print the report as JSON")
+	healthCmd.Flags().Bool("list", false, "List the registered probes")
+	healthCmd.Flags().Duration("timeout", 0, "Time each probe may take (default 2s)")
+}
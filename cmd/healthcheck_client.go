@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// healthcheckClientCmd represents the command to generate the dependency probe registry.
+var healthcheckClientCmd = &cobra.Command{
+	Use:     "healthcheck-client",
+	Short:   "Generate a dependency probe registry with health endpoints",
+	Aliases: []string{"health"},
+	Long: `Generates a health package (internal/platform/health by default) where
+each adapter registers a named probe, and the consumers of that registry:
+
+  health.go           Registry running probes concurrently with a timeout
+  handler.go          Gin /health/live and /health/ready (503 when a
+                      critical probe fails)
+  probes.go           RegisterProbes, listing the project's probes
+  cmd/healthcheck     Runs the same probes from the shell ('goforge health')
+
+Probes are registered for the adapters the project already has (PostgreSQL,
+Redis, the OIDC provider). Afterwards 'goforge g repository', 'goforge g
+ratelimiter --backend redis' and 'goforge g oidc' register their probes in
+probes.go automatically.
+
+Examples:
+  goforge g healthcheck-client
+  goforge g health --path internal/platform/probes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateHealthcheckClient(componentOptions(cmd))
+	},
+}
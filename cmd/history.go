@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/history"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd lists the commands recorded in .goforge/history.
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List previously run goforge commands",
+	Long: `Every goforge command run inside a project is recorded, with its
+arguments, working directory, and outcome, in .goforge/history. Use the
+number in the first column with 'goforge redo' to run a command again.
+
+Help, completion, history, and redo itself are not recorded.
+
+Examples:
+  goforge history
+  goforge history --limit 50
+  goforge history --failed
+  goforge history --clear`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+		failed, _ := cmd.Flags().GetBool("failed")
+		clear, _ := cmd.Flags().GetBool("clear")
+
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+
+		if clear {
+			if err := history.Clear(projectRoot); err != nil {
+				return fmt.Errorf("failed to clear history: %w", err)
+			}
+			logger.Success("✅ History cleared")
+			return nil
+		}
+
+		entries, err := history.Load(projectRoot)
+		if err != nil {
+			return err
+		}
+		if failed {
+			var kept []history.Entry
+			for _, entry := range entries {
+				if entry.ExitCode != 0 {
+					kept = append(kept, entry)
+				}
+			}
+			entries = kept
+		}
+		if len(entries) == 0 {
+			logger.Info("No commands recorded yet")
+			return nil
+		}
+		if limit > 0 && len(entries) > limit {
+			entries = entries[len(entries)-limit:]
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "#\tTIME\tSTATUS\tDURATION\tCOMMAND")
+		for _, entry := range entries {
+			status := "ok"
+			if entry.ExitCode != 0 {
+				status = fmt.Sprintf("exit %d", entry.ExitCode)
+			}
+			command := entry.Command()
+			if entry.Dir != "" && entry.Dir != "." {
+				command = fmt.Sprintf("(%s) %s", entry.Dir, command)
+			}
+			duration := (time.Duration(entry.Duration) * time.Millisecond).Round(time.Millisecond)
+			fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", entry.ID, entry.Time.Local().Format("2006-01-02 15:04:05"), status, duration, command)
+		}
+		return w.Flush()
+	},
+}
+
+// redoCmd re-runs a recorded command.
+var redoCmd = &cobra.Command{
+	Use:   "redo [n]",
+	Short: "Re-run a command from 'goforge history'",
+	Long: `Runs a recorded command again, from the directory it was first run in.
+Without an argument the most recent command is repeated; otherwise n is
+the number shown by 'goforge history'.
+
+Examples:
+  goforge redo
+  goforge redo 42
+  goforge redo 42 --dry-run`,
+	Args:          cobra.MaximumNArgs(1),
+	SilenceUsage:  true,
+	SilenceErrors: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		_, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		entries, err := history.Load(projectRoot)
+		if err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return fmt.Errorf("no commands recorded yet")
+		}
+
+		entry := entries[len(entries)-1]
+		if len(args) == 1 {
+			id, err := strconv.Atoi(args[0])
+			if err != nil {
+				return exitcode.Errorf(exitcode.Validation, "invalid history number '%s'", args[0])
+			}
+			var ok bool
+			if entry, ok = history.Find(entries, id); !ok {
+				return fmt.Errorf("no command #%d in history (see 'goforge history')", id)
+			}
+		}
+
+		dir := filepath.Join(projectRoot, filepath.FromSlash(entry.Dir))
+		if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+			dir = projectRoot
+		}
+
+		if dryRun {
+			fmt.Println(entry.Command())
+			return nil
+		}
+		logger.Info("🔁 %s", entry.Command())
+
+		executable, err := os.Executable()
+		if err != nil {
+			return err
+		}
+		run := exec.Command(executable, entry.Args...)
+		run.Dir = dir
+		run.Stdin = os.Stdin
+		run.Stdout = os.Stdout
+		run.Stderr = os.Stderr
+		return run.Run()
+	},
+}
+
+// recordInvocation starts recording a command line in the project's
+// history. The returned function completes the entry with the outcome;
+// it does nothing when the command is not recorded.
+func recordInvocation(args []string) func(exitCode int, err error) {
+	if !shouldRecord(args) {
+		return func(int, error) {}
+	}
+	_, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return func(int, error) {}
+	}
+
+	dir := "."
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(projectRoot, cwd); err == nil {
+			dir = filepath.ToSlash(rel)
+		}
+	}
+
+	start := time.Now()
+	return func(exitCode int, err error) {
+		entry := history.Entry{
+			Time:     start.UTC().Truncate(time.Second),
+			Args:     args,
+			Dir:      dir,
+			ExitCode: exitCode,
+			Duration: time.Since(start).Milliseconds(),
+		}
+		if err != nil && exitCode != 0 {
+			entry.Error = err.Error()
+		}
+		if _, err := history.Append(projectRoot, entry); err != nil {
+			logger.Debug("Could not record history: %v", err)
+		}
+	}
+}
+
+// shouldRecord reports whether a command line is worth keeping in history.
+func shouldRecord(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	for _, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if arg == "-h" || arg == "--help" || arg == "--version" {
+			return false
+		}
+	}
+
+	target, _, err := rootCmd.Find(args)
+	if err != nil || target == rootCmd || target == historyCmd || target == redoCmd {
+		return false
+	}
+	switch target.Name() {
+	case "help", "completion", cobra.ShellCompRequestCmd, cobra.ShellCompNoDescRequestCmd:
+		return false
+	}
+	return true
+}
+
+func init() {
+	historyCmd.Flags().Int("limit", 20, "Show only the last n commands (0 for all)")
+	historyCmd.Flags().Bool("failed", false, "Show only commands that failed")
+	historyCmd.Flags().Bool("clear", false, "Delete the recorded history")
+
+	redoCmd.Flags().Bool("dry-run", false, "Print the command instead of running it")
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// hooksCmd represents the command to generate git hooks.
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install git hooks that format and lint before commits and test before pushes",
+	Long: `Installs git hooks wired to the project's scripts:
+
+  pre-commit    the fmt and lint scripts of goforge.yml; the commit is
+                stopped when formatting changes files
+  pre-push      the test script
+
+Steps without a script fall back to go fmt, go vet, and go test.
+
+The hooks are shell scripts in .githooks/, versioned with the project and
+enabled with 'git config core.hooksPath .githooks'. With --pre-commit, a
+.pre-commit-config.yaml for the pre-commit framework is written instead
+and installed when pre-commit is available.
+
+Skip the hooks once with 'git commit --no-verify' or 'git push --no-verify'.
+
+Examples:
+  goforge g hooks
+  goforge g hooks --pre-commit
+  goforge g hooks --force      # Regenerate after changing the scripts`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		preCommit, _ := cmd.Flags().GetBool("pre-commit")
+
+		return scaffold.GenerateHooks(scaffold.HooksOptions{
+			PreCommit: preCommit,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	hooksCmd.Flags().Bool("pre-commit", false, "Write a .pre-commit-config.yaml for the pre-commit framework instead of shell hooks")
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+)
+
+// infoCmd shows the project's metadata from goforge.yml.
+var infoCmd = &cobra.Command{
+	Use:   "info",
+	Short: "Show the project's name, description, author, and license",
+	Long: `Prints the metadata in goforge.yml: the project name, module path,
+description, Go version, author, license, and repository, followed by the
+template and framework the project was created with, if known.
+
+Set the fields with 'goforge config set', e.g.
+  goforge config set description "Order processing service"
+
+With --output json the fields are printed as JSON, with empty ones left
+out.`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{jsonAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, _, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		if wantsJSON(cmd) {
+			info := projectInfo{
+				Name:        cfg.ProjectName,
+				Module:      cfg.ModuleName,
+				Description: cfg.Description,
+				GoVersion:   cfg.GoVersion,
+				Author:      cfg.Author,
+				License:     cfg.License,
+				Repository:  cfg.Repository,
+				Framework:   cfg.Framework,
+			}
+			if cfg.Template != nil {
+				info.Template = cfg.Template.Name
+			}
+			reportJSON(cmd, info)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Name:\t%s\n", cfg.ProjectName)
+		fmt.Fprintf(w, "Module:\t%s\n", cfg.ModuleName)
+		fmt.Fprintf(w, "Description:\t%s\n", valueOr(cfg.Description, "-"))
+		fmt.Fprintf(w, "Go version:\t%s\n", cfg.GoVersion)
+		fmt.Fprintf(w, "Author:\t%s\n", valueOr(cfg.Author, "-"))
+		fmt.Fprintf(w, "License:\t%s\n", valueOr(cfg.License, "-"))
+		fmt.Fprintf(w, "Repository:\t%s\n", valueOr(cfg.Repository, "-"))
+		if cfg.Template != nil && cfg.Template.Name != "" {
+			fmt.Fprintf(w, "Template:\t%s\n", cfg.Template.Name)
+		}
+		if cfg.Framework != "" {
+			fmt.Fprintf(w, "Framework:\t%s\n", cfg.Framework)
+		}
+		return w.Flush()
+	},
+}
+
+// projectInfo is the result of 'goforge info --output json'.
+type projectInfo struct {
+	Name        string `json:"name"`
+	Module      string `json:"module"`
+	Description string `json:"description,omitempty"`
+	GoVersion   string `json:"go_version"`
+	Author      string `json:"author,omitempty"`
+	License     string `json:"license,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+	Template    string `json:"template,omitempty"`
+	Framework   string `json:"framework,omitempty"`
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// jobCmd represents the command to generate a background job.
+var jobCmd = &cobra.Command{
+	Use:   "job <name>",
+	Short: "Generate a scheduled background job",
+	Long: `Generates a job in internal/jobs and registers it in internal/jobs/jobs.go.
+The first job also creates:
+
+  internal/platform/scheduler  Cron scheduler with panic recovery and
+                               overlap protection
+  cmd/worker/main.go           Worker that runs the jobs and, on SIGINT or
+                               SIGTERM, waits for running jobs to finish
+
+and a 'worker' script in goforge.yml. Schedules use cron syntax
+("0 * * * *") or descriptors such as @hourly, @daily, or "@every 5m".
+
+Examples:
+  goforge g job cleanup-sessions --schedule "0 * * * *"
+  goforge g job send-digest --schedule @daily
+  goforge g job refresh-cache --schedule "@every 5m"`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		schedule, _ := cmd.Flags().GetString("schedule")
+
+		return scaffold.GenerateJob(args[0], scaffold.JobOptions{
+			Schedule: schedule,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	jobCmd.Flags().String("schedule", scaffold.DefaultJobSchedule, "When the job runs (cron syntax or @hourly, @daily, @every <duration>)")
+}
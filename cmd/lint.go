@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/lint"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/runner"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// lintConfigFiles are the names golangci-lint reads its configuration
+// from.
+var lintConfigFiles = []string{".golangci.yml", ".golangci.yaml", ".golangci.toml", ".golangci.json"}
+
+// lintCmd represents the command to lint the project.
+var lintCmd = &cobra.Command{
+	Use:   "lint [packages...]",
+	Short: "Lint the project with golangci-lint",
+	Long: `Runs golangci-lint on the given packages, ./... by default, and lists the
+issues by file.
+
+golangci-lint is installed on first use, at the version goforge pins
+(` + lint.DefaultVersion + `) or the one in lint.version of goforge.yml, into the user
+cache directory, so every checkout lints alike whatever is on PATH.
+
+Projects without a golangci-lint configuration get a default .golangci.yml:
+the standard linters plus a few catching common bugs, and goimports with
+the module as the local import prefix.
+
+Examples:
+  goforge lint
+  goforge lint ./internal/...
+  goforge lint --fix
+  goforge lint --json > lint.json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		fix, _ := cmd.Flags().GetBool("fix")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		if !hasLintConfig(projectRoot) {
+			if _, err := scaffold.WriteLintConfig(projectRoot, cfg); err != nil {
+				return err
+			}
+			logger.Info("📝 Wrote a default %s; adjust the linters there", scaffold.LintConfigFile)
+		}
+
+		version := ""
+		if cfg.Lint != nil {
+			version = cfg.Lint.Version
+		}
+		toolPath, err := runner.EnsureTool(lint.Tool(version))
+		if err != nil {
+			return err
+		}
+
+		if !asJSON {
+			logger.Println("🔍 Linting with golangci-lint...")
+		}
+		issues, report, err := lint.Run(projectRoot, toolPath, lint.Options{Paths: args, Fix: fix})
+		if err != nil {
+			return err
+		}
+
+		if asJSON {
+			fmt.Println(string(report))
+		} else {
+			printLintIssues(issues)
+		}
+		if len(issues) > 0 {
+			return fmt.Errorf("%d lint issues found", len(issues))
+		}
+		return nil
+	},
+}
+
+// hasLintConfig reports whether the project configures golangci-lint.
+func hasLintConfig(projectRoot string) bool {
+	for _, name := range lintConfigFiles {
+		if _, err := os.Stat(filepath.Join(projectRoot, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// printLintIssues lists the issues by file, followed by their count per
+// linter.
+func printLintIssues(issues []lint.Issue) {
+	if len(issues) == 0 {
+		logger.Println("✅ No lint issues.")
+		return
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].File != issues[j].File {
+			return issues[i].File < issues[j].File
+		}
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+		return issues[i].Column < issues[j].Column
+	})
+
+	byLinter := map[string]int{}
+	file := ""
+	for _, issue := range issues {
+		if issue.File != file {
+			file = issue.File
+			fmt.Printf("\n%s\n", file)
+		}
+		fmt.Printf("  %d:%d  %s %s\n", issue.Line, issue.Column, issue.Text, faint("("+issue.Linter+")"))
+		byLinter[issue.Linter]++
+	}
+
+	var counts []string
+	for _, linter := range slices.Sorted(maps.Keys(byLinter)) {
+		counts = append(counts, fmt.Sprintf("%s %d", linter, byLinter[linter]))
+	}
+	logger.Printf("\n⚠️  %d issues: %s\n", len(issues), strings.Join(counts, ", "))
+}
+
+func init() {
+	lintCmd.Flags().Bool("fix", false, "Apply the fixes the linters suggest")
+	lintCmd.Flags().Bool("json", false, "Print golangci-lint's JSON report instead of the issue list")
+}
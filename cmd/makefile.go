@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// makefileCmd represents the command to generate a Makefile.
+var makefileCmd = &cobra.Command{
+	Use:   "makefile",
+	Short: "Generate a Makefile delegating to goforge and the project's scripts",
+	Long: `Generates a Makefile for teams and tools that expect one. Its targets
+delegate to goforge: 'build' and 'clean' to the commands of the same name,
+and one target per script of goforge.yml to 'goforge run <script>', with
+':' in script names written as '-' (make dev-watch runs dev:watch).
+'make help', the default target, lists them.
+
+Run it again after changing the scripts. Targets of your own kept between
+the '# goforge:keep custom-targets' and '# goforge:end' lines survive.
+
+Examples:
+  goforge g makefile
+  goforge g makefile --force
+  make build`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateRunnerFile(cmd, scaffold.FormatMake)
+	},
+}
+
+// taskfileCmd represents the command to generate a Taskfile.
+var taskfileCmd = &cobra.Command{
+	Use:   "taskfile",
+	Short: "Generate a Taskfile.yml delegating to goforge and the project's scripts",
+	Long: `Generates a Taskfile.yml for Task (https://taskfile.dev). Like the
+Makefile of 'goforge g makefile', its tasks delegate to goforge build,
+clean, and the scripts of goforge.yml, under their own names.
+
+Run it again after changing the scripts. Tasks of your own kept between
+the '# goforge:keep custom-tasks' and '# goforge:end' lines survive.
+
+Examples:
+  goforge g taskfile
+  task --list`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return generateRunnerFile(cmd, scaffold.FormatTask)
+	},
+}
+
+// generateRunnerFile generates the Makefile or Taskfile with the conflict
+// flags of cmd.
+func generateRunnerFile(cmd *cobra.Command, format string) error {
+	return scaffold.GenerateRunnerFile(format, componentOptions(cmd))
+}
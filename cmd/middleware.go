@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/night-slayer18/goforge/internal/scaffold"
 	"github.com/spf13/cobra"
 )
@@ -10,9 +12,40 @@ var middlewareCmd = &cobra.Command{
 	Use:     "middleware <name>",
 	Short:   "Generate a new HTTP middleware",
 	Aliases: []string{"m"},
-	Args:    cobra.ExactArgs(1),
+	Long: `Generates an HTTP middleware skeleton, or with --preset a working
+implementation with a config struct and tests:
+
+  cors       Cross-origin resource sharing with preflight handling
+  jwt        Bearer token authentication (github.com/golang-jwt/jwt/v5)
+  ratelimit  Per-client in-memory token bucket limiting
+  requestid  Request IDs in headers, the gin context, and context.Context
+  recovery   Panic recovery with structured logging
+
+With a preset the name is optional and defaults to the preset.
+
+Examples:
+  goforge g middleware audit
+  goforge g middleware --preset cors
+  goforge g middleware auth --preset jwt`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		name := args[0]
-		return scaffold.GenerateComponent("middleware", name)
+		preset, _ := cmd.Flags().GetString("preset")
+
+		name := ""
+		if len(args) == 1 {
+			name = args[0]
+		}
+		if preset == "" {
+			if name == "" {
+				return fmt.Errorf("a middleware name is required unless --preset is given")
+			}
+			return generateComponent(cmd, "middleware", name)
+		}
+
+		return scaffold.GenerateMiddlewarePreset(preset, name, componentOptions(cmd))
 	},
-}
\ No newline at end of file
+}
+
+func init() {
+	middlewareCmd.Flags().String("preset", "", "Generate a working implementation: cors, jwt, ratelimit, requestid, recovery")
+}
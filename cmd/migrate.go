@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd groups the commands managing database migrations.
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Create and apply database migrations",
+	Long: `Creates and applies the migrations in migrations.dir of goforge.yml with
+the engine at migrations.engine: golang-migrate (the default), goose, or
+atlas. The engine's CLI is installed with 'go install' when missing.
+
+The engine also sets the file format of the migrations goforge writes,
+here and in 'goforge generate repository':
+
+  golang-migrate  <version>_<name>.up.sql and <version>_<name>.down.sql
+  goose           <version>_<name>.sql with -- +goose Up/Down sections
+  atlas           <version>_<name>.sql, checksummed in atlas.sum
+
+The database is --database, else $DATABASE_URL, else
+migrations.database_url.
+
+Examples:
+  goforge migrate create add_email_to_users
+  goforge migrate up
+  goforge migrate down 2
+  goforge migrate status`,
+}
+
+// migrateCreateCmd writes a new, empty migration.
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create an empty migration in the engine's format",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectRoot, migrations, err := loadMigrations(cmd)
+		if err != nil {
+			return err
+		}
+		files, err := scaffold.CreateMigration(projectRoot, migrations, args[0], "-- Write the migration here.\n", "-- Write the rollback here.\n")
+		if err != nil {
+			return err
+		}
+		logger.Info("")
+		logger.Info("📋 Next steps:")
+		logger.Info("   1. Write the migration in %s", files[0])
+		logger.Info("   2. Apply it: goforge migrate up")
+		return nil
+	},
+}
+
+// migrateUpCmd applies pending migrations.
+var migrateUpCmd = &cobra.Command{
+	Use:   "up [n]",
+	Short: "Apply all pending migrations, or the next n",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrations(cmd, "up", args)
+	},
+}
+
+// migrateDownCmd rolls back applied migrations.
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [n]",
+	Short: "Roll back the last migration, or the last n",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrations(cmd, "down", args)
+	},
+}
+
+// migrateStatusCmd shows which migrations are applied.
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show which migrations are applied",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runMigrations(cmd, "status", nil)
+	},
+}
+
+// loadMigrations returns the project root and its migration settings,
+// with the database the flags and environment select.
+func loadMigrations(cmd *cobra.Command) (string, scaffold.Migrations, error) {
+	cfg, projectRoot, err := requireProject(cmd)
+	if err != nil {
+		return "", scaffold.Migrations{}, err
+	}
+	migrations, err := scaffold.LoadMigrations(cfg)
+	if err != nil {
+		return "", migrations, err
+	}
+	if err := exportComposeEnv(projectRoot); err != nil {
+		return "", migrations, err
+	}
+	if url := os.Getenv("DATABASE_URL"); url != "" {
+		migrations.DatabaseURL = url
+	}
+	if url, _ := cmd.Flags().GetString("database"); url != "" {
+		migrations.DatabaseURL = url
+	}
+	return projectRoot, migrations, nil
+}
+
+// runMigrations runs a migration action, taking the number of steps from
+// args.
+func runMigrations(cmd *cobra.Command, action string, args []string) error {
+	projectRoot, migrations, err := loadMigrations(cmd)
+	if err != nil {
+		return err
+	}
+	steps := 0
+	if len(args) > 0 {
+		steps, err = strconv.Atoi(args[0])
+		if err != nil || steps < 1 {
+			return fmt.Errorf("the number of migrations must be a positive number, got '%s'", args[0])
+		}
+	}
+
+	if action != "status" {
+		logger.Info("🗄️  Migrating %s with %s...", action, migrations.Engine)
+	}
+	if err := scaffold.RunMigrations(projectRoot, migrations, action, steps); err != nil {
+		return fmt.Errorf("migrate %s failed: %w", action, err)
+	}
+	if action != "status" {
+		logger.Success("✅ Migrated %s", action)
+	}
+	return nil
+}
+
+func init() {
+	migrateCmd.PersistentFlags().String("database", "", "Database URL to migrate (default: $DATABASE_URL, then migrations.database_url)")
+
+	migrateCmd.AddCommand(migrateCreateCmd)
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+}
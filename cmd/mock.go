@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// mockCmd represents the command to generate mocks for a port.
+var mockCmd = &cobra.Command{
+	Use:   "mock <port>",
+	Short: "Generate mocks for a port interface",
+	Long: `Generates mocks for every interface in a port file into internal/mocks
+(layout.mock in goforge.yml), mirroring the port's namespace. The port is
+given by name, as passed to 'goforge g port', or by the path of its file.
+
+Mocks are written by mockgen (go.uber.org/mock) unless --tool or
+mocks.tool in goforge.yml selects mockery. A missing tool is installed
+with 'go install' on first use.
+
+Examples:
+  goforge g mock user
+  goforge g mock admin/user
+  goforge g mock internal/ports/billing.go --tool mockery`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool, _ := cmd.Flags().GetString("tool")
+		return scaffold.GenerateMock(args[0], scaffold.MockOptions{Tool: tool})
+	},
+}
+
+func init() {
+	mockCmd.Flags().String("tool", "", "Mock generator to use (mockgen or mockery)")
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// mocksCmd regenerates the mocks of every port.
+var mocksCmd = &cobra.Command{
+	Use:   "mocks",
+	Short: "Regenerate mocks for all port interfaces",
+	Long: `Regenerates the mocks of every port under internal/ports and removes
+mock files whose port no longer exists. Run it after editing port
+interfaces, or in CI to check that committed mocks are up to date.
+
+Examples:
+  goforge mocks
+  goforge mocks --tool mockery`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tool, _ := cmd.Flags().GetString("tool")
+
+		logger.Info("🧪 Regenerating mocks...")
+		count, err := scaffold.RegenerateMocks(scaffold.MockOptions{Tool: tool})
+		if err != nil {
+			return err
+		}
+		if count == 0 {
+			logger.Warn("⚠️  No port interfaces found")
+			return nil
+		}
+		logger.Success("✅ Regenerated %d mock file(s)", count)
+		return nil
+	},
+}
+
+func init() {
+	mocksCmd.Flags().String("tool", "", "Mock generator to use (mockgen or mockery)")
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"github.com/night-slayer18/goforge/internal/scaffold"
 	"github.com/spf13/cobra"
 )
 
@@ -13,6 +12,6 @@ var modelCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		return scaffold.GenerateComponent("model", name)
+		return generateComponent(cmd, "model", name)
 	},
 }
\ No newline at end of file
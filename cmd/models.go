@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// modelsCmd generates entities for the tables of an existing database.
+var modelsCmd = &cobra.Command{
+	Use:   "models --from-db",
+	Short: "Generate models, ports, and repositories from the database's tables",
+	Long: `Reads the tables of the project's database from information_schema (or
+SQLite's table info) and generates, for each table, a domain model with
+a field per column, its repository port, and a repository skeleton, in
+one transactional run like 'goforge generate --from'.
+
+Entities are named after the singular of their table (order_items makes
+orderItem) and keep the table's name. The id, created_at, and updated_at
+columns are part of every model; column types map to Go types, with
+pointers for nullable columns. The migration engines' bookkeeping tables
+are left out.
+
+The database is the one 'goforge migrate' uses: --database, else
+$DATABASE_URL, else migrations.database_url of goforge.yml. It is read
+with its client, psql, mysql, or sqlite3.
+
+Examples:
+  goforge g models --from-db
+  goforge g models --from-db --tables users,orders
+  goforge g models --from-db --exclude audit_log --layers model,port`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if fromDB, _ := cmd.Flags().GetBool("from-db"); !fromDB {
+			return fmt.Errorf("models are generated from the database; use --from-db")
+		}
+		projectRoot, db, migrations, err := loadDatabase(cmd)
+		if err != nil {
+			return err
+		}
+		schema, _ := cmd.Flags().GetString("schema")
+		tables, _ := cmd.Flags().GetStringSlice("tables")
+		exclude, _ := cmd.Flags().GetStringSlice("exclude")
+		layers, _ := cmd.Flags().GetStringSlice("layers")
+
+		logger.Info("🔍 Reading the tables of %s...", db.Redacted())
+		schemaTables, err := db.Tables(projectRoot, schema)
+		if err != nil {
+			return fmt.Errorf("failed to read the tables: %w", err)
+		}
+		spec, err := scaffold.DBModelsSpec(schemaTables, migrations.Table, scaffold.DBModelsOptions{
+			Tables:  tables,
+			Exclude: exclude,
+			Layers:  layers,
+		})
+		if err != nil {
+			return err
+		}
+
+		return scaffold.GenerateModelsFromDB(spec, componentOptions(cmd))
+	},
+}
+
+func init() {
+	modelsCmd.Flags().Bool("from-db", false, "Introspect the project's database")
+	modelsCmd.Flags().String("database", "", "Database URL (default: $DATABASE_URL, then migrations.database_url)")
+	modelsCmd.Flags().String("schema", "public", "PostgreSQL schema to read")
+	modelsCmd.Flags().StringSlice("tables", nil, "Only generate these tables, e.g. --tables users,orders")
+	modelsCmd.Flags().StringSlice("exclude", nil, "Tables to leave out")
+	modelsCmd.Flags().StringSlice("layers", scaffold.DefaultDBModelLayers, "Components to generate for each table")
+}
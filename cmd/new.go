@@ -4,13 +4,18 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/night-slayer18/goforge/internal/exitcode"
 	"github.com/night-slayer18/goforge/internal/interactive"
 	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
 	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/night-slayer18/goforge/internal/userconfig"
 	"github.com/night-slayer18/goforge/internal/validation"
 	"github.com/spf13/cobra"
 )
@@ -54,21 +59,44 @@ func checkPrerequisites() error {
 	return nil
 }
 
-// checkDirectoryExists checks if the target directory already exists
-func checkDirectoryExists(projectName string) error {
-	if _, err := os.Stat(projectName); err == nil {
-		return fmt.Errorf("directory '%s' already exists\n\nSuggestions:\n  • Choose a different project name\n  • Remove the existing directory: rm -rf %s\n  • Use a different location", projectName, projectName)
+// checkTargetDirectory checks that the project can be created in dir: a
+// missing directory is created, an existing one is scaffolded into with
+// conflict detection. It reports whether dir already existed.
+func checkTargetDirectory(dir string) (bool, error) {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return false, nil
 	}
-	return nil
+	if err != nil {
+		return false, err
+	}
+	if !info.IsDir() {
+		return false, fmt.Errorf("'%s' exists and is not a directory\n\nSuggestions:\n  • Choose a different project name\n  • Pass another directory with --dir", dir)
+	}
+	return true, nil
+}
+
+// inDir returns command prefixed with a 'cd' into dir, unless dir is the
+// current directory.
+func inDir(dir, command string) string {
+	if dir == "." {
+		return command
+	}
+	return fmt.Sprintf("cd %s && %s", dir, command)
 }
 
 // newCmd represents the 'new' command, responsible for creating new projects.
 var newCmd = &cobra.Command{
-	Use:   "new [project-name]",
+	Use:   "new [project-name | .]",
 	Short: "Create a new Go project with a scalable architecture",
 	Long: `The 'new' command creates a new directory with the specified project name,
 and scaffolds a complete Go application based on Clean Architecture principles.
 
+'goforge new .' scaffolds into the current directory, named after it, and
+--dir into another directory. Existing directories may hold other files, but
+files the project would overwrite are listed and left alone unless --force
+is given.
+
 It sets up the entire project structure, including handlers, services, repositories,
 a go.mod file, and a goforge.yml project manifest.
 
@@ -76,14 +104,33 @@ Examples:
   goforge new my-api
   goforge new user-service --module-path github.com/myorg/user-service
   goforge new blog-app -m gitlab.com/company/blog-app
+  goforge new graph-api --graphql     # Add a GraphQL API with gqlgen
+  goforge new my-api --framework chi  # Default template on chi instead of Gin
+  goforge new my-api --orm sqlc       # Repositories on sqlc instead of pgx
+  goforge new my-api --logger zap     # Log with zap instead of slog
+  goforge new app --license GPL-3.0   # GPL-3.0 LICENSE instead of MIT
+  goforge new my-api --description "Billing API" --author "Acme Inc."
+  goforge new my-api --ci github      # With a GitHub Actions workflow
+  goforge new my-api --docker         # With a Dockerfile and docker-compose.yml
+  goforge new my-api --features auth,metrics   # With JWT auth and Prometheus metrics
+  goforge new blog-api -t graphql     # GraphQL API template
+  goforge new greeter-svc -t grpc     # gRPC microservice template
+  goforge new my-tool -t cli          # Command-line tool template
+  goforge new jobs -t worker --var broker=nats   # Queue worker template
+  goforge new thumbnailer -t lambda   # AWS Lambda function template
+  goforge new go-retry -t library     # Reusable library template
+  goforge new platform -t workspace   # go.work monorepo template
+  goforge new shop -t cqrs            # Event-driven CQRS template
+  goforge new site -t web             # HTMX and templ web app template
+  goforge new .                       # Scaffold into the current directory
+  goforge new my-api --dir services/api   # Scaffold into an existing folder
+  goforge new . --force               # Overwrite files the project also has
+  goforge new my-api --offline        # Without network access; tidy later
+  goforge new my-api --create-remote github:myorg   # Create and push to a GitHub repository
   goforge new --interactive           # Use interactive mode`,
 	
 	Args: cobra.MaximumNArgs(1), // Changed from ExactArgs(1) to allow interactive mode
 	PreRunE: func(cmd *cobra.Command, args []string) error {
-		// Set up logging based on verbose flag
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		logger.SetVerbose(verbose)
-		
 		return checkPrerequisites()
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
@@ -93,12 +140,35 @@ Examples:
 		modulePath, _ := cmd.Flags().GetString("module-path")
 		skipGit, _ := cmd.Flags().GetBool("skip-git")
 		template, _ := cmd.Flags().GetString("template")
-		verbose, _ := cmd.Flags().GetBool("verbose")
+		verbose := logger.Verbose()
 		interactiveFlag, _ := cmd.Flags().GetBool("interactive")
+		dir, _ := cmd.Flags().GetString("dir")
+		force, _ := cmd.Flags().GetBool("force")
+		offline, _ := cmd.Flags().GetBool("offline")
+		skipTidy, _ := cmd.Flags().GetBool("skip-tidy")
+		graphql, _ := cmd.Flags().GetBool("graphql")
+		features, _ := cmd.Flags().GetStringSlice("features")
+		
+		// The user config's defaults apply where flags are not given;
+		// applyUserConfig has reported a broken one
+		defaults, err := userconfig.Load()
+		if err != nil {
+			defaults = &userconfig.Config{}
+		}
+		if !cmd.Flags().Changed("template") && defaults.Template != "" {
+			template = defaults.Template
+		}
+		createRemote, _ := cmd.Flags().GetString("create-remote")
+		if !cmd.Flags().Changed("skip-git") && defaults.SkipGit && createRemote == "" {
+			skipGit = true
+		}
 		
 		var projectName string
+		var targetDir string
 		var finalModulePath string
 		var finalTemplate string
+		var finalLicense string
+		var finalDescription, finalAuthor, finalRepository string
 		var finalSkipGit bool
 		var finalVerbose bool
 		
@@ -121,7 +191,17 @@ Examples:
 		
 		if useInteractive {
 			// Use interactive mode
-			session := interactive.NewInteractiveSession()
+			session := interactive.NewInteractiveSession().
+				WithTemplates(wizardTemplates()).
+				WithFeatures(wizardFeatures).
+				WithVariables(wizardVariables).
+				WithDefaults(interactive.ProjectDefaults{
+					ModulePrefix: defaults.ModulePrefix,
+					Template:     template,
+					Author:       scaffold.DefaultAuthor(),
+					License:      scaffold.DefaultLicense(),
+					SkipGit:      skipGit,
+				})
 			options, err := session.RunProjectCreationWizard()
 			if err != nil {
 				return fmt.Errorf("interactive session failed: %w", err)
@@ -130,22 +210,63 @@ Examples:
 			projectName = options.ProjectName
 			finalModulePath = options.ModulePath
 			finalTemplate = options.Template
+			finalLicense = options.License
+			finalDescription = options.Description
+			finalAuthor = options.Author
+			finalRepository = options.Repository
 			finalSkipGit = options.SkipGit
 			finalVerbose = options.Verbose || verbose // Respect CLI flag if set
 			
+			// Answers count as flags, which take precedence
+			if len(features) == 0 {
+				features = options.Features
+			}
+			given, _ := cmd.Flags().GetStringArray("var")
+			values, _ := scaffold.ParseVarFlags(given)
+			for name, value := range options.Vars {
+				if _, ok := values[name]; !ok {
+					cmd.Flags().Set("var", name+"="+value)
+				}
+			}
+			
 		} else {
 			// Use traditional command-line mode
 			projectName = args[0]
+			if projectName == "." {
+				// The current directory names the project
+				if dir != "" {
+					return fmt.Errorf("'goforge new .' scaffolds into the current directory; pass a project name with --dir")
+				}
+				cwd, err := os.Getwd()
+				if err != nil {
+					return fmt.Errorf("could not determine the current directory: %w", err)
+				}
+				projectName = filepath.Base(cwd)
+				targetDir = "."
+			}
 			finalModulePath = modulePath
 			finalTemplate = template
+			finalLicense, _ = cmd.Flags().GetString("license")
+			finalDescription, _ = cmd.Flags().GetString("description")
+			finalAuthor, _ = cmd.Flags().GetString("author")
+			if !cmd.Flags().Changed("author") {
+				finalAuthor = scaffold.DefaultAuthor()
+			}
+			finalRepository, _ = cmd.Flags().GetString("repository")
 			finalSkipGit = skipGit
 			finalVerbose = verbose
 			
 			// Set defaults for traditional mode
 			if finalModulePath == "" {
 				finalModulePath = projectName
+				if defaults.ModulePrefix != "" {
+					finalModulePath = path.Join(defaults.ModulePrefix, projectName)
+				}
 				logger.Debug("Using default module path: %s", finalModulePath)
 			}
+			if !cmd.Flags().Changed("repository") {
+				finalRepository = project.RepositoryFor(finalModulePath)
+			}
 			
 			if finalTemplate == "" {
 				finalTemplate = "default"
@@ -155,32 +276,109 @@ Examples:
 		// Initialize validator
 		validator := validation.NewProjectValidator()
 		
-		// Validate project name (same for both modes)
-		if err := validator.ValidateProjectName(projectName); err != nil {
-			if validationErr, ok := err.(*validation.ValidationError); ok {
-				logger.ValidationError(validationErr.Field, validationErr.Value, validationErr.Message, validationErr.Suggestions)
-				return fmt.Errorf("invalid project name")
+		// Validate every input before creating anything, so all problems are
+		// reported together (same for both modes)
+		var problems validation.ValidationErrors
+		problems.Add("project", validator.ValidateProject(projectName, finalModulePath))
+		
+		// Without --license, the user config or MIT decides
+		if finalLicense == "" {
+			finalLicense = scaffold.DefaultLicense()
+		}
+		license, err := scaffold.NormalizeLicense(finalLicense)
+		problems.Add("license", err)
+		for _, field := range []struct{ name, value string }{
+			{"description", finalDescription}, {"author", finalAuthor}, {"repository", finalRepository},
+		} {
+			if strings.ContainsAny(field.value, "\r\n") {
+				problems.Add(field.name, fmt.Errorf("the %s must fit on one line", field.name))
+			}
+		}
+		
+		ci, _ := cmd.Flags().GetString("ci")
+		if ci != "" && !scaffold.ValidCIProvider(ci) {
+			problems.Add("ci", fmt.Errorf("unknown CI system '%s' (use %s)", ci, strings.Join(scaffold.CIProviders, ", ")))
+		}
+		docker, _ := cmd.Flags().GetBool("docker")
+		var remote *runner.Remote
+		if createRemote != "" {
+			parsed, err := runner.ParseRemote(createRemote)
+			problems.Add("create-remote", err)
+			switch {
+			case finalSkipGit:
+				problems.Add("create-remote", fmt.Errorf("--create-remote pushes the initial commit, so it cannot be used with --skip-git"))
+			case offline:
+				problems.Add("create-remote", fmt.Errorf("--create-remote needs network access, so it cannot be used with --offline"))
+			case err == nil:
+				parsed.Name = projectName
+				parsed.Public, _ = cmd.Flags().GetBool("public")
+				remote = &parsed
 			}
-			return err
 		}
+		problems.Add("features", scaffold.ValidateFeatures(finalTemplate, features))
 		
-		// Validate module path (same for both modes)
-		if err := validator.ValidateModulePath(finalModulePath); err != nil {
-			if validationErr, ok := err.(*validation.ValidationError); ok {
-				logger.ValidationError(validationErr.Field, validationErr.Value, validationErr.Message, validationErr.Suggestions)
-				return fmt.Errorf("invalid module path")
+		// Collect values for the variables declared in the template's template.yml
+		var templateValues map[string]string
+		manifest, err := scaffold.ProjectTemplateManifest(finalTemplate)
+		if err != nil {
+			problems.Add("template", err)
+		} else if framework, _ := cmd.Flags().GetString("framework"); framework != "" {
+			// --framework is short for --var framework=<name>
+			if _, ok := manifest.Variable("framework"); !ok {
+				problems.Add("framework", fmt.Errorf("the %s template has no framework choice; --framework works with the default template", finalTemplate))
+			} else {
+				cmd.Flags().Set("var", "framework="+framework)
+			}
+		}
+		if orm, _ := cmd.Flags().GetString("orm"); orm != "" && manifest != nil {
+			// --orm is short for --var orm=<name>
+			if _, ok := manifest.Variable("orm"); !ok {
+				problems.Add("orm", fmt.Errorf("the %s template has no data access choice; --orm works with the default template", finalTemplate))
+			} else {
+				cmd.Flags().Set("var", "orm="+orm)
+			}
+		}
+		if loggerName, _ := cmd.Flags().GetString("logger"); loggerName != "" && manifest != nil {
+			// --logger is short for --var logger=<name>
+			if _, ok := manifest.Variable("logger"); !ok {
+				problems.Add("logger", fmt.Errorf("the %s template has no logging library choice; --logger works with the default template", finalTemplate))
+			} else {
+				cmd.Flags().Set("var", "logger="+loggerName)
+			}
+		}
+		if manifest != nil {
+			templateValues, err = templateVars(cmd, manifest.Variables, useInteractive)
+			if err != nil && useInteractive {
+				return err
+			}
+			problems.Add("vars", err)
+			if err == nil {
+				problems.Add("vars", manifest.CheckValues(templateValues))
 			}
-			return err
 		}
 		
-		// Check if directory already exists
-		if err := checkDirectoryExists(projectName); err != nil {
+		if err := problems.Err(); err != nil {
+			validation.Report(err)
+			return exitcode.Errorf(exitcode.Validation, "invalid project options")
+		}
+		
+		if targetDir == "" {
+			targetDir = projectName
+			if dir != "" {
+				targetDir = filepath.Clean(dir)
+			}
+		}
+		
+		// Existing directories are scaffolded into; creation lists the
+		// files it would overwrite
+		existed, err := checkTargetDirectory(targetDir)
+		if err != nil {
 			logger.Error("❌ %v", err)
 			return fmt.Errorf("directory conflict")
 		}
 		
 		// Get absolute path for the new project directory
-		destPath, err := filepath.Abs(projectName)
+		destPath, err := filepath.Abs(targetDir)
 		if err != nil {
 			logger.Error("Failed to determine absolute path for project")
 			return fmt.Errorf("could not determine absolute path for project: %w", err)
@@ -217,11 +415,30 @@ Examples:
 			Template:    finalTemplate,
 			SkipGit:     finalSkipGit,
 			Verbose:     finalVerbose,
+			Vars:        templateValues,
+			License:     license,
+			Author:      finalAuthor,
+			Description: finalDescription,
+			Repository:  finalRepository,
+			CI:          ci,
+			Docker:      docker,
+			Features:    features,
+			Force:       force,
+			SkipTidy:    skipTidy,
+			Offline:     offline,
+			Version:     version,
+		}
+		
+		if profileCreate, _ := cmd.Flags().GetBool("profile-create"); profileCreate {
+			scaffoldOptions.Profile = scaffold.NewProfile()
 		}
 		
+		// Go commands resolve modules from the module cache only
+		runner.SetOffline(offline)
+		
 		if err := scaffold.CreateProjectWithOptions(scaffoldOptions); err != nil {
-			// Clean up on failure
-			if _, statErr := os.Stat(destPath); statErr == nil {
+			// Clean up on failure, leaving directories that held files alone
+			if _, statErr := os.Stat(destPath); statErr == nil && !existed {
 				logger.Debug("Cleaning up failed project creation...")
 				os.RemoveAll(destPath)
 			}
@@ -230,19 +447,161 @@ Examples:
 			return fmt.Errorf("failed to create project: %w", err)
 		}
 		
+		// Code generation needs the dependencies, so without tidy it is
+		// left to the user along with the tidy itself
+		var deferred []string
+		if offline || skipTidy {
+			deferred = deferredSteps(destPath, graphql, offline)
+		} else {
+			// Templates with a GraphQL schema need their generated code before
+			// the project builds; --graphql adds one to the others
+			if _, err := os.Stat(filepath.Join(destPath, "gqlgen.yml")); err == nil {
+				if err := generateGraphQL(destPath); err != nil {
+					logger.Warn("⚠️  Could not generate the GraphQL code: %v", err)
+					logger.Info("💡 Generate it later with: %s", inDir(targetDir, "goforge run graphql:generate"))
+				}
+			} else if graphql {
+				if err := setupGraphQL(destPath); err != nil {
+					logger.Warn("⚠️  Could not set up GraphQL: %v", err)
+					logger.Info("💡 Set it up later with: %s", inDir(targetDir, "goforge g resolver"))
+				}
+			}
+		
+			// Templates with protobuf services need their Go code before the
+			// project builds
+			if _, err := os.Stat(filepath.Join(destPath, "buf.gen.yaml")); err == nil {
+				if err := setupProto(destPath); err != nil {
+					logger.Warn("⚠️  Could not generate the protobuf code: %v", err)
+					logger.Info("💡 Generate it later with: %s", inDir(targetDir, "goforge proto generate"))
+				}
+			}
+		
+			// Templates with templ components need their Go code before the
+			// project builds
+			if scaffold.HasTemplComponents(destPath) {
+				logger.Info("")
+				logger.Info("🧩 Generating templ components...")
+				if err := scaffold.GenerateTemplCode(destPath); err != nil {
+					logger.Warn("⚠️  Could not generate the templ components: %v", err)
+					logger.Info("💡 Generate them later with: %s", inDir(targetDir, "goforge run templ:generate"))
+				}
+			}
+
+			// Projects on ent need the generated client before they build
+			if scaffold.HasEntSchema(destPath) {
+				logger.Info("")
+				logger.Info("🗄️  Generating the ent client...")
+				if err := scaffold.GenerateEntCode(destPath); err != nil {
+					logger.Warn("⚠️  Could not generate the ent client: %v", err)
+					logger.Info("💡 Generate it later with: %s", inDir(targetDir, "goforge run ent:generate"))
+				}
+			}
+		}
+		
+		// The remote gets the initial commit
+		if remote != nil {
+			logger.Info("")
+			logger.Info("🌐 Creating the %s repository %s...", remote.Host, remote.Name)
+			webURL, err := runner.CreateRemote(destPath, *remote)
+			if err != nil {
+				logger.Warn("⚠️  Could not set up the remote repository: %v", err)
+			} else {
+				logger.Success("✅ Pushed the initial commit to %s", webURL)
+			}
+		}
+		
 		// Calculate total time
 		duration := time.Since(startTime)
 		logger.ProjectCreationComplete(projectName, duration)
 		
+		if scaffoldOptions.Profile != nil {
+			showCreateProfile(scaffoldOptions.Profile, destPath)
+		}
+		
 		// Show additional information
-		showPostCreationInfo(projectName, finalModulePath, destPath)
+		showPostCreationInfo(projectName, finalModulePath, destPath, targetDir)
+		showFeatureUsage(features)
+		showDeferredSteps(deferred, targetDir)
 		
 		return nil
 	},
 }
 
+// setupGraphQL adds gqlgen and resolvers for the template's services to the
+// new project, as 'goforge g resolver' run inside it would
+func setupGraphQL(destPath string) error {
+	logger.Info("")
+	logger.Info("🕸️  Setting up GraphQL...")
+	
+	previous, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(destPath); err != nil {
+		return err
+	}
+	defer os.Chdir(previous)
+	
+	return scaffold.SetupGraphQL(scaffold.GenerateOptions{OnConflict: scaffold.ConflictSkip})
+}
+
+// generateGraphQL generates the Go code for the new project's GraphQL
+// schema, as 'goforge run graphql:generate' run inside it would
+func generateGraphQL(destPath string) error {
+	logger.Info("")
+	logger.Info("🕸️  Generating GraphQL code...")
+	
+	previous, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(destPath); err != nil {
+		return err
+	}
+	defer os.Chdir(previous)
+	
+	return scaffold.GenerateGraphQLCode()
+}
+
+// setupProto generates the Go code for the new project's .proto files, as
+// 'goforge proto generate' run inside it would
+func setupProto(destPath string) error {
+	logger.Info("")
+	logger.Info("🧬 Generating protobuf code...")
+	
+	previous, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(destPath); err != nil {
+		return err
+	}
+	defer os.Chdir(previous)
+	
+	_, err = scaffold.GenerateProtoCode(scaffold.ProtoOptions{})
+	return err
+}
+
+// showCreateProfile prints the phase breakdown and saves it in the project
+func showCreateProfile(profile *scaffold.Profile, destPath string) {
+	profile.Finish()
+	
+	logger.Info("⏱️  Creation profile:")
+	for _, line := range strings.Split(strings.TrimRight(profile.Report(), "\n"), "\n") {
+		logger.Info("   %s", line)
+	}
+	
+	path, err := profile.WriteReport(destPath)
+	if err != nil {
+		logger.Warn("Failed to write profile report: %v", err)
+		return
+	}
+	logger.Info("   Report saved to %s", path)
+	logger.Info("")
+}
+
 // showPostCreationInfo displays helpful information after project creation
-func showPostCreationInfo(projectName, modulePath string, destPath string) {
+func showPostCreationInfo(projectName, modulePath string, destPath, targetDir string) {
 	logger.Info("📋 Project Information:")
 	logger.Info("   Name: %s", projectName)
 	logger.Info("   Module: %s", modulePath)
@@ -257,23 +616,197 @@ func showPostCreationInfo(projectName, modulePath string, destPath string) {
 	logger.Info("")
 	
 	logger.Info("📚 Quick Start:")
-	logger.Info("   cd %s                # Navigate to project", projectName)
+	if targetDir != "." {
+		logger.Info("   cd %s                # Navigate to project", targetDir)
+	}
 	logger.Info("   goforge run dev      # Start coding!")
 }
 
+// wizardTemplates lists the project templates, packs included, for the
+// interactive wizard.
+func wizardTemplates() []interactive.Template {
+	infos, err := scaffold.ListTemplates("")
+	if err != nil {
+		return nil
+	}
+	var templates []interactive.Template
+	for _, info := range infos {
+		if info.Kind == scaffold.KindProject {
+			templates = append(templates, interactive.Template{Name: info.Name, Description: info.Description})
+		}
+	}
+	return templates
+}
+
+// wizardFeatures lists the features available for template.
+func wizardFeatures(template string) []interactive.Template {
+	var features []interactive.Template
+	for _, name := range scaffold.Features {
+		if scaffold.ValidateFeatures(template, []string{name}) != nil {
+			continue
+		}
+		feature, err := scaffold.LoadFeature(name)
+		if err == nil {
+			features = append(features, interactive.Template{Name: name, Description: feature.Description})
+		}
+	}
+	return features
+}
+
+// wizardVariables asks for the variables declared by template.
+func wizardVariables(template string) []interactive.Question {
+	manifest, err := scaffold.ProjectTemplateManifest(template)
+	if err != nil {
+		return nil
+	}
+	var questions []interactive.Question
+	for _, v := range manifest.Variables {
+		questions = append(questions, variableQuestion(v))
+	}
+	return questions
+}
+
+// deferredSteps lists, in order, the commands that finish a project
+// created with --offline or --skip-tidy: code generation the other code
+// imports, tidy in every module, then the generators that need the
+// dependencies.
+func deferredSteps(destPath string, graphql, offline bool) []string {
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(destPath, name))
+		return err == nil
+	}
+
+	var steps []string
+	if offline && exists("sqlc.yaml") {
+		steps = append(steps, "goforge run sqlc:generate")
+	}
+	steps = append(steps, "go mod tidy")
+	if ws, err := project.FindWorkspace(destPath); err == nil && exists("go.work") {
+		for _, dir := range ws.Modules {
+			if dir != "." {
+				steps = append(steps, fmt.Sprintf("(cd %s && go mod tidy)", dir))
+			}
+		}
+	}
+	if exists("gqlgen.yml") {
+		steps = append(steps, "goforge run graphql:generate")
+	} else if graphql {
+		steps = append(steps, "goforge g resolver")
+	}
+	if exists("buf.gen.yaml") {
+		steps = append(steps, "goforge proto generate")
+	}
+	if scaffold.HasTemplComponents(destPath) {
+		steps = append(steps, "goforge run templ:generate")
+	}
+	if scaffold.HasEntSchema(destPath) {
+		steps = append(steps, "goforge run ent:generate")
+	}
+	return steps
+}
+
+// showDeferredSteps shows the commands left to run once the network is
+// available
+func showDeferredSteps(steps []string, targetDir string) {
+	if len(steps) == 0 {
+		return
+	}
+	logger.Info("")
+	logger.Info("⏳ Deferred steps (run them once the dependencies can be downloaded):")
+	if targetDir != "." {
+		logger.Info("   cd %s", targetDir)
+	}
+	for _, step := range steps {
+		logger.Info("   %s", step)
+	}
+}
+
+// showFeatureUsage shows how to use the packages of the --features
+// overlays
+func showFeatureUsage(features []string) {
+	if len(features) == 0 {
+		return
+	}
+	logger.Info("")
+	logger.Info("🧩 Features:")
+	for _, name := range features {
+		feature, err := scaffold.LoadFeature(name)
+		if err != nil {
+			continue
+		}
+		for _, usage := range feature.Usage {
+			logger.Info("   %s", usage)
+		}
+	}
+}
+
 func init() {
 	// Enhanced flags with better descriptions
 	newCmd.Flags().StringP("module-path", "m", "", 
 		"Go module path (e.g., github.com/user/repo)")
 	
 	newCmd.Flags().StringP("template", "t", "default", 
-		"Project template to use (default, minimal, microservice)")
+		"Project template to use (default, graphql, grpc, cli, worker, lambda, library, workspace, cqrs, web, or <pack>/<template> from an installed pack)")
 	
 	newCmd.Flags().BoolP("skip-git", "", false, 
 		"Skip Git repository initialization")
 	
-	newCmd.Flags().BoolP("verbose", "v", false, 
-		"Enable verbose logging")
+	newCmd.Flags().StringArray("var", nil, 
+		"Set a variable declared in the template's template.yml (key=value, repeatable)")
+	
+	newCmd.Flags().Bool("profile-create", false, 
+		"Time each creation phase (render, mod init, tidy, git) and save a report")
+	
+	newCmd.Flags().String("framework", "", 
+		"HTTP framework of the default template: "+strings.Join(scaffold.Frameworks, ", ")+" (gin when omitted)")
+
+	newCmd.Flags().String("orm", "",
+		"Data access style of the default template: "+strings.Join(scaffold.ORMs, ", ")+" (pgx when omitted)")
+
+	newCmd.Flags().String("logger", "",
+		"Logging library of the default template: "+strings.Join(scaffold.Loggers, ", ")+" (slog when omitted)")
+
+	newCmd.Flags().String("license", "",
+		"LICENSE file to write: "+strings.Join(scaffold.Licenses, ", ")+" (license in the user config, or MIT, when omitted)")
+
+	newCmd.Flags().String("description", "",
+		"One-line summary of the project for the README and goforge.yml")
+
+	newCmd.Flags().String("author", "",
+		"Copyright holder of the project (author in the user config, or git's user.name, when omitted)")
+
+	newCmd.Flags().String("repository", "",
+		"URL of the project's source repository (derived from GitHub, GitLab, Bitbucket, and Codeberg module paths when omitted)")
+
+	newCmd.Flags().String("ci", "",
+		"CI pipeline to write: "+strings.Join(scaffold.CIProviders, ", ")+" (none when omitted)")
+
+	newCmd.Flags().StringSlice("features", nil,
+		"Feature overlays to add on top of the template: "+strings.Join(scaffold.Features, ", "))
+
+	newCmd.Flags().Bool("docker", false,
+		"Write a multi-stage Dockerfile, .dockerignore, and docker-compose.yml with the project's database")
+
+	newCmd.Flags().String("dir", "",
+		"Directory to create the project in, which may already exist (the project name when omitted)")
+
+	newCmd.Flags().Bool("force", false,
+		"Overwrite the files of an existing directory that the project also has")
+
+	newCmd.Flags().Bool("skip-tidy", false,
+		"Skip 'go mod tidy' and the code generation needing it; the deferred steps are printed")
+
+	newCmd.Flags().Bool("offline", false,
+		"Create the project without network access: like --skip-tidy, with go commands on GOPROXY=off")
+
+	newCmd.Flags().String("create-remote", "",
+		"Create the repository on github[:org] or gitlab[:group] with gh/glab or GITHUB_TOKEN/GITLAB_TOKEN, add it as origin, and push")
+
+	newCmd.Flags().Bool("public", false,
+		"Make the repository of --create-remote public instead of private")
+
+	newCmd.Flags().Bool("graphql", false, 
+		"Set up a GraphQL API with gqlgen, with resolvers for the template's services")
 	
 	// NEW: Interactive mode flag
 	newCmd.Flags().BoolP("interactive", "i", false, 
@@ -292,6 +825,57 @@ func init() {
   # Create without Git initialization
   goforge new simple-app --skip-git
   
+  # Write the HTTP layer for Echo, Fiber, chi, or net/http instead of Gin
+  goforge new my-api --framework echo
+  
+  # Generate the repositories with GORM, sqlc, or ent instead of plain pgx
+  goforge new my-api --orm gorm
+  
+  # Log with zap or zerolog instead of log/slog
+  goforge new my-api --logger zerolog
+  
+  # License the project under Apache-2.0 (GPL-3.0, or none for no LICENSE)
+  goforge new my-api --license Apache-2.0
+  
+  # Build, test, and lint on every push with GitHub Actions, GitLab CI, or CircleCI
+  goforge new my-api --ci gitlab
+  
+  # Add JWT auth, Prometheus metrics, OpenTelemetry tracing, Swagger UI, and Redis
+  goforge new my-api --features auth,metrics,tracing,swagger,redis
+  
+  # Containerize the application and run it next to its database with Docker Compose
+  goforge new my-api --docker
+  
+  # Add a GraphQL API next to the REST one
+  goforge new graph-api --graphql
+  
+  # Create a GraphQL API instead of a REST API
+  goforge new blog-api -t graphql
+  
+  # Create a gRPC microservice instead of a REST API
+  goforge new greeter-svc -t grpc
+  
+  # Create a command-line tool
+  goforge new my-tool -t cli
+  
+  # Create a background worker consuming RabbitMQ
+  goforge new order-processor -t worker --var broker=rabbitmq
+  
+  # Create an AWS Lambda function (or a Cloud Function with --var provider=gcp)
+  goforge new thumbnailer -t lambda
+  
+  # Create a reusable library (no main package)
+  goforge new go-retry -t library
+  
+  # Create a go.work monorepo with services/ and shared pkg/
+  goforge new platform -t workspace
+  
+  # Create an event-driven API with CQRS and a transactional outbox
+  goforge new shop -t cqrs
+  
+  # Create a server-rendered web app with templ and htmx
+  goforge new site -t web
+  
   # Use interactive mode
   goforge new --interactive
   goforge new -i`
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// oidcCmd represents the command to generate OpenID Connect login.
+var oidcCmd = &cobra.Command{
+	Use:   "oidc",
+	Short: "Generate OpenID Connect login for browser-facing apps",
+	Long: `Generates an OpenID Connect login package (internal/platform/auth/oidc by
+default) using the authorization code flow with PKCE:
+
+  config.go      Config loaded from the oidc section of config/default.yml
+  session.go     Encrypted cookie sessions
+  handler.go     Login, callback, and logout handlers
+  middleware.go  RequireAuth middleware and CurrentSession helper
+
+The oidc keys are added to config/default.yml; the client and session
+secrets are read from OIDC_CLIENT_SECRET and OIDC_SESSION_SECRET. Required
+modules are recorded in the dependencies section of goforge.yml.
+
+Examples:
+  goforge g oidc
+  goforge g oidc --provider auth0 --issuer https://acme.eu.auth0.com/
+  goforge g oidc --provider google --client-id 1234.apps.googleusercontent.com
+  goforge g oidc --provider generic --issuer https://login.example.com --prefix /sso`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		provider, _ := cmd.Flags().GetString("provider")
+		issuer, _ := cmd.Flags().GetString("issuer")
+		clientID, _ := cmd.Flags().GetString("client-id")
+		prefix, _ := cmd.Flags().GetString("prefix")
+
+		return scaffold.GenerateOIDC(scaffold.OIDCOptions{
+			Provider: provider,
+			Issuer:   issuer,
+			ClientID: clientID,
+			Prefix:   prefix,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	oidcCmd.Flags().String("provider", scaffold.ProviderKeycloak, "Identity provider (keycloak, auth0, google, generic)")
+	oidcCmd.Flags().String("issuer", "", "Issuer URL (defaults to a placeholder for the provider)")
+	oidcCmd.Flags().String("client-id", "", "OAuth client ID (defaults to the project name)")
+	oidcCmd.Flags().String("prefix", "/auth", "Route prefix for the login, callback, and logout handlers")
+}
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// Values of the global --output flag.
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// jsonAnnotation marks the commands that report a result with --output
+// json; their subcommands inherit it.
+const jsonAnnotation = "goforge:json"
+
+// commandResult is what a command prints with --output json, in place of
+// its usual messages.
+type commandResult struct {
+	Command  string `json:"command"`
+	OK       bool   `json:"ok"`
+	Error    string `json:"error,omitempty"`
+	ExitCode int    `json:"exit_code,omitempty"`
+	Duration int64  `json:"duration_ms"`
+	Result   any    `json:"result,omitempty"`
+}
+
+// resultKey is the context key of the result a command reports.
+type resultKey struct{}
+
+// wantsJSON reports whether cmd runs with --output json, or the --json
+// flag some commands have.
+func wantsJSON(cmd *cobra.Command) bool {
+	if output, _ := cmd.Flags().GetString("output"); output == outputJSON && isGlobalOutputFlag(cmd) {
+		return true
+	}
+	asJSON, _ := cmd.Flags().GetBool("json")
+	return asJSON
+}
+
+// isGlobalOutputFlag reports whether --output is the global flag for cmd;
+// commands writing a file take --output for its path instead.
+func isGlobalOutputFlag(cmd *cobra.Command) bool {
+	return cmd.Flags().Lookup("output") == cmd.Root().PersistentFlags().Lookup("output")
+}
+
+// supportsJSON reports whether cmd or one of its parents reports a result
+// with --output json.
+func supportsJSON(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Annotations[jsonAnnotation] != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// reportJSON sets the result cmd prints with --output json.
+func reportJSON(cmd *cobra.Command, result any) {
+	if holder, ok := cmd.Context().Value(resultKey{}).(*any); ok {
+		*holder = result
+	}
+}
+
+// emitJSON runs commands given --output json with their messages and the
+// output of the tools they run sent to stderr, then prints their result
+// as a single JSON document on stdout. Commands with a --json flag of
+// their own get it set instead.
+func emitJSON(next runFunc) runFunc {
+	return func(cmd *cobra.Command, args []string) error {
+		if !isGlobalOutputFlag(cmd) {
+			return next(cmd, args)
+		}
+		output, _ := cmd.Flags().GetString("output")
+		switch {
+		case output == outputText:
+			return next(cmd, args)
+		case output != outputJSON:
+			return exitcode.Errorf(exitcode.Validation, "unknown output format '%s' (use %s or %s)", output, outputText, outputJSON)
+		case cmd.Flags().Lookup("json") != nil:
+			if err := cmd.Flags().Set("json", "true"); err != nil {
+				return err
+			}
+			return next(cmd, args)
+		case !supportsJSON(cmd):
+			return exitcode.Errorf(exitcode.Validation, "'%s' has no JSON output", cmd.CommandPath())
+		}
+
+		stdout := os.Stdout
+		os.Stdout = os.Stderr
+		logger.SetOutput(os.Stderr)
+		defer func() {
+			os.Stdout = stdout
+			logger.SetOutput(stdout)
+		}()
+
+		var result any
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		cmd.SetContext(context.WithValue(ctx, resultKey{}, &result))
+
+		start := time.Now()
+		err := next(cmd, args)
+		report := commandResult{
+			Command:  strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" "),
+			OK:       err == nil,
+			Duration: time.Since(start).Milliseconds(),
+			Result:   result,
+		}
+		if err != nil {
+			report.Error = err.Error()
+			report.ExitCode = int(exitcode.Of(err))
+		}
+		if result == nil && isGenerator(cmd) {
+			report.Result = generatedFiles()
+		}
+
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if encodeErr := enc.Encode(report); encodeErr != nil {
+			return encodeErr
+		}
+		return err
+	}
+}
+
+// isGenerator reports whether cmd is one of the 'goforge generate'
+// commands.
+func isGenerator(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c == generateCmd {
+			return true
+		}
+	}
+	return false
+}
+
+// generatedFiles is the result of the generators: the files they wrote,
+// relative to the current directory when possible.
+func generatedFiles() map[string][]string {
+	dir, _ := os.Getwd()
+	files := []string{}
+	for _, path := range scaffold.WrittenFiles() {
+		if rel, err := filepath.Rel(dir, path); err == nil && !strings.HasPrefix(rel, "..") {
+			path = rel
+		}
+		files = append(files, filepath.ToSlash(path))
+	}
+	return map[string][]string{"files": files}
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+// pluginAnnotation marks the commands registered for plugins.
+const pluginAnnotation = "goforge/plugin"
+
+// pluginsCmd lists the goforge-<name> executables found on PATH.
+var pluginsCmd = &cobra.Command{
+	Use:   "plugins",
+	Short: "List installed goforge plugins",
+	Long: `Plugins are executables named goforge-<name> anywhere on your PATH. Each
+one is available as 'goforge <name>', with all arguments passed through.
+
+A plugin receives the project context as JSON on stdin:
+
+  {"goforge_version": "...", "project_root": "...", "config": {...goforge.yml...}}
+
+and in the environment as GOFORGE_PROJECT_ROOT, GOFORGE_CONFIG (the path
+to goforge.yml), GOFORGE_VERSION, and GOFORGE_PLUGIN_NAME. Outside a
+project the root is empty and the config is null.
+
+Plugins cannot replace built-in commands; a plugin with the name of a
+built-in command is listed but ignored.
+
+Examples:
+  goforge plugins
+  goforge preview --env staging    # runs goforge-preview --env staging`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		found := plugins.Discover()
+		if len(found) == 0 {
+			logger.Info("No plugins found on PATH")
+			logger.Info("💡 Install an executable named %s<name> to add 'goforge <name>'", plugins.Prefix)
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tPATH\tSTATUS")
+		for _, plugin := range found {
+			status := "ok"
+			if isBuiltinCommand(plugin.Name) {
+				status = "ignored: conflicts with built-in command"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\n", plugin.Name, plugin.Path, status)
+			for _, path := range plugin.Shadowed {
+				fmt.Fprintf(w, "\t%s\tshadowed\n", path)
+			}
+		}
+		return w.Flush()
+	},
+}
+
+// registerPlugins adds a subcommand for every plugin on PATH that does not
+// clash with a built-in command.
+func registerPlugins() {
+	for _, plugin := range plugins.Discover() {
+		if isBuiltinCommand(plugin.Name) {
+			continue
+		}
+		rootCmd.AddCommand(pluginCommand(plugin))
+	}
+}
+
+// pluginCommand wraps a plugin as a cobra command that forwards all
+// arguments, flags included, to the executable.
+func pluginCommand(plugin *plugins.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                plugin.Name,
+		Short:              fmt.Sprintf("Plugin (%s)", plugin.Path),
+		DisableFlagParsing: true,
+		SilenceUsage:       true,
+		SilenceErrors:      true, // the plugin reports its own errors
+		Annotations:        map[string]string{pluginAnnotation: plugin.Path},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, err := plugins.NewContext(version, currentProjectRoot())
+			if err != nil {
+				return err
+			}
+			logger.Debug("Running plugin %s", plugin.Path)
+			return plugin.Run(ctx, args)
+		},
+	}
+}
+
+// isBuiltinCommand reports whether name is a built-in command or alias.
+func isBuiltinCommand(name string) bool {
+	if name == "help" || name == "completion" {
+		return true
+	}
+	for _, c := range rootCmd.Commands() {
+		if _, isPlugin := c.Annotations[pluginAnnotation]; isPlugin {
+			continue
+		}
+		if c.Name() == name || c.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
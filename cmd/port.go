@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/night-slayer18/goforge/internal/project"
 	"github.com/night-slayer18/goforge/internal/scaffold"
 	"github.com/spf13/cobra"
 )
@@ -13,6 +14,24 @@ var portCmd = &cobra.Command{
 	Args:    cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		return scaffold.GenerateComponent("port", name)
+		if err := generateComponent(cmd, "port", name); err != nil {
+			return err
+		}
+
+		mock, _ := cmd.Flags().GetBool("mock")
+		if !mock {
+			if cfg, _, err := project.LoadConfig(); err == nil && cfg.Mocks != nil {
+				mock = cfg.Mocks.Auto
+			}
+		}
+		if !mock {
+			return nil
+		}
+		path, _ := cmd.Flags().GetString("path")
+		return scaffold.GenerateMock(name, scaffold.MockOptions{Path: path})
 	},
-}
\ No newline at end of file
+}
+
+func init() {
+	portCmd.Flags().Bool("mock", false, "Also generate mocks for the port (see 'goforge g mock')")
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/profiling"
+	"github.com/spf13/cobra"
+)
+
+// profileStartTimeout bounds how long a started server may take to serve
+// its pprof endpoints.
+const profileStartTimeout = time.Minute
+
+// profileCmd represents the command to capture profiles of the server.
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Capture CPU, heap, and goroutine profiles of the running server",
+	Long: `Captures profiles from the server's net/http/pprof endpoints, saves them
+under ` + profiling.Dir + `/<time>, and opens the first in 'go tool pprof -http'.
+
+The CPU profile samples for --seconds, so put the server under load
+meanwhile; the other profiles are snapshots taken at the end. Types are
+` + strings.Join(profiling.Types, ", ") + `.
+
+When nothing answers at the endpoints, the 'dev' script (or --script) is
+started, profiled, and stopped again; --no-start only attaches to a
+running server. The endpoints are at profile.url of goforge.yml, by
+default ` + profiling.DefaultURL + `, which the server serves with:
+
+  import _ "net/http/pprof"
+
+  go http.ListenAndServe("localhost:6060", nil)
+
+Examples:
+  goforge profile
+  goforge profile --seconds 10 --types cpu
+  goforge profile --url http://localhost:8080/debug/pprof --no-start
+  goforge profile --no-open`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+		url, _ := cmd.Flags().GetString("url")
+		if url == "" && cfg.Profile != nil {
+			url = cfg.Profile.URL
+		}
+		if url == "" {
+			url = profiling.DefaultURL
+		}
+		seconds, _ := cmd.Flags().GetInt("seconds")
+		types, _ := cmd.Flags().GetStringSlice("types")
+		scriptName, _ := cmd.Flags().GetString("script")
+		noStart, _ := cmd.Flags().GetBool("no-start")
+		noOpen, _ := cmd.Flags().GetBool("no-open")
+		verbose := logger.Verbose()
+		if seconds < 1 {
+			return fmt.Errorf("--seconds must be at least 1")
+		}
+		if len(types) == 0 {
+			return fmt.Errorf("no profile types given: use %s", strings.Join(profiling.Types, ", "))
+		}
+		for _, kind := range types {
+			if !slices.Contains(profiling.Types, kind) {
+				return fmt.Errorf("unknown profile type '%s': use %s", kind, strings.Join(profiling.Types, ", "))
+			}
+		}
+
+		stopServer := func() {}
+		if err := profiling.Check(url); err != nil {
+			if noStart || errors.Is(err, profiling.ErrNoEndpoint) {
+				return fmt.Errorf("%w\n\nServe the endpoints with net/http/pprof, or set profile.url in goforge.yml (see 'goforge profile --help')", err)
+			}
+			script, exists := cfg.Scripts[scriptName]
+			if !exists {
+				return fmt.Errorf("nothing answers at %s and script '%s' not found in goforge.yml to start the server", url, scriptName)
+			}
+
+			logger.Info("🚀 Nothing answers at %s; starting '%s'...", url, scriptName)
+			pm := NewProcessManager(projectRoot, script, verbose)
+			exited := make(chan struct{})
+			pm.onExit = func(error, time.Duration) { close(exited) }
+			if err := pm.Start(); err != nil {
+				return err
+			}
+			stopServer = func() { pm.Stop() }
+			defer stopServer()
+			if err := profiling.WaitFor(url, profileStartTimeout, exited); err != nil {
+				return err
+			}
+		}
+
+		dir := filepath.Join(projectRoot, profiling.Dir, time.Now().Format("20060102-150405"))
+		var files []string
+		for _, kind := range types {
+			if kind == "cpu" {
+				logger.Info("⏱️  Capturing the CPU profile for %ds; put the server under load now...", seconds)
+			}
+			path := filepath.Join(dir, kind+".pprof")
+			if err := profiling.Capture(url, kind, seconds, path); err != nil {
+				return err
+			}
+			files = append(files, path)
+		}
+		stopServer()
+
+		logger.Println("\n📁 Profiles:")
+		for _, path := range files {
+			rel, _ := filepath.Rel(projectRoot, path)
+			fmt.Printf("  %s\n", rel)
+		}
+		if noOpen {
+			fmt.Printf("\nOpen one with: go tool pprof -http=localhost:0 %s\n", files[0])
+			return nil
+		}
+
+		logger.Printf("\n🔬 Opening %s in pprof (Ctrl+C to quit)...\n", filepath.Base(files[0]))
+		pprof := exec.Command("go", "tool", "pprof", "-http=localhost:0", files[0])
+		pprof.Dir = projectRoot
+		pprof.Stdin = os.Stdin
+		pprof.Stdout = os.Stdout
+		pprof.Stderr = os.Stderr
+		return pprof.Run()
+	},
+}
+
+func init() {
+	profileCmd.Flags().String("url", "", "Base URL of the pprof endpoints (default profile.url or "+profiling.DefaultURL+")")
+	profileCmd.Flags().Int("seconds", 30, "How long to sample the CPU profile")
+	profileCmd.Flags().StringSlice("types", profiling.DefaultTypes, "Profiles to capture")
+	profileCmd.Flags().String("script", "dev", "Script starting the server when none is running")
+	profileCmd.Flags().Bool("no-start", false, "Only attach to a running server")
+	profileCmd.Flags().Bool("no-open", false, "Save the profiles without opening pprof")
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// protoCmd groups the protobuf commands.
+var protoCmd = &cobra.Command{
+	Use:   "proto",
+	Short: "Work with the project's protobuf definitions",
+}
+
+// protoGenerateCmd generates Go code from the .proto files.
+var protoGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate Go code from the project's .proto files",
+	Long: `Generates Go messages and gRPC stubs into gen/proto for every .proto file
+under api/proto. buf is used by default, driven by buf.gen.yaml; buf,
+protoc-gen-go and protoc-gen-go-grpc are installed with 'go install' when
+missing. Use --protoc to run protoc (which must be on PATH) instead.
+
+Examples:
+  goforge proto generate
+  goforge proto generate --protoc`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		useProtoc, _ := cmd.Flags().GetBool("protoc")
+
+		count, err := scaffold.GenerateProtoCode(scaffold.ProtoOptions{Protoc: useProtoc})
+		if err != nil {
+			return err
+		}
+		logger.Success("✅ Generated Go code for %d .proto file(s)", count)
+		return nil
+	},
+}
+
+func init() {
+	protoGenerateCmd.Flags().Bool("protoc", false, "Generate with protoc instead of buf")
+
+	protoCmd.AddCommand(protoGenerateCmd)
+}
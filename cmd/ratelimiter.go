@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// ratelimiterCmd represents the command to generate the shared rate limiting package.
+var ratelimiterCmd = &cobra.Command{
+	Use:     "ratelimiter",
+	Short:   "Generate a shared rate limiting package",
+	Aliases: []string{"rl"},
+	Long: `Generates a rate limiting package (internal/platform/ratelimit by default)
+containing:
+
+  limiter.go     Limiter port, rules, and per-route configuration
+  memory.go      In-memory token bucket and sliding window backend
+  redis.go       Redis backend shared across replicas (--backend redis)
+  middleware.go  Gin middleware setting X-RateLimit-* and Retry-After headers
+
+Required modules are recorded in the dependencies section of goforge.yml.
+
+Examples:
+  goforge g ratelimiter
+  goforge g ratelimiter --strategy sliding-window --rate 60 --window 1m
+  goforge g ratelimiter --backend redis --rate 100 --window 1s --burst 200`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		strategy, _ := cmd.Flags().GetString("strategy")
+		backend, _ := cmd.Flags().GetString("backend")
+		rate, _ := cmd.Flags().GetInt("rate")
+		window, _ := cmd.Flags().GetDuration("window")
+		burst, _ := cmd.Flags().GetInt("burst")
+
+		return scaffold.GenerateRateLimiter(scaffold.RateLimiterOptions{
+			Strategy: strategy,
+			Backend:  backend,
+			Rate:     rate,
+			Window:   window,
+			Burst:    burst,
+		}, componentOptions(cmd))
+	},
+}
+
+func init() {
+	ratelimiterCmd.Flags().String("strategy", scaffold.StrategyTokenBucket, "Default limiting strategy (token-bucket, sliding-window)")
+	ratelimiterCmd.Flags().String("backend", scaffold.BackendMemory, "Storage backend (memory, redis)")
+	ratelimiterCmd.Flags().Int("rate", 100, "Requests allowed per window")
+	ratelimiterCmd.Flags().Duration("window", time.Minute, "Window the rate applies to")
+	ratelimiterCmd.Flags().Int("burst", 0, "Token bucket capacity (defaults to --rate)")
+}
@@ -10,9 +10,39 @@ var repositoryCmd = &cobra.Command{
 	Use:     "repository <name>",
 	Short:   "Generate a new repository",
 	Aliases: []string{"repo", "r"},
-	Args:    cobra.ExactArgs(1),
+	Long: `Generates a PostgreSQL repository skeleton implementing the model's port,
+or with --with a complete implementation on a query layer:
+
+  sqlc      Writes the queries to db/queries/<table>.sql, adds them to
+            sqlc.yaml, runs sqlc generate and maps the generated rows to
+            the domain model
+  squirrel  Builds the queries with github.com/Masterminds/squirrel
+
+Both read the columns from the fields of the domain model, which must
+exist, and add a migration creating its table unless one already does.
+Projects created with --orm sqlc use sqlc unless --with says otherwise;
+in --orm gorm and ent projects the skeleton uses GORM or ent, and for ent
+also adds ent/schema/<name>.go and regenerates the client.
+
+Examples:
+  goforge g repository user
+  goforge g repository user --with sqlc
+  goforge g repository order --with squirrel`,
+	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
-		return scaffold.GenerateComponent("repository", name)
+		with, _ := cmd.Flags().GetString("with")
+		if !cmd.Flags().Changed("with") {
+			with = scaffold.DefaultRepositoryLayer()
+		}
+		if with == "" {
+			return generateComponent(cmd, "repository", name)
+		}
+
+		return scaffold.GenerateRepositoryWith(with, name, componentOptions(cmd))
 	},
-}
\ No newline at end of file
+}
+
+func init() {
+	repositoryCmd.Flags().String("with", "", "Implement the repository on a query layer: sqlc or squirrel")
+}
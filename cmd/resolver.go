@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// resolverCmd represents the command to generate GraphQL resolvers.
+var resolverCmd = &cobra.Command{
+	Use:     "resolver [name]",
+	Aliases: []string{"graphql"},
+	Short:   "Generate a GraphQL schema and gqlgen resolvers",
+	Long: `Generates a GraphQL schema file and its resolvers with gqlgen:
+
+  internal/adapters/graphql/schema/<name>.graphqls
+                                       Type, input, queries and mutations
+  internal/adapters/graphql/<name>.resolvers.go
+                                       Resolver skeletons
+
+When the project has a <name> service, it is added to the Resolver so the
+resolvers can call it. Without a name, schema files and resolvers are
+generated for every existing service.
+
+The first run also creates gqlgen.yml, the root schema, the Resolver, an
+HTTP handler with the playground, and the 'graphql:generate' script, then
+runs gqlgen. Rerun 'goforge run graphql:generate' after editing the schema;
+resolver implementations are kept.
+
+Examples:
+  goforge g resolver              # every existing service
+  goforge g resolver user
+  goforge g resolver order-item`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		genOptions := componentOptions(cmd)
+		if len(args) == 0 {
+			return scaffold.SetupGraphQL(genOptions)
+		}
+		return scaffold.GenerateResolver(args[0], genOptions)
+	},
+}
@@ -1,9 +1,13 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"strings"
 
+	"github.com/night-slayer18/goforge/internal/exitcode"
 	"github.com/spf13/cobra"
 )
 
@@ -26,23 +30,92 @@ Interactive Mode:
 }
 
 func Execute() {
-	if err := rootCmd.Execute(); err!= nil {
-		fmt.Println(err)
-		os.Exit(1)
+	registerPlugins()
+	wrapCommands(rootCmd)
+	finish := recordInvocation(os.Args[1:])
+	report := startTelemetry()
+	cmd, err := execute(os.Args[1:])
+	report(cmd, err)
+	if err == nil {
+		finish(0, nil)
+		return
 	}
+
+	// A failing plugin or redone command has already reported its error;
+	// keep its exit code.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+		finish(exitErr.ExitCode(), err)
+		os.Exit(exitErr.ExitCode())
+	}
+
+	// Other errors exit with the status of their kind, see exitcode
+	code := int(exitcode.Of(err))
+	finish(code, err)
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(code)
+}
+
+// execute runs the command args name and returns it with its error.
+func execute(args []string) (*cobra.Command, error) {
+	rootCmd.SetArgs(args)
+	cmd, err := rootCmd.ExecuteC()
+	if err != nil && cmd == rootCmd && strings.HasPrefix(err.Error(), "unknown command") {
+		// cobra's error for a mistyped command; unknownSubcommand covers
+		// the subcommands
+		err = exitcode.Wrap(exitcode.Validation, err)
+	}
+	return cmd, err
 }
 
 func init() {
 	rootCmd.SetVersionTemplate(`{{printf "GoForge CLI Version: %s\n" .Version}}`)
+	rootCmd.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return exitcode.Wrap(exitcode.Validation, err)
+	})
 	rootCmd.AddCommand(newCmd)
 	rootCmd.AddCommand(generateCmd)
 	rootCmd.AddCommand(runCmd)
 	rootCmd.AddCommand(addCmd)
 	rootCmd.AddCommand(buildCmd)
+	rootCmd.AddCommand(testCmd)
+	rootCmd.AddCommand(lintCmd)
+	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(checkCmd)
+	rootCmd.AddCommand(benchCmd)
+	rootCmd.AddCommand(profileCmd)
+	rootCmd.AddCommand(migrateCmd)
+	rootCmd.AddCommand(dbCmd)
+	rootCmd.AddCommand(composeCmd)
+	rootCmd.AddCommand(deployCmd)
+	rootCmd.AddCommand(exportCmd)
 	rootCmd.AddCommand(watchCmd)    
 	rootCmd.AddCommand(updateCmd) 
 	rootCmd.AddCommand(cleanCmd) 
+	rootCmd.AddCommand(devCmd)
+	rootCmd.AddCommand(gitattributesCmd)
+	rootCmd.AddCommand(templatesCmd)
+	rootCmd.AddCommand(benchScaffoldCmd)
+	rootCmd.AddCommand(pluginsCmd)
+	rootCmd.AddCommand(supportBundleCmd)
+	rootCmd.AddCommand(mocksCmd)
+	rootCmd.AddCommand(historyCmd)
+	rootCmd.AddCommand(redoCmd)
+	rootCmd.AddCommand(healthCmd)
+	rootCmd.AddCommand(protoCmd)
+	rootCmd.AddCommand(docsCmd)
+	rootCmd.AddCommand(upgradeTemplateCmd)
+	rootCmd.AddCommand(configCmd)
+	rootCmd.AddCommand(infoCmd)
+	rootCmd.AddCommand(telemetryCmd)
 	
 	// Add global flags
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose logging")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Only log warnings and errors")
+	rootCmd.PersistentFlags().String("log-level", "info", "Log level: debug, info, warn, or error")
+	rootCmd.PersistentFlags().StringP("chdir", "C", "", "Run as if goforge was started in this directory")
+	rootCmd.PersistentFlags().String("output", outputText, "Output format: text, or json for a machine-readable result (build, test, info, generate)")
+	rootCmd.PersistentFlags().String("log-format", "text", "Log format: text, or json for a JSON object per message")
+	rootCmd.PersistentFlags().Bool("no-color", false, "Disable colored output (also set by NO_COLOR)")
+	rootCmd.PersistentFlags().Bool("plain", false, "Plain output without colors, emoji, or spinners, for CI logs and screen readers")
 }
\ No newline at end of file
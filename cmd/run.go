@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"fmt"
+	"path/filepath"
+	"strings"
 
+	"github.com/night-slayer18/goforge/internal/logger"
 	"github.com/night-slayer18/goforge/internal/project"
 	"github.com/night-slayer18/goforge/internal/runner"
+	"github.com/night-slayer18/goforge/internal/sandbox"
 	"github.com/spf13/cobra"
 )
 
@@ -13,20 +17,53 @@ var runCmd = &cobra.Command{
 	Use:   "run <script-name>",
 	Short: "Run a custom script defined in goforge.yml",
 	Long: `Executes a command from the 'scripts' section of your project's goforge.yml file.
-This is analogous to 'npm run <script-name>' in the Node.js ecosystem.`,
+This is analogous to 'npm run <script-name>' in the Node.js ecosystem.
+In a service of a go.work workspace, scripts the service does not define
+are looked up in the workspace's goforge.yml and run from its root;
+--service, or a <service>:<script> name that is not a script itself, runs
+the script of a service from anywhere in the workspace.
+
+With --sandbox the script runs in a temporary copy of the project, with its
+own PORT and data directory (GOFORGE_DATA_DIR), so destructive scripts such
+as database resets or codegen experiments leave the working tree untouched.
+Afterwards the changed files are listed; use --diff to see them and --apply
+to copy them back.
+
+Examples:
+  goforge run test
+  goforge run test --service api
+  goforge run api:dev              # the dev script of service api
+  goforge run --sandbox db:reset
+  goforge run --sandbox --diff --apply generate`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		scriptName := args[0]
 
 		// Load the project configuration to find the scripts.
-		cfg, projectRoot, err := project.LoadConfig()
-		if err!= nil {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
 			return err
 		}
 
 		scriptCommand, exists := cfg.Scripts[scriptName]
 		if!exists {
-			return fmt.Errorf("script '%s' not found in goforge.yml", scriptName)
+			scriptCommand, projectRoot, err = findWorkspaceScript(projectRoot, scriptName, cfg.Scripts)
+			if err != nil {
+				return err
+			}
+		}
+
+		sandboxed, _ := cmd.Flags().GetBool("sandbox")
+		for _, name := range []string{"diff", "apply", "keep"} {
+			if cmd.Flags().Changed(name) && !sandboxed {
+				return fmt.Errorf("--%s requires --sandbox", name)
+			}
+		}
+		if err := exportComposeEnv(projectRoot); err != nil {
+			return err
+		}
+		if sandboxed {
+			return runSandboxed(cmd, projectRoot, scriptName, scriptCommand)
 		}
 
 		fmt.Printf("▶️  Running script '%s': %s\n\n", scriptName, scriptCommand)
@@ -34,3 +71,106 @@ This is analogous to 'npm run <script-name>' in the Node.js ecosystem.`,
 		return runner.ExecuteScript(projectRoot, scriptCommand)
 	},
 }
+
+// findWorkspaceScript looks up a script the project at projectRoot does
+// not define, among its scripts, in its go.work workspace, returning the
+// command and the directory to run it in.
+func findWorkspaceScript(projectRoot, scriptName string, scripts map[string]string) (string, string, error) {
+	notFound := scriptNotFound(scriptName, scripts)
+	ws, err := project.FindWorkspace(projectRoot)
+	if err != nil {
+		return "", "", err
+	}
+	if ws == nil {
+		return "", "", notFound
+	}
+
+	// Services of a workspace also run the workspace's scripts, from its
+	// root
+	if !ws.IsWorkspaceRoot(projectRoot) && ws.Config != nil && ws.Config.Scripts[scriptName] != "" {
+		logger.Debug("Script '%s' is defined by the workspace at %s", scriptName, ws.Root)
+		return ws.Config.Scripts[scriptName], ws.Root, nil
+	}
+
+	// <service>:<script> runs a script of a service from anywhere in the
+	// workspace
+	if serviceName, script, ok := strings.Cut(scriptName, ":"); ok {
+		if service, err := ws.FindService(serviceName); err == nil {
+			if command, ok := service.Config.Scripts[script]; ok {
+				logger.Debug("Script '%s' is defined by service '%s'", script, service.Name)
+				return command, filepath.Join(ws.Root, filepath.FromSlash(service.Dir)), nil
+			}
+		}
+	}
+	return "", "", notFound
+}
+
+// runSandboxed runs a script in a temporary copy of the project and
+// reports, diffs, or applies the files it changed.
+func runSandboxed(cmd *cobra.Command, projectRoot, scriptName, scriptCommand string) error {
+	showDiff, _ := cmd.Flags().GetBool("diff")
+	apply, _ := cmd.Flags().GetBool("apply")
+	keep, _ := cmd.Flags().GetBool("keep")
+
+	sb, err := sandbox.New(projectRoot)
+	if err != nil {
+		return err
+	}
+	if keep {
+		defer logger.Info("📁 Sandbox kept at %s", sb.Root)
+	} else {
+		defer sb.Remove()
+	}
+
+	logger.Info("🧪 Sandbox: %s (PORT=%d)", sb.Root, sb.Port)
+	fmt.Printf("▶️  Running script '%s' in sandbox: %s\n\n", scriptName, scriptCommand)
+
+	opts := runner.DefaultOptions()
+	opts.Env = append(opts.Env, sb.Env()...)
+	scriptErr := runner.ExecuteScriptWithOptions(sb.Root, scriptCommand, opts)
+
+	changes, err := sb.Changes()
+	if err != nil {
+		return fmt.Errorf("failed to compare sandbox with project: %w", err)
+	}
+
+	if len(changes) == 0 {
+		logger.Info("No files changed in the sandbox")
+		return scriptErr
+	}
+
+	logger.Info("📝 %d file(s) changed in the sandbox:", len(changes))
+	for _, change := range changes {
+		logger.Info("   %-8s %s", change.Kind, change.Path)
+	}
+
+	if showDiff {
+		patch, err := sb.Diff(changes)
+		if err != nil {
+			return err
+		}
+		fmt.Println()
+		fmt.Print(patch)
+	}
+
+	switch {
+	case apply && scriptErr != nil:
+		logger.Warn("Script failed; not applying its changes")
+	case apply:
+		if err := sb.Apply(changes); err != nil {
+			return fmt.Errorf("failed to apply sandbox changes: %w", err)
+		}
+		logger.Success("✅ Applied %d change(s) to the project", len(changes))
+	default:
+		logger.Info("💡 Re-run with --apply to copy these changes into the project")
+	}
+	return scriptErr
+}
+
+func init() {
+	serviceFlag(runCmd.Flags())
+	runCmd.Flags().Bool("sandbox", false, "Run the script in a temporary copy of the project")
+	runCmd.Flags().Bool("diff", false, "With --sandbox, show a diff of the files the script changed")
+	runCmd.Flags().Bool("apply", false, "With --sandbox, copy the changed files back into the project")
+	runCmd.Flags().Bool("keep", false, "With --sandbox, keep the sandbox directory afterwards")
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// seederCmd represents the command to generate a database seeder.
+var seederCmd = &cobra.Command{
+	Use:   "seeder <name>",
+	Short: "Generate a database seeder and register it",
+	Long: `Generates a seeder in db/seeds and adds it to the seeder registry
+(db/seeds/seeds.go). The first seeder also creates the registry and
+cmd/seed, which runs every seeder, each in its own transaction, against
+the database in config/default.yml.
+
+Examples:
+  goforge g seeder users
+  goforge g seeder demo-orders
+  goforge run db:seed               # run all seeders
+  go run ./cmd/seed users           # run some`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateSeeder(args[0], componentOptions(cmd))
+	},
+}
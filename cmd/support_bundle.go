@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/support"
+	"github.com/spf13/cobra"
+)
+
+// supportBundleCmd packages diagnostics into an encrypted archive.
+var supportBundleCmd = &cobra.Command{
+	Use:   "support-bundle",
+	Short: "Create an encrypted diagnostics bundle for your platform team",
+	Long: `Collects environment diagnostics, the project's manifests (with secrets
+redacted), timing reports, logs under .goforge, installed template packs,
+and plugins into a single encrypted file to attach to a support request.
+
+The bundle is encrypted to the support queue's public key, taken from
+--recipient, the GOFORGE_SUPPORT_RECIPIENT environment variable, or
+support.recipient in goforge.yml. Without a key, use --passphrase-env to
+encrypt with a passphrase shared out of band.
+
+Run with --list first to see exactly what will be included.
+
+Examples:
+  goforge support-bundle
+  goforge support-bundle --recipient gfsb-pub-... -o ticket-4812.gfsb
+  goforge support-bundle --passphrase-env BUNDLE_PASS
+  goforge support-bundle --list
+
+Platform teams:
+  goforge support-bundle keygen
+  goforge support-bundle decrypt ticket-4812.gfsb --key-file support.key`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		list, _ := cmd.Flags().GetBool("list")
+		output, _ := cmd.Flags().GetString("output")
+		recipient, _ := cmd.Flags().GetString("recipient")
+		passphraseEnv, _ := cmd.Flags().GetString("passphrase-env")
+
+		config, projectRoot, err := project.LoadConfig()
+		if err != nil {
+			logger.Debug("Not in a goforge project: %v", err)
+			projectRoot = ""
+		}
+		if recipient == "" {
+			recipient = os.Getenv("GOFORGE_SUPPORT_RECIPIENT")
+		}
+		if recipient == "" && config != nil && config.Support != nil {
+			recipient = config.Support.Recipient
+		}
+
+		logger.Info("🩺 Collecting diagnostics...")
+		bundle, err := support.Collect(version, projectRoot)
+		if err != nil {
+			return fmt.Errorf("failed to collect diagnostics: %w", err)
+		}
+
+		if list {
+			fmt.Println("manifest.json")
+			for _, name := range bundle.Names() {
+				fmt.Println(name)
+			}
+			return nil
+		}
+
+		archive, err := bundle.Archive()
+		if err != nil {
+			return fmt.Errorf("failed to create archive: %w", err)
+		}
+
+		var sealed []byte
+		switch {
+		case recipient != "":
+			sealed, err = support.EncryptForRecipient(archive, recipient)
+		case passphraseEnv != "":
+			passphrase := os.Getenv(passphraseEnv)
+			if passphrase == "" {
+				return fmt.Errorf("environment variable %s is empty", passphraseEnv)
+			}
+			sealed, err = support.EncryptWithPassphrase(archive, passphrase)
+		default:
+			return fmt.Errorf("no encryption key configured\n\nSet support.recipient in goforge.yml, pass --recipient, or use --passphrase-env")
+		}
+		if err != nil {
+			return fmt.Errorf("failed to encrypt bundle: %w", err)
+		}
+
+		if output == "" {
+			output = fmt.Sprintf("goforge-support-%s.gfsb", time.Now().Format("20060102-150405"))
+		}
+		if err := os.WriteFile(output, sealed, 0600); err != nil {
+			return fmt.Errorf("failed to write bundle: %w", err)
+		}
+
+		logger.Success("✅ Support bundle written to %s (%d files, %d KB)", output, len(bundle.Names())+1, (len(sealed)+1023)/1024)
+		return nil
+	},
+}
+
+// supportKeygenCmd creates a key pair for a support queue.
+var supportKeygenCmd = &cobra.Command{
+	Use:   "keygen",
+	Short: "Generate a key pair for receiving support bundles",
+	Long: `Prints a new public key to share with developers (for example in
+support.recipient of goforge.yml) and writes the private key to --key-file.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyFile, _ := cmd.Flags().GetString("key-file")
+
+		if _, err := os.Stat(keyFile); err == nil {
+			return fmt.Errorf("%s already exists; remove it or choose another --key-file", keyFile)
+		}
+
+		public, private, err := support.GenerateKeyPair()
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(keyFile, []byte(private+"\n"), 0600); err != nil {
+			return fmt.Errorf("failed to write private key: %w", err)
+		}
+
+		logger.Success("✅ Private key written to %s — keep it secret", keyFile)
+		fmt.Println(public)
+		return nil
+	},
+}
+
+// supportDecryptCmd opens a bundle back into a .tar.gz archive.
+var supportDecryptCmd = &cobra.Command{
+	Use:   "decrypt <bundle>",
+	Short: "Decrypt a support bundle into a .tar.gz archive",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		keyFile, _ := cmd.Flags().GetString("key-file")
+		passphraseEnv, _ := cmd.Flags().GetString("passphrase-env")
+		output, _ := cmd.Flags().GetString("output")
+
+		var secret string
+		switch {
+		case passphraseEnv != "":
+			secret = os.Getenv(passphraseEnv)
+		case keyFile != "":
+			data, err := os.ReadFile(keyFile)
+			if err != nil {
+				return fmt.Errorf("failed to read key file: %w", err)
+			}
+			secret = strings.TrimSpace(string(data))
+		default:
+			return fmt.Errorf("pass --key-file or --passphrase-env")
+		}
+
+		data, err := os.ReadFile(args[0])
+		if err != nil {
+			return err
+		}
+		archive, err := support.Decrypt(data, secret)
+		if err != nil {
+			return err
+		}
+
+		if output == "" {
+			output = strings.TrimSuffix(args[0], ".gfsb") + ".tar.gz"
+		}
+		if err := os.WriteFile(output, archive, 0600); err != nil {
+			return err
+		}
+		logger.Success("✅ Decrypted to %s", output)
+		return nil
+	},
+}
+
+func init() {
+	supportBundleCmd.Flags().StringP("output", "o", "", "Bundle file to write (default goforge-support-<timestamp>.gfsb)")
+	supportBundleCmd.Flags().String("recipient", "", "Public key (gfsb-pub-...) to encrypt the bundle to")
+	supportBundleCmd.Flags().String("passphrase-env", "", "Encrypt with the passphrase in this environment variable")
+	supportBundleCmd.Flags().Bool("list", false, "List the files that would be included and exit")
+
+	supportKeygenCmd.Flags().String("key-file", "goforge-support.key", "Where to write the private key")
+
+	supportDecryptCmd.Flags().String("key-file", "", "Private key file from 'support-bundle keygen'")
+	supportDecryptCmd.Flags().String("passphrase-env", "", "Decrypt with the passphrase in this environment variable")
+	supportDecryptCmd.Flags().StringP("output", "o", "", "Archive to write (default <bundle>.tar.gz)")
+
+	supportBundleCmd.AddCommand(supportKeygenCmd)
+	supportBundleCmd.AddCommand(supportDecryptCmd)
+}
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// systemdCmd represents the command to generate a systemd unit.
+var systemdCmd = &cobra.Command{
+	Use:   "systemd",
+	Short: "Generate a systemd unit running the deployed binary",
+	Long: `Generates deploy/systemd/<service>.service for running the project on a
+plain VM. The unit starts <dir>/current/<binary>, the layout 'goforge
+deploy' leaves with the ssh provider, from that directory, so the assets
+of build.assets are found next to it. It restarts on failure and runs as
+its own user with systemd's basic hardening.
+
+The directory and service name come from deploy.ssh in goforge.yml,
+/opt/<project_name> and the project name by default; the binary from
+build.binary_name. The settings and secrets of the environment --env
+names are read from /etc/<service>/<env>.env, and the variables of
+docker.env are set too. Settings kept between the
+'# goforge:keep service-settings' and '# goforge:end' lines survive
+regeneration.
+
+Examples:
+  goforge g systemd
+  goforge g systemd --env staging --user www-data`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		env, _ := cmd.Flags().GetString("env")
+		user, _ := cmd.Flags().GetString("user")
+
+		return scaffold.GenerateSystemd(scaffold.SystemdOptions{Env: env, User: user}, componentOptions(cmd))
+	},
+}
+
+// procfileCmd represents the command to generate a Procfile.
+var procfileCmd = &cobra.Command{
+	Use:   "procfile",
+	Short: "Generate a Procfile running the built binary",
+	Long: `Generates a Procfile whose web process runs the binary 'goforge build'
+writes, <build.output_dir>/<binary> (dist/<project_name> by default), for
+process managers such as foreman and honcho, which read the environment
+from .env, and Procfile-based hosts such as Dokku.
+
+Examples:
+  goforge g procfile
+  goforge build && foreman start`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return scaffold.GenerateProcfile(componentOptions(cmd))
+	},
+}
+
+func init() {
+	systemdCmd.Flags().String("env", scaffold.DefaultServiceEnv, "Environment whose settings the unit reads from /etc/<service>/<env>.env")
+	systemdCmd.Flags().String("user", "", "Account the service runs as (default: the service name)")
+}
@@ -0,0 +1,181 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/telemetry"
+	"github.com/night-slayer18/goforge/internal/userconfig"
+	"github.com/spf13/cobra"
+)
+
+// telemetryCmd turns the anonymous usage statistics on and off.
+var telemetryCmd = &cobra.Command{
+	Use:   "telemetry",
+	Short: "Turn anonymous usage statistics on or off",
+	Long: `goforge can send anonymous usage statistics to help decide which features
+to work on next. It is off unless you turn it on.
+
+When on, each command records its name (e.g. 'generate handler'), how long
+it took, and the kind of failure if it failed (config, validation, tool,
+build, test, or failure), along with the goforge version, OS, and
+architecture. Arguments, paths, project names, and error messages are
+never recorded. Events wait in a queue in the user cache directory and are
+sent in the background while later commands run.
+
+GOFORGE_NO_TELEMETRY=1 or DO_NOT_TRACK=1 turns it off whatever the setting.
+
+Examples:
+  goforge telemetry status
+  goforge telemetry on
+  goforge telemetry off`,
+}
+
+var telemetryOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Send anonymous usage statistics",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := userconfig.Set("telemetry", "true"); err != nil {
+			return err
+		}
+		logger.Success("✅ Telemetry is on. Thank you!")
+		if telemetry.Disabled() {
+			logger.Warn("⚠️  %s or DO_NOT_TRACK is set, so nothing is recorded until it is unset", telemetry.DisableEnv)
+		}
+		return nil
+	},
+}
+
+var telemetryOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Stop sending usage statistics and drop the queued ones",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := userconfig.Set("telemetry", "false"); err != nil {
+			return err
+		}
+		if err := telemetry.Clear(); err != nil {
+			return fmt.Errorf("failed to drop the queued events: %w", err)
+		}
+		logger.Success("✅ Telemetry is off")
+		return nil
+	},
+}
+
+var telemetryStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether usage statistics are sent, and the queued events",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := userconfig.Load()
+		if err != nil {
+			return err
+		}
+		state := "off"
+		switch {
+		case cfg.Telemetry && telemetry.Disabled():
+			state = fmt.Sprintf("off (%s or DO_NOT_TRACK is set)", telemetry.DisableEnv)
+		case cfg.Telemetry:
+			state = "on"
+		}
+		events, err := telemetry.Queued()
+		if err != nil {
+			return err
+		}
+		queue, err := telemetry.QueuePath()
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Telemetry:\t%s\n", state)
+		fmt.Fprintf(w, "Queued events:\t%d\n", len(events))
+		fmt.Fprintf(w, "Queue:\t%s\n", queue)
+		fmt.Fprintf(w, "Sent to:\t%s\n", valueOr(telemetry.Endpoint(), "nowhere; this build keeps events queued"))
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if len(events) > 0 {
+			fmt.Println("\nLatest events:")
+			for _, event := range events[max(0, len(events)-5):] {
+				fmt.Printf("  %s  %s  %s  %dms\n", event.Time.Local().Format(time.DateTime), event.Command, event.Outcome, event.Duration)
+			}
+		}
+		return nil
+	},
+}
+
+// telemetryWait bounds how long a finished command waits for the queued
+// events being sent alongside it.
+const telemetryWait = 2 * time.Second
+
+// startTelemetry sends the events queued by earlier commands alongside
+// this one, when the user turned telemetry on. The returned function
+// waits briefly for the sending to finish and records this command; it
+// does nothing when telemetry is off.
+func startTelemetry() func(cmd *cobra.Command, err error) {
+	cfg, err := userconfig.Load()
+	if err != nil || !cfg.Telemetry || telemetry.Disabled() {
+		return func(*cobra.Command, error) {}
+	}
+	// 'goforge telemetry off' drops the queue, so it must not be sent first
+	if cmd, _, err := rootCmd.Find(os.Args[1:]); err == nil && cmd == telemetryOffCmd {
+		return func(*cobra.Command, error) {}
+	}
+
+	start := time.Now()
+	sent := make(chan struct{})
+	go func() {
+		if err := telemetry.Flush(); err != nil {
+			logger.Debug("Sending usage statistics failed: %v", err)
+		}
+		close(sent)
+	}()
+
+	return func(cmd *cobra.Command, err error) {
+		elapsed := time.Since(start)
+		select {
+		case <-sent:
+		case <-time.After(telemetryWait):
+		}
+
+		// The command may have been 'goforge telemetry off'
+		if cfg, loadErr := userconfig.Load(); loadErr != nil || !cfg.Telemetry {
+			telemetry.Clear()
+			return
+		}
+		if cmd == nil || cmd.Hidden {
+			return
+		}
+		event := telemetry.NewEvent(telemetryCommand(cmd), exitcode.Of(err).String(), version, elapsed)
+		if err := telemetry.Record(event); err != nil {
+			logger.Debug("Could not record usage statistics: %v", err)
+		}
+	}
+}
+
+// telemetryCommand is the name cmd is recorded under: its path without
+// the program name, with plugins, whose names are the user's own, all
+// recorded as "plugin".
+func telemetryCommand(cmd *cobra.Command) string {
+	if _, isPlugin := cmd.Annotations[pluginAnnotation]; isPlugin {
+		return "plugin"
+	}
+	if !cmd.HasParent() {
+		return cmd.Name()
+	}
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+func init() {
+	telemetryCmd.AddCommand(telemetryOnCmd)
+	telemetryCmd.AddCommand(telemetryOffCmd)
+	telemetryCmd.AddCommand(telemetryStatusCmd)
+}
@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/packs"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// templatesCmd groups the commands that list templates and manage remote packs.
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "List templates and manage remote template packs",
+	Long: `Template packs are git repositories that provide project templates and
+component generators. Once added, their contents are referenced as
+"<pack>/<name>":
+
+  goforge new app -t org/microservice-v2
+  goforge g org/consumer events
+
+A pack repository is laid out as:
+
+  templates/<name>/...        Project templates
+  generators/<type>.go.tpl    Component generators (optional <type>.yml manifest)
+
+Examples:
+  goforge templates list
+  goforge templates info org/consumer
+  goforge templates update org
+  goforge templates remove org`,
+}
+
+// templatesAddCmd clones a pack into the local cache.
+var templatesAddCmd = &cobra.Command{
+	Use:   "add <source>[@version]",
+	Short: "Install a template pack from a git repository",
+	Long: `Clones a template pack into the local cache and registers it.
+
+The version may be any tag or branch; without one the default branch is used.
+The pack name defaults to the repository owner (github.com/org/repo -> "org").
+
+Examples:
+  goforge templates add github.com/org/goforge-templates
+  goforge templates add github.com/org/goforge-templates@v1.2.0
+  goforge templates add git@github.com:org/templates.git --name acme`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		source, version := packs.ParseSource(args[0])
+
+		reg, err := packs.LoadRegistry()
+		if err != nil {
+			return err
+		}
+
+		logger.Info("📦 Fetching %s...", source)
+		pack, err := reg.Add(source, version, name)
+		if err != nil {
+			return err
+		}
+		if err := reg.Save(); err != nil {
+			return err
+		}
+
+		logger.Success("✅ Installed pack '%s' (%s)", pack.Name, pack.Version)
+		logger.Debug("Cached at %s", pack.Path)
+		logger.Info("💡 Use it with: goforge new <name> -t %s/<template>", pack.Name)
+		return nil
+	},
+}
+
+// templatesListCmd lists embedded, local, and pack templates.
+var templatesListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List available project templates and generators",
+	Aliases: []string{"ls"},
+	Args:    cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		kind, _ := cmd.Flags().GetString("kind")
+		if kind != "" && kind != scaffold.KindProject && kind != scaffold.KindGenerator {
+			return fmt.Errorf("unknown kind '%s' (use %s or %s)", kind, scaffold.KindProject, scaffold.KindGenerator)
+		}
+
+		infos, err := scaffold.ListTemplates(currentProjectRoot())
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tKIND\tORIGIN\tVERSION\tDESCRIPTION")
+		for _, info := range infos {
+			if kind != "" && info.Kind != kind {
+				continue
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.Name, info.Kind, info.Origin, valueOr(info.Version, "-"), info.Description)
+		}
+		return w.Flush()
+	},
+}
+
+// templatesInfoCmd shows the details of one template.
+var templatesInfoCmd = &cobra.Command{
+	Use:   "info <name>",
+	Short: "Show details about a template or generator",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		info, found, err := scaffold.FindTemplate(currentProjectRoot(), args[0])
+		if err != nil {
+			return err
+		}
+		if !found {
+			return fmt.Errorf("template '%s' not found\n\nRun 'goforge templates list' to see available templates", args[0])
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(w, "Name:\t%s\n", info.Name)
+		fmt.Fprintf(w, "Kind:\t%s\n", info.Kind)
+		fmt.Fprintf(w, "Origin:\t%s\n", info.Origin)
+		if info.Description != "" {
+			fmt.Fprintf(w, "Description:\t%s\n", info.Description)
+		}
+		if info.Source != "" {
+			fmt.Fprintf(w, "Source:\t%s\n", info.Source)
+		}
+		if info.Version != "" {
+			fmt.Fprintf(w, "Version:\t%s\n", info.Version)
+		}
+		if info.Path != "" {
+			fmt.Fprintf(w, "Path:\t%s\n", info.Path)
+		}
+		fmt.Fprintf(w, "Variables:\t%s\n", strings.Join(info.Variables, ", "))
+		if err := w.Flush(); err != nil {
+			return err
+		}
+
+		if info.Kind == scaffold.KindProject {
+			fmt.Printf("\nUsage: goforge new <project-name> -t %s\n", info.Name)
+		} else {
+			fmt.Printf("\nUsage: goforge generate %s <name>\n", info.Name)
+		}
+		return nil
+	},
+}
+
+// templatesUpdateCmd re-fetches installed packs.
+var templatesUpdateCmd = &cobra.Command{
+	Use:   "update [pack...]",
+	Short: "Update installed template packs",
+	Long: `Re-fetches template packs. Packs tracking a branch move to its latest
+commit; use --version to switch a single pack to another tag or branch.
+Without arguments every installed pack is updated.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, _ := cmd.Flags().GetString("version")
+		if version != "" && len(args) != 1 {
+			return fmt.Errorf("--version requires exactly one pack name")
+		}
+
+		reg, err := packs.LoadRegistry()
+		if err != nil {
+			return err
+		}
+
+		names := args
+		if len(names) == 0 {
+			for _, pack := range reg.Packs {
+				names = append(names, pack.Name)
+			}
+		}
+		if len(names) == 0 {
+			logger.Info("No template packs installed")
+			return nil
+		}
+
+		var failed []string
+		for _, name := range names {
+			previous := ""
+			if pack, found := reg.Find(name); found {
+				previous = pack.Version
+			}
+
+			pack, err := reg.Update(name, version)
+			if err != nil {
+				logger.Error("Failed to update '%s': %v", name, err)
+				failed = append(failed, name)
+				continue
+			}
+
+			if pack.Version == previous {
+				logger.Info("✔️  %s is up to date (%s)", pack.Name, pack.Version)
+			} else {
+				logger.Success("✅ Updated %s: %s → %s", pack.Name, previous, pack.Version)
+			}
+		}
+
+		if err := reg.Save(); err != nil {
+			return err
+		}
+		if len(failed) > 0 {
+			return fmt.Errorf("failed to update: %s", strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+// templatesRemoveCmd uninstalls packs.
+var templatesRemoveCmd = &cobra.Command{
+	Use:     "remove <pack>...",
+	Short:   "Remove installed template packs",
+	Aliases: []string{"rm"},
+	Args:    cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		reg, err := packs.LoadRegistry()
+		if err != nil {
+			return err
+		}
+
+		for _, name := range args {
+			pack, err := reg.Remove(name)
+			if err != nil {
+				return err
+			}
+			logger.Success("✅ Removed pack '%s' (%s)", pack.Name, pack.Source)
+		}
+		return reg.Save()
+	},
+}
+
+// currentProjectRoot returns the enclosing goforge project, or "" outside one.
+func currentProjectRoot() string {
+	_, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return ""
+	}
+	return projectRoot
+}
+
+// valueOr returns value, or fallback when value is empty.
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func init() {
+	templatesAddCmd.Flags().String("name", "", "Name to register the pack under (defaults to the repository owner)")
+
+	templatesListCmd.Flags().String("kind", "", "Only list one kind (project, generator)")
+	templatesUpdateCmd.Flags().String("version", "", "Switch the pack to this tag or branch")
+
+	templatesCmd.AddCommand(templatesAddCmd)
+	templatesCmd.AddCommand(templatesListCmd)
+	templatesCmd.AddCommand(templatesInfoCmd)
+	templatesCmd.AddCommand(templatesUpdateCmd)
+	templatesCmd.AddCommand(templatesRemoveCmd)
+}
@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/testrun"
+	"github.com/spf13/cobra"
+)
+
+// testCmd represents the command to run the project's tests.
+var testCmd = &cobra.Command{
+	Use:   "test [packages...]",
+	Short: "Run the tests with a summary of the results",
+	Long: `Runs go test on the given packages, ./... by default, and summarizes the
+results: one line per package as it finishes, marked when its result came
+from the test cache, then the output of the failed tests, the slowest
+tests, and the number of tests that passed, failed, and were skipped.
+
+With --verbose the output of every test is shown as it runs.
+
+With --watch the tests run again whenever a file is saved, but only those
+of the packages the change affects: the changed packages, the packages
+importing them directly or indirectly, and those whose tests import them.
+Press f and Enter to rerun just the tests that failed, Enter alone to run
+everything.
+
+With --coverage the coverage profile is written to coverage.out and its
+HTML report to coverage.html, and the coverage of each package and the
+total are printed.
+
+Settings in the test section of goforge.yml:
+
+  timeout             limit of the whole run, e.g. 10m (--timeout overrides it)
+  coverage_threshold  lowest total coverage in percent; --coverage fails
+                      below it
+  report_format       junit or json: write a report on every run
+                      (--format overrides it)
+  report_file         where the report goes (--report overrides it)
+
+--format junit writes JUnit XML, which Jenkins and GitLab display; --format
+json writes the packages and tests with their results and timings. With
+--output json, that report is printed instead of the test results.
+
+Examples:
+  goforge test
+  goforge test ./internal/...
+  goforge test --race --coverage
+  goforge test --run 'TestUser/valid'
+  goforge test --no-cache
+  goforge test --watch
+  goforge test --format junit --report reports/junit.xml`,
+	Annotations: map[string]string{jsonAnnotation: "true"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, projectRoot, err := requireProject(cmd)
+		if err != nil {
+			return err
+		}
+
+		options := testrun.Options{Packages: args}
+		options.Run, _ = cmd.Flags().GetString("run")
+		options.Race, _ = cmd.Flags().GetBool("race")
+		if coverage, _ := cmd.Flags().GetBool("coverage"); coverage {
+			options.Profile = testrun.CoverProfile
+			// Don't report a profile left over from an earlier run
+			os.Remove(filepath.Join(projectRoot, testrun.CoverProfile))
+		}
+		options.Short, _ = cmd.Flags().GetBool("short")
+		options.NoCache, _ = cmd.Flags().GetBool("no-cache")
+		options.Timeout, _ = cmd.Flags().GetString("timeout")
+		if options.Timeout == "" && cfg.Test != nil {
+			options.Timeout = cfg.Test.Timeout
+		}
+
+		format, _ := cmd.Flags().GetString("format")
+		reportFile, _ := cmd.Flags().GetString("report")
+		if cfg.Test != nil {
+			format = cmp.Or(format, cfg.Test.ReportFormat)
+			reportFile = cmp.Or(reportFile, cfg.Test.ReportFile)
+		}
+		if format != "" && format != testrun.FormatJUnit && format != testrun.FormatJSON {
+			return exitcode.Errorf(exitcode.Validation, "unknown report format '%s': use %s or %s", format, testrun.FormatJUnit, testrun.FormatJSON)
+		}
+
+		verbose := logger.Verbose()
+		if verbose {
+			options.OnOutput = func(line string) { fmt.Println(line) }
+		}
+		options.OnPackage = func(pkg *testrun.Package) { printPackageResult(pkg, verbose) }
+
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			if options.Profile != "" || format != "" {
+				return exitcode.Errorf(exitcode.Validation, "--watch cannot be combined with --coverage or a report format")
+			}
+			if wantsJSON(cmd) {
+				return exitcode.Errorf(exitcode.Validation, "--watch cannot be combined with --output json")
+			}
+			return watchTests(projectRoot, args, options)
+		}
+
+		logger.Printf("🧪 Running go %s\n\n", strings.Join(testrun.Args(options), " "))
+		report, err := testrun.Run(projectRoot, options)
+		if err != nil {
+			return err
+		}
+		reportJSON(cmd, testrun.JSONReport(report))
+		testErr := summarizeTests(report)
+		if format != "" {
+			path := cmp.Or(reportFile, testrun.DefaultReportFile(format))
+			if !filepath.IsAbs(path) {
+				path = filepath.Join(projectRoot, path)
+			}
+			if err := testrun.WriteReport(report, format, path); err != nil {
+				return err
+			}
+			logger.Printf("📄 Test report: %s\n", path)
+		}
+		if options.Profile == "" {
+			return testErr
+		}
+
+		threshold := 0.0
+		if cfg.Test != nil {
+			threshold = cfg.Test.CoverageThreshold
+		}
+		coverageErr := reportCoverage(projectRoot, report, threshold)
+		if testErr != nil {
+			return testErr
+		}
+		return coverageErr
+	},
+}
+
+// faint prints the details of the results.
+var faint = color.New(color.Faint).SprintFunc()
+
+// printPackageResult prints the line of a finished package. Packages
+// without tests are only listed in verbose mode.
+func printPackageResult(pkg *testrun.Package, verbose bool) {
+	if pkg.NoTests && !verbose {
+		return
+	}
+
+	var details []string
+	switch {
+	case pkg.NoTests:
+		details = append(details, "no test files")
+	case pkg.Cached:
+		details = append(details, "cached")
+	default:
+		details = append(details, formatElapsed(pkg.Elapsed))
+	}
+	if pkg.Coverage >= 0 {
+		details = append(details, fmt.Sprintf("coverage %.1f%%", pkg.Coverage))
+	}
+
+	mark := "✅"
+	switch {
+	case pkg.Status == testrun.Fail:
+		mark = "❌"
+	case pkg.NoTests || pkg.Status == testrun.Skip:
+		mark = "➖"
+	}
+	fmt.Printf("  %s %s %s\n", mark, pkg.Path, faint("("+strings.Join(details, ", ")+")"))
+}
+
+// summarizeTests prints the failures, the slowest tests, and the counts of
+// the run, and fails when a test or package did.
+func summarizeTests(report *testrun.Report) error {
+	failures := report.Failures()
+	if len(failures) > 0 {
+		logger.Println("\n❌ Failed tests:")
+		for _, t := range failures {
+			fmt.Printf("\n  %s %s\n", t.Name, faint("("+t.Package+", "+formatElapsed(t.Elapsed)+")"))
+			printTestOutput(t.Output)
+		}
+	}
+
+	var brokenPackages []string
+	for _, pkg := range report.Packages {
+		if pkg.Status != testrun.Fail || hasFailedTest(failures, pkg.Path) {
+			continue
+		}
+		brokenPackages = append(brokenPackages, pkg.Path)
+		logger.Printf("\n❌ %s failed:\n", pkg.Path)
+		printTestOutput(pkg.Output)
+	}
+
+	if slowest := report.Slowest(5); len(slowest) > 0 && report.Count(testrun.Pass)+report.Count(testrun.Fail) > 1 {
+		logger.Println("\n🐢 Slowest tests:")
+		for _, t := range slowest {
+			fmt.Printf("  %8s  %s %s\n", formatElapsed(t.Elapsed), t.Name, faint("("+t.Package+")"))
+		}
+	}
+
+	tested, cached := 0, 0
+	for _, pkg := range report.Packages {
+		if !pkg.NoTests {
+			tested++
+		}
+		if pkg.Cached {
+			cached++
+		}
+	}
+	passed, failed, skipped := report.Count(testrun.Pass), report.Count(testrun.Fail), report.Count(testrun.Skip)
+	fmt.Printf("\nTests: %d passed, %d failed, %d skipped · Packages: %d (%d cached) · %s\n",
+		passed, failed, skipped, tested, cached, formatElapsed(report.Elapsed))
+
+	switch {
+	case failed > 0:
+		return exitcode.Errorf(exitcode.Test, "%d of %d tests failed", failed, passed+failed)
+	case len(brokenPackages) > 0:
+		return exitcode.Errorf(exitcode.Test, "packages failed to build or run: %s", strings.Join(brokenPackages, ", "))
+	case passed == 0 && skipped == 0:
+		fmt.Println("No tests to run.")
+	default:
+		logger.Println("✨ All tests passed.")
+	}
+	return nil
+}
+
+// reportCoverage prints the coverage of each package and the total,
+// writes the HTML report, and fails when the total is below threshold.
+func reportCoverage(projectRoot string, report *testrun.Report, threshold float64) error {
+	if _, err := os.Stat(filepath.Join(projectRoot, testrun.CoverProfile)); err != nil {
+		return fmt.Errorf("no coverage profile was written; fix the packages that failed to build")
+	}
+
+	var packages []*testrun.Package
+	width := 0
+	for _, pkg := range report.Packages {
+		if pkg.Coverage >= 0 {
+			packages = append(packages, pkg)
+			width = max(width, len(pkg.Path))
+		}
+	}
+	sort.Slice(packages, func(i, j int) bool { return packages[i].Path < packages[j].Path })
+
+	logger.Println("\n📊 Coverage:")
+	for _, pkg := range packages {
+		fmt.Printf("  %-*s  %5.1f%%\n", width, pkg.Path, pkg.Coverage)
+	}
+	total, err := testrun.CoverageReport(projectRoot, testrun.CoverProfile, testrun.CoverHTML)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("  %-*s  %5.1f%%\n", width, "total", total)
+	logger.Printf("\n📄 Coverage report: %s (open %s in a browser)\n", testrun.CoverProfile, testrun.CoverHTML)
+
+	if threshold <= 0 {
+		return nil
+	}
+	if total < threshold {
+		return exitcode.Errorf(exitcode.Test, "total coverage %.1f%% is below the threshold of %g%% (test.coverage_threshold in goforge.yml)", total, threshold)
+	}
+	logger.Printf("✅ Coverage meets the threshold of %g%%.\n", threshold)
+	return nil
+}
+
+// printTestOutput prints the output of a failure, indented, without the
+// run markers of go test.
+func printTestOutput(lines []string) {
+	for _, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "=== ") {
+			continue
+		}
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+// hasFailedTest reports whether a test of the package is among failures.
+func hasFailedTest(failures []*testrun.Test, pkg string) bool {
+	for _, t := range failures {
+		if t.Package == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// formatElapsed formats a duration for the test results, e.g. 0.42s.
+func formatElapsed(d time.Duration) string {
+	return fmt.Sprintf("%.2fs", d.Seconds())
+}
+
+func init() {
+	testCmd.Flags().Bool("coverage", false, "Write coverage.out and coverage.html and check test.coverage_threshold")
+	testCmd.Flags().Bool("race", false, "Enable the race detector")
+	testCmd.Flags().String("run", "", "Run only the tests matching this regular expression")
+	testCmd.Flags().Bool("short", false, "Tell long-running tests to shorten their run time")
+	testCmd.Flags().Bool("no-cache", false, "Run the tests again even when their results are cached")
+	testCmd.Flags().String("timeout", "", "Limit of the whole run, e.g. 10m (overrides test.timeout)")
+	testCmd.Flags().Bool("watch", false, "Rerun the tests of the packages each change affects")
+	testCmd.Flags().String("format", "", "Write a report for CI systems: junit or json (overrides test.report_format)")
+	testCmd.Flags().String("report", "", "File of the report, test-report.xml or test-report.json by default (overrides test.report_file)")
+	serviceFlag(testCmd.Flags())
+}
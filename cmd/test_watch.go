@@ -0,0 +1,265 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/testrun"
+)
+
+// testWatchDebounce gathers the events of one save, which editors often
+// split into several writes.
+const testWatchDebounce = 300 * time.Millisecond
+
+// testWatcher reruns the tests of the packages a change affects.
+type testWatcher struct {
+	projectRoot string
+	patterns    []string // packages given on the command line, all when empty
+	options     testrun.Options
+	graph       *testrun.Graph
+	scope       map[string]bool // packages of patterns; nil for all
+	files       *fsnotify.Watcher
+
+	// failures are the top-level tests of each package that failed when
+	// it last ran; none when the package itself failed, e.g. to build
+	failures map[string][]string
+}
+
+// watchTests runs the tests, then reruns those of the packages each change
+// affects until interrupted.
+func watchTests(projectRoot string, patterns []string, options testrun.Options) error {
+	tw := &testWatcher{projectRoot: projectRoot, patterns: patterns, options: options, failures: map[string][]string{}}
+	if err := tw.loadGraph(); err != nil {
+		return err
+	}
+
+	var err error
+	tw.files, err = fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create file watcher: %w", err)
+	}
+	defer tw.files.Close()
+	if err := tw.addDirs(projectRoot); err != nil {
+		return fmt.Errorf("failed to setup watch paths: %w", err)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(sigChan)
+	commands := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			commands <- strings.TrimSpace(scanner.Text())
+		}
+	}()
+
+	tw.run(patterns, "", "all packages")
+
+	changed := map[string]bool{}
+	reload := false
+	var debounce <-chan time.Time
+	for {
+		select {
+		case event, ok := <-tw.files.Events:
+			if !ok {
+				return nil
+			}
+			if event.Op&fsnotify.Create != 0 {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					tw.addDirs(event.Name)
+					reload = true
+					continue
+				}
+			}
+			if !isTestWatchFile(event.Name) || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if event.Op&(fsnotify.Create|fsnotify.Remove|fsnotify.Rename) != 0 || isModuleFile(event.Name) {
+				// Packages or imports may have come or gone
+				reload = true
+			}
+			changed[event.Name] = true
+			debounce = time.After(testWatchDebounce)
+
+		case <-debounce:
+			debounce = nil
+			files := slices.Sorted(maps.Keys(changed))
+			clear(changed)
+			tw.runAffected(files, reload)
+			reload = false
+
+		case command := <-commands:
+			switch command {
+			case "":
+				tw.run(tw.patterns, "", "all packages")
+			case "f":
+				tw.runFailures()
+			case "q":
+				return nil
+			default:
+				fmt.Println("Enter: run all · f: run the failed tests · q: quit")
+			}
+
+		case err, ok := <-tw.files.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Warn("File watcher error: %v", err)
+
+		case <-sigChan:
+			fmt.Println()
+			return nil
+		}
+	}
+}
+
+// loadGraph reads the import graph of the module and the packages the
+// command line selects.
+func (tw *testWatcher) loadGraph() error {
+	graph, err := testrun.LoadGraph(tw.projectRoot)
+	if err != nil {
+		return err
+	}
+	tw.graph = graph
+	if len(tw.patterns) > 0 {
+		selected, err := testrun.LoadGraph(tw.projectRoot, tw.patterns...)
+		if err != nil {
+			return err
+		}
+		tw.scope = map[string]bool{}
+		for _, pkg := range selected.Packages() {
+			tw.scope[pkg] = true
+		}
+	}
+	return nil
+}
+
+// addDirs watches dir and the directories below it, leaving out hidden
+// ones and those holding no Go code of the module.
+func (tw *testWatcher) addDirs(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || !d.IsDir() {
+			return err
+		}
+		name := d.Name()
+		if path != tw.projectRoot && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "node_modules") {
+			return filepath.SkipDir
+		}
+		return tw.files.Add(path)
+	})
+}
+
+// runAffected runs the tests of the packages the changed files affect.
+func (tw *testWatcher) runAffected(files []string, reload bool) {
+	if reload {
+		if err := tw.loadGraph(); err != nil {
+			logger.Error("%v", err)
+			return
+		}
+	}
+
+	var names []string
+	for _, file := range files {
+		rel, _ := filepath.Rel(tw.projectRoot, file)
+		names = append(names, rel)
+		if isModuleFile(file) {
+			tw.run(tw.patterns, "", rel+" changed")
+			return
+		}
+	}
+
+	var packages []string
+	for _, pkg := range tw.graph.Affected(files) {
+		if tw.scope == nil || tw.scope[pkg] {
+			packages = append(packages, pkg)
+		}
+	}
+	if len(packages) == 0 {
+		fmt.Println(faint("No tests affected by " + strings.Join(names, ", ")))
+		return
+	}
+	tw.run(packages, "", strings.Join(names, ", ")+" changed")
+}
+
+// runFailures reruns the tests that failed last time.
+func (tw *testWatcher) runFailures() {
+	if len(tw.failures) == 0 {
+		fmt.Println("No failed tests to run.")
+		return
+	}
+	var quoted []string
+	for _, names := range tw.failures {
+		for _, name := range names {
+			if !slices.Contains(quoted, regexp.QuoteMeta(name)) {
+				quoted = append(quoted, regexp.QuoteMeta(name))
+			}
+		}
+	}
+	run := ""
+	if len(quoted) > 0 {
+		run = "^(" + strings.Join(quoted, "|") + ")$"
+	}
+	tw.run(slices.Sorted(maps.Keys(tw.failures)), run, "failed tests")
+}
+
+// run runs the tests of packages, only those matching run when set, and
+// remembers the failures.
+func (tw *testWatcher) run(packages []string, run, reason string) {
+	options := tw.options
+	options.Packages = packages
+	if run != "" {
+		options.Run = run
+	}
+
+	logger.Printf("\n🧪 %s %s\n\n", time.Now().Format("15:04:05"), faint("("+reason+")"))
+	report, err := testrun.Run(tw.projectRoot, options)
+	if err != nil {
+		logger.Error("%v", err)
+	} else {
+		summarizeTests(report)
+
+		for _, pkg := range report.Packages {
+			delete(tw.failures, pkg.Path)
+			if pkg.Status == testrun.Fail {
+				tw.failures[pkg.Path] = nil
+			}
+		}
+		for _, t := range report.Failures() {
+			name, _, _ := strings.Cut(t.Name, "/")
+			if !slices.Contains(tw.failures[t.Package], name) {
+				tw.failures[t.Package] = append(tw.failures[t.Package], name)
+			}
+		}
+	}
+
+	hint := "Watching for changes · Enter: run all · q: quit"
+	if len(tw.failures) > 0 {
+		hint = "Watching for changes · f: run the failed tests · Enter: run all · q: quit"
+	}
+	fmt.Println(faint(hint))
+}
+
+// isTestWatchFile reports whether a change to the file can change test
+// results: Go files, the module files, and test data.
+func isTestWatchFile(path string) bool {
+	return strings.HasSuffix(path, ".go") || isModuleFile(path) ||
+		strings.Contains(filepath.ToSlash(path), "/testdata/")
+}
+
+// isModuleFile reports whether path is go.mod or go.sum.
+func isModuleFile(path string) bool {
+	name := filepath.Base(path)
+	return name == "go.mod" || name == "go.sum"
+}
@@ -19,12 +19,9 @@ Examples:
   goforge update                           # Update all dependencies
   goforge update github.com/gin-gonic/gin # Update specific dependency`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		logger.SetVerbose(verbose)
-
-		cfg, projectRoot, err := project.LoadConfig()
+		cfg, projectRoot, err := requireProject(cmd)
 		if err != nil {
-			return fmt.Errorf("command must be run from a goforge project: %w", err)
+			return err
 		}
 
 		if len(args) > 0 {
@@ -79,5 +76,4 @@ func updateAllDependencies(projectRoot string, cfg *project.Config) error {
 }
 
 func init() {
-	updateCmd.Flags().BoolP("verbose", "v", false, "Enable verbose logging")
 }
\ No newline at end of file
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/night-slayer18/goforge/internal/scaffold"
+	"github.com/spf13/cobra"
+)
+
+// upgradeTemplateCmd represents the command to bring a project up to the
+// latest version of its template.
+var upgradeTemplateCmd = &cobra.Command{
+	Use:   "upgrade-template",
+	Short: "Apply the latest version of the project's template",
+	Long: `Renders the latest version of the template the project was created from,
+recorded under template in goforge.yml, and applies its changes:
+
+  • files left as the template wrote them are updated
+  • files the template adds are created
+  • files you edited are three-way merged with the template's changes
+  • files whose edits conflict with them are listed and left alone
+
+Embedded templates are versioned with goforge; pack templates with the
+installed pack, so run 'goforge templates update' first to upgrade to a
+newer pack. goforge.yml itself is never rewritten.
+
+Examples:
+  goforge upgrade-template
+  goforge upgrade-template --patch > template.patch   # Review, then git apply`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		patch, _ := cmd.Flags().GetBool("patch")
+
+		return scaffold.UpgradeTemplate(scaffold.UpgradeOptions{
+			Version: version,
+			Patch:   patch,
+		})
+	},
+}
+
+func init() {
+	upgradeTemplateCmd.Flags().Bool("patch", false,
+		"Print the changes, conflicting ones included, as a patch instead of applying them")
+}
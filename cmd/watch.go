@@ -5,24 +5,44 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 	"os"
 	"os/exec"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/fsnotify/fsnotify"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/globs"
 	"github.com/night-slayer18/goforge/internal/logger"
 	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/utils"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
+// Patterns watch mode uses unless dev.watch and dev.ignore are set.
+var (
+	defaultWatchPatterns  = []string{"**/*.{go,yml,yaml,json}"}
+	defaultIgnorePatterns = []string{
+		"**/*_test.go",
+		"{dist,vendor,.git,.goforge}/**",
+		"**/node_modules/**",
+		"**/*.{tmp,log}",
+	}
+)
+
+// watchDebounce is how long watch mode waits for changes to settle.
+const watchDebounce = 1500 * time.Millisecond
+
 var watchCmd = &cobra.Command{
 	Use:   "watch [script-name]",
 	Short: "Watch for file changes and restart the application",
@@ -33,19 +53,29 @@ it defaults to the 'dev' script.
 GoForge handles all process management, port cleanup, and graceful restarts internally.
 Your application code stays clean and simple.
 
+For 'go run <package>' scripts, GoForge builds the binary itself and keeps
+the last build that started successfully. If a change fails to compile or
+crashes on start, you can roll back to it by typing 'r' + Enter, or let
+GoForge do it automatically with 'dev.rollback: true' in goforge.yml.
+
+Before each restart the commands in 'dev.on_change' run, e.g. code
+generators. With 'dev.live_reload: true', open pages reload once the
+restarted server is up: watch mode serves the client at
+http://localhost:35729/livereload.js ('dev.live_reload_port' changes the
+port) and exports that base URL as GOFORGE_LIVE_RELOAD, so the application
+can add the script to its pages only while watched.
+
 Examples:
   goforge watch           # Watch and run 'dev' script
   goforge watch dev       # Same as above
-  goforge watch test      # Watch and run 'test' script`,
+  goforge watch test      # Watch and run 'test' script
+  goforge watch -w api    # Watch the api service of a go.work workspace
+  goforge watch --log-file dev.log  # Also keep the output in dev.log`,
 	Args: cobra.MaximumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		// Set up logging
-		verbose, _ := cmd.Flags().GetBool("verbose")
-		logger.SetVerbose(verbose)
-
-		cfg, projectRoot, err := project.LoadConfig()
+		cfg, projectRoot, err := requireProject(cmd)
 		if err != nil {
-			return fmt.Errorf("command must be run from a goforge project: %w", err)
+			return err
 		}
 
 		// Determine script to run
@@ -56,41 +86,75 @@ Examples:
 
 		script, exists := cfg.Scripts[scriptName]
 		if !exists {
-			return fmt.Errorf("script '%s' not found in goforge.yml\n\nAvailable scripts:\n%s", 
-				scriptName, formatAvailableScripts(cfg.Scripts))
+			return scriptNotFound(scriptName, cfg.Scripts)
 		}
 		
-		logger.Info("👀 Starting GoForge watch mode")
-		logger.Info("📝 Script: %s → %s", scriptName, script)
-		logger.Info("📁 Watching: %s", projectRoot)
-		logger.Info("🔄 Press Ctrl+C to stop")
-		logger.Info("")
+		if err := exportComposeEnv(projectRoot); err != nil {
+			return err
+		}
+		logFile, _ := cmd.Flags().GetString("log-file")
+		return runWatchMode(projectRoot, scriptName, script, logger.Verbose(), logFile, cfg)
+	},
+}
 
-		// Create the advanced watcher
-		watcher := NewAdvancedWatcher(projectRoot, script, verbose, cfg)
-		defer watcher.Close()
+// runWatchMode starts the watcher for a script and blocks until interrupted.
+// With a log file, given or from dev.log_file, the messages and the output
+// of the script also go there.
+func runWatchMode(projectRoot, scriptName, script string, verbose bool, logFile string, cfg *project.Config) error {
+	if logFile == "" && cfg.Dev != nil {
+		logFile = cfg.Dev.LogFile
+	}
+	var log *logger.RotatingFile
+	if logFile != "" {
+		if !filepath.IsAbs(logFile) {
+			logFile = filepath.Join(projectRoot, logFile)
+		}
+		path, err := filepath.Abs(logFile)
+		if err != nil {
+			return err
+		}
+		if log, err = logger.OpenLogFile(path); err != nil {
+			return err
+		}
+		defer log.Close()
+		logger.SetTee(log)
+		defer logger.SetTee(nil)
+	}
 
-		// Set up graceful shutdown
-		sigChan := make(chan os.Signal, 1)
-		signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	logger.Info("👀 Starting GoForge watch mode")
+	logger.Info("📝 Script: %s → %s", scriptName, script)
+	logger.Info("📁 Watching: %s", projectRoot)
+	if log != nil {
+		logger.Info("📄 Logging to: %s", log.Path())
+	}
+	logger.Info("🔄 Press Ctrl+C to stop")
+	logger.Info("")
 
-		// Start the watcher
-		if err := watcher.Start(); err != nil {
-			return fmt.Errorf("failed to start watcher: %w", err)
-		}
+	// Create the advanced watcher
+	watcher := NewAdvancedWatcher(projectRoot, script, verbose, cfg)
+	watcher.logFile = log
+	defer watcher.Close()
 
-		// Wait for shutdown signal
-		<-sigChan
-		logger.Info("\n🛑 Shutting down...")
-		
-		if err := watcher.Stop(); err != nil {
-			logger.Error("Error during shutdown: %v", err)
-		} else {
-			logger.Info("✅ GoForge watch mode stopped")
-		}
+	// Set up graceful shutdown
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
-		return nil
-	},
+	// Start the watcher
+	if err := watcher.Start(); err != nil {
+		return fmt.Errorf("failed to start watcher: %w", err)
+	}
+
+	// Wait for shutdown signal
+	<-sigChan
+	logger.Info("\n🛑 Shutting down...")
+
+	if err := watcher.Stop(); err != nil {
+		logger.Error("Error during shutdown: %v", err)
+	} else {
+		logger.Info("✅ GoForge watch mode stopped")
+	}
+
+	return nil
 }
 
 // AdvancedWatcher handles all the complexity of file watching and process management
@@ -104,9 +168,21 @@ type AdvancedWatcher struct {
 	debouncer      *Debouncer
 	
 	// Configuration from project
-	projectPort    int
-	watchPatterns  []string
-	ignorePatterns []string
+	projectPorts   []int
+	watchSet       *globs.Set
+	ignoreSet      *globs.Set
+	onChange       []string             // dev.on_change, run before each restart
+	liveReload     *LiveReloadServer    // set with dev.live_reload
+	logFile        *logger.RotatingFile // set with --log-file or dev.log_file
+
+	// Rollback support for 'go run' scripts
+	builds            *BuildKeeper
+	autoRollback      bool
+	mu                sync.Mutex
+	generation        int
+	crashedGeneration int
+	runningLastGood   bool
+	rollbackOffered   bool
 }
 
 // NewAdvancedWatcher creates a new advanced watcher
@@ -115,61 +191,71 @@ func NewAdvancedWatcher(projectRoot, script string, verbose bool, cfg *project.C
 		projectRoot: projectRoot,
 		script:      script,
 		verbose:     verbose,
-		debouncer:   NewDebouncer(1500 * time.Millisecond), // Smart debouncing
+		debouncer:   NewDebouncer(watchDebounce), // Smart debouncing
 	}
 	
 	watcher.loadProjectConfig(cfg)
+	watcher.builds = NewBuildKeeper(projectRoot, script)
+	if cfg.Dev != nil {
+		watcher.autoRollback = cfg.Dev.Rollback
+		watcher.onChange = cfg.Dev.OnChange
+		if cfg.Dev.LiveReload {
+			watcher.liveReload = NewLiveReloadServer(cfg.Dev.LiveReloadPort)
+		}
+	}
 	
 	return watcher
 }
 
 // loadProjectConfig loads project-specific configuration
 func (aw *AdvancedWatcher) loadProjectConfig(cfg *project.Config) {
-	// Try to load viper config to detect port from config/default.yml
-	viper.SetConfigName("default")
-	viper.SetConfigType("yml")
-	viper.AddConfigPath(filepath.Join(aw.projectRoot, "config"))
-	
-	if err := viper.ReadInConfig(); err == nil {
-		aw.projectPort = viper.GetInt("server.port")
+	// dev.port and dev.ports name the ports; config/default.yml is the fallback
+	if cfg.Dev != nil && cfg.Dev.Port != 0 {
+		aw.projectPorts = append(aw.projectPorts, cfg.Dev.Port)
+	}
+	if cfg.Dev != nil {
+		for _, port := range cfg.Dev.Ports {
+			if !slices.Contains(aw.projectPorts, port) {
+				aw.projectPorts = append(aw.projectPorts, port)
+			}
+		}
 	}
-	
-	if aw.projectPort == 0 {
-		aw.projectPort = 8080 // Default
+	if len(aw.projectPorts) == 0 {
+		aw.projectPorts = []int{configuredServerPort(aw.projectRoot)}
 	}
 	
 	// Set up watch and ignore patterns
 	// Use values from goforge.yml if available, otherwise use defaults.
 	if cfg.Dev != nil && len(cfg.Dev.Watch) > 0 {
-		aw.watchPatterns = cfg.Dev.Watch
+		aw.watchSet = globs.NewSet(cfg.Dev.Watch...)
 		logger.Debug("Loaded %d watch patterns from goforge.yml", len(cfg.Dev.Watch))
 	} else {
-		aw.watchPatterns = []string{
-			"**/*.go",
-			"**/*.yml",
-			"**/*.yaml",
-			"**/*.json",
-		}
+		aw.watchSet = globs.NewSet(defaultWatchPatterns...)
 		logger.Debug("Using default watch patterns")
 	}
 	
 	if cfg.Dev != nil && len(cfg.Dev.Ignore) > 0 {
-		aw.ignorePatterns = cfg.Dev.Ignore
+		aw.ignoreSet = globs.NewSet(cfg.Dev.Ignore...)
 		logger.Debug("Loaded %d ignore patterns from goforge.yml", len(cfg.Dev.Ignore))
 	} else {
-		aw.ignorePatterns = []string{
-			"**/*_test.go",
-			"dist/**",
-			"vendor/**",
-			".git/**",
-			"node_modules/**",
-			"**/*.tmp",
-			"**/*.log",
-		}
+		aw.ignoreSet = globs.NewSet(defaultIgnorePatterns...)
 		logger.Debug("Using default ignore patterns")
 	}
 	
-	logger.Debug("Detected project port: %d", aw.projectPort)
+	logger.Debug("Detected project ports: %v", aw.projectPorts)
+}
+
+// configuredServerPort returns server.port of config/default.yml, or 8080
+// when the file does not set it.
+func configuredServerPort(projectRoot string) int {
+	v := viper.New()
+	v.SetConfigName("default")
+	v.SetConfigType("yml")
+	v.AddConfigPath(filepath.Join(projectRoot, "config"))
+	if err := v.ReadInConfig(); err == nil && v.GetInt("server.port") != 0 {
+		return v.GetInt("server.port")
+	}
+	return 8080 // Default
 }
 
 // Start begins watching and starts the initial process
@@ -184,6 +270,9 @@ func (aw *AdvancedWatcher) Start() error {
 	
 	// Initialize process manager
 	aw.processManager = NewProcessManager(aw.projectRoot, aw.script, aw.verbose)
+	if aw.logFile != nil {
+		aw.processManager.logFile = aw.logFile
+	}
 	
 	// Initialize port manager
 	aw.portManager = NewPortManager()
@@ -193,14 +282,24 @@ func (aw *AdvancedWatcher) Start() error {
 		return fmt.Errorf("failed to setup watch paths: %w", err)
 	}
 	
+	if aw.liveReload != nil {
+		if err := aw.liveReload.Start(); err != nil {
+			return err
+		}
+	}
+	
 	// Start the initial process
 	logger.Info("🚀 Starting initial process...")
-	if err := aw.processManager.Start(); err != nil {
+	aw.mu.Lock()
+	err = aw.launch()
+	aw.mu.Unlock()
+	if err != nil {
 		return fmt.Errorf("failed to start initial process: %w", err)
 	}
 	
 	// Start watching for file changes
 	go aw.watchLoop()
+	go aw.listenForRollback()
 	
 	return nil
 }
@@ -220,7 +319,8 @@ func (aw *AdvancedWatcher) watchLoop() {
 				continue
 			}
 			
-			logger.Debug("File changed: %s (%s)", event.Name, event.Op)
+			changed := logger.Fields{"file": event.Name, "op": event.Op.String()}
+			logger.WithFields(changed).Debug("File changed: %s (%s)", event.Name, event.Op)
 			
 			// Prevent rapid restarts
 			if time.Since(lastRestart) < 2*time.Second {
@@ -231,7 +331,7 @@ func (aw *AdvancedWatcher) watchLoop() {
 			// Debounce the restart
 			aw.debouncer.Debounce(func() {
 				lastRestart = time.Now()
-				logger.Info("🔄 Changes detected, restarting...")
+				logger.WithFields(changed).Info("🔄 Changes detected, restarting...")
 				
 				if err := aw.smartRestart(); err != nil {
 					logger.Error("Failed to restart: %v", err)
@@ -249,17 +349,25 @@ func (aw *AdvancedWatcher) watchLoop() {
 
 // smartRestart performs an intelligent restart with port management
 func (aw *AdvancedWatcher) smartRestart() error {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	// Step 0: Run the dev.on_change commands, e.g. code generators
+	aw.runOnChange()
+
 	// Step 1: Stop the current process gracefully
 	logger.Debug("Stopping current process...")
 	if err := aw.processManager.Stop(); err != nil {
 		logger.Warn("Error stopping process: %v", err)
 	}
 	
-	// Step 2: Ensure port is available
-	logger.Debug("Ensuring port %d is available...", aw.projectPort)
-	if err := aw.portManager.EnsurePortAvailable(aw.projectPort, 8*time.Second); err != nil {
-		logger.Warn("Port cleanup may have failed: %v", err)
-		// Continue anyway - the process start might still work
+	// Step 2: Ensure the ports are available
+	for _, port := range aw.projectPorts {
+		logger.Debug("Ensuring port %d is available...", port)
+		if err := aw.portManager.EnsurePortAvailable(port, 8*time.Second); err != nil {
+			logger.Warn("Port cleanup may have failed: %v", err)
+			// Continue anyway - the process start might still work
+		}
 	}
 	
 	// Step 3: Wait a moment for system cleanup
@@ -267,14 +375,34 @@ func (aw *AdvancedWatcher) smartRestart() error {
 	
 	// Step 4: Start new process
 	logger.Debug("Starting new process...")
-	if err := aw.processManager.Start(); err != nil {
+	if err := aw.launch(); err != nil {
 		return fmt.Errorf("failed to start new process: %w", err)
 	}
 	
 	logger.Success("✅ Process restarted successfully")
+	if aw.liveReload != nil {
+		go aw.liveReload.ReloadWhenUp(aw.projectPorts[0], 15*time.Second)
+	}
 	return nil
 }
 
+// runOnChange runs the dev.on_change commands in order. A failing command
+// is reported but does not hold back the restart, which shows whether the
+// code still builds.
+func (aw *AdvancedWatcher) runOnChange() {
+	for _, command := range aw.onChange {
+		logger.Debug("Running on_change command: %s", command)
+		cmd := exec.Command("sh", "-c", command)
+		cmd.Dir = aw.projectRoot
+		if out, err := cmd.CombinedOutput(); err != nil {
+			logger.Warn("⚠️  on_change command '%s' failed: %v", command, err)
+			if len(out) > 0 {
+				logger.Warn("%s", strings.TrimRight(string(out), "\n"))
+			}
+		}
+	}
+}
+
 // shouldIgnoreEvent determines if a file change event should be ignored
 func (aw *AdvancedWatcher) shouldIgnoreEvent(event fsnotify.Event) bool {
 	// Only care about write and create events
@@ -287,37 +415,19 @@ func (aw *AdvancedWatcher) shouldIgnoreEvent(event fsnotify.Event) bool {
 		return true
 	}
 	
-	// Check ignore patterns
-	for _, pattern := range aw.ignorePatterns {
-		if matched, _ := filepath.Match(pattern, relPath); matched {
+	// Writing the log file must not restart the script, nor must rotating it
+	if aw.logFile != nil {
+		if path, err := filepath.Abs(event.Name); err == nil && strings.HasPrefix(path, aw.logFile.Path()) {
 			return true
 		}
-		
-		// Check if any directory in the path matches
-		dirs := strings.Split(filepath.Dir(relPath), string(filepath.Separator))
-		for _, dir := range dirs {
-			if matched, _ := filepath.Match(strings.TrimSuffix(pattern, "/**"), dir); matched {
-				return true
-			}
-		}
 	}
 	
-	// Check if file matches watch patterns
-	for _, pattern := range aw.watchPatterns {
-		if matched, _ := filepath.Match(pattern, relPath); matched {
-			return false // Should watch this file
-		}
-		
-		// Check extension-based patterns
-		if strings.HasPrefix(pattern, "**/*") {
-			ext := strings.TrimPrefix(pattern, "**/*")
-			if strings.HasSuffix(relPath, ext) {
-				return false // Should watch this file
-			}
-		}
+	// Ignore patterns take precedence over watch patterns
+	if aw.ignoreSet.Match(relPath) {
+		return true
 	}
 	
-	return true // Ignore by default
+	return !aw.watchSet.Match(relPath)
 }
 
 // addWatchPaths recursively adds directories to the file watcher
@@ -336,13 +446,10 @@ func (aw *AdvancedWatcher) addWatchPaths() error {
 			return err
 		}
 		
-		// Check if directory should be ignored
-		for _, pattern := range aw.ignorePatterns {
-			dirPattern := strings.TrimSuffix(pattern, "/**")
-			if matched, _ := filepath.Match(dirPattern, relPath); matched {
-				logger.Debug("Ignoring directory: %s", relPath)
-				return filepath.SkipDir
-			}
+		// Check if directory should be ignored ("dist/**" also matches "dist")
+		if relPath != "." && aw.ignoreSet.Match(relPath) {
+			logger.Debug("Ignoring directory: %s", relPath)
+			return filepath.SkipDir
 		}
 		
 		logger.Debug("Watching directory: %s", relPath)
@@ -366,6 +473,12 @@ func (aw *AdvancedWatcher) Stop() error {
 		}
 	}
 	
+	if aw.liveReload != nil {
+		if err := aw.liveReload.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("live reload: %w", err))
+		}
+	}
+	
 	if len(errs) > 0 {
 		return fmt.Errorf("shutdown errors: %v", errs)
 	}
@@ -386,6 +499,13 @@ type ProcessManager struct {
 	cmd      *exec.Cmd
 	ctx      context.Context
 	cancel   context.CancelFunc
+
+	// onExit is called when the process exits without being stopped.
+	onExit   func(err error, uptime time.Duration)
+
+	// logFile also gets the output of the process in verbose mode; the
+	// logger copies it there otherwise.
+	logFile  io.Writer
 }
 
 // NewProcessManager creates a new process manager
@@ -407,9 +527,19 @@ func (pm *ProcessManager) Start() error {
 	// Set up process group for better control
 	pm.cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
 	
+	// Copies of the output in the log file, closed when the process exits
+	// to write a last line without a newline
+	var logs []io.Closer
 	if pm.verbose {
 		pm.cmd.Stdout = os.Stdout
 		pm.cmd.Stderr = os.Stderr
+		if pm.logFile != nil {
+			stdoutLog := logger.TimestampLines(pm.logFile, "STDOUT")
+			stderrLog := logger.TimestampLines(pm.logFile, "STDERR")
+			logs = append(logs, stdoutLog, stderrLog)
+			pm.cmd.Stdout = io.MultiWriter(os.Stdout, stdoutLog)
+			pm.cmd.Stderr = io.MultiWriter(os.Stderr, stderrLog)
+		}
 	} else {
 		// Capture output for smart filtering
 		stdout, err := pm.cmd.StdoutPipe()
@@ -430,15 +560,25 @@ func (pm *ProcessManager) Start() error {
 		return fmt.Errorf("failed to start process: %w", err)
 	}
 	
-	logger.Success("✅ Process started (PID: %d)", pm.cmd.Process.Pid)
+	logger.WithFields(logger.Fields{"pid": pm.cmd.Process.Pid}).Success("✅ Process started (PID: %d)", pm.cmd.Process.Pid)
 	
 	// Monitor process completion
+	cmd, ctx, onExit, started := pm.cmd, pm.ctx, pm.onExit, time.Now()
 	go func() {
-		err := pm.cmd.Wait()
-		if err != nil && pm.ctx.Err() == nil {
+		err := cmd.Wait()
+		for _, log := range logs {
+			log.Close()
+		}
+		if ctx.Err() != nil {
+			return // Stopped on purpose
+		}
+		if err != nil {
 			// Process died unexpectedly (not due to cancellation)
 			logger.Error("❌ Process exited unexpectedly: %v", err)
 		}
+		if onExit != nil {
+			onExit(err, time.Since(started))
+		}
 	}()
 	
 	return nil
@@ -446,6 +586,12 @@ func (pm *ProcessManager) Start() error {
 
 // handleOutput processes stdout/stderr with smart filtering
 func (pm *ProcessManager) handleOutput(pipe io.Reader, isError bool) {
+	stream := "stdout"
+	if isError {
+		stream = "stderr"
+	}
+	log := logger.WithFields(logger.Fields{"script": pm.script, "stream": stream})
+	
 	scanner := bufio.NewScanner(pipe)
 	for scanner.Scan() {
 		line := scanner.Text()
@@ -456,13 +602,13 @@ func (pm *ProcessManager) handleOutput(pipe io.Reader, isError bool) {
 		}
 		
 		if isError {
-			logger.Error("🔴 %s", line)
+			log.Error("🔴 %s", line)
 		} else {
 			// Highlight important messages
 			if pm.isImportantLine(line) {
-				logger.Success("🟢 %s", line)
+				log.Success("🟢 %s", line)
 			} else {
-				logger.Info("⚪ %s", line)
+				log.Info("⚪ %s", line)
 			}
 		}
 	}
@@ -685,18 +831,29 @@ func (d *Debouncer) Debounce(fn func()) {
 	d.timer = time.AfterFunc(d.duration, fn)
 }
 
+// scriptNotFound is the error for a script missing from scripts: it
+// suggests the script a typo most likely meant, or lists them all when
+// none is close.
+func scriptNotFound(scriptName string, scripts map[string]string) error {
+	if suggestion := utils.Closest(scriptName, slices.Collect(maps.Keys(scripts))); suggestion != "" {
+		return exitcode.Errorf(exitcode.Validation, "script '%s' not found in goforge.yml, did you mean '%s'?", scriptName, suggestion)
+	}
+	return exitcode.Errorf(exitcode.Validation, "script '%s' not found in goforge.yml\n\nAvailable scripts:\n%s", scriptName, formatAvailableScripts(scripts))
+}
+
 func formatAvailableScripts(scripts map[string]string) string {
 	if len(scripts) == 0 {
 		return "  No scripts defined"
 	}
 
 	result := ""
-	for name, command := range scripts {
-		result += fmt.Sprintf("  %s: %s\n", name, command)
+	for _, name := range slices.Sorted(maps.Keys(scripts)) {
+		result += fmt.Sprintf("  %s: %s\n", name, scripts[name])
 	}
 	return result
 }
 
 func init() {
-	watchCmd.Flags().BoolP("verbose", "v", false, "Enable verbose logging")
-}
\ No newline at end of file
+	serviceFlag(watchCmd.Flags())
+	watchCmd.Flags().String("log-file", "", "Also write the output to this log file, with timestamps, rotated at 10 MB (default: dev.log_file)")
+}
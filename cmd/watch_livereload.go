@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+const (
+	// defaultLiveReloadPort serves the live reload events unless
+	// dev.live_reload_port says otherwise.
+	defaultLiveReloadPort = 35729
+
+	// liveReloadEnv tells the watched process where the live reload server
+	// is, so it can add the script to its pages only in watch mode.
+	liveReloadEnv = "GOFORGE_LIVE_RELOAD"
+)
+
+// liveReloadScript reconnects after the server goes away and reloads the
+// page on every reload event.
+const liveReloadScript = `(function () {
+  var url = document.currentScript.src.replace(/\/livereload\.js.*$/, "/livereload");
+  function connect() {
+    var source = new EventSource(url);
+    source.addEventListener("reload", function () { location.reload(); });
+    source.onerror = function () { source.close(); setTimeout(connect, 1000); };
+  }
+  connect();
+})();
+`
+
+// LiveReloadServer tells browsers to reload once the watched process has
+// restarted, through server-sent events on /livereload. Pages load the
+// client from /livereload.js.
+type LiveReloadServer struct {
+	port    int
+	server  *http.Server
+	mu      sync.Mutex
+	clients map[chan struct{}]bool
+}
+
+// NewLiveReloadServer returns a server for the given port.
+func NewLiveReloadServer(port int) *LiveReloadServer {
+	if port == 0 {
+		port = defaultLiveReloadPort
+	}
+	return &LiveReloadServer{port: port, clients: make(map[chan struct{}]bool)}
+}
+
+// URL is the base URL of the server, exported to the watched process as
+// GOFORGE_LIVE_RELOAD.
+func (lr *LiveReloadServer) URL() string {
+	return fmt.Sprintf("http://localhost:%d", lr.port)
+}
+
+// Start listens in the background and exports GOFORGE_LIVE_RELOAD.
+func (lr *LiveReloadServer) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("localhost:%d", lr.port))
+	if err != nil {
+		return fmt.Errorf("live reload: %w (set dev.live_reload_port to use another port)", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livereload.js", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Header().Set("Cache-Control", "no-store")
+		fmt.Fprint(w, liveReloadScript)
+	})
+	mux.HandleFunc("/livereload", lr.serveEvents)
+	lr.server = &http.Server{Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		if err := lr.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Warn("Live reload server stopped: %v", err)
+		}
+	}()
+	logger.Info("🔁 Live reload: %s/livereload.js", lr.URL())
+	return os.Setenv(liveReloadEnv, lr.URL())
+}
+
+// serveEvents streams a reload event to the browser on every restart.
+func (lr *LiveReloadServer) serveEvents(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	fmt.Fprint(w, ": connected\n\n")
+	flusher.Flush()
+
+	reload := make(chan struct{}, 1)
+	lr.mu.Lock()
+	lr.clients[reload] = true
+	lr.mu.Unlock()
+	defer func() {
+		lr.mu.Lock()
+		delete(lr.clients, reload)
+		lr.mu.Unlock()
+	}()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-reload:
+			fmt.Fprint(w, "event: reload\ndata: {}\n\n")
+			flusher.Flush()
+		}
+	}
+}
+
+// ReloadWhenUp reloads the connected browsers once the process accepts
+// connections on port, giving up after timeout.
+func (lr *LiveReloadServer) ReloadWhenUp(port int, timeout time.Duration) {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		conn, err := net.DialTimeout("tcp", fmt.Sprintf("localhost:%d", port), 500*time.Millisecond)
+		if err == nil {
+			conn.Close()
+			lr.Reload()
+			return
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+	logger.Debug("Port %d did not open within %s; not reloading browsers", port, timeout)
+}
+
+// Reload sends a reload event to every connected browser.
+func (lr *LiveReloadServer) Reload() {
+	lr.mu.Lock()
+	defer lr.mu.Unlock()
+	for client := range lr.clients {
+		select {
+		case client <- struct{}{}:
+		default: // A reload is already pending
+		}
+	}
+	logger.Debug("Sent live reload to %d browser(s)", len(lr.clients))
+}
+
+// Close stops the server.
+func (lr *LiveReloadServer) Close() error {
+	if lr.server == nil {
+		return nil
+	}
+	return lr.server.Close()
+}
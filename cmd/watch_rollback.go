@@ -0,0 +1,255 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/interactive"
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+const (
+	// crashWindow is how soon after start an exit counts as a crash.
+	crashWindow = 5 * time.Second
+	// stableAfter is how long a fresh build must stay up before it
+	// becomes the rollback target.
+	stableAfter = 5 * time.Second
+)
+
+// BuildKeeper builds the watched program into .goforge/watch and keeps the
+// last binary that started successfully, so watch mode can fall back to it
+// when a change breaks the build or makes the process crash.
+type BuildKeeper struct {
+	projectRoot string
+	buildFlags  []string
+	pkg         string
+	args        []string
+	dir         string
+}
+
+// NewBuildKeeper returns a keeper for scripts of the form
+// 'go run [build flags] <package> [args]', or nil for any other script.
+func NewBuildKeeper(projectRoot, script string) *BuildKeeper {
+	fields := strings.Fields(script)
+	if len(fields) < 3 || fields[0] != "go" || fields[1] != "run" {
+		return nil
+	}
+
+	bk := &BuildKeeper{
+		projectRoot: projectRoot,
+		dir:         filepath.Join(projectRoot, ".goforge", "watch"),
+	}
+
+	// Flags that take a separate value argument.
+	valueFlags := map[string]bool{"-tags": true, "-ldflags": true, "-gcflags": true, "-mod": true}
+
+	rest := fields[2:]
+	for len(rest) > 0 && strings.HasPrefix(rest[0], "-") {
+		bk.buildFlags = append(bk.buildFlags, rest[0])
+		if valueFlags[rest[0]] && len(rest) > 1 {
+			bk.buildFlags = append(bk.buildFlags, rest[1])
+			rest = rest[1:]
+		}
+		rest = rest[1:]
+	}
+	if len(rest) == 0 || strings.HasSuffix(rest[0], ".go") {
+		// Single-file 'go run main.go' scripts are left alone.
+		return nil
+	}
+
+	bk.pkg = rest[0]
+	bk.args = rest[1:]
+	return bk
+}
+
+func (bk *BuildKeeper) binaryPath(name string) string {
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(bk.dir, name)
+}
+
+// Build compiles the package into the current binary.
+func (bk *BuildKeeper) Build() error {
+	if err := os.MkdirAll(bk.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	args := append([]string{"build", "-o", bk.binaryPath("current")}, bk.buildFlags...)
+	args = append(args, bk.pkg)
+
+	cmd := exec.Command("go", args...)
+	cmd.Dir = bk.projectRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Promote marks the current binary as the last known good build.
+func (bk *BuildKeeper) Promote() error {
+	return copyFile(bk.binaryPath("current"), bk.binaryPath("last-good"))
+}
+
+// HasLastGood reports whether a rollback target exists.
+func (bk *BuildKeeper) HasLastGood() bool {
+	_, err := os.Stat(bk.binaryPath("last-good"))
+	return err == nil
+}
+
+// CurrentCommand is the shell command running the fresh build.
+func (bk *BuildKeeper) CurrentCommand() string {
+	return bk.command("current")
+}
+
+// LastGoodCommand is the shell command running the rollback binary.
+func (bk *BuildKeeper) LastGoodCommand() string {
+	return bk.command("last-good")
+}
+
+func (bk *BuildKeeper) command(name string) string {
+	parts := []string{shellQuote(bk.binaryPath(name))}
+	parts = append(parts, bk.args...)
+	return strings.Join(parts, " ")
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// launch starts the watched program. With a BuildKeeper it builds first
+// and falls back to the last good binary on failure; otherwise it just
+// runs the script.
+func (aw *AdvancedWatcher) launch() error {
+	if aw.builds == nil {
+		return aw.startProcess(aw.script, false)
+	}
+
+	logger.Info("🔨 Building %s...", aw.builds.pkg)
+	if err := aw.builds.Build(); err != nil {
+		logger.Error("❌ Build failed: %v", err)
+		aw.offerRollback()
+		return nil
+	}
+
+	return aw.startProcess(aw.builds.CurrentCommand(), false)
+}
+
+// startProcess runs command and tracks it so crashes and promotion can be
+// attributed to the right run.
+func (aw *AdvancedWatcher) startProcess(command string, lastGood bool) error {
+	aw.generation++
+	generation := aw.generation
+	aw.runningLastGood = lastGood
+	aw.rollbackOffered = false
+
+	aw.processManager.script = command
+	aw.processManager.onExit = func(err error, uptime time.Duration) {
+		aw.handleExit(generation, err, uptime)
+	}
+
+	if err := aw.processManager.Start(); err != nil {
+		return err
+	}
+
+	if aw.builds != nil && !lastGood {
+		time.AfterFunc(stableAfter, func() {
+			aw.mu.Lock()
+			defer aw.mu.Unlock()
+			if aw.generation != generation || aw.crashedGeneration == generation {
+				return
+			}
+			if err := aw.builds.Promote(); err != nil {
+				logger.Debug("Could not save last good build: %v", err)
+				return
+			}
+			logger.Debug("Saved last good build")
+		})
+	}
+
+	return nil
+}
+
+// handleExit reacts to the watched process exiting on its own.
+func (aw *AdvancedWatcher) handleExit(generation int, err error, uptime time.Duration) {
+	aw.mu.Lock()
+	defer aw.mu.Unlock()
+
+	if aw.generation != generation {
+		return
+	}
+	aw.crashedGeneration = generation
+
+	if aw.builds == nil || aw.runningLastGood || err == nil || uptime > crashWindow {
+		return
+	}
+
+	logger.Error("💥 Process crashed %v after start", uptime.Round(time.Millisecond))
+	aw.offerRollback()
+}
+
+// offerRollback rolls back automatically when dev.rollback is enabled,
+// otherwise tells the user how to do it.
+func (aw *AdvancedWatcher) offerRollback() {
+	if !aw.builds.HasLastGood() {
+		logger.Warn("No previous successful build to roll back to; waiting for changes...")
+		return
+	}
+
+	if aw.autoRollback {
+		if err := aw.rollbackToLastGood(); err != nil {
+			logger.Error("Rollback failed: %v", err)
+		}
+		return
+	}
+
+	aw.rollbackOffered = true
+	if interactive.IsInteractiveTerminal() {
+		logger.Warn("💡 Type 'r' and press Enter to roll back to the last successful build (or set dev.rollback: true)")
+	} else {
+		logger.Warn("💡 Set dev.rollback: true in goforge.yml to roll back to the last successful build automatically")
+	}
+}
+
+// rollbackToLastGood runs the last good binary until the next change.
+func (aw *AdvancedWatcher) rollbackToLastGood() error {
+	if err := aw.processManager.Stop(); err != nil {
+		logger.Warn("Error stopping process: %v", err)
+	}
+	if err := aw.startProcess(aw.builds.LastGoodCommand(), true); err != nil {
+		return err
+	}
+	logger.Success("⏪ Rolled back to the last successful build; fix the error and save to retry")
+	return nil
+}
+
+// listenForRollback lets the user accept a rollback offer from the terminal.
+func (aw *AdvancedWatcher) listenForRollback() {
+	if aw.builds == nil || !interactive.IsInteractiveTerminal() {
+		return
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		if strings.TrimSpace(strings.ToLower(scanner.Text())) != "r" {
+			continue
+		}
+
+		aw.mu.Lock()
+		if aw.rollbackOffered {
+			if err := aw.rollbackToLastGood(); err != nil {
+				logger.Error("Rollback failed: %v", err)
+			}
+		} else {
+			logger.Info("Nothing to roll back")
+		}
+		aw.mu.Unlock()
+	}
+}
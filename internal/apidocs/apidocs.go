@@ -0,0 +1,160 @@
+// Package apidocs builds an OpenAPI 3 document from the swaggo-style
+// annotations (@Summary, @Param, @Success, @Router, ...) in a project's
+// handler comments.
+package apidocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Document is the generated OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Servers    []Server                         `json:"servers,omitempty"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components *Components                      `json:"components,omitempty"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	URL string `json:"url"`
+}
+
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+type Operation struct {
+	OperationID string               `json:"operationId,omitempty"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Deprecated  bool                 `json:"deprecated,omitempty"`
+	Parameters  []*Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Description string               `json:"description,omitempty"`
+	Required    bool                 `json:"required,omitempty"`
+	Content     map[string]MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema is a JSON schema as used by OpenAPI 3.0.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Default              any                `json:"default,omitempty"`
+}
+
+// Options control what is scanned.
+type Options struct {
+	ProjectName string   // Default title
+	ModulePath  string   // Module of the scanned code, to resolve imports
+	Dirs        []string // Directories to scan, relative to the root; all when empty
+}
+
+// Result is a built document with the problems found on the way.
+type Result struct {
+	Document   *Document
+	Operations int
+	Warnings   []string // e.g. "internal/x.go:12: @Param needs ..."
+}
+
+// Build scans the Go files under root and builds the document from the
+// annotated functions. Annotations that cannot be understood are reported
+// as warnings and skipped.
+func Build(root string, options Options) (*Result, error) {
+	scan, err := scanProject(root, options)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: options.ProjectName + " API", Version: "1.0.0"},
+		Paths:   make(map[string]map[string]*Operation),
+	}
+	if scan.info.Title != "" {
+		doc.Info.Title = scan.info.Title
+	}
+	if scan.info.Version != "" {
+		doc.Info.Version = scan.info.Version
+	}
+	doc.Info.Description = scan.info.Description
+	if url := scan.serverURL(); url != "" {
+		doc.Servers = []Server{{URL: url}}
+	}
+
+	result := &Result{Document: doc, Warnings: scan.warnings}
+	for _, op := range scan.operations {
+		methods, ok := doc.Paths[op.path]
+		if !ok {
+			methods = make(map[string]*Operation)
+			doc.Paths[op.path] = methods
+		}
+		if _, ok := methods[op.method]; ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: %s %s is documented twice; keeping the later one", op.pos, strings.ToUpper(op.method), op.path))
+		} else {
+			result.Operations++
+		}
+		if len(op.operation.Responses) == 0 {
+			op.operation.Responses["200"] = &Response{Description: http.StatusText(http.StatusOK)}
+		}
+		methods[op.method] = op.operation
+	}
+	if len(scan.types.schemas) > 0 {
+		doc.Components = &Components{Schemas: scan.types.schemas}
+	}
+	sort.Strings(result.Warnings)
+	return result, nil
+}
+
+// Write saves the document as indented JSON, creating its directory.
+func Write(doc *Document, file string) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, append(data, '\n'), 0644)
+}
@@ -0,0 +1,502 @@
+package apidocs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ginParamPattern matches gin path parameters such as :id.
+var ginParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+// majorVersionPattern matches the major version element of an import path.
+var majorVersionPattern = regexp.MustCompile(`^v[0-9]+$`)
+
+// mimeAliases are the short content types swaggo accepts in @Accept and
+// @Produce.
+var mimeAliases = map[string]string{
+	"json":                  "application/json",
+	"xml":                   "application/xml",
+	"plain":                 "text/plain",
+	"html":                  "text/html",
+	"mpfd":                  "multipart/form-data",
+	"x-www-form-urlencoded": "application/x-www-form-urlencoded",
+	"octet-stream":          "application/octet-stream",
+}
+
+// sourceFile is a parsed Go file of the project.
+type sourceFile struct {
+	rel     string // path relative to the project root
+	pkgPath string // import path of its package
+	fset    *token.FileSet
+	ast     *ast.File
+}
+
+// importPath returns the path imported under name in the file, or "".
+func (f *sourceFile) importPath(name string) string {
+	for _, spec := range f.ast.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		if spec.Name != nil {
+			if spec.Name.Name == name {
+				return importPath
+			}
+			continue
+		}
+		if importName(importPath) == name {
+			return importPath
+		}
+	}
+	return ""
+}
+
+// position formats pos as file:line for warnings.
+func (f *sourceFile) position(pos token.Pos) string {
+	return fmt.Sprintf("%s:%d", filepath.ToSlash(f.rel), f.fset.Position(pos).Line)
+}
+
+// importName guesses the package name of an import path: its last element
+// without a major version suffix (v2) or gopkg.in version (.v3).
+func importName(importPath string) string {
+	elems := strings.Split(importPath, "/")
+	name := elems[len(elems)-1]
+	if len(elems) > 1 && majorVersionPattern.MatchString(name) {
+		name = elems[len(elems)-2]
+	}
+	if i := strings.Index(name, ".v"); i > 0 {
+		name = name[:i]
+	}
+	return strings.ReplaceAll(name, "-", "_")
+}
+
+// generalInfo is the API description given once, usually above main.
+type generalInfo struct {
+	Title       string
+	Version     string
+	Description string
+	Host        string
+	BasePath    string
+	Schemes     []string
+}
+
+type scannedOperation struct {
+	pos       string
+	path      string
+	method    string
+	operation *Operation
+}
+
+type scanResult struct {
+	info       generalInfo
+	operations []*scannedOperation
+	types      *typeIndex
+	warnings   []string
+}
+
+// serverURL combines @schemes, @host and @BasePath into the server URL.
+func (r *scanResult) serverURL() string {
+	if r.info.Host == "" {
+		return r.info.BasePath
+	}
+	scheme := "http"
+	if len(r.info.Schemes) > 0 {
+		scheme = r.info.Schemes[0]
+	}
+	return scheme + "://" + r.info.Host + r.info.BasePath
+}
+
+func (r *scanResult) warn(pos, format string, args ...any) {
+	r.warnings = append(r.warnings, pos+": "+fmt.Sprintf(format, args...))
+}
+
+// scanProject parses the Go files in the scanned directories, skipping
+// tests, vendor, testdata and hidden directories, and collects the
+// annotations.
+func scanProject(root string, options Options) (*scanResult, error) {
+	dirs := options.Dirs
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	result := &scanResult{types: newTypeIndex()}
+	seen := make(map[string]bool)
+	var files []*sourceFile
+	for _, dir := range dirs {
+		start := filepath.Join(root, dir)
+		if _, err := os.Stat(start); err != nil {
+			return nil, err
+		}
+		err := filepath.WalkDir(start, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			name := entry.Name()
+			if entry.IsDir() {
+				if file != start && (strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" || name == "node_modules") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") || seen[file] {
+				return nil
+			}
+			seen[file] = true
+
+			rel, _ := filepath.Rel(root, file)
+			fset := token.NewFileSet()
+			parsed, err := parser.ParseFile(fset, file, nil, parser.ParseComments)
+			if err != nil {
+				result.warnings = append(result.warnings, fmt.Sprintf("%s: skipped: %v", filepath.ToSlash(rel), err))
+				return nil
+			}
+			pkgPath := options.ModulePath
+			if relDir := filepath.ToSlash(filepath.Dir(rel)); relDir != "." {
+				pkgPath = path.Join(options.ModulePath, relDir)
+			}
+			source := &sourceFile{rel: rel, pkgPath: pkgPath, fset: fset, ast: parsed}
+			files = append(files, source)
+			result.types.add(source)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, file := range files {
+		for _, group := range file.ast.Comments {
+			if !hasAnnotation(group, "@router") {
+				scanGeneralInfo(&result.info, group)
+			}
+		}
+		for _, decl := range file.ast.Decls {
+			if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil && hasAnnotation(fn.Doc, "@router") {
+				scanOperation(result, file, fn.Doc)
+			}
+		}
+	}
+	return result, nil
+}
+
+// annotations returns the @key value lines of a comment group.
+func annotations(group *ast.CommentGroup) []*ast.Comment {
+	var lines []*ast.Comment
+	for _, comment := range group.List {
+		if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(comment.Text, "//")), "@") {
+			lines = append(lines, comment)
+		}
+	}
+	return lines
+}
+
+// splitAnnotation returns the key and the value of an annotation line.
+func splitAnnotation(comment *ast.Comment) (string, string) {
+	text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+	key := strings.Fields(text)[0]
+	return key, strings.TrimSpace(text[len(key):])
+}
+
+func hasAnnotation(group *ast.CommentGroup, key string) bool {
+	for _, comment := range annotations(group) {
+		if name, _ := splitAnnotation(comment); strings.ToLower(name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+// scanGeneralInfo reads @title, @version, @description, @host, @BasePath
+// and @schemes.
+func scanGeneralInfo(info *generalInfo, group *ast.CommentGroup) {
+	for _, comment := range annotations(group) {
+		key, value := splitAnnotation(comment)
+		switch key {
+		case "@title":
+			info.Title = value
+		case "@version":
+			info.Version = value
+		case "@description":
+			info.Description = strings.TrimSpace(info.Description + "\n" + value)
+		case "@host":
+			info.Host = value
+		case "@BasePath":
+			info.BasePath = strings.TrimRight(value, "/")
+		case "@schemes":
+			info.Schemes = strings.Fields(value)
+		}
+	}
+}
+
+// scanOperation reads the annotations of a handler's doc comment.
+func scanOperation(result *scanResult, file *sourceFile, doc *ast.CommentGroup) {
+	op := &Operation{Responses: make(map[string]*Response)}
+	accept := []string{"application/json"}
+	produce := []string{"application/json"}
+
+	var params, responses, routes []*ast.Comment
+	for _, comment := range annotations(doc) {
+		key, value := splitAnnotation(comment)
+		switch strings.ToLower(key) {
+		case "@summary":
+			op.Summary = value
+		case "@description":
+			op.Description = strings.TrimSpace(op.Description + "\n" + value)
+		case "@tags":
+			for _, tag := range strings.Split(value, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					op.Tags = append(op.Tags, tag)
+				}
+			}
+		case "@id":
+			op.OperationID = value
+		case "@accept":
+			accept = mimeTypes(value)
+		case "@produce":
+			produce = mimeTypes(value)
+		case "@deprecated":
+			op.Deprecated = true
+		case "@param":
+			params = append(params, comment)
+		case "@success", "@failure", "@response":
+			responses = append(responses, comment)
+		case "@router":
+			routes = append(routes, comment)
+		}
+	}
+
+	for _, comment := range params {
+		scanParam(result, file, op, comment, accept)
+	}
+	for _, comment := range responses {
+		scanResponse(result, file, op, comment, produce)
+	}
+	for _, comment := range routes {
+		_, value := splitAnnotation(comment)
+		fields := strings.Fields(value)
+		if len(fields) != 2 || !strings.HasPrefix(fields[1], "[") || !strings.HasSuffix(fields[1], "]") {
+			result.warn(file.position(comment.Pos()), "@Router expects a path and [method], e.g. /users/{id} [get]")
+			continue
+		}
+		method := strings.ToLower(strings.Trim(fields[1], "[]"))
+		switch method {
+		case "get", "post", "put", "patch", "delete", "head", "options":
+		default:
+			result.warn(file.position(comment.Pos()), "unknown method [%s] in @Router", method)
+			continue
+		}
+		result.operations = append(result.operations, &scannedOperation{
+			pos:       file.position(comment.Pos()),
+			path:      ginParamPattern.ReplaceAllString(fields[0], "{$1}"),
+			method:    method,
+			operation: op,
+		})
+	}
+}
+
+// scanParam reads "@Param name in type required "description" attributes".
+func scanParam(result *scanResult, file *sourceFile, op *Operation, comment *ast.Comment, accept []string) {
+	_, value := splitAnnotation(comment)
+	args := splitArgs(value)
+	if len(args) < 4 {
+		result.warn(file.position(comment.Pos()), `@Param expects name, in, type and required, e.g. id path int true "User ID"`)
+		return
+	}
+	name, in, typeName := args[0], args[1], args[2]
+	required, err := strconv.ParseBool(args[3])
+	if err != nil {
+		result.warn(file.position(comment.Pos()), "@Param %s: required must be true or false, not %q", name, args[3])
+		return
+	}
+	description := ""
+	attributes := args[4:]
+	if len(attributes) > 0 && !strings.Contains(attributes[0], "(") {
+		description, attributes = attributes[0], attributes[1:]
+	}
+
+	schema, err := result.types.schemaForType(typeName, file)
+	if err != nil {
+		result.warn(file.position(comment.Pos()), "@Param %s: %v", name, err)
+		return
+	}
+	applyAttributes(schema, attributes)
+
+	switch in {
+	case "body":
+		content := make(map[string]MediaType, len(accept))
+		for _, contentType := range accept {
+			content[contentType] = MediaType{Schema: schema}
+		}
+		op.RequestBody = &RequestBody{Description: description, Required: required, Content: content}
+	case "formData":
+		contentType := "application/x-www-form-urlencoded"
+		if typeName == "file" {
+			contentType = "multipart/form-data"
+		}
+		if op.RequestBody == nil || op.RequestBody.Content[contentType].Schema == nil {
+			form := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+			op.RequestBody = &RequestBody{Content: map[string]MediaType{contentType: {Schema: form}}}
+		}
+		form := op.RequestBody.Content[contentType].Schema
+		schema.Description = description
+		form.Properties[name] = schema
+		if required {
+			form.Required = append(form.Required, name)
+		}
+	case "path", "query", "header", "cookie":
+		op.Parameters = append(op.Parameters, &Parameter{
+			Name:        name,
+			In:          in,
+			Description: description,
+			Required:    required || in == "path",
+			Schema:      schema,
+		})
+	default:
+		result.warn(file.position(comment.Pos()), "@Param %s: unknown location %q (use path, query, header, cookie, body or formData)", name, in)
+	}
+}
+
+// scanResponse reads "@Success code {kind} type "description"".
+func scanResponse(result *scanResult, file *sourceFile, op *Operation, comment *ast.Comment, produce []string) {
+	_, value := splitAnnotation(comment)
+	args := splitArgs(value)
+	if len(args) == 0 {
+		result.warn(file.position(comment.Pos()), `@Success and @Failure expect a status code, e.g. 200 {object} domain.User`)
+		return
+	}
+	code := args[0]
+	response := &Response{}
+	if status, err := strconv.Atoi(code); err == nil {
+		response.Description = http.StatusText(status)
+	} else if code != "default" {
+		result.warn(file.position(comment.Pos()), "invalid status code %q", code)
+		return
+	}
+	args = args[1:]
+
+	if len(args) > 0 && strings.HasPrefix(args[0], "{") {
+		kind := strings.Trim(args[0], "{}")
+		if len(args) < 2 {
+			result.warn(file.position(comment.Pos()), "response %s: {%s} needs a type", code, kind)
+			return
+		}
+		// Generic wrappers such as response.JSON{data=User} are documented
+		// as the wrapper
+		typeName, _, _ := strings.Cut(args[1], "{")
+		schema, err := result.types.schemaForType(typeName, file)
+		if err != nil {
+			result.warn(file.position(comment.Pos()), "response %s: %v", code, err)
+			return
+		}
+		if kind == "array" {
+			schema = &Schema{Type: "array", Items: schema}
+		}
+		response.Content = make(map[string]MediaType, len(produce))
+		for _, contentType := range produce {
+			response.Content[contentType] = MediaType{Schema: schema}
+		}
+		args = args[2:]
+	}
+	if len(args) > 0 {
+		response.Description = args[0]
+	}
+	if response.Description == "" {
+		response.Description = code
+	}
+	op.Responses[code] = response
+}
+
+// applyAttributes applies parameter attributes such as enums(a,b) and
+// default(10).
+func applyAttributes(schema *Schema, attributes []string) {
+	for _, attribute := range attributes {
+		name, value, ok := strings.Cut(attribute, "(")
+		if !ok || !strings.HasSuffix(value, ")") {
+			continue
+		}
+		value = strings.TrimSuffix(value, ")")
+		switch strings.ToLower(name) {
+		case "enums":
+			for _, item := range strings.Split(value, ",") {
+				schema.Enum = append(schema.Enum, typedValue(schema, strings.TrimSpace(item)))
+			}
+		case "default":
+			schema.Default = typedValue(schema, value)
+		}
+	}
+}
+
+// typedValue converts an attribute value to the schema's type.
+func typedValue(schema *Schema, value string) any {
+	switch schema.Type {
+	case "integer":
+		if n, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return n
+		}
+	case "number":
+		if n, err := strconv.ParseFloat(value, 64); err == nil {
+			return n
+		}
+	case "boolean":
+		if b, err := strconv.ParseBool(value); err == nil {
+			return b
+		}
+	}
+	return value
+}
+
+// mimeTypes expands a comma-separated list of content types.
+func mimeTypes(value string) []string {
+	var types []string
+	for _, name := range strings.Split(value, ",") {
+		name = strings.TrimSpace(name)
+		if full, ok := mimeAliases[name]; ok {
+			name = full
+		}
+		if name != "" {
+			types = append(types, name)
+		}
+	}
+	return types
+}
+
+// splitArgs splits an annotation value on spaces, keeping quoted strings
+// and parenthesized attributes together and unquoting the former.
+func splitArgs(value string) []string {
+	var args []string
+	var current strings.Builder
+	quoted, depth, started := false, 0, false
+	flush := func() {
+		if started {
+			args = append(args, current.String())
+		}
+		current.Reset()
+		started = false
+	}
+	for _, r := range value {
+		switch {
+		case r == '"' && depth == 0:
+			quoted = !quoted
+			started = true
+		case (r == ' ' || r == '\t') && !quoted && depth == 0:
+			flush()
+		default:
+			if r == '(' && !quoted {
+				depth++
+			} else if r == ')' && !quoted && depth > 0 {
+				depth--
+			}
+			current.WriteRune(r)
+			started = true
+		}
+	}
+	flush()
+	return args
+}
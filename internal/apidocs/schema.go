@@ -0,0 +1,348 @@
+package apidocs
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// externalTypes are the schemas of common types from outside the project.
+var externalTypes = map[string]Schema{
+	"time.Time":                             {Type: "string", Format: "date-time"},
+	"time.Duration":                         {Type: "integer", Format: "int64"},
+	"encoding/json.RawMessage":              {},
+	"github.com/gin-gonic/gin.H":            {Type: "object"},
+	"github.com/google/uuid.UUID":           {Type: "string", Format: "uuid"},
+	"github.com/shopspring/decimal.Decimal": {Type: "string"},
+	"database/sql.NullString":               {Type: "string"},
+	"database/sql.NullInt64":                {Type: "integer", Format: "int64"},
+	"database/sql.NullInt32":                {Type: "integer", Format: "int32"},
+	"database/sql.NullFloat64":              {Type: "number", Format: "double"},
+	"database/sql.NullBool":                 {Type: "boolean"},
+	"database/sql.NullTime":                 {Type: "string", Format: "date-time"},
+}
+
+// typeEntry is a type declared in the project.
+type typeEntry struct {
+	spec *ast.TypeSpec
+	doc  string
+	file *sourceFile
+}
+
+// typeIndex resolves Go types of the project to schemas, declaring a
+// component for each named type it meets.
+type typeIndex struct {
+	types   map[string]map[string]typeEntry // import path -> type name -> declaration
+	names   map[string]string               // import path -> package name
+	byName  map[string][]string             // package name -> import paths
+	methods map[string]bool                 // "<import path>.<type>.<method>"
+	consts  map[string][]any                // "<import path>.<type>" -> constant values
+	schemas map[string]*Schema
+}
+
+func newTypeIndex() *typeIndex {
+	return &typeIndex{
+		types:   make(map[string]map[string]typeEntry),
+		names:   make(map[string]string),
+		byName:  make(map[string][]string),
+		methods: make(map[string]bool),
+		consts:  make(map[string][]any),
+		schemas: make(map[string]*Schema),
+	}
+}
+
+// add indexes the types, methods and typed constants of a file.
+func (x *typeIndex) add(file *sourceFile) {
+	pkgPath := file.pkgPath
+	if _, ok := x.names[pkgPath]; !ok {
+		x.names[pkgPath] = file.ast.Name.Name
+		x.byName[file.ast.Name.Name] = append(x.byName[file.ast.Name.Name], pkgPath)
+		x.types[pkgPath] = make(map[string]typeEntry)
+	}
+
+	for _, decl := range file.ast.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil || len(decl.Recv.List) == 0 {
+				continue
+			}
+			recv := decl.Recv.List[0].Type
+			if star, ok := recv.(*ast.StarExpr); ok {
+				recv = star.X
+			}
+			if ident, ok := recv.(*ast.Ident); ok {
+				x.methods[pkgPath+"."+ident.Name+"."+decl.Name.Name] = true
+			}
+		case *ast.GenDecl:
+			switch decl.Tok {
+			case token.TYPE:
+				for _, spec := range decl.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					doc := typeSpec.Doc
+					if doc == nil && len(decl.Specs) == 1 {
+						doc = decl.Doc
+					}
+					x.types[pkgPath][typeSpec.Name.Name] = typeEntry{spec: typeSpec, doc: docText(doc), file: file}
+				}
+			case token.CONST:
+				x.addConsts(pkgPath, decl)
+			}
+		}
+	}
+}
+
+// addConsts records the literal values of typed constants, which become
+// the enum of their type.
+func (x *typeIndex) addConsts(pkgPath string, decl *ast.GenDecl) {
+	for _, spec := range decl.Specs {
+		value := spec.(*ast.ValueSpec)
+		ident, ok := value.Type.(*ast.Ident)
+		if !ok || len(value.Values) != len(value.Names) {
+			continue
+		}
+		for _, expr := range value.Values {
+			literal, ok := expr.(*ast.BasicLit)
+			if !ok {
+				continue
+			}
+			key := pkgPath + "." + ident.Name
+			switch literal.Kind {
+			case token.STRING:
+				text, _ := strconv.Unquote(literal.Value)
+				x.consts[key] = append(x.consts[key], text)
+			case token.INT:
+				n, _ := strconv.ParseInt(literal.Value, 0, 64)
+				x.consts[key] = append(x.consts[key], n)
+			}
+		}
+	}
+}
+
+// schemaForType returns the schema of a type named in an annotation, e.g.
+// "domain.User", "[]string" or "int", resolved from file.
+func (x *typeIndex) schemaForType(name string, file *sourceFile) (*Schema, error) {
+	switch name {
+	case "file":
+		return &Schema{Type: "string", Format: "binary"}, nil
+	case "integer", "number", "boolean", "object":
+		return &Schema{Type: name}, nil
+	}
+	expr, err := parser.ParseExpr(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid type %q", name)
+	}
+	return x.schemaFor(expr, file), nil
+}
+
+// schemaFor returns the schema of a Go type expression in file.
+func (x *typeIndex) schemaFor(expr ast.Expr, file *sourceFile) *Schema {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if schema := builtinSchema(expr.Name); schema != nil {
+			return schema
+		}
+		return x.named(file.pkgPath, expr.Name)
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return &Schema{}
+		}
+		importPath := file.importPath(pkg.Name)
+		if importPath == "" {
+			// Annotations name packages the file may not import
+			for _, candidate := range x.byName[pkg.Name] {
+				if _, ok := x.types[candidate][expr.Sel.Name]; ok {
+					importPath = candidate
+					break
+				}
+			}
+		}
+		if schema, ok := externalTypes[importPath+"."+expr.Sel.Name]; ok {
+			return &schema
+		}
+		return x.named(importPath, expr.Sel.Name)
+	case *ast.StarExpr:
+		return x.schemaFor(expr.X, file)
+	case *ast.ParenExpr:
+		return x.schemaFor(expr.X, file)
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "byte" {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: x.schemaFor(expr.Elt, file)}
+	case *ast.MapType:
+		return &Schema{Type: "object", AdditionalProperties: x.schemaFor(expr.Value, file)}
+	case *ast.StructType:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		x.addFields(schema, expr, file)
+		return schema
+	}
+	return &Schema{}
+}
+
+// named returns a reference to the component of a project type, declaring
+// it on first use. Types from outside the project are left open.
+func (x *typeIndex) named(pkgPath, name string) *Schema {
+	entry, ok := x.types[pkgPath][name]
+	if !ok {
+		return &Schema{}
+	}
+	key := x.names[pkgPath] + "." + name
+	if _, ok := x.schemas[key]; !ok {
+		// Register first so recursive types refer to themselves
+		component := &Schema{}
+		x.schemas[key] = component
+
+		typeKey := pkgPath + "." + name
+		if x.methods[typeKey+".MarshalJSON"] || x.methods[typeKey+".MarshalText"] {
+			// Custom encodings, such as enums marshaled by name, are strings
+			*component = Schema{Type: "string"}
+		} else {
+			*component = *x.schemaFor(entry.spec.Type, entry.file)
+			component.Enum = x.consts[typeKey]
+		}
+		if component.Ref == "" {
+			component.Description = entry.doc
+		}
+	}
+	return &Schema{Ref: "#/components/schemas/" + key}
+}
+
+// addFields adds the exported fields of a struct to schema, following
+// json tags and flattening embedded structs. Fields whose binding or
+// validate tag says required are required.
+func (x *typeIndex) addFields(schema *Schema, st *ast.StructType, file *sourceFile) {
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			value, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(value)
+		}
+		jsonName, jsonOptions, _ := strings.Cut(tag.Get("json"), ",")
+		if (jsonName == "-" && jsonOptions == "") || tag.Get("swaggerignore") == "true" {
+			continue
+		}
+
+		names := field.Names
+		if len(names) == 0 {
+			if jsonName == "" && x.embed(schema, field.Type, file) {
+				continue
+			}
+			names = []*ast.Ident{embeddedName(field.Type)}
+		}
+		for _, name := range names {
+			if name == nil || !name.IsExported() {
+				continue
+			}
+			key := jsonName
+			if key == "" {
+				key = name.Name
+			}
+			property := x.schemaFor(field.Type, file)
+			if property.Ref == "" {
+				if doc := docText(field.Doc); doc != "" {
+					property.Description = doc
+				} else if comment := docText(field.Comment); comment != "" {
+					property.Description = comment
+				}
+			}
+			schema.Properties[key] = property
+			if hasRule(tag.Get("binding"), "required") || hasRule(tag.Get("validate"), "required") {
+				schema.Required = append(schema.Required, key)
+			}
+		}
+	}
+}
+
+// embed flattens the fields of an embedded project struct into schema,
+// reporting whether it did.
+func (x *typeIndex) embed(schema *Schema, expr ast.Expr, file *sourceFile) bool {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	pkgPath, name := file.pkgPath, ""
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		name = expr.Name
+	case *ast.SelectorExpr:
+		pkg, ok := expr.X.(*ast.Ident)
+		if !ok {
+			return false
+		}
+		pkgPath, name = file.importPath(pkg.Name), expr.Sel.Name
+	}
+	entry, ok := x.types[pkgPath][name]
+	if !ok {
+		return false
+	}
+	st, ok := entry.spec.Type.(*ast.StructType)
+	if !ok {
+		return false
+	}
+	x.addFields(schema, st, entry.file)
+	return true
+}
+
+// embeddedName returns the field name of an embedded type.
+func embeddedName(expr ast.Expr) *ast.Ident {
+	switch expr := expr.(type) {
+	case *ast.StarExpr:
+		return embeddedName(expr.X)
+	case *ast.Ident:
+		return expr
+	case *ast.SelectorExpr:
+		return expr.Sel
+	}
+	return nil
+}
+
+// builtinSchema returns the schema of a predeclared type, or nil.
+func builtinSchema(name string) *Schema {
+	switch name {
+	case "string", "error":
+		return &Schema{Type: "string"}
+	case "bool":
+		return &Schema{Type: "boolean"}
+	case "int", "int8", "int16", "uint", "uint8", "uint16", "uintptr", "byte", "rune":
+		return &Schema{Type: "integer"}
+	case "int32", "uint32":
+		return &Schema{Type: "integer", Format: "int32"}
+	case "int64", "uint64":
+		return &Schema{Type: "integer", Format: "int64"}
+	case "float32":
+		return &Schema{Type: "number", Format: "float"}
+	case "float64":
+		return &Schema{Type: "number", Format: "double"}
+	case "any":
+		return &Schema{}
+	}
+	return nil
+}
+
+// hasRule reports whether a comma-separated validation tag has rule.
+func hasRule(tag, rule string) bool {
+	for _, item := range strings.Split(tag, ",") {
+		if strings.TrimSpace(item) == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// docText returns a comment's text without annotation lines.
+func docText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	var lines []string
+	for _, line := range strings.Split(group.Text(), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "@") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, " ")
+}
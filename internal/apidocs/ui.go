@@ -0,0 +1,50 @@
+package apidocs
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// SwaggerUIPage is an HTML page showing the spec served next to it, at
+// <page path>/openapi.json, in Swagger UI loaded from a CDN.
+const SwaggerUIPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>API documentation</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({
+      url: window.location.pathname.replace(/\/$/, "") + "/openapi.json",
+      dom_id: "#swagger-ui",
+    });
+  </script>
+</body>
+</html>
+`
+
+// Handler serves Swagger UI at / and the document at /openapi.json,
+// rebuilding it on every request so handler edits show on reload.
+func Handler(build func() (*Document, error)) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		doc, err := build()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(doc)
+	})
+	mux.HandleFunc("/{$}", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte(SwaggerUIPage))
+	})
+	return mux
+}
@@ -0,0 +1,243 @@
+// Package bench runs the benchmarks of a project with go test -bench,
+// reads their results, and compares them with a baseline saved earlier.
+package bench
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// BaselineDir holds the saved baselines, relative to the project root.
+const BaselineDir = ".goforge/bench"
+
+// DefaultBaseline is the name of the baseline when none is given.
+const DefaultBaseline = "baseline"
+
+// Benchstat compares the runs with statistics, for the detailed report of
+// a comparison.
+var Benchstat = runner.Tool{Name: "benchstat", Package: "golang.org/x/perf/cmd/benchstat", Version: "v0.0.0-20260908200009-22c9c6c9d4da"}
+
+// Options selects the benchmarks to run and how.
+type Options struct {
+	Packages  []string // package patterns, ./... when empty
+	Bench     string   // regular expression selecting the benchmarks, "." when empty
+	Count     int      // runs of each benchmark, go test's default when 0
+	Benchtime string   // e.g. "2s" or "100x"
+	CPU       string   // GOMAXPROCS values, e.g. "1,4"
+	NoMem     bool     // leave out the allocation statistics of -benchmem
+
+	// OnResult is called for every result line as it arrives.
+	OnResult func(r Result)
+}
+
+// Result is one run of a benchmark.
+type Result struct {
+	Package    string
+	Name       string // without the Benchmark prefix and the GOMAXPROCS suffix
+	Procs      int    // GOMAXPROCS of the run
+	Iterations int64
+	Metrics    map[string]float64 // value per unit, e.g. "ns/op", "B/op", "allocs/op"
+	Units      []string           // units in the order go test printed them
+}
+
+// Report is the outcome of a benchmark run.
+type Report struct {
+	Results []Result
+	Raw     []byte   // output of go test, in the format benchstat reads
+	Output  []string // lines that are no result, e.g. failures and logs
+	Failed  bool
+}
+
+// Args returns the arguments of go test for options.
+func Args(options Options) []string {
+	bench := options.Bench
+	if bench == "" {
+		bench = "."
+	}
+	// -run=^$ keeps the tests from running along
+	args := []string{"test", "-run=^$", "-bench", bench}
+	if !options.NoMem {
+		args = append(args, "-benchmem")
+	}
+	if options.Count > 0 {
+		args = append(args, "-count", strconv.Itoa(options.Count))
+	}
+	if options.Benchtime != "" {
+		args = append(args, "-benchtime", options.Benchtime)
+	}
+	if options.CPU != "" {
+		args = append(args, "-cpu", options.CPU)
+	}
+	if len(options.Packages) == 0 {
+		return append(args, "./...")
+	}
+	return append(args, options.Packages...)
+}
+
+// Run runs the benchmarks in dir. Failing benchmarks are not an error;
+// the report tells. An error means go test could not run at all.
+func Run(dir string, options Options) (*Report, error) {
+	cmd := exec.Command("go", Args(options)...)
+	cmd.Dir = dir
+	cmd.Env = runner.Environ()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run go test: %w", err)
+	}
+
+	var raw bytes.Buffer
+	report, parseErr := Parse(io.TeeReader(stdout, &raw), options.OnResult)
+	waitErr := cmd.Wait()
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	report.Raw = raw.Bytes()
+	if waitErr != nil {
+		if len(report.Results) == 0 && len(report.Output) == 0 {
+			return nil, fmt.Errorf("go test failed: %s", strings.TrimSpace(stderr.String()))
+		}
+		report.Failed = true
+		for _, line := range strings.Split(strings.TrimSpace(stderr.String()), "\n") {
+			if line != "" {
+				report.Output = append(report.Output, line)
+			}
+		}
+	}
+	return report, nil
+}
+
+// Parse reads the benchmark results of go test -bench output, calling
+// onResult, if set, for each.
+func Parse(r io.Reader, onResult func(Result)) (*Report, error) {
+	report := &Report{}
+	pkg := ""
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "pkg: "):
+			pkg = strings.TrimPrefix(line, "pkg: ")
+			continue
+		case isConfigLine(line), line == "PASS", strings.HasPrefix(line, "ok  \t"), strings.HasPrefix(line, "?   \t"):
+			continue
+		}
+		if result, ok := parseResult(line); ok {
+			result.Package = pkg
+			report.Results = append(report.Results, result)
+			if onResult != nil {
+				onResult(result)
+			}
+			continue
+		}
+		// A benchmark name is printed before its result only with -v or
+		// when it logs; a bare name is no news
+		if fields := strings.Fields(line); len(fields) == 1 && strings.HasPrefix(fields[0], "Benchmark") {
+			continue
+		}
+		if strings.TrimSpace(line) != "" {
+			report.Output = append(report.Output, line)
+		}
+	}
+	return report, scanner.Err()
+}
+
+// configKeys are the keys of the lines go test prints before the results
+// of a package, e.g. "goos: linux".
+var configKeys = []string{"goos", "goarch", "cpu"}
+
+// isConfigLine reports whether line is one of configKeys.
+func isConfigLine(line string) bool {
+	key, _, ok := strings.Cut(line, ": ")
+	return ok && slices.Contains(configKeys, key)
+}
+
+// parseResult parses a result line such as
+// "BenchmarkParse-8   	  120000	      9876 ns/op	     512 B/op	       4 allocs/op".
+func parseResult(line string) (Result, bool) {
+	fields := strings.Fields(line)
+	if len(fields) < 4 || len(fields)%2 != 0 || !strings.HasPrefix(fields[0], "Benchmark") {
+		return Result{}, false
+	}
+	iterations, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return Result{}, false
+	}
+
+	result := Result{Iterations: iterations, Procs: 1, Metrics: map[string]float64{}}
+	result.Name = strings.TrimPrefix(fields[0], "Benchmark")
+	if i := strings.LastIndex(result.Name, "-"); i >= 0 {
+		if procs, err := strconv.Atoi(result.Name[i+1:]); err == nil {
+			result.Name, result.Procs = result.Name[:i], procs
+		}
+	}
+	for i := 2; i < len(fields); i += 2 {
+		value, err := strconv.ParseFloat(fields[i], 64)
+		if err != nil {
+			return Result{}, false
+		}
+		unit := fields[i+1]
+		result.Metrics[unit] = value
+		result.Units = append(result.Units, unit)
+	}
+	return result, true
+}
+
+// Key identifies a benchmark across runs.
+func (r Result) Key() string {
+	key := r.Package + "." + r.Name
+	if r.Procs != 1 {
+		key += "-" + strconv.Itoa(r.Procs)
+	}
+	return key
+}
+
+// BaselinePath returns the file of the named baseline.
+func BaselinePath(projectRoot, name string) string {
+	return filepath.Join(projectRoot, BaselineDir, name+".txt")
+}
+
+// SaveBaseline stores the output of a run as the named baseline.
+func SaveBaseline(projectRoot, name string, raw []byte) (string, error) {
+	path := BaselinePath(projectRoot, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		return "", fmt.Errorf("failed to save baseline: %w", err)
+	}
+	return path, nil
+}
+
+// LoadBaseline reads the results of the named baseline.
+func LoadBaseline(projectRoot, name string) ([]Result, error) {
+	path := BaselinePath(projectRoot, name)
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no baseline '%s' at %s: save one with 'goforge bench --save'", name, path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	report, err := Parse(f, nil)
+	if err != nil {
+		return nil, err
+	}
+	return report.Results, nil
+}
@@ -0,0 +1,107 @@
+package bench
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Change is how the time per operation of a benchmark moved against the
+// baseline.
+type Change struct {
+	Key   string
+	Old   float64 // mean ns/op of the baseline
+	New   float64 // mean ns/op of the run
+	Delta float64 // change in percent; positive is slower
+}
+
+// Compare returns the changes of the benchmarks found in both the
+// baseline and the run, in the order of the run. Runs of the same
+// benchmark are averaged.
+func Compare(baseline, current []Result) []Change {
+	old, now := meanTimes(baseline), meanTimes(current)
+	var changes []Change
+	seen := map[string]bool{}
+	for _, r := range current {
+		key := r.Key()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		before, ok := old[key]
+		if !ok || before == 0 {
+			continue
+		}
+		after, ok := now[key]
+		if !ok {
+			continue
+		}
+		changes = append(changes, Change{Key: key, Old: before, New: after, Delta: (after - before) / before * 100})
+	}
+	return changes
+}
+
+// Regressions returns the changes slower than threshold percent.
+func Regressions(changes []Change, threshold float64) []Change {
+	var slower []Change
+	for _, c := range changes {
+		if c.Delta > threshold {
+			slower = append(slower, c)
+		}
+	}
+	return slower
+}
+
+// meanTimes returns the mean ns/op of each benchmark.
+func meanTimes(results []Result) map[string]float64 {
+	sums := map[string]float64{}
+	counts := map[string]int{}
+	for _, r := range results {
+		ns, ok := r.Metrics["ns/op"]
+		if !ok {
+			continue
+		}
+		sums[r.Key()] += ns
+		counts[r.Key()]++
+	}
+	means := make(map[string]float64, len(sums))
+	for key, sum := range sums {
+		means[key] = sum / float64(counts[key])
+	}
+	return means
+}
+
+// BenchstatReport returns the report of benchstat comparing the named baseline
+// with the output of a run, installing benchstat on first use.
+func BenchstatReport(projectRoot, baseline string, raw []byte) (string, error) {
+	toolPath, err := runner.EnsureTool(Benchstat)
+	if err != nil {
+		return "", err
+	}
+
+	dir, err := os.MkdirTemp("", "goforge-bench-")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(dir)
+	// benchstat labels the columns with the file names
+	current := filepath.Join(dir, "current")
+	if err := os.WriteFile(current, raw, 0644); err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command(toolPath, baseline+"="+BaselinePath(projectRoot, baseline), "current="+current)
+	cmd.Env = runner.Environ()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("benchstat failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
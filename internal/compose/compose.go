@@ -0,0 +1,263 @@
+// Package compose runs the backing services of a project's
+// docker-compose.yml and tells later goforge commands how to reach them.
+package compose
+
+import (
+	"bufio"
+	"cmp"
+	"fmt"
+	"maps"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// Files are the names docker compose looks for, in its order.
+var Files = []string{"compose.yaml", "compose.yml", "docker-compose.yaml", "docker-compose.yml"}
+
+// EnvFile holds the connection variables of the running services,
+// relative to the project root; run, watch, and dev read it.
+const EnvFile = ".goforge/compose.env"
+
+// Project is a compose file and the services it declares.
+type Project struct {
+	Root     string
+	File     string // relative to Root
+	Services map[string]Service
+}
+
+// Service is the part of a compose service goforge reads.
+type Service struct {
+	Image       string   `yaml:"image"`
+	Build       any      `yaml:"build"`
+	Profiles    []string `yaml:"profiles"`
+	Environment any      `yaml:"environment"` // a mapping or a list of KEY=value
+}
+
+// Backing reports whether the service runs a published image, such as a
+// database or broker, rather than the application built from the project.
+func (s Service) Backing() bool {
+	return s.Image != "" && s.Build == nil && len(s.Profiles) == 0
+}
+
+// env returns the service's environment as a map.
+func (s Service) env() map[string]string {
+	env := map[string]string{}
+	switch e := s.Environment.(type) {
+	case map[string]any:
+		for key, value := range e {
+			env[key] = fmt.Sprint(value)
+		}
+	case []any:
+		for _, item := range e {
+			key, value, _ := strings.Cut(fmt.Sprint(item), "=")
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// Load reads the compose file of the project.
+func Load(projectRoot string) (*Project, error) {
+	for _, name := range Files {
+		data, err := os.ReadFile(filepath.Join(projectRoot, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		var file struct {
+			Services map[string]Service `yaml:"services"`
+		}
+		if err := yaml.Unmarshal(data, &file); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		return &Project{Root: projectRoot, File: name, Services: file.Services}, nil
+	}
+	return nil, fmt.Errorf("no docker-compose.yml in the project; generate one with: goforge g docker")
+}
+
+// BackingServices returns the names of the backing services, sorted.
+func (p *Project) BackingServices() []string {
+	var names []string
+	for name, service := range p.Services {
+		if service.Backing() {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Compose runs 'docker compose' on the project's file with args.
+func (p *Project) Compose(args ...string) error {
+	opts := runner.DefaultOptions()
+	opts.Dir = p.Root
+	opts.Timeout = 0
+	return runner.ExecuteCommandWithOptions("docker", append([]string{"compose", "-f", p.File}, args...), opts)
+}
+
+// Up starts services in the background and waits up to timeoutSeconds
+// for their health checks to pass.
+func (p *Project) Up(services []string, timeoutSeconds int) error {
+	args := []string{"up", "--detach", "--wait", "--wait-timeout", fmt.Sprint(timeoutSeconds)}
+	return p.Compose(append(args, services...)...)
+}
+
+// publishedPort returns the host port a service's container port is
+// published on.
+func (p *Project) publishedPort(service, port string) (string, error) {
+	out, err := runner.ExecuteCommandWithOutput(p.Root, "docker", "compose", "-f", p.File, "port", service, port)
+	if err != nil {
+		return "", err
+	}
+	address := strings.TrimSpace(strings.Split(out, "\n")[0])
+	if i := strings.LastIndex(address, ":"); i >= 0 && address[i+1:] != "0" {
+		return address[i+1:], nil
+	}
+	return "", fmt.Errorf("port %s of %s is not published", port, service)
+}
+
+// kinds recognizes the services whose connection variables goforge
+// knows, by image name, with their container port.
+var kinds = []struct {
+	images []string
+	kind   string
+	port   string
+}{
+	{[]string{"postgres", "postgis/postgis", "timescale/timescaledb"}, "postgres", "5432"},
+	{[]string{"mysql", "mariadb"}, "mysql", "3306"},
+	{[]string{"mongo"}, "mongodb", "27017"},
+	{[]string{"redis", "valkey/valkey"}, "redis", "6379"},
+	{[]string{"nats"}, "nats", "4222"},
+	{[]string{"rabbitmq"}, "rabbitmq", "5672"},
+	{[]string{"bitnami/kafka", "apache/kafka", "confluentinc/cp-kafka"}, "kafka", "9092"},
+}
+
+// kindOf returns what a service runs, judged by its image, and the port
+// its clients connect to.
+func kindOf(image string) (kind, port string) {
+	name, _, _ := strings.Cut(image, ":")
+	name = strings.TrimPrefix(name, "docker.io/")
+	name = strings.TrimPrefix(name, "library/")
+	for _, k := range kinds {
+		if slices.Contains(k.images, name) {
+			return k.kind, k.port
+		}
+	}
+	return "", ""
+}
+
+// Env returns the variables the application reads to reach the running
+// services from the host, named as in the app service of the compose
+// files goforge generates: DATABASE_HOST and friends, REDIS_ADDR, and
+// BROKER_URL. DATABASE_URL is added for 'goforge migrate' and 'goforge db'.
+func (p *Project) Env(services []string) (map[string]string, error) {
+	env := map[string]string{}
+	for _, name := range services {
+		service := p.Services[name]
+		kind, containerPort := kindOf(service.Image)
+		if kind == "" {
+			continue
+		}
+		port, err := p.publishedPort(name, containerPort)
+		if err != nil {
+			return nil, err
+		}
+		address := "localhost:" + port
+		vars := service.env()
+
+		switch kind {
+		case "postgres":
+			user := cmp.Or(vars["POSTGRES_USER"], "postgres")
+			databaseEnv(env, "postgres", port, user, vars["POSTGRES_PASSWORD"], cmp.Or(vars["POSTGRES_DB"], user), "sslmode=disable")
+		case "mysql":
+			user, password := vars["MYSQL_USER"], vars["MYSQL_PASSWORD"]
+			if user == "" {
+				user, password = "root", vars["MYSQL_ROOT_PASSWORD"]
+			}
+			databaseEnv(env, "mysql", port, user, password, vars["MYSQL_DATABASE"], "")
+		case "mongodb":
+			databaseEnv(env, "mongodb", port, vars["MONGO_INITDB_ROOT_USERNAME"], vars["MONGO_INITDB_ROOT_PASSWORD"], vars["MONGO_INITDB_DATABASE"], "authSource=admin")
+		case "redis":
+			env["REDIS_ADDR"] = address
+		case "nats":
+			env["BROKER_URL"] = "nats://" + address
+		case "rabbitmq":
+			credentials := url.UserPassword(cmp.Or(vars["RABBITMQ_DEFAULT_USER"], "guest"), cmp.Or(vars["RABBITMQ_DEFAULT_PASS"], "guest"))
+			env["BROKER_URL"] = (&url.URL{Scheme: "amqp", User: credentials, Host: address, Path: "/"}).String()
+		case "kafka":
+			env["BROKER_URL"] = address
+		}
+	}
+	return env, nil
+}
+
+// databaseEnv sets the DATABASE_* variables of a database on localhost.
+func databaseEnv(env map[string]string, scheme, port, user, password, name, query string) {
+	env["DATABASE_HOST"] = "localhost"
+	env["DATABASE_PORT"] = port
+	env["DATABASE_USER"] = user
+	env["DATABASE_PASSWORD"] = password
+	env["DATABASE_DBNAME"] = name
+
+	u := &url.URL{Scheme: scheme, Host: "localhost:" + port, Path: "/" + name, RawQuery: query}
+	if user != "" {
+		u.User = url.UserPassword(user, password)
+	}
+	env["DATABASE_URL"] = u.String()
+}
+
+// SaveEnv writes env to EnvFile, sorted by name.
+func SaveEnv(projectRoot string, env map[string]string) error {
+	var b strings.Builder
+	b.WriteString("# Written by 'goforge compose up'; removed by 'goforge compose down'.\n")
+	for _, key := range slices.Sorted(maps.Keys(env)) {
+		fmt.Fprintf(&b, "%s=%s\n", key, env[key])
+	}
+	file := filepath.Join(projectRoot, EnvFile)
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, []byte(b.String()), 0600)
+}
+
+// LoadEnv reads EnvFile; it is empty when no services were started.
+func LoadEnv(projectRoot string) (map[string]string, error) {
+	env := map[string]string{}
+	file, err := os.Open(filepath.Join(projectRoot, EnvFile))
+	if os.IsNotExist(err) {
+		return env, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			env[key] = value
+		}
+	}
+	return env, scanner.Err()
+}
+
+// RemoveEnv deletes EnvFile.
+func RemoveEnv(projectRoot string) error {
+	err := os.Remove(filepath.Join(projectRoot, EnvFile))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,108 @@
+package database
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Table is a table of the database with its columns in order.
+type Table struct {
+	Name    string
+	Columns []Column
+}
+
+// Column is a column as information_schema describes it.
+type Column struct {
+	Name     string
+	Type     string // lowercased, e.g. "bigint", "character varying", "tinyint(1)"
+	Nullable bool
+}
+
+// schemaQueries list the columns of the base tables as tab-separated
+// table, column, type, and nullability (YES or NO). PostgreSQL reads
+// the schema given by %s.
+var schemaQueries = map[string]string{
+	Postgres: `SELECT c.table_name, c.column_name,
+  CASE WHEN c.data_type IN ('ARRAY', 'USER-DEFINED') THEN c.udt_name ELSE c.data_type END, c.is_nullable
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = '%s' AND t.table_type = 'BASE TABLE'
+ORDER BY c.table_name, c.ordinal_position`,
+	MySQL: `SELECT c.table_name, c.column_name, c.column_type, c.is_nullable
+FROM information_schema.columns c
+JOIN information_schema.tables t ON t.table_schema = c.table_schema AND t.table_name = c.table_name
+WHERE c.table_schema = DATABASE() AND t.table_type = 'BASE TABLE'
+ORDER BY c.table_name, c.ordinal_position`,
+	SQLite: `SELECT m.name, p.name, p.type, CASE WHEN p."notnull" OR p.pk THEN 'NO' ELSE 'YES' END
+FROM sqlite_master m JOIN pragma_table_info(m.name) p
+WHERE m.type = 'table' AND m.name NOT LIKE 'sqlite_%'
+ORDER BY m.name, p.cid`,
+}
+
+// Tables reads the tables of the database, those of schema for
+// PostgreSQL ("public" when empty), with the database's client.
+func (db *Database) Tables(dir, schema string) ([]Table, error) {
+	query, ok := schemaQueries[db.Kind]
+	if !ok {
+		return nil, fmt.Errorf("%s has no schema to read tables from", db.URL.Scheme)
+	}
+
+	var args []string
+	switch db.Kind {
+	case Postgres:
+		if schema == "" {
+			schema = "public"
+		}
+		query = fmt.Sprintf(query, strings.ReplaceAll(schema, "'", "''"))
+		args = []string{db.URL.String(), "-X", "-A", "-t", "-F", "\t", "-v", "ON_ERROR_STOP=1", "-c", query}
+	case MySQL:
+		args = append(db.mysqlArgs(), "-N", "-B", "-e", query, db.Name)
+	case SQLite:
+		args = []string{"-separator", "\t", db.Name, query}
+	}
+	out, err := db.query(dir, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []Table
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 4 {
+			continue
+		}
+		column := Column{Name: fields[1], Type: strings.ToLower(fields[2]), Nullable: fields[3] == "YES"}
+		if len(tables) == 0 || tables[len(tables)-1].Name != fields[0] {
+			tables = append(tables, Table{Name: fields[0]})
+		}
+		tables[len(tables)-1].Columns = append(tables[len(tables)-1].Columns, column)
+	}
+	return tables, nil
+}
+
+// query runs the database's client with args and returns its output.
+func (db *Database) query(dir string, args []string) (string, error) {
+	client := clients[db.Kind]
+	binary, err := exec.LookPath(client.binary)
+	if err != nil {
+		return "", fmt.Errorf("%s not found on PATH; %s", client.binary, client.hint)
+	}
+
+	cmd := exec.Command(binary, args...)
+	cmd.Dir = dir
+	cmd.Env = runner.Environ()
+	if password, ok := db.URL.User.Password(); ok && db.Kind == MySQL {
+		cmd.Env = append(cmd.Env, "MYSQL_PWD="+password)
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("%s failed: %s", client.binary, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,195 @@
+// Package deploy ships a project with the provider chosen in the deploy
+// section of goforge.yml: Fly.io, Render, kubectl, Helm, or SSH with
+// systemd, or a goforge-deploy-<name> plugin.
+//
+// A provider turns a deploy or a rollback into a list of steps, which are
+// either run or, for a dry run, only printed.
+package deploy
+
+import (
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/plugins"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Built-in providers, chosen with deploy.provider in goforge.yml.
+const (
+	ProviderFly        = "fly"
+	ProviderRender     = "render"
+	ProviderKubernetes = "kubernetes"
+	ProviderHelm       = "helm"
+	ProviderSSH        = "ssh"
+)
+
+// Providers are the built-in providers.
+var Providers = []string{ProviderFly, ProviderRender, ProviderKubernetes, ProviderHelm, ProviderSSH}
+
+// PluginPrefix names the plugins that add providers: goforge-deploy-<name>
+// provides <name>.
+const PluginPrefix = "deploy-"
+
+// tools maps the CLIs the providers run to where to get them.
+var tools = map[string]string{
+	"flyctl":  "https://fly.io/docs/flyctl/install/",
+	"kubectl": "https://kubernetes.io/docs/tasks/tools/",
+	"helm":    "https://helm.sh/docs/intro/install/",
+	"ssh":     "your OpenSSH client",
+	"scp":     "your OpenSSH client",
+}
+
+// Release is what a deploy ships.
+type Release struct {
+	// Version names the release; {version} in images and values is
+	// replaced with it.
+	Version string
+
+	// Build compiles the project for goos and goarch into Dir, where the
+	// binary is named Binary. Only providers shipping binaries call it.
+	Build  func(goos, goarch string) error
+	Dir    string
+	Binary string
+}
+
+// expand replaces {version} in s with the release version.
+func (r Release) expand(s string) string {
+	return strings.ReplaceAll(s, "{version}", r.Version)
+}
+
+// Step is a part of a deploy or rollback: a command, or Run for what
+// takes more than one.
+type Step struct {
+	Description string
+	Command     []string
+	Run         func() error
+}
+
+// Provider plans deploys and rollbacks.
+type Provider interface {
+	// Deploy returns the steps shipping r.
+	Deploy(r Release) ([]Step, error)
+
+	// Rollback returns the steps going back to the release before the
+	// live one, or to the release named by to when it is not empty.
+	Rollback(to string) ([]Step, error)
+}
+
+// New returns the built-in provider deploy.provider names.
+func New(cfg *project.Config, projectRoot string) (Provider, error) {
+	d := cfg.Deploy
+	if d == nil || d.Provider == "" {
+		return nil, fmt.Errorf("no deploy.provider in goforge.yml (use %s, or a goforge-deploy-<name> plugin)", strings.Join(Providers, ", "))
+	}
+	name := projectName(cfg, projectRoot)
+	switch d.Provider {
+	case ProviderFly:
+		return newFly(d.Fly, projectRoot)
+	case ProviderRender:
+		return newRender(d.Render)
+	case ProviderKubernetes:
+		return newKubernetes(d.Kubernetes, name)
+	case ProviderHelm:
+		return newHelm(d.Helm, name)
+	case ProviderSSH:
+		return newSSH(d.SSH, name)
+	}
+	return nil, fmt.Errorf("unknown deploy provider '%s': not built in (%s) and no %s%s%s plugin on PATH",
+		d.Provider, strings.Join(Providers, ", "), plugins.Prefix, PluginPrefix, d.Provider)
+}
+
+// FindPlugin returns the goforge-deploy-<provider> plugin on PATH, or nil.
+// Built-in providers take precedence, so it is nil for them too.
+func FindPlugin(provider string) *plugins.Plugin {
+	for _, name := range Providers {
+		if name == provider {
+			return nil
+		}
+	}
+	for _, plugin := range plugins.Discover() {
+		if plugin.Name == PluginPrefix+provider {
+			return plugin
+		}
+	}
+	return nil
+}
+
+// projectName returns the name of the project, which the providers
+// default their resource names to.
+func projectName(cfg *project.Config, projectRoot string) string {
+	if cfg.ProjectName != "" {
+		return cfg.ProjectName
+	}
+	return filepath.Base(projectRoot)
+}
+
+// Print lists the steps without running them.
+func Print(steps []Step) {
+	for i, step := range steps {
+		logger.Info("%d. %s", i+1, step.Description)
+		if len(step.Command) > 0 {
+			logger.Info("   $ %s", commandLine(step.Command))
+		}
+	}
+}
+
+// Execute runs the steps in order from dir, stopping at the first that
+// fails.
+func Execute(dir string, steps []Step) error {
+	for i, step := range steps {
+		logger.Info("🚀 [%d/%d] %s", i+1, len(steps), step.Description)
+		if step.Run != nil {
+			if err := step.Run(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := run(dir, step.Command); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// run runs a command of a step from dir.
+func run(dir string, command []string) error {
+	if err := requireTool(command[0]); err != nil {
+		return err
+	}
+	opts := runner.DefaultOptions()
+	opts.Dir = dir
+	opts.Timeout = 0 // rollouts wait as long as their own timeouts say
+	return runner.ExecuteCommandWithOptions(command[0], command[1:], opts)
+}
+
+// requireTool fails with where to get the CLI when it is not on PATH.
+func requireTool(name string) error {
+	if _, err := exec.LookPath(name); err != nil {
+		if hint, ok := tools[name]; ok {
+			return fmt.Errorf("%s not found on PATH; install it from %s", name, hint)
+		}
+		return fmt.Errorf("%s not found on PATH", name)
+	}
+	return nil
+}
+
+// commandLine quotes a command for display and for remote shells.
+func commandLine(command []string) string {
+	quoted := make([]string, len(command))
+	for i, arg := range command {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s for a POSIX shell when it needs quoting.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./:=@,+%") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
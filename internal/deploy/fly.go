@@ -0,0 +1,127 @@
+package deploy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// fly deploys with 'flyctl deploy', which builds the Dockerfile on Fly's
+// builders unless an image is given. Fly.io keeps no rollback of its own,
+// so rolling back redeploys the image of an earlier release.
+type fly struct {
+	config      *project.FlyDeployConfig
+	projectRoot string
+}
+
+func newFly(config *project.FlyDeployConfig, projectRoot string) (*fly, error) {
+	if config == nil {
+		config = &project.FlyDeployConfig{}
+	}
+	return &fly{config: config, projectRoot: projectRoot}, nil
+}
+
+// flags are the flags selecting the app.
+func (f *fly) flags() []string {
+	var flags []string
+	if f.config.App != "" {
+		flags = append(flags, "--app", f.config.App)
+	}
+	if f.config.Config != "" {
+		flags = append(flags, "--config", f.config.Config)
+	}
+	return flags
+}
+
+func (f *fly) deployCommand(image string) []string {
+	command := append([]string{"flyctl", "deploy"}, f.flags()...)
+	if image != "" {
+		command = append(command, "--image", image)
+	}
+	return command
+}
+
+func (f *fly) Deploy(r Release) ([]Step, error) {
+	description := "Build and deploy on Fly.io"
+	image := r.expand(f.config.Image)
+	if image != "" {
+		description = "Deploy " + image + " on Fly.io"
+	}
+	return []Step{{Description: description, Command: f.deployCommand(image)}}, nil
+}
+
+// Rollback redeploys the image of the release before the live one, of the
+// release numbered to, or the image to itself.
+func (f *fly) Rollback(to string) ([]Step, error) {
+	if strings.ContainsAny(to, "/:") {
+		return []Step{{Description: "Redeploy " + to + " on Fly.io", Command: f.deployCommand(to)}}, nil
+	}
+	description := "Redeploy the image of the previous release on Fly.io"
+	if to != "" {
+		description = "Redeploy the image of release v" + strings.TrimPrefix(to, "v") + " on Fly.io"
+	}
+	return []Step{{Description: description, Run: func() error {
+		image, err := f.releaseImage(to)
+		if err != nil {
+			return err
+		}
+		logger.Info("🔙 Redeploying %s", image)
+		return run(f.projectRoot, f.deployCommand(image))
+	}}}, nil
+}
+
+// flyRelease is a release as 'flyctl releases --json' lists it.
+type flyRelease struct {
+	Version  int    `json:"Version"`
+	Status   string `json:"Status"`
+	ImageRef string `json:"ImageRef"`
+}
+
+// releaseImage returns the image of release version, or of the release
+// before the live one when version is empty.
+func (f *fly) releaseImage(version string) (string, error) {
+	if err := requireTool("flyctl"); err != nil {
+		return "", err
+	}
+	out, err := runner.ExecuteCommandWithOutput(f.projectRoot, "flyctl", append([]string{"releases", "--image", "--json"}, f.flags()...)...)
+	if err != nil {
+		return "", err
+	}
+	var releases []flyRelease
+	if err := json.Unmarshal([]byte(out), &releases); err != nil {
+		return "", fmt.Errorf("unreadable releases from flyctl: %w", err)
+	}
+
+	if version != "" {
+		number, err := strconv.Atoi(strings.TrimPrefix(version, "v"))
+		if err != nil {
+			return "", fmt.Errorf("'%s' is neither a release number nor an image", version)
+		}
+		for _, release := range releases {
+			if release.Version == number && release.ImageRef != "" {
+				return release.ImageRef, nil
+			}
+		}
+		return "", fmt.Errorf("no release v%d with an image on Fly.io", number)
+	}
+
+	// Releases are listed newest first; the live one is the newest that
+	// completed
+	live := ""
+	for _, release := range releases {
+		if release.ImageRef == "" || (release.Status != "complete" && release.Status != "succeeded") {
+			continue
+		}
+		if live == "" {
+			live = release.ImageRef
+		} else if release.ImageRef != live {
+			return release.ImageRef, nil
+		}
+	}
+	return "", fmt.Errorf("no earlier release with another image to roll back to")
+}
@@ -0,0 +1,128 @@
+package deploy
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// defaultRolloutTimeout bounds waiting for a rollout unless configured.
+const defaultRolloutTimeout = "5m"
+
+// kubernetes applies manifests with kubectl, sets the release's image, and
+// waits for the deployment to roll out; rolling back undoes the rollout.
+type kubernetes struct {
+	config     *project.KubernetesDeployConfig
+	deployment string
+}
+
+func newKubernetes(config *project.KubernetesDeployConfig, projectName string) (*kubernetes, error) {
+	if config == nil {
+		config = &project.KubernetesDeployConfig{}
+	}
+	return &kubernetes{config: config, deployment: "deployment/" + cmp.Or(config.Deployment, projectName)}, nil
+}
+
+// kubectl returns a kubectl command on the configured cluster.
+func (k *kubernetes) kubectl(args ...string) []string {
+	command := []string{"kubectl"}
+	if k.config.Context != "" {
+		command = append(command, "--context", k.config.Context)
+	}
+	if k.config.Namespace != "" {
+		command = append(command, "--namespace", k.config.Namespace)
+	}
+	return append(command, args...)
+}
+
+func (k *kubernetes) rolloutStatus() Step {
+	return Step{
+		Description: "Wait for " + k.deployment + " to roll out",
+		Command:     k.kubectl("rollout", "status", k.deployment, "--timeout", cmp.Or(k.config.Timeout, defaultRolloutTimeout)),
+	}
+}
+
+func (k *kubernetes) Deploy(r Release) ([]Step, error) {
+	var steps []Step
+	manifests := k.config.Manifests
+	if len(manifests) == 0 {
+		manifests = []string{"k8s"}
+	}
+	for _, manifest := range manifests {
+		steps = append(steps, Step{Description: "Apply " + manifest, Command: k.kubectl("apply", "--filename", manifest)})
+	}
+	if image := r.expand(k.config.Image); image != "" {
+		steps = append(steps, Step{
+			Description: "Set the image of " + k.deployment + " to " + image,
+			Command:     k.kubectl("set", "image", k.deployment, cmp.Or(k.config.Container, "*")+"="+image),
+		})
+	}
+	return append(steps, k.rolloutStatus()), nil
+}
+
+// Rollback undoes the last rollout, or rolls back to revision to.
+func (k *kubernetes) Rollback(to string) ([]Step, error) {
+	undo := Step{Description: "Roll " + k.deployment + " back to its previous revision", Command: k.kubectl("rollout", "undo", k.deployment)}
+	if to != "" {
+		undo.Description = fmt.Sprintf("Roll %s back to revision %s", k.deployment, to)
+		undo.Command = append(undo.Command, "--to-revision", to)
+	}
+	return []Step{undo, k.rolloutStatus()}, nil
+}
+
+// helm upgrades or installs a chart and waits for its resources; rolling
+// back returns to an earlier revision of the release.
+type helm struct {
+	config  *project.HelmDeployConfig
+	release string
+}
+
+func newHelm(config *project.HelmDeployConfig, projectName string) (*helm, error) {
+	if config == nil {
+		config = &project.HelmDeployConfig{}
+	}
+	return &helm{config: config, release: cmp.Or(config.Release, projectName)}, nil
+}
+
+// helm returns a helm command on the configured cluster that waits for
+// the release's resources.
+func (h *helm) helm(args ...string) []string {
+	command := append([]string{"helm"}, args...)
+	if h.config.Context != "" {
+		command = append(command, "--kube-context", h.config.Context)
+	}
+	if h.config.Namespace != "" {
+		command = append(command, "--namespace", h.config.Namespace)
+	}
+	return append(command, "--wait", "--timeout", cmp.Or(h.config.Timeout, defaultRolloutTimeout))
+}
+
+func (h *helm) Deploy(r Release) ([]Step, error) {
+	chart := cmp.Or(h.config.Chart, "./chart")
+	args := []string{"upgrade", h.release, chart, "--install"}
+	if h.config.Namespace != "" {
+		args = append(args, "--create-namespace")
+	}
+	for _, values := range h.config.Values {
+		args = append(args, "--values", values)
+	}
+	for _, key := range slices.Sorted(maps.Keys(h.config.Set)) {
+		args = append(args, "--set", key+"="+r.expand(h.config.Set[key]))
+	}
+	return []Step{{Description: "Upgrade release " + h.release + " with chart " + chart, Command: h.helm(args...)}}, nil
+}
+
+// Rollback returns the release to its previous revision, or to revision
+// to.
+func (h *helm) Rollback(to string) ([]Step, error) {
+	args := []string{"rollback", h.release}
+	description := "Roll release " + h.release + " back to its previous revision"
+	if to != "" {
+		args = append(args, to)
+		description = "Roll release " + h.release + " back to revision " + to
+	}
+	return []Step{{Description: description, Command: h.helm(args...)}}, nil
+}
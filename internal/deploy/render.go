@@ -0,0 +1,171 @@
+package deploy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// renderAPI is the Render API the render provider talks to.
+const renderAPI = "https://api.render.com/v1"
+
+// RenderAPIKeyEnv holds the Render API key.
+const RenderAPIKeyEnv = "RENDER_API_KEY"
+
+// renderPollInterval is how often a deploy's status is checked.
+const renderPollInterval = 5 * time.Second
+
+// render triggers deploys of a Render service, which builds from its
+// repository or pulls the given image, and rolls back to earlier deploys
+// through the Render API.
+type render struct {
+	config *project.RenderDeployConfig
+	client *http.Client
+}
+
+func newRender(config *project.RenderDeployConfig) (*render, error) {
+	if config == nil || config.ServiceID == "" {
+		return nil, fmt.Errorf("the render provider needs deploy.render.service_id in goforge.yml")
+	}
+	return &render{config: config, client: &http.Client{Timeout: 30 * time.Second}}, nil
+}
+
+// renderDeploy is a deploy as the Render API returns it.
+type renderDeploy struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+func (r *render) Deploy(release Release) ([]Step, error) {
+	body := map[string]string{}
+	description := "Deploy service " + r.config.ServiceID + " on Render"
+	if image := release.expand(r.config.Image); image != "" {
+		body["imageUrl"] = image
+		description = "Deploy " + image + " to service " + r.config.ServiceID + " on Render"
+	}
+	return []Step{{Description: description, Run: func() error {
+		var deploy renderDeploy
+		if err := r.call(http.MethodPost, "/services/"+r.config.ServiceID+"/deploys", body, &deploy); err != nil {
+			return err
+		}
+		return r.wait(deploy)
+	}}}, nil
+}
+
+// Rollback goes back to the deploy that was live before the current one,
+// or to the deploy with the ID to.
+func (r *render) Rollback(to string) ([]Step, error) {
+	description := "Roll service " + r.config.ServiceID + " back to its previous deploy on Render"
+	if to != "" {
+		description = "Roll service " + r.config.ServiceID + " back to deploy " + to + " on Render"
+	}
+	return []Step{{Description: description, Run: func() error {
+		target := to
+		if target == "" {
+			var err error
+			if target, err = r.previousDeploy(); err != nil {
+				return err
+			}
+		}
+		logger.Info("🔙 Rolling back to deploy %s", target)
+		var deploy renderDeploy
+		if err := r.call(http.MethodPost, "/services/"+r.config.ServiceID+"/rollback", map[string]string{"deployId": target}, &deploy); err != nil {
+			return err
+		}
+		return r.wait(deploy)
+	}}}, nil
+}
+
+// previousDeploy returns the ID of the deploy that was live before the
+// current one. Deploys are listed newest first, and a deploy replaced by
+// a newer one is deactivated.
+func (r *render) previousDeploy() (string, error) {
+	var deploys []struct {
+		Deploy renderDeploy `json:"deploy"`
+	}
+	if err := r.call(http.MethodGet, "/services/"+r.config.ServiceID+"/deploys?limit=50", nil, &deploys); err != nil {
+		return "", err
+	}
+	live := false
+	for _, d := range deploys {
+		switch {
+		case d.Deploy.Status == "live":
+			live = true
+		case live && d.Deploy.Status == "deactivated":
+			return d.Deploy.ID, nil
+		}
+	}
+	return "", fmt.Errorf("no earlier deploy of %s to roll back to", r.config.ServiceID)
+}
+
+// wait polls a deploy until it is live or has failed.
+func (r *render) wait(deploy renderDeploy) error {
+	logger.Info("⏳ Waiting for deploy %s", deploy.ID)
+	last := ""
+	for {
+		switch deploy.Status {
+		case "live":
+			return nil
+		case "build_failed", "update_failed", "pre_deploy_failed", "canceled", "deactivated":
+			return fmt.Errorf("deploy %s ended as %s; see the logs in the Render dashboard", deploy.ID, deploy.Status)
+		}
+		if deploy.Status != last {
+			logger.Info("   %s", deploy.Status)
+			last = deploy.Status
+		}
+		time.Sleep(renderPollInterval)
+		if err := r.call(http.MethodGet, "/services/"+r.config.ServiceID+"/deploys/"+deploy.ID, nil, &deploy); err != nil {
+			return err
+		}
+	}
+}
+
+// call sends a request to the Render API and decodes its answer into out.
+func (r *render) call(method, path string, body, out any) error {
+	key := os.Getenv(RenderAPIKeyEnv)
+	if key == "" {
+		return fmt.Errorf("set %s to a Render API key to deploy", RenderAPIKeyEnv)
+	}
+
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, renderAPI+path, payload)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+key)
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to reach the Render API: %w", err)
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("the Render API answered %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("unreadable answer from the Render API: %w", err)
+	}
+	return nil
+}
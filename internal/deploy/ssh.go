@@ -0,0 +1,121 @@
+package deploy
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// defaultKeptReleases stay on the server unless deploy.ssh.keep says
+// otherwise.
+const defaultKeptReleases = 5
+
+// unsafeReleaseChars are replaced in the version part of release names.
+var unsafeReleaseChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// ssh copies the build into a new directory under <dir>/releases, points
+// <dir>/current at it, and restarts the systemd unit running
+// <dir>/current/<binary>. Rolling back points current at an earlier
+// release. Release names start with their time, so they sort by age.
+type ssh struct {
+	config  *project.SSHDeployConfig
+	dir     string
+	service string
+}
+
+func newSSH(config *project.SSHDeployConfig, projectName string) (*ssh, error) {
+	if config == nil || config.Host == "" {
+		return nil, fmt.Errorf("the ssh provider needs deploy.ssh.host in goforge.yml, e.g. deploy@example.com")
+	}
+	return &ssh{
+		config:  config,
+		dir:     cmp.Or(config.Dir, path.Join("/opt", projectName)),
+		service: cmp.Or(config.Service, projectName),
+	}, nil
+}
+
+// remote returns an ssh command running script on the server.
+func (s *ssh) remote(script string) []string {
+	command := []string{"ssh"}
+	if s.config.Port != 0 {
+		command = append(command, "-p", strconv.Itoa(s.config.Port))
+	}
+	return append(command, s.config.Host, script)
+}
+
+// restart is the shell command restarting the unit and checking it came
+// up.
+func (s *ssh) restart() string {
+	systemctl := "sudo systemctl"
+	if s.config.NoSudo {
+		systemctl = "systemctl"
+	}
+	unit := shellQuote(s.service)
+	return fmt.Sprintf("%s restart %s && sleep 2 && systemctl is-active --quiet %s", systemctl, unit, unit)
+}
+
+func (s *ssh) Deploy(r Release) ([]Step, error) {
+	goos, goarch := cmp.Or(s.config.GOOS, "linux"), cmp.Or(s.config.GOARCH, "amd64")
+	name := time.Now().UTC().Format("20060102150405")
+	if version := unsafeReleaseChars.ReplaceAllString(r.Version, "-"); version != "" {
+		name += "-" + version
+	}
+	releases := path.Join(s.dir, "releases")
+	release := path.Join(releases, name)
+	keep := s.config.Keep
+	if keep <= 0 {
+		keep = defaultKeptReleases
+	}
+
+	scp := []string{"scp", "-r"}
+	if s.config.Port != 0 {
+		scp = append(scp, "-P", strconv.Itoa(s.config.Port))
+	}
+	scp = append(scp, r.Dir, s.config.Host+":"+release)
+
+	return []Step{
+		{Description: fmt.Sprintf("Build %s for %s/%s", r.Binary, goos, goarch), Run: func() error { return r.Build(goos, goarch) }},
+		{Description: "Create " + releases + " on " + s.config.Host, Command: s.remote("mkdir -p " + shellQuote(releases))},
+		{Description: "Copy the build to " + release, Command: scp},
+		{
+			Description: "Switch " + path.Join(s.dir, "current") + " to " + name + " and restart " + s.service,
+			Command: s.remote(fmt.Sprintf("ln -sfn %s %s && %s",
+				shellQuote(release), shellQuote(path.Join(s.dir, "current")), s.restart())),
+		},
+		{
+			Description: fmt.Sprintf("Remove all but the last %d releases", keep),
+			Command:     s.remote(fmt.Sprintf("cd %s && ls -1 | sort -r | tail -n +%d | xargs -r rm -rf", shellQuote(releases), keep+1)),
+		},
+	}, nil
+}
+
+// Rollback points current at the release before the live one, or at the
+// release named to, and restarts the unit.
+func (s *ssh) Rollback(to string) ([]Step, error) {
+	releases := path.Join(s.dir, "releases")
+	current := path.Join(s.dir, "current")
+	description := "Switch " + current + " to the previous release and restart " + s.service
+	if to != "" {
+		description = "Switch " + current + " to " + to + " and restart " + s.service
+	}
+	script := fmt.Sprintf(`set -e
+cd %[1]s
+live=$(basename "$(readlink %[2]s)")
+target=%[3]s
+if [ -z "$target" ]; then
+  target=$(ls -1 | sort | awk -v live="$live" '$0 == live { print previous; exit } { previous = $0 }')
+fi
+if [ -z "$target" ] || [ ! -d "$target" ]; then
+  echo "no release to roll back to from $live" >&2
+  exit 1
+fi
+ln -sfn %[1]s/"$target" %[2]s
+echo "rolled back from $live to $target"
+%[4]s`, shellQuote(releases), shellQuote(current), shellQuote(to), s.restart())
+	return []Step{{Description: description, Command: s.remote(script)}}, nil
+}
@@ -0,0 +1,294 @@
+// Package diff provides line-based unified diffs and three-way merging for
+// generated files.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// opKind identifies a single edit operation.
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+type op struct {
+	kind opKind
+	line string
+}
+
+// SplitLines splits text into lines, keeping the trailing newline semantics
+// simple: a final newline does not produce an empty trailing line.
+func SplitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// JoinLines is the inverse of SplitLines.
+func JoinLines(lines []string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// match returns, for every line in a, the index of the matching line in b
+// according to a longest common subsequence, or -1 if the line is not part
+// of it.
+func match(a, b []string) []int {
+	n, m := len(a), len(b)
+	table := make([][]int, n+1)
+	for i := range table {
+		table[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				table[i][j] = table[i+1][j+1] + 1
+			} else if table[i+1][j] >= table[i][j+1] {
+				table[i][j] = table[i+1][j]
+			} else {
+				table[i][j] = table[i][j+1]
+			}
+		}
+	}
+
+	result := make([]int, n)
+	for i := range result {
+		result[i] = -1
+	}
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			result[i] = j
+			i++
+			j++
+		case table[i+1][j] >= table[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return result
+}
+
+// edits computes the edit script turning a into b.
+func edits(a, b []string) []op {
+	matches := match(a, b)
+	var ops []op
+	j := 0
+	for i, line := range a {
+		if matches[i] == -1 {
+			ops = append(ops, op{opDelete, line})
+			continue
+		}
+		for ; j < matches[i]; j++ {
+			ops = append(ops, op{opInsert, b[j]})
+		}
+		ops = append(ops, op{opEqual, line})
+		j++
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, op{opInsert, b[j]})
+	}
+	return ops
+}
+
+// Unified returns a unified diff between a and b with the given number of
+// context lines. It returns an empty string when the inputs are identical.
+func Unified(a, b, fromName, toName string, context int) string {
+	if a == b {
+		return ""
+	}
+
+	ops := edits(SplitLines(a), SplitLines(b))
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	// Walk the script, grouping changes that are within 2*context lines
+	// of each other into a single hunk.
+	aLine, bLine := 1, 1
+	for i := 0; i < len(ops); {
+		if ops[i].kind == opEqual {
+			aLine++
+			bLine++
+			i++
+			continue
+		}
+
+		start := i
+		for k := 0; k < context && start > 0 && ops[start-1].kind == opEqual; k++ {
+			start--
+		}
+		end := i
+		for end < len(ops) {
+			if ops[end].kind != opEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(ops) && ops[run].kind == opEqual {
+				run++
+			}
+			if run == len(ops) || run-end > 2*context {
+				end += min(context, run-end)
+				break
+			}
+			end = run
+		}
+
+		lead := i - start
+		hunkA, hunkB := aLine-lead, bLine-lead
+		var countA, countB int
+		var body strings.Builder
+		for _, o := range ops[start:end] {
+			switch o.kind {
+			case opEqual:
+				body.WriteString(" " + o.line + "\n")
+				countA++
+				countB++
+			case opDelete:
+				body.WriteString("-" + o.line + "\n")
+				countA++
+			case opInsert:
+				body.WriteString("+" + o.line + "\n")
+				countB++
+			}
+		}
+		fmt.Fprintf(&out, "@@ -%d,%d +%d,%d @@\n", hunkA, countA, hunkB, countB)
+		out.WriteString(body.String())
+
+		aLine = hunkA + countA
+		bLine = hunkB + countB
+		i = end
+	}
+
+	return out.String()
+}
+
+// Merge3 performs a line-based three-way merge of ours and theirs, which
+// both derive from base. Regions changed on only one side are taken from
+// that side; regions changed differently on both sides are emitted with
+// git-style conflict markers. It returns the merged text and the number of
+// conflicting regions.
+func Merge3(base, ours, theirs string) (string, int) {
+	baseLines := SplitLines(base)
+	ourLines := SplitLines(ours)
+	theirLines := SplitLines(theirs)
+
+	toOurs := match(baseLines, ourLines)
+	toTheirs := match(baseLines, theirLines)
+
+	var merged []string
+	conflicts := 0
+	i, o, t := 0, 0, 0
+
+	for {
+		// Find the next base line that survived unchanged on both sides.
+		j := i
+		for j < len(baseLines) && (toOurs[j] == -1 || toTheirs[j] == -1) {
+			j++
+		}
+
+		oEnd, tEnd := len(ourLines), len(theirLines)
+		if j < len(baseLines) {
+			oEnd, tEnd = toOurs[j], toTheirs[j]
+		}
+
+		baseChunk := baseLines[i:j]
+		ourChunk := ourLines[o:oEnd]
+		theirChunk := theirLines[t:tEnd]
+
+		switch {
+		case equalLines(ourChunk, baseChunk):
+			merged = append(merged, theirChunk...)
+		case equalLines(theirChunk, baseChunk), equalLines(ourChunk, theirChunk):
+			merged = append(merged, ourChunk...)
+		default:
+			conflicts++
+			merged = append(merged, "<<<<<<< current")
+			merged = append(merged, ourChunk...)
+			merged = append(merged, "=======")
+			merged = append(merged, theirChunk...)
+			merged = append(merged, ">>>>>>> generated")
+		}
+
+		if j >= len(baseLines) {
+			break
+		}
+
+		merged = append(merged, baseLines[j])
+		i, o, t = j+1, oEnd+1, tEnd+1
+	}
+
+	return JoinLines(merged), conflicts
+}
+
+// Merge2 merges ours and theirs without a common base: lines both share
+// are kept, and every region where they differ, even a line added on one
+// side only, is emitted with conflict markers since it is unknown which
+// side changed it. It returns the merged text and the number of
+// conflicting regions.
+func Merge2(ours, theirs string) (string, int) {
+	ourLines := SplitLines(ours)
+	theirLines := SplitLines(theirs)
+	toTheirs := match(ourLines, theirLines)
+
+	var merged []string
+	conflicts := 0
+	o, t := 0, 0
+
+	for {
+		// Find the next line both sides share.
+		j := o
+		for j < len(ourLines) && toTheirs[j] == -1 {
+			j++
+		}
+		tEnd := len(theirLines)
+		if j < len(ourLines) {
+			tEnd = toTheirs[j]
+		}
+
+		if ourChunk, theirChunk := ourLines[o:j], theirLines[t:tEnd]; len(ourChunk) > 0 || len(theirChunk) > 0 {
+			conflicts++
+			merged = append(merged, "<<<<<<< current")
+			merged = append(merged, ourChunk...)
+			merged = append(merged, "=======")
+			merged = append(merged, theirChunk...)
+			merged = append(merged, ">>>>>>> generated")
+		}
+
+		if j >= len(ourLines) {
+			break
+		}
+
+		merged = append(merged, ourLines[j])
+		o, t = j+1, tEnd+1
+	}
+
+	return JoinLines(merged), conflicts
+}
+
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
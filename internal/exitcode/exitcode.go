@@ -0,0 +1,97 @@
+// Package exitcode defines the statuses goforge exits with, so scripts and
+// CI can tell the kinds of failure apart, and the errors that carry them.
+package exitcode
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code is a status goforge exits with.
+type Code int
+
+const (
+	// OK is the status of a command that succeeded.
+	OK Code = 0
+	// Failure is the status of any failure without a more specific code.
+	Failure Code = 1
+	// Config means goforge.yml is missing, unreadable, or invalid.
+	Config Code = 2
+	// Validation means an argument, flag, or name given to goforge is
+	// invalid.
+	Validation Code = 3
+	// Tool means an external tool goforge runs, such as go, git, or a
+	// script, failed or is not installed.
+	Tool Code = 4
+	// Build means the project does not compile.
+	Build Code = 5
+	// Test means tests failed, or coverage is below its threshold.
+	Test Code = 6
+)
+
+// names are the kinds of outcome the codes stand for.
+var names = map[Code]string{
+	OK:         "ok",
+	Failure:    "failure",
+	Config:     "config",
+	Validation: "validation",
+	Tool:       "tool",
+	Build:      "build",
+	Test:       "test",
+}
+
+// String returns the kind of outcome c stands for, e.g. "build", or
+// "exit N" for codes of other programs.
+func (c Code) String() string {
+	if name, ok := names[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("exit %d", int(c))
+}
+
+// Error is an error that makes goforge exit with Code.
+type Error struct {
+	Code Code
+	Err  error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the error e carries.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// ExitCode returns the status goforge exits with for e.
+func (e *Error) ExitCode() int {
+	return int(e.Code)
+}
+
+// Wrap returns err with code, or nil when err is nil.
+func Wrap(code Code, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Errorf formats an error with code, like fmt.Errorf.
+func Errorf(code Code, format string, args ...any) error {
+	return &Error{Code: code, Err: fmt.Errorf(format, args...)}
+}
+
+// Of returns the status goforge exits with for err: OK for nil, the code
+// of the outermost error in its chain with an ExitCode method, such as
+// *Error or *exec.ExitError, or Failure when there is none.
+func Of(err error) Code {
+	if err == nil {
+		return OK
+	}
+	var coded interface{ ExitCode() int }
+	if errors.As(err, &coded) && coded.ExitCode() > 0 {
+		return Code(coded.ExitCode())
+	}
+	return Failure
+}
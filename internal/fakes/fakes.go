@@ -0,0 +1,100 @@
+// Package fakes provides lightweight local stand-ins for external services
+// (SMTP, S3, webhooks) so scaffolded integrations can be exercised in
+// development without cloud accounts.
+package fakes
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// Service is a single fake backend that can be started and stopped.
+type Service interface {
+	// Name returns a short identifier such as "smtp" or "s3".
+	Name() string
+	// Start begins serving in the background.
+	Start() error
+	// Stop shuts the service down.
+	Stop() error
+	// Env returns the environment variables the application should use
+	// to reach this service.
+	Env() map[string]string
+}
+
+// Manager starts and stops a set of fake services together.
+type Manager struct {
+	services []Service
+	started  []Service
+}
+
+// NewManager creates a manager for the given services.
+func NewManager(services ...Service) *Manager {
+	return &Manager{services: services}
+}
+
+// Start starts every service. If one fails, the ones already running are
+// stopped before the error is returned.
+func (m *Manager) Start() error {
+	for _, svc := range m.services {
+		if err := svc.Start(); err != nil {
+			m.Stop()
+			return fmt.Errorf("failed to start %s fake: %w", svc.Name(), err)
+		}
+		m.started = append(m.started, svc)
+		logger.Debug("Started %s fake", svc.Name())
+	}
+	return nil
+}
+
+// Stop stops all running services in reverse start order.
+func (m *Manager) Stop() {
+	for i := len(m.started) - 1; i >= 0; i-- {
+		svc := m.started[i]
+		if err := svc.Stop(); err != nil {
+			logger.Warn("Failed to stop %s fake: %v", svc.Name(), err)
+		}
+	}
+	m.started = nil
+}
+
+// Env merges the environment of every service.
+func (m *Manager) Env() map[string]string {
+	env := make(map[string]string)
+	for _, svc := range m.services {
+		for k, v := range svc.Env() {
+			env[k] = v
+		}
+	}
+	return env
+}
+
+// Export sets the merged environment on the current process so that any
+// child process (scripts, watch mode) inherits it.
+func (m *Manager) Export() error {
+	for k, v := range m.Env() {
+		if err := os.Setenv(k, v); err != nil {
+			return fmt.Errorf("failed to export %s: %w", k, err)
+		}
+	}
+	return nil
+}
+
+// EnvKeys returns the exported variable names in sorted order.
+func (m *Manager) EnvKeys() []string {
+	env := m.Env()
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// DataDir returns the directory fakes store captured data in.
+func DataDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".goforge", "fakes")
+}
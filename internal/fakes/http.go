@@ -0,0 +1,39 @@
+package fakes
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// httpServer is the shared lifecycle for HTTP-based fakes.
+type httpServer struct {
+	port   int
+	server *http.Server
+}
+
+func (h *httpServer) start(handler http.Handler) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", h.port))
+	if err != nil {
+		return err
+	}
+
+	h.server = &http.Server{Handler: handler}
+	go h.server.Serve(listener)
+	return nil
+}
+
+func (h *httpServer) stop() error {
+	if h.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return h.server.Shutdown(ctx)
+}
+
+func (h *httpServer) url() string {
+	return fmt.Sprintf("http://127.0.0.1:%d", h.port)
+}
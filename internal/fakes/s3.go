@@ -0,0 +1,154 @@
+package fakes
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// S3Store is a minimal path-style S3-compatible object store backed by the
+// local filesystem. It supports bucket creation, object PUT/GET/HEAD/DELETE
+// and ListObjectsV2, which covers what typical upload/download code needs.
+type S3Store struct {
+	httpServer
+	dir     string
+	buckets []string
+}
+
+// NewS3Store creates an S3 fake on port storing objects under dir. The given
+// buckets are created on start.
+func NewS3Store(port int, dir string, buckets []string) *S3Store {
+	return &S3Store{httpServer: httpServer{port: port}, dir: dir, buckets: buckets}
+}
+
+func (s *S3Store) Name() string { return "s3" }
+
+func (s *S3Store) Start() error {
+	for _, bucket := range s.buckets {
+		if err := os.MkdirAll(filepath.Join(s.dir, bucket), os.ModePerm); err != nil {
+			return err
+		}
+	}
+	return s.start(http.HandlerFunc(s.serve))
+}
+
+func (s *S3Store) Stop() error { return s.stop() }
+
+func (s *S3Store) Env() map[string]string {
+	return map[string]string{
+		"S3_ENDPOINT":           s.url(),
+		"AWS_ENDPOINT_URL_S3":   s.url(),
+		"AWS_ACCESS_KEY_ID":     "goforge",
+		"AWS_SECRET_ACCESS_KEY": "goforge",
+		"AWS_REGION":            "us-east-1",
+		"S3_FORCE_PATH_STYLE":   "true",
+	}
+}
+
+type listBucketResult struct {
+	XMLName  xml.Name      `xml:"ListBucketResult"`
+	Name     string        `xml:"Name"`
+	Prefix   string        `xml:"Prefix"`
+	KeyCount int           `xml:"KeyCount"`
+	Contents []s3ObjectXML `xml:"Contents"`
+}
+
+type s3ObjectXML struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	Size         int64  `xml:"Size"`
+}
+
+func (s *S3Store) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+	if bucket == "" || strings.Contains(path, "..") {
+		http.Error(w, "invalid bucket", http.StatusBadRequest)
+		return
+	}
+
+	bucketDir := filepath.Join(s.dir, bucket)
+	if key == "" {
+		s.serveBucket(w, r, bucket, bucketDir)
+		return
+	}
+
+	objectPath := filepath.Join(bucketDir, filepath.FromSlash(key))
+	switch r.Method {
+	case http.MethodPut:
+		if err := os.MkdirAll(filepath.Dir(objectPath), os.ModePerm); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		file, err := os.Create(objectPath)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer file.Close()
+		if _, err := io.Copy(file, r.Body); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		logger.Info("🪣 Stored s3://%s/%s", bucket, key)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		if _, err := os.Stat(objectPath); err != nil {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		http.ServeFile(w, r, objectPath)
+	case http.MethodDelete:
+		os.Remove(objectPath)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		http.Error(w, "method not supported", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *S3Store) serveBucket(w http.ResponseWriter, r *http.Request, bucket, bucketDir string) {
+	switch r.Method {
+	case http.MethodPut:
+		if err := os.MkdirAll(bucketDir, os.ModePerm); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodHead:
+		if _, err := os.Stat(bucketDir); err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet:
+		prefix := r.URL.Query().Get("prefix")
+		result := listBucketResult{Name: bucket, Prefix: prefix}
+		filepath.Walk(bucketDir, func(p string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			rel, _ := filepath.Rel(bucketDir, p)
+			key := filepath.ToSlash(rel)
+			if !strings.HasPrefix(key, prefix) {
+				return nil
+			}
+			result.Contents = append(result.Contents, s3ObjectXML{
+				Key:          key,
+				LastModified: info.ModTime().UTC().Format(time.RFC3339),
+				Size:         info.Size(),
+			})
+			return nil
+		})
+		result.KeyCount = len(result.Contents)
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(result)
+	default:
+		http.Error(w, "method not supported", http.StatusMethodNotAllowed)
+	}
+}
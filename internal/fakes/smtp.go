@@ -0,0 +1,177 @@
+package fakes
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// SMTPSink accepts any mail delivered to it and writes each message as an
+// .eml file instead of relaying it.
+type SMTPSink struct {
+	port     int
+	dir      string
+	listener net.Listener
+	wg       sync.WaitGroup
+}
+
+// NewSMTPSink creates an SMTP sink listening on port and storing mail in dir.
+func NewSMTPSink(port int, dir string) *SMTPSink {
+	return &SMTPSink{port: port, dir: dir}
+}
+
+func (s *SMTPSink) Name() string { return "smtp" }
+
+func (s *SMTPSink) Start() error {
+	if err := os.MkdirAll(s.dir, os.ModePerm); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", s.port))
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go s.handle(conn)
+		}
+	}()
+
+	return nil
+}
+
+func (s *SMTPSink) Stop() error {
+	if s.listener == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.wg.Wait()
+	return err
+}
+
+func (s *SMTPSink) Env() map[string]string {
+	return map[string]string{
+		"SMTP_HOST": "127.0.0.1",
+		"SMTP_PORT": strconv.Itoa(s.port),
+	}
+}
+
+// handle speaks just enough SMTP for standard clients to deliver a message.
+func (s *SMTPSink) handle(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		fmt.Fprintf(conn, "%s\r\n", line)
+	}
+
+	var from string
+	var to []string
+
+	reply("220 goforge fake SMTP ready")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		verb := strings.ToUpper(strings.SplitN(line, " ", 2)[0])
+
+		switch verb {
+		case "HELO":
+			reply("250 goforge")
+		case "EHLO":
+			reply("250-goforge")
+			reply("250 8BITMIME")
+		case "MAIL":
+			from = smtpAddress(line)
+			reply("250 OK")
+		case "RCPT":
+			to = append(to, smtpAddress(line))
+			reply("250 OK")
+		case "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			body, err := readSMTPData(reader)
+			if err != nil {
+				return
+			}
+			if path, err := s.store(body); err != nil {
+				logger.Warn("SMTP fake failed to store message: %v", err)
+				reply("451 Local error")
+			} else {
+				logger.Info("📧 Captured mail from %s to %s → %s", from, strings.Join(to, ", "), path)
+				reply("250 OK")
+			}
+			from, to = "", nil
+		case "RSET":
+			from, to = "", nil
+			reply("250 OK")
+		case "NOOP":
+			reply("250 OK")
+		case "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("502 Command not implemented")
+		}
+	}
+}
+
+func (s *SMTPSink) store(body string) (string, error) {
+	name := fmt.Sprintf("%d.eml", time.Now().UnixNano())
+	path := filepath.Join(s.dir, name)
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// readSMTPData reads a DATA section terminated by a single "." line and
+// undoes dot-stuffing.
+func readSMTPData(reader *bufio.Reader) (string, error) {
+	var b strings.Builder
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return "", err
+		}
+		trimmed := strings.TrimRight(line, "\r\n")
+		if trimmed == "." {
+			return b.String(), nil
+		}
+		if strings.HasPrefix(trimmed, "..") {
+			trimmed = trimmed[1:]
+		}
+		b.WriteString(trimmed)
+		b.WriteString("\r\n")
+	}
+}
+
+// smtpAddress extracts the address from "MAIL FROM:<a@b>" style commands.
+func smtpAddress(line string) string {
+	start := strings.Index(line, "<")
+	end := strings.LastIndex(line, ">")
+	if start == -1 || end <= start {
+		if i := strings.Index(line, ":"); i != -1 {
+			return strings.TrimSpace(line[i+1:])
+		}
+		return ""
+	}
+	return line[start+1 : end]
+}
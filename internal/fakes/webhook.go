@@ -0,0 +1,72 @@
+package fakes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// WebhookCatcher records every HTTP request it receives as a JSON file, so
+// outgoing webhooks and callbacks can be inspected after the fact.
+type WebhookCatcher struct {
+	httpServer
+	dir string
+}
+
+// NewWebhookCatcher creates a webhook catcher on port storing requests in dir.
+func NewWebhookCatcher(port int, dir string) *WebhookCatcher {
+	return &WebhookCatcher{httpServer: httpServer{port: port}, dir: dir}
+}
+
+func (c *WebhookCatcher) Name() string { return "webhook" }
+
+func (c *WebhookCatcher) Start() error {
+	if err := os.MkdirAll(c.dir, os.ModePerm); err != nil {
+		return err
+	}
+	return c.start(http.HandlerFunc(c.serve))
+}
+
+func (c *WebhookCatcher) Stop() error { return c.stop() }
+
+func (c *WebhookCatcher) Env() map[string]string {
+	return map[string]string{
+		"WEBHOOK_URL": c.url(),
+	}
+}
+
+type capturedRequest struct {
+	Time    time.Time           `json:"time"`
+	Method  string              `json:"method"`
+	Path    string              `json:"path"`
+	Query   string              `json:"query,omitempty"`
+	Headers map[string][]string `json:"headers"`
+	Body    string              `json:"body"`
+}
+
+func (c *WebhookCatcher) serve(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	captured := capturedRequest{
+		Time:    time.Now(),
+		Method:  r.Method,
+		Path:    r.URL.Path,
+		Query:   r.URL.RawQuery,
+		Headers: r.Header,
+		Body:    string(body),
+	}
+
+	data, _ := json.MarshalIndent(captured, "", "  ")
+	path := filepath.Join(c.dir, fmt.Sprintf("%d.json", captured.Time.UnixNano()))
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		logger.Warn("Webhook fake failed to store request: %v", err)
+	}
+
+	logger.Info("🪝 Caught %s %s (%d bytes)", r.Method, r.URL.Path, len(body))
+	w.WriteHeader(http.StatusOK)
+}
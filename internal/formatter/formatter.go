@@ -0,0 +1,173 @@
+// Package formatter formats the Go files of a project with gofmt,
+// goimports, and optionally gofumpt, or checks that they are formatted.
+package formatter
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Tools pinned by goforge; gofmt comes with Go.
+var (
+	Goimports = runner.Tool{Name: "goimports", Package: "golang.org/x/tools/cmd/goimports", Version: "v0.38.0"}
+	Gofumpt   = runner.Tool{Name: "gofumpt", Package: "mvdan.cc/gofumpt", Version: "v0.9.1"}
+)
+
+// skipDirs are never formatted.
+var skipDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+	"dist":         true,
+}
+
+// batchSize limits the files per formatter invocation, keeping command
+// lines short.
+const batchSize = 200
+
+// Options selects the formatters and whether to write.
+type Options struct {
+	LocalPrefix string // module path grouped after third-party imports
+	Gofumpt     bool   // use gofumpt's stricter rules
+	Check       bool   // only list the files that need formatting
+}
+
+// Files returns the Go files below root, relative to it, leaving out
+// hidden directories, skipDirs, nested modules, and generated files.
+func Files(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || skipDirs[name] {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || isGenerated(path) {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// isGenerated reports whether a Go file carries the "Code generated ...
+// DO NOT EDIT." comment before its package clause.
+func isGenerated(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, "package ") {
+			return false
+		}
+		if strings.HasPrefix(line, "// Code generated ") && strings.HasSuffix(line, " DO NOT EDIT.") {
+			return true
+		}
+	}
+	return false
+}
+
+// Run formats files, relative to root, and returns those that changed,
+// or with Options.Check those that would.
+func Run(root string, files []string, options Options) ([]string, error) {
+	type step struct {
+		name string
+		args []string
+	}
+	// -l lists the files each formatter changes, or would without -w
+	write := func(args ...string) []string {
+		if !options.Check {
+			args = append(args, "-w")
+		}
+		return args
+	}
+
+	steps := []step{{"gofmt", write("-s", "-l")}}
+	if options.Gofumpt {
+		gofumpt, err := runner.EnsureTool(Gofumpt)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, step{gofumpt, write("-l")})
+	}
+	goimports, err := runner.EnsureTool(Goimports)
+	if err != nil {
+		return nil, err
+	}
+	importArgs := write("-l")
+	if options.LocalPrefix != "" {
+		importArgs = append(importArgs, "-local", options.LocalPrefix)
+	}
+	steps = append(steps, step{goimports, importArgs})
+
+	changed := map[string]bool{}
+	for _, s := range steps {
+		for batch := range slices.Chunk(files, batchSize) {
+			listed, err := listFiles(root, s.name, slices.Concat(s.args, batch))
+			if err != nil {
+				return nil, err
+			}
+			for _, file := range listed {
+				changed[file] = true
+			}
+		}
+	}
+
+	var result []string
+	for _, file := range files {
+		if changed[file] {
+			result = append(result, file)
+		}
+	}
+	return result, nil
+}
+
+// listFiles runs a formatter with -l in root and returns the files it
+// lists.
+func listFiles(root, name string, args []string) ([]string, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Dir = root
+	cmd.Env = runner.Environ()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s failed: %s", filepath.Base(name), strings.TrimSpace(stderr.String()))
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, filepath.Clean(line))
+		}
+	}
+	return files, nil
+}
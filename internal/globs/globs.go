@@ -0,0 +1,424 @@
+// Package globs implements the path matching used throughout goforge:
+// watch and ignore lists, clean targets, and build assets.
+//
+// Patterns are matched against slash-separated paths relative to the
+// project root and support:
+//
+//	?        a single character within one path segment
+//	*        any run of characters within one path segment
+//	**       zero or more whole path segments
+//	[a-z]    a character class ([!a-z] negates)
+//	{a,b}    alternatives, which may nest and contain separators
+//	\x       a literal x
+//
+// In a Set, a pattern starting with "!" re-includes paths excluded by an
+// earlier pattern (or excludes paths included by one); the last matching
+// pattern wins.
+package globs
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Match reports whether name matches pattern. Malformed patterns match nothing.
+func Match(pattern, name string) bool {
+	name = clean(name)
+	for _, expanded := range Expand(pattern) {
+		if matchSegments(split(expanded), split(name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate reports whether pattern is well formed.
+func Validate(pattern string) error {
+	pattern = strings.TrimPrefix(pattern, "!")
+	for _, expanded := range Expand(pattern) {
+		for _, segment := range split(expanded) {
+			if _, err := path.Match(segmentPattern(segment), ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// HasMeta reports whether pattern contains any glob syntax. Patterns
+// without it name a single path literally.
+func HasMeta(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[{\`) || strings.HasPrefix(pattern, "!")
+}
+
+// Regexp returns a regular expression matching the same slash-separated
+// paths as pattern, for tools that take regular expressions instead of
+// globs. A leading "!" is not part of a pattern and must be removed first.
+func Regexp(pattern string) string {
+	var alternatives []string
+	for _, expanded := range Expand(pattern) {
+		alternatives = append(alternatives, segmentsRegexp(split(clean(expanded))))
+	}
+	if len(alternatives) == 1 {
+		return "^" + alternatives[0] + "$"
+	}
+	return "^(" + strings.Join(alternatives, "|") + ")$"
+}
+
+// segmentsRegexp translates the segments of an expanded pattern, "**"
+// matching zero or more whole segments as in matchSegments.
+func segmentsRegexp(segments []string) string {
+	var b strings.Builder
+	for i, segment := range segments {
+		switch {
+		case segment == "**" && i > 0 && segments[i-1] == "**":
+			continue
+		case segment == "**" && i == len(segments)-1:
+			if i == 0 {
+				b.WriteString(".*")
+			} else {
+				b.WriteString("(/.*)?")
+			}
+		case segment == "**":
+			if i > 0 {
+				b.WriteString("/")
+			}
+			b.WriteString("(.*/)?")
+		default:
+			if i > 0 && segments[i-1] != "**" {
+				b.WriteString("/")
+			}
+			b.WriteString(segmentRegexp(segment))
+		}
+	}
+	return b.String()
+}
+
+// segmentRegexp translates a single path segment.
+func segmentRegexp(segment string) string {
+	var b strings.Builder
+	for i := 0; i < len(segment); i++ {
+		switch c := segment[i]; c {
+		case '*':
+			b.WriteString("[^/]*")
+		case '?':
+			b.WriteString("[^/]")
+		case '\\':
+			if i+1 < len(segment) {
+				i++
+			}
+			b.WriteString(regexp.QuoteMeta(segment[i : i+1]))
+		case '[':
+			end := strings.IndexByte(segment[i+1:], ']')
+			if end < 0 {
+				b.WriteString(`\[`)
+				continue
+			}
+			class := segment[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			b.WriteString("[" + class + "]")
+			i += end + 1
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	return b.String()
+}
+
+// Expand performs brace expansion: "a/{b,c}.go" becomes "a/b.go" and
+// "a/c.go". Unbalanced braces are kept literally.
+func Expand(pattern string) []string {
+	start, end, ok := findBraces(pattern)
+	if !ok {
+		return []string{pattern}
+	}
+
+	prefix, body, suffix := pattern[:start], pattern[start+1:end], pattern[end+1:]
+	var result []string
+	for _, alternative := range splitAlternatives(body) {
+		result = append(result, Expand(prefix+alternative+suffix)...)
+	}
+	return result
+}
+
+// findBraces locates the first "{...}" group containing a top-level comma.
+func findBraces(pattern string) (int, int, bool) {
+	for start := 0; start < len(pattern); start++ {
+		switch pattern[start] {
+		case '\\':
+			start++
+		case '{':
+			if end, hasComma := closingBrace(pattern, start); end > 0 && hasComma {
+				return start, end, true
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// closingBrace returns the index of the brace closing the one at start, or
+// -1, and whether the group contains a top-level comma.
+func closingBrace(pattern string, start int) (int, bool) {
+	depth, hasComma := 0, false
+	for i := start; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case ',':
+			if depth == 1 {
+				hasComma = true
+			}
+		case '}':
+			depth--
+			if depth == 0 {
+				return i, hasComma
+			}
+		}
+	}
+	return -1, false
+}
+
+// splitAlternatives splits a brace body on its top-level commas.
+func splitAlternatives(body string) []string {
+	var parts []string
+	depth, last := 0, 0
+	for i := 0; i < len(body); i++ {
+		switch body[i] {
+		case '\\':
+			i++
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, body[last:i])
+				last = i + 1
+			}
+		}
+	}
+	return append(parts, body[last:])
+}
+
+// matchSegments matches path segments, letting "**" consume any number of them.
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			for len(pattern) > 1 && pattern[1] == "**" {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(pattern[1:], name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(segmentPattern(pattern[0]), name[0]); !ok {
+			return false
+		}
+		pattern, name = pattern[1:], name[1:]
+	}
+	return len(name) == 0
+}
+
+// segmentPattern translates a pattern segment to the syntax of
+// path.Match, which negates character classes with "^" instead of "!".
+func segmentPattern(segment string) string {
+	if !strings.Contains(segment, "[!") {
+		return segment
+	}
+	b := []byte(segment)
+	for i := 0; i < len(b); i++ {
+		switch b[i] {
+		case '\\':
+			i++
+		case '[':
+			if i+1 < len(b) && b[i+1] == '!' {
+				b[i+1] = '^'
+			}
+			if end := strings.IndexByte(segment[i+1:], ']'); end >= 0 {
+				i += end + 1
+			}
+		}
+	}
+	return string(b)
+}
+
+// clean normalizes a path to the slash-separated form patterns use.
+func clean(name string) string {
+	name = path.Clean(filepath.ToSlash(name))
+	return strings.TrimPrefix(name, "./")
+}
+
+// split breaks a cleaned path or pattern into segments.
+func split(p string) []string {
+	p = strings.Trim(p, "/")
+	if p == "" || p == "." {
+		return nil
+	}
+	return strings.Split(p, "/")
+}
+
+// Set is an ordered list of patterns, some possibly negated.
+type Set struct {
+	rules []rule
+}
+
+type rule struct {
+	pattern string
+	negate  bool
+}
+
+// NewSet builds a Set from patterns. Empty patterns are ignored.
+func NewSet(patterns ...string) *Set {
+	s := &Set{}
+	for _, p := range patterns {
+		negate := strings.HasPrefix(p, "!")
+		p = strings.TrimPrefix(p, "!")
+		if p == "" {
+			continue
+		}
+		s.rules = append(s.rules, rule{pattern: p, negate: negate})
+	}
+	return s
+}
+
+// Empty reports whether the set has no patterns.
+func (s *Set) Empty() bool {
+	return s == nil || len(s.rules) == 0
+}
+
+// Match reports whether name is included by the set: the last pattern
+// matching name decides, and a name matched by none is excluded.
+func (s *Set) Match(name string) bool {
+	if s == nil {
+		return false
+	}
+	matched := false
+	for _, r := range s.rules {
+		if Match(r.pattern, name) {
+			matched = !r.negate
+		}
+	}
+	return matched
+}
+
+// Glob returns the paths under root (joined with root) whose relative
+// path is included by patterns, in lexical order. Only the directories a
+// pattern can reach are walked.
+func Glob(root string, patterns ...string) ([]string, error) {
+	set := NewSet(patterns...)
+	if set.Empty() {
+		return nil, nil
+	}
+
+	maxDepth := set.maxDepth()
+	seen := make(map[string]bool)
+
+	var matches []string
+	for _, base := range set.bases() {
+		start := filepath.Join(root, filepath.FromSlash(base))
+		if _, err := os.Lstat(start); os.IsNotExist(err) {
+			continue
+		}
+
+		err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			rel, err := filepath.Rel(root, p)
+			if err != nil || rel == "." {
+				return err
+			}
+
+			if !seen[p] && set.Match(rel) {
+				seen[p] = true
+				matches = append(matches, p)
+			}
+			if d.IsDir() && maxDepth >= 0 && len(split(clean(rel))) >= maxDepth {
+				return filepath.SkipDir
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	sort.Strings(matches)
+	return matches, nil
+}
+
+// bases returns the literal leading directories of the non-negated
+// patterns, e.g. "web/static" for "web/static/**/*.css". Nested bases are
+// dropped in favour of their parents.
+func (s *Set) bases() []string {
+	var bases []string
+	for _, r := range s.rules {
+		if r.negate {
+			continue
+		}
+		for _, expanded := range Expand(r.pattern) {
+			segments := split(expanded)
+			literal := 0
+			for literal < len(segments)-1 && !HasMeta(segments[literal]) {
+				literal++
+			}
+			bases = append(bases, strings.Join(segments[:literal], "/"))
+		}
+	}
+
+	sort.Strings(bases)
+	var result []string
+	for _, base := range bases {
+		covered := false
+		for _, parent := range result {
+			if parent == "" || base == parent || strings.HasPrefix(base, parent+"/") {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			result = append(result, base)
+		}
+	}
+	return result
+}
+
+// maxDepth returns the deepest path any pattern can match, or -1 when a
+// pattern contains "**" and can match at any depth.
+func (s *Set) maxDepth() int {
+	depth := 0
+	for _, r := range s.rules {
+		for _, expanded := range Expand(r.pattern) {
+			segments := split(expanded)
+			for _, segment := range segments {
+				if segment == "**" {
+					return -1
+				}
+			}
+			if len(segments) > depth {
+				depth = len(segments)
+			}
+		}
+	}
+	return depth
+}
@@ -0,0 +1,330 @@
+package globs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+var matchTests = []struct {
+	pattern string
+	name    string
+	want    bool
+}{
+	// Single segments
+	{"*.go", "main.go", true},
+	{"*.go", "cmd/main.go", false},
+	{"*.go", "main.go.orig", false},
+	{"cmd/*.go", "./cmd/main.go", true},
+	{"cmd/*", "cmd/server/main.go", false},
+	{"?.go", "a.go", true},
+	{"?.go", "ab.go", false},
+	{"?", "/", false},
+
+	// ** at the start
+	{"**/*.go", "main.go", true},
+	{"**/*.go", "internal/a/b/c.go", true},
+	{"**/*.go", "internal/a/b/c.txt", false},
+	{"**/node_modules/**", "node_modules/a.js", true},
+	{"**/node_modules/**", "web/node_modules/a/b.js", true},
+	{"**/node_modules/**", "web/node_modules_old/a.js", false},
+
+	// ** in the middle
+	{"internal/**/*.go", "internal/x.go", true},
+	{"internal/**/*.go", "internal/a/b/x.go", true},
+	{"internal/**/*.go", "cmd/x.go", false},
+	{"a/**/b/**/c", "a/b/c", true},
+	{"a/**/b/**/c", "a/x/b/y/z/c", true},
+	{"a/**/b/**/c", "a/c", false},
+	{"a/**/**/c", "a/c", true},
+
+	// ** at the end
+	{"internal/**", "internal", true},
+	{"internal/**", "internal/a/b", true},
+	{"internal/**", "internalx/a", false},
+	{"**", "anything/at/all", true},
+
+	// ** only matches whole segments
+	{"a**/x", "ab/x", true},
+	{"a**/x", "ab/c/x", false},
+
+	// Character classes
+	{"[a-c].go", "b.go", true},
+	{"[a-c].go", "d.go", false},
+	{"[!a-c].go", "d.go", true},
+	{"[!a-c].go", "a.go", false},
+	{"v[0-9]/*.proto", "v1/user.proto", true},
+	{"v[0-9]/*.proto", "vx/user.proto", false},
+
+	// Braces
+	{"*.{go,mod}", "go.mod", true},
+	{"*.{go,mod}", "go.sum", false},
+	{"{cmd,internal}/**/*.go", "cmd/x/y.go", true},
+	{"{cmd,internal}/**/*.go", "pkg/x.go", false},
+	{"{cmd/server,internal}/main.go", "cmd/server/main.go", true},
+	{"{a,b{c,d}}.txt", "a.txt", true},
+	{"{a,b{c,d}}.txt", "bd.txt", true},
+	{"{a,b{c,d}}.txt", "b.txt", false},
+	{"{a,b{c,d}}.txt", "be.txt", false},
+	{"{,x}y", "y", true},
+	{"{,x}y", "xy", true},
+	{"{a}.txt", "{a}.txt", true},
+	{"{a,b.txt", "{a,b.txt", true},
+
+	// Escaped metacharacters
+	{`\*.go`, "*.go", true},
+	{`\*.go`, "main.go", false},
+	{`a\?`, "a?", true},
+	{`a\?`, "ab", false},
+	{`\[x\]`, "[x]", true},
+	{`\[x\]`, "x", false},
+	{`\{a,b\}`, "{a,b}", true},
+	{`\{a,b\}`, "a", false},
+
+	// Malformed patterns match nothing
+	{"[a-", "[a-", false},
+}
+
+func TestMatch(t *testing.T) {
+	for _, tt := range matchTests {
+		if got := Match(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("Match(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestRegexpAgreesWithMatch(t *testing.T) {
+	for _, tt := range matchTests {
+		if Validate(tt.pattern) != nil {
+			continue
+		}
+		re, err := regexp.Compile(Regexp(tt.pattern))
+		if err != nil {
+			t.Errorf("Regexp(%q) = %q does not compile: %v", tt.pattern, Regexp(tt.pattern), err)
+			continue
+		}
+		if got := re.MatchString(clean(tt.name)); got != tt.want {
+			t.Errorf("Regexp(%q) = %q matches %q: %v, want %v", tt.pattern, re, tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"a/b.go", []string{"a/b.go"}},
+		{"a/{b,c}.go", []string{"a/b.go", "a/c.go"}},
+		{"{a,b{c,d}}", []string{"a", "bc", "bd"}},
+		{"{a,b}{1,2}", []string{"a1", "a2", "b1", "b2"}},
+		{"{,x}y", []string{"y", "xy"}},
+		{"{a}", []string{"{a}"}},
+		{"{a,b", []string{"{a,b"}},
+		{`\{a,b\}`, []string{`\{a,b\}`}},
+		{`{a\,b,c}`, []string{`a\,b`, "c"}},
+	}
+	for _, tt := range tests {
+		if got := Expand(tt.pattern); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Expand(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		pattern string
+		valid   bool
+	}{
+		{"**/*.go", true},
+		{"!**/*_test.go", true},
+		{"{a,b}/[0-9]*", true},
+		{"[a-", false},
+		{"{a,[b}", false},
+	}
+	for _, tt := range tests {
+		if err := Validate(tt.pattern); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) = %v, want valid %v", tt.pattern, err, tt.valid)
+		}
+	}
+}
+
+func TestHasMeta(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    bool
+	}{
+		{"dist", false},
+		{"web/static", false},
+		{"*.test", true},
+		{"a?", true},
+		{"[ab]", true},
+		{"a{b,c}", true},
+		{`a\b`, true},
+		{"!dist", true},
+	}
+	for _, tt := range tests {
+		if got := HasMeta(tt.pattern); got != tt.want {
+			t.Errorf("HasMeta(%q) = %v, want %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		matches  map[string]bool
+	}{
+		{
+			name:     "negation excludes",
+			patterns: []string{"**/*.go", "!**/*_test.go"},
+			matches:  map[string]bool{"a/b.go": true, "a/b_test.go": false, "a/b.txt": false},
+		},
+		{
+			name:     "last match wins",
+			patterns: []string{"!**/*_test.go", "**/*.go"},
+			matches:  map[string]bool{"a_test.go": true, "a.go": true},
+		},
+		{
+			name:     "re-include after exclude",
+			patterns: []string{"dist/**", "!dist/keep/**", "dist/keep/tmp/**"},
+			matches: map[string]bool{
+				"dist/a":          true,
+				"dist/keep/x":     false,
+				"dist/keep/tmp/y": true,
+			},
+		},
+		{
+			name:     "only negations match nothing",
+			patterns: []string{"!*.go"},
+			matches:  map[string]bool{"main.go": false, "main.txt": false},
+		},
+		{
+			name:     "empty patterns are ignored",
+			patterns: []string{"", "!", "*.go"},
+			matches:  map[string]bool{"main.go": true},
+		},
+		{
+			// defaultWatchPatterns of goforge watch
+			name:     "watch defaults",
+			patterns: []string{"**/*.{go,yml,yaml,json}"},
+			matches: map[string]bool{
+				"cmd/server/main.go": true,
+				"config/default.yml": true,
+				"goforge.yaml":       true,
+				"api/openapi.json":   true,
+				"README.md":          false,
+			},
+		},
+		{
+			// defaultIgnorePatterns of goforge watch
+			name: "watch ignore defaults",
+			patterns: []string{
+				"**/*_test.go",
+				"{dist,vendor,.git,.goforge}/**",
+				"**/node_modules/**",
+				"**/*.{tmp,log}",
+			},
+			matches: map[string]bool{
+				"internal/x_test.go":       true,
+				"dist/app":                 true,
+				".git/HEAD":                true,
+				".goforge/watch/watch.log": true,
+				"web/node_modules/a/b.js":  true,
+				"tmp/a.log":                true,
+				"internal/x.go":            false,
+				"distro/x.go":              false,
+				"internal/vendor/x.go":     false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		set := NewSet(tt.patterns...)
+		for name, want := range tt.matches {
+			if got := set.Match(name); got != want {
+				t.Errorf("%s: NewSet(%q).Match(%q) = %v, want %v", tt.name, tt.patterns, name, got, want)
+			}
+		}
+	}
+
+	var nilSet *Set
+	if !nilSet.Empty() || nilSet.Match("a") {
+		t.Errorf("a nil Set must be empty and match nothing")
+	}
+	if !NewSet("", "!").Empty() {
+		t.Errorf("NewSet(\"\", \"!\") is not empty")
+	}
+}
+
+func TestGlob(t *testing.T) {
+	root := t.TempDir()
+	for _, file := range []string{
+		"coverage.out",
+		"app.test",
+		"internal/x.test",
+		"dist/app",
+		"dist/sub/x",
+		"config/default.yml",
+		"config/local/dev.yml",
+		"web/static/app.js",
+		"web/static/app.js.map",
+		"web/static/css/site.css",
+		"web/static/css/site.css.map",
+	} {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		patterns []string
+		want     []string
+	}{
+		// The targets of goforge clean
+		{"clean directory", []string{"dist"}, []string{"dist"}},
+		{"clean file", []string{"coverage.out"}, []string{"coverage.out"}},
+		{"clean top-level only", []string{"*.test"}, []string{"app.test"}},
+		{"clean nested", []string{"**/*.test"}, []string{"app.test", "internal/x.test"}},
+		{"missing target", []string{"coverage.html"}, nil},
+
+		// The build.assets of goforge build, directories expanded to dir/**
+		{
+			"assets",
+			[]string{"web/static/**", "!**/*.map", "config/*.yml"},
+			[]string{"config/default.yml", "web/static", "web/static/app.js", "web/static/css", "web/static/css/site.css"},
+		},
+		{
+			"assets with braces",
+			[]string{"{config,web}/**/*.{yml,css}"},
+			[]string{"config/default.yml", "config/local/dev.yml", "web/static/css/site.css"},
+		},
+		{"missing base", []string{"nope/**"}, nil},
+		{"no patterns", nil, nil},
+	}
+	for _, tt := range tests {
+		matches, err := Glob(root, tt.patterns...)
+		if err != nil {
+			t.Errorf("%s: Glob(%q): %v", tt.name, tt.patterns, err)
+			continue
+		}
+		var got []string
+		for _, match := range matches {
+			rel, err := filepath.Rel(root, match)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got = append(got, filepath.ToSlash(rel))
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Glob(%q) = %q, want %q", tt.name, tt.patterns, got, tt.want)
+		}
+	}
+}
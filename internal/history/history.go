@@ -0,0 +1,142 @@
+// Package history records the goforge commands run in a project so they
+// can be listed with 'goforge history' and repeated with 'goforge redo'.
+//
+// Entries are stored one JSON object per line in .goforge/history under
+// the project root. Only the most recent MaxEntries are kept.
+package history
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// File is the history file, relative to the project root.
+const File = ".goforge/history"
+
+// MaxEntries is how many entries are kept; older ones are dropped.
+const MaxEntries = 1000
+
+// Entry is one recorded invocation.
+type Entry struct {
+	ID       int       `json:"id"`
+	Time     time.Time `json:"time"`
+	Args     []string  `json:"args"`          // Arguments after the program name
+	Dir      string    `json:"dir,omitempty"` // Working directory, relative to the project root
+	ExitCode int       `json:"exit_code"`
+	Error    string    `json:"error,omitempty"`
+	Duration int64     `json:"duration_ms"`
+}
+
+// Command returns the entry as a shell command line.
+func (e Entry) Command() string {
+	parts := []string{"goforge"}
+	for _, arg := range e.Args {
+		parts = append(parts, quote(arg))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Load reads the history of the project at projectRoot, oldest first. A
+// missing file is an empty history; unreadable lines are skipped.
+func Load(projectRoot string) ([]Entry, error) {
+	data, err := os.ReadFile(filepath.Join(projectRoot, File))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+
+	var entries []Entry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if json.Unmarshal(scanner.Bytes(), &entry) == nil && len(entry.Args) > 0 {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, scanner.Err()
+}
+
+// Append records an entry, numbering it after the last one, and trims the
+// file to MaxEntries. It returns the entry as stored.
+func Append(projectRoot string, entry Entry) (Entry, error) {
+	entries, err := Load(projectRoot)
+	if err != nil {
+		return entry, err
+	}
+	entry.ID = 1
+	if len(entries) > 0 {
+		entry.ID = entries[len(entries)-1].ID + 1
+	}
+
+	path := filepath.Join(projectRoot, File)
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return entry, err
+	}
+
+	if len(entries) >= MaxEntries {
+		entries = append(entries[len(entries)-MaxEntries+1:], entry)
+		return entry, write(path, entries)
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return entry, err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return entry, err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return entry, err
+	}
+	return entry, f.Close()
+}
+
+// Clear deletes the project's history.
+func Clear(projectRoot string) error {
+	err := os.Remove(filepath.Join(projectRoot, File))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// Find returns the entry with the given ID.
+func Find(entries []Entry, id int) (Entry, bool) {
+	for _, entry := range entries {
+		if entry.ID == id {
+			return entry, true
+		}
+	}
+	return Entry{}, false
+}
+
+func write(path string, entries []Entry) error {
+	var buf bytes.Buffer
+	for _, entry := range entries {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		buf.Write(append(line, '\n'))
+	}
+	return os.WriteFile(path, buf.Bytes(), 0644)
+}
+
+// quote single-quotes an argument when the shell would split or expand it.
+func quote(arg string) string {
+	if arg != "" && !strings.ContainsAny(arg, " \t\n'\"\\$`*?[]{}()<>|&;#~!") {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
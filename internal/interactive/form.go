@@ -0,0 +1,365 @@
+package interactive
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/fatih/color"
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// QuestionKind is how a question is answered.
+type QuestionKind int
+
+const (
+	// Input takes free-form text.
+	Input QuestionKind = iota
+	// Select picks one of the options with the arrow keys.
+	Select
+	// MultiSelect toggles any number of the options with space.
+	MultiSelect
+	// Confirm is answered with yes or no.
+	Confirm
+)
+
+// Question is a step of a form. Answers are strings: the text of an
+// Input, the Name of the selected option, the comma-separated names of
+// the MultiSelect options, or "true"/"false" for a Confirm.
+type Question struct {
+	Key         string
+	Prompt      string
+	Description string
+	Kind        QuestionKind
+	Options     []Template // Select and MultiSelect
+	Default     string
+
+	// Validate rejects an answer with the reason shown under the question.
+	Validate func(string) error
+}
+
+// Answers are the answers of a form, by question key.
+type Answers map[string]string
+
+// List splits the answer of a MultiSelect question.
+func (a Answers) List(key string) []string {
+	if a[key] == "" {
+		return nil
+	}
+	return strings.Split(a[key], ",")
+}
+
+// Bool returns the answer of a Confirm question.
+func (a Answers) Bool(key string) bool {
+	return a[key] == "true"
+}
+
+// ErrCancelled is returned when the user quits a form.
+var ErrCancelled = fmt.Errorf("cancelled by user")
+
+// Ask runs a form over questions. Questions are rebuilt from the answers
+// so far after every step, so later questions may depend on earlier
+// answers; answers are kept by key when the user goes back.
+func Ask(title string, questions func(Answers) []Question) (Answers, error) {
+	m := &formModel{title: title, build: questions, answers: Answers{}}
+	m.load()
+
+	final, err := tea.NewProgram(m).Run()
+	if err != nil {
+		return nil, fmt.Errorf("failed to run the form: %w", err)
+	}
+	result := final.(*formModel)
+	if result.cancelled {
+		return nil, ErrCancelled
+	}
+	return result.answers, nil
+}
+
+// formModel is the bubbletea model of Ask.
+type formModel struct {
+	title     string
+	build     func(Answers) []Question
+	answers   Answers
+	questions []Question
+	step      int
+
+	// State of the current question
+	text     []rune
+	cursor   int
+	selected map[string]bool
+	problem  string
+
+	done, cancelled bool
+}
+
+// current returns the question being answered.
+func (m *formModel) current() Question {
+	return m.questions[m.step]
+}
+
+// load rebuilds the questions and prepares the current one from its
+// previous answer or its default.
+func (m *formModel) load() {
+	m.questions = m.build(m.answers)
+	if m.step >= len(m.questions) {
+		m.done = true
+		return
+	}
+	q := m.current()
+	value, ok := m.answers[q.Key]
+	if !ok {
+		value = q.Default
+	}
+
+	m.text, m.cursor, m.selected, m.problem = []rune(value), 0, map[string]bool{}, ""
+	switch q.Kind {
+	case Select:
+		for i, option := range q.Options {
+			if option.Name == value {
+				m.cursor = i
+			}
+		}
+	case MultiSelect:
+		for _, name := range strings.Split(value, ",") {
+			m.selected[name] = true
+		}
+	case Confirm:
+		if value != "true" && value != "false" {
+			m.text = []rune("true")
+		}
+	}
+}
+
+// Init implements tea.Model.
+func (m *formModel) Init() tea.Cmd {
+	if m.done {
+		return tea.Quit
+	}
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *formModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	q := m.current()
+
+	switch key.Type {
+	case tea.KeyCtrlC:
+		m.cancelled = true
+		return m, tea.Quit
+	case tea.KeyEsc, tea.KeyShiftTab:
+		if m.step > 0 {
+			m.step--
+			m.load()
+		}
+		return m, nil
+	case tea.KeyEnter:
+		return m.submit()
+	}
+
+	switch q.Kind {
+	case Input:
+		switch key.Type {
+		case tea.KeyRunes, tea.KeySpace:
+			m.text = append(m.text, key.Runes...)
+		case tea.KeyBackspace:
+			if len(m.text) > 0 {
+				m.text = m.text[:len(m.text)-1]
+			}
+		case tea.KeyCtrlU:
+			m.text = nil
+		}
+		m.problem = ""
+
+	case Select, MultiSelect:
+		switch key.String() {
+		case "up", "k":
+			m.cursor = (m.cursor + len(q.Options) - 1) % len(q.Options)
+		case "down", "j", "tab":
+			m.cursor = (m.cursor + 1) % len(q.Options)
+		case " ", "x":
+			if q.Kind == MultiSelect {
+				name := q.Options[m.cursor].Name
+				m.selected[name] = !m.selected[name]
+			}
+		}
+
+	case Confirm:
+		switch key.String() {
+		case "y", "Y":
+			m.text = []rune("true")
+			return m.submit()
+		case "n", "N":
+			m.text = []rune("false")
+			return m.submit()
+		case "left", "right", "h", "l", "tab", " ":
+			if string(m.text) == "true" {
+				m.text = []rune("false")
+			} else {
+				m.text = []rune("true")
+			}
+		}
+	}
+	return m, nil
+}
+
+// submit validates the answer of the current question and moves on.
+func (m *formModel) submit() (tea.Model, tea.Cmd) {
+	q := m.current()
+
+	var answer string
+	switch q.Kind {
+	case Input:
+		answer = strings.TrimSpace(string(m.text))
+	case Select:
+		answer = q.Options[m.cursor].Name
+	case MultiSelect:
+		var names []string
+		for _, option := range q.Options {
+			if m.selected[option.Name] {
+				names = append(names, option.Name)
+			}
+		}
+		answer = strings.Join(names, ",")
+	case Confirm:
+		answer = string(m.text)
+	}
+
+	if q.Validate != nil {
+		if err := q.Validate(answer); err != nil {
+			m.problem = err.Error()
+			return m, nil
+		}
+	}
+	m.answers[q.Key] = answer
+	m.step++
+	m.load()
+	if m.done {
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// formMarks are the symbols of the form; plainMarks replace them when
+// emoji are turned off.
+type formMarks struct {
+	answered, pointer, selected, unselected string
+}
+
+var (
+	fancyMarks = formMarks{answered: "✔", pointer: "❯ ", selected: "◉ ", unselected: "◯ "}
+	plainMarks = formMarks{answered: "+", pointer: "> ", selected: "[x] ", unselected: "[ ] "}
+)
+
+// View implements tea.Model.
+func (m *formModel) View() string {
+	var b strings.Builder
+	bold := color.New(color.FgCyan, color.Bold)
+	faint := color.New(color.Faint)
+	green := color.New(color.FgGreen)
+	marks := fancyMarks
+	if !logger.EmojiEnabled() {
+		marks = plainMarks
+	}
+
+	if m.title != "" {
+		b.WriteString(bold.Sprint(logger.Plain(m.title)) + "\n\n")
+	}
+
+	// Answered questions stay on screen
+	for _, q := range m.questions[:min(m.step, len(m.questions))] {
+		fmt.Fprintf(&b, "%s %s %s\n", green.Sprint(marks.answered), logger.Plain(q.Prompt), green.Sprint(display(q, m.answers[q.Key])))
+	}
+	if m.done || m.cancelled {
+		return b.String()
+	}
+
+	q := m.current()
+	fmt.Fprintf(&b, "%s %s", color.New(color.FgYellow).Sprint("?"), logger.Plain(q.Prompt))
+	description := ""
+	if q.Description != "" {
+		description = "  " + faint.Sprint(q.Description) + "\n"
+	}
+
+	switch q.Kind {
+	case Input:
+		b.WriteString(" " + string(m.text) + "█\n" + description)
+	case Select, MultiSelect:
+		b.WriteString("\n" + description)
+		for i, option := range q.Options {
+			pointer := "  "
+			if i == m.cursor {
+				pointer = bold.Sprint(marks.pointer)
+			}
+			box := ""
+			if q.Kind == MultiSelect {
+				box = marks.unselected
+				if m.selected[option.Name] {
+					box = green.Sprint(marks.selected)
+				}
+			}
+			name := option.Name
+			if i == m.cursor {
+				name = bold.Sprint(name)
+			}
+			fmt.Fprintf(&b, "%s%s%s", pointer, box, name)
+			if option.Description != "" {
+				b.WriteString(faint.Sprint(" - " + option.Description))
+			}
+			b.WriteString("\n")
+		}
+	case Confirm:
+		yes, no := "Yes", "No"
+		if string(m.text) == "true" {
+			yes = bold.Sprint("[Yes]")
+		} else {
+			no = bold.Sprint("[No]")
+		}
+		fmt.Fprintf(&b, " %s / %s\n%s", yes, no, description)
+	}
+
+	if m.problem != "" {
+		b.WriteString(color.New(color.FgRed).Sprint(logger.Plain(fmt.Sprintf("  ❌ %s\n", m.problem))))
+	}
+
+	var hints []string
+	switch q.Kind {
+	case Select:
+		hints = append(hints, "↑/↓ move", "enter select")
+	case MultiSelect:
+		hints = append(hints, "↑/↓ move", "space toggle", "enter confirm")
+	case Confirm:
+		hints = append(hints, "y/n", "←/→ toggle", "enter confirm")
+	default:
+		hints = append(hints, "enter confirm")
+	}
+	if m.step > 0 {
+		hints = append(hints, "esc back")
+	}
+	hints = append(hints, "ctrl+c cancel")
+	b.WriteString("\n" + faint.Sprint(strings.Join(hints, " • ")) + "\n")
+	return b.String()
+}
+
+// display formats an answer for the list of answered questions.
+func display(q Question, answer string) string {
+	switch q.Kind {
+	case Confirm:
+		if answer == "true" {
+			return "Yes"
+		}
+		return "No"
+	case MultiSelect:
+		if answer == "" {
+			return "none"
+		}
+		return strings.ReplaceAll(answer, ",", ", ")
+	}
+	if answer == "" {
+		return "(empty)"
+	}
+	return answer
+}
@@ -2,12 +2,16 @@ package interactive
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"os"
+	"path"
 	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
 	"github.com/night-slayer18/goforge/internal/validation"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -15,14 +19,16 @@ import (
 
 // InteractiveSession manages the interactive CLI session
 type InteractiveSession struct {
-	scanner   *bufio.Scanner
 	validator *validation.ProjectValidator
+	templates []Template
+	features  func(template string) []Template
+	variables func(template string) []Question
+	defaults  ProjectDefaults
 }
 
 // NewInteractiveSession creates a new interactive session
 func NewInteractiveSession() *InteractiveSession {
 	return &InteractiveSession{
-		scanner:   bufio.NewScanner(os.Stdin),
 		validator: validation.NewProjectValidator(),
 	}
 }
@@ -32,268 +38,236 @@ type ProjectOptions struct {
 	ProjectName string
 	ModulePath  string
 	Template    string
+	Features    []string
+	Vars        map[string]string // Values of the template's variables
+	Description string
+	Author      string
+	Repository  string
+	License     string
 	SkipGit     bool
 	Verbose     bool
 }
 
+// WithTemplates replaces the offered project templates, e.g. to include
+// the templates of installed packs.
+func (is *InteractiveSession) WithTemplates(templates []Template) *InteractiveSession {
+	is.templates = templates
+	return is
+}
+
+// WithFeatures sets the features offered for each template.
+func (is *InteractiveSession) WithFeatures(features func(template string) []Template) *InteractiveSession {
+	is.features = features
+	return is
+}
+
+// WithVariables sets the questions for the variables of each template.
+// Their keys are the variable names.
+func (is *InteractiveSession) WithVariables(variables func(template string) []Question) *InteractiveSession {
+	is.variables = variables
+	return is
+}
+
+// ProjectDefaults are the answers the project wizard starts from, e.g.
+// from the user config.
+type ProjectDefaults struct {
+	ModulePrefix string // Module paths default to <prefix>/<name>
+	Template     string
+	Author       string
+	License      string
+	SkipGit      bool
+}
+
+// WithDefaults sets the default answers of the project wizard.
+func (is *InteractiveSession) WithDefaults(defaults ProjectDefaults) *InteractiveSession {
+	is.defaults = defaults
+	return is
+}
+
+// Question keys of the project wizard; template variables are asked
+// under varPrefix + name.
+const (
+	keyName        = "name"
+	keyModule      = "module"
+	keyTemplate    = "template"
+	keyFeatures    = "features"
+	keyDescription = "description"
+	keyAuthor      = "author"
+	keyRepository  = "repository"
+	keyLicense     = "license"
+	keyGit         = "git"
+	keyVerbose     = "verbose"
+	keyConfirm     = "confirm"
+	varPrefix      = "var."
+)
+
 // RunProjectCreationWizard runs the interactive project creation wizard
 func (is *InteractiveSession) RunProjectCreationWizard() (*ProjectOptions, error) {
-	options := &ProjectOptions{}
-	
-	// Welcome message
-	is.printWelcome()
-	
-	// Step 1: Project name
-	projectName, err := is.promptProjectName()
-	if err != nil {
-		return nil, err
-	}
-	options.ProjectName = projectName
-	
-	// Step 2: Module path
-	modulePath, err := is.promptModulePath(projectName)
-	if err != nil {
-		return nil, err
-	}
-	options.ModulePath = modulePath
-	
-	// Step 3: Template selection
-	template, err := is.promptTemplateSelection()
-	if err != nil {
-		return nil, err
-	}
-	options.Template = template
-	
-	// Step 4: Git initialization
-	skipGit, err := is.promptGitInit()
-	if err != nil {
-		return nil, err
-	}
-	options.SkipGit = skipGit
-	
-	// Step 5: Verbose output
-	verbose, err := is.promptVerboseOutput()
+	answers, err := Ask("🚀 Welcome to GoForge Project Creator!", is.projectQuestions)
 	if err != nil {
 		return nil, err
 	}
-	options.Verbose = verbose
-	
-	// Summary
-	is.showSummary(options)
-	
-	// Confirmation
-	confirmed, err := is.promptConfirmation()
-	if err != nil {
-		return nil, err
-	}
-	
-	if !confirmed {
+	if !answers.Bool(keyConfirm) {
+		color.New(color.FgYellow).Println("Project creation cancelled.")
 		return nil, fmt.Errorf("project creation cancelled by user")
 	}
-	
-	return options, nil
-}
 
-func (is *InteractiveSession) printWelcome() {
-	fmt.Println()
-	color.New(color.FgCyan, color.Bold).Println("🚀 Welcome to GoForge Project Creator!")
-	fmt.Println("Let's create your new Go project step by step.")
-	fmt.Println("(Press Ctrl+C anytime to cancel)")
-	fmt.Println()
-}
-
-func (is *InteractiveSession) promptProjectName() (string, error) {
-	for {
-		fmt.Print(color.New(color.FgYellow).Sprint("📝 Project name: "))
-		
-		if !is.scanner.Scan() {
-			return "", fmt.Errorf("failed to read input")
-		}
-		
-		name := strings.TrimSpace(is.scanner.Text())
-		if name == "" {
-			color.New(color.FgRed).Println("   ❌ Project name cannot be empty")
-			continue
-		}
-		
-		// Validate project name
-		if err := is.validator.ValidateProjectName(name); err != nil {
-			if validationErr, ok := err.(*validation.ValidationError); ok {
-				color.New(color.FgRed).Printf("   ❌ %s\n", validationErr.Message)
-				if len(validationErr.Suggestions) > 0 {
-					color.New(color.FgBlue).Println("   💡 Suggestions:")
-					for _, suggestion := range validationErr.Suggestions {
-						color.New(color.FgBlue).Printf("      • %s\n", suggestion)
-					}
-				}
-				continue
-			}
-			return "", err
-		}
-		
-		// Check if directory exists
-		if _, err := os.Stat(name); err == nil {
-			color.New(color.FgRed).Printf("   ❌ Directory '%s' already exists\n", name)
-			continue
+	options := &ProjectOptions{
+		ProjectName: answers[keyName],
+		ModulePath:  answers[keyModule],
+		Template:    answers[keyTemplate],
+		Features:    answers.List(keyFeatures),
+		Vars:        map[string]string{},
+		Description: answers[keyDescription],
+		Author:      answers[keyAuthor],
+		Repository:  answers[keyRepository],
+		License:     answers[keyLicense],
+		SkipGit:     !answers.Bool(keyGit),
+		Verbose:     answers.Bool(keyVerbose),
+	}
+	for key, value := range answers {
+		if name, ok := strings.CutPrefix(key, varPrefix); ok {
+			options.Vars[name] = value
 		}
-		
-		color.New(color.FgGreen).Printf("   ✅ Project name: %s\n", name)
-		return name, nil
 	}
+	return options, nil
 }
 
-func (is *InteractiveSession) promptModulePath(defaultPath string) (string, error) {
-	fmt.Printf("📦 Module path (press Enter for '%s'): ", defaultPath)
-	
-	if !is.scanner.Scan() {
-		return "", fmt.Errorf("failed to read input")
-	}
-	
-	modulePath := strings.TrimSpace(is.scanner.Text())
-	if modulePath == "" {
-		modulePath = defaultPath
-	}
-	
-	// Validate module path
-	if err := is.validator.ValidateModulePath(modulePath); err != nil {
-		if validationErr, ok := err.(*validation.ValidationError); ok {
-			color.New(color.FgRed).Printf("   ❌ %s\n", validationErr.Message)
-			// Ask again
-			return is.promptModulePath(defaultPath)
+// projectQuestions returns the questions of the project wizard given the
+// answers so far; features and variables follow the chosen template.
+func (is *InteractiveSession) projectQuestions(answers Answers) []Question {
+	templates := is.templates
+	if len(templates) == 0 {
+		templates = []Template{
+			{Name: "default", Description: "Full-featured web API with clean architecture"},
+			{Name: "graphql", Description: "GraphQL API with gqlgen, dataloaders and PostgreSQL"},
+			{Name: "grpc", Description: "gRPC microservice with buf, health checks and interceptors"},
+			{Name: "cli", Description: "Command-line tool with Cobra, config loading and versioned builds"},
+			{Name: "worker", Description: "Queue worker for Kafka, RabbitMQ or NATS with retries and a dead-letter queue"},
+			{Name: "lambda", Description: "Serverless function for AWS Lambda (SAM) or Google Cloud Functions"},
+			{Name: "library", Description: "Reusable library with a pkg/ layout, example tests and release checks"},
+			{Name: "workspace", Description: "go.work monorepo with services/ and shared pkg/ modules"},
+			{Name: "cqrs", Description: "Event-driven CQRS API with command/query buses, projections and an outbox"},
+			{Name: "web", Description: "Server-rendered web app with templ components, HTMX and embedded assets"},
 		}
-		return "", err
 	}
-	
-	color.New(color.FgGreen).Printf("   ✅ Module path: %s\n", modulePath)
-	return modulePath, nil
-}
 
-func (is *InteractiveSession) promptTemplateSelection() (string, error) {
-	templates := []Template{
-		{Name: "default", Description: "Full-featured web API with clean architecture"},
-		{Name: "minimal", Description: "Lightweight template with basic structure"},
-		{Name: "microservice", Description: "Microservice template with Docker and health checks"},
+	modulePath := answers[keyName]
+	if is.defaults.ModulePrefix != "" && modulePath != "" {
+		modulePath = path.Join(is.defaults.ModulePrefix, modulePath)
 	}
-	
-	fmt.Println("📋 Available templates:")
-	for i, template := range templates {
-		fmt.Printf("   %d. %s - %s\n", i+1, 
-			color.New(color.FgCyan).Sprint(template.Name), 
-			template.Description)
+	defaultTemplate, license := "default", "MIT"
+	if is.defaults.Template != "" {
+		defaultTemplate = is.defaults.Template
 	}
-	
-	for {
-		fmt.Print("Select template (1-3, or press Enter for default): ")
-		
-		if !is.scanner.Scan() {
-			return "", fmt.Errorf("failed to read input")
-		}
-		
-		input := strings.TrimSpace(is.scanner.Text())
-		if input == "" {
-			color.New(color.FgGreen).Println("   ✅ Template: default")
-			return "default", nil
-		}
-		
-		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > len(templates) {
-			color.New(color.FgRed).Println("   ❌ Invalid selection. Please choose 1-3.")
-			continue
-		}
-		
-		selected := templates[choice-1]
-		color.New(color.FgGreen).Printf("   ✅ Template: %s\n", selected.Name)
-		return selected.Name, nil
+	if is.defaults.License != "" {
+		license = is.defaults.License
 	}
-}
+	git := strconv.FormatBool(!is.defaults.SkipGit)
 
-func (is *InteractiveSession) promptGitInit() (bool, error) {
-	for {
-		fmt.Print("🔧 Initialize Git repository? (Y/n): ")
-		
-		if !is.scanner.Scan() {
-			return false, fmt.Errorf("failed to read input")
-		}
-		
-		input := strings.TrimSpace(strings.ToLower(is.scanner.Text()))
-		
-		switch input {
-		case "", "y", "yes":
-			color.New(color.FgGreen).Println("   ✅ Git repository will be initialized")
-			return false, nil // false means don't skip
-		case "n", "no":
-			color.New(color.FgYellow).Println("   ⚠️  Git repository will be skipped")
-			return true, nil // true means skip
-		default:
-			color.New(color.FgRed).Println("   ❌ Please answer 'y' (yes) or 'n' (no)")
-		}
+	questions := []Question{
+		{
+			Key:      keyName,
+			Prompt:   "📝 Project name:",
+			Validate: is.validateProjectName,
+		},
+		{
+			Key:     keyModule,
+			Prompt:  "📦 Module path:",
+			Default: modulePath,
+			Validate: func(modulePath string) error {
+				return problem(is.validator.ValidateModulePath(modulePath))
+			},
+		},
+		{
+			Key:     keyTemplate,
+			Prompt:  "📋 Template:",
+			Kind:    Select,
+			Options: templates,
+			Default: defaultTemplate,
+		},
 	}
-}
 
-func (is *InteractiveSession) promptVerboseOutput() (bool, error) {
-	for {
-		fmt.Print("🔍 Enable verbose output? (y/N): ")
-		
-		if !is.scanner.Scan() {
-			return false, fmt.Errorf("failed to read input")
+	template := answers[keyTemplate]
+	if is.features != nil {
+		if features := is.features(template); len(features) > 0 {
+			questions = append(questions, Question{
+				Key:     keyFeatures,
+				Prompt:  "🧩 Features:",
+				Kind:    MultiSelect,
+				Options: features,
+			})
 		}
-		
-		input := strings.TrimSpace(strings.ToLower(is.scanner.Text()))
-		
-		switch input {
-		case "", "n", "no":
-			color.New(color.FgGreen).Println("   ✅ Standard output mode")
-			return false, nil
-		case "y", "yes":
-			color.New(color.FgGreen).Println("   ✅ Verbose output enabled")
-			return true, nil
-		default:
-			color.New(color.FgRed).Println("   ❌ Please answer 'y' (yes) or 'n' (no)")
+	}
+	if is.variables != nil {
+		for _, question := range is.variables(template) {
+			question.Key = varPrefix + question.Key
+			questions = append(questions, question)
 		}
 	}
+
+	questions = append(questions,
+		Question{
+			Key:         keyDescription,
+			Prompt:      "💬 Description:",
+			Description: "One line for the README and goforge.yml; may be left empty",
+		},
+		Question{Key: keyAuthor, Prompt: "👤 Author:", Default: is.defaults.Author},
+		Question{
+			Key:     keyRepository,
+			Prompt:  "🔗 Repository URL:",
+			Default: project.RepositoryFor(answers[keyModule]),
+		},
+		Question{
+			Key:    keyLicense,
+			Prompt: "⚖️  License:",
+			Kind:   Select,
+			Options: []Template{
+				{Name: "MIT", Description: "Short and permissive"},
+				{Name: "Apache-2.0", Description: "Permissive, with an express patent grant"},
+				{Name: "GPL-3.0", Description: "Copyleft: derived works must stay open source"},
+				{Name: "none", Description: "No LICENSE file"},
+			},
+			Default: license,
+		},
+		Question{Key: keyGit, Prompt: "🔧 Initialize Git repository?", Kind: Confirm, Default: git},
+		Question{Key: keyVerbose, Prompt: "🔍 Enable verbose output?", Kind: Confirm, Default: "false"},
+		Question{
+			Key:         keyConfirm,
+			Prompt:      "✨ Create this project?",
+			Description: fmt.Sprintf("%s (%s) from the %s template", answers[keyName], answers[keyModule], template),
+			Kind:        Confirm,
+			Default:     "true",
+		},
+	)
+	return questions
 }
 
-func (is *InteractiveSession) showSummary(options *ProjectOptions) {
-	fmt.Println()
-	color.New(color.FgCyan, color.Bold).Println("📋 Project Summary:")
-	fmt.Printf("   Project Name: %s\n", color.New(color.FgGreen).Sprint(options.ProjectName))
-	fmt.Printf("   Module Path:  %s\n", color.New(color.FgGreen).Sprint(options.ModulePath))
-	fmt.Printf("   Template:     %s\n", color.New(color.FgGreen).Sprint(options.Template))
-	
-	gitStatus := "Yes"
-	if options.SkipGit {
-		gitStatus = "No"
+// validateProjectName rejects invalid names and existing directories.
+func (is *InteractiveSession) validateProjectName(name string) error {
+	if name == "" {
+		return fmt.Errorf("project name cannot be empty")
 	}
-	fmt.Printf("   Git Init:     %s\n", color.New(color.FgGreen).Sprint(gitStatus))
-	
-	verboseStatus := "No"
-	if options.Verbose {
-		verboseStatus = "Yes"
+	if err := problem(is.validator.ValidateProjectName(name)); err != nil {
+		return err
+	}
+	if _, err := os.Stat(name); err == nil {
+		return fmt.Errorf("directory '%s' already exists (use 'goforge new %s --force' to scaffold into it)", name, name)
 	}
-	fmt.Printf("   Verbose:      %s\n", color.New(color.FgGreen).Sprint(verboseStatus))
-	fmt.Println()
+	return nil
 }
 
-func (is *InteractiveSession) promptConfirmation() (bool, error) {
-	for {
-		fmt.Print("✨ Create this project? (Y/n): ")
-		
-		if !is.scanner.Scan() {
-			return false, fmt.Errorf("failed to read input")
-		}
-		
-		input := strings.TrimSpace(strings.ToLower(is.scanner.Text()))
-		
-		switch input {
-		case "", "y", "yes":
-			return true, nil
-		case "n", "no":
-			color.New(color.FgYellow).Println("Project creation cancelled.")
-			return false, nil
-		default:
-			color.New(color.FgRed).Println("Please answer 'y' (yes) or 'n' (no)")
-		}
+// problem shortens validation errors to their message and first
+// suggestion, to fit under a question.
+func problem(err error) error {
+	var validationErr *validation.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	if len(validationErr.Suggestions) > 0 {
+		return fmt.Errorf("%s (💡 %s)", validationErr.Message, validationErr.Suggestions[0])
 	}
+	return errors.New(validationErr.Message)
 }
 
 // Template represents a project template
@@ -304,14 +278,13 @@ type Template struct {
 
 // ComponentWizard handles interactive component generation
 type ComponentWizard struct {
-	scanner   *bufio.Scanner
-	validator *validation.ProjectValidator
+	validator  *validation.ProjectValidator
+	components []Template
 }
 
 // NewComponentWizard creates a new component wizard
 func NewComponentWizard() *ComponentWizard {
 	return &ComponentWizard{
-		scanner:   bufio.NewScanner(os.Stdin),
 		validator: validation.NewProjectValidator(),
 	}
 }
@@ -324,104 +297,46 @@ type ComponentOptions struct {
 
 // RunComponentCreationWizard runs the interactive component creation wizard
 func (cw *ComponentWizard) RunComponentCreationWizard() (*ComponentOptions, error) {
-	options := &ComponentOptions{}
-	
-	// Welcome
-	fmt.Println()
-	color.New(color.FgCyan, color.Bold).Println("🔧 Component Generator")
-	fmt.Println()
-	
-	// Select component type
-	componentType, err := cw.promptComponentType()
-	if err != nil {
-		return nil, err
-	}
-	options.Type = componentType
-	
-	// Get component name
-	componentName, err := cw.promptComponentName(componentType)
-	if err != nil {
-		return nil, err
+	components := cw.components
+	if len(components) == 0 {
+		components = []Template{
+			{"handler", "HTTP request handlers for API endpoints"},
+			{"service", "Business logic services"},
+			{"repository", "Data access layer implementations"},
+			{"model", "Domain models and entities"},
+			{"middleware", "HTTP middleware components"},
+			{"port", "Interface definitions for clean architecture"},
+		}
 	}
-	options.Name = componentName
-	
-	return options, nil
-}
+	titleCaser := cases.Title(language.English)
 
-func (cw *ComponentWizard) promptComponentType() (string, error) {
-	components := []struct {
-		Name        string
-		Description string
-	}{
-		{"handler", "HTTP request handlers for API endpoints"},
-		{"service", "Business logic services"},
-		{"repository", "Data access layer implementations"},
-		{"model", "Domain models and entities"},
-		{"middleware", "HTTP middleware components"},
-		{"port", "Interface definitions for clean architecture"},
-	}
-	
-	fmt.Println("Available components:")
-	for i, comp := range components {
-		fmt.Printf("   %d. %s - %s\n", i+1, 
-			color.New(color.FgCyan).Sprint(comp.Name), 
-			comp.Description)
-	}
-	
-	for {
-		fmt.Print("Select component type (1-6): ")
-		
-		if !cw.scanner.Scan() {
-			return "", fmt.Errorf("failed to read input")
-		}
-		
-		input := strings.TrimSpace(cw.scanner.Text())
-		choice, err := strconv.Atoi(input)
-		if err != nil || choice < 1 || choice > len(components) {
-			color.New(color.FgRed).Println("   ❌ Invalid selection. Please choose 1-6.")
-			continue
+	answers, err := Ask("🔧 Component Generator", func(answers Answers) []Question {
+		componentType := answers["type"]
+		return []Question{
+			{Key: "type", Prompt: "Component type:", Kind: Select, Options: components},
+			{
+				Key:    "name",
+				Prompt: fmt.Sprintf("📝 %s name:", titleCaser.String(componentType)),
+				Validate: func(name string) error {
+					if name == "" {
+						return fmt.Errorf("%s name cannot be empty", componentType)
+					}
+					return problem(cw.validator.ValidateComponentName(componentType, name))
+				},
+			},
 		}
-		
-		selected := components[choice-1]
-		color.New(color.FgGreen).Printf("   ✅ Component type: %s\n", selected.Name)
-		return selected.Name, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+	return &ComponentOptions{Type: answers["type"], Name: answers["name"]}, nil
 }
 
-func (cw *ComponentWizard) promptComponentName(componentType string) (string, error) {
-    titleCaser := cases.Title(language.English) // added
-    for {
-        // replaced strings.Title with titleCaser.String(componentType)
-        fmt.Printf("📝 %s name: ", titleCaser.String(componentType))
-        
-        if !cw.scanner.Scan() {
-            return "", fmt.Errorf("failed to read input")
-        }
-        
-        name := strings.TrimSpace(cw.scanner.Text())
-        if name == "" {
-            color.New(color.FgRed).Printf("   ❌ %s name cannot be empty\n", componentType)
-            continue
-        }
-        
-        // Validate component name
-        if err := cw.validator.ValidateComponentName(componentType, name); err != nil {
-            if validationErr, ok := err.(*validation.ValidationError); ok {
-                color.New(color.FgRed).Printf("   ❌ %s\n", validationErr.Message)
-                if len(validationErr.Suggestions) > 0 {
-                    color.New(color.FgBlue).Println("   💡 Suggestions:")
-                    for _, suggestion := range validationErr.Suggestions {
-                        color.New(color.FgBlue).Printf("      • %s\n", suggestion)
-                    }
-                }
-                continue
-            }
-            return "", err
-        }
-        
-        color.New(color.FgGreen).Printf("   ✅ %s name: %s\n", titleCaser.String(componentType), name)
-        return name, nil
-    }
+// WithComponents replaces the offered component types, e.g. to include
+// a project's custom generators.
+func (cw *ComponentWizard) WithComponents(components []Template) *ComponentWizard {
+	cw.components = components
+	return cw
 }
 
 // IsInteractiveTerminal checks if we're running in an interactive terminal
@@ -467,4 +382,48 @@ func PromptForInteractiveMode() bool {
 	
 	input := strings.TrimSpace(strings.ToLower(scanner.Text()))
 	return input == "" || input == "y" || input == "yes"
-}
\ No newline at end of file
+}
+// PromptChoice asks the user to pick one of the given single-word choices.
+// Each choice can be answered with its full name or its first letter. An
+// empty answer selects defaultChoice.
+func PromptChoice(question string, choices []string, defaultChoice string) (string, error) {
+	scanner := bufio.NewScanner(os.Stdin)
+
+	var hints []string
+	for _, choice := range choices {
+		hints = append(hints, fmt.Sprintf("[%s]%s", choice[:1], choice[1:]))
+	}
+
+	for {
+		fmt.Printf("%s %s: ", question, strings.Join(hints, "/"))
+
+		if !scanner.Scan() {
+			return "", fmt.Errorf("failed to read input")
+		}
+
+		input := strings.TrimSpace(strings.ToLower(scanner.Text()))
+		if input == "" && defaultChoice != "" {
+			return defaultChoice, nil
+		}
+
+		for _, choice := range choices {
+			if input == choice || input == choice[:1] {
+				return choice, nil
+			}
+		}
+
+		color.New(color.FgRed).Print(logger.Plain(fmt.Sprintf("   ❌ Please answer one of: %s\n", strings.Join(choices, ", "))))
+	}
+}
+
+// PromptValue asks for a free-form value. An empty answer selects
+// defaultValue; validate, if set, rejects answers until one passes.
+func PromptValue(question, defaultValue string, validate func(string) error) (string, error) {
+	answers, err := Ask("", func(Answers) []Question {
+		return []Question{{Key: "value", Prompt: question, Default: defaultValue, Validate: validate}}
+	})
+	if err != nil {
+		return "", err
+	}
+	return answers["value"], nil
+}
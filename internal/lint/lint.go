@@ -0,0 +1,121 @@
+// Package lint runs golangci-lint at the version pinned by goforge and
+// reads its issues from the JSON report.
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// DefaultVersion is the golangci-lint release 'goforge lint' installs
+// unless lint.version in goforge.yml pins another.
+const DefaultVersion = "v2.5.0"
+
+// Tool returns golangci-lint at version, DefaultVersion when empty.
+func Tool(version string) runner.Tool {
+	if version == "" {
+		version = DefaultVersion
+	}
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	return runner.Tool{
+		Name:    "golangci-lint",
+		Package: "github.com/golangci/golangci-lint/v2/cmd/golangci-lint",
+		Version: version,
+	}
+}
+
+// Options selects what to lint and how.
+type Options struct {
+	Paths []string // package patterns, ./... when empty
+	Fix   bool     // apply the fixes the linters suggest
+}
+
+// Issue is a problem reported by a linter.
+type Issue struct {
+	Linter   string
+	Text     string
+	Severity string
+	File     string // relative to the project root
+	Line     int
+	Column   int
+}
+
+// Report is the JSON report of golangci-lint, as far as goforge reads it.
+type Report struct {
+	Issues []struct {
+		FromLinter string
+		Text       string
+		Severity   string
+		Pos        struct {
+			Filename string
+			Line     int
+			Column   int
+		}
+	}
+}
+
+// Run lints the project in dir with the golangci-lint binary at toolPath.
+// It returns the issues and the JSON report they were read from; issues
+// are not an error, but a failure to lint is.
+func Run(dir, toolPath string, options Options) ([]Issue, []byte, error) {
+	args := []string{"run", "--output.json.path=stdout", "--show-stats=false"}
+	if options.Fix {
+		args = append(args, "--fix")
+	}
+	if len(options.Paths) == 0 {
+		args = append(args, "./...")
+	} else {
+		args = append(args, options.Paths...)
+	}
+
+	cmd := exec.Command(toolPath, args...)
+	cmd.Dir = dir
+	cmd.Env = runner.Environ()
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	runErr := cmd.Run()
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, nil, fmt.Errorf("failed to run golangci-lint: %w", runErr)
+	}
+
+	// The report is the first JSON value on stdout
+	var report Report
+	decoder := json.NewDecoder(bytes.NewReader(stdout.Bytes()))
+	var raw json.RawMessage
+	if err := decoder.Decode(&raw); err != nil || json.Unmarshal(raw, &report) != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, nil, fmt.Errorf("golangci-lint failed:\n%s", msg)
+		}
+		if runErr != nil {
+			return nil, nil, fmt.Errorf("golangci-lint failed: %w", runErr)
+		}
+		return nil, nil, fmt.Errorf("golangci-lint printed no report")
+	}
+	// Warnings, e.g. about the configuration
+	os.Stderr.Write(stderr.Bytes())
+
+	issues := make([]Issue, 0, len(report.Issues))
+	for _, issue := range report.Issues {
+		issues = append(issues, Issue{
+			Linter:   issue.FromLinter,
+			Text:     issue.Text,
+			Severity: issue.Severity,
+			File:     issue.Pos.Filename,
+			Line:     issue.Pos.Line,
+			Column:   issue.Pos.Column,
+		})
+	}
+	return issues, raw, nil
+}
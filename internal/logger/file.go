@@ -0,0 +1,203 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Defaults of OpenLogFile: the size a log file may reach before it is
+// rotated, and how many rotated files are kept
+const (
+	DefaultMaxLogSize   = 10 << 20
+	DefaultLogBackups   = 3
+	fileTimestampFormat = "2006-01-02 15:04:05.000"
+)
+
+// RotatingFile is a log file that is rotated once it would grow past
+// maxSize: path becomes path.1, path.1 becomes path.2, and so on, and the
+// oldest beyond backups is removed
+type RotatingFile struct {
+	mu      sync.Mutex
+	path    string
+	maxSize int64
+	backups int
+	file    *os.File
+	size    int64
+}
+
+// OpenLogFile opens the log file at path for appending, creating it and
+// its directory if needed, rotated at DefaultMaxLogSize
+func OpenLogFile(path string) (*RotatingFile, error) {
+	return OpenRotatingFile(path, DefaultMaxLogSize, DefaultLogBackups)
+}
+
+// OpenRotatingFile opens the log file at path for appending, rotated once
+// it would grow past maxSize bytes, keeping backups rotated files
+func OpenRotatingFile(path string, maxSize int64, backups int) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create the directory of %s: %w", path, err)
+	}
+	r := &RotatingFile{path: path, maxSize: maxSize, backups: backups}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Path returns the path of the log file
+func (r *RotatingFile) Path() string {
+	return r.path
+}
+
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file %s: %w", r.path, err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to open log file %s: %w", r.path, err)
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+// Write appends p to the log file, rotating it first if p would take it
+// past its maximum size
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	var rotateErr error
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if rotateErr = r.rotate(); r.file == nil {
+			return 0, rotateErr
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	if err == nil {
+		err = rotateErr
+	}
+	return n, err
+}
+
+// rotate shifts the rotated files up by one, drops the oldest, and starts
+// a new log file. When the log file cannot be renamed it is reopened, so
+// writing goes on past its maximum size
+func (r *RotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	if r.backups < 1 {
+		os.Remove(r.path)
+	} else {
+		os.Remove(fmt.Sprintf("%s.%d", r.path, r.backups))
+		for i := r.backups - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", r.path, i), fmt.Sprintf("%s.%d", r.path, i+1))
+		}
+		if err := os.Rename(r.path, r.path+".1"); err != nil {
+			if openErr := r.open(); openErr != nil {
+				return openErr
+			}
+			return fmt.Errorf("failed to rotate log file %s: %w", r.path, err)
+		}
+	}
+	return r.open()
+}
+
+// Close closes the log file
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// SetTee also writes every message to w, or stops when w is nil. The
+// messages are plain text with the date and time, without colors, so w
+// is usually a log file
+func SetTee(w io.Writer) {
+	globalLogger.tee = w
+}
+
+// writeTee writes a message to the tee of the logger, if any
+func (l *Logger) writeTee(now time.Time, level, message string) {
+	if l.tee == nil {
+		return
+	}
+	fmt.Fprintf(l.tee, "%s %-7s %s\n", now.Format(fileTimestampFormat), level, message)
+}
+
+// lineWriter writes each line written to it to a log file, prefixed with
+// the date and time and a label
+type lineWriter struct {
+	mu      sync.Mutex
+	w       io.Writer
+	label   string
+	partial []byte
+	failed  bool
+}
+
+// TimestampLines returns a writer passing the lines written to it on to
+// w, each prefixed with the date and time and label, e.g. the output of
+// a process copied to a log file. Writing never fails: an error of w is
+// reported once and the lines are dropped, so a full disk cannot break
+// the output of the process. Close writes the last line when it has no
+// newline; it does not close w
+func TimestampLines(w io.Writer, label string) io.WriteCloser {
+	return &lineWriter{w: w, label: label}
+}
+
+func (lw *lineWriter) Write(p []byte) (int, error) {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	lw.partial = append(lw.partial, p...)
+	for {
+		i := bytes.IndexByte(lw.partial, '\n')
+		if i < 0 {
+			break
+		}
+		lw.writeLine(lw.partial[:i])
+		lw.partial = lw.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Close writes the pending partial line, if any
+func (lw *lineWriter) Close() error {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	if len(lw.partial) > 0 {
+		lw.writeLine(lw.partial)
+		lw.partial = nil
+	}
+	return nil
+}
+
+// writeLine writes line to w, warning about the first error only
+func (lw *lineWriter) writeLine(line []byte) {
+	_, err := fmt.Fprintf(lw.w, "%s %-7s %s\n", time.Now().Format(fileTimestampFormat), lw.label, line)
+	if err != nil && !lw.failed {
+		lw.failed = true
+		Warn("⚠️  Could not write process output (%s) to the log file: %v", lw.label, err)
+	}
+}
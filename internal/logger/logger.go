@@ -2,8 +2,10 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"strings"
 	"time"
@@ -21,10 +23,30 @@ const (
 	ERROR
 )
 
+// Format is how log messages are written
+type Format int
+
+const (
+	// TextFormat writes a line of text per message, colored on terminals
+	TextFormat Format = iota
+	// JSONFormat writes a JSON object per message, with its time, level,
+	// message, and fields, for log collectors
+	JSONFormat
+)
+
+// Fields are structured data attached to log messages, such as the file
+// that changed or the PID of a process. Only JSONFormat writes them; the
+// text format keeps to the message
+type Fields map[string]any
+
 // Logger provides structured logging with colors and levels
 type Logger struct {
 	level  LogLevel
 	writer io.Writer
+	plain  bool // Strip emoji from messages and keep spinners still
+	output Format
+	fields Fields
+	tee    io.Writer // Also gets every message, see SetTee
 	
 	// Color functions
 	debugColor *color.Color
@@ -60,6 +82,63 @@ func SetLevel(level LogLevel) {
 	globalLogger.level = level
 }
 
+// SetOutput sets where log messages are written
+func SetOutput(writer io.Writer) {
+	globalLogger.writer = writer
+}
+
+// SetFormat sets how log messages are written
+func SetFormat(format Format) {
+	globalLogger.output = format
+}
+
+// ParseFormat returns the Format named text or json
+func ParseFormat(name string) (Format, error) {
+	switch name {
+	case "text":
+		return TextFormat, nil
+	case "json":
+		return JSONFormat, nil
+	}
+	return TextFormat, fmt.Errorf("unknown log format '%s' (use text or json)", name)
+}
+
+// WithFields returns a logger adding fields to the messages of the
+// global logger
+func WithFields(fields Fields) *Logger {
+	return globalLogger.WithFields(fields)
+}
+
+// WithFields returns a copy of the logger adding fields to its messages
+func (l *Logger) WithFields(fields Fields) *Logger {
+	child := *l
+	child.fields = make(Fields, len(l.fields)+len(fields))
+	maps.Copy(child.fields, l.fields)
+	maps.Copy(child.fields, fields)
+	return &child
+}
+
+// ParseLevel returns the LogLevel named debug, info, warn, or error
+func ParseLevel(name string) (LogLevel, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return DEBUG, nil
+	case "info":
+		return INFO, nil
+	case "warn", "warning":
+		return WARN, nil
+	case "error":
+		return ERROR, nil
+	}
+	return INFO, fmt.Errorf("unknown log level '%s' (use debug, info, warn, or error)", name)
+}
+
+// Verbose reports whether debug messages are logged. Commands check it
+// to show the full output of the tools they run
+func Verbose() bool {
+	return globalLogger.level <= DEBUG
+}
+
 // SetVerbose enables debug logging
 func SetVerbose(verbose bool) {
 	if verbose {
@@ -69,6 +148,54 @@ func SetVerbose(verbose bool) {
 	}
 }
 
+// SetEmoji turns the emoji of log messages on or off. Without emoji,
+// progress is logged as plain lines instead of spinners, which CI logs
+// and screen readers cannot follow
+func SetEmoji(enabled bool) {
+	globalLogger.plain = !enabled
+}
+
+// EmojiEnabled reports whether messages keep their emoji
+func EmojiEnabled() bool {
+	return !globalLogger.plain
+}
+
+// Plain returns message without its emoji when they are turned off, for
+// output written without the logger
+func Plain(message string) string {
+	return globalLogger.format("%s", message)
+}
+
+// Printf prints to stdout like fmt.Printf, without emoji when they are
+// turned off
+func Printf(format string, args ...interface{}) {
+	fmt.Fprint(os.Stdout, globalLogger.format(format, args...))
+}
+
+// Println prints to stdout like fmt.Println, without emoji when they are
+// turned off
+func Println(args ...interface{}) {
+	fmt.Fprintln(os.Stdout, Plain(fmt.Sprint(args...)))
+}
+
+// animated reports whether progress may be drawn in place, which only
+// the text format with emoji does, and only on a terminal: redrawn lines
+// garble CI logs and files, so they get a line when the progress starts
+// and one when it finishes instead
+func (l *Logger) animated() bool {
+	return l.output == TextFormat && !l.plain && isTerminal(l.writer)
+}
+
+// isTerminal reports whether w is a terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
 // Debug logs debug messages (only shown in verbose mode)
 func Debug(format string, args ...interface{}) {
 	globalLogger.Debug(format, args...)
@@ -132,17 +259,56 @@ func Step(step int, total int, message string, args ...interface{}) {
 
 // Progress shows progress without newline
 func Progress(message string, args ...interface{}) {
-	fmt.Fprintf(globalLogger.writer, "\r⏳ %s", fmt.Sprintf(message, args...))
+	if !globalLogger.animated() {
+		Info(message, args...)
+		return
+	}
+	fmt.Fprintf(globalLogger.writer, "\r%s", globalLogger.format("⏳ "+message, args...))
 }
 
 // Complete completes a progress line
 func Complete(message string, args ...interface{}) {
-	fmt.Fprintf(globalLogger.writer, "\r✅ %s\n", fmt.Sprintf(message, args...))
+	if !globalLogger.animated() {
+		Success(message, args...)
+		return
+	}
+	fmt.Fprintf(globalLogger.writer, "\r%s\n", globalLogger.format("✅ "+message, args...))
+}
+
+// jsonEntry is a message in JSONFormat
+type jsonEntry struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	Fields  Fields `json:"fields,omitempty"`
 }
 
 func (l *Logger) log(level string, colorFunc *color.Color, format string, args ...interface{}) {
-	timestamp := time.Now().Format("15:04:05")
-	message := fmt.Sprintf(format, args...)
+	now := time.Now()
+	if message := strings.TrimSpace(l.format(format, args...)); message != "" {
+		l.writeTee(now, level, message)
+	}
+	if l.output == JSONFormat {
+		// Blank lines only space out the text format
+		message := strings.TrimSpace(stripEmoji(fmt.Sprintf(format, args...)))
+		if message == "" {
+			return
+		}
+		data, err := json.Marshal(jsonEntry{
+			Time:    now.Format(time.RFC3339Nano),
+			Level:   strings.ToLower(level),
+			Message: message,
+			Fields:  l.fields,
+		})
+		if err != nil {
+			data, _ = json.Marshal(jsonEntry{Time: now.Format(time.RFC3339Nano), Level: strings.ToLower(level), Message: message})
+		}
+		fmt.Fprintf(l.writer, "%s\n", data)
+		return
+	}
+
+	timestamp := now.Format("15:04:05")
+	message := l.format(format, args...)
 	
 	if colorFunc != nil {
 		levelStr := colorFunc.Sprintf("%-7s", level)
@@ -156,17 +322,58 @@ func (l *Logger) log(level string, colorFunc *color.Color, format string, args .
 	}
 }
 
+// format formats a message, without its emoji when they are turned off
+func (l *Logger) format(format string, args ...interface{}) string {
+	message := fmt.Sprintf(format, args...)
+	if l.plain {
+		message = stripEmoji(message)
+	}
+	return message
+}
+
+// stripEmoji removes emoji and the spaces after them, keeping the
+// indentation of the message
+func stripEmoji(message string) string {
+	var b strings.Builder
+	runes := []rune(message)
+	for i := 0; i < len(runes); i++ {
+		if !isEmoji(runes[i]) {
+			b.WriteRune(runes[i])
+			continue
+		}
+		for i+1 < len(runes) && (runes[i+1] == ' ' || runes[i+1] == '\uFE0F' || runes[i+1] == '\u200D') {
+			i++
+		}
+	}
+	return b.String()
+}
+
+// isEmoji reports whether r is a pictograph or a symbol usually drawn as one
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF: // Pictographs, emoticons, transport
+		return true
+	case r >= 0x2600 && r <= 0x27BF: // Miscellaneous symbols, dingbats
+		return true
+	case r >= 0x2300 && r <= 0x23FF: // Hourglasses, clocks, media controls
+		return true
+	case r == 0x2B50 || r == 0x2B06 || r == 0x2B07 || r == 0x2139 || r == 0x203C:
+		return true
+	}
+	return false
+}
+
 // Command execution logging helpers
 func CommandStart(cmd string, args ...string) {
-	Debug("Executing command: %s %s", cmd, strings.Join(args, " "))
+	WithFields(Fields{"command": cmd, "args": args}).Debug("Executing command: %s %s", cmd, strings.Join(args, " "))
 }
 
 func CommandSuccess(cmd string, duration time.Duration) {
-	Debug("Command '%s' completed successfully in %v", cmd, duration)
+	WithFields(Fields{"command": cmd, "duration_ms": duration.Milliseconds()}).Debug("Command '%s' completed successfully in %v", cmd, duration)
 }
 
 func CommandError(cmd string, err error, duration time.Duration) {
-	Error("Command '%s' failed after %v: %v", cmd, duration, err)
+	WithFields(Fields{"command": cmd, "duration_ms": duration.Milliseconds(), "error": err.Error()}).Error("Command '%s' failed after %v: %v", cmd, duration, err)
 }
 
 // Project lifecycle logging
@@ -264,6 +471,12 @@ func NewProgress(message string) *ProgressIndicator {
 }
 
 func (p *ProgressIndicator) start() {
+	if !globalLogger.animated() {
+		// A spinner would break the lines up
+		Info("%s", p.message)
+		go func() { <-p.done }()
+		return
+	}
 	go func() {
 		chars := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
 		i := 0
@@ -273,7 +486,7 @@ func (p *ProgressIndicator) start() {
 			case <-p.done:
 				return
 			default:
-				fmt.Fprintf(globalLogger.writer, "\r%s %s", chars[i%len(chars)], p.message)
+				fmt.Fprintf(globalLogger.writer, "\r%s %s", chars[i%len(chars)], globalLogger.format("%s", p.message))
 				time.Sleep(100 * time.Millisecond)
 				i++
 			}
@@ -283,10 +496,17 @@ func (p *ProgressIndicator) start() {
 
 func (p *ProgressIndicator) Complete(message string) {
 	p.done <- true
-	fmt.Fprintf(globalLogger.writer, "\r✅ %s\n", message)
+	if !globalLogger.animated() {
+		Success("%s", message)
+		return
+	}
+	fmt.Fprintf(globalLogger.writer, "\r%s\n", globalLogger.format("✅ %s", message))
 }
 
 func (p *ProgressIndicator) Stop() {
 	p.done <- true
+	if !globalLogger.animated() {
+		return
+	}
 	fmt.Fprintf(globalLogger.writer, "\r")
 }
\ No newline at end of file
@@ -0,0 +1,307 @@
+// Package packs manages remote template and generator packs: git
+// repositories that are cloned into a local cache and referenced as
+// "<pack>/<name>" by 'goforge new -t' and 'goforge generate'.
+//
+// A pack repository is laid out as:
+//
+//	templates/<name>/...         project templates (like the built-in "default")
+//	generators/<type>.go.tpl     component generators, with optional <type>.yml
+package packs
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"gopkg.in/yaml.v3"
+)
+
+// Pack is an installed template pack.
+type Pack struct {
+	Name    string    `yaml:"name"`
+	Source  string    `yaml:"source"`
+	Ref     string    `yaml:"ref,omitempty"` // Requested tag or branch; empty tracks the default branch
+	Version string    `yaml:"version"`       // Resolved tag or commit
+	Path    string    `yaml:"path"`
+	Added   time.Time `yaml:"added"`
+	Updated time.Time `yaml:"updated,omitempty"`
+}
+
+// Registry is the list of installed packs, stored in the user's config dir.
+type Registry struct {
+	Packs []*Pack `yaml:"packs"`
+}
+
+// TemplatesDir returns the directory holding the pack's project templates.
+func (p *Pack) TemplatesDir() string {
+	return filepath.Join(p.Path, "templates")
+}
+
+// GeneratorsDir returns the directory holding the pack's component generators.
+func (p *Pack) GeneratorsDir() string {
+	return filepath.Join(p.Path, "generators")
+}
+
+// registryPath returns the location of the packs registry file.
+func registryPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine user config directory: %w", err)
+	}
+	return filepath.Join(dir, "goforge", "packs.yml"), nil
+}
+
+// cacheDir returns the root directory packs are cloned into.
+func cacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine user cache directory: %w", err)
+	}
+	return filepath.Join(dir, "goforge", "packs"), nil
+}
+
+// LoadRegistry reads the installed packs. A missing registry is empty.
+func LoadRegistry() (*Registry, error) {
+	path, err := registryPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Registry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var reg Registry
+	if err := yaml.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return &reg, nil
+}
+
+// Save writes the registry back to disk.
+func (r *Registry) Save() error {
+	path, err := registryPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return err
+	}
+
+	sort.Slice(r.Packs, func(i, j int) bool { return r.Packs[i].Name < r.Packs[j].Name })
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to marshal pack registry: %w", err)
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Find returns the installed pack with the given name.
+func (r *Registry) Find(name string) (*Pack, bool) {
+	for _, p := range r.Packs {
+		if p.Name == name {
+			return p, true
+		}
+	}
+	return nil, false
+}
+
+// ParseSource splits "github.com/org/repo@v1.2.0" into source and version.
+func ParseSource(arg string) (string, string) {
+	i := strings.LastIndex(arg, "@")
+	if i > 0 && i > strings.LastIndex(arg, "/") {
+		return arg[:i], arg[i+1:]
+	}
+	return arg, ""
+}
+
+// DefaultName derives a pack name from its source: the owner segment for
+// hosted repositories (github.com/org/repo -> "org"), else the last segment.
+func DefaultName(source string) string {
+	trimmed := strings.TrimSuffix(strings.TrimSuffix(source, "/"), ".git")
+	trimmed = strings.TrimPrefix(trimmed, "https://")
+	trimmed = strings.TrimPrefix(trimmed, "git@")
+	trimmed = strings.ReplaceAll(trimmed, ":", "/")
+
+	parts := strings.Split(trimmed, "/")
+	if len(parts) >= 3 && strings.Contains(parts[0], ".") {
+		return parts[1]
+	}
+	return parts[len(parts)-1]
+}
+
+// cloneURL turns a source into something git can clone.
+func cloneURL(source string) string {
+	switch {
+	case strings.Contains(source, "://"), strings.HasPrefix(source, "git@"):
+		return source
+	case filepath.IsAbs(source), strings.HasPrefix(source, "."):
+		return source
+	default:
+		return "https://" + source
+	}
+}
+
+// Add clones source at version (a tag or branch; empty means the default
+// branch) into the cache and registers it under name.
+func (r *Registry) Add(source, version, name string) (*Pack, error) {
+	if name == "" {
+		name = DefaultName(source)
+	}
+	if strings.ContainsAny(name, `/\ `) {
+		return nil, fmt.Errorf("invalid pack name '%s'", name)
+	}
+	if _, exists := r.Find(name); exists {
+		return nil, fmt.Errorf("a pack named '%s' is already installed\n\nUse --name to install it under a different name, or 'goforge templates update %s'", name, name)
+	}
+
+	pack := &Pack{Name: name, Source: source, Ref: version}
+	if err := fetch(pack, version); err != nil {
+		return nil, err
+	}
+
+	pack.Added = time.Now()
+	r.Packs = append(r.Packs, pack)
+	return pack, nil
+}
+
+// Update re-fetches an installed pack. An empty ref keeps the pack's
+// current ref, so branches move forward and tags are re-downloaded.
+func (r *Registry) Update(name, ref string) (*Pack, error) {
+	pack, found := r.Find(name)
+	if !found {
+		return nil, fmt.Errorf("template pack '%s' is not installed", name)
+	}
+	if ref == "" {
+		ref = pack.Ref
+	}
+
+	oldPath := pack.Path
+	updated := *pack
+	updated.Ref = ref
+	if err := fetch(&updated, ref); err != nil {
+		return nil, err
+	}
+	updated.Updated = time.Now()
+
+	if oldPath != updated.Path {
+		if err := os.RemoveAll(oldPath); err != nil {
+			logger.Warn("Failed to remove old pack cache %s: %v", oldPath, err)
+		}
+	}
+
+	*pack = updated
+	return pack, nil
+}
+
+// Remove unregisters a pack and deletes its cached clone.
+func (r *Registry) Remove(name string) (*Pack, error) {
+	for i, pack := range r.Packs {
+		if pack.Name != name {
+			continue
+		}
+		if err := os.RemoveAll(pack.Path); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", pack.Path, err)
+		}
+		r.Packs = append(r.Packs[:i], r.Packs[i+1:]...)
+		return pack, nil
+	}
+	return nil, fmt.Errorf("template pack '%s' is not installed", name)
+}
+
+// fetch clones the pack into a fresh versioned cache directory and records
+// the resolved version. The previous clone is only replaced once the new
+// one succeeds.
+func fetch(pack *Pack, version string) error {
+	if _, err := exec.LookPath("git"); err != nil {
+		return fmt.Errorf("git is required to fetch template packs")
+	}
+
+	root, err := cacheDir()
+	if err != nil {
+		return err
+	}
+
+	label := version
+	if label == "" {
+		label = "default"
+	}
+	dest := filepath.Join(root, pack.Name+"@"+label)
+	staging := dest + ".tmp"
+	if err := os.RemoveAll(staging); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(root, os.ModePerm); err != nil {
+		return err
+	}
+
+	args := []string{"clone", "--depth", "1", "--quiet"}
+	if version != "" {
+		args = append(args, "--branch", version)
+	}
+	args = append(args, cloneURL(pack.Source), staging)
+
+	logger.Debug("Cloning %s", cloneURL(pack.Source))
+	if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+		os.RemoveAll(staging)
+		return fmt.Errorf("failed to clone %s: %s", pack.Source, strings.TrimSpace(string(output)))
+	}
+
+	if err := os.RemoveAll(dest); err != nil {
+		return err
+	}
+	if err := os.Rename(staging, dest); err != nil {
+		return err
+	}
+
+	// Record what was actually fetched: the tag, or the commit a branch
+	// pointed at, e.g. "main (3f2a1c9)".
+	resolved := describe(dest)
+	if version != "" && resolved != version {
+		resolved = fmt.Sprintf("%s (%s)", version, resolved)
+	}
+
+	pack.Version = resolved
+	pack.Path = dest
+	return nil
+}
+
+// describe returns the tag at HEAD, or the short commit hash.
+func describe(dir string) string {
+	if out, err := exec.Command("git", "-C", dir, "describe", "--tags", "--exact-match").Output(); err == nil {
+		return strings.TrimSpace(string(out))
+	}
+	if out, err := exec.Command("git", "-C", dir, "rev-parse", "--short", "HEAD").Output(); err == nil {
+		return strings.TrimSpace(string(out))
+	}
+	return "unknown"
+}
+
+// Resolve splits a "<pack>/<item>" reference and returns the installed
+// pack and item name.
+func Resolve(ref string) (*Pack, string, error) {
+	name, item, ok := strings.Cut(ref, "/")
+	if !ok || name == "" || item == "" {
+		return nil, "", fmt.Errorf("invalid pack reference '%s' (expected <pack>/<name>)", ref)
+	}
+
+	reg, err := LoadRegistry()
+	if err != nil {
+		return nil, "", err
+	}
+
+	pack, found := reg.Find(name)
+	if !found {
+		return nil, "", fmt.Errorf("template pack '%s' is not installed\n\nInstall it with: goforge templates add <git-url>", name)
+	}
+	return pack, item, nil
+}
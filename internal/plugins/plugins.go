@@ -0,0 +1,163 @@
+// Package plugins discovers and runs external goforge plugins: executables
+// named goforge-<name> on PATH, exposed as 'goforge <name>' in the same way
+// kubectl and cargo plugins work.
+//
+// A plugin receives its context as JSON on stdin:
+//
+//	{
+//	  "goforge_version": "1.4.0",
+//	  "project_root": "/path/to/project",   // empty outside a project
+//	  "config": { ...goforge.yml... }       // null outside a project
+//	}
+//
+// and the same values in the GOFORGE_* environment variables.
+package plugins
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Prefix is the executable name prefix that marks a goforge plugin.
+const Prefix = "goforge-"
+
+// Plugin is an executable discovered on PATH.
+type Plugin struct {
+	Name string `json:"name"` // Subcommand name, e.g. "preview" for goforge-preview
+	Path string `json:"path"`
+
+	// Shadowed lists executables with the same name later on PATH,
+	// which are ignored.
+	Shadowed []string `json:"shadowed,omitempty"`
+}
+
+// Context is the project context passed to a plugin on stdin.
+type Context struct {
+	Version     string         `json:"goforge_version"`
+	ProjectRoot string         `json:"project_root"`
+	Config      map[string]any `json:"config"`
+}
+
+// Discover returns the plugins on PATH, sorted by name. When two
+// directories contain the same plugin, the first one on PATH wins.
+func Discover() []*Plugin {
+	found := make(map[string]*Plugin)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || entry.IsDir() {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+
+			if existing, ok := found[name]; ok {
+				if existing.Path != path {
+					existing.Shadowed = append(existing.Shadowed, path)
+				}
+				continue
+			}
+			found[name] = &Plugin{Name: name, Path: path}
+		}
+	}
+
+	plugins := make([]*Plugin, 0, len(found))
+	for _, plugin := range found {
+		plugins = append(plugins, plugin)
+	}
+	sort.Slice(plugins, func(i, j int) bool { return plugins[i].Name < plugins[j].Name })
+	return plugins
+}
+
+// pluginName extracts the subcommand name from an executable file name.
+func pluginName(file string) (string, bool) {
+	if !strings.HasPrefix(file, Prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(name))
+		if ext != ".exe" && ext != ".bat" && ext != ".cmd" {
+			return "", false
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	if name == "" || strings.ContainsAny(name, " \t") {
+		return "", false
+	}
+	return name, true
+}
+
+// isExecutable reports whether path is a regular file that can be run.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true // filtered by extension in pluginName
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// NewContext builds the context for a plugin run. projectRoot may be empty
+// when goforge is not run from inside a project.
+func NewContext(version, projectRoot string) (*Context, error) {
+	ctx := &Context{Version: version, ProjectRoot: projectRoot}
+	if projectRoot == "" {
+		return ctx, nil
+	}
+
+	data, err := project.ReadConfigData(projectRoot)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read goforge.yml: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &ctx.Config); err != nil {
+		return nil, fmt.Errorf("failed to parse goforge.yml: %w", err)
+	}
+	return ctx, nil
+}
+
+// Run executes the plugin with args, writing the context to its stdin and
+// connecting its output to goforge's. The plugin's exit code is returned
+// as an *exec.ExitError.
+func (p *Plugin) Run(ctx *Context, args []string) error {
+	payload, err := json.Marshal(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to encode plugin context: %w", err)
+	}
+
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = bytes.NewReader(append(payload, '\n'))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"GOFORGE_PLUGIN_NAME="+p.Name,
+		"GOFORGE_VERSION="+ctx.Version,
+		"GOFORGE_PROJECT_ROOT="+ctx.ProjectRoot,
+	)
+	if ctx.ProjectRoot != "" {
+		cmd.Env = append(cmd.Env, "GOFORGE_CONFIG="+project.ConfigPath(ctx.ProjectRoot))
+	}
+	return cmd.Run()
+}
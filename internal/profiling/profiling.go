@@ -0,0 +1,102 @@
+// Package profiling captures profiles from the net/http/pprof endpoints of
+// a running program.
+package profiling
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultURL is where the pprof endpoints are served unless profile.url in
+// goforge.yml says otherwise.
+const DefaultURL = "http://localhost:6060/debug/pprof"
+
+// Dir holds the captured profiles, relative to the project root.
+const Dir = ".goforge/profiles"
+
+// Types are the profiles that can be captured. cpu samples for the
+// duration of the capture; the others are snapshots taken at its end.
+var Types = []string{"cpu", "heap", "goroutine", "allocs", "block", "mutex"}
+
+// DefaultTypes are captured when none are asked for.
+var DefaultTypes = []string{"cpu", "heap", "goroutine"}
+
+// ErrNoEndpoint means the server answers, but not with pprof at the URL.
+var ErrNoEndpoint = errors.New("no pprof endpoint")
+
+// Check reports whether the pprof endpoints answer at url.
+func Check(url string) error {
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Get(strings.TrimSuffix(url, "/") + "/")
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w at %s (%s)", ErrNoEndpoint, url, resp.Status)
+	}
+	return nil
+}
+
+// WaitFor waits until the pprof endpoints answer at url, for at most
+// timeout, or until stop is closed.
+func WaitFor(url string, timeout time.Duration, stop <-chan struct{}) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		err := Check(url)
+		if err == nil || errors.Is(err, ErrNoEndpoint) {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("pprof endpoints did not answer at %s within %s", url, timeout)
+		}
+		select {
+		case <-stop:
+			return fmt.Errorf("the server exited before it served %s", url)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+// Capture fetches a profile of type kind from the endpoints at url into
+// path. seconds is how long cpu samples.
+func Capture(url, kind string, seconds int, path string) error {
+	if !slices.Contains(Types, kind) {
+		return fmt.Errorf("unknown profile type '%s': use %s", kind, strings.Join(Types, ", "))
+	}
+	endpoint := strings.TrimSuffix(url, "/") + "/" + kind
+	if kind == "cpu" {
+		endpoint = fmt.Sprintf("%s/profile?seconds=%d", strings.TrimSuffix(url, "/"), seconds)
+	}
+
+	client := &http.Client{Timeout: time.Duration(seconds)*time.Second + 30*time.Second}
+	resp, err := client.Get(endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to capture the %s profile: %w", kind, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to capture the %s profile: %s: %s", kind, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, resp.Body); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to save the %s profile: %w", kind, err)
+	}
+	return f.Close()
+}
@@ -4,89 +4,513 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 
+	"github.com/night-slayer18/goforge/internal/exitcode"
 	"gopkg.in/yaml.v3"
 )
 
 // Config represents the structure of the goforge.yml file.
 type Config struct {
+	// Extends and Include name the files this one builds on; see
+	// inherit.go for how they are merged. The other fields hold the
+	// merged settings.
+	Extends string   `yaml:"extends,omitempty"`
+	Include []string `yaml:"include,omitempty"`
+
+	// Strict makes commands fail while goforge.yml has keys the schema
+	// does not know, such as typos, instead of ignoring them.
+	Strict bool `yaml:"strict,omitempty"`
+
 	ProjectName  string            `yaml:"project_name"`
 	ModuleName   string            `yaml:"module_path"`
 	GoVersion    string            `yaml:"go_version"`
 	Dependencies map[string]string `yaml:"dependencies"`
-	Scripts      map[string]string `yaml:"scripts"`
+	Scripts      Scripts           `yaml:"scripts"`
 	Build        *BuildConfig      `yaml:"build"`
+	Test         *TestConfig       `yaml:"test,omitempty"`
+	Lint         *LintConfig       `yaml:"lint,omitempty"`
+	Fmt          *FmtConfig        `yaml:"fmt,omitempty"`
+	Check        *CheckConfig      `yaml:"check,omitempty"`
+	Profile      *ProfileConfig    `yaml:"profile,omitempty"`
+	Migrations   *MigrationsConfig `yaml:"migrations,omitempty"`
 	Dev          *DevConfig        `yaml:"dev"`
+	Layout       map[string]string `yaml:"layout,omitempty"`
+	Support      *SupportConfig    `yaml:"support,omitempty"`
+	Generate     *GenerateConfig   `yaml:"generate,omitempty"`
+	Mocks        *MocksConfig      `yaml:"mocks,omitempty"`
+	Policy       *PolicyConfig     `yaml:"policy,omitempty"`
+	Workspace    *WorkspaceConfig  `yaml:"workspace,omitempty"`
+	Docker       *DockerConfig     `yaml:"docker,omitempty"`
+	Template     *TemplateConfig   `yaml:"template,omitempty"`
+	Deploy       *DeployConfig     `yaml:"deploy,omitempty"`
+
+	// Description, Author, License, and Repository describe the project:
+	// a one-line summary, the copyright holder, the license, and the URL
+	// of its source repository.
+	Description string `yaml:"description,omitempty"`
+	Author      string `yaml:"author,omitempty"`
+	License     string `yaml:"license,omitempty"`
+	Repository  string `yaml:"repository,omitempty"`
+
+	// Framework is the HTTP framework the handlers and middleware are
+	// written for: gin (the default), echo, fiber, chi, or stdlib.
+	Framework string `yaml:"framework,omitempty"`
+
+	// ORM is how the repositories reach the database: pgx (the default),
+	// gorm, sqlc, or ent.
+	ORM string `yaml:"orm,omitempty"`
+
+	// Logger is the logging library of internal/logger: slog, zap, or
+	// zerolog. Projects without one log with the standard log package.
+	Logger string `yaml:"logger,omitempty"`
+}
+
+// Scripts maps script names to commands. Instead of a command, a script
+// may map operating systems, as named by GOOS, to the command run there,
+// with default for the others:
+//
+//	open:
+//	  default: xdg-open http://localhost:8080
+//	  darwin: open http://localhost:8080
+//	  windows: start http://localhost:8080
+//
+// Decoding picks the command for the current system; a script with
+// neither a variant for it nor a default is left out.
+type Scripts map[string]string
+
+func (s *Scripts) UnmarshalYAML(node *yaml.Node) error {
+	var scripts map[string]scriptVariants
+	if err := node.Decode(&scripts); err != nil {
+		return err
+	}
+	*s = make(Scripts, len(scripts))
+	for name, variants := range scripts {
+		command, ok := variants[runtime.GOOS]
+		if !ok {
+			command, ok = variants["default"]
+		}
+		if ok {
+			(*s)[name] = command
+		}
+	}
+	return nil
+}
+
+// scriptVariants holds the commands of a script by GOOS; a plain command
+// is its default.
+type scriptVariants map[string]string
+
+func (v *scriptVariants) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.MappingNode {
+		return node.Decode((*map[string]string)(v))
+	}
+	var command string
+	if err := node.Decode(&command); err != nil {
+		return err
+	}
+	*v = scriptVariants{"default": command}
+	return nil
+}
+
+// forges host repositories at <host>/<owner>/<name>.
+var forges = []string{"github.com", "gitlab.com", "bitbucket.org", "codeberg.org"}
+
+// RepositoryFor returns the repository URL of a module hosted on a
+// well-known forge, e.g. https://github.com/org/app for
+// github.com/org/app/v2, and "" for other module paths.
+func RepositoryFor(modulePath string) string {
+	parts := strings.Split(modulePath, "/")
+	if len(parts) < 3 || !slices.Contains(forges, parts[0]) {
+		return ""
+	}
+	return "https://" + strings.Join(parts[:3], "/")
+}
+
+// PolicyConfig constrains how goforge is used in a project; it is checked
+// before every command.
+type PolicyConfig struct {
+	// MinVersion is the oldest goforge release allowed, e.g. "1.3.0".
+	MinVersion string `yaml:"min_version,omitempty"`
+
+	// Deny lists commands that may not run in the project, e.g. "update"
+	// or "generate oidc". Denying a command also denies its subcommands.
+	Deny []string `yaml:"deny,omitempty"`
+}
+
+// MocksConfig configures 'goforge generate mock' and 'goforge mocks'.
+type MocksConfig struct {
+	// Tool is the mock generator: "mockgen" (default) or "mockery".
+	Tool string `yaml:"tool,omitempty"`
+
+	// Auto generates mocks whenever 'goforge generate port' runs, as if
+	// --mock had been passed.
+	Auto bool `yaml:"auto,omitempty"`
+}
+
+// GenerateConfig controls how 'goforge generate' writes files.
+type GenerateConfig struct {
+	// Format runs generated Go files through goimports (default true).
+	Format *bool `yaml:"format,omitempty"`
+
+	// PostHooks are shell commands run after generation, e.g.
+	// "gofumpt -w {files}". {files} is replaced by the generated files.
+	PostHooks []string `yaml:"post_hooks,omitempty"`
+}
+
+// SupportConfig configures 'goforge support-bundle'.
+type SupportConfig struct {
+	// Recipient is the platform team's public key (gfsb-pub-...) that
+	// bundles are encrypted to.
+	Recipient string `yaml:"recipient,omitempty"`
 }
 
 // BuildConfig defines the build-specific configuration.
 type BuildConfig struct {
 	Assets []string `yaml:"assets"`
+
+	// Main is the package 'goforge build' compiles, ./cmd/server by default.
+	Main string `yaml:"main,omitempty"`
+
+	// LDFlags are passed to the linker; {version}, {commit} and {date} are
+	// replaced with the git tag, the short commit hash and the build time.
+	LDFlags string `yaml:"ldflags,omitempty"`
+
+	// OutputDir and BinaryName place the binary, dist/<project_name> by
+	// default.
+	OutputDir  string `yaml:"output_dir,omitempty"`
+	BinaryName string `yaml:"binary_name,omitempty"`
+
+	// Env is added to the environment of go build, e.g. GOOS and GOARCH to
+	// cross-compile.
+	Env map[string]string `yaml:"env,omitempty"`
+
+	// Tags are the build tags.
+	Tags []string `yaml:"tags,omitempty"`
+
+	// Archive packs the binary and the assets into
+	// <output_dir>/<project_name>.<archive>; "zip" is the only format.
+	Archive string `yaml:"archive,omitempty"`
+}
+
+// TestConfig configures 'goforge test'.
+type TestConfig struct {
+	// Timeout limits the whole test run, e.g. "10m"; go test's default of
+	// 10 minutes applies when empty.
+	Timeout string `yaml:"timeout,omitempty"`
+
+	// CoverageThreshold is the lowest total coverage, in percent, that
+	// 'goforge test --coverage' accepts; 0 accepts any.
+	CoverageThreshold float64 `yaml:"coverage_threshold,omitempty"`
+
+	// ReportFormat makes every run write a report for CI systems: "junit"
+	// or "json". ReportFile is where, test-report.xml or test-report.json
+	// by default.
+	ReportFormat string `yaml:"report_format,omitempty"`
+	ReportFile   string `yaml:"report_file,omitempty"`
+}
+
+// LintConfig configures 'goforge lint'.
+type LintConfig struct {
+	// Version pins the golangci-lint release, e.g. "v2.5.0"; goforge's
+	// default when empty.
+	Version string `yaml:"version,omitempty"`
+}
+
+// FmtConfig configures 'goforge fmt'.
+type FmtConfig struct {
+	// Gofumpt formats with gofumpt's stricter rules on top of gofmt.
+	Gofumpt bool `yaml:"gofumpt,omitempty"`
+}
+
+// CheckConfig configures 'goforge check'.
+type CheckConfig struct {
+	// Steps are the checks to run in order: vet, fmt, lint, test, build,
+	// audit, or script names; vet, lint, test, build, and audit when empty.
+	Steps []string `yaml:"steps,omitempty"`
+}
+
+// ProfileConfig configures 'goforge profile'.
+type ProfileConfig struct {
+	// URL serves the program's net/http/pprof endpoints,
+	// http://localhost:6060/debug/pprof by default.
+	URL string `yaml:"url,omitempty"`
+}
+
+// MigrationsConfig configures 'goforge migrate' and the migrations the
+// generators write.
+type MigrationsConfig struct {
+	// Engine runs the migrations and sets their file format:
+	// golang-migrate (the default), goose, or atlas.
+	Engine string `yaml:"engine,omitempty"`
+
+	// Dir holds the migrations, migrations by default.
+	Dir string `yaml:"dir,omitempty"`
+
+	// Table records the applied migrations; the engine's default when
+	// empty.
+	Table string `yaml:"table,omitempty"`
+
+	// DatabaseURL is the database to migrate; DATABASE_URL overrides it.
+	DatabaseURL string `yaml:"database_url,omitempty"`
+
+	// DevURL is the scratch database atlas plans down migrations on.
+	DevURL string `yaml:"dev_url,omitempty"`
+}
+
+// DockerConfig configures the Dockerfile and docker-compose.yml of
+// 'goforge generate docker'.
+type DockerConfig struct {
+	// BaseImage builds the binary, golang:<go_version>-alpine by default;
+	// RuntimeImage runs it, alpine:latest by default.
+	BaseImage    string `yaml:"base_image,omitempty"`
+	RuntimeImage string `yaml:"runtime_image,omitempty"`
+
+	// Port is exposed by the container; none when 0.
+	Port int `yaml:"port,omitempty"`
+
+	// Env is set in the image and in the compose services.
+	Env map[string]string `yaml:"env,omitempty"`
+}
+
+// DeployConfig configures 'goforge deploy'. Only the section of the
+// chosen provider is read.
+type DeployConfig struct {
+	// Provider deploys the project: fly, render, kubernetes, helm, ssh, or
+	// <name> for a goforge-deploy-<name> plugin on PATH.
+	Provider string `yaml:"provider"`
+
+	Fly        *FlyDeployConfig        `yaml:"fly,omitempty"`
+	Render     *RenderDeployConfig     `yaml:"render,omitempty"`
+	Kubernetes *KubernetesDeployConfig `yaml:"kubernetes,omitempty"`
+	Helm       *HelmDeployConfig       `yaml:"helm,omitempty"`
+	SSH        *SSHDeployConfig        `yaml:"ssh,omitempty"`
+}
+
+// FlyDeployConfig deploys to Fly.io with flyctl.
+type FlyDeployConfig struct {
+	// App is the Fly app; fly.toml's when empty.
+	App string `yaml:"app,omitempty"`
+
+	// Config is the fly.toml to use, fly.toml by default.
+	Config string `yaml:"config,omitempty"`
+
+	// Image deploys a pushed image instead of building the Dockerfile;
+	// {version} is replaced with the release version.
+	Image string `yaml:"image,omitempty"`
+}
+
+// RenderDeployConfig deploys a Render service through the Render API,
+// authenticated by RENDER_API_KEY.
+type RenderDeployConfig struct {
+	// ServiceID is the service to deploy, srv-...
+	ServiceID string `yaml:"service_id"`
+
+	// Image deploys a pushed image to an image-backed service; {version}
+	// is replaced with the release version.
+	Image string `yaml:"image,omitempty"`
+}
+
+// KubernetesDeployConfig deploys manifests with kubectl.
+type KubernetesDeployConfig struct {
+	// Context and Namespace select the cluster; kubectl's current ones
+	// when empty.
+	Context   string `yaml:"context,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Manifests are applied with 'kubectl apply -f', k8s by default.
+	Manifests []string `yaml:"manifests,omitempty"`
+
+	// Deployment is rolled out and rolled back, the project name by
+	// default.
+	Deployment string `yaml:"deployment,omitempty"`
+
+	// Image is set on Container (the deployment's first container when
+	// empty) after applying; {version} is replaced with the release
+	// version.
+	Image     string `yaml:"image,omitempty"`
+	Container string `yaml:"container,omitempty"`
+
+	// Timeout bounds waiting for the rollout, e.g. "5m".
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// HelmDeployConfig deploys a Helm chart.
+type HelmDeployConfig struct {
+	// Release is the Helm release, the project name by default; Chart is
+	// its chart, ./chart by default.
+	Release string `yaml:"release,omitempty"`
+	Chart   string `yaml:"chart,omitempty"`
+
+	// Context and Namespace select the cluster; kubectl's current ones
+	// when empty.
+	Context   string `yaml:"context,omitempty"`
+	Namespace string `yaml:"namespace,omitempty"`
+
+	// Values are values files; Set are single values, where {version} is
+	// replaced with the release version, e.g. image.tag: "{version}".
+	Values []string          `yaml:"values,omitempty"`
+	Set    map[string]string `yaml:"set,omitempty"`
+
+	// Timeout bounds waiting for the release, e.g. "5m".
+	Timeout string `yaml:"timeout,omitempty"`
+}
+
+// SSHDeployConfig copies the binary to a server over SSH and restarts its
+// systemd unit.
+type SSHDeployConfig struct {
+	// Host is the server, as in user@example.com; Port is SSH's, 22 by
+	// default.
+	Host string `yaml:"host"`
+	Port int    `yaml:"port,omitempty"`
+
+	// Dir holds a directory per release under releases/ and the current
+	// symlink to the live one, /opt/<project_name> by default.
+	Dir string `yaml:"dir,omitempty"`
+
+	// Service is the systemd unit running <dir>/current/<binary>, the
+	// project name by default.
+	Service string `yaml:"service,omitempty"`
+
+	// GOOS and GOARCH are the server's, linux and amd64 by default.
+	GOOS   string `yaml:"goos,omitempty"`
+	GOARCH string `yaml:"goarch,omitempty"`
+
+	// Keep is how many releases stay on the server for rollbacks, 5 by
+	// default.
+	Keep int `yaml:"keep,omitempty"`
+
+	// NoSudo runs systemctl without sudo, for users allowed to manage the
+	// unit.
+	NoSudo bool `yaml:"no_sudo,omitempty"`
+}
+
+// TemplateConfig records the project template a project was created from,
+// for 'goforge upgrade-template'.
+type TemplateConfig struct {
+	// Name is an embedded template, e.g. "default", or <pack>/<template>.
+	Name string `yaml:"name"`
+
+	// Version is the goforge release of an embedded template, or the pack
+	// version, the project's files were last rendered from.
+	Version string `yaml:"version,omitempty"`
+
+	// Vars are the values of the template's variables.
+	Vars map[string]string `yaml:"vars,omitempty"`
 }
 
 // DevConfig defines the development-specific configuration for the watch command.
 type DevConfig struct {
-	Watch  []string `yaml:"watch"`
-	Ignore []string `yaml:"ignore"`
+	// Port is the port of the development server, which watch mode frees
+	// before each restart and waits for before reloading pages. Ports adds
+	// the others of apps listening on several, e.g. gRPC and its gateway.
+	// Without either, server.port of config/default.yml is used.
+	Port  int   `yaml:"port,omitempty"`
+	Ports []int `yaml:"ports,omitempty"`
+
+	Watch  []string     `yaml:"watch"`
+	Ignore []string     `yaml:"ignore"`
+	Fakes  *FakesConfig `yaml:"fakes,omitempty"`
+
+	// Rollback restarts the last successful build automatically when a
+	// change fails to compile or crashes on start.
+	Rollback bool `yaml:"rollback,omitempty"`
+
+	// OnChange lists commands run before each restart, e.g. code generators.
+	OnChange []string `yaml:"on_change,omitempty"`
+
+	// LiveReload makes watch mode reload open pages after each restart;
+	// LiveReloadPort serves the events (35729 by default).
+	LiveReload     bool `yaml:"live_reload,omitempty"`
+	LiveReloadPort int  `yaml:"live_reload_port,omitempty"`
+
+	// LogFile is a file watch mode also writes its messages and the output
+	// of the script to, relative to the project root. It is rotated once it
+	// reaches 10 MB, keeping three old files.
+	LogFile string `yaml:"log_file,omitempty"`
+}
+
+// FakesConfig declares the local service fakes started by 'goforge dev --with-fakes'.
+type FakesConfig struct {
+	SMTP    *FakeServiceConfig `yaml:"smtp,omitempty"`
+	S3      *FakeServiceConfig `yaml:"s3,omitempty"`
+	Webhook *FakeServiceConfig `yaml:"webhook,omitempty"`
+}
+
+// FakeServiceConfig configures a single fake service.
+type FakeServiceConfig struct {
+	Port    int      `yaml:"port"`
+	Buckets []string `yaml:"buckets,omitempty"` // S3 only
 }
 
-// LoadConfig finds and parses the goforge.yml file from the current directory
-// or any parent directory. It returns the parsed config, the project root
-// directory (where the config was found), and any error that occurred.
+// LoadConfig finds and parses the goforge.yml file, or another of
+// ConfigFiles, from the current directory or any parent directory. It returns the parsed config, the project root
+// directory (where the config was found), and any error that occurred,
+// which makes goforge exit with exitcode.Config.
 func LoadConfig() (*Config, string, error) {
-	currentDir, err := os.Getwd()
+	projectRoot, err := FindRoot()
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to get current directory: %w", err)
+		return nil, "", exitcode.Wrap(exitcode.Config, err)
 	}
+	configPath := ConfigPath(projectRoot)
 
-	var configPath string
-	dir := currentDir
-	for {
-		potentialPath := filepath.Join(dir, "goforge.yml")
-		if _, err := os.Stat(potentialPath); err == nil {
-			configPath = potentialPath
-			break
-		}
-
-		parentDir := filepath.Dir(dir)
-		if parentDir == dir { // Reached the root directory
-			return nil, "", fmt.Errorf("goforge.yml not found in this directory or any parent")
-		}
-		dir = parentDir
+	cfg, err := readConfig(configPath)
+	if os.IsNotExist(err) {
+		return nil, "", exitcode.Errorf(exitcode.Config, "failed to read goforge.yml: %w", err)
 	}
-
-	projectRoot := filepath.Dir(configPath)
-
-	data, err := os.ReadFile(configPath)
 	if err != nil {
-		return nil, "", fmt.Errorf("failed to read goforge.yml: %w", err)
+		return nil, "", exitcode.Wrap(exitcode.Config, err)
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return nil, "", fmt.Errorf("failed to parse goforge.yml: %w", err)
+	// In a service declared only in the workspace manifest, the service is
+	// the project
+	if cfg.Workspace != nil {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+		service, ok, err := declaredService(projectRoot, cfg, dir)
+		if err != nil {
+			return nil, "", exitcode.Wrap(exitcode.Config, err)
+		}
+		if ok {
+			return service.Config, filepath.Join(projectRoot, filepath.FromSlash(service.Dir)), nil
+		}
 	}
 
-	return &cfg, projectRoot, nil
+	return cfg, projectRoot, nil
 }
 
-// SaveConfig marshals the provided Config struct back to YAML and writes it
-// to the goforge.yml file in the specified project root directory.
-func SaveConfig(projectRoot string, cfg *Config) error {
-	configPath := filepath.Join(projectRoot, "goforge.yml")
-
-	// Marshal the struct into YAML format.
-	data, err := yaml.Marshal(cfg)
+// FindRoot returns the project root: the current directory or the nearest
+// parent holding a goforge.yml file or another of ConfigFiles, which is
+// not parsed.
+func FindRoot() (string, error) {
+	dir, err := os.Getwd()
 	if err != nil {
-		return fmt.Errorf("failed to marshal config to YAML: %w", err)
+		return "", fmt.Errorf("failed to get current directory: %w", err)
+	}
+	for {
+		if HasConfig(dir) {
+			return dir, nil
+		}
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir { // Reached the root directory
+			return "", fmt.Errorf("goforge.yml not found in this directory or any parent")
+		}
+		dir = parentDir
 	}
+}
 
-	// Write the new content back to the goforge.yml file.
-	// 0644 is a standard file permission for text files.
-	err = os.WriteFile(configPath, data, 0644)
+// LoadConfigFrom parses the goforge.yml file in projectRoot.
+func LoadConfigFrom(projectRoot string) (*Config, error) {
+	cfg, err := readConfig(ConfigPath(projectRoot))
 	if err != nil {
-		return fmt.Errorf("failed to write to goforge.yml: %w", err)
+		return nil, exitcode.Errorf(exitcode.Config, "failed to read goforge.yml: %w", err)
 	}
-
-	return nil
+	return cfg, nil
 }
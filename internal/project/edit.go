@@ -0,0 +1,202 @@
+package project
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SetConfigValue sets the scalar at keyPath in goforge.yml, creating any
+// missing mappings along the way. Comments, key order, and sections
+// goforge does not model are preserved.
+func SetConfigValue(projectRoot string, keyPath []string, value string) error {
+	configPath := ConfigPath(projectRoot)
+	if _, err := os.Stat(configPath); err != nil {
+		return fmt.Errorf("failed to read goforge.yml: %w", err)
+	}
+	return SetYAMLValue(configPath, keyPath, value, "!!str")
+}
+
+// SetYAMLValue sets the scalar at keyPath in the YAML file at path to
+// value, tagged with tag (e.g. "!!str" or "!!bool"), preserving the rest
+// of the file as SetConfigValue does. A missing file is created.
+func SetYAMLValue(path string, keyPath []string, value, tag string) error {
+	return editYAML(path, keyPath, func(mapping *yaml.Node) error {
+		node := mappingChild(mapping, keyPath[len(keyPath)-1], false)
+		if node.Kind != yaml.ScalarNode {
+			node.Style = 0
+		}
+		node.Kind = yaml.ScalarNode
+		node.Tag = tag
+		node.Value = value
+		node.Content = nil
+		return nil
+	})
+}
+
+// SetYAMLList sets keyPath in the YAML file at path to a list of the
+// string values, preserving the rest of the file as SetYAMLValue does.
+func SetYAMLList(path string, keyPath []string, values []string) error {
+	return editYAML(path, keyPath, func(mapping *yaml.Node) error {
+		node := mappingChild(mapping, keyPath[len(keyPath)-1], false)
+		if node.Kind != yaml.SequenceNode {
+			node.Style = 0
+		}
+		node.Kind = yaml.SequenceNode
+		node.Tag = "!!seq"
+		node.Value = ""
+		node.Content = nil
+		for _, value := range values {
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value})
+		}
+		return nil
+	})
+}
+
+// UnsetYAMLValue removes keyPath, with its comments, from the YAML file at
+// path and reports whether it was there.
+func UnsetYAMLValue(path string, keyPath []string) (bool, error) {
+	if node, err := LookupYAMLValue(path, keyPath); err != nil || node == nil {
+		return false, err
+	}
+	err := editYAML(path, keyPath, func(mapping *yaml.Node) error {
+		for i := 0; i+1 < len(mapping.Content); i += 2 {
+			if mapping.Content[i].Value == keyPath[len(keyPath)-1] {
+				mapping.Content = slices.Delete(mapping.Content, i, i+2)
+				break
+			}
+		}
+		return nil
+	})
+	return err == nil, err
+}
+
+// LookupYAMLValue returns the node at keyPath in the YAML file at path, or
+// nil when the file or the key is missing.
+func LookupYAMLValue(path string, keyPath []string) (*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filepath.Base(path), err)
+	}
+	doc, err := parseDocument(path, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath.Base(path), err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	return lookupNode(doc.Content[0], keyPath), nil
+}
+
+// editYAML applies edit to the mapping holding the last key of keyPath in
+// the YAML file at path, creating the mappings leading to it, and writes
+// the file back with its comments, key order, and blank lines. A missing
+// file is created. JSON and TOML files, such as goforge.json, are edited
+// in their own format.
+func editYAML(path string, keyPath []string, edit func(mapping *yaml.Node) error) error {
+	if len(keyPath) == 0 {
+		return fmt.Errorf("empty configuration key")
+	}
+
+	name := filepath.Base(path)
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read %s: %w", name, err)
+	}
+
+	isYAML := FormatOf(path) == "yaml"
+	if isYAML {
+		data = markBlankLines(data)
+	}
+	doc, err := parseDocument(path, data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	if doc.Kind == 0 {
+		*doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+
+	node := doc.Content[0]
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("%s must contain a mapping at the top level", name)
+	}
+	for _, key := range keyPath[:len(keyPath)-1] {
+		node = mappingChild(node, key, true)
+	}
+	if err := edit(node); err != nil {
+		return err
+	}
+
+	encoded, err := encodeDocument(path, doc)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	if isYAML {
+		encoded = restoreBlankLines(encoded)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, encoded, 0644); err != nil {
+		return fmt.Errorf("failed to write to %s: %w", name, err)
+	}
+	return nil
+}
+
+// blankLineMarker stands in for blank lines while the document is a node
+// tree; yaml.v3 keeps comments but drops empty lines.
+const blankLineMarker = "#goforge:blank"
+
+// markBlankLines replaces blank lines with blankLineMarker comments.
+func markBlankLines(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 && i < len(lines)-1 {
+			lines[i] = []byte(blankLineMarker)
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// restoreBlankLines turns blankLineMarker comments back into blank lines.
+func restoreBlankLines(data []byte) []byte {
+	lines := bytes.Split(data, []byte("\n"))
+	for i, line := range lines {
+		if string(bytes.TrimSpace(line)) == blankLineMarker {
+			lines[i] = nil
+		}
+	}
+	return bytes.Join(lines, []byte("\n"))
+}
+
+// mappingChild returns the value node for key, appending it if missing.
+// Missing intermediate keys become mappings, the final key a scalar.
+func mappingChild(mapping *yaml.Node, key string, intermediate bool) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			value := mapping.Content[i+1]
+			if intermediate && value.Kind != yaml.MappingNode {
+				// e.g. an empty "dependencies:" entry parses as a null scalar
+				*value = yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			}
+			return value
+		}
+	}
+
+	value := &yaml.Node{Kind: yaml.ScalarNode}
+	if intermediate {
+		value = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	}
+	mapping.Content = append(mapping.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key},
+		value)
+	return value
+}
@@ -0,0 +1,265 @@
+package project
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFiles are the names the project's configuration may have, in the
+// order goforge looks for them. Whatever the format, messages call it
+// goforge.yml.
+var ConfigFiles = []string{"goforge.yml", "goforge.yaml", "goforge.json", "goforge.toml"}
+
+// Formats are the formats a configuration file may be written in.
+var Formats = []string{"yaml", "json", "toml"}
+
+// ConfigPath returns the configuration file of the project in
+// projectRoot: the first of ConfigFiles there, or goforge.yml when there
+// is none.
+func ConfigPath(projectRoot string) string {
+	for _, name := range ConfigFiles {
+		path := filepath.Join(projectRoot, name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return filepath.Join(projectRoot, ConfigFiles[0])
+}
+
+// HasConfig reports whether dir holds a configuration file.
+func HasConfig(dir string) bool {
+	_, err := os.Stat(ConfigPath(dir))
+	return err == nil
+}
+
+// FormatOf returns the format of the file at path by its extension: json,
+// toml, or yaml for anything else.
+func FormatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return "json"
+	case ".toml":
+		return "toml"
+	}
+	return "yaml"
+}
+
+// parseDocument parses data, the contents of the file at path, into a
+// YAML document node. JSON is a subset of YAML and keeps its positions;
+// TOML is converted, so its nodes have none and its keys are sorted.
+func parseDocument(path string, data []byte) (*yaml.Node, error) {
+	var doc yaml.Node
+	if FormatOf(path) != "toml" {
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, err
+		}
+		return &doc, nil
+	}
+
+	var values map[string]any
+	if err := toml.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return &doc, nil
+	}
+	var root yaml.Node
+	if err := root.Encode(values); err != nil {
+		return nil, err
+	}
+	doc.Kind, doc.Content = yaml.DocumentNode, []*yaml.Node{&root}
+	return &doc, nil
+}
+
+// encodeDocument writes doc in the format of the file at path. Comments
+// only survive in YAML; JSON keeps the order of the keys, while TOML
+// sorts them and cannot hold null values.
+func encodeDocument(path string, doc *yaml.Node) ([]byte, error) {
+	switch FormatOf(path) {
+	case "json":
+		value, err := jsonValue(doc)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.MarshalIndent(value, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(data, '\n'), nil
+	case "toml":
+		var values map[string]any
+		if err := doc.Decode(&values); err != nil {
+			return nil, err
+		}
+		if path := nullPath(values, ""); path != "" {
+			return nil, fmt.Errorf("%s is null, which TOML cannot hold; remove it first", path)
+		}
+		return toml.Marshal(values)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// nullPath returns the dotted path of the first null value under values,
+// or an empty string when there is none.
+func nullPath(values map[string]any, prefix string) string {
+	for key, value := range values {
+		switch value := value.(type) {
+		case nil:
+			return prefix + key
+		case map[string]any:
+			if path := nullPath(value, prefix+key+"."); path != "" {
+				return path
+			}
+		}
+	}
+	return ""
+}
+
+// jsonMapping is a YAML mapping encoded as a JSON object with its keys in
+// their order.
+type jsonMapping struct {
+	keys   []string
+	values []any
+}
+
+func (m jsonMapping) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(m.values[i])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// jsonValue returns the value of node for encoding/json, keeping the order
+// of mapping keys.
+func jsonValue(node *yaml.Node) (any, error) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		if len(node.Content) == 0 {
+			return jsonMapping{}, nil
+		}
+		return jsonValue(node.Content[0])
+	case yaml.AliasNode:
+		return jsonValue(node.Alias)
+	case yaml.MappingNode:
+		var mapping jsonMapping
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			value, err := jsonValue(node.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			mapping.keys = append(mapping.keys, node.Content[i].Value)
+			mapping.values = append(mapping.values, value)
+		}
+		return mapping, nil
+	case yaml.SequenceNode:
+		items := make([]any, 0, len(node.Content))
+		for _, child := range node.Content {
+			item, err := jsonValue(child)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+	var value any
+	if err := node.Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// ConvertConfig rewrites the configuration file of the project in
+// projectRoot in format, one of Formats, and removes the old file. It
+// returns the path of the new file. The file is converted as written:
+// the files it extends and includes stay as they are, and so do
+// environment variable placeholders.
+func ConvertConfig(projectRoot, format string) (string, error) {
+	from := ConfigPath(projectRoot)
+	if FormatOf(from) == format {
+		return from, nil
+	}
+	data, err := os.ReadFile(from)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", filepath.Base(from), err)
+	}
+	doc, err := parseDocument(from, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", filepath.Base(from), err)
+	}
+
+	if FormatOf(from) == "json" && len(doc.Content) > 0 {
+		// JSON's flow style and quotes would carry over into YAML
+		resetStyle(doc)
+
+		// $schema links the schema in JSON only; the other formats do it
+		// differently, if at all
+		root := doc.Content[0]
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "$schema" {
+				root.Content = slices.Delete(root.Content, i, i+2)
+				break
+			}
+		}
+	}
+
+	name := "goforge." + format
+	if format == "yaml" {
+		name = ConfigFiles[0]
+	}
+	to := filepath.Join(projectRoot, name)
+	converted, err := encodeDocument(to, doc)
+	if err != nil {
+		return "", fmt.Errorf("cannot convert %s to %s: %w", filepath.Base(from), format, err)
+	}
+	if err := os.WriteFile(to, converted, 0644); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if err := os.Remove(from); err != nil {
+		return "", fmt.Errorf("failed to remove %s: %w", filepath.Base(from), err)
+	}
+	return to, nil
+}
+
+// resetStyle makes node and its descendants use the default style: block
+// mappings and lists, and quotes only where the value needs them.
+func resetStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		resetStyle(child)
+	}
+}
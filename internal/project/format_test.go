@@ -0,0 +1,54 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConvertConfigKeepsPlaceholders(t *testing.T) {
+	t.Setenv("GOFORGE_TEST_PORT", "")
+	t.Setenv("GOFORGE_TEST_GO", "")
+
+	root := t.TempDir()
+	config := `project_name: app
+module_path: example.com/app
+go_version: ${GOFORGE_TEST_GO:-1.20}
+dev:
+  port: ${GOFORGE_TEST_PORT:-3000}
+  ports: ["${GOFORGE_TEST_PORT:-3000}", 3001]
+`
+	if err := os.WriteFile(filepath.Join(root, ConfigFiles[0]), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, format := range []string{"json", "toml", "yaml"} {
+		path, err := ConvertConfig(root, format)
+		if err != nil {
+			t.Fatalf("ConvertConfig(%s): %v", format, err)
+		}
+		if got := FormatOf(path); got != format {
+			t.Fatalf("ConvertConfig(%s) wrote %s", format, filepath.Base(path))
+		}
+
+		cfg, err := LoadConfigFrom(root)
+		if err != nil {
+			t.Fatalf("loading the %s config: %v", format, err)
+		}
+		if cfg.GoVersion != "1.20" {
+			t.Errorf("%s: go_version = %q, want 1.20", format, cfg.GoVersion)
+		}
+		if cfg.Dev == nil || cfg.Dev.Port != 3000 || len(cfg.Dev.Ports) != 2 || cfg.Dev.Ports[0] != 3000 {
+			t.Errorf("%s: dev = %+v, want port 3000 and ports [3000 3001]", format, cfg.Dev)
+		}
+
+		t.Setenv("GOFORGE_TEST_PORT", "8080")
+		if cfg, err = LoadConfigFrom(root); err != nil {
+			t.Fatalf("loading the %s config with GOFORGE_TEST_PORT set: %v", format, err)
+		}
+		if cfg.Dev.Port != 8080 {
+			t.Errorf("%s: dev.port = %d with GOFORGE_TEST_PORT=8080", format, cfg.Dev.Port)
+		}
+		t.Setenv("GOFORGE_TEST_PORT", "")
+	}
+}
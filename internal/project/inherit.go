@@ -0,0 +1,224 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// A goforge.yml may build on other files: extends names a base file and
+// include a list of files, with paths relative to the file naming them.
+// Both may extend and include further files. From lowest to highest
+// precedence, the settings are merged from
+//
+//  1. the file of extends,
+//  2. the files of include, in order,
+//  3. the file itself.
+//
+// Mappings are merged key by key, so a project can add one script or
+// override one dev setting; lists and values are replaced as a whole. A
+// key set to null (e.g. "lint:" or "scripts.deploy: ~") drops the
+// inherited value.
+
+// configSource is the parsed document of a goforge.yml and the files it
+// builds on, merged, with the file each node came from.
+type configSource struct {
+	dir     string // of the project's goforge.yml
+	root    *yaml.Node
+	origins map[*yaml.Node]string
+}
+
+// inherits reports whether the mapping names files to build on.
+func inherits(root *yaml.Node) bool {
+	return lookupNode(root, []string{"extends"}) != nil || lookupNode(root, []string{"include"}) != nil
+}
+
+// loadConfigSource parses the config file at path, merges the files it
+// extends and includes into it, and expands environment variables.
+func loadConfigSource(path string) (*configSource, error) {
+	source := &configSource{dir: filepath.Dir(path), origins: map[*yaml.Node]string{}}
+	root, err := source.load(path, nil)
+	if err != nil {
+		return nil, err
+	}
+	source.root = root
+	if inherits(root) {
+		dropNulls(root)
+	}
+	interpolateNode(root)
+	return source, nil
+}
+
+// load parses path and merges the files it builds on under it. chain
+// holds the files loading it, to report cycles. The errors of the
+// project's own file are returned as is, for readConfig to wrap.
+func (s *configSource) load(path string, chain []string) (*yaml.Node, error) {
+	path = filepath.Clean(path)
+	if slices.Contains(chain, path) {
+		names := make([]string, 0, len(chain)+1)
+		for _, file := range append(chain, path) {
+			names = append(names, s.name(file))
+		}
+		return nil, fmt.Errorf("%s extends or includes itself: %s", s.name(path), strings.Join(names, " → "))
+	}
+	chain = append(chain, path)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if len(chain) > 1 {
+			return nil, fmt.Errorf("failed to read %s, which %s builds on: %w", s.name(path), s.name(chain[len(chain)-2]), err)
+		}
+		return nil, err
+	}
+	doc, err := parseDocument(path, data)
+	if err != nil {
+		if len(chain) > 1 {
+			return nil, fmt.Errorf("failed to parse %s: %w", s.name(path), err)
+		}
+		return nil, err
+	}
+	root := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map", Line: 1, Column: 1}
+	if len(doc.Content) > 0 {
+		root = doc.Content[0]
+	}
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s must contain a mapping at the top level", s.name(path))
+	}
+	s.record(root, path)
+	if !inherits(root) {
+		return root, nil
+	}
+
+	var bases []string
+	dir := filepath.Dir(path)
+	if extends := lookupNode(root, []string{"extends"}); extends != nil && extends.Tag != "!!null" {
+		if extends.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s:%d: extends must name a single file", s.name(path), extends.Line)
+		}
+		bases = append(bases, extends.Value)
+	}
+	if include := lookupNode(root, []string{"include"}); include != nil && include.Tag != "!!null" {
+		if include.Kind != yaml.SequenceNode {
+			return nil, fmt.Errorf("%s:%d: include must be a list of files", s.name(path), include.Line)
+		}
+		for _, item := range include.Content {
+			if item.Kind != yaml.ScalarNode {
+				return nil, fmt.Errorf("%s:%d: include must be a list of files", s.name(path), item.Line)
+			}
+			bases = append(bases, item.Value)
+		}
+	}
+
+	// The last base takes precedence, so they go under the file in reverse
+	for _, base := range slices.Backward(bases) {
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(dir, base)
+		}
+		baseRoot, err := s.load(base, chain)
+		if err != nil {
+			return nil, err
+		}
+		mergeUnder(root, baseRoot)
+	}
+	return root, nil
+}
+
+// record notes the file of node and its descendants.
+func (s *configSource) record(node *yaml.Node, path string) {
+	s.origins[node] = path
+	for _, child := range node.Content {
+		s.record(child, path)
+	}
+}
+
+// name returns how messages refer to the file at path: relative to the
+// project's goforge.yml when possible.
+func (s *configSource) name(path string) string {
+	if rel, err := filepath.Rel(s.dir, path); err == nil {
+		return filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// mergeUnder adds the keys of the mapping low that high lacks to high, and
+// merges the mappings both have. high keeps every other value it has.
+func mergeUnder(high, low *yaml.Node) {
+	for i := 0; i+1 < len(low.Content); i += 2 {
+		key, value := low.Content[i], low.Content[i+1]
+		if key.Value == "extends" || key.Value == "include" {
+			continue // already resolved relative to their own file
+		}
+		existing := lookupNode(high, []string{key.Value})
+		switch {
+		case existing == nil:
+			high.Content = append(high.Content, key, value)
+		case existing.Kind == yaml.MappingNode && value.Kind == yaml.MappingNode:
+			mergeUnder(existing, value)
+		}
+	}
+}
+
+// dropNulls removes the keys set to null from the mappings under node,
+// which is how a file drops an inherited value.
+func dropNulls(node *yaml.Node) {
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	content := node.Content[:0]
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if value.Kind == yaml.ScalarNode && value.Tag == "!!null" {
+			continue
+		}
+		dropNulls(value)
+		content = append(content, key, value)
+	}
+	node.Content = content
+}
+
+// lookupNode returns the value at keyPath under node, or nil.
+func lookupNode(node *yaml.Node, keyPath []string) *yaml.Node {
+	for _, key := range keyPath {
+		if node.Kind == yaml.AliasNode {
+			node = node.Alias
+		}
+		if node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var child *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				child = node.Content[i+1]
+			}
+		}
+		if child == nil {
+			return nil
+		}
+		node = child
+	}
+	return node
+}
+
+// ReadConfigData returns the settings of the project's goforge.yml merged
+// with the files it extends and includes, as YAML.
+func ReadConfigData(projectRoot string) ([]byte, error) {
+	source, err := loadConfigSource(ConfigPath(projectRoot))
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(source.root)
+}
+
+// LookupConfigValue returns the node at keyPath in the project's merged
+// settings, or nil when it is not set.
+func LookupConfigValue(projectRoot string, keyPath []string) (*yaml.Node, error) {
+	source, err := loadConfigSource(ConfigPath(projectRoot))
+	if err != nil {
+		return nil, err
+	}
+	return lookupNode(source.root, keyPath), nil
+}
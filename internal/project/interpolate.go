@@ -0,0 +1,70 @@
+package project
+
+import (
+	"os"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Values in goforge.yml may refer to environment variables, so secrets and
+// machine-specific paths stay out of the file:
+//
+//	${VAR}           the value of VAR
+//	${VAR:-default}  the value of VAR, or default when VAR is unset or empty
+//	$${VAR}          a literal ${VAR}
+//
+// They are expanded when goforge.yml is loaded. ${VAR} is kept as written
+// while VAR is unset, so a script can still leave it to the shell, e.g.
+// for the variables 'goforge dev --with-fakes' exports; other forms of
+// ${...}, like ${VAR%.go}, are always left to the shell.
+
+// placeholderPattern matches an escaped "$${" or a placeholder, capturing
+// the variable and the ":-default" part with the default.
+var placeholderPattern = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolate expands the placeholders in value.
+func interpolate(value string) string {
+	return placeholderPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if match == "$${" {
+			return "${"
+		}
+		groups := placeholderPattern.FindStringSubmatch(match)
+		name, hasDefault, fallback := groups[1], groups[2] != "", groups[3]
+		if env, ok := os.LookupEnv(name); ok && (env != "" || !hasDefault) {
+			return env
+		}
+		if hasDefault {
+			return fallback
+		}
+		return match
+	})
+}
+
+// interpolateNode expands the placeholders in the values under node; keys
+// are left alone.
+func interpolateNode(node *yaml.Node) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			interpolateNode(child)
+		}
+	case yaml.MappingNode:
+		for i := 1; i < len(node.Content); i += 2 {
+			interpolateNode(node.Content[i])
+		}
+	case yaml.ScalarNode:
+		value := interpolate(node.Value)
+		if value == node.Value {
+			return
+		}
+		// An unquoted value is typed by what it expands to, e.g. a port;
+		// so is a lone placeholder, which JSON and TOML always quote
+		whole := placeholderPattern.FindString(node.Value) == node.Value
+		node.Value = value
+		if node.Style == 0 || whole {
+			node.Style, node.Tag = 0, ""
+			node.Tag = node.ShortTag()
+		}
+	}
+}
@@ -0,0 +1,414 @@
+package project
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/night-slayer18/goforge/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaJSON is the JSON Schema of goforge.yml, which editors use for
+// completion and 'goforge config validate' checks files against.
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// Schema returns the JSON Schema of goforge.yml.
+func Schema() []byte {
+	return slices.Clone(schemaJSON)
+}
+
+// Problem is a place where goforge.yml does not follow the schema.
+type Problem struct {
+	File    string // relative to the project's goforge.yml; empty when checking data alone
+	Line    int
+	Column  int
+	Path    string // dotted path of the value, e.g. dev.port; empty for the document
+	Message string
+	Unknown bool // the key is not in the schema, so goforge ignores it
+}
+
+func (p Problem) String() string {
+	if p.Line == 0 {
+		// Converted from TOML, without positions
+		if p.Path == "" {
+			return p.Message
+		}
+		return fmt.Sprintf("%s: %s", p.Path, p.Message)
+	}
+	if p.Path == "" {
+		return fmt.Sprintf("%d:%d: %s", p.Line, p.Column, p.Message)
+	}
+	return fmt.Sprintf("%d:%d: %s: %s", p.Line, p.Column, p.Path, p.Message)
+}
+
+// schema is the part of JSON Schema that schema.json uses.
+type schema struct {
+	Type                 string             `json:"type"`
+	Properties           map[string]*schema `json:"properties"`
+	PatternProperties    map[string]*schema `json:"patternProperties"`
+	AdditionalProperties *additional        `json:"additionalProperties"`
+	Required             []string           `json:"required"`
+	Enum                 []any              `json:"enum"`
+	Items                *schema            `json:"items"`
+	AnyOf                []*schema          `json:"anyOf"`
+	Minimum              *float64           `json:"minimum"`
+	Maximum              *float64           `json:"maximum"`
+	Ref                  string             `json:"$ref"`
+	Definitions          map[string]*schema `json:"definitions"`
+}
+
+// additional is additionalProperties: false, or the schema of the values
+// of keys not listed in properties.
+type additional struct {
+	denied bool
+	schema *schema
+}
+
+func (a *additional) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.denied = !allowed
+		return nil
+	}
+	return json.Unmarshal(data, &a.schema)
+}
+
+// loadSchema parses the embedded schema once.
+var loadSchema = sync.OnceValues(func() (*schema, error) {
+	var root schema
+	if err := json.Unmarshal(schemaJSON, &root); err != nil {
+		return nil, fmt.Errorf("invalid embedded schema: %w", err)
+	}
+	return &root, nil
+})
+
+// ConfigKey splits a dotted key of goforge.yml into its path and returns
+// the JSON Schema type of its value. The schema decides where the dots
+// are: keys of free-form mappings keep theirs, so
+// dependencies.github.com/spf13/cobra is two keys deep. Keys the schema
+// does not allow are an error; the type is empty for keys it leaves open,
+// such as those under x-.
+func ConfigKey(key string) ([]string, string, error) {
+	s, err := loadSchema()
+	if err != nil {
+		return nil, "", err
+	}
+	v := &schemaValidator{root: s}
+
+	segments := strings.Split(key, ".")
+	var path []string
+	for i := 0; i < len(segments); i++ {
+		s = v.resolve(s)
+		segment := segments[i]
+		if child, ok := s.Properties[segment]; ok {
+			path, s = append(path, segment), child
+			continue
+		}
+		if child := matchPattern(s.PatternProperties, segment); child != nil {
+			path, s = append(path, segment), child
+			continue
+		}
+		switch additional := s.AdditionalProperties; {
+		case additional != nil && additional.schema != nil && v.typeOf(additional.schema) != "object":
+			// A mapping of names to values, e.g. dependencies or scripts
+			return append(path, strings.Join(segments[i:], ".")), v.typeOf(additional.schema), nil
+		case additional != nil && additional.schema != nil:
+			path, s = append(path, segment), additional.schema
+		case additional == nil && len(s.Properties) == 0:
+			return append(path, segments[i:]...), "", nil
+		default:
+			key := strings.Join(append(path, segment), ".")
+			if suggestion := utils.Closest(segment, slices.Collect(maps.Keys(s.Properties))); suggestion != "" {
+				return nil, "", fmt.Errorf("unknown key '%s' in goforge.yml, did you mean '%s'?", key, strings.Join(append(path, suggestion), "."))
+			}
+			return nil, "", fmt.Errorf("unknown key '%s' in goforge.yml", key)
+		}
+	}
+	return path, v.typeOf(s), nil
+}
+
+// yaml11Booleans are the YAML 1.1 spellings of booleans yaml.v3 still
+// decodes into bool fields.
+var yaml11Booleans = []string{"y", "yes", "on", "n", "no", "off"}
+
+// schemaValidator collects the problems of one document.
+type schemaValidator struct {
+	root     *schema
+	source   *configSource // of the document, when it was merged from files
+	problems []Problem
+}
+
+// ValidateConfig checks the contents of a goforge.yml file against the
+// schema: unknown keys, values of the wrong type, and missing required
+// keys are reported with their lines, in the order of the file. Invalid
+// YAML is an error.
+func ValidateConfig(data []byte) ([]Problem, error) {
+	root, err := loadSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return []Problem{{Line: 1, Column: 1, Message: "the file is empty"}}, nil
+	}
+
+	v := &schemaValidator{root: root}
+	v.validate(doc.Content[0], root, "")
+	return v.sorted(), nil
+}
+
+// ValidateProject checks the project's goforge.yml like ValidateConfig,
+// after merging the files it extends and includes; each problem names the
+// file it is in. Required keys may come from any of them.
+func ValidateProject(projectRoot string) ([]Problem, error) {
+	root, err := loadSchema()
+	if err != nil {
+		return nil, err
+	}
+	source, err := loadConfigSource(ConfigPath(projectRoot))
+	if err != nil {
+		return nil, err
+	}
+
+	v := &schemaValidator{root: root, source: source}
+	v.validate(source.root, root, "")
+	return v.sorted(), nil
+}
+
+func (v *schemaValidator) report(node *yaml.Node, path, format string, args ...any) {
+	problem := Problem{Line: node.Line, Column: node.Column, Path: path, Message: fmt.Sprintf(format, args...)}
+	if v.source != nil {
+		problem.File = v.source.name(v.source.origins[node])
+	}
+	v.problems = append(v.problems, problem)
+}
+
+// reportUnknown reports key, which s does not allow, suggesting the
+// allowed key it is closest to.
+func (v *schemaValidator) reportUnknown(key *yaml.Node, s *schema, path string) {
+	message := "unknown key"
+	if suggestion := utils.Closest(key.Value, slices.Collect(maps.Keys(s.Properties))); suggestion != "" {
+		message = fmt.Sprintf("unknown key; did you mean '%s'?", suggestion)
+	}
+	v.report(key, path, "%s", message)
+	v.problems[len(v.problems)-1].Unknown = true
+}
+
+// sorted returns the problems in the order of the files.
+func (v *schemaValidator) sorted() []Problem {
+	sort.SliceStable(v.problems, func(i, j int) bool {
+		a, b := v.problems[i], v.problems[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return v.problems
+}
+
+// resolve follows a "#/definitions/<name>" reference.
+func (v *schemaValidator) resolve(s *schema) *schema {
+	if name, ok := strings.CutPrefix(s.Ref, "#/definitions/"); ok {
+		if target := v.root.Definitions[name]; target != nil {
+			return target
+		}
+	}
+	return s
+}
+
+// typeOf returns the type of values s allows; the type of the first
+// alternative for anyOf.
+func (v *schemaValidator) typeOf(s *schema) string {
+	s = v.resolve(s)
+	if s.Type == "" && len(s.AnyOf) > 0 {
+		return v.typeOf(s.AnyOf[0])
+	}
+	return s.Type
+}
+
+func (v *schemaValidator) validate(node *yaml.Node, s *schema, path string) {
+	s = v.resolve(s)
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	if node.Kind == yaml.ScalarNode && node.Tag == "!!null" {
+		return // an empty value leaves the setting unset
+	}
+	if len(s.AnyOf) > 0 {
+		// The alternatives goforge.yml uses differ in type, which picks one
+		var types []string
+		for _, option := range s.AnyOf {
+			option = v.resolve(option)
+			if option.Type == "" || matchesType(node, option.Type) {
+				v.validate(node, option, path)
+				return
+			}
+			types = append(types, describeType(option.Type))
+		}
+		v.report(node, path, "expected %s, got %s", strings.Join(types, " or "), describeNode(node))
+		return
+	}
+	if s.Type != "" && !matchesType(node, s.Type) {
+		v.report(node, path, "expected %s, got %s", describeType(s.Type), describeNode(node))
+		return
+	}
+
+	switch node.Kind {
+	case yaml.MappingNode:
+		v.validateMapping(node, s, path)
+	case yaml.SequenceNode:
+		if s.Items != nil {
+			for i, item := range node.Content {
+				v.validate(item, s.Items, fmt.Sprintf("%s[%d]", path, i))
+			}
+		}
+	case yaml.ScalarNode:
+		if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(value any) bool { return fmt.Sprint(value) == node.Value }) {
+			allowed := make([]string, len(s.Enum))
+			for i, value := range s.Enum {
+				allowed[i] = fmt.Sprint(value)
+			}
+			v.report(node, path, "'%s' is not one of %s", node.Value, strings.Join(allowed, ", "))
+		}
+		if s.Minimum != nil || s.Maximum != nil {
+			if number, err := strconv.ParseFloat(node.Value, 64); err == nil {
+				if s.Minimum != nil && number < *s.Minimum {
+					v.report(node, path, "%s is below the minimum of %g", node.Value, *s.Minimum)
+				}
+				if s.Maximum != nil && number > *s.Maximum {
+					v.report(node, path, "%s is above the maximum of %g", node.Value, *s.Maximum)
+				}
+			}
+		}
+	}
+}
+
+func (v *schemaValidator) validateMapping(node *yaml.Node, s *schema, path string) {
+	seen := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value == "<<" {
+			continue // merge keys bring in another mapping's keys
+		}
+		childPath := key.Value
+		if path != "" {
+			childPath = path + "." + key.Value
+		}
+		if first, ok := seen[key.Value]; ok {
+			v.report(key, childPath, "defined twice, first on line %d", first.Line)
+			continue
+		}
+		seen[key.Value] = key
+
+		if child, ok := s.Properties[key.Value]; ok {
+			v.validate(value, child, childPath)
+			continue
+		}
+		if child := matchPattern(s.PatternProperties, key.Value); child != nil {
+			v.validate(value, child, childPath)
+			continue
+		}
+		switch {
+		case s.AdditionalProperties == nil:
+		case s.AdditionalProperties.denied:
+			v.reportUnknown(key, s, childPath)
+		case s.AdditionalProperties.schema != nil:
+			v.validate(value, s.AdditionalProperties.schema, childPath)
+		}
+	}
+
+	for _, required := range s.Required {
+		if _, ok := seen[required]; !ok {
+			v.report(node, path, "missing required key '%s'", required)
+		}
+	}
+}
+
+// matchPattern returns the schema of the first pattern matching key.
+func matchPattern(patterns map[string]*schema, key string) *schema {
+	for pattern, s := range patterns {
+		if matched, _ := regexp.MatchString(pattern, key); matched {
+			return s
+		}
+	}
+	return nil
+}
+
+// matchesType reports whether node holds a value goforge decodes as the
+// JSON Schema type: any scalar is a string, as yaml.v3 decodes them into
+// string fields.
+func matchesType(node *yaml.Node, typ string) bool {
+	switch typ {
+	case "object":
+		return node.Kind == yaml.MappingNode
+	case "array":
+		return node.Kind == yaml.SequenceNode
+	case "string":
+		return node.Kind == yaml.ScalarNode
+	case "boolean":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!bool" || slices.Contains(yaml11Booleans, strings.ToLower(node.Value)))
+	case "integer":
+		if node.Kind != yaml.ScalarNode {
+			return false
+		}
+		if node.Tag == "!!int" {
+			return true
+		}
+		number, err := strconv.ParseFloat(node.Value, 64)
+		return node.Tag == "!!float" && err == nil && number == math.Trunc(number)
+	case "number":
+		return node.Kind == yaml.ScalarNode && (node.Tag == "!!int" || node.Tag == "!!float")
+	}
+	return true
+}
+
+// describeType names a JSON Schema type in messages.
+func describeType(typ string) string {
+	switch typ {
+	case "object":
+		return "a mapping"
+	case "array":
+		return "a list"
+	case "string":
+		return "text"
+	case "boolean":
+		return "true or false"
+	case "integer":
+		return "a whole number"
+	case "number":
+		return "a number"
+	}
+	return typ
+}
+
+// describeNode names the kind of value a node holds in messages.
+func describeNode(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.MappingNode:
+		return "a mapping"
+	case yaml.SequenceNode:
+		return "a list"
+	}
+	if node.Tag == "!!str" {
+		return fmt.Sprintf("'%s'", node.Value)
+	}
+	return node.Value
+}
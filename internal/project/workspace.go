@@ -0,0 +1,289 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"gopkg.in/yaml.v3"
+)
+
+// WorkspaceConfig marks goforge.yml as the manifest of a go.work monorepo.
+// Services keep their own goforge.yml or are declared in the manifest,
+// which also holds the scripts that span them.
+type WorkspaceConfig struct {
+	// Services lists the service directories, relative to the workspace
+	// root, or maps service names to their settings. Empty means every
+	// go.work module with its own goforge.yml.
+	Services ServiceList `yaml:"services,omitempty"`
+}
+
+// ServiceConfig declares a service in the workspace manifest. Its scripts
+// and port apply unless the service's own goforge.yml sets them; a
+// service without one is configured by them alone.
+type ServiceConfig struct {
+	Name    string  `yaml:"-"` // empty in a list of directories
+	Path    string  `yaml:"path"`
+	Scripts Scripts `yaml:"scripts,omitempty"`
+	Port    int     `yaml:"port,omitempty"`
+}
+
+// ServiceList is the services of a workspace, written as a list of
+// directories or as a mapping of names to a directory or settings:
+//
+//	services:
+//	  api: services/api
+//	  worker:
+//	    path: services/worker
+//	    port: 9100
+//	    scripts:
+//	      dev: go run ./cmd/worker
+type ServiceList []ServiceConfig
+
+func (l *ServiceList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	*l = nil
+	switch node.Kind {
+	case yaml.SequenceNode:
+		var dirs []string
+		if err := node.Decode(&dirs); err != nil {
+			return err
+		}
+		for _, dir := range dirs {
+			*l = append(*l, ServiceConfig{Path: dir})
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			name, value := node.Content[i].Value, node.Content[i+1]
+			service := ServiceConfig{Path: value.Value}
+			if value.Kind != yaml.ScalarNode {
+				if err := value.Decode(&service); err != nil {
+					return err
+				}
+			}
+			if service.Path == "" {
+				return fmt.Errorf("line %d: service '%s' needs a path", value.Line, name)
+			}
+			service.Name = name
+			*l = append(*l, service)
+		}
+	default:
+		if node.Tag != "!!null" {
+			return fmt.Errorf("line %d: workspace.services must be a list of directories or a mapping of services", node.Line)
+		}
+	}
+	return nil
+}
+
+// Workspace is a go.work monorepo and its goforge.yml, if it has one.
+type Workspace struct {
+	Root    string
+	Config  *Config  // nil without a goforge.yml next to go.work
+	Modules []string // directories of the go.work use directives, relative to Root
+}
+
+// Service is a workspace module with its own goforge.yml, or declared in
+// the workspace manifest.
+type Service struct {
+	Name   string // as declared, or the directory's base name, e.g. api
+	Dir    string // relative to the workspace root, e.g. services/api
+	Config *Config
+}
+
+// FindWorkspace looks for go.work in dir or any parent directory and
+// returns the workspace it describes, or nil when there is none.
+func FindWorkspace(dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		workFile := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(workFile); err == nil {
+			return loadWorkspace(dir, workFile)
+		}
+
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return nil, nil
+		}
+		dir = parentDir
+	}
+}
+
+// loadWorkspace parses go.work and the goforge.yml next to it.
+func loadWorkspace(root, workFile string) (*Workspace, error) {
+	data, err := os.ReadFile(workFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+	work, err := modfile.ParseWork(workFile, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	ws := &Workspace{Root: root}
+	for _, use := range work.Use {
+		ws.Modules = append(ws.Modules, filepath.ToSlash(filepath.Clean(use.Path)))
+	}
+
+	cfg, err := readConfig(ConfigPath(root))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	ws.Config = cfg
+	return ws, nil
+}
+
+// Services lists the workspace's services: those named in the workspace
+// section of goforge.yml, or else every module with its own goforge.yml.
+func (w *Workspace) Services() ([]Service, error) {
+	var declared ServiceList
+	if w.Config != nil && w.Config.Workspace != nil && len(w.Config.Workspace.Services) > 0 {
+		declared = w.Config.Workspace.Services
+	} else {
+		for _, dir := range w.Modules {
+			declared = append(declared, ServiceConfig{Path: dir})
+		}
+	}
+
+	var services []Service
+	for _, service := range declared {
+		dir := filepath.ToSlash(filepath.Clean(service.Path))
+		if dir == "." {
+			continue
+		}
+		cfg, err := readConfig(ConfigPath(filepath.Join(w.Root, filepath.FromSlash(dir))))
+		switch {
+		case os.IsNotExist(err) && service.Name == "":
+			continue
+		case os.IsNotExist(err):
+			cfg = &Config{ProjectName: service.Name, GoVersion: w.Config.GoVersion}
+			if data, err := os.ReadFile(filepath.Join(w.Root, filepath.FromSlash(dir), "go.mod")); err == nil {
+				cfg.ModuleName = modfile.ModulePath(data)
+			}
+		case err != nil:
+			return nil, err
+		}
+		service.applyTo(cfg)
+
+		name := service.Name
+		if name == "" {
+			name = filepath.Base(dir)
+		}
+		services = append(services, Service{Name: name, Dir: dir, Config: cfg})
+	}
+	sort.Slice(services, func(i, j int) bool { return services[i].Dir < services[j].Dir })
+	return services, nil
+}
+
+// FindService returns the service named by its directory's base name or
+// by its directory relative to the workspace root.
+func (w *Workspace) FindService(name string) (Service, error) {
+	services, err := w.Services()
+	if err != nil {
+		return Service{}, err
+	}
+	if len(services) == 0 {
+		return Service{}, fmt.Errorf("the workspace at %s has no services with a goforge.yml", w.Root)
+	}
+
+	dir := filepath.ToSlash(filepath.Clean(name))
+	var matches []Service
+	for _, service := range services {
+		if service.Dir == dir {
+			return service, nil
+		}
+		if service.Name == name {
+			matches = append(matches, service)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		var names []string
+		for _, service := range services {
+			names = append(names, service.Name)
+		}
+		return Service{}, fmt.Errorf("no service '%s' in the workspace (services: %s)", name, strings.Join(names, ", "))
+	default:
+		var dirs []string
+		for _, service := range matches {
+			dirs = append(dirs, service.Dir)
+		}
+		return Service{}, fmt.Errorf("several services are named '%s'; select one by its directory: %s", name, strings.Join(dirs, ", "))
+	}
+}
+
+// applyTo adds the scripts and port of the declaration to cfg, keeping
+// those cfg sets.
+func (s ServiceConfig) applyTo(cfg *Config) {
+	if len(s.Scripts) > 0 && cfg.Scripts == nil {
+		cfg.Scripts = Scripts{}
+	}
+	for name, command := range s.Scripts {
+		if _, ok := cfg.Scripts[name]; !ok {
+			cfg.Scripts[name] = command
+		}
+	}
+	if s.Port != 0 {
+		if cfg.Dev == nil {
+			cfg.Dev = &DevConfig{}
+		}
+		if cfg.Dev.Port == 0 {
+			cfg.Dev.Port = s.Port
+		}
+	}
+}
+
+// declaredService returns the service of the workspace manifest at root,
+// parsed into cfg, whose directory holds dir, and whether there is one. Only
+// services without a goforge.yml of their own are considered, as the
+// others are projects by themselves.
+func declaredService(root string, cfg *Config, dir string) (Service, bool, error) {
+	ws := &Workspace{Root: root, Config: cfg}
+	services, err := ws.Services()
+	if err != nil {
+		return Service{}, false, err
+	}
+	for _, service := range services {
+		serviceRoot := filepath.Join(root, filepath.FromSlash(service.Dir))
+		if dir != serviceRoot && !strings.HasPrefix(dir, serviceRoot+string(filepath.Separator)) {
+			continue
+		}
+		if HasConfig(serviceRoot) {
+			return Service{}, false, nil
+		}
+		return service, true, nil
+	}
+	return Service{}, false, nil
+}
+
+// IsWorkspaceRoot reports whether root is the root of a workspace rather
+// than one of its services.
+func (w *Workspace) IsWorkspaceRoot(root string) bool {
+	return w != nil && filepath.Clean(root) == filepath.Clean(w.Root)
+}
+
+// readConfig parses a goforge.yml file and the files it extends and
+// includes; the error satisfies os.IsNotExist when there is none.
+func readConfig(configPath string) (*Config, error) {
+	source, err := loadConfigSource(configPath)
+	if os.IsNotExist(err) {
+		return nil, err
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	var cfg Config
+	if err := source.root.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,255 @@
+package runner
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// Hosts of 'goforge new --create-remote'.
+const (
+	GitHub = "github"
+	GitLab = "gitlab"
+)
+
+// Remote is a repository to create on GitHub or GitLab.
+type Remote struct {
+	Host   string // GitHub or GitLab
+	Owner  string // Organization or group; the authenticated user when empty
+	Name   string
+	Public bool
+}
+
+// ParseRemote parses "github[:org]" or "gitlab[:group]" into a Remote
+// without a name.
+func ParseRemote(spec string) (Remote, error) {
+	host, owner, hasOwner := strings.Cut(spec, ":")
+	host = strings.ToLower(host)
+	if host != GitHub && host != GitLab {
+		return Remote{}, fmt.Errorf("unknown remote host '%s' (use github[:org] or gitlab[:group])", host)
+	}
+	if hasOwner && owner == "" {
+		return Remote{}, fmt.Errorf("missing organization or group after '%s:'", host)
+	}
+	return Remote{Host: host, Owner: owner}, nil
+}
+
+// fullName returns owner/name, or the name alone for the user's account.
+func (r Remote) fullName() string {
+	if r.Owner == "" {
+		return r.Name
+	}
+	return r.Owner + "/" + r.Name
+}
+
+// CreateRemote creates the repository, adds it as the origin of the git
+// repository in dir, and pushes the current branch to it. The host's CLI,
+// gh or glab, is used when installed, and its API with GITHUB_TOKEN
+// (or GH_TOKEN) or GITLAB_TOKEN otherwise. It returns the repository's
+// web address.
+func CreateRemote(dir string, remote Remote) (string, error) {
+	if !isGitRepository(dir) {
+		return "", fmt.Errorf("%s is not a git repository", dir)
+	}
+	if origin, err := gitOutput(dir, "remote", "get-url", "origin"); err == nil {
+		return "", fmt.Errorf("the repository already has an origin remote (%s)", origin)
+	}
+
+	var webURL, cloneURL string
+	var err error
+	switch {
+	case remote.Host == GitHub && isCommandAvailable("gh"):
+		webURL, err = createWithCLI(dir, "gh", "repo", "create", remote.fullName(), visibilityFlag(remote), "--source", ".", "--remote", "origin")
+	case remote.Host == GitLab && isCommandAvailable("glab"):
+		webURL, err = createWithCLI(dir, "glab", "repo", "create", remote.fullName(), visibilityFlag(remote))
+		cloneURL = webURL + ".git"
+	case remote.Host == GitHub:
+		webURL, cloneURL, err = createGitHubRepository(remote)
+	default:
+		webURL, cloneURL, err = createGitLabRepository(remote)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	// gh adds the remote itself, glab only inside some repositories
+	if _, err := gitOutput(dir, "remote", "get-url", "origin"); err != nil {
+		if cloneURL == "" {
+			return webURL, fmt.Errorf("created %s but could not tell its clone URL; add it with: git remote add origin <url>", webURL)
+		}
+		if _, err := gitOutput(dir, "remote", "add", "origin", cloneURL); err != nil {
+			return webURL, fmt.Errorf("created %s but could not add it as origin: %w", webURL, err)
+		}
+	}
+
+	opts := DefaultOptions()
+	opts.Dir = dir
+	opts.ShowOutput = false
+	if err := ExecuteCommandWithOptions("git", []string{"push", "-u", "origin", "HEAD"}, opts); err != nil {
+		return webURL, fmt.Errorf("created %s but could not push to it: %w\n\nPush it yourself with: git push -u origin HEAD", webURL, err)
+	}
+	return webURL, nil
+}
+
+// visibilityFlag returns the visibility flag of gh and glab.
+func visibilityFlag(remote Remote) string {
+	if remote.Public {
+		return "--public"
+	}
+	return "--private"
+}
+
+// repositoryURL matches the web address printed by gh and glab.
+var repositoryURL = regexp.MustCompile(`https?://\S+`)
+
+// createWithCLI creates a repository with gh or glab and returns the web
+// address they print.
+func createWithCLI(dir, name string, args ...string) (string, error) {
+	logger.CommandStart(name, args...)
+	cmd := exec.Command(name, args...)
+	cmd.Dir = dir
+	cmd.Env = Environ()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("'%s %s' failed: %s", name, strings.Join(args[:2], " "), strings.TrimSpace(stderr.String()))
+	}
+	found := repositoryURL.FindString(string(out) + stderr.String())
+	if found == "" {
+		return "", fmt.Errorf("'%s %s' did not print the repository address", name, strings.Join(args[:2], " "))
+	}
+	return strings.TrimSuffix(found, ".git"), nil
+}
+
+// createGitHubRepository creates the repository through the GitHub API.
+func createGitHubRepository(remote Remote) (string, string, error) {
+	token := os.Getenv("GH_TOKEN")
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+	if token == "" {
+		return "", "", fmt.Errorf("creating a GitHub repository needs the gh CLI (https://cli.github.com) or a token in GITHUB_TOKEN")
+	}
+
+	endpoint := "https://api.github.com/user/repos"
+	if remote.Owner != "" {
+		endpoint = "https://api.github.com/orgs/" + url.PathEscape(remote.Owner) + "/repos"
+	}
+	headers := map[string]string{
+		"Authorization": "Bearer " + token,
+		"Accept":        "application/vnd.github+json",
+	}
+	var created struct {
+		HTMLURL  string `json:"html_url"`
+		CloneURL string `json:"clone_url"`
+	}
+	body := map[string]any{"name": remote.Name, "private": !remote.Public}
+	if err := apiRequest(http.MethodPost, endpoint, headers, body, &created); err != nil {
+		return "", "", fmt.Errorf("failed to create the GitHub repository %s: %w", remote.fullName(), err)
+	}
+	return created.HTMLURL, created.CloneURL, nil
+}
+
+// createGitLabRepository creates the project through the GitLab API of
+// GITLAB_HOST, gitlab.com by default.
+func createGitLabRepository(remote Remote) (string, string, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return "", "", fmt.Errorf("creating a GitLab project needs the glab CLI (https://gitlab.com/gitlab-org/cli) or a token in GITLAB_TOKEN")
+	}
+	base := strings.TrimSuffix(os.Getenv("GITLAB_HOST"), "/")
+	if base == "" {
+		base = "https://gitlab.com"
+	} else if !strings.Contains(base, "://") {
+		base = "https://" + base
+	}
+	headers := map[string]string{"PRIVATE-TOKEN": token}
+
+	visibility := "private"
+	if remote.Public {
+		visibility = "public"
+	}
+	body := map[string]any{"name": remote.Name, "path": remote.Name, "visibility": visibility}
+	if remote.Owner != "" {
+		var namespace struct {
+			ID int `json:"id"`
+		}
+		if err := apiRequest(http.MethodGet, base+"/api/v4/namespaces/"+url.PathEscape(remote.Owner), headers, nil, &namespace); err != nil {
+			return "", "", fmt.Errorf("failed to look up the GitLab group %s: %w", remote.Owner, err)
+		}
+		body["namespace_id"] = namespace.ID
+	}
+
+	var created struct {
+		WebURL  string `json:"web_url"`
+		HTTPURL string `json:"http_url_to_repo"`
+	}
+	if err := apiRequest(http.MethodPost, base+"/api/v4/projects", headers, body, &created); err != nil {
+		return "", "", fmt.Errorf("failed to create the GitLab project %s: %w", remote.fullName(), err)
+	}
+	return created.WebURL, created.HTTPURL, nil
+}
+
+// apiRequest sends body as JSON and decodes the JSON response into
+// result. Error responses are reported with their message.
+func apiRequest(method, endpoint string, headers map[string]string, body, result any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, endpoint, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	logger.Debug("%s %s", method, endpoint)
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 300 {
+		var failure struct {
+			Message any `json:"message"`
+		}
+		if json.Unmarshal(data, &failure) == nil && failure.Message != nil {
+			return fmt.Errorf("%s: %v", resp.Status, failure.Message)
+		}
+		return fmt.Errorf("%s", resp.Status)
+	}
+	return json.Unmarshal(data, result)
+}
+
+// gitOutput runs git in dir and returns its trimmed output, without
+// logging failures, which callers use as answers.
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	return strings.TrimSpace(string(out)), err
+}
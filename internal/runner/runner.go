@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/night-slayer18/goforge/internal/exitcode"
 	"github.com/night-slayer18/goforge/internal/logger"
 )
 
@@ -22,11 +23,50 @@ type CommandOptions struct {
 	ShowCommand bool
 }
 
+// offline makes go commands resolve modules from the module cache only.
+var offline bool
+
+// SetOffline makes the go commands of every runner invocation work
+// without network access, with GOPROXY=off.
+func SetOffline(enabled bool) {
+	offline = enabled
+}
+
+// Environ returns the environment of runner invocations: the process
+// environment, so GOFLAGS, GOPROXY, GOPRIVATE and friends set for a
+// corporate proxy apply to every go command, with GOPROXY=off when
+// offline.
+func Environ() []string {
+	env := os.Environ()
+	if offline {
+		env = append(env, "GOPROXY=off")
+	}
+	return env
+}
+
+// goProxySettings describes the module proxy settings in effect, for
+// troubleshooting messages.
+func goProxySettings() string {
+	var settings []string
+	for _, key := range []string{"GOPROXY", "GOFLAGS", "GOPRIVATE", "GONOSUMDB"} {
+		if value := os.Getenv(key); value != "" {
+			settings = append(settings, key+"="+value)
+		}
+	}
+	if offline {
+		settings = append(settings, "GOPROXY=off (--offline)")
+	}
+	if len(settings) == 0 {
+		return "Go defaults"
+	}
+	return strings.Join(settings, " ")
+}
+
 // DefaultOptions returns sensible default options
 func DefaultOptions() *CommandOptions {
 	return &CommandOptions{
 		Dir:         "",
-		Env:         os.Environ(),
+		Env:         Environ(),
 		Timeout:     5 * time.Minute,
 		ShowOutput:  true,
 		ShowCommand: true,
@@ -84,18 +124,18 @@ func ExecuteCommandWithOptions(name string, args []string, opts *CommandOptions)
 		// Check for timeout
 		if ctx.Err() == context.DeadlineExceeded {
 			logger.CommandError(name, fmt.Errorf("command timed out after %v", opts.Timeout), duration)
-			return fmt.Errorf("command '%s' timed out after %v", name, opts.Timeout)
+			return exitcode.Errorf(exitcode.Tool, "command '%s' timed out after %v", name, opts.Timeout)
 		}
 		
 		// Check for exit code
 		if exitError, ok := err.(*exec.ExitError); ok {
 			exitCode := exitError.ExitCode()
 			logger.CommandError(name, fmt.Errorf("exit code %d", exitCode), duration)
-			return fmt.Errorf("command '%s' failed with exit code %d", name, exitCode)
+			return exitcode.Errorf(exitcode.Tool, "command '%s' failed with exit code %d", name, exitCode)
 		}
 		
 		logger.CommandError(name, err, duration)
-		return fmt.Errorf("command '%s' failed: %w", name, err)
+		return exitcode.Errorf(exitcode.Tool, "command '%s' failed: %w", name, err)
 	}
 	
 	logger.CommandSuccess(name, duration)
@@ -120,6 +160,7 @@ func ExecuteCommandWithOutput(dir, name string, args ...string) (string, error)
 	
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
+	cmd.Env = Environ()
 	
 	output, err := cmd.Output()
 	duration := time.Since(start)
@@ -128,10 +169,10 @@ func ExecuteCommandWithOutput(dir, name string, args ...string) (string, error)
 		if exitError, ok := err.(*exec.ExitError); ok {
 			stderr := string(exitError.Stderr)
 			logger.CommandError(name, fmt.Errorf("exit code %d: %s", exitError.ExitCode(), stderr), duration)
-			return "", fmt.Errorf("command '%s' failed: %s", name, stderr)
+			return "", exitcode.Errorf(exitcode.Tool, "command '%s' failed: %s", name, stderr)
 		}
 		logger.CommandError(name, err, duration)
-		return "", err
+		return "", exitcode.Wrap(exitcode.Tool, err)
 	}
 	
 	logger.CommandSuccess(name, duration)
@@ -149,6 +190,7 @@ type StreamingExecutor struct {
 func NewStreamingExecutor(dir, name string, args ...string) (*StreamingExecutor, error) {
 	cmd := exec.Command(name, args...)
 	cmd.Dir = dir
+	cmd.Env = Environ()
 	
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
@@ -179,17 +221,19 @@ func (se *StreamingExecutor) Start() error {
 	
 	// Stream stdout
 	go func() {
+		log := logger.WithFields(logger.Fields{"command": se.cmd.Path, "pid": se.cmd.Process.Pid, "stream": "stdout"})
 		scanner := bufio.NewScanner(se.stdout)
 		for scanner.Scan() {
-			logger.Info("📤 %s", scanner.Text())
+			log.Info("📤 %s", scanner.Text())
 		}
 	}()
 	
 	// Stream stderr
 	go func() {
+		log := logger.WithFields(logger.Fields{"command": se.cmd.Path, "pid": se.cmd.Process.Pid, "stream": "stderr"})
 		scanner := bufio.NewScanner(se.stderr)
 		for scanner.Scan() {
-			logger.Error("📥 %s", scanner.Text())
+			log.Error("📥 %s", scanner.Text())
 		}
 	}()
 	
@@ -204,9 +248,9 @@ func (se *StreamingExecutor) Wait() error {
 	err := se.cmd.Wait()
 	if err != nil {
 		if exitError, ok := err.(*exec.ExitError); ok {
-			return fmt.Errorf("command failed with exit code %d", exitError.ExitCode())
+			return exitcode.Errorf(exitcode.Tool, "command failed with exit code %d", exitError.ExitCode())
 		}
-		return fmt.Errorf("command failed: %w", err)
+		return exitcode.Errorf(exitcode.Tool, "command failed: %w", err)
 	}
 	
 	return nil
@@ -263,7 +307,7 @@ func TidyGoModuleWithVerbose(dir string, verbose bool) error {
 			logger.Error("Failed to install dependencies")
 			logger.Info("💡 Run with --verbose flag to see detailed output")
 		}
-		return fmt.Errorf("failed to tidy Go module: %w\n\nTroubleshooting:\n  • Check your internet connection\n  • Verify go.mod file is valid\n  • Ensure dependencies are accessible\n  • Try running 'go mod tidy' manually for more details", err)
+		return fmt.Errorf("failed to tidy Go module: %w\n\nTroubleshooting:\n  • Check your internet connection\n  • Behind a proxy, set GOPROXY and GOFLAGS (now: %s)\n  • Verify go.mod file is valid\n  • Ensure dependencies are accessible\n  • Try running 'go mod tidy' manually for more details\n  • Create the project with --offline and tidy it later", err, goProxySettings())
 	}
 	
 	if !verbose {
@@ -284,7 +328,7 @@ func InitGitRepository(dir string) error {
 	
 	// Check if Git is available
 	if !isCommandAvailable("git") {
-		return fmt.Errorf("git is not installed or not available in PATH")
+		return exitcode.Errorf(exitcode.Tool, "git is not installed or not available in PATH")
 	}
 	
 	// Check if already a Git repository
@@ -368,7 +412,7 @@ func InstallDependency(dir, module string) error {
 	
 	err := ExecuteCommandWithOptions("go", []string{"get", module}, opts)
 	if err != nil {
-		return fmt.Errorf("failed to install dependency '%s': %w\n\nTroubleshooting:\n  • Check your internet connection\n  • Verify the module path is correct\n  • Ensure the module version exists\n  • Check if the module requires authentication", module, err)
+		return fmt.Errorf("failed to install dependency '%s': %w\n\nTroubleshooting:\n  • Check your internet connection\n  • Behind a proxy, set GOPROXY and GOFLAGS (now: %s)\n  • Verify the module path is correct\n  • Ensure the module version exists\n  • Check if the module requires authentication", module, err, goProxySettings())
 	}
 	
 	logger.DependencyAdded(module)
@@ -396,38 +440,10 @@ func BuildBinary(dir, outputPath, entrypoint string) error {
 	
 	err := ExecuteCommandWithOptions("go", args, opts)
 	if err != nil {
-		return fmt.Errorf("failed to build binary: %w\n\nTroubleshooting:\n  • Check for compilation errors above\n  • Ensure all dependencies are available\n  • Verify the entry point path is correct", err)
+		return exitcode.Errorf(exitcode.Build, "failed to build binary: %w\n\nTroubleshooting:\n  • Check for compilation errors above\n  • Ensure all dependencies are available\n  • Verify the entry point path is correct", err)
 	}
 	
 	duration := time.Since(start)
 	logger.BuildComplete(outputPath, duration)
 	return nil
 }
-
-// RunTests executes Go tests with enhanced output
-func RunTests(dir string, packages ...string) error {
-	logger.Info("🧪 Running tests...")
-	start := time.Now()
-	
-	args := []string{"test", "-v"}
-	if len(packages) > 0 {
-		args = append(args, packages...)
-	} else {
-		args = append(args, "./...")
-	}
-	
-	opts := DefaultOptions()
-	opts.Dir = dir
-	opts.Timeout = 10 * time.Minute
-	
-	err := ExecuteCommandWithOptions("go", args, opts)
-	duration := time.Since(start)
-	
-	if err != nil {
-		logger.Error("❌ Tests failed after %v", duration.Round(time.Millisecond))
-		return fmt.Errorf("tests failed: %w", err)
-	}
-	
-	logger.Success("✅ All tests passed in %v", duration.Round(time.Millisecond))
-	return nil
-}
\ No newline at end of file
@@ -0,0 +1,56 @@
+package runner
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// Tool is a Go program goforge runs at a pinned version, installed apart
+// from the user's own copy so every checkout gets the same results.
+type Tool struct {
+	Name    string // name of the binary, e.g. golangci-lint
+	Package string // package path to install, e.g. github.com/golangci/golangci-lint/v2/cmd/golangci-lint
+	Version string // module version, e.g. v2.5.0
+}
+
+// Path returns where the tool's version is installed: a directory of its
+// own in the user cache, e.g. ~/.cache/goforge/tools/golangci-lint@v2.5.0.
+func (t Tool) Path() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("cannot locate the user cache directory: %w", err)
+	}
+	name := t.Name
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return filepath.Join(cacheDir, "goforge", "tools", t.Name+"@"+t.Version, name), nil
+}
+
+// EnsureTool returns the path of the tool, installing its version with
+// 'go install' on first use.
+func EnsureTool(t Tool) (string, error) {
+	toolPath, err := t.Path()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(toolPath); err == nil {
+		return toolPath, nil
+	}
+
+	logger.Info("📦 Installing %s %s...", t.Name, t.Version)
+	opts := DefaultOptions()
+	opts.Env = append(opts.Env, "GOBIN="+filepath.Dir(toolPath))
+	opts.Timeout = 0
+	if err := ExecuteCommandWithOptions("go", []string{"install", t.Package + "@" + t.Version}, opts); err != nil {
+		return "", fmt.Errorf("failed to install %s %s: %w", t.Name, t.Version, err)
+	}
+	if _, err := os.Stat(toolPath); err != nil {
+		return "", fmt.Errorf("installed %s but did not find it at %s", t.Name, toolPath)
+	}
+	return toolPath, nil
+}
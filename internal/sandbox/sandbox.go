@@ -0,0 +1,308 @@
+// Package sandbox runs project scripts against a throwaway copy of the
+// project, so destructive scripts can be tried without touching the
+// working tree. Changes made in the copy can be listed, diffed, and
+// applied back afterwards.
+package sandbox
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/diff"
+)
+
+// DataDir is the sandbox's private data directory, relative to its root.
+// It is excluded from the changes reported back.
+const DataDir = ".goforge/sandbox-data"
+
+// skipDirs are never copied into a sandbox.
+var skipDirs = map[string]bool{
+	".git":           true,
+	".goforge/watch": true,
+	DataDir:          true,
+}
+
+// Change kinds reported by Changes.
+const (
+	Added    = "added"
+	Modified = "modified"
+	Deleted  = "deleted"
+)
+
+// Change is a file that differs between the sandbox and the project.
+type Change struct {
+	Path string // Slash-separated, relative to the project root
+	Kind string
+}
+
+// Sandbox is a copy of a project in a temporary directory.
+type Sandbox struct {
+	ProjectRoot string
+	Root        string
+	Port        int
+}
+
+// New copies projectRoot into a fresh temporary directory and reserves a
+// free port for the sandboxed run.
+func New(projectRoot string) (*Sandbox, error) {
+	root, err := os.MkdirTemp("", "goforge-sandbox-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sandbox: %w", err)
+	}
+
+	sb := &Sandbox{ProjectRoot: projectRoot, Root: root}
+	if err := copyTree(projectRoot, root); err != nil {
+		sb.Remove()
+		return nil, fmt.Errorf("failed to copy project into sandbox: %w", err)
+	}
+	if err := os.MkdirAll(sb.DataPath(), os.ModePerm); err != nil {
+		sb.Remove()
+		return nil, err
+	}
+
+	sb.Port, err = freePort()
+	if err != nil {
+		sb.Remove()
+		return nil, fmt.Errorf("failed to reserve a port: %w", err)
+	}
+	return sb, nil
+}
+
+// DataPath returns the absolute path of the sandbox data directory.
+func (sb *Sandbox) DataPath() string {
+	return filepath.Join(sb.Root, filepath.FromSlash(DataDir))
+}
+
+// Env returns the variables that point a script at the sandbox's port and
+// data directory instead of the project's.
+func (sb *Sandbox) Env() []string {
+	return []string{
+		"GOFORGE_SANDBOX=1",
+		"GOFORGE_SANDBOX_ROOT=" + sb.Root,
+		"GOFORGE_PROJECT_ROOT=" + sb.Root,
+		"GOFORGE_DATA_DIR=" + sb.DataPath(),
+		"PORT=" + strconv.Itoa(sb.Port),
+		"TMPDIR=" + sb.DataPath(),
+	}
+}
+
+// Remove deletes the sandbox.
+func (sb *Sandbox) Remove() error {
+	return os.RemoveAll(sb.Root)
+}
+
+// Changes compares the sandbox with the project and returns the files the
+// script added, modified, or deleted, sorted by path.
+func (sb *Sandbox) Changes() ([]Change, error) {
+	before, err := listFiles(sb.ProjectRoot)
+	if err != nil {
+		return nil, err
+	}
+	after, err := listFiles(sb.Root)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []Change
+	for path := range after {
+		if _, ok := before[path]; !ok {
+			changes = append(changes, Change{Path: path, Kind: Added})
+			continue
+		}
+		same, err := sameContent(filepath.Join(sb.ProjectRoot, path), filepath.Join(sb.Root, path))
+		if err != nil {
+			return nil, err
+		}
+		if !same {
+			changes = append(changes, Change{Path: path, Kind: Modified})
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changes = append(changes, Change{Path: path, Kind: Deleted})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes, nil
+}
+
+// Diff returns a unified diff of the changes. Binary files are only named.
+func (sb *Sandbox) Diff(changes []Change) (string, error) {
+	var b strings.Builder
+	for _, change := range changes {
+		var before, after []byte
+		var err error
+		if change.Kind != Added {
+			if before, err = os.ReadFile(filepath.Join(sb.ProjectRoot, change.Path)); err != nil {
+				return "", err
+			}
+		}
+		if change.Kind != Deleted {
+			if after, err = os.ReadFile(filepath.Join(sb.Root, change.Path)); err != nil {
+				return "", err
+			}
+		}
+
+		if isBinary(before) || isBinary(after) {
+			fmt.Fprintf(&b, "Binary file %s %s\n", change.Path, change.Kind)
+			continue
+		}
+		b.WriteString(diff.Unified(string(before), string(after), "a/"+change.Path, "b/"+change.Path, 3))
+	}
+	return b.String(), nil
+}
+
+// Apply copies the changes back into the project.
+func (sb *Sandbox) Apply(changes []Change) error {
+	for _, change := range changes {
+		target := filepath.Join(sb.ProjectRoot, filepath.FromSlash(change.Path))
+		if change.Kind == Deleted {
+			if err := os.Remove(target); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		if err := copyFile(filepath.Join(sb.Root, filepath.FromSlash(change.Path)), target); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// copyTree copies src into dst, skipping skipDirs. Symlinks are recreated
+// rather than followed.
+func copyTree(src, dst string) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." {
+			return nil
+		}
+		if d.IsDir() && skipDirs[filepath.ToSlash(rel)] {
+			return filepath.SkipDir
+		}
+
+		target := filepath.Join(dst, rel)
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, os.ModePerm)
+		case d.Type()&fs.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case d.Type().IsRegular():
+			return copyFile(path, target)
+		}
+		return nil // sockets, devices, and pipes are not copied
+	})
+}
+
+// listFiles returns the regular files under root as slash-separated
+// relative paths, skipping skipDirs.
+func listFiles(root string) (map[string]bool, error) {
+	files := make(map[string]bool)
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			if skipDirs[rel] {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.Type().IsRegular() {
+			files[rel] = true
+		}
+		return nil
+	})
+	return files, err
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+func sameContent(a, b string) (bool, error) {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false, err
+	}
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false, err
+	}
+	if infoA.Size() != infoB.Size() || infoA.Mode().Perm() != infoB.Mode().Perm() {
+		return false, nil
+	}
+
+	dataA, err := os.ReadFile(a)
+	if err != nil {
+		return false, err
+	}
+	dataB, err := os.ReadFile(b)
+	if err != nil {
+		return false, err
+	}
+	return bytes.Equal(dataA, dataB), nil
+}
+
+// isBinary guesses whether data is binary by looking for NUL bytes.
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// freePort asks the kernel for an unused TCP port.
+func freePort() (int, error) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, err
+	}
+	defer l.Close()
+	return l.Addr().(*net.TCPAddr).Port, nil
+}
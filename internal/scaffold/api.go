@@ -0,0 +1,547 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/textproto"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// apiSpec places the code generated from an OpenAPI document; override it
+// with layout.api in goforge.yml.
+var apiSpec = ComponentSpec{Type: "api", Dir: "internal/adapters/http/api"}
+
+// apiMapFile records the operations stubs were generated for, next to the
+// generated code.
+const apiMapFile = "openapi-map.yml"
+
+// apiMapHeader explains the mapping file to whoever opens it.
+const apiMapHeader = `# Operations 'goforge g api' has generated handler stubs for. Rerunning it
+# only adds stubs for operations missing here. Rename a handler by changing
+# it here and in its file; delete an entry to have its stub generated again.
+`
+
+// pathParamPattern matches OpenAPI path parameters such as {id}.
+var pathParamPattern = regexp.MustCompile(`\{([^}/]+)\}`)
+
+// apiMap is the mapping file's content.
+type apiMap struct {
+	Spec       string                 `yaml:"spec"`
+	Operations map[string]apiMapEntry `yaml:"operations"`
+}
+
+type apiMapEntry struct {
+	Method  string `yaml:"method"`
+	Path    string `yaml:"path"`
+	Handler string `yaml:"handler"`
+	File    string `yaml:"file"`
+}
+
+// apiOperation is an operation as seen by the api templates.
+type apiOperation struct {
+	ID        string // operationId, or derived from the method and path
+	Method    string // e.g. GET
+	Path      string // e.g. /users/{id}
+	GinPath   string // e.g. /users/:id
+	Handler   string // Handler method, e.g. GetUser
+	File      string // file of the stub, e.g. users_handler.go
+	Summary   string // comment lines
+	Params    string // parameters struct, or ""
+	Body      string // request body type, or ""
+	Signature string // e.g. "c *gin.Context, params GetUserParams"
+	Args      string // e.g. "c, params"
+	Status    string // success status, e.g. http.StatusOK
+	Response  string // success response type, or ""
+}
+
+// GenerateAPI generates typed models, handler stubs and route registration
+// from an OpenAPI 3 document. The models and routes are regenerated on
+// every run; stubs are only added for operations the mapping file does
+// not list yet, so implemented handlers are never touched.
+func GenerateAPI(specFile string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if err := requireGin(cfg, "goforge generate api"); err != nil {
+		return err
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, apiSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	absSpec, err := filepath.Abs(specFile)
+	if err != nil {
+		return err
+	}
+	spec := filepath.ToSlash(relativeTo(projectRoot, absSpec))
+	doc, err := loadOpenAPI(absSpec)
+	if err != nil {
+		return err
+	}
+
+	types := newTypeBuilder(doc)
+	if err := types.declareComponents(); err != nil {
+		return fmt.Errorf("%s: %w", spec, err)
+	}
+	operations, err := apiOperations(doc, types)
+	if err != nil {
+		return fmt.Errorf("%s: %w", spec, err)
+	}
+
+	mapPath := filepath.Join(projectRoot, filepath.FromSlash(dir), apiMapFile)
+	mapping, err := loadAPIMap(mapPath)
+	if err != nil {
+		return err
+	}
+	mapping.Spec = spec
+
+	// Operations keep the handler and file recorded for them
+	var added []*apiOperation
+	current := make(map[string]bool, len(operations))
+	handlers := make(map[string]string, len(operations))
+	for _, op := range operations {
+		current[op.ID] = true
+		if entry, ok := mapping.Operations[op.ID]; ok {
+			op.Handler, op.File = entry.Handler, entry.File
+		} else {
+			added = append(added, op)
+		}
+		if other, ok := handlers[op.Handler]; ok {
+			return fmt.Errorf("%s: operations %s and %s both map to the handler %s; rename one in %s", spec, other, op.ID, op.Handler, path.Join(dir, apiMapFile))
+		}
+		handlers[op.Handler] = op.ID
+		mapping.Operations[op.ID] = apiMapEntry{Method: op.Method, Path: op.Path, Handler: op.Handler, File: op.File}
+	}
+	var removed []string
+	for id, entry := range mapping.Operations {
+		if !current[id] {
+			removed = append(removed, fmt.Sprintf("%s (%s in %s)", id, entry.Handler, entry.File))
+			delete(mapping.Operations, id)
+		}
+	}
+	sort.Strings(removed)
+
+	hasParams := false
+	for _, op := range operations {
+		hasParams = hasParams || op.Params != ""
+	}
+	data := TemplateData{
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"spec":       spec,
+			"types":      types.decls,
+			"usesTime":   types.usesTime(),
+			"operations": operations,
+			"hasParams":  hasParams,
+		},
+	}
+
+	logger.ComponentGenerationStart("api", spec)
+
+	var written []string
+	render := func(template, file string, data TemplateData) (FileGenerationTask, []byte, error) {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/api", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(dir), file),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		return task, content, err
+	}
+	write := func(template, file string, data TemplateData, onConflict string) error {
+		task, content, err := render(template, file, data)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// The models and routes are generated code, rewritten from the spec
+	for _, file := range []struct{ template, target string }{
+		{"models.go.tpl", "models.gen.go"},
+		{"routes.go.tpl", "routes.gen.go"},
+	} {
+		if err := write(file.template, file.target, data, ConflictOverwrite); err != nil {
+			return err
+		}
+	}
+	if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(dir), "handler.go")); os.IsNotExist(err) {
+		if err := write("handler.go.tpl", "handler.go", data, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	// New stubs go into their tag's file, which is created or appended to
+	byFile := make(map[string][]*apiOperation)
+	var files []string
+	for _, op := range added {
+		if _, ok := byFile[op.File]; !ok {
+			files = append(files, op.File)
+		}
+		byFile[op.File] = append(byFile[op.File], op)
+	}
+	for _, file := range files {
+		fileData := data
+		fileData.Vars = map[string]any{"operations": byFile[file]}
+		target := filepath.Join(projectRoot, filepath.FromSlash(dir), file)
+		if _, err := os.Stat(target); os.IsNotExist(err) {
+			if err := write("operations.go.tpl", file, fileData, genOptions.OnConflict); err != nil {
+				return err
+			}
+			continue
+		}
+		_, content, err := render("operations.go.tpl", file, fileData)
+		if err != nil {
+			return err
+		}
+		if err := appendDecls(target, content); err != nil {
+			return fmt.Errorf("could not add the new handlers to %s: %w", relativeTo(projectRoot, target), err)
+		}
+		written = append(written, target)
+	}
+
+	if err := saveAPIMap(mapPath, mapping); err != nil {
+		return err
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if _, ok := cfg.Scripts["api:generate"]; !ok {
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", "api:generate"}, "goforge g api --from "+spec); err != nil {
+			logger.Warn("Could not add the api:generate script to goforge.yml: %v", err)
+		}
+	}
+
+	for _, op := range removed {
+		logger.Warn("⚠️  Operation %s is no longer in %s; delete its handler", op, spec)
+	}
+	if len(added) > 0 {
+		logger.Info("🧩 Added %d handler stub(s)", len(added))
+	} else {
+		logger.Info("✔️  Every operation already has a handler")
+	}
+
+	logger.ComponentGenerationComplete("api", spec, filepath.Join(projectRoot, filepath.FromSlash(dir)))
+
+	pkg := data.PackageName
+	group := "router"
+	if prefix := serverBasePath(doc); prefix != "" {
+		group = fmt.Sprintf("router.Group(%q)", prefix)
+	}
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Register the routes in main:")
+	logger.Info("        %s.RegisterRoutes(%s, %s.NewHandler())", pkg, group, pkg)
+	logger.Info("   2. Implement the handler stubs in %s", dir)
+	logger.Info("   3. After changing %s, regenerate with: goforge run api:generate", spec)
+
+	return nil
+}
+
+// apiOperations lists the document's operations in path order, declaring
+// types for their parameters and inline bodies.
+func apiOperations(doc *openAPIDoc, types *typeBuilder) ([]*apiOperation, error) {
+	var operations []*apiOperation
+	ids := make(map[string]bool)
+	for _, route := range doc.Paths.Keys {
+		item := doc.Paths.Values[route]
+		if item == nil || !strings.HasPrefix(route, "/") {
+			continue
+		}
+		for _, entry := range item.operations() {
+			op, err := apiOperationFor(doc, types, route, item, entry)
+			if err != nil {
+				return nil, fmt.Errorf("%s %s: %w", entry.Method, route, err)
+			}
+			if ids[op.ID] {
+				return nil, fmt.Errorf("operationId '%s' is used by more than one operation", op.ID)
+			}
+			ids[op.ID] = true
+			operations = append(operations, op)
+		}
+	}
+	return operations, nil
+}
+
+// apiOperationFor builds the template view of one operation.
+func apiOperationFor(doc *openAPIDoc, types *typeBuilder, route string, item *openAPIPathItem, entry methodOperation) (*apiOperation, error) {
+	spec := entry.Operation
+	op := &apiOperation{
+		ID:      spec.OperationID,
+		Method:  entry.Method,
+		Path:    route,
+		GinPath: pathParamPattern.ReplaceAllString(route, ":$1"),
+		Summary: commentLines(spec.Summary),
+		Status:  "http.StatusOK",
+	}
+	if op.ID == "" {
+		op.ID = deriveOperationID(entry.Method, route)
+	}
+	op.Handler = strcase.ToCamel(op.ID)
+	if !token.IsIdentifier(op.Handler) {
+		return nil, fmt.Errorf("operationId '%s' does not make a Go method name", op.ID)
+	}
+	tag := "default"
+	if len(spec.Tags) > 0 {
+		tag = spec.Tags[0]
+	}
+	op.File = strcase.ToSnake(tag) + "_handler.go"
+
+	signature := []string{"c *gin.Context"}
+	args := []string{"c"}
+
+	// Operation parameters override path-level ones of the same name
+	params := make(map[string]*openAPIParameter)
+	var order []string
+	for _, list := range [][]*openAPIParameter{item.Parameters, spec.Parameters} {
+		for _, raw := range list {
+			param, err := doc.parameter(raw)
+			if err != nil {
+				return nil, err
+			}
+			key := param.In + ":" + param.Name
+			if _, ok := params[key]; !ok {
+				order = append(order, key)
+			}
+			params[key] = param
+		}
+	}
+	if len(order) > 0 {
+		decl := &goTypeDecl{Name: types.unique(op.Handler + "Params")}
+		decl.Doc = fmt.Sprintf("// %s holds the parameters of %s.", decl.Name, op.ID)
+		for _, key := range order {
+			param := params[key]
+			tagName, name := "", param.Name
+			switch param.In {
+			case "path":
+				tagName = "uri"
+				param.Required = true
+			case "query":
+				tagName = "form"
+			case "header":
+				tagName, name = "header", textproto.CanonicalMIMEHeaderKey(param.Name)
+			default:
+				logger.Warn("⚠️  %s: %s parameter '%s' is not bound; read it from the gin context", op.ID, param.In, param.Name)
+				continue
+			}
+			fieldName := strcase.ToCamel(param.Name)
+			schema := param.Schema
+			if schema == nil {
+				schema = &openAPISchema{Type: schemaType{Name: "string"}}
+			}
+			goType, err := types.goType(schema, op.Handler+fieldName)
+			if err != nil {
+				return nil, fmt.Errorf("parameter %s: %w", param.Name, err)
+			}
+			field := types.field(fieldName, goType, schema, param.Required, tagName, name)
+			field.Doc = commentLines(param.Description)
+			decl.Fields = append(decl.Fields, field)
+		}
+		if len(decl.Fields) > 0 {
+			types.decls = append(types.decls, decl)
+			op.Params = decl.Name
+			signature = append(signature, "params "+decl.Name)
+			args = append(args, "params")
+		}
+	}
+
+	if spec.RequestBody != nil {
+		body, err := doc.requestBody(spec.RequestBody)
+		if err != nil {
+			return nil, err
+		}
+		if schema := jsonSchema(body.Content); schema != nil {
+			goType, err := types.goType(schema, op.Handler+"Request")
+			if err != nil {
+				return nil, fmt.Errorf("request body: %w", err)
+			}
+			op.Body = goType
+			signature = append(signature, "body "+goType)
+			args = append(args, "body")
+		} else {
+			logger.Warn("⚠️  %s: the request body is not JSON and is not bound; read it from the gin context", op.ID)
+		}
+	}
+
+	for _, code := range spec.Responses.Keys {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		response, err := doc.response(spec.Responses.Values[code])
+		if err != nil {
+			return nil, err
+		}
+		op.Status = statusConstant(code)
+		if schema := jsonSchema(response.Content); schema != nil {
+			goType, err := types.goType(schema, op.Handler+"Response")
+			if err != nil {
+				return nil, fmt.Errorf("response %s: %w", code, err)
+			}
+			op.Response = goType
+		}
+		break
+	}
+
+	op.Signature = strings.Join(signature, ", ")
+	op.Args = strings.Join(args, ", ")
+	return op, nil
+}
+
+// deriveOperationID names an operation without an operationId after its
+// method and path, e.g. get-users-by-id for GET /users/{id}.
+func deriveOperationID(method, route string) string {
+	words := []string{strings.ToLower(method)}
+	for _, segment := range strings.Split(route, "/") {
+		if segment == "" {
+			continue
+		}
+		if match := pathParamPattern.FindStringSubmatch(segment); match != nil {
+			words = append(words, "by", match[1])
+			continue
+		}
+		words = append(words, segment)
+	}
+	return strcase.ToKebab(strings.Join(words, " "))
+}
+
+// statusConstant returns the net/http constant for a success status code.
+func statusConstant(code string) string {
+	switch code {
+	case "201":
+		return "http.StatusCreated"
+	case "202":
+		return "http.StatusAccepted"
+	case "204":
+		return "http.StatusNoContent"
+	case "200", "2XX", "2xx":
+		return "http.StatusOK"
+	}
+	return code
+}
+
+// serverBasePath returns the path of the document's first server URL,
+// e.g. /api/v1 for https://example.com/api/v1.
+func serverBasePath(doc *openAPIDoc) string {
+	if len(doc.Servers) == 0 {
+		return ""
+	}
+	url := doc.Servers[0].URL
+	if _, rest, ok := strings.Cut(url, "://"); ok {
+		if i := strings.Index(rest, "/"); i >= 0 {
+			url = rest[i:]
+		} else {
+			url = ""
+		}
+	}
+	return strings.TrimRight(url, "/")
+}
+
+// loadAPIMap reads the mapping file, returning an empty mapping when there
+// is none yet.
+func loadAPIMap(file string) (*apiMap, error) {
+	mapping := &apiMap{Operations: make(map[string]apiMapEntry)}
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return mapping, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if mapping.Operations == nil {
+		mapping.Operations = make(map[string]apiMapEntry)
+	}
+	return mapping, nil
+}
+
+// saveAPIMap writes the mapping file.
+func saveAPIMap(file string, mapping *apiMap) error {
+	var buf bytes.Buffer
+	buf.WriteString(apiMapHeader)
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(mapping); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(file, buf.Bytes(), 0644)
+}
+
+// appendDecls appends the declarations of rendered, a complete Go file, to
+// file and adds the imports they need.
+func appendDecls(file string, rendered []byte) error {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "", rendered, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	start := -1
+	for _, decl := range parsed.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			continue
+		}
+		pos := decl.Pos()
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Doc != nil {
+			pos = fn.Doc.Pos()
+		} else if gen, ok := decl.(*ast.GenDecl); ok && gen.Doc != nil {
+			pos = gen.Doc.Pos()
+		}
+		start = fset.Position(pos).Offset
+		break
+	}
+	if start < 0 {
+		return nil
+	}
+
+	existing, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	updated := strings.TrimRight(string(existing), "\n") + "\n\n" + string(rendered[start:])
+	if err := os.WriteFile(file, []byte(updated), 0644); err != nil {
+		return err
+	}
+
+	imports := make(map[string]string, len(parsed.Imports))
+	for _, spec := range parsed.Imports {
+		importPath := strings.Trim(spec.Path.Value, `"`)
+		name := path.Base(importPath)
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return addImports(file, imports)
+}
@@ -0,0 +1,233 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// asyncAPIDoc is the part of an AsyncAPI 2.x or 3.0 document goforge
+// generates consumers from.
+type asyncAPIDoc struct {
+	AsyncAPI           string                         `yaml:"asyncapi"`
+	DefaultContentType string                         `yaml:"defaultContentType"`
+	Servers            map[string]*asyncAPIServer     `yaml:"servers"`
+	Channels           orderedMap[*asyncAPIChannel]   `yaml:"channels"`
+	Operations         orderedMap[*asyncAPIOperation] `yaml:"operations"`
+	Components         struct {
+		Schemas  orderedMap[*openAPISchema]  `yaml:"schemas"`
+		Messages map[string]*asyncAPIMessage `yaml:"messages"`
+	} `yaml:"components"`
+}
+
+type asyncAPIServer struct {
+	Protocol string `yaml:"protocol"`
+}
+
+type asyncAPIChannel struct {
+	Address  *string                      `yaml:"address"`
+	Publish  *asyncAPIOperation           `yaml:"publish"`
+	Messages orderedMap[*asyncAPIMessage] `yaml:"messages"`
+}
+
+type asyncAPIOperation struct {
+	OperationID string `yaml:"operationId"`
+	Summary     string `yaml:"summary"`
+	Action      string `yaml:"action"`
+	Channel     struct {
+		Ref string `yaml:"$ref"`
+	} `yaml:"channel"`
+	Message  *asyncAPIMessage   `yaml:"message"`
+	Messages []*asyncAPIMessage `yaml:"messages"`
+}
+
+type asyncAPIMessage struct {
+	Ref         string             `yaml:"$ref"`
+	Name        string             `yaml:"name"`
+	Summary     string             `yaml:"summary"`
+	ContentType string             `yaml:"contentType"`
+	Payload     *openAPISchema     `yaml:"payload"`
+	OneOf       []*asyncAPIMessage `yaml:"oneOf"`
+}
+
+// receivedChannel is a channel the application consumes, with the message
+// it receives there.
+type receivedChannel struct {
+	Key         string // key in channels
+	Address     string // topic or subject
+	OperationID string
+	Summary     string
+	Message     *asyncAPIMessage
+	MessageName string // component or channel message name, if any
+	Extra       int    // further messages on the channel, not generated
+}
+
+// loadAsyncAPI reads an AsyncAPI 2.x or 3.0 document.
+func loadAsyncAPI(file string) (*asyncAPIDoc, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc asyncAPIDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if !strings.HasPrefix(doc.AsyncAPI, "2.") && !strings.HasPrefix(doc.AsyncAPI, "3.") {
+		return nil, fmt.Errorf("%s is not an AsyncAPI 2 or 3 document (missing 'asyncapi: 2.x' or '3.x')", file)
+	}
+	return &doc, nil
+}
+
+// protocols lists the protocols of the document's servers.
+func (d *asyncAPIDoc) protocols() map[string]bool {
+	protocols := make(map[string]bool)
+	for _, server := range d.Servers {
+		if server != nil && server.Protocol != "" {
+			protocols[strings.ToLower(server.Protocol)] = true
+		}
+	}
+	return protocols
+}
+
+// received lists the channels the application consumes: those with a
+// publish operation in AsyncAPI 2 (others publish, the application
+// receives) and those of receive operations in AsyncAPI 3.
+func (d *asyncAPIDoc) received() ([]*receivedChannel, error) {
+	var channels []*receivedChannel
+	if strings.HasPrefix(d.AsyncAPI, "2.") {
+		for _, key := range d.Channels.Keys {
+			channel := d.Channels.Values[key]
+			if channel == nil || channel.Publish == nil {
+				continue
+			}
+			messages, err := d.expand(channel.Publish.Message)
+			if err != nil {
+				return nil, fmt.Errorf("channel %s: %w", key, err)
+			}
+			channels = append(channels, d.newReceived(key, key, channel.Publish, messages))
+		}
+		return channels, nil
+	}
+
+	for _, id := range d.Operations.Keys {
+		op := d.Operations.Values[id]
+		if op == nil || op.Action != "receive" {
+			continue
+		}
+		key, err := localRef(op.Channel.Ref, "channels")
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", id, err)
+		}
+		channel, ok := d.Channels.Values[key]
+		if !ok || channel == nil {
+			return nil, fmt.Errorf("operation %s: channel '%s' is not defined", id, key)
+		}
+		address := key
+		if channel.Address != nil {
+			address = *channel.Address
+		}
+
+		refs := op.Messages
+		if len(refs) == 0 {
+			// Without a list, the operation receives every message of the channel
+			for _, name := range channel.Messages.Keys {
+				refs = append(refs, &asyncAPIMessage{Ref: "#/channels/" + key + "/messages/" + name})
+			}
+		}
+		var messages []*asyncAPIMessage
+		for _, ref := range refs {
+			expanded, err := d.expand(ref)
+			if err != nil {
+				return nil, fmt.Errorf("operation %s: %w", id, err)
+			}
+			messages = append(messages, expanded...)
+		}
+		if op.OperationID == "" {
+			op.OperationID = id
+		}
+		channels = append(channels, d.newReceived(key, address, op, messages))
+	}
+	return channels, nil
+}
+
+// newReceived describes a consumed channel by its first message.
+func (d *asyncAPIDoc) newReceived(key, address string, op *asyncAPIOperation, messages []*asyncAPIMessage) *receivedChannel {
+	received := &receivedChannel{Key: key, Address: address, OperationID: op.OperationID, Summary: op.Summary}
+	if len(messages) > 0 {
+		received.Message = messages[0]
+		received.MessageName = messages[0].Name
+		received.Extra = len(messages) - 1
+	}
+	if received.Message == nil {
+		received.Message = &asyncAPIMessage{}
+	}
+	return received
+}
+
+// expand resolves a message reference and flattens oneOf, naming each
+// message after its component when it has no name.
+func (d *asyncAPIDoc) expand(message *asyncAPIMessage) ([]*asyncAPIMessage, error) {
+	if message == nil {
+		return nil, nil
+	}
+	if message.Ref != "" {
+		resolved, name, err := d.message(message.Ref)
+		if err != nil {
+			return nil, err
+		}
+		copied := *resolved
+		if copied.Name == "" {
+			copied.Name = name
+		}
+		message = &copied
+	}
+	if len(message.OneOf) == 0 {
+		return []*asyncAPIMessage{message}, nil
+	}
+	var messages []*asyncAPIMessage
+	for _, option := range message.OneOf {
+		expanded, err := d.expand(option)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, expanded...)
+	}
+	return messages, nil
+}
+
+// message resolves #/components/messages/<name> and, in AsyncAPI 3,
+// #/channels/<channel>/messages/<name> references.
+func (d *asyncAPIDoc) message(ref string) (*asyncAPIMessage, string, error) {
+	if name, err := refName(ref, "messages"); err == nil {
+		if message, ok := d.Components.Messages[name]; ok && message != nil {
+			return message, name, nil
+		}
+		return nil, "", fmt.Errorf("message '%s' is not defined in components", name)
+	}
+
+	path, err := localRef(ref, "channels")
+	if err != nil {
+		return nil, "", fmt.Errorf("unsupported message reference '%s'", ref)
+	}
+	key, name, ok := strings.Cut(path, "/messages/")
+	if channel := d.Channels.Values[key]; ok && channel != nil {
+		if message, ok := channel.Messages.Values[name]; ok && message != nil {
+			if message.Ref != "" {
+				return d.message(message.Ref)
+			}
+			return message, name, nil
+		}
+	}
+	return nil, "", fmt.Errorf("message reference '%s' does not resolve", ref)
+}
+
+// localRef returns the path of a #/<section>/... reference.
+func localRef(ref, section string) (string, error) {
+	prefix := "#/" + section + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported reference '%s' (expected %s...)", ref, prefix)
+	}
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(strings.TrimPrefix(ref, prefix)), nil
+}
@@ -0,0 +1,357 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/parser"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultBatchLayers are generated for entities that list no layers.
+var DefaultBatchLayers = []string{"model", "port", "repository", "service", "handler"}
+
+// BatchSpec is the file read by 'goforge generate --from', describing
+// several entities to generate at once:
+//
+//	layers: [model, port, repository, service, handler]   # default for every entity
+//	entities:
+//	  - name: user
+//	    fields:
+//	      - {name: email, type: string, required: true}
+//	      - {name: age, type: int}
+//	  - name: order
+//	    layers: [model, port, repository]
+//	    fields:
+//	      - {name: total, type: float64, tags: {validate: "gte=0"}}
+type BatchSpec struct {
+	Layers   []string     `yaml:"layers"`
+	Entities []EntitySpec `yaml:"entities"`
+}
+
+// EntitySpec is one entity in a batch spec.
+type EntitySpec struct {
+	Name   string            `yaml:"name"`
+	Table  string            `yaml:"table"` // The plural of the name when empty
+	Layers []string          `yaml:"layers"`
+	Fields []FieldSpec       `yaml:"fields"`
+	Vars   map[string]string `yaml:"vars"` // Template variables, as with --var
+}
+
+// FieldSpec is a field of an entity as written in a batch spec.
+type FieldSpec struct {
+	Name     string            `yaml:"name"`
+	Type     string            `yaml:"type"`
+	Required bool              `yaml:"required"`
+	Tags     map[string]string `yaml:"tags"` // Extra or overriding struct tags
+}
+
+// Field is an entity field as seen by templates.
+type Field struct {
+	Name     string // As written in the spec, e.g. "created_by"
+	GoName   string // e.g. "CreatedBy"
+	Type     string // Go type expression, e.g. "*time.Time"
+	JSONName string // e.g. "created_by"
+	Required bool
+	Tag      string // Complete struct tag without backquotes
+}
+
+// LoadBatchSpec reads and validates a batch spec file.
+func LoadBatchSpec(file string) (*BatchSpec, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+
+	var spec BatchSpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if len(spec.Entities) == 0 {
+		return nil, fmt.Errorf("%s declares no entities", file)
+	}
+	if len(spec.Layers) == 0 {
+		spec.Layers = DefaultBatchLayers
+	}
+
+	// Check every entity so all mistakes in the spec are reported together
+	var errs validation.ValidationErrors
+	validator := validation.NewProjectValidator()
+	seen := make(map[string]bool)
+	for i, entity := range spec.Entities {
+		if entity.Name == "" {
+			errs = append(errs, &validation.ValidationError{
+				Field:   fmt.Sprintf("entities[%d].name", i),
+				Message: "entity has no name",
+			})
+			continue
+		}
+		if seen[entity.Name] {
+			errs = append(errs, &validation.ValidationError{
+				Field:   "entities." + entity.Name,
+				Value:   entity.Name,
+				Message: "entity is declared twice",
+			})
+		}
+		seen[entity.Name] = true
+
+		namespaces, name := splitComponentName(entity.Name)
+		errs.Add("entities."+entity.Name, validator.ValidateComponentPath("entity", namespaces, name))
+
+		_, err := entity.fields()
+		errs.Add("entities."+entity.Name+".fields", err)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// layers returns the component types to generate for the entity.
+func (e EntitySpec) layers(defaults []string) []string {
+	if len(e.Layers) > 0 {
+		return e.Layers
+	}
+	return defaults
+}
+
+// fields validates the field specs and converts them for templates. All
+// invalid fields are reported together as validation.ValidationErrors.
+func (e EntitySpec) fields() ([]Field, error) {
+	var fields []Field
+	var errs validation.ValidationErrors
+	seen := make(map[string]bool)
+	for _, spec := range e.Fields {
+		field := fmt.Sprintf("entities.%s.fields.%s", e.Name, spec.Name)
+		if !variableNamePattern.MatchString(spec.Name) {
+			errs = append(errs, &validation.ValidationError{
+				Field:       field,
+				Value:       spec.Name,
+				Message:     "invalid field name",
+				Suggestions: []string{"Use letters, digits, and underscores, starting with a letter"},
+			})
+			continue
+		}
+		goName := strcase.ToCamel(spec.Name)
+		if seen[goName] {
+			errs = append(errs, &validation.ValidationError{Field: field, Value: spec.Name, Message: "field is declared twice"})
+			continue
+		}
+		seen[goName] = true
+
+		if spec.Type == "" {
+			errs = append(errs, &validation.ValidationError{
+				Field:       field,
+				Message:     "field has no type",
+				Suggestions: []string{"Add a Go type, e.g. {name: " + spec.Name + ", type: string}"},
+			})
+			continue
+		}
+		if _, err := parser.ParseExpr(spec.Type); err != nil {
+			errs = append(errs, &validation.ValidationError{Field: field, Value: spec.Type, Message: "invalid Go type"})
+			continue
+		}
+
+		jsonName := strcase.ToSnake(spec.Name)
+		tags := map[string]string{"json": jsonName, "db": jsonName}
+		if spec.Required {
+			tags["validate"] = "required"
+		}
+		for key, value := range spec.Tags {
+			tags[key] = value
+		}
+
+		fields = append(fields, Field{
+			Name:     spec.Name,
+			GoName:   goName,
+			Type:     spec.Type,
+			JSONName: jsonName,
+			Required: spec.Required,
+			Tag:      structTag(tags),
+		})
+	}
+	return fields, errs.Err()
+}
+
+// structTag renders tags as json, db, then the rest alphabetically.
+func structTag(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		if key != "json" && key != "db" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	keys = append([]string{"json", "db"}, keys...)
+
+	var parts []string
+	for _, key := range keys {
+		if value, ok := tags[key]; ok && value != "" {
+			parts = append(parts, fmt.Sprintf("%s:%q", key, value))
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// pendingFile is a rendered file waiting to be written by a batch.
+type pendingFile struct {
+	task    FileGenerationTask
+	content []byte
+}
+
+// journalEntry remembers a file's previous state so a batch can be undone.
+type journalEntry struct {
+	path    string
+	existed bool
+	content []byte
+}
+
+// GenerateBatch generates every layer of every entity in spec as one
+// transaction: all templates are rendered and conflicts checked before
+// anything is written, and a failure while writing restores the files
+// already touched.
+func GenerateBatch(spec *BatchSpec, options GenerateOptions) error {
+	s := NewScaffolder()
+	if err := options.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	custom, err := CustomComponents(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	// Render everything first so a bad template or name fails before any write
+	var pending []pendingFile
+	targets := make(map[string]string)
+	repositories := false
+	for _, entity := range spec.Entities {
+		fields, err := entity.fields()
+		if err != nil {
+			return fmt.Errorf("entity '%s': %w", entity.Name, err)
+		}
+		for _, layer := range entity.layers(spec.Layers) {
+			entityOptions := options
+			entityOptions.Vars = entity.Vars
+			entityOptions.Fields = fields
+			entityOptions.Table = entity.Table
+
+			task, _, err := s.planComponent(cfg, projectRoot, custom, layer, entity.Name, entityOptions)
+			if err != nil {
+				return reportInvalid(err, fmt.Sprintf("entity '%s', %s: invalid input", entity.Name, layer))
+			}
+			if previous, ok := targets[task.TargetPath]; ok {
+				return fmt.Errorf("%s and %s %s would both write %s", previous, layer, entity.Name, relativeTo(projectRoot, task.TargetPath))
+			}
+			targets[task.TargetPath] = layer + " " + entity.Name
+
+			content, err := s.renderTemplate(task)
+			if err != nil {
+				return fmt.Errorf("entity '%s', %s: %w", entity.Name, layer, err)
+			}
+			pending = append(pending, pendingFile{task: task, content: content})
+			repositories = repositories || layer == "repository"
+		}
+	}
+
+	if err := s.checkBatchConflicts(pending, projectRoot, options.OnConflict); err != nil {
+		return err
+	}
+
+	logger.Info("🏗️  Generating %d file(s) for %d entities...", len(pending), len(spec.Entities))
+
+	var journal []journalEntry
+	var written []string
+	for _, file := range pending {
+		entries, err := s.journalFile(projectRoot, file.task.TargetPath)
+		if err != nil {
+			s.rollback(journal)
+			return fmt.Errorf("generation rolled back: %w", err)
+		}
+		journal = append(journal, entries...)
+
+		ok, err := s.writeGenerated(file.task, file.content, projectRoot, options.OnConflict)
+		if err != nil {
+			s.rollback(journal)
+			return fmt.Errorf("generation rolled back: %w", err)
+		}
+		if ok {
+			written = append(written, file.task.TargetPath)
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+	if repositories {
+		s.registerProbe(cfg, projectRoot, ProbePostgres)
+	}
+
+	logger.Success("✅ Generated %d file(s) for %d entities", len(written), len(spec.Entities))
+	return nil
+}
+
+// checkBatchConflicts refuses to start when an existing file differs from
+// its new content and no conflict mode was chosen, since a batch cannot
+// stop halfway to ask.
+func (s *Scaffolder) checkBatchConflicts(pending []pendingFile, projectRoot, mode string) error {
+	if mode != "" && mode != ConflictPrompt {
+		return nil
+	}
+
+	var conflicts []string
+	for _, file := range pending {
+		existing, err := os.ReadFile(file.task.TargetPath)
+		if err == nil && string(existing) != string(file.content) {
+			conflicts = append(conflicts, relativeTo(projectRoot, file.task.TargetPath))
+		}
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%d file(s) already exist:\n  %s\n\nChoose how to handle them with --on-conflict skip, overwrite, or merge",
+		len(conflicts), strings.Join(conflicts, "\n  "))
+}
+
+// journalFile records the current state of a target and its snapshot.
+func (s *Scaffolder) journalFile(projectRoot, target string) ([]journalEntry, error) {
+	var entries []journalEntry
+	for _, path := range []string{target, s.snapshotPath(projectRoot, target)} {
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("could not back up %s: %w", path, err)
+		}
+		entries = append(entries, journalEntry{path: path, existed: err == nil, content: content})
+	}
+	return entries, nil
+}
+
+// rollback restores journaled files in reverse order. Directories created
+// for new files are left in place.
+func (s *Scaffolder) rollback(journal []journalEntry) {
+	for i := len(journal) - 1; i >= 0; i-- {
+		entry := journal[i]
+		var err error
+		if !entry.existed {
+			err = os.Remove(entry.path)
+			if os.IsNotExist(err) {
+				err = nil
+			}
+		} else {
+			err = os.WriteFile(entry.path, entry.content, 0644)
+		}
+		if err != nil {
+			logger.Error("Could not restore %s: %v", entry.path, err)
+		}
+	}
+	logger.Warn("⚠️  Rolled back %d file(s)", len(journal)/2)
+}
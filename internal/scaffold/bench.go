@@ -0,0 +1,109 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"testing/fstest"
+	"time"
+)
+
+// BenchResult compares sequential and parallel generation for one
+// template size. Durations are the median of the measured iterations.
+type BenchResult struct {
+	Files      int
+	Sequential time.Duration
+	Parallel   time.Duration
+}
+
+// Speedup returns how many times faster parallel generation was.
+func (r BenchResult) Speedup() float64 {
+	if r.Parallel == 0 {
+		return 0
+	}
+	return float64(r.Sequential) / float64(r.Parallel)
+}
+
+// BenchmarkGeneration renders synthetic templates of each size into
+// temporary directories, once sequentially and once with the parallel
+// worker pool, to show where the generateFiles threshold should sit.
+func BenchmarkGeneration(sizes []int, iterations int) ([]BenchResult, error) {
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	sample, err := fs.ReadFile(templatesFS, "templates/components/handler.go.tpl")
+	if err != nil {
+		return nil, err
+	}
+
+	s := NewScaffolder()
+	var results []BenchResult
+	for _, size := range sizes {
+		source := syntheticTemplate(sample, size)
+
+		sequential, err := s.timeGeneration(source, iterations, s.generateFilesSequential)
+		if err != nil {
+			return nil, err
+		}
+		parallel, err := s.timeGeneration(source, iterations, s.generateFilesParallel)
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, BenchResult{Files: size, Sequential: sequential, Parallel: parallel})
+	}
+	return results, nil
+}
+
+// syntheticTemplate builds an in-memory template tree of n files spread
+// over nested directories, like a real project template.
+func syntheticTemplate(sample []byte, n int) fs.FS {
+	fsys := fstest.MapFS{}
+	for i := 0; i < n; i++ {
+		name := path.Join("bench", fmt.Sprintf("pkg%d", i%10), fmt.Sprintf("sub%d", i%3), fmt.Sprintf("file%d.go.tpl", i))
+		fsys[name] = &fstest.MapFile{Data: sample}
+	}
+	return fsys
+}
+
+// timeGeneration returns the median duration of generating source with generate.
+func (s *Scaffolder) timeGeneration(source fs.FS, iterations int, generate func([]FileGenerationTask) error) (time.Duration, error) {
+	data := TemplateData{
+		ProjectName: "bench",
+		ModuleName:  "example.com/bench",
+		Name:        "user",
+		NameTitle:   "User",
+		ModulePath:  "example.com/bench",
+		PackageName: "handler",
+		Imports:     map[string]string{"model": "example.com/bench/internal/domain", "service": "example.com/bench/internal/app/service"},
+	}
+
+	durations := make([]time.Duration, 0, iterations)
+	for i := 0; i < iterations; i++ {
+		dest, err := os.MkdirTemp("", "goforge-bench-*")
+		if err != nil {
+			return 0, err
+		}
+
+		tasks, err := s.collectGenerationTasks(source, "bench", dest, data)
+		if err != nil {
+			os.RemoveAll(dest)
+			return 0, err
+		}
+
+		start := time.Now()
+		err = generate(tasks)
+		durations = append(durations, time.Since(start))
+
+		os.RemoveAll(dest)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	return durations[len(durations)/2], nil
+}
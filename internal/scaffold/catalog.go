@@ -0,0 +1,290 @@
+package scaffold
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/packs"
+)
+
+// Template kinds and origins reported by ListTemplates.
+const (
+	KindProject   = "project"
+	KindGenerator = "generator"
+
+	OriginEmbedded = "embedded"
+	OriginLocal    = "local"
+	OriginPack     = "pack"
+)
+
+// TemplateInfo describes a template available to 'goforge new' or 'goforge generate'.
+type TemplateInfo struct {
+	Name        string // Reference used on the command line, e.g. "default" or "org/consumer"
+	Kind        string // KindProject or KindGenerator
+	Origin      string // OriginEmbedded, OriginLocal, or OriginPack
+	Description string
+	Variables   []string // Template data the template may reference
+	Source      string   // Pack source or local directory
+	Version     string   // Pack version
+	Path        string   // Location on disk, if any
+}
+
+// projectVariables and componentVariables list the TemplateData fields
+// available to each kind of template.
+var (
+	projectVariables   = []string{".ProjectName", ".ModuleName", ".GoVersion"}
+	componentVariables = []string{".Name", ".NameTitle", ".ModulePath", ".PackageName", ".Imports.<type>"}
+)
+
+// ListTemplates returns every available template: embedded ones, the
+// project's own generators (when projectRoot is not empty), and the
+// contents of installed packs.
+func ListTemplates(projectRoot string) ([]TemplateInfo, error) {
+	var infos []TemplateInfo
+
+	entries, err := fs.ReadDir(templatesFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !isProjectTemplate(entry.Name()) {
+			continue
+		}
+		manifest, err := readManifest(templatesFS, "templates/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, TemplateInfo{
+			Name:        entry.Name(),
+			Kind:        KindProject,
+			Origin:      OriginEmbedded,
+			Description: manifest.Description,
+			Variables:   variableList(projectVariables, manifest.Variables),
+		})
+	}
+
+	for _, spec := range componentRegistry {
+		infos = append(infos, generatorInfo(spec, OriginEmbedded))
+	}
+	infos = append(infos, TemplateInfo{
+		Name:        "ratelimiter",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Shared rate limiting package with middleware",
+		Variables:   []string{".Vars.strategy", ".Vars.backend", ".Vars.rate", ".Vars.window", ".Vars.burst"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "oidc",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "OpenID Connect login with sessions and auth middleware",
+		Variables:   []string{".Vars.provider", ".Vars.prefix"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "job",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Scheduled background job with cron scheduler and worker",
+		Variables:   []string{".Vars.schedule"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "grpc",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "gRPC service with .proto definition, server and cmd/grpc",
+		Variables:   []string{".Vars.protoPackage", ".Vars.goPackage", ".Vars.genImport"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "resolver",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "GraphQL schema and gqlgen resolvers wired to the resource's service",
+		Variables:   []string{".Vars.service", ".Vars.generatedImport", ".Vars.modelImport"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "command",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Cobra subcommand registered in the CLI's root command",
+		Variables:   []string{".Vars.constructor", ".Vars.binary"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "config",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Typed config section with defaults and env bindings, synced with config/default.yml",
+		Variables:   []string{".Vars.section", ".Vars.fields"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "api",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Models, handler stubs and gin routes generated from an OpenAPI 3 document",
+		Variables:   []string{".Vars.spec", ".Vars.types", ".Vars.operations"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "consumer",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Kafka or NATS consumers, message structs and codecs generated from an AsyncAPI document",
+		Variables:   []string{".Vars.spec", ".Vars.types", ".Vars.channels", ".Vars.broker"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "enum",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Typed enum with String, Parse, IsValid, JSON marshaling and tests",
+		Variables:   []string{".Vars.values", ".Vars.label", ".Vars.list"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "event",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Event type and listener with an in-process event bus",
+		Variables:   []string{".Vars.eventName", ".Vars.listenerTitle"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "fixture",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Test data builder with valid, unique defaults for a domain model",
+		Variables:   []string{".Vars.fields", ".Vars.imports", ".Vars.variable"},
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "healthcheck-client",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Dependency probe registry with health endpoints and CLI",
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "mock",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: mockSpec.Description + " (mockgen or mockery)",
+	})
+	infos = append(infos, TemplateInfo{
+		Name:        "seeder",
+		Kind:        KindGenerator,
+		Origin:      OriginEmbedded,
+		Description: "Database seeder registered with cmd/seed",
+		Variables:   []string{".Vars.table", ".Vars.seedsImport"},
+	})
+
+	if projectRoot != "" {
+		custom, err := CustomComponents(projectRoot)
+		if err != nil {
+			return nil, err
+		}
+		for _, spec := range custom {
+			info := generatorInfo(spec, OriginLocal)
+			info.Source = CustomTemplatesDir
+			info.Path = filepath.Join(projectRoot, CustomTemplatesDir, spec.Template)
+			infos = append(infos, info)
+		}
+	}
+
+	reg, err := packs.LoadRegistry()
+	if err != nil {
+		return nil, err
+	}
+	for _, pack := range reg.Packs {
+		packInfos, err := PackTemplates(pack)
+		if err != nil {
+			logger.Warn("Skipping pack '%s': %v", pack.Name, err)
+			continue
+		}
+		infos = append(infos, packInfos...)
+	}
+
+	return infos, nil
+}
+
+// PackTemplates lists the project templates and generators in a pack.
+func PackTemplates(pack *packs.Pack) ([]TemplateInfo, error) {
+	var infos []TemplateInfo
+
+	entries, err := os.ReadDir(pack.TemplatesDir())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	source := os.DirFS(pack.TemplatesDir())
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		manifest, err := readManifest(source, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, TemplateInfo{
+			Name:        pack.Name + "/" + entry.Name(),
+			Kind:        KindProject,
+			Origin:      OriginPack,
+			Description: manifest.Description,
+			Variables:   variableList(projectVariables, manifest.Variables),
+			Source:      pack.Source,
+			Version:     pack.Version,
+			Path:        filepath.Join(pack.TemplatesDir(), entry.Name()),
+		})
+	}
+
+	specs, err := PackComponents(pack)
+	if err != nil {
+		return nil, err
+	}
+	for _, spec := range specs {
+		info := generatorInfo(spec, OriginPack)
+		info.Source = pack.Source
+		info.Version = pack.Version
+		info.Path = filepath.Join(pack.GeneratorsDir(), spec.Template)
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// FindTemplate looks up a template by name. Project templates are
+// preferred when a generator shares the name.
+func FindTemplate(projectRoot, name string) (TemplateInfo, bool, error) {
+	infos, err := ListTemplates(projectRoot)
+	if err != nil {
+		return TemplateInfo{}, false, err
+	}
+
+	var found *TemplateInfo
+	for i := range infos {
+		if infos[i].Name != name {
+			continue
+		}
+		// Local generators override embedded ones of the same name
+		if found == nil || (found.Kind != KindProject && (infos[i].Kind == KindProject || infos[i].Origin == OriginLocal)) {
+			found = &infos[i]
+		}
+	}
+	if found == nil {
+		return TemplateInfo{}, false, nil
+	}
+	return *found, true, nil
+}
+
+// generatorInfo converts a component spec into a TemplateInfo.
+func generatorInfo(spec ComponentSpec, origin string) TemplateInfo {
+	return TemplateInfo{
+		Name:        spec.Type,
+		Kind:        KindGenerator,
+		Origin:      origin,
+		Description: spec.Description,
+		Variables:   variableList(componentVariables, spec.Variables),
+	}
+}
+
+// variableList combines the built-in template data with declared variables.
+func variableList(builtin []string, declared []TemplateVariable) []string {
+	list := append([]string(nil), builtin...)
+	for _, v := range declared {
+		list = append(list, v.describe())
+	}
+	return list
+}
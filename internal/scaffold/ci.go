@@ -0,0 +1,104 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// CIProviders lists the CI systems of 'goforge new --ci' and 'goforge
+// generate ci'.
+var CIProviders = []string{"github", "gitlab", "circleci", NoCI}
+
+// NoCI creates a project without a CI pipeline.
+const NoCI = "none"
+
+// ciFiles is where each CI system reads its pipeline from.
+var ciFiles = map[string]string{
+	"github":   ".github/workflows/ci.yml",
+	"gitlab":   ".gitlab-ci.yml",
+	"circleci": ".circleci/config.yml",
+}
+
+// ValidCIProvider reports whether name is one of CIProviders.
+func ValidCIProvider(name string) bool {
+	for _, provider := range CIProviders {
+		if provider == name {
+			return true
+		}
+	}
+	return false
+}
+
+// ciGoVersions returns the Go versions the pipeline tests with: the
+// project's minor release and the latest one.
+func ciGoVersions(goVersion string) []string {
+	parts := strings.SplitN(goVersion, ".", 3)
+	if len(parts) >= 2 {
+		goVersion = parts[0] + "." + parts[1]
+	}
+	return []string{goVersion, "latest"}
+}
+
+// writeCI renders the pipeline of provider into the project.
+func (s *Scaffolder) writeCI(projectRoot, provider string, data TemplateData, onConflict string) (string, error) {
+	target := filepath.Join(projectRoot, filepath.FromSlash(ciFiles[provider]))
+	data.Vars = map[string]any{"goVersions": ciGoVersions(data.GoVersion)}
+	task := FileGenerationTask{
+		TemplatePath: path.Join("templates/components/ci", provider+".yml.tpl"),
+		TargetPath:   target,
+		Data:         data,
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return "", err
+	}
+	if _, err := s.writeGenerated(task, content, projectRoot, onConflict); err != nil {
+		return "", err
+	}
+	return target, nil
+}
+
+// GenerateCI writes a CI pipeline for provider that builds, tests, and
+// lints the project through its goforge commands on a matrix of Go
+// versions, caching the module and build caches between runs.
+func GenerateCI(provider string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if provider == NoCI || !ValidCIProvider(provider) {
+		return fmt.Errorf("unknown CI system '%s' (use github, gitlab or circleci)", provider)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	logger.ComponentGenerationStart("ci", provider)
+
+	data := TemplateData{
+		ProjectName: cfg.ProjectName,
+		ModuleName:  cfg.ModuleName,
+		GoVersion:   cfg.GoVersion,
+	}
+	target, err := s.writeCI(projectRoot, provider, data, genOptions.OnConflict)
+	if err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationComplete("ci", provider, target)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Review the jobs in %s", ciFiles[provider])
+	logger.Info("   2. Keep the test and lint scripts in goforge.yml working; the pipeline runs them")
+	return nil
+}
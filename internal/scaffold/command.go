@@ -0,0 +1,274 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// commandSpec places the CLI's cobra commands; override it with
+// layout.command in goforge.yml.
+var commandSpec = ComponentSpec{Type: "command", Dir: "internal/cli"}
+
+// cliMain is the entry point running the root command.
+const cliMain = "cmd/cli/main.go"
+
+// rootCommandVar is the name the root command is looked up by first.
+const rootCommandVar = "rootCmd"
+
+// GenerateCommand writes a cobra subcommand and registers it in the
+// project's root command by adding an AddCommand call to the init function
+// of the file declaring it. A project without a root command gets one,
+// with cmd/cli running it.
+func GenerateCommand(name string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid command name '%s' (use lowercase words separated by hyphens, e.g. import-users)", name)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, commandSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	constructor := "new" + strcase.ToCamel(name) + "Cmd"
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   strcase.ToCamel(name),
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"constructor":    constructor,
+			"binary":         cfg.ProjectName,
+			"commandImport":  path.Join(cfg.ModuleName, dir),
+			"commandPackage": packageNameFor(dir),
+		},
+	}
+
+	logger.ComponentGenerationStart("command", name)
+
+	var written []string
+	write := func(template, target, pkg, onConflict string) error {
+		fileData := data
+		fileData.PackageName = pkg
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/command", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         fileData,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// Look for the root command in the command package, then next to main
+	rootFile, rootVar, err := findRootCommand(projectRoot, dir, path.Dir(cliMain))
+	if err != nil {
+		return err
+	}
+	if rootFile == "" {
+		rootFile = filepath.Join(projectRoot, filepath.FromSlash(dir), "root.go")
+		rootVar = rootCommandVar
+		if err := write("root.go.tpl", path.Join(dir, "root.go"), data.PackageName, ConflictSkip); err != nil {
+			return err
+		}
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(cliMain))); os.IsNotExist(err) {
+			if err := write("main.go.tpl", cliMain, "main", ConflictSkip); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The root command calls the constructor unqualified, so the command
+	// goes into its package
+	rootPackage, err := parser.ParseFile(token.NewFileSet(), rootFile, nil, parser.PackageClauseOnly)
+	if err != nil {
+		return fmt.Errorf("could not parse %s: %w", relativeTo(projectRoot, rootFile), err)
+	}
+	commandFile := path.Join(filepath.ToSlash(relativeTo(projectRoot, filepath.Dir(rootFile))), strcase.ToSnake(name)+".go")
+	if err := write("command.go.tpl", commandFile, rootPackage.Name.Name, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	call := fmt.Sprintf("%s.AddCommand(%s())", rootVar, constructor)
+	registered, err := registerCommand(rootFile, rootVar, constructor)
+	if err != nil {
+		return fmt.Errorf("could not register the command in %s: %w", relativeTo(projectRoot, rootFile), err)
+	}
+	if registered {
+		logger.Debug("Registered %s in %s", call, rootFile)
+	} else {
+		logger.Info("✔️  %s is already registered", constructor)
+	}
+	if len(written) == 0 && !registered {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, []string{"github.com/spf13/cobra"}); err != nil {
+		return err
+	}
+	if _, ok := cfg.Scripts["cli"]; !ok {
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", "cli"}, "go run ./"+path.Dir(cliMain)); err != nil {
+			logger.Warn("Could not add the cli script to goforge.yml: %v", err)
+		}
+	}
+
+	logger.ComponentGenerationComplete("command", name, filepath.Join(projectRoot, filepath.FromSlash(commandFile)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Describe the command and implement RunE in %s", commandFile)
+	logger.Info("   2. Try it: go run ./%s %s --help", path.Dir(cliMain), name)
+
+	return nil
+}
+
+// findRootCommand returns the file declaring the CLI's root command and the
+// variable holding it, searching the given project directories in order.
+// The root command is the package-level rootCmd, or else the only
+// package-level *cobra.Command variable. It returns "" when there is none.
+func findRootCommand(projectRoot string, dirs ...string) (string, string, error) {
+	for _, dir := range dirs {
+		files, _ := filepath.Glob(filepath.Join(projectRoot, filepath.FromSlash(dir), "*.go"))
+
+		var candidates [][2]string
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+			if err != nil {
+				return "", "", fmt.Errorf("could not parse %s: %w", relativeTo(projectRoot, file), err)
+			}
+			for _, name := range commandVars(parsed) {
+				if name == rootCommandVar {
+					return file, name, nil
+				}
+				candidates = append(candidates, [2]string{file, name})
+			}
+		}
+		if len(candidates) == 1 {
+			return candidates[0][0], candidates[0][1], nil
+		}
+	}
+	return "", "", nil
+}
+
+// commandVars lists the package-level variables initialized with
+// &cobra.Command{...}.
+func commandVars(file *ast.File) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if i < len(value.Values) && isCobraCommand(value.Values[i]) {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+	return names
+}
+
+// isCobraCommand reports whether expr is &cobra.Command{...}.
+func isCobraCommand(expr ast.Expr) bool {
+	unary, ok := expr.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return false
+	}
+	literal, ok := unary.X.(*ast.CompositeLit)
+	if !ok {
+		return false
+	}
+	selector, ok := literal.Type.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+	return ok && pkg.Name == "cobra" && selector.Sel.Name == "Command"
+}
+
+// registerCommand adds rootVar.AddCommand(constructor()) at the end of the
+// init function in rootFile, creating the function if the file has none.
+// It reports whether the file changed; a file already calling constructor
+// is left alone.
+func registerCommand(rootFile, rootVar, constructor string) (bool, error) {
+	data, err := os.ReadFile(rootFile)
+	if err != nil {
+		return false, err
+	}
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, rootFile, data, parser.ParseComments)
+	if err != nil {
+		return false, err
+	}
+
+	called := false
+	ast.Inspect(parsed, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok {
+			if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == constructor {
+				called = true
+			}
+		}
+		return !called
+	})
+	if called {
+		return false, nil
+	}
+
+	statement := fmt.Sprintf("%s.AddCommand(%s())", rootVar, constructor)
+	var updated string
+	if init := initFunc(parsed); init != nil {
+		offset := fset.Position(init.Body.Rbrace).Offset
+		updated = string(data[:offset]) + "\t" + statement + "\n" + string(data[offset:])
+	} else {
+		updated = strings.TrimRight(string(data), "\n") + "\n\nfunc init() {\n\t" + statement + "\n}\n"
+	}
+
+	if err := os.WriteFile(rootFile, []byte(updated), 0644); err != nil {
+		return false, err
+	}
+	return true, formatFile(rootFile)
+}
+
+// initFunc returns the file's first init function, if any.
+func initFunc(file *ast.File) *ast.FuncDecl {
+	for _, decl := range file.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Recv == nil && fn.Name.Name == "init" {
+			return fn
+		}
+	}
+	return nil
+}
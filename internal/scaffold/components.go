@@ -0,0 +1,131 @@
+package scaffold
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// ComponentSpec describes how a component type is generated.
+type ComponentSpec struct {
+	Type        string // e.g. "handler"
+	Description string
+	Template    string             // embedded template path
+	Dir         string             // default output directory, relative to the project root
+	FileName    string             // file name pattern, formatted with the snake_case name
+	Generated   bool               // output is fully machine-generated and never edited by hand
+	Source      fs.FS              // filesystem holding Template; nil means the embedded templates
+	Variables   []TemplateVariable // extra template parameters, from the manifest
+}
+
+// componentRegistry lists every built-in component in display order.
+var componentRegistry = []ComponentSpec{
+	{Type: "handler", Description: "HTTP request handlers for API endpoints", Template: "templates/components/handler.go.tpl", Dir: "internal/adapters/http/handler", FileName: "%s_handler.go"},
+	{Type: "service", Description: "Business logic services", Template: "templates/components/service.go.tpl", Dir: "internal/app/service", FileName: "%s_service.go"},
+	{Type: "repository", Description: "Data access layer implementations", Template: "templates/components/repository.go.tpl", Dir: "internal/adapters/postgres", FileName: "%s_repo.go"},
+	{Type: "model", Description: "Domain models and entities", Template: "templates/components/model.go.tpl", Dir: "internal/domain", FileName: "%s.go"},
+	{Type: "middleware", Description: "HTTP middleware components", Template: "templates/components/middleware.go.tpl", Dir: "internal/adapters/http/middleware", FileName: "%s.go"},
+	{Type: "port", Description: "Interface definitions for clean architecture", Template: "templates/components/port.go.tpl", Dir: "internal/ports", FileName: "%s_port.go"},
+}
+
+// LookupComponent returns the spec for a built-in component type.
+func LookupComponent(componentType string) (ComponentSpec, bool) {
+	for _, spec := range componentRegistry {
+		if spec.Type == componentType {
+			return spec, true
+		}
+	}
+	return ComponentSpec{}, false
+}
+
+// Components returns all built-in component specs in display order.
+func Components() []ComponentSpec {
+	return append([]ComponentSpec(nil), componentRegistry...)
+}
+
+// ComponentTypes returns the names of all built-in component types.
+func ComponentTypes() []string {
+	types := make([]string, len(componentRegistry))
+	for i, spec := range componentRegistry {
+		types[i] = spec.Type
+	}
+	return types
+}
+
+// GeneratedDirs returns the project-relative directories whose contents are
+// entirely machine-generated, taking the goforge.yml layout into account.
+func GeneratedDirs(cfg *project.Config) []string {
+	var dirs []string
+	for _, spec := range append(Components(), mockSpec) {
+		if spec.Generated {
+			dirs = append(dirs, componentDir(cfg, spec, ""))
+		}
+	}
+	return dirs
+}
+
+// componentDir resolves the output directory for a component type. An
+// explicit override wins over the goforge.yml layout map, which wins over
+// the built-in default.
+func componentDir(cfg *project.Config, spec ComponentSpec, override string) string {
+	if override != "" {
+		return filepath.ToSlash(filepath.Clean(override))
+	}
+	if dir, ok := cfg.Layout[spec.Type]; ok && dir != "" {
+		return filepath.ToSlash(filepath.Clean(dir))
+	}
+	return spec.Dir
+}
+
+// resolveDir returns the output directory of a component type like
+// componentDir, joined with namespaces, and an error when it lies
+// outside the project.
+func resolveDir(cfg *project.Config, spec ComponentSpec, override string, namespaces ...string) (string, error) {
+	dir := path.Join(append([]string{componentDir(cfg, spec, override)}, namespaces...)...)
+	if filepath.IsAbs(dir) || strings.HasPrefix(dir, "..") {
+		return "", exitcode.Errorf(exitcode.Validation, "component path must be inside the project: %s", dir)
+	}
+	return dir, nil
+}
+
+// componentImports maps each component type to the import path of the
+// package it is generated into, so templates can reference sibling layers
+// (e.g. {{.Imports.port}}) regardless of the project layout. Custom
+// components are included after the built-ins so they can override them.
+func componentImports(cfg *project.Config, custom ...ComponentSpec) map[string]string {
+	imports := make(map[string]string, len(componentRegistry)+len(custom))
+	for _, spec := range append(Components(), custom...) {
+		imports[spec.Type] = path.Join(cfg.ModuleName, componentDir(cfg, spec, ""))
+	}
+	return imports
+}
+
+// splitComponentName splits a namespaced name such as "admin/user" into its
+// namespace segments and the component name.
+func splitComponentName(name string) ([]string, string) {
+	parts := strings.Split(filepath.ToSlash(name), "/")
+	return parts[:len(parts)-1], parts[len(parts)-1]
+}
+
+// packageNameFor derives a valid Go package name from a directory.
+func packageNameFor(dir string) string {
+	base := strings.ToLower(path.Base(filepath.ToSlash(dir)))
+
+	var b strings.Builder
+	for _, r := range base {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+
+	name := b.String()
+	if name == "" || unicode.IsDigit(rune(name[0])) {
+		name = "pkg" + name
+	}
+	return name
+}
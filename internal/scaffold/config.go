@@ -0,0 +1,500 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// configSpec places the typed configuration sections; override it with
+// layout.config in goforge.yml.
+var configSpec = ComponentSpec{Type: "config", Dir: "internal/config"}
+
+// configFieldTypes maps the types accepted by --field to Go types.
+var configFieldTypes = map[string]string{
+	"string":            "string",
+	"int":               "int",
+	"int64":             "int64",
+	"bool":              "bool",
+	"float":             "float64",
+	"float64":           "float64",
+	"duration":          "time.Duration",
+	"time.Duration":     "time.Duration",
+	"[]string":          "[]string",
+	"[]int":             "[]int",
+	"map[string]string": "map[string]string",
+}
+
+// envUnsafe matches the characters replaced by underscores in environment
+// variable names.
+var envUnsafe = regexp.MustCompile(`[^A-Za-z0-9]+`)
+
+// ConfigOptions parameterizes a generated configuration section.
+type ConfigOptions struct {
+	// Fields are "name:type[=default]" entries added to the section, e.g.
+	// "timeout:duration=30s". Keys already in config/default.yml keep their
+	// value; a type given here overrides the inferred one.
+	Fields []string
+}
+
+// configField is one key of a configuration section as seen by templates.
+type configField struct {
+	Key         string // YAML key, e.g. "api_key"
+	GoName      string // e.g. "ApiKey"
+	Type        string // Go type, e.g. "time.Duration"
+	GoDefault   string // Go expression of the default, e.g. "30 * time.Second"
+	YAMLDefault string // YAML value written for new keys
+	Env         string // Environment variable, e.g. "PAYMENT_API_KEY"
+}
+
+// GenerateConfig writes a typed struct for a section of config/default.yml
+// with its defaults and environment variable bindings. Keys already in the
+// section become fields, and keys passed as fields that are missing from the
+// file are added to it, so running the generator again after editing
+// either side keeps the two in sync. The first section also creates the
+// package's Load function.
+func GenerateConfig(name string, options ConfigOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid config name '%s' (use lowercase words separated by hyphens, e.g. payment)", name)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, configSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	section := strcase.ToSnake(name)
+	fields, added, err := syncConfigSection(projectRoot, section, options.Fields)
+	if err != nil {
+		return err
+	}
+
+	usesTime := false
+	for _, field := range fields {
+		usesTime = usesTime || strings.Contains(field.Type+field.GoDefault, "time.")
+	}
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   strcase.ToCamel(name),
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"section":  section,
+			"fields":   fields,
+			"usesTime": usesTime,
+		},
+	}
+
+	logger.ComponentGenerationStart("config", name)
+
+	var written []string
+	write := func(template, target string, onConflict string) error {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/config", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// The loader belongs to the project once created
+	loader := path.Join(dir, "config.go")
+	if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(loader))); err != nil {
+		if err := write("config.go.tpl", loader, ConflictSkip); err != nil {
+			return err
+		}
+	}
+	sectionFile := path.Join(dir, section+".go")
+	if err := write("section.go.tpl", sectionFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	for _, key := range added {
+		logger.Info("📝 Added %s.%s to %s", section, key, oidcAppConfig)
+	}
+	if len(written) == 0 && len(added) == 0 {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, []string{"github.com/spf13/viper"}); err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationComplete("config", name, filepath.Join(projectRoot, filepath.FromSlash(sectionFile)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Load the configuration once in main: %s.Load()", data.PackageName)
+	logger.Info("   2. Read the section: %s.%s()", data.PackageName, data.NameTitle)
+	logger.Info("   3. Override values with environment variables, e.g. %s", fields[0].Env)
+	logger.Info("   4. After editing the %s section, rerun: goforge g config %s", section, name)
+
+	return nil
+}
+
+// syncConfigSection merges the section's keys in config/default.yml with the
+// requested fields, adds the requested keys the file lacks, and returns
+// every field of the section along with the added keys. The file is
+// created when missing. Invalid fields are reported together as
+// validation.ValidationErrors.
+func syncConfigSection(projectRoot, section string, requested []string) ([]configField, []string, error) {
+	configPath := filepath.Join(projectRoot, filepath.FromSlash(oidcAppConfig))
+	data, err := os.ReadFile(configPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, nil, err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", oidcAppConfig, err)
+	}
+	var keyNode, valueNode *yaml.Node
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 && doc.Content[0].Kind == yaml.MappingNode {
+		root := doc.Content[0]
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == section {
+				keyNode, valueNode = root.Content[i], root.Content[i+1]
+			}
+		}
+	}
+	if valueNode != nil && valueNode.Tag != "!!null" && valueNode.Kind != yaml.MappingNode {
+		return nil, nil, fmt.Errorf("%s in %s is not a mapping", section, oidcAppConfig)
+	}
+
+	// Keys in the file come first, in file order
+	var fields []configField
+	index := make(map[string]int)
+	if valueNode != nil {
+		for i := 0; i+1 < len(valueNode.Content); i += 2 {
+			field := inferConfigField(section, valueNode.Content[i].Value, valueNode.Content[i+1])
+			index[field.Key] = len(fields)
+			fields = append(fields, field)
+		}
+	}
+
+	var errs validation.ValidationErrors
+	var added []configField
+	for _, raw := range requested {
+		field, invalid := parseConfigField(section, raw)
+		if invalid != nil {
+			errs = append(errs, invalid)
+			continue
+		}
+		if i, ok := index[field.Key]; ok {
+			// The file's value stays; only the type changes
+			var err error
+			field.GoDefault, field.YAMLDefault, err = configDefault(field.Type, yamlValue(valueNode, field.Key))
+			if err != nil {
+				errs = append(errs, &validation.ValidationError{Field: "field." + field.Key, Value: raw, Message: err.Error() + " in " + oidcAppConfig})
+				continue
+			}
+			fields[i] = field
+			continue
+		}
+		index[field.Key] = len(fields)
+		fields = append(fields, field)
+		added = append(added, field)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, nil, err
+	}
+	if len(fields) == 0 {
+		return nil, nil, fmt.Errorf("the %s section has no keys\n\nAdd them with --field name:type[=default], e.g. --field timeout:duration=30s", section)
+	}
+	if len(added) == 0 {
+		return fields, nil, nil
+	}
+
+	var lines []string
+	var keys []string
+	for _, field := range added {
+		lines = append(lines, fmt.Sprintf("%s: %s", field.Key, field.YAMLDefault))
+		keys = append(keys, field.Key)
+	}
+	if valueNode != nil && valueNode.Style&yaml.FlowStyle != 0 {
+		return nil, nil, fmt.Errorf("%s in %s is written inline; add %s to it yourself and run the command again", section, oidcAppConfig, strings.Join(keys, ", "))
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), os.ModePerm); err != nil {
+		return nil, nil, err
+	}
+	updated := insertSectionKeys(string(data), section, keyNode, valueNode, lines)
+	if err := os.WriteFile(configPath, []byte(updated), 0644); err != nil {
+		return nil, nil, err
+	}
+	return fields, keys, nil
+}
+
+// insertSectionKeys adds lines to the section of a YAML document, after its
+// last key, or appends the section when keyNode is nil.
+func insertSectionKeys(content, section string, keyNode, valueNode *yaml.Node, lines []string) string {
+	indent := "  "
+	if valueNode != nil && len(valueNode.Content) > 0 {
+		indent = strings.Repeat(" ", valueNode.Content[0].Column-1)
+	}
+	for i, line := range lines {
+		lines[i] = indent + line
+	}
+
+	if keyNode == nil {
+		if content != "" && !strings.HasSuffix(content, "\n") {
+			content += "\n"
+		}
+		if content != "" {
+			content += "\n"
+		}
+		return content + section + ":\n" + strings.Join(lines, "\n") + "\n"
+	}
+
+	all := strings.Split(content, "\n")
+	start := keyNode.Line - 1
+	if valueNode.Tag == "!!null" {
+		// e.g. "payment: ~" becomes a mapping
+		all[start] = section + ":"
+	}
+
+	// The section ends before the next top-level key, not counting the
+	// blank lines and comments leading up to it
+	end := len(all)
+	for i := start + 1; i < len(all); i++ {
+		line := all[i]
+		if strings.TrimSpace(line) != "" && !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") && !strings.HasPrefix(line, "#") {
+			end = i
+			break
+		}
+	}
+	for end > start+1 {
+		previous := strings.TrimSpace(all[end-1])
+		if previous != "" && !strings.HasPrefix(previous, "#") {
+			break
+		}
+		end--
+	}
+
+	result := append([]string{}, all[:end]...)
+	result = append(result, lines...)
+	return strings.Join(append(result, all[end:]...), "\n")
+}
+
+// parseConfigField parses a "name:type[=default]" field.
+func parseConfigField(section, raw string) (configField, *validation.ValidationError) {
+	spec, value, hasDefault := strings.Cut(raw, "=")
+	name, typeName, _ := strings.Cut(spec, ":")
+	if !variableNamePattern.MatchString(name) {
+		return configField{}, &validation.ValidationError{
+			Field:       "field",
+			Value:       raw,
+			Message:     "invalid field name",
+			Suggestions: []string{"Use name:type[=default], e.g. api_key:string or timeout:duration=30s"},
+		}
+	}
+	if typeName == "" {
+		typeName = "string"
+	}
+	goType, ok := configFieldTypes[typeName]
+	if !ok {
+		return configField{}, &validation.ValidationError{
+			Field:       "field." + name,
+			Value:       typeName,
+			Message:     "unsupported type",
+			Suggestions: []string{"Use string, int, int64, bool, float64, duration, []string, []int or map[string]string"},
+		}
+	}
+
+	field := newConfigField(section, strcase.ToSnake(name), goType)
+	var node *yaml.Node
+	if hasDefault {
+		node = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+	}
+	var err error
+	field.GoDefault, field.YAMLDefault, err = configDefault(goType, node)
+	if err != nil {
+		return configField{}, &validation.ValidationError{Field: "field." + name, Value: value, Message: err.Error()}
+	}
+	return field, nil
+}
+
+// inferConfigField types a key found in config/default.yml from its value.
+func inferConfigField(section, key string, value *yaml.Node) configField {
+	goType := "string"
+	switch value.Kind {
+	case yaml.ScalarNode:
+		switch value.Tag {
+		case "!!bool":
+			goType = "bool"
+		case "!!int":
+			goType = "int"
+		case "!!float":
+			goType = "float64"
+		case "!!str":
+			if _, err := time.ParseDuration(value.Value); err == nil && strings.IndexFunc(value.Value, isLetter) >= 0 {
+				goType = "time.Duration"
+			}
+		}
+	case yaml.SequenceNode:
+		goType = "[]int"
+		for _, item := range value.Content {
+			if item.Tag != "!!int" {
+				goType = "[]string"
+			}
+		}
+	case yaml.MappingNode:
+		goType = "map[string]string"
+		for i := 1; i < len(value.Content); i += 2 {
+			if value.Content[i].Kind != yaml.ScalarNode {
+				goType = "map[string]any"
+			}
+		}
+	}
+
+	field := newConfigField(section, key, goType)
+	field.GoDefault, field.YAMLDefault, _ = configDefault(goType, value)
+	return field
+}
+
+// newConfigField names a field of the section.
+func newConfigField(section, key, goType string) configField {
+	return configField{
+		Key:    key,
+		GoName: strcase.ToCamel(key),
+		Type:   goType,
+		Env:    strings.ToUpper(envUnsafe.ReplaceAllString(section+"_"+key, "_")),
+	}
+}
+
+// configDefault renders a YAML value as a Go expression of the type and as
+// YAML. A nil value stands for the zero value.
+func configDefault(goType string, value *yaml.Node) (string, string, error) {
+	raw := ""
+	if value != nil && value.Kind == yaml.ScalarNode && value.Tag != "!!null" {
+		raw = value.Value
+	}
+
+	switch goType {
+	case "string":
+		return strconv.Quote(raw), strconv.Quote(raw), nil
+	case "int", "int64":
+		if raw == "" {
+			return "0", "0", nil
+		}
+		if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+			return "", "", fmt.Errorf("not an integer")
+		}
+		return raw, raw, nil
+	case "float64":
+		if raw == "" {
+			return "0.0", "0.0", nil
+		}
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return "", "", fmt.Errorf("not a number")
+		}
+		expr := strconv.FormatFloat(f, 'g', -1, 64)
+		if !strings.ContainsAny(expr, ".e") {
+			expr += ".0"
+		}
+		return expr, expr, nil
+	case "bool":
+		if raw == "" {
+			return "false", "false", nil
+		}
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "", "", fmt.Errorf("not a boolean")
+		}
+		return strconv.FormatBool(b), strconv.FormatBool(b), nil
+	case "time.Duration":
+		if raw == "" {
+			return "time.Duration(0)", `"0s"`, nil
+		}
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return "", "", fmt.Errorf("not a duration such as 30s or 5m")
+		}
+		if d == 0 {
+			return "time.Duration(0)", `"0s"`, nil
+		}
+		return durationExpr(d), strconv.Quote(raw), nil
+	case "[]string", "[]int":
+		var items []string
+		if value != nil && value.Kind == yaml.SequenceNode {
+			for _, item := range value.Content {
+				items = append(items, item.Value)
+			}
+		} else if raw != "" {
+			items = strings.Split(raw, ",")
+		}
+		goItems := make([]string, len(items))
+		for i, item := range items {
+			item = strings.TrimSpace(item)
+			if goType == "[]int" {
+				if _, err := strconv.Atoi(item); err != nil {
+					return "", "", fmt.Errorf("'%s' is not an integer", item)
+				}
+				goItems[i] = item
+			} else {
+				goItems[i] = strconv.Quote(item)
+			}
+		}
+		list := strings.Join(goItems, ", ")
+		return goType + "{" + list + "}", "[" + list + "]", nil
+	case "map[string]string":
+		var entries []string
+		if value != nil && value.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(value.Content); i += 2 {
+				entries = append(entries, fmt.Sprintf("%q: %q", value.Content[i].Value, value.Content[i+1].Value))
+			}
+		}
+		return "map[string]string{" + strings.Join(entries, ", ") + "}", "{}", nil
+	}
+	return "map[string]any{}", "{}", nil
+}
+
+// yamlValue returns the value of key in a mapping node, or nil.
+func yamlValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping == nil {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// isLetter reports whether r is an ASCII letter, telling "30s" from "30".
+func isLetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
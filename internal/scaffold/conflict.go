@@ -0,0 +1,204 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/night-slayer18/goforge/internal/diff"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/interactive"
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// Conflict resolution modes for regenerating an existing file.
+const (
+	ConflictPrompt    = "prompt"
+	ConflictSkip      = "skip"
+	ConflictOverwrite = "overwrite"
+	ConflictMerge     = "merge"
+)
+
+// snapshotDir holds the pristine output of the last generation for every
+// generated file, used as the base for three-way merges.
+const snapshotDir = ".goforge/generated"
+
+// ValidConflictMode reports whether mode is a known conflict mode.
+func ValidConflictMode(mode string) bool {
+	switch mode {
+	case "", ConflictPrompt, ConflictSkip, ConflictOverwrite, ConflictMerge:
+		return true
+	}
+	return false
+}
+
+// validate reports an unknown conflict mode.
+func (o GenerateOptions) validate() error {
+	if !ValidConflictMode(o.OnConflict) {
+		return exitcode.Errorf(exitcode.Validation, "unknown conflict mode '%s' (use prompt, skip, overwrite or merge)", o.OnConflict)
+	}
+	return nil
+}
+
+// writeGenerated writes rendered content to task.TargetPath, resolving
+// conflicts with an existing file according to mode. It returns false if
+// the file was left untouched.
+func (s *Scaffolder) writeGenerated(task FileGenerationTask, rendered []byte, projectRoot, mode string) (bool, error) {
+	existing, err := os.ReadFile(task.TargetPath)
+	if os.IsNotExist(err) {
+		if err := s.writeFile(task.TargetPath, rendered); err != nil {
+			return false, err
+		}
+		s.saveSnapshot(projectRoot, task.TargetPath, rendered)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("could not read existing file %s: %w", task.TargetPath, err)
+	}
+
+	relPath := relativeTo(projectRoot, task.TargetPath)
+	if string(existing) == string(rendered) {
+		logger.Info("✔️  %s is already up to date", relPath)
+		s.saveSnapshot(projectRoot, task.TargetPath, rendered)
+		return false, nil
+	}
+
+	if mode == "" || mode == ConflictPrompt {
+		mode, err = s.promptConflict(relPath, string(existing), applyKeepRegions(string(rendered), string(existing)))
+		if err != nil {
+			return false, err
+		}
+	}
+
+	switch mode {
+	case ConflictSkip:
+		logger.Info("⏭️  Skipped existing file: %s", relPath)
+		return false, nil
+
+	case ConflictOverwrite:
+		content := applyKeepRegions(string(rendered), string(existing))
+		if err := s.writeFile(task.TargetPath, []byte(content)); err != nil {
+			return false, err
+		}
+		s.saveSnapshot(projectRoot, task.TargetPath, rendered)
+		logger.Info("♻️  Overwrote %s (protected regions preserved)", relPath)
+		return true, nil
+
+	case ConflictMerge:
+		theirs := applyKeepRegions(string(rendered), string(existing))
+		var merged string
+		var conflicts int
+		if base, ok := s.loadSnapshot(projectRoot, task.TargetPath); ok {
+			merged, conflicts = diff.Merge3(base, string(existing), theirs)
+		} else {
+			// Without a record of the previous output there is no telling
+			// the user's edits from template changes, so every difference
+			// is left to the user as a conflict.
+			merged, conflicts = diff.Merge2(string(existing), theirs)
+		}
+		if err := s.writeFile(task.TargetPath, []byte(merged)); err != nil {
+			return false, err
+		}
+		s.saveSnapshot(projectRoot, task.TargetPath, rendered)
+		if conflicts > 0 {
+			logger.Warn("⚠️  Merged %s with %d conflict(s); resolve the <<<<<<< markers", relPath, conflicts)
+		} else {
+			logger.Info("🔀 Merged template changes into %s", relPath)
+		}
+		return true, nil
+
+	default:
+		return false, fmt.Errorf("unknown conflict mode '%s' (use prompt, skip, overwrite or merge)", mode)
+	}
+}
+
+// promptConflict asks how to handle an existing file, showing a diff on
+// request.
+func (s *Scaffolder) promptConflict(relPath, existing, rendered string) (string, error) {
+	if !interactive.IsInteractiveTerminal() {
+		return "", fmt.Errorf("file %s already exists\n\nUse --on-conflict skip|overwrite|merge (or --force) to decide what to do", relPath)
+	}
+
+	for {
+		choice, err := interactive.PromptChoice(
+			fmt.Sprintf("⚠️  %s already exists.", relPath),
+			[]string{ConflictSkip, ConflictOverwrite, "diff", ConflictMerge},
+			ConflictSkip,
+		)
+		if err != nil {
+			return "", err
+		}
+		if choice != "diff" {
+			return choice, nil
+		}
+		fmt.Print(diff.Unified(existing, rendered, relPath+" (current)", relPath+" (generated)", 3))
+	}
+}
+
+// writeFile writes content, creating parent directories as needed.
+func (s *Scaffolder) writeFile(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return fmt.Errorf("could not create parent directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("could not write file %s: %w", path, err)
+	}
+	logger.FileCreated(path)
+	writtenMu.Lock()
+	written = append(written, path)
+	writtenMu.Unlock()
+	return nil
+}
+
+// written lists the files the scaffolders wrote in this process, for
+// 'goforge generate --output json' to report.
+var (
+	written   []string
+	writtenMu sync.Mutex
+)
+
+// WrittenFiles returns the paths of the files written from templates so
+// far, in the order they were written.
+func WrittenFiles() []string {
+	writtenMu.Lock()
+	defer writtenMu.Unlock()
+	return slices.Clone(written)
+}
+
+func (s *Scaffolder) snapshotPath(projectRoot, targetPath string) string {
+	return filepath.Join(projectRoot, snapshotDir, relativeTo(projectRoot, targetPath))
+}
+
+// saveSnapshot records the pristine rendered output. Failures are only
+// logged since snapshots are an optimisation for later merges.
+func (s *Scaffolder) saveSnapshot(projectRoot, targetPath string, rendered []byte) {
+	if projectRoot == "" {
+		return
+	}
+	path := s.snapshotPath(projectRoot, targetPath)
+	err := os.MkdirAll(filepath.Dir(path), os.ModePerm)
+	if err == nil {
+		err = os.WriteFile(path, rendered, 0644)
+	}
+	if err != nil {
+		logger.Debug("Could not save generation snapshot for %s: %v", targetPath, err)
+	}
+}
+
+func (s *Scaffolder) loadSnapshot(projectRoot, targetPath string) (string, bool) {
+	data, err := os.ReadFile(s.snapshotPath(projectRoot, targetPath))
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+func relativeTo(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,411 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// consumerSpec places the consumers generated from an AsyncAPI document;
+// override it with layout.consumer in goforge.yml.
+var consumerSpec = ComponentSpec{Type: "consumer", Dir: "internal/adapters/messaging"}
+
+// consumerMain is the entry point running the consumers.
+const consumerMain = "cmd/consumer/main.go"
+
+// Brokers consumers can be generated for.
+const (
+	BrokerKafka = "kafka"
+	BrokerNATS  = "nats"
+)
+
+// consumerBrokers maps AsyncAPI server protocols to brokers.
+var consumerBrokers = map[string]string{
+	"kafka":        BrokerKafka,
+	"kafka-secure": BrokerKafka,
+	"nats":         BrokerNATS,
+}
+
+// consumerModules are the client libraries of each broker.
+var consumerModules = map[string]string{
+	BrokerKafka: "github.com/segmentio/kafka-go",
+	BrokerNATS:  "github.com/nats-io/nats.go",
+}
+
+// consumerAppConfig is added to config/default.yml with the first
+// consumers, per broker.
+var consumerAppConfig = map[string]string{
+	BrokerKafka: `# Message consumers (goforge generate consumer).
+messaging:
+  broker: kafka
+  brokers:
+    - localhost:9092
+  group: %s
+`,
+	BrokerNATS: `# Message consumers (goforge generate consumer).
+messaging:
+  broker: nats
+  url: nats://localhost:4222
+  queue: %s
+`,
+}
+
+// ConsumerOptions parameterizes generated consumers.
+type ConsumerOptions struct {
+	// Broker is kafka or nats; by default it follows the protocol of the
+	// document's servers.
+	Broker string
+}
+
+// consumerChannel is a consumed channel as seen by the consumer templates.
+type consumerChannel struct {
+	Channel     string // channel address, e.g. user.signedup
+	Subject     string // topic or subject subscribed to
+	Handler     string // Consumers method, e.g. HandleUserSignedUp
+	Message     string // Go type of the payload
+	ContentType string
+	Summary     string // comment lines
+	File        string // file of the stub, e.g. user_signed_up_consumer.go
+}
+
+// GenerateConsumer generates a consumer for every channel an AsyncAPI
+// document says the application receives from: message structs, the
+// subscription table decoding payloads with the registered codecs, a
+// handler stub per channel and a command running them on Kafka or NATS.
+// The structs and subscriptions are regenerated on every run; stubs are
+// only added for handlers the package does not declare yet.
+func GenerateConsumer(specFile string, options ConsumerOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+	if options.Broker != "" && consumerModules[options.Broker] == "" {
+		return fmt.Errorf("unknown broker '%s' (use kafka or nats)", options.Broker)
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, consumerSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	absSpec, err := filepath.Abs(specFile)
+	if err != nil {
+		return err
+	}
+	spec := filepath.ToSlash(relativeTo(projectRoot, absSpec))
+	doc, err := loadAsyncAPI(absSpec)
+	if err != nil {
+		return err
+	}
+
+	broker := options.Broker
+	if broker == "" {
+		if broker, err = detectBroker(doc); err != nil {
+			return fmt.Errorf("%s: %w", spec, err)
+		}
+	}
+
+	// Payload schemas are JSON Schema, which the OpenAPI type builder reads
+	types := newTypeBuilder(&openAPIDoc{Components: openAPIComponents{Schemas: doc.Components.Schemas}})
+	types.binding = false
+	if err := types.declareComponents(); err != nil {
+		return fmt.Errorf("%s: %w", spec, err)
+	}
+	channels, err := consumerChannels(doc, types, broker)
+	if err != nil {
+		return fmt.Errorf("%s: %w", spec, err)
+	}
+	if len(channels) == 0 {
+		return fmt.Errorf("%s: no channel is consumed by the application (AsyncAPI 2: 'publish' operations; 3: 'receive' operations)", spec)
+	}
+
+	declared, err := declaredMethods(filepath.Join(projectRoot, filepath.FromSlash(dir)), "Consumers")
+	if err != nil {
+		return err
+	}
+
+	pkg := packageNameFor(dir)
+	data := TemplateData{
+		ModulePath:  cfg.ModuleName,
+		ProjectName: cfg.ProjectName,
+		PackageName: pkg,
+		Vars: map[string]any{
+			"spec":     spec,
+			"types":    types.decls,
+			"usesTime": types.usesTime(),
+			"channels": channels,
+			"broker":   broker,
+			"import":   path.Join(cfg.ModuleName, dir),
+			"package":  pkg,
+		},
+	}
+
+	logger.ComponentGenerationStart("consumer", spec)
+
+	var written []string
+	render := func(template, target string, data TemplateData) (FileGenerationTask, []byte, error) {
+		task := FileGenerationTask{
+			TemplatePath: template,
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		return task, content, err
+	}
+	write := func(template, target string, data TemplateData, onConflict string) error {
+		task, content, err := render(template, target, data)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// The message structs and subscriptions are generated code, rewritten
+	// from the spec; the message structs share the api models template
+	for _, file := range []struct{ template, target string }{
+		{"templates/components/api/models.go.tpl", path.Join(dir, "messages.gen.go")},
+		{"templates/components/consumer/subscriptions.go.tpl", path.Join(dir, "subscriptions.gen.go")},
+	} {
+		if err := write(file.template, file.target, data, ConflictOverwrite); err != nil {
+			return err
+		}
+	}
+
+	// Shared files are created once and then belong to the project
+	mainData := data
+	mainData.PackageName = "main"
+	for _, file := range []struct {
+		template, target string
+		data             TemplateData
+	}{
+		{"consumers.go.tpl", path.Join(dir, "consumers.go"), data},
+		{"codec.go.tpl", path.Join(dir, "codec.go"), data},
+		{broker + ".go.tpl", path.Join(dir, broker+".go"), data},
+		{"main.go.tpl", consumerMain, mainData},
+	} {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			continue
+		}
+		if err := write(path.Join("templates/components/consumer", file.template), file.target, file.data, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	// Stubs are added for handlers the package does not declare yet, into
+	// their file, which is created or appended to
+	added := 0
+	for _, channel := range channels {
+		if declared[channel.Handler] {
+			continue
+		}
+		added++
+		stubData := data
+		stubData.Vars = map[string]any{"channel": channel}
+		target := path.Join(dir, channel.File)
+		absTarget := filepath.Join(projectRoot, filepath.FromSlash(target))
+		if _, err := os.Stat(absTarget); os.IsNotExist(err) {
+			if err := write("templates/components/consumer/handler.go.tpl", target, stubData, genOptions.OnConflict); err != nil {
+				return err
+			}
+			continue
+		}
+		_, content, err := render("templates/components/consumer/handler.go.tpl", target, stubData)
+		if err != nil {
+			return err
+		}
+		if err := appendDecls(absTarget, content); err != nil {
+			return fmt.Errorf("could not add %s to %s: %w", channel.Handler, target, err)
+		}
+		written = append(written, absTarget)
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	configState, err := addAppConfig(projectRoot, "messaging", fmt.Sprintf(consumerAppConfig[broker], cfg.ProjectName))
+	if err != nil {
+		logger.Warn("Could not add the messaging section to %s: %v", oidcAppConfig, err)
+	}
+	if configState == configPresent {
+		logger.Info("✔️  %s already has a messaging section; check it configures %s", oidcAppConfig, broker)
+	}
+	if err := recordDependencies(cfg, projectRoot, []string{consumerModules[broker], "github.com/spf13/viper"}); err != nil {
+		return err
+	}
+	for script, command := range map[string]string{
+		"consumer":          "go run ./" + path.Dir(consumerMain),
+		"consumer:generate": fmt.Sprintf("goforge g consumer --from %s --broker %s", spec, broker),
+	} {
+		if _, ok := cfg.Scripts[script]; ok {
+			continue
+		}
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", script}, command); err != nil {
+			logger.Warn("Could not add the %s script to goforge.yml: %v", script, err)
+		}
+	}
+
+	if added > 0 {
+		logger.Info("🧩 Added %d handler stub(s)", added)
+	} else {
+		logger.Info("✔️  Every channel already has a handler")
+	}
+
+	logger.ComponentGenerationComplete("consumer", spec, filepath.Join(projectRoot, filepath.FromSlash(dir)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Implement the handler stubs in %s", dir)
+	logger.Info("   2. Configure the %s connection under messaging in %s", broker, oidcAppConfig)
+	logger.Info("   3. Start the consumers: goforge run consumer (or go run ./%s)", path.Dir(consumerMain))
+	logger.Info("   4. After changing %s, regenerate with: goforge run consumer:generate", spec)
+
+	return nil
+}
+
+// detectBroker picks the broker from the protocols of the document's
+// servers.
+func detectBroker(doc *asyncAPIDoc) (string, error) {
+	found := make(map[string]bool)
+	for protocol := range doc.protocols() {
+		if broker, ok := consumerBrokers[protocol]; ok {
+			found[broker] = true
+		}
+	}
+	switch {
+	case len(found) == 1:
+		for broker := range found {
+			return broker, nil
+		}
+	case len(found) > 1:
+		return "", fmt.Errorf("servers use both Kafka and NATS; choose one with --broker")
+	}
+	return "", fmt.Errorf("no Kafka or NATS server is defined; choose the broker with --broker")
+}
+
+// consumerChannels lists the consumed channels in document order,
+// declaring types for inline payloads.
+func consumerChannels(doc *asyncAPIDoc, types *typeBuilder, broker string) ([]*consumerChannel, error) {
+	received, err := doc.received()
+	if err != nil {
+		return nil, err
+	}
+
+	var channels []*consumerChannel
+	handlers := make(map[string]string)
+	for _, r := range received {
+		channel := &consumerChannel{
+			Channel:     r.Address,
+			Subject:     r.Address,
+			ContentType: r.Message.ContentType,
+			Summary:     commentLines(r.Summary),
+		}
+		if channel.ContentType == "" {
+			channel.ContentType = doc.DefaultContentType
+		}
+		if channel.ContentType == "" {
+			channel.ContentType = "application/json"
+		}
+		if channel.Summary == "" {
+			channel.Summary = commentLines(r.Message.Summary)
+		}
+
+		name := r.OperationID
+		if name == "" {
+			name = r.MessageName
+		}
+		if name == "" {
+			name = r.Key
+		}
+		name = strcase.ToCamel(strings.NewReplacer(".", " ", "/", " ", "{", "", "}", "").Replace(name))
+		channel.Handler = name
+		if !strings.HasPrefix(name, "Handle") && !strings.HasPrefix(name, "On") {
+			channel.Handler = "Handle" + name
+		}
+		if !token.IsIdentifier(channel.Handler) {
+			return nil, fmt.Errorf("channel %s: '%s' does not make a Go method name", r.Key, channel.Handler)
+		}
+		if other, ok := handlers[channel.Handler]; ok {
+			return nil, fmt.Errorf("channels %s and %s both map to the handler %s; give one an operationId", other, r.Key, channel.Handler)
+		}
+		handlers[channel.Handler] = r.Key
+		channel.File = strcase.ToSnake(strings.TrimPrefix(strings.TrimPrefix(channel.Handler, "Handle"), "On")) + "_consumer.go"
+
+		hint := strcase.ToCamel(r.MessageName)
+		if hint == "" {
+			hint = strings.TrimPrefix(strings.TrimPrefix(channel.Handler, "Handle"), "On") + "Message"
+		}
+		if r.Message.Payload == nil {
+			channel.Message = "map[string]any"
+		} else if channel.Message, err = types.goType(r.Message.Payload, hint); err != nil {
+			return nil, fmt.Errorf("channel %s: payload: %w", r.Key, err)
+		}
+
+		if pathParamPattern.MatchString(r.Address) {
+			if broker == BrokerNATS {
+				channel.Subject = pathParamPattern.ReplaceAllString(r.Address, "*")
+			} else {
+				logger.Warn("⚠️  %s: Kafka topics cannot have parameters; name a concrete topic in the spec", r.Key)
+			}
+		}
+		if r.Extra > 0 {
+			logger.Warn("⚠️  %s: only the first of its %d messages gets a type; %s receives every message as %s", r.Key, r.Extra+1, channel.Handler, channel.Message)
+		}
+		channels = append(channels, channel)
+	}
+	return channels, nil
+}
+
+// declaredMethods returns the methods declared on recv in the Go files of
+// dir, which may not exist yet.
+func declaredMethods(dir, recv string) (map[string]bool, error) {
+	methods := make(map[string]bool)
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return methods, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", entry.Name(), err)
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+				continue
+			}
+			expr := fn.Recv.List[0].Type
+			if star, ok := expr.(*ast.StarExpr); ok {
+				expr = star.X
+			}
+			if ident, ok := expr.(*ast.Ident); ok && ident.Name == recv {
+				methods[fn.Name.Name] = true
+			}
+		}
+	}
+	return methods, nil
+}
@@ -0,0 +1,172 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/packs"
+	"github.com/night-slayer18/goforge/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// CustomTemplatesDir is where a project keeps its own component templates.
+const CustomTemplatesDir = ".goforge/templates"
+
+// customManifest is the optional <type>.yml file next to a custom template.
+type customManifest struct {
+	Description string             `yaml:"description"`
+	Dir         string             `yaml:"dir"`
+	File        string             `yaml:"file"`
+	Variables   []TemplateVariable `yaml:"variables"`
+}
+
+// CustomComponents discovers the component templates shipped with the
+// project in .goforge/templates. A template named usecase.go.tpl defines
+// the "usecase" component; an optional usecase.yml manifest sets its
+// description, output directory, and file name pattern. A custom template
+// with the name of a built-in component overrides the built-in template.
+func CustomComponents(projectRoot string) ([]ComponentSpec, error) {
+	return discoverComponents(filepath.Join(projectRoot, CustomTemplatesDir))
+}
+
+// PackComponents discovers the generators shipped in a template pack.
+// Their types are prefixed with the pack name, e.g. "org/consumer".
+func PackComponents(pack *packs.Pack) ([]ComponentSpec, error) {
+	specs, err := discoverComponents(pack.GeneratorsDir())
+	if err != nil {
+		return nil, err
+	}
+	for i := range specs {
+		specs[i].Type = pack.Name + "/" + specs[i].Type
+	}
+	return specs, nil
+}
+
+// discoverComponents loads every <type>.go.tpl (and <type>.yml manifest)
+// in dir.
+func discoverComponents(dir string) ([]ComponentSpec, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+
+	var specs []ComponentSpec
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go.tpl") {
+			continue
+		}
+
+		componentType := strings.TrimSuffix(entry.Name(), ".go.tpl")
+		spec := ComponentSpec{
+			Type:        componentType,
+			Description: "Custom project generator",
+			Template:    entry.Name(),
+			Dir:         "internal/" + componentType,
+			FileName:    "%s_" + componentType + ".go",
+			Source:      os.DirFS(dir),
+		}
+
+		// Overrides inherit the location of the built-in component.
+		if builtin, ok := LookupComponent(componentType); ok {
+			spec.Description = builtin.Description
+			spec.Dir = builtin.Dir
+			spec.FileName = builtin.FileName
+		}
+
+		manifestPath := filepath.Join(dir, componentType+".yml")
+		if data, err := os.ReadFile(manifestPath); err == nil {
+			var manifest customManifest
+			if err := yaml.Unmarshal(data, &manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", manifestPath, err)
+			}
+			if manifest.Description != "" {
+				spec.Description = manifest.Description
+			}
+			if manifest.Dir != "" {
+				spec.Dir = filepath.ToSlash(filepath.Clean(manifest.Dir))
+			}
+			if err := checkVariables(manifest.Variables); err != nil {
+				return nil, fmt.Errorf("%s: %w", manifestPath, err)
+			}
+			spec.Variables = manifest.Variables
+			if manifest.File != "" {
+				if !strings.Contains(manifest.File, "%s") {
+					return nil, fmt.Errorf("%s: 'file' must contain %%s for the component name", manifestPath)
+				}
+				spec.FileName = manifest.File
+			}
+		}
+
+		specs = append(specs, spec)
+	}
+
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Type < specs[j].Type })
+	return specs, nil
+}
+
+// resolveComponent finds the spec for a component type, preferring the
+// project's custom templates over the built-in ones.
+func resolveComponent(projectRoot, componentType string) (ComponentSpec, error) {
+	if strings.Contains(componentType, "/") {
+		return resolvePackComponent(componentType)
+	}
+
+	custom, err := CustomComponents(projectRoot)
+	if err != nil {
+		return ComponentSpec{}, err
+	}
+	for _, spec := range custom {
+		if spec.Type == componentType {
+			return spec, nil
+		}
+	}
+
+	if spec, ok := LookupComponent(componentType); ok {
+		return spec, nil
+	}
+
+	types := ComponentTypes()
+	for _, spec := range custom {
+		types = append(types, spec.Type)
+	}
+	if suggestion := utils.Closest(componentType, types); suggestion != "" {
+		return ComponentSpec{}, exitcode.Errorf(exitcode.Validation, "unknown component type: %s, did you mean '%s'?", componentType, suggestion)
+	}
+	return ComponentSpec{}, exitcode.Errorf(exitcode.Validation, "unknown component type: %s\n\nAvailable types: %s\n\nAdd your own by creating %s/%s.go.tpl",
+		componentType, strings.Join(types, ", "), CustomTemplatesDir, componentType)
+}
+
+// templateSource returns the filesystem a spec's template is read from.
+func (spec ComponentSpec) templateSource() fs.FS {
+	if spec.Source != nil {
+		return spec.Source
+	}
+	return templatesFS
+}
+
+// resolvePackComponent finds a "<pack>/<type>" generator.
+func resolvePackComponent(ref string) (ComponentSpec, error) {
+	pack, item, err := packs.Resolve(ref)
+	if err != nil {
+		return ComponentSpec{}, err
+	}
+
+	specs, err := PackComponents(pack)
+	if err != nil {
+		return ComponentSpec{}, err
+	}
+	for _, spec := range specs {
+		if spec.Type == ref {
+			return spec, nil
+		}
+	}
+	return ComponentSpec{}, fmt.Errorf("generator '%s' not found in pack '%s' (%s)", item, pack.Name, pack.Version)
+}
@@ -0,0 +1,184 @@
+package scaffold
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/database"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// DefaultDBModelLayers are generated for each table by 'goforge generate
+// models --from-db'.
+var DefaultDBModelLayers = []string{"model", "port", "repository"}
+
+// migrationTables record applied migrations rather than domain data.
+var migrationTables = []string{"schema_migrations", "goose_db_version", "atlas_schema_revisions"}
+
+// modelColumns are the columns the model template declares itself.
+var modelColumns = map[string]bool{"id": true, "created_at": true, "updated_at": true}
+
+// DBModelsOptions select the tables 'goforge generate models --from-db'
+// generates entities for.
+type DBModelsOptions struct {
+	Tables  []string // only these tables; all when empty
+	Exclude []string
+	Layers  []string // DefaultDBModelLayers when empty
+}
+
+// sqlGoTypes maps column types, without their size or precision, to Go
+// types.
+var sqlGoTypes = map[string]string{
+	"smallint": "int16", "int2": "int16", "tinyint": "int16", "year": "int16", "smallserial": "int16",
+	"integer": "int32", "int": "int32", "int4": "int32", "mediumint": "int32", "serial": "int32",
+	"bigint": "int64", "int8": "int64", "bigserial": "int64",
+	"boolean": "bool", "bool": "bool", "bit": "bool",
+	"real": "float32", "float4": "float32", "float": "float32",
+	"double precision": "float64", "double": "float64", "float8": "float64", "numeric": "float64", "decimal": "float64",
+	"text": "string", "character varying": "string", "varchar": "string", "character": "string", "char": "string",
+	"bpchar": "string", "citext": "string", "tinytext": "string", "mediumtext": "string", "longtext": "string",
+	"enum": "string", "set": "string", "inet": "string", "cidr": "string", "xml": "string", "interval": "string",
+	"time": "string", "time without time zone": "string", "time with time zone": "string",
+	"uuid":  "uuid.UUID",
+	"bytea": "[]byte", "blob": "[]byte", "tinyblob": "[]byte", "mediumblob": "[]byte", "longblob": "[]byte",
+	"binary": "[]byte", "varbinary": "[]byte",
+	"json": "json.RawMessage", "jsonb": "json.RawMessage",
+	"date": "time.Time", "datetime": "time.Time", "timestamp": "time.Time", "timestamptz": "time.Time",
+	"timestamp with time zone": "time.Time", "timestamp without time zone": "time.Time",
+}
+
+// columnModifiers are the size, precision, and attributes dropped from a
+// column type before looking it up, as in varchar(255) or int unsigned.
+var columnModifiers = regexp.MustCompile(`\(.*\)|\s+(unsigned|zerofill)\b`)
+
+// goFieldType returns the Go type of a column, a pointer when it is
+// nullable, and false for types it does not know, which become strings.
+func goFieldType(column database.Column) (string, bool) {
+	sqlType := column.Type
+	array := strings.HasPrefix(sqlType, "_") || strings.HasSuffix(sqlType, "[]")
+	sqlType = strings.TrimSuffix(strings.TrimPrefix(sqlType, "_"), "[]")
+
+	goType, known := "string", true
+	if sqlType == "tinyint(1)" {
+		goType = "bool" // MySQL's boolean
+	} else if t, ok := sqlGoTypes[strings.TrimSpace(columnModifiers.ReplaceAllString(sqlType, ""))]; ok {
+		goType = t
+	} else {
+		known = false
+	}
+
+	switch {
+	case array:
+		return "[]" + goType, known
+	case column.Nullable && !strings.HasPrefix(goType, "[]") && goType != "json.RawMessage":
+		return "*" + goType, known
+	}
+	return goType, known
+}
+
+// singularize undoes pluralize for table names: users to user,
+// categories to category, and addresses to address.
+func singularize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "zes"),
+		strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// DBModelsSpec describes an entity for each selected table, named after
+// the singular of the table, with a field for each column but those the
+// model declares itself. migrationsTable is left out along with the
+// bookkeeping tables of the migration engines.
+func DBModelsSpec(tables []database.Table, migrationsTable string, options DBModelsOptions) (*BatchSpec, error) {
+	for _, name := range options.Tables {
+		if !slices.ContainsFunc(tables, func(t database.Table) bool { return t.Name == name }) {
+			return nil, fmt.Errorf("table '%s' not found in the database", name)
+		}
+	}
+
+	spec := &BatchSpec{Layers: options.Layers}
+	if len(spec.Layers) == 0 {
+		spec.Layers = DefaultDBModelLayers
+	}
+	for _, table := range tables {
+		switch {
+		case len(options.Tables) > 0 && !slices.Contains(options.Tables, table.Name),
+			slices.Contains(options.Exclude, table.Name),
+			len(options.Tables) == 0 && (slices.Contains(migrationTables, table.Name) || table.Name == migrationsTable):
+			continue
+		}
+
+		name := strcase.ToLowerCamel(singularize(table.Name))
+		if !variableNamePattern.MatchString(name) {
+			logger.Warn("⚠️  Skipped table %s: its name does not make a Go identifier", table.Name)
+			continue
+		}
+		entity := EntitySpec{Name: name, Table: table.Name}
+		for _, column := range table.Columns {
+			if modelColumns[column.Name] {
+				if goType, _ := goFieldType(column); column.Name == "id" && goType != "int64" && goType != "int32" {
+					logger.Warn("⚠️  %s.id is a %s, while the model and repository use int64 IDs", table.Name, column.Type)
+				}
+				continue
+			}
+			if !variableNamePattern.MatchString(column.Name) {
+				logger.Warn("⚠️  Skipped column %s.%s: its name does not make a Go identifier", table.Name, column.Name)
+				continue
+			}
+			goType, known := goFieldType(column)
+			if !known {
+				logger.Warn("⚠️  %s.%s is a %s, mapped to %s; check the field type", table.Name, column.Name, column.Type, goType)
+			}
+			// Zero numbers and false are valid values, so only text is required
+			entity.Fields = append(entity.Fields, FieldSpec{Name: column.Name, Type: goType, Required: goType == "string"})
+		}
+		spec.Entities = append(spec.Entities, entity)
+	}
+	if len(spec.Entities) == 0 {
+		return nil, fmt.Errorf("no tables to generate models for")
+	}
+	return spec, nil
+}
+
+// GenerateModelsFromDB adds the modules of the field types, then
+// generates the layers of every entity in spec, as built by DBModelsSpec.
+func GenerateModelsFromDB(spec *BatchSpec, options GenerateOptions) error {
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	// Added first, so formatting the models finds the imports
+	uuids := slices.ContainsFunc(spec.Entities, func(e EntitySpec) bool {
+		return slices.ContainsFunc(e.Fields, func(f FieldSpec) bool { return strings.Contains(f.Type, "uuid.UUID") })
+	})
+	if uuids {
+		if err := recordDependencies(cfg, projectRoot, []string{"github.com/google/uuid"}); err != nil {
+			return err
+		}
+	}
+
+	if err := GenerateBatch(spec, options); err != nil {
+		return err
+	}
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Review the field types and validation tags of the generated models")
+	step := 2
+	if slices.Contains(spec.Layers, "repository") {
+		logger.Info("   %d. Fill in the repository queries for the columns beyond id, created_at, and updated_at", step)
+		step++
+	}
+	logger.Info("   %d. Keep the schema in migrations from now on: goforge migrate create <name>", step)
+	return nil
+}
@@ -0,0 +1,140 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// devcontainerTemplateDir holds the templates of 'goforge generate
+// devcontainer'.
+const devcontainerTemplateDir = "templates/components/devcontainer"
+
+// DevcontainerOptions parameterizes the generated dev container.
+type DevcontainerOptions struct {
+	// VSCode also writes the editor settings and extension
+	// recommendations to .vscode/.
+	VSCode bool
+}
+
+// devcontainerFiles maps the templates in devcontainerTemplateDir to the
+// files they render into the project root; the .vscode files are written
+// with DevcontainerOptions.VSCode only.
+var devcontainerFiles = []struct{ template, target string }{
+	{"devcontainer.json.tpl", ".devcontainer/devcontainer.json"},
+	{"docker-compose.yml.tpl", ".devcontainer/docker-compose.yml"},
+	{"settings.json.tpl", ".vscode/settings.json"},
+	{"extensions.json.tpl", ".vscode/extensions.json"},
+}
+
+// usesRedis reports whether the project depends on a Redis client.
+func usesRedis(cfg *project.Config) bool {
+	for dependency := range cfg.Dependencies {
+		if strings.HasPrefix(dependency, "github.com/redis/go-redis") {
+			return true
+		}
+	}
+	return false
+}
+
+// editorExtensions returns the VS Code extensions for the project's
+// languages and tools.
+func editorExtensions(projectRoot string, database string) []string {
+	extensions := []string{"golang.go", "redhat.vscode-yaml"}
+	exists := func(name string) bool {
+		_, err := os.Stat(filepath.Join(projectRoot, name))
+		return err == nil
+	}
+	if HasTemplComponents(projectRoot) {
+		extensions = append(extensions, "a-h.templ")
+	}
+	if exists("buf.yaml") || exists("buf.gen.yaml") {
+		extensions = append(extensions, "bufbuild.vscode-buf")
+	}
+	if exists("gqlgen.yml") {
+		extensions = append(extensions, "graphql.vscode-graphql")
+	}
+	if exists("Dockerfile") || exists("docker-compose.yml") {
+		extensions = append(extensions, "ms-azuretools.vscode-docker")
+	}
+	if database == "mongodb" {
+		extensions = append(extensions, "mongodb.mongodb-vscode")
+	}
+	return extensions
+}
+
+// GenerateDevcontainer writes .devcontainer/devcontainer.json with the Go
+// toolchain and goforge, and a docker-compose.yml running the workspace
+// next to the project's database and Redis when it uses them.
+func GenerateDevcontainer(options DevcontainerOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	logger.ComponentGenerationStart("devcontainer", cfg.ProjectName)
+
+	database := projectDatabase(cfg)
+	redis := usesRedis(cfg)
+	port := 0
+	if cfg.Docker != nil {
+		port = cfg.Docker.Port
+	}
+	data := TemplateData{
+		ProjectName: cfg.ProjectName,
+		ModuleName:  cfg.ModuleName,
+		GoVersion:   cfg.GoVersion,
+		Vars: map[string]any{
+			"image":      "mcr.microsoft.com/devcontainers/go:1-" + ciGoVersions(cfg.GoVersion)[0] + "-bookworm",
+			"database":   database,
+			"redis":      redis,
+			"compose":    database != "" || redis,
+			"port":       port,
+			"extensions": editorExtensions(projectRoot, database),
+		},
+	}
+
+	var written []string
+	for _, file := range devcontainerFiles {
+		if path.Dir(file.target) == ".vscode" && !options.VSCode {
+			continue
+		}
+		task := FileGenerationTask{
+			TemplatePath: path.Join(devcontainerTemplateDir, file.template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(file.target)),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		if len(bytes.TrimSpace(content)) == 0 {
+			continue
+		}
+		if _, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict); err != nil {
+			return err
+		}
+		written = append(written, file.target)
+	}
+
+	logger.ComponentGenerationComplete("devcontainer", strings.Join(written, ", "), projectRoot)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Open the project in VS Code and run 'Dev Containers: Reopen in Container'")
+	logger.Info("   2. Or start it from the command line: devcontainer up --workspace-folder .")
+	return nil
+}
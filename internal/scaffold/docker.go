@@ -0,0 +1,168 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// dockerTemplateDir holds the templates of 'goforge generate docker'.
+const dockerTemplateDir = "templates/components/docker"
+
+// dockerFiles maps the templates in dockerTemplateDir to the files they
+// render into the project root.
+var dockerFiles = []struct{ template, target string }{
+	{"Dockerfile.tpl", "Dockerfile"},
+	{"dockerignore.tpl", ".dockerignore"},
+	{"docker-compose.yml.tpl", "docker-compose.yml"},
+}
+
+// databaseDrivers maps the database of docker-compose.yml to the module
+// paths of its Go drivers, matched as prefixes of the dependencies.
+var databaseDrivers = []struct {
+	database string
+	modules  []string
+}{
+	{"postgres", []string{"github.com/jackc/pgx", "github.com/lib/pq", "gorm.io/driver/postgres"}},
+	{"mysql", []string{"github.com/go-sql-driver/mysql", "gorm.io/driver/mysql"}},
+	{"mongodb", []string{"go.mongodb.org/mongo-driver"}},
+}
+
+// projectDatabase returns the database the project talks to, judged by
+// its dependencies: postgres, mysql, mongodb, or "" for none.
+func projectDatabase(cfg *project.Config) string {
+	for _, driver := range databaseDrivers {
+		for dependency := range cfg.Dependencies {
+			for _, module := range driver.modules {
+				if strings.HasPrefix(dependency, module) {
+					return driver.database
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// dockerVars collects the variables of the docker templates from the
+// project's goforge.yml.
+func dockerVars(cfg *project.Config, projectRoot string) (map[string]any, error) {
+	goVersion := ciGoVersions(cfg.GoVersion)[0]
+	vars := map[string]any{
+		"main":         "./cmd/server",
+		"binary":       cfg.ProjectName,
+		"tags":         "",
+		"assets":       []string{},
+		"baseImage":    "golang:" + goVersion + "-alpine",
+		"runtimeImage": "alpine:latest",
+		"goVersion":    goVersion,
+		"port":         0,
+		"env":          map[string]string{},
+		"database":     projectDatabase(cfg),
+		"redis":        usesRedis(cfg),
+	}
+
+	if build := cfg.Build; build != nil {
+		if build.Main != "" {
+			vars["main"] = build.Main
+		}
+		if build.BinaryName != "" {
+			vars["binary"] = build.BinaryName
+		}
+		vars["tags"] = strings.Join(build.Tags, ",")
+
+		// Only assets that exist can be copied into the image
+		var assets []string
+		for _, asset := range build.Assets {
+			if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(asset))); err == nil {
+				assets = append(assets, path.Clean(asset))
+			}
+		}
+		vars["assets"] = assets
+	}
+	if docker := cfg.Docker; docker != nil {
+		if docker.BaseImage != "" {
+			vars["baseImage"] = docker.BaseImage
+		}
+		if docker.RuntimeImage != "" {
+			vars["runtimeImage"] = docker.RuntimeImage
+		}
+		vars["port"] = docker.Port
+		if docker.Env != nil {
+			vars["env"] = docker.Env
+		}
+	}
+	vars["alpine"] = strings.HasPrefix(vars["runtimeImage"].(string), "alpine")
+
+	mainDir := filepath.Join(projectRoot, filepath.FromSlash(vars["main"].(string)))
+	if _, err := os.Stat(mainDir); err != nil {
+		return nil, fmt.Errorf("no main package at %s to build the image from; set build.main in goforge.yml", vars["main"])
+	}
+	return vars, nil
+}
+
+// writeDocker renders the Dockerfile, .dockerignore, and docker-compose.yml
+// of the project at projectRoot.
+func (s *Scaffolder) writeDocker(projectRoot string, cfg *project.Config, onConflict string) error {
+	vars, err := dockerVars(cfg, projectRoot)
+	if err != nil {
+		return err
+	}
+	data := TemplateData{
+		ProjectName: cfg.ProjectName,
+		ModuleName:  cfg.ModuleName,
+		GoVersion:   cfg.GoVersion,
+		Vars:        vars,
+	}
+
+	for _, file := range dockerFiles {
+		task := FileGenerationTask{
+			TemplatePath: path.Join(dockerTemplateDir, file.template),
+			TargetPath:   filepath.Join(projectRoot, file.target),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		if _, err := s.writeGenerated(task, content, projectRoot, onConflict); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateDocker writes a multi-stage Dockerfile, a .dockerignore, and a
+// docker-compose.yml running the application next to its database and
+// Redis, with a hot-reloading variant under the dev profile.
+func GenerateDocker(genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	logger.ComponentGenerationStart("docker", cfg.ProjectName)
+
+	if err := s.writeDocker(projectRoot, cfg, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationComplete("docker", "Dockerfile, .dockerignore, docker-compose.yml", projectRoot)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Build and start the stack: docker compose up --build")
+	logger.Info("   2. Or develop with hot reload: docker compose --profile dev up dev")
+	return nil
+}
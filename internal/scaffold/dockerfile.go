@@ -0,0 +1,210 @@
+package scaffold
+
+import (
+	"cmp"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// Runtime bases of 'goforge generate dockerfile'.
+const (
+	DockerBaseDistroless = "distroless"
+	DockerBaseAlpine     = "alpine"
+	DockerBaseDebian     = "debian"
+)
+
+// DockerBases are the runtime bases in the order they are offered.
+var DockerBases = []string{DockerBaseDistroless, DockerBaseAlpine, DockerBaseDebian}
+
+// dockerBaseImages maps the bases to their runtime images, static ones
+// first and those with glibc for cgo binaries second.
+var dockerBaseImages = map[string][2]string{
+	DockerBaseDistroless: {"gcr.io/distroless/static-debian12:nonroot", "gcr.io/distroless/base-debian12:nonroot"},
+	DockerBaseAlpine:     {"alpine:3", ""},
+	DockerBaseDebian:     {"debian:bookworm-slim", "debian:bookworm-slim"},
+}
+
+// dockerEntrypoint is a main package built into the image.
+type dockerEntrypoint struct {
+	Package string // as go build takes it, e.g. ./cmd/worker
+	Binary  string
+}
+
+// DockerfileOptions are the choices of 'goforge generate dockerfile'.
+type DockerfileOptions struct {
+	Base string // runtime base, one of DockerBases; DockerBaseDistroless when empty
+}
+
+// isMainPackage reports whether dir holds a main package.
+func isMainPackage(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, parser.PackageClauseOnly)
+		if err == nil && file.Name.Name == "main" {
+			return true
+		}
+	}
+	return false
+}
+
+// dockerEntrypoints returns the main packages of the project: build.main,
+// named after the binary of 'goforge build', followed by the other
+// directories of cmd/ holding main packages, named after their directory.
+func dockerEntrypoints(cfg *project.Config, projectRoot string, binary string) ([]dockerEntrypoint, error) {
+	main := "./cmd/server"
+	if cfg.Build != nil && cfg.Build.Main != "" {
+		main = "./" + path.Clean(strings.TrimPrefix(filepath.ToSlash(cfg.Build.Main), "./"))
+	}
+	if !isMainPackage(filepath.Join(projectRoot, filepath.FromSlash(main))) {
+		return nil, fmt.Errorf("no main package at %s to build the image from; set build.main in goforge.yml", main)
+	}
+	entrypoints := []dockerEntrypoint{{Package: main, Binary: binary}}
+
+	entries, _ := os.ReadDir(filepath.Join(projectRoot, "cmd"))
+	for _, entry := range entries {
+		pkg := "./cmd/" + entry.Name()
+		if !entry.IsDir() || pkg == main || entry.Name() == binary {
+			continue
+		}
+		if isMainPackage(filepath.Join(projectRoot, "cmd", entry.Name())) {
+			entrypoints = append(entrypoints, dockerEntrypoint{Package: pkg, Binary: entry.Name()})
+		}
+	}
+	return entrypoints, nil
+}
+
+// dockerLDFlags turns build.ldflags into the flags of the image's build,
+// with the {version}, {commit}, and {date} placeholders read from the
+// build arguments.
+func dockerLDFlags(ldflags string) string {
+	return strings.NewReplacer(
+		"{version}", "${VERSION}",
+		"{commit}", "${COMMIT}",
+		"{date}", "${BUILD_DATE}",
+		`"`, `\"`,
+	).Replace(ldflags)
+}
+
+// dockerfileVars collects the variables of the Dockerfile template from
+// the project's goforge.yml.
+func dockerfileVars(cfg *project.Config, projectRoot, base string) (map[string]any, error) {
+	vars, err := dockerVars(cfg, projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	build := cfg.Build
+	if build == nil {
+		build = &project.BuildConfig{}
+	}
+
+	cgo := build.Env["CGO_ENABLED"] == "1"
+	runtimeImage := dockerBaseImages[base][0]
+	if cgo {
+		runtimeImage = dockerBaseImages[base][1]
+		if runtimeImage == "" {
+			return nil, fmt.Errorf("build.env sets CGO_ENABLED=1, and cgo binaries need glibc, which %s lacks; use --base %s or %s",
+				base, DockerBaseDistroless, DockerBaseDebian)
+		}
+	}
+	builderImage := "golang:" + vars["goVersion"].(string) + "-alpine"
+	if cgo {
+		builderImage = "golang:" + vars["goVersion"].(string) + "-bookworm"
+	}
+	if cfg.Docker != nil && cfg.Docker.BaseImage != "" {
+		if cgo && strings.Contains(cfg.Docker.BaseImage, "alpine") {
+			// Binaries linked against musl do not run on glibc
+			logger.Warn("⚠️  Building with %s instead of docker.base_image %s, as cgo binaries must link against glibc", builderImage, cfg.Docker.BaseImage)
+		} else {
+			builderImage = cfg.Docker.BaseImage
+		}
+	}
+
+	entrypoints, err := dockerEntrypoints(cfg, projectRoot, vars["binary"].(string))
+	if err != nil {
+		return nil, err
+	}
+	vars["base"] = base
+	vars["builderImage"] = builderImage
+	vars["runtimeImage"] = runtimeImage
+	vars["entrypoints"] = entrypoints
+	vars["cgo"] = cgo
+	vars["ldflags"] = dockerLDFlags(build.LDFlags)
+	return vars, nil
+}
+
+// GenerateDockerfile writes a multi-stage Dockerfile building every
+// entrypoint of the project with the Go version, build settings, and
+// assets of goforge.yml into a runtime image on the chosen base, running
+// as a non-root user.
+func GenerateDockerfile(options DockerfileOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	base := cmp.Or(options.Base, DockerBaseDistroless)
+	if !slices.Contains(DockerBases, base) {
+		return fmt.Errorf("unknown base '%s' (use %s)", base, strings.Join(DockerBases, ", "))
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	vars, err := dockerfileVars(cfg, projectRoot, base)
+	if err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationStart("dockerfile", cfg.ProjectName)
+	task := FileGenerationTask{
+		TemplatePath: "templates/components/dockerfile/Dockerfile.tpl",
+		TargetPath:   filepath.Join(projectRoot, "Dockerfile"),
+		Data: TemplateData{
+			ProjectName: cfg.ProjectName,
+			ModuleName:  cfg.ModuleName,
+			GoVersion:   cfg.GoVersion,
+			Vars:        vars,
+		},
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return err
+	}
+	if _, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict); err != nil {
+		return err
+	}
+	logger.ComponentGenerationComplete("dockerfile", "Dockerfile", projectRoot)
+
+	entrypoints := vars["entrypoints"].([]dockerEntrypoint)
+	logger.Info("")
+	logger.Info("📦 Entrypoints: %s runs by default", entrypoints[0].Binary)
+	for _, entrypoint := range entrypoints[1:] {
+		logger.Info("   /app/%s from %s", entrypoint.Binary, entrypoint.Package)
+	}
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Build the image: docker build --build-arg VERSION=$(git describe --tags --always) -t %s .", cfg.ProjectName)
+	if _, err := os.Stat(filepath.Join(projectRoot, ".dockerignore")); err != nil {
+		logger.Info("   2. Keep .git, dist, and .env out of the build context with a .dockerignore")
+	}
+	return nil
+}
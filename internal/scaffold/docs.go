@@ -0,0 +1,68 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/apidocs"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// docsSpec places the Swagger UI route and the spec it embeds; override
+// it with layout.docs in goforge.yml.
+var docsSpec = ComponentSpec{Type: "docs", Dir: "internal/adapters/http/docs"}
+
+// DocsSpecFile is the name of the spec next to the Swagger UI route.
+const DocsSpecFile = "openapi.json"
+
+// GenerateSwaggerUI writes a package serving Swagger UI and the OpenAPI
+// spec it embeds, returning its directory relative to the project root.
+// The spec itself is written by the caller.
+func GenerateSwaggerUI(genOptions GenerateOptions) (string, error) {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return "", err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return "", fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if err := requireGin(cfg, "goforge docs openapi --ui"); err != nil {
+		return "", err
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, docsSpec, genOptions.Path)
+	if err != nil {
+		return "", err
+	}
+
+	task := FileGenerationTask{
+		TemplatePath: "templates/components/docs/docs.go.tpl",
+		TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(dir), "docs.go"),
+		Data: TemplateData{
+			ModulePath:  cfg.ModuleName,
+			PackageName: packageNameFor(dir),
+			Vars:        map[string]any{"page": apidocs.SwaggerUIPage},
+		},
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return "", err
+	}
+	written, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict)
+	if err != nil {
+		return "", err
+	}
+	if written {
+		s.runPostHooks(cfg, projectRoot, []string{task.TargetPath})
+		pkg := packageNameFor(dir)
+		logger.Info("📚 Added the Swagger UI route in %s; serve it from main:", path.Join(dir, "docs.go"))
+		logger.Info("     %s.RegisterRoutes(router)   // import %q", pkg, path.Join(cfg.ModuleName, dir))
+	}
+	return dir, nil
+}
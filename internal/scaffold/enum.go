@@ -0,0 +1,147 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/validation"
+)
+
+// enumSpec places generated enums next to the domain models; override it
+// with layout.enum in goforge.yml.
+var enumSpec = ComponentSpec{Type: "enum", Dir: "internal/domain"}
+
+// enumValue is one value of an enum as seen by templates.
+type enumValue struct {
+	Const string // e.g. "OrderStatusPaid"
+	Name  string // e.g. "paid"
+}
+
+// GenerateEnum writes a typed enum with String, Parse, IsValid and JSON
+// marshaling by name, and a test file covering them.
+func GenerateEnum(name string, values []string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid enum name '%s' (use lowercase words separated by hyphens, e.g. order-status)", name)
+	}
+	enumValues, err := parseEnumValues(name, values)
+	if err != nil {
+		return err
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, enumSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(enumValues))
+	for i, value := range enumValues {
+		names[i] = value.Name
+	}
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   strcase.ToCamel(name),
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"label":  strings.NewReplacer("-", " ", "_", " ").Replace(name),
+			"values": enumValues,
+			"list":   strings.Join(names, ", "),
+		},
+	}
+
+	logger.ComponentGenerationStart("enum", name)
+
+	var written []string
+	for _, file := range []struct{ template, suffix string }{
+		{"enum.go.tpl", ".go"},
+		{"enum_test.go.tpl", "_test.go"},
+	} {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/enum", file.template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(dir), strcase.ToSnake(name)+file.suffix),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+	}
+	if len(written) == 0 {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	logger.ComponentGenerationComplete("enum", name, written[0])
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Use %s.%s as a field type; it is encoded as its name in JSON", data.PackageName, data.NameTitle)
+	logger.Info("   2. Check input with %s.Parse%s or IsValid", data.PackageName, data.NameTitle)
+	logger.Info("   3. Run the tests: go test ./%s", dir)
+
+	return nil
+}
+
+// parseEnumValues validates the value names, accepting comma-separated
+// lists. All invalid values are reported together as
+// validation.ValidationErrors.
+func parseEnumValues(name string, raw []string) ([]enumValue, error) {
+	var errs validation.ValidationErrors
+	var values []enumValue
+	seen := make(map[string]bool)
+	for _, list := range raw {
+		for _, value := range strings.Split(list, ",") {
+			value = strings.TrimSpace(value)
+			if value == "" {
+				continue
+			}
+			if !eventNamePattern.MatchString(value) {
+				errs = append(errs, &validation.ValidationError{
+					Field:       "values",
+					Value:       value,
+					Message:     "invalid enum value",
+					Suggestions: []string{"Use lowercase words separated by hyphens or underscores, e.g. in-progress"},
+				})
+				continue
+			}
+			constant := strcase.ToCamel(name) + strcase.ToCamel(value)
+			if seen[constant] {
+				errs = append(errs, &validation.ValidationError{Field: "values", Value: value, Message: "value is declared twice"})
+				continue
+			}
+			seen[constant] = true
+			values = append(values, enumValue{Const: constant, Name: value})
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, fmt.Errorf("enum %s has no values\n\nList them after the name, e.g. goforge g enum %s pending,active", name, name)
+	}
+	return values, nil
+}
@@ -0,0 +1,174 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// listenersMarker is the line in the listener registry above which new
+// listeners are added.
+const listenersMarker = "// goforge:listeners"
+
+// eventSpec places generated events, their listeners and the registry;
+// eventBusSpec places the bus package created with the first event.
+// Override them with layout.event and layout.eventbus in goforge.yml.
+var (
+	eventSpec    = ComponentSpec{Type: "event", Dir: "internal/events"}
+	eventBusSpec = ComponentSpec{Type: "eventbus", Dir: "internal/platform/eventbus"}
+)
+
+// eventNamePattern accepts names like "user-registered" or "order_shipped".
+var eventNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// EventOptions parameterizes a generated event.
+type EventOptions struct {
+	// Listener names the listener, e.g. "send-welcome-email"; it defaults
+	// to the event name. Run the generator again with another listener to
+	// add one to an existing event.
+	Listener string
+}
+
+// GenerateEvent writes an event type and a listener for it, and subscribes
+// the listener in the listener registry. The first event also creates the
+// registry and the event bus package.
+func GenerateEvent(name string, options EventOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if options.Listener == "" {
+		options.Listener = name
+	}
+	for _, n := range []string{name, options.Listener} {
+		if !eventNamePattern.MatchString(n) {
+			return exitcode.Errorf(exitcode.Validation, "invalid name '%s' (use lowercase words separated by hyphens, e.g. user-registered)", n)
+		}
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	eventsDir, err := resolveDir(cfg, eventSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+	busDir, err := resolveDir(cfg, eventBusSpec, "")
+	if err != nil {
+		return err
+	}
+
+	nameTitle := strcase.ToCamel(name)
+	listenerTitle := strcase.ToCamel(options.Listener)
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   nameTitle,
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(eventsDir),
+		Vars: map[string]any{
+			"eventName":     strings.NewReplacer("-", ".", "_", ".").Replace(name),
+			"listenerTitle": listenerTitle,
+			"busImport":     path.Join(cfg.ModuleName, busDir),
+			"busPackage":    packageNameFor(busDir),
+		},
+	}
+
+	logger.ComponentGenerationStart("event", name)
+
+	var written []string
+	write := func(template, target string, onConflict string) error {
+		fileData := data
+		fileData.PackageName = packageNameFor(path.Dir(target))
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/event", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         fileData,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// The bus, the registry and the event type are created once and then
+	// belong to the project, so more listeners can be added to an event
+	shared := []struct{ template, target string }{
+		{"bus.go.tpl", path.Join(busDir, "bus.go")},
+		{"listeners.go.tpl", path.Join(eventsDir, "listeners.go")},
+		{"event.go.tpl", path.Join(eventsDir, strcase.ToSnake(name)+".go")},
+	}
+	for _, file := range shared {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			continue
+		}
+		if err := write(file.template, file.target, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	listenerFile := path.Join(eventsDir, strcase.ToSnake(options.Listener)+"_listener.go")
+	if err := write("listener.go.tpl", listenerFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	registered, err := registerListener(filepath.Join(projectRoot, filepath.FromSlash(eventsDir), "listeners.go"), nameTitle, listenerTitle)
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 && !registered {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	logger.ComponentGenerationComplete("event", name, filepath.Join(projectRoot, filepath.FromSlash(eventsDir)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Add the event's data to %s in %s", nameTitle, path.Join(eventsDir, strcase.ToSnake(name)+".go"))
+	logger.Info("   2. Implement %sListener.Handle in %s", listenerTitle, listenerFile)
+	logger.Info("   3. Wire the bus in main, then publish the event:")
+	logger.Info("        bus := %s.New(nil)", data.Vars["busPackage"])
+	logger.Info("        %s.Register(bus)", data.PackageName)
+	logger.Info("        bus.Publish(ctx, %s.%s{OccurredAt: time.Now()})", data.PackageName, nameTitle)
+
+	return nil
+}
+
+// registerListener subscribes the listener in the registry above the
+// goforge:listeners marker. It reports whether the registry changed.
+func registerListener(registry, eventTitle, listenerTitle string) (bool, error) {
+	constructor := fmt.Sprintf("New%sListener()", listenerTitle)
+	entry := fmt.Sprintf("bus.Subscribe(%sName, %s.Handle)", eventTitle, constructor)
+	result, err := insertAtMarker(registry, listenersMarker, entry, constructor)
+	if err != nil {
+		return false, fmt.Errorf("could not update listener registry: %w", err)
+	}
+
+	switch result {
+	case markerPresent:
+		logger.Info("✔️  %sListener is already registered", listenerTitle)
+	case markerMissing:
+		logger.Warn("⚠️  %s has no '%s' marker; register the listener yourself:", registry, listenersMarker)
+		logger.Warn("   %s", entry)
+	default:
+		logger.Debug("Registered %sListener in %s", listenerTitle, registry)
+	}
+	return result == markerInserted, nil
+}
@@ -0,0 +1,104 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+)
+
+// conflictingFiles returns the files of an existing destination directory,
+// relative to it, that creating the project would overwrite.
+func (s *Scaffolder) conflictingFiles(options Options, tasks []FileGenerationTask) ([]string, error) {
+	if _, err := os.Stat(options.DestPath); os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	var targets []string
+	for _, task := range tasks {
+		if _, err := os.Stat(task.TargetPath); err != nil {
+			continue
+		}
+		// Templates rendering nothing are left out, so they overwrite nothing
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(content)) > 0 {
+			targets = append(targets, task.TargetPath)
+		}
+	}
+
+	for _, name := range options.Features {
+		root := path.Join(featuresDir, name)
+		featureTasks, err := s.collectGenerationTasks(templatesFS, root, options.DestPath, TemplateData{})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range featureTasks {
+			if task.TemplatePath != path.Join(root, FeatureManifestFile) {
+				targets = append(targets, task.TargetPath)
+			}
+		}
+	}
+
+	// 'go mod init' refuses to replace a go.mod
+	targets = append(targets, filepath.Join(options.DestPath, "go.mod"))
+	if options.CI != "" && options.CI != NoCI {
+		targets = append(targets, filepath.Join(options.DestPath, filepath.FromSlash(ciFiles[options.CI])))
+	}
+	if options.Docker {
+		for _, file := range dockerFiles {
+			targets = append(targets, filepath.Join(options.DestPath, file.target))
+		}
+	}
+
+	var conflicts []string
+	for _, target := range targets {
+		if _, err := os.Stat(target); err != nil {
+			continue
+		}
+		rel := filepath.ToSlash(relativeTo(options.DestPath, target))
+		if !slices.Contains(conflicts, rel) {
+			conflicts = append(conflicts, rel)
+		}
+	}
+	slices.Sort(conflicts)
+	return conflicts, nil
+}
+
+// checkConflicts refuses to create the project over existing files unless
+// options.Force is set.
+func (s *Scaffolder) checkConflicts(options Options, tasks []FileGenerationTask) error {
+	conflicts, err := s.conflictingFiles(options, tasks)
+	if err != nil {
+		return err
+	}
+	if len(conflicts) == 0 {
+		return nil
+	}
+	if options.Force {
+		logger.Warn("⚠️  Overwriting %d existing file(s) in %s", len(conflicts), options.DestPath)
+		return nil
+	}
+	return fmt.Errorf("%d file(s) in %s would be overwritten:\n  %s\n\nRerun with --force to overwrite them",
+		len(conflicts), options.DestPath, strings.Join(conflicts, "\n  "))
+}
+
+// removeExistingModule deletes the go.mod of a project created with
+// --force over an existing module, so that 'go mod init' can write its own.
+func removeExistingModule(options Options) error {
+	if !options.Force {
+		return nil
+	}
+	err := os.Remove(filepath.Join(options.DestPath, "go.mod"))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// featuresDir holds the overlays of 'goforge new --features', one
+// directory per feature.
+const featuresDir = "templates/features"
+
+// FeatureManifestFile describes a feature; the other files of its
+// directory are rendered over the base template.
+const FeatureManifestFile = "feature.yml"
+
+// Features lists the features of 'goforge new --features'.
+var Features = featureNames()
+
+// Feature is an overlay adding packages, config entries, and dependencies
+// on top of a project template.
+type Feature struct {
+	Name string `yaml:"-"`
+
+	// Description is shown in the help of 'goforge new'.
+	Description string `yaml:"description"`
+
+	// Templates are the project templates the feature applies to.
+	Templates []string `yaml:"templates"`
+
+	// Dependencies are recorded in goforge.yml, module: version.
+	Dependencies map[string]string `yaml:"dependencies"`
+
+	// Config is appended to config/default.yml unless its top-level key is
+	// already there. It may use template actions, e.g. {{.ProjectName}}.
+	Config string `yaml:"config"`
+
+	// Scripts are added to the scripts of goforge.yml.
+	Scripts map[string]string `yaml:"scripts"`
+
+	// Usage is shown once the project has been created.
+	Usage []string `yaml:"usage"`
+}
+
+// featureNames returns the embedded features in directory order.
+func featureNames() []string {
+	entries, err := fs.ReadDir(templatesFS, featuresDir)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// LoadFeature reads the manifest of the named feature.
+func LoadFeature(name string) (*Feature, error) {
+	file := path.Join(featuresDir, name, FeatureManifestFile)
+	data, err := fs.ReadFile(templatesFS, file)
+	if err != nil {
+		return nil, fmt.Errorf("unknown feature '%s' (use %s)", name, strings.Join(Features, ", "))
+	}
+	var feature Feature
+	if err := yaml.Unmarshal(data, &feature); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	feature.Name = name
+	return &feature, nil
+}
+
+// ValidateFeatures checks that every feature exists and applies to the
+// project template.
+func ValidateFeatures(templateName string, names []string) error {
+	for _, name := range names {
+		feature, err := LoadFeature(name)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(feature.Templates, templateName) {
+			return fmt.Errorf("feature '%s' is not available for the %s template (use it with %s)",
+				name, templateName, strings.Join(feature.Templates, ", "))
+		}
+	}
+	return nil
+}
+
+// featureFramework returns the HTTP framework the overlays write code
+// for. Templates without a framework setting are on gin when they depend
+// on it, and on net/http otherwise.
+func featureFramework(cfg *project.Config) string {
+	if cfg.Framework != "" {
+		return cfg.Framework
+	}
+	if _, ok := cfg.Dependencies["github.com/gin-gonic/gin"]; ok {
+		return "gin"
+	}
+	return "stdlib"
+}
+
+// applyFeatures renders the overlays of the named features over the
+// freshly rendered project and records their config entries,
+// dependencies, and scripts.
+func (s *Scaffolder) applyFeatures(destPath string, names []string, data TemplateData) error {
+	if len(names) == 0 {
+		return nil
+	}
+	cfg, err := project.LoadConfigFrom(destPath)
+	if err != nil {
+		return err
+	}
+
+	vars := map[string]any{}
+	for key, value := range data.Vars {
+		vars[key] = value
+	}
+	if _, ok := vars["framework"]; !ok {
+		vars["framework"] = featureFramework(cfg)
+	}
+	data.Vars = vars
+
+	for _, name := range names {
+		feature, err := LoadFeature(name)
+		if err != nil {
+			return err
+		}
+		logger.Debug("Applying feature %s", name)
+
+		root := path.Join(featuresDir, name)
+		tasks, err := s.collectGenerationTasks(templatesFS, root, destPath, data)
+		if err != nil {
+			return fmt.Errorf("failed to collect the files of feature %s: %w", name, err)
+		}
+		overlay := tasks[:0]
+		for _, task := range tasks {
+			if task.TemplatePath != path.Join(root, FeatureManifestFile) {
+				overlay = append(overlay, task)
+			}
+		}
+		if err := s.generateFiles(overlay); err != nil {
+			return fmt.Errorf("failed to apply feature %s: %w", name, err)
+		}
+
+		if err := s.addFeatureConfig(destPath, feature, data); err != nil {
+			return fmt.Errorf("failed to apply feature %s: %w", name, err)
+		}
+		for _, module := range slices.Sorted(maps.Keys(feature.Dependencies)) {
+			if err := project.SetConfigValue(destPath, []string{"dependencies", module}, feature.Dependencies[module]); err != nil {
+				return fmt.Errorf("failed to update goforge.yml: %w", err)
+			}
+		}
+		for _, script := range slices.Sorted(maps.Keys(feature.Scripts)) {
+			if err := project.SetConfigValue(destPath, []string{"scripts", script}, feature.Scripts[script]); err != nil {
+				return fmt.Errorf("failed to update goforge.yml: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// addFeatureConfig appends the rendered config block of feature to
+// config/default.yml.
+func (s *Scaffolder) addFeatureConfig(destPath string, feature *Feature, data TemplateData) error {
+	if feature.Config == "" {
+		return nil
+	}
+	tmpl, err := template.New(feature.Name).Funcs(s.getTemplateFunctions()).Parse(feature.Config)
+	if err != nil {
+		return fmt.Errorf("failed to parse the config block: %w", err)
+	}
+	var block strings.Builder
+	if err := tmpl.Execute(&block, data); err != nil {
+		return fmt.Errorf("failed to render the config block: %w", err)
+	}
+
+	var entries map[string]any
+	if err := yaml.Unmarshal([]byte(block.String()), &entries); err != nil || len(entries) != 1 {
+		return fmt.Errorf("the config block must be a YAML mapping with a single top-level key")
+	}
+	for key := range entries {
+		result, err := addAppConfig(destPath, key, block.String())
+		if err != nil {
+			return err
+		}
+		if result == configMissing {
+			logger.Warn("⚠️  No %s to add the %s settings to", oidcAppConfig, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// fixtureSpec places test fixtures; override it with layout.fixture in
+// goforge.yml.
+var fixtureSpec = ComponentSpec{Type: "fixture", Dir: "internal/testutil"}
+
+// sequencePattern matches fixture defaults using the sequence number.
+var sequencePattern = regexp.MustCompile(`\bn\b`)
+
+// fixtureField is a model field as seen by the fixture template.
+type fixtureField struct {
+	Name     string // e.g. Email
+	Type     string // as seen from the fixture package, e.g. domain.Status
+	Param    string // parameter of its With method
+	Default  string // Go expression, or "" to keep the zero value
+	Sequence bool   // Default uses the sequence number n
+	Format   bool   // Default uses fmt
+}
+
+// modelStruct is a domain struct with what the fixture needs to refer to
+// its field types.
+type modelStruct struct {
+	fields  *ast.FieldList
+	imports map[string]string // name -> path, of the struct's file
+	types   map[string]bool   // exported types of the domain package
+	consts  map[string]string // type -> its first constant
+}
+
+// GenerateFixture writes a test data builder for a domain model, with
+// defaults making a valid, unique value and a With method per field. The
+// first fixture also creates the package's Sequence and Now helpers.
+func GenerateFixture(name string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid model name '%s' (use lowercase words separated by hyphens, e.g. user or order-item)", name)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, fixtureSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	modelSpec, _ := LookupComponent("model")
+	modelDir := componentDir(cfg, modelSpec, "")
+	nameTitle := strcase.ToCamel(name)
+	model, err := findModel(filepath.Join(projectRoot, filepath.FromSlash(modelDir)), nameTitle)
+	if err != nil {
+		return err
+	}
+	if model == nil {
+		return fmt.Errorf("no %s struct in %s; generate the model first with 'goforge g model %s'", nameTitle, modelDir, name)
+	}
+
+	fields, imports := fixtureFields(model, nameTitle)
+	usesSequence, usesFmt := false, false
+	for _, field := range fields {
+		usesSequence = usesSequence || field.Sequence
+		usesFmt = usesFmt || field.Format
+	}
+
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   nameTitle,
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Imports:     componentImports(cfg),
+		Vars: map[string]any{
+			"fields":       fields,
+			"imports":      imports,
+			"variable":     strcase.ToLowerCamel(name),
+			"usesSequence": usesSequence,
+			"usesFmt":      usesFmt,
+		},
+	}
+
+	logger.ComponentGenerationStart("fixture", name)
+
+	var written []string
+	write := func(template, target string, onConflict string) error {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/fixture", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	helpers := path.Join(dir, "testutil.go")
+	if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(helpers))); os.IsNotExist(err) {
+		if err := write("testutil.go.tpl", helpers, ConflictSkip); err != nil {
+			return err
+		}
+	}
+	fixtureFile := path.Join(dir, strcase.ToSnake(name)+"_fixture.go")
+	if err := write("fixture.go.tpl", fixtureFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+	if len(written) == 0 {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	logger.ComponentGenerationComplete("fixture", name, filepath.Join(projectRoot, filepath.FromSlash(fixtureFile)))
+
+	pkg := data.PackageName
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Build %s values in tests:", nameTitle)
+	logger.Info("        %s := %s.New%s().Build()", data.Vars["variable"], pkg, nameTitle)
+	if len(fields) > 0 {
+		logger.Info("        %s := %s.New%s().With%s(...).Build()", data.Vars["variable"], pkg, nameTitle, fields[len(fields)-1].Name)
+	}
+	logger.Info("   2. Adjust the defaults in %s to keep them valid as %s changes", fixtureFile, nameTitle)
+
+	return nil
+}
+
+// findModel looks up the struct named name among the Go files of dir,
+// returning nil when there is none.
+func findModel(dir, name string) (*modelStruct, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var model *modelStruct
+	exported := make(map[string]bool)
+	consts := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".go") || strings.HasSuffix(entry.Name(), "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fset, filepath.Join(dir, entry.Name()), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse %s: %w", entry.Name(), err)
+		}
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			switch gen.Tok {
+			case token.TYPE:
+				for _, spec := range gen.Specs {
+					typeSpec := spec.(*ast.TypeSpec)
+					if typeSpec.Name.IsExported() {
+						exported[typeSpec.Name.Name] = true
+					}
+					st, ok := typeSpec.Type.(*ast.StructType)
+					if ok && typeSpec.Name.Name == name && typeSpec.TypeParams == nil {
+						model = &modelStruct{fields: st.Fields, imports: fileImports(file)}
+					}
+				}
+			case token.CONST:
+				// Constants without a type or value continue the previous
+				// one's, as with iota
+				var current string
+				for _, spec := range gen.Specs {
+					value := spec.(*ast.ValueSpec)
+					if ident, ok := value.Type.(*ast.Ident); ok {
+						current = ident.Name
+					} else if value.Type != nil || len(value.Values) > 0 {
+						current = ""
+					}
+					if current != "" && consts[current] == "" && value.Names[0].IsExported() {
+						consts[current] = value.Names[0].Name
+					}
+				}
+			}
+		}
+	}
+	if model != nil {
+		model.types, model.consts = exported, consts
+	}
+	return model, nil
+}
+
+// fileImports maps the names a file imports packages under to their
+// paths.
+func fileImports(file *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, spec := range file.Imports {
+		importPath, _ := strconv.Unquote(spec.Path.Value)
+		name := path.Base(importPath)
+		if majorVersion(name) {
+			name = path.Base(path.Dir(importPath))
+		}
+		if spec.Name != nil {
+			name = spec.Name.Name
+		}
+		imports[name] = importPath
+	}
+	return imports
+}
+
+// majorVersion reports whether a path element is a module major version
+// suffix such as v2.
+func majorVersion(elem string) bool {
+	if len(elem) < 2 || elem[0] != 'v' {
+		return false
+	}
+	_, err := strconv.Atoi(elem[1:])
+	return err == nil
+}
+
+// fixtureFields lists the exported fields of a model with their defaults,
+// and the imports their types need.
+func fixtureFields(model *modelStruct, nameTitle string) ([]fixtureField, []string) {
+	var fields []fixtureField
+	needed := make(map[string]bool)
+	for _, field := range model.fields.List {
+		// Embedded fields are set through their own fields
+		if len(field.Names) == 0 {
+			continue
+		}
+		goType := qualifyType(field.Type, model.types)
+		if goType == "" {
+			continue
+		}
+		for _, pkg := range typePackages(field.Type) {
+			if importPath, ok := model.imports[pkg]; ok {
+				needed[importPath] = true
+			}
+		}
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			param := strcase.ToLowerCamel(name.Name)
+			if token.IsKeyword(param) || param == "b" || param == "n" {
+				param = "value"
+			}
+			value := fixtureDefault(name.Name, field.Type, nameTitle, model.consts)
+			fields = append(fields, fixtureField{
+				Name:     name.Name,
+				Type:     goType,
+				Param:    param,
+				Default:  value,
+				Sequence: sequencePattern.MatchString(value),
+				Format:   strings.HasPrefix(value, "fmt."),
+			})
+		}
+	}
+
+	var imports []string
+	for importPath := range needed {
+		imports = append(imports, importPath)
+	}
+	sort.Strings(imports)
+	return fields, imports
+}
+
+// qualifyType returns a field type as written outside the domain package,
+// or "" when it refers to unexported types.
+func qualifyType(expr ast.Expr, exported map[string]bool) string {
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		if exported[expr.Name] {
+			return "domain." + expr.Name
+		}
+		if types.Universe.Lookup(expr.Name) != nil {
+			return expr.Name
+		}
+		return ""
+	case *ast.StarExpr:
+		if inner := qualifyType(expr.X, exported); inner != "" {
+			return "*" + inner
+		}
+		return ""
+	case *ast.ArrayType:
+		inner := qualifyType(expr.Elt, exported)
+		if inner == "" {
+			return ""
+		}
+		if expr.Len == nil {
+			return "[]" + inner
+		}
+		return "[" + types.ExprString(expr.Len) + "]" + inner
+	case *ast.MapType:
+		key, value := qualifyType(expr.Key, exported), qualifyType(expr.Value, exported)
+		if key == "" || value == "" {
+			return ""
+		}
+		return "map[" + key + "]" + value
+	case *ast.SelectorExpr:
+		return types.ExprString(expr)
+	case *ast.InterfaceType, *ast.FuncType, *ast.ChanType:
+		return types.ExprString(expr)
+	}
+	return ""
+}
+
+// typePackages lists the packages a type expression refers to.
+func typePackages(expr ast.Expr) []string {
+	var pkgs []string
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				pkgs = append(pkgs, ident.Name)
+			}
+			return false
+		}
+		return true
+	})
+	return pkgs
+}
+
+// fixtureDefault returns a valid default for a field, unique per built
+// value where it matters (n is the builder's sequence number), or "" to
+// keep the zero value.
+func fixtureDefault(name string, expr ast.Expr, nameTitle string, consts map[string]string) string {
+	lower := strings.ToLower(name)
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		switch expr.Name {
+		case "string":
+			snake := strcase.ToSnake(nameTitle)
+			switch {
+			case strings.Contains(lower, "email"):
+				return fmt.Sprintf(`fmt.Sprintf("%s%%d@example.com", n)`, strings.ReplaceAll(snake, "_", "."))
+			case strings.HasSuffix(lower, "url"):
+				return fmt.Sprintf(`fmt.Sprintf("https://example.com/%s/%%d", n)`, strings.ReplaceAll(snake, "_", "-"))
+			case lower == "id" || strings.HasSuffix(lower, "slug"):
+				return fmt.Sprintf(`fmt.Sprintf("%s-%%d", n)`, strings.ReplaceAll(snake, "_", "-"))
+			}
+			return fmt.Sprintf(`fmt.Sprintf("%s %%d", n)`, strcase.ToDelimited(name, ' '))
+		case "int64":
+			if lower == "id" {
+				return "n"
+			}
+		case "int", "int32", "uint", "uint32", "uint64":
+			if lower == "id" {
+				return expr.Name + "(n)"
+			}
+		}
+		if constant, ok := consts[expr.Name]; ok {
+			return "domain." + constant
+		}
+	case *ast.SelectorExpr:
+		switch types.ExprString(expr) {
+		case "time.Time":
+			return "Now"
+		case "uuid.UUID":
+			return "uuid.New()"
+		}
+	}
+	return ""
+}
@@ -0,0 +1,83 @@
+package scaffold
+
+import (
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+	"golang.org/x/tools/imports"
+)
+
+// FilesPlaceholder in a post hook is replaced by the generated files.
+const FilesPlaceholder = "{files}"
+
+// formatGo formats rendered Go code like goimports: gofmt layout, unused
+// imports removed, and missing ones added. Code that does not parse is
+// returned unchanged so the user can see what the template produced.
+func formatGo(targetPath string, content []byte) []byte {
+	formatted, err := imports.Process(targetPath, content, &imports.Options{
+		Comments:  true,
+		TabIndent: true,
+		TabWidth:  8,
+	})
+	if err != nil {
+		logger.Warn("⚠️  Could not format %s: %v", targetPath, err)
+		return content
+	}
+	return formatted
+}
+
+// configure applies the project's generate settings.
+func (s *Scaffolder) configure(cfg *project.Config) {
+	s.skipFormat = cfg.Generate != nil && cfg.Generate.Format != nil && !*cfg.Generate.Format
+	setLocalModule(cfg.ModuleName)
+}
+
+// setLocalModule makes formatGo put the imports of module in a group of
+// their own after the third-party ones, as 'goforge fmt' does with
+// goimports -local, so generated code passes 'goforge fmt --check'. It
+// must be called before files are rendered concurrently.
+func setLocalModule(module string) {
+	imports.LocalPrefix = module
+}
+
+// runPostHooks runs generate.post_hooks from goforge.yml on the files a
+// generator wrote. Each hook gets the project-relative paths in place of
+// {files}, or appended when it has no placeholder. A failing hook is
+// reported but leaves the generated files in place.
+func (s *Scaffolder) runPostHooks(cfg *project.Config, projectRoot string, files []string) {
+	if cfg.Generate == nil || len(cfg.Generate.PostHooks) == 0 || len(files) == 0 {
+		return
+	}
+
+	quoted := make([]string, len(files))
+	for i, file := range files {
+		quoted[i] = shellQuote(relativeTo(projectRoot, file))
+	}
+	list := strings.Join(quoted, " ")
+
+	for _, hook := range cfg.Generate.PostHooks {
+		script := hook
+		if strings.Contains(script, FilesPlaceholder) {
+			script = strings.ReplaceAll(script, FilesPlaceholder, list)
+		} else {
+			script += " " + list
+		}
+
+		logger.Debug("Running post hook: %s", script)
+		if err := runner.ExecuteScript(projectRoot, script); err != nil {
+			logger.Warn("⚠️  Post hook '%s' failed: %v", hook, err)
+		}
+	}
+}
+
+// shellQuote quotes s for sh when it contains anything but safe characters.
+func shellQuote(s string) string {
+	if s != "" && strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./", r))
+	}) < 0 {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
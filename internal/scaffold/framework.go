@@ -0,0 +1,59 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// Frameworks lists the HTTP frameworks of 'goforge new --framework', the
+// default first.
+var Frameworks = []string{"gin", "echo", "fiber", "chi", "stdlib"}
+
+// defaultFramework is used by projects without a framework in goforge.yml.
+const defaultFramework = "gin"
+
+// ValidFramework reports whether name is one of Frameworks.
+func ValidFramework(name string) bool {
+	for _, framework := range Frameworks {
+		if framework == name {
+			return true
+		}
+	}
+	return false
+}
+
+// projectFramework returns the project's HTTP framework.
+func projectFramework(cfg *project.Config) string {
+	if cfg.Framework == "" {
+		return defaultFramework
+	}
+	return cfg.Framework
+}
+
+// frameworkComponent swaps an embedded component's template for the one
+// written for the project's framework, when there is one:
+// templates/components/framework/<framework>/<type>.go.tpl. Custom and
+// pack templates are used as they are.
+func frameworkComponent(cfg *project.Config, spec ComponentSpec) ComponentSpec {
+	framework := projectFramework(cfg)
+	if spec.Source != nil || framework == defaultFramework {
+		return spec
+	}
+	template := path.Join("templates/components/framework", framework, spec.Type+".go.tpl")
+	if _, err := fs.Stat(templatesFS, template); err == nil {
+		spec.Template = template
+	}
+	return spec
+}
+
+// requireGin stops the generators whose code is written for gin in
+// projects using another framework.
+func requireGin(cfg *project.Config, generator string) error {
+	if framework := projectFramework(cfg); framework != defaultFramework {
+		return fmt.Errorf("%s generates gin code, but this project uses %s (framework in goforge.yml)", generator, framework)
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// resolversMarker is the line in the Resolver struct above which services
+// are added.
+const resolversMarker = "// goforge:resolvers"
+
+// graphqlSpec places the resolvers, with the schema, generated code and
+// models in its schema, generated and model subdirectories; override it
+// with layout.graphql in goforge.yml.
+var graphqlSpec = ComponentSpec{Type: "graphql", Dir: "internal/adapters/graphql"}
+
+// gqlgenModule is the GraphQL code generator, run with 'go run' so it
+// matches the runtime version in go.mod.
+const gqlgenModule = "github.com/99designs/gqlgen"
+
+// gqlgenGenerate regenerates the GraphQL code from the schema.
+const gqlgenGenerate = "go run " + gqlgenModule + " generate"
+
+// GenerateResolver writes a GraphQL schema file and resolvers for one
+// resource. The resolvers are wired to the resource's service when the
+// project has one.
+func GenerateResolver(name string, genOptions GenerateOptions) error {
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid resolver name '%s' (use lowercase words separated by hyphens, e.g. user or order-item)", name)
+	}
+	return generateGraphQL([]string{name}, genOptions)
+}
+
+// SetupGraphQL adds GraphQL to a project: the gqlgen configuration, the
+// root schema, a handler, and a schema file with resolvers for every
+// service the project already has.
+func SetupGraphQL(genOptions GenerateOptions) error {
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+
+	names := existingServices(cfg, projectRoot)
+	if len(names) == 0 {
+		return fmt.Errorf("no services found in %s\n\nCreate a resolver with: goforge g resolver <name>", componentDir(cfg, serviceSpec(), ""))
+	}
+	return generateGraphQL(names, genOptions)
+}
+
+// generateGraphQL writes the shared GraphQL files when missing and a schema
+// file and resolvers for each name, then runs gqlgen.
+func generateGraphQL(names []string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, graphqlSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+	schemaDir := path.Join(dir, "schema")
+
+	data := TemplateData{
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"schemaDir":       schemaDir,
+			"generatedDir":    path.Join(dir, "generated"),
+			"modelDir":        path.Join(dir, "model"),
+			"resolverDir":     dir,
+			"resolverPackage": packageNameFor(dir),
+			"generatedImport": path.Join(cfg.ModuleName, dir, "generated"),
+			"modelImport":     path.Join(cfg.ModuleName, dir, "model"),
+		},
+	}
+
+	logger.ComponentGenerationStart("resolver", strings.Join(names, ", "))
+
+	var written []string
+	write := func(template, target string, data TemplateData, onConflict string) error {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/graphql", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// The configuration, root schema and resolver belong to the project
+	// once created
+	shared := []struct{ template, target string }{
+		{"gqlgen.yml.tpl", "gqlgen.yml"},
+		{"schema.graphqls.tpl", path.Join(schemaDir, "schema.graphqls")},
+		{"resolver.go.tpl", path.Join(dir, "resolver.go")},
+		{"schema.resolvers.go.tpl", path.Join(dir, "schema.resolvers.go")},
+		{"handler.go.tpl", path.Join(dir, "handler.go")},
+	}
+	for _, file := range shared {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			continue
+		}
+		if err := write(file.template, file.target, data, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	resolverFile := filepath.Join(projectRoot, filepath.FromSlash(dir), "resolver.go")
+	wired := 0
+	for _, name := range names {
+		resourceData := data
+		resourceData.Name = name
+		resourceData.NameTitle = strcase.ToCamel(name)
+		resourceData.Vars = make(map[string]any, len(data.Vars)+1)
+		for key, value := range data.Vars {
+			resourceData.Vars[key] = value
+		}
+
+		service, inserted, err := wireService(cfg, projectRoot, resolverFile, name)
+		if err != nil {
+			return err
+		}
+		if service != "" {
+			resourceData.Vars["service"] = service
+		}
+		if inserted {
+			wired++
+		}
+
+		snake := strcase.ToSnake(name)
+		if err := write("type.graphqls.tpl", path.Join(schemaDir, snake+".graphqls"), resourceData, genOptions.OnConflict); err != nil {
+			return err
+		}
+		if err := write("resolvers.go.tpl", path.Join(dir, snake+".resolvers.go"), resourceData, genOptions.OnConflict); err != nil {
+			return err
+		}
+	}
+	if len(written) == 0 && wired == 0 {
+		return nil
+	}
+
+	// Post hooks such as gofumpt only handle the Go files
+	var goFiles []string
+	for _, file := range written {
+		if strings.HasSuffix(file, ".go") {
+			goFiles = append(goFiles, file)
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, goFiles)
+
+	if err := recordDependencies(cfg, projectRoot, []string{gqlgenModule, "github.com/vektah/gqlparser/v2"}); err != nil {
+		return err
+	}
+	if _, ok := cfg.Scripts["graphql:generate"]; !ok {
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", "graphql:generate"}, gqlgenGenerate); err != nil {
+			logger.Warn("Could not add the graphql:generate script to goforge.yml: %v", err)
+		}
+	}
+
+	generated := true
+	logger.Info("⚙️  Generating GraphQL code with gqlgen...")
+	if err := runner.ExecuteScript(projectRoot, gqlgenGenerate); err != nil {
+		logger.Warn("⚠️  Could not generate the GraphQL code: %v", err)
+		generated = false
+	}
+
+	logger.ComponentGenerationComplete("resolver", strings.Join(names, ", "), filepath.Join(projectRoot, filepath.FromSlash(dir)))
+
+	pkg := data.PackageName
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	step := 1
+	if !generated {
+		logger.Info("   %d. Generate the GraphQL code: goforge run graphql:generate", step)
+		step++
+	}
+	logger.Info("   %d. Add fields to the types in %s and rerun goforge run graphql:generate", step, schemaDir)
+	logger.Info("   %d. Implement the resolvers in %s", step+1, dir)
+	logger.Info("   %d. Serve GraphQL in main, setting the services in the Resolver:", step+2)
+	logger.Info("        router.Any(\"/graphql\", gin.WrapH(%s.NewHandler(&%s.Resolver{ /* services */ })))", pkg, pkg)
+	logger.Info("        router.GET(\"/playground\", gin.WrapH(%s.PlaygroundHandler(\"/graphql\")))", pkg)
+
+	return nil
+}
+
+// GenerateGraphQLCode regenerates the GraphQL code of the project in the
+// current directory from its schema with gqlgen.
+func GenerateGraphQLCode() error {
+	_, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	return runner.ExecuteScript(projectRoot, gqlgenGenerate)
+}
+
+// wireService adds the resource's service to the Resolver struct above the
+// goforge:resolvers marker when the project has one. It returns the field
+// name, or "" when there is no service to wire, and whether the Resolver
+// changed.
+func wireService(cfg *project.Config, projectRoot, resolverFile, name string) (string, bool, error) {
+	serviceDir := componentDir(cfg, serviceSpec(), "")
+	title := strcase.ToCamel(name)
+	source, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(serviceDir), strcase.ToSnake(name)+"_service.go"))
+	if err != nil || !strings.Contains(string(source), "type "+title+"Service struct") {
+		logger.Debug("No %sService in %s; the resolvers are not wired to a service", title, serviceDir)
+		return "", false, nil
+	}
+
+	pkg := packageNameFor(serviceDir)
+	field := title + "Service"
+	fieldType := fmt.Sprintf("*%s.%s", pkg, field)
+	entry := field + " " + fieldType
+	result, err := insertAtMarker(resolverFile, resolversMarker, entry, fieldType)
+	if err != nil {
+		return "", false, fmt.Errorf("could not update the Resolver: %w", err)
+	}
+
+	switch result {
+	case markerMissing:
+		logger.Warn("⚠️  %s has no '%s' marker; add the service to the Resolver yourself:", resolverFile, resolversMarker)
+		logger.Warn("   %s", entry)
+		return field, false, nil
+	case markerPresent:
+		return field, false, nil
+	}
+
+	if err := addImports(resolverFile, map[string]string{pkg: path.Join(cfg.ModuleName, serviceDir)}); err != nil {
+		return "", false, fmt.Errorf("could not update the imports of %s: %w", resolverFile, err)
+	}
+	logger.Info("🔌 Wired %s into the Resolver", field)
+	return field, true, nil
+}
+
+// existingServices lists the resources with a service in the project,
+// e.g. "user" for user_service.go.
+func existingServices(cfg *project.Config, projectRoot string) []string {
+	dir := componentDir(cfg, serviceSpec(), "")
+	matches, _ := filepath.Glob(filepath.Join(projectRoot, filepath.FromSlash(dir), "*_service.go"))
+
+	var names []string
+	for _, match := range matches {
+		name := strcase.ToKebab(strings.TrimSuffix(filepath.Base(match), "_service.go"))
+		if eventNamePattern.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// serviceSpec returns the registered service component.
+func serviceSpec() ComponentSpec {
+	spec, _ := LookupComponent("service")
+	return spec
+}
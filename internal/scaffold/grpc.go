@@ -0,0 +1,228 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// grpcMarker is the line in cmd/grpc above which services are registered.
+const grpcMarker = "// goforge:grpc"
+
+// grpcSpec places gRPC server implementations; override it with
+// layout.grpc in goforge.yml.
+var grpcSpec = ComponentSpec{Type: "grpc", Dir: "internal/adapters/grpc"}
+
+// grpcMain is the entry point serving the gRPC services.
+const grpcMain = "cmd/grpc/main.go"
+
+// grpcServerMain is the server of projects created from the grpc template,
+// which registers the services itself.
+const grpcServerMain = "cmd/server/main.go"
+
+// grpcAppConfig is added to config/default.yml with the first service.
+const grpcAppConfig = `# gRPC server (goforge generate grpc).
+grpc:
+  port: 9090
+`
+
+// GenerateGRPC writes a service definition in api/proto/<name>/v1, a
+// server implementing it, and registers the server in cmd/grpc, or in
+// cmd/server in projects from the grpc template. The first service also
+// creates cmd/grpc, when needed, and the buf configuration. The Go
+// code for the .proto file is generated right away when buf and the
+// plugins can be installed; otherwise 'goforge proto generate' does it
+// later.
+func GenerateGRPC(name string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid service name '%s' (use lowercase words separated by hyphens, e.g. user or order-item)", name)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	serverDir, err := resolveDir(cfg, grpcSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+	protoDir, err := resolveDir(cfg, protoSpec, "")
+	if err != nil {
+		return err
+	}
+
+	// user -> api/proto/user/v1/user.proto, package user.v1, Go package userv1
+	snake := strcase.ToSnake(name)
+	protoFile := path.Join(protoDir, snake, "v1", snake+".proto")
+	genImport := path.Join(cfg.ModuleName, protoGenDir, snake, "v1")
+	goPackage := strings.ReplaceAll(snake, "_", "") + "v1"
+	nameTitle := strcase.ToCamel(name)
+
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   nameTitle,
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(serverDir),
+		Vars: map[string]any{
+			"protoPackage": snake + ".v1",
+			"goPackage":    goPackage,
+			"genImport":    genImport,
+			"protoDir":     protoDir,
+			"genDir":       protoGenDir,
+		},
+	}
+
+	logger.ComponentGenerationStart("grpc", name)
+
+	var written []string
+	write := func(template, target string, onConflict string) error {
+		fileData := data
+		fileData.PackageName = packageNameFor(path.Dir(target))
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/grpc", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         fileData,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// Shared files are created once and then belong to the project
+	mainFile := grpcMainFile(projectRoot)
+	shared := []struct{ template, target string }{
+		{"main.go.tpl", mainFile},
+		{"buf.yaml.tpl", "buf.yaml"},
+		{"buf.gen.yaml.tpl", "buf.gen.yaml"},
+	}
+	for _, file := range shared {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			continue
+		}
+		if err := write(file.template, file.target, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	serverFile := path.Join(serverDir, snake+"_server.go")
+	if err := write("service.proto.tpl", protoFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+	if err := write("server.go.tpl", serverFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	registered, err := registerGRPCService(filepath.Join(projectRoot, filepath.FromSlash(mainFile)), nameTitle, goPackage, genImport,
+		packageNameFor(serverDir)+"adapter", path.Join(cfg.ModuleName, serverDir))
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 && !registered {
+		return nil
+	}
+
+	// Post hooks such as gofumpt only handle the Go files
+	var goFiles []string
+	for _, file := range written {
+		if strings.HasSuffix(file, ".go") {
+			goFiles = append(goFiles, file)
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, goFiles)
+
+	if _, err := addAppConfig(projectRoot, "grpc", grpcAppConfig); err != nil {
+		logger.Warn("Could not add the grpc section to %s: %v", oidcAppConfig, err)
+	}
+	if err := recordDependencies(cfg, projectRoot, []string{"google.golang.org/grpc", "google.golang.org/protobuf", "github.com/spf13/viper"}); err != nil {
+		return err
+	}
+	for script, command := range map[string]string{"grpc": "go run ./" + path.Dir(mainFile), "proto:generate": "goforge proto generate"} {
+		if _, ok := cfg.Scripts[script]; ok {
+			continue
+		}
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", script}, command); err != nil {
+			logger.Warn("Could not add the %s script to goforge.yml: %v", script, err)
+		}
+	}
+
+	generated := true
+	if _, err := generateProto(cfg, projectRoot, ProtoOptions{}); err != nil {
+		logger.Warn("⚠️  Could not generate Go code from %s: %v", protoFile, err)
+		generated = false
+	}
+
+	logger.ComponentGenerationComplete("grpc", name, filepath.Join(projectRoot, filepath.FromSlash(serverFile)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	step := 1
+	if !generated {
+		logger.Info("   %d. Generate the Go code: goforge proto generate (or --protoc)", step)
+		step++
+	}
+	logger.Info("   %d. Define the %s messages in %s and regenerate", step, nameTitle, protoFile)
+	logger.Info("   %d. Implement %sServer in %s", step+1, nameTitle, serverFile)
+	logger.Info("   %d. Start the server: goforge run grpc (or go run ./%s)", step+2, path.Dir(mainFile))
+
+	return nil
+}
+
+// grpcMainFile returns the entry point services are registered in: the
+// server of projects from the grpc template, which carries the
+// goforge:grpc marker, or cmd/grpc.
+func grpcMainFile(projectRoot string) string {
+	data, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(grpcServerMain)))
+	if err == nil && strings.Contains(string(data), grpcMarker) {
+		return grpcServerMain
+	}
+	return grpcMain
+}
+
+// registerGRPCService registers the service's server in cmd/grpc above
+// the goforge:grpc marker and imports its packages. It reports whether
+// the file changed.
+func registerGRPCService(mainFile, nameTitle, goPackage, genImport, adapterAlias, adapterImport string) (bool, error) {
+	constructor := fmt.Sprintf("%s.New%sServer()", adapterAlias, nameTitle)
+	entry := fmt.Sprintf("%s.Register%sServiceServer(server, %s)", goPackage, nameTitle, constructor)
+	result, err := insertAtMarker(mainFile, grpcMarker, entry, constructor)
+	if err != nil {
+		return false, fmt.Errorf("could not update %s: %w", mainFile, err)
+	}
+
+	switch result {
+	case markerPresent:
+		logger.Info("✔️  %sServer is already registered", nameTitle)
+		return false, nil
+	case markerMissing:
+		logger.Warn("⚠️  %s has no '%s' marker; register the service yourself:", mainFile, grpcMarker)
+		logger.Warn("   %s", entry)
+		return false, nil
+	}
+
+	err = addImports(mainFile, map[string]string{goPackage: genImport, adapterAlias: adapterImport})
+	if err != nil {
+		return false, fmt.Errorf("could not update the imports of %s: %w", mainFile, err)
+	}
+	logger.Debug("Registered %sServer in %s", nameTitle, mainFile)
+	return true, nil
+}
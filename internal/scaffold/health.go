@@ -0,0 +1,275 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Probes goforge registers for the adapters it scaffolds.
+const (
+	ProbePostgres = "postgres"
+	ProbeRedis    = "redis"
+	ProbeOIDC     = "oidc"
+)
+
+// probesMarker is the line in RegisterProbes above which new probes are added.
+const probesMarker = "// goforge:probes"
+
+// healthSpec places the probe registry; override it with layout.health in
+// goforge.yml.
+var healthSpec = ComponentSpec{Type: "health", Dir: "internal/platform/health"}
+
+// healthcheckMain is the command that runs the probes outside the server.
+const healthcheckMain = "cmd/healthcheck/main.go"
+
+// healthProbe describes how one kind of adapter is probed: the helper file
+// written next to the registry, the line added to RegisterProbes, and the
+// modules the helper imports.
+type healthProbe struct {
+	file        string
+	register    string
+	constructor string // Present in RegisterProbes once the probe is registered
+	modules     []string
+}
+
+var healthProbes = map[string]healthProbe{
+	ProbePostgres: {
+		file:        "postgres",
+		register:    `r.Register("postgres", PostgresFromConfig())`,
+		constructor: "PostgresFromConfig()",
+		modules:     []string{"github.com/jackc/pgx/v5", "github.com/spf13/viper"},
+	},
+	ProbeRedis: {
+		file:        "redis",
+		register:    `r.Register("redis", RedisFromConfig())`,
+		constructor: "RedisFromConfig()",
+		modules:     []string{"github.com/redis/go-redis/v9", "github.com/spf13/viper"},
+	},
+	ProbeOIDC: {
+		file:        "oidc",
+		register:    `r.RegisterOptional("oidc", OIDCFromConfig())`,
+		constructor: "OIDCFromConfig()",
+		modules:     []string{"github.com/spf13/viper"},
+	},
+}
+
+// redisAppConfig is added to config/default.yml with the first Redis probe.
+const redisAppConfig = `# Redis server checked by the redis health probe.
+redis:
+  addr: "localhost:6379"
+`
+
+// GenerateHealthcheckClient writes the dependency probe registry: the
+// Registry with /health/live and /health/ready handlers, RegisterProbes
+// listing the project's probes, and cmd/healthcheck, which runs the same
+// probes from the command line. Probes are registered for the adapters
+// the project already has; later repository, Redis rate limiter and OIDC
+// generators register theirs as they run.
+func GenerateHealthcheckClient(genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if err := requireGin(cfg, "goforge generate healthcheck-client"); err != nil {
+		return err
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, healthSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	data := TemplateData{
+		Name:        "health",
+		NameTitle:   "Health",
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"healthImport":  path.Join(cfg.ModuleName, dir),
+			"healthPackage": packageNameFor(dir),
+		},
+	}
+
+	logger.ComponentGenerationStart("healthcheck-client", dir)
+
+	var written []string
+	for _, file := range []string{"health", "handler", "http"} {
+		ok, err := s.writeHealthFile(projectRoot, file+".go.tpl", path.Join(dir, file+".go"), data, genOptions.OnConflict)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written = append(written, filepath.Join(projectRoot, filepath.FromSlash(dir), file+".go"))
+		}
+	}
+
+	// The registrations and the command belong to the project once created
+	shared := []struct{ template, target string }{
+		{"probes.go.tpl", path.Join(dir, "probes.go")},
+		{"main.go.tpl", healthcheckMain},
+	}
+	for _, file := range shared {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			continue
+		}
+		fileData := data
+		fileData.PackageName = packageNameFor(path.Dir(file.target))
+		ok, err := s.writeHealthFile(projectRoot, file.template, file.target, fileData, ConflictSkip)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written = append(written, filepath.Join(projectRoot, filepath.FromSlash(file.target)))
+		}
+	}
+
+	modules := []string{"github.com/gin-gonic/gin", "github.com/spf13/viper"}
+	probes := detectProbes(cfg, projectRoot)
+	for _, kind := range probes {
+		files, err := s.addProbe(cfg, projectRoot, dir, kind)
+		if err != nil {
+			return err
+		}
+		written = append(written, files...)
+		for _, module := range healthProbes[kind].modules {
+			if !slices.Contains(modules, module) {
+				modules = append(modules, module)
+			}
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, modules); err != nil {
+		return err
+	}
+	if _, ok := cfg.Scripts["health"]; !ok {
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", "health"}, "go run ./"+path.Dir(healthcheckMain)); err != nil {
+			logger.Warn("Could not add the health script to goforge.yml: %v", err)
+		}
+	}
+
+	logger.ComponentGenerationComplete("healthcheck-client", strings.Join(append([]string{"registry"}, probes...), ", "), filepath.Join(projectRoot, filepath.FromSlash(dir)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Serve the endpoints in main:")
+	logger.Info("        registry := %s.NewRegistry(%s.DefaultTimeout)", data.PackageName, data.PackageName)
+	logger.Info("        %s.RegisterProbes(registry)", data.PackageName)
+	logger.Info("        %s.RegisterRoutes(router, registry)", data.PackageName)
+	logger.Info("   2. Check dependencies from the shell: goforge health [probe...]")
+	logger.Info("   3. Add probes for other clients in %s", path.Join(dir, "probes.go"))
+
+	return nil
+}
+
+// registerProbe registers the probe for a newly scaffolded adapter when the
+// project has a probe registry. Failures only warn, since the adapter
+// itself was generated.
+func (s *Scaffolder) registerProbe(cfg *project.Config, projectRoot, kind string) {
+	dir := componentDir(cfg, healthSpec, "")
+	if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(dir), "probes.go")); err != nil {
+		return
+	}
+
+	files, err := s.addProbe(cfg, projectRoot, dir, kind)
+	if err != nil {
+		logger.Warn("Could not register the %s health probe: %v", kind, err)
+		return
+	}
+	s.runPostHooks(cfg, projectRoot, files)
+
+	if err := recordDependencies(cfg, projectRoot, healthProbes[kind].modules); err != nil {
+		logger.Warn("Could not record the %s health probe's modules: %v", kind, err)
+	}
+}
+
+// addProbe writes the helper for a kind of probe if missing and adds its
+// registration to RegisterProbes. It returns the files it wrote; the
+// caller records the probe's modules.
+func (s *Scaffolder) addProbe(cfg *project.Config, projectRoot, dir, kind string) ([]string, error) {
+	probe := healthProbes[kind]
+
+	var written []string
+	helper := path.Join(dir, probe.file+".go")
+	if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(helper))); err != nil {
+		data := TemplateData{Name: "health", NameTitle: "Health", ModulePath: cfg.ModuleName, PackageName: packageNameFor(dir)}
+		ok, err := s.writeHealthFile(projectRoot, probe.file+".go.tpl", helper, data, ConflictSkip)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			written = append(written, filepath.Join(projectRoot, filepath.FromSlash(helper)))
+		}
+	}
+
+	registry := filepath.Join(projectRoot, filepath.FromSlash(dir), "probes.go")
+	registered, err := insertAtMarker(registry, probesMarker, probe.register, probe.constructor)
+	if err != nil {
+		return nil, fmt.Errorf("could not update probe registrations: %w", err)
+	}
+	switch registered {
+	case markerInserted:
+		logger.Info("🩺 Registered the %s health probe", kind)
+	case markerMissing:
+		logger.Warn("⚠️  %s has no '%s' marker; register the probe yourself:", registry, probesMarker)
+		logger.Warn("   %s", probe.register)
+	}
+
+	if kind == ProbeRedis {
+		if _, err := addAppConfig(projectRoot, "redis", redisAppConfig); err != nil {
+			logger.Warn("Could not add the redis section to %s: %v", oidcAppConfig, err)
+		}
+	}
+	return written, nil
+}
+
+// writeHealthFile renders one template of the health generator.
+func (s *Scaffolder) writeHealthFile(projectRoot, template, target string, data TemplateData, onConflict string) (bool, error) {
+	task := FileGenerationTask{
+		TemplatePath: path.Join("templates/components/health", template),
+		TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+		Data:         data,
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return false, err
+	}
+	return s.writeGenerated(task, content, projectRoot, onConflict)
+}
+
+// detectProbes lists the probes for the adapters a project already has:
+// PostgreSQL when it uses pgx or configures a database, Redis when it uses
+// go-redis, and the identity provider when it configures OIDC.
+func detectProbes(cfg *project.Config, projectRoot string) []string {
+	var appConfig map[string]any
+	if data, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(oidcAppConfig))); err == nil {
+		yaml.Unmarshal(data, &appConfig)
+	}
+
+	var probes []string
+	if _, ok := cfg.Dependencies["github.com/jackc/pgx/v5"]; ok || appConfig["database"] != nil {
+		probes = append(probes, ProbePostgres)
+	}
+	if _, ok := cfg.Dependencies["github.com/redis/go-redis/v9"]; ok {
+		probes = append(probes, ProbeRedis)
+	}
+	if appConfig["oidc"] != nil {
+		probes = append(probes, ProbeOIDC)
+	}
+	return probes
+}
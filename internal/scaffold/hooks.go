@@ -0,0 +1,165 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// hooksTemplateDir holds the templates of 'goforge generate hooks'.
+const hooksTemplateDir = "templates/components/hooks"
+
+// HooksDir holds the shell hooks, versioned with the project and enabled
+// with git's core.hooksPath.
+const HooksDir = ".githooks"
+
+// HooksOptions parameterizes the generated git hooks.
+type HooksOptions struct {
+	// PreCommit writes a .pre-commit-config.yaml for the pre-commit
+	// framework instead of shell hooks.
+	PreCommit bool
+}
+
+// hookSteps are the checks of the hooks: the project's script when it has
+// one, the go command otherwise.
+var hookSteps = []struct{ name, fallback string }{
+	{"fmt", "go fmt ./..."},
+	{"lint", "go vet ./..."},
+	{"test", "go test ./..."},
+}
+
+// hookCommands returns the command of each hook step.
+func hookCommands(cfg *project.Config) map[string]any {
+	commands := map[string]any{}
+	for _, step := range hookSteps {
+		commands[step.name] = step.fallback
+		if _, ok := cfg.Scripts[step.name]; ok {
+			commands[step.name] = "goforge run " + step.name
+		}
+	}
+	return commands
+}
+
+// GenerateHooks installs git hooks formatting and linting before each
+// commit and testing before each push: shell hooks in HooksDir, enabled
+// through core.hooksPath, or a pre-commit framework config.
+func GenerateHooks(options HooksOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	logger.ComponentGenerationStart("hooks", cfg.ProjectName)
+
+	type hookFile struct{ template, target string }
+	files := []hookFile{
+		{"pre-commit.tpl", path.Join(HooksDir, "pre-commit")},
+		{"pre-push.tpl", path.Join(HooksDir, "pre-push")},
+	}
+	if options.PreCommit {
+		files = []hookFile{{"pre-commit-config.yaml.tpl", ".pre-commit-config.yaml"}}
+	}
+	data := TemplateData{
+		ProjectName: cfg.ProjectName,
+		ModuleName:  cfg.ModuleName,
+		GoVersion:   cfg.GoVersion,
+		Vars:        hookCommands(cfg),
+	}
+
+	var written []string
+	for _, file := range files {
+		task := FileGenerationTask{
+			TemplatePath: path.Join(hooksTemplateDir, file.template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(file.target)),
+			Data:         data,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		if _, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict); err != nil {
+			return err
+		}
+		if !options.PreCommit {
+			if err := os.Chmod(task.TargetPath, 0755); err != nil {
+				return fmt.Errorf("could not make %s executable: %w", file.target, err)
+			}
+		}
+		written = append(written, file.target)
+	}
+
+	logger.ComponentGenerationComplete("hooks", strings.Join(written, ", "), projectRoot)
+
+	if options.PreCommit {
+		installPreCommit(projectRoot)
+		return nil
+	}
+	return enableHooksPath(projectRoot)
+}
+
+// enableHooksPath points git's core.hooksPath at HooksDir, warning about
+// the hooks in .git/hooks it replaces.
+func enableHooksPath(projectRoot string) error {
+	if _, err := os.Stat(filepath.Join(projectRoot, ".git")); err != nil {
+		logger.Warn("⚠️  %s is not a git repository yet", projectRoot)
+		logger.Info("💡 Enable the hooks after 'git init' with: git config core.hooksPath %s", HooksDir)
+		return nil
+	}
+
+	entries, _ := os.ReadDir(filepath.Join(projectRoot, ".git", "hooks"))
+	var replaced []string
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), ".sample") {
+			replaced = append(replaced, entry.Name())
+		}
+	}
+
+	cmd := exec.Command("git", "config", "core.hooksPath", HooksDir)
+	cmd.Dir = projectRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to set core.hooksPath: %s", strings.TrimSpace(string(out)))
+	}
+	logger.Info("🪝 Enabled the hooks with: git config core.hooksPath %s", HooksDir)
+	if len(replaced) > 0 {
+		logger.Warn("⚠️  Hooks in .git/hooks no longer run: %s", strings.Join(replaced, ", "))
+	}
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Commit %s so the whole team gets the hooks", HooksDir)
+	logger.Info("   2. Each clone enables them with: git config core.hooksPath %s", HooksDir)
+	return nil
+}
+
+// installPreCommit installs the hooks of .pre-commit-config.yaml with the
+// pre-commit framework when it is available.
+func installPreCommit(projectRoot string) {
+	if _, err := exec.LookPath("pre-commit"); err != nil {
+		logger.Info("")
+		logger.Info("📋 Next steps:")
+		logger.Info("   1. Install the framework: pip install pre-commit")
+		logger.Info("   2. Install the hooks: pre-commit install")
+		return
+	}
+	cmd := exec.Command("pre-commit", "install")
+	cmd.Dir = projectRoot
+	if out, err := cmd.CombinedOutput(); err != nil {
+		logger.Warn("⚠️  Could not install the hooks: %s", strings.TrimSpace(string(out)))
+		logger.Info("💡 Install them yourself with: pre-commit install")
+		return
+	}
+	logger.Info("🪝 Installed the hooks with: pre-commit install")
+}
@@ -0,0 +1,189 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/robfig/cron/v3"
+)
+
+// DefaultJobSchedule is used when 'goforge generate job' gets no --schedule.
+const DefaultJobSchedule = "@hourly"
+
+// jobsMarker is the line in the jobs registry above which new jobs are added.
+const jobsMarker = "// goforge:jobs"
+
+// jobSpec places generated jobs and their registry; schedulerSpec places
+// the scheduler package created with the first job. Override them with
+// layout.job and layout.scheduler in goforge.yml.
+var (
+	jobSpec       = ComponentSpec{Type: "job", Dir: "internal/jobs"}
+	schedulerSpec = ComponentSpec{Type: "scheduler", Dir: "internal/platform/scheduler"}
+)
+
+// workerMain is the entry point that runs the scheduler.
+const workerMain = "cmd/worker/main.go"
+
+// jobNamePattern accepts names like "cleanup-sessions" or "send_digest".
+var jobNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*([-_][a-z0-9]+)*$`)
+
+// JobOptions parameterizes a generated job.
+type JobOptions struct {
+	Schedule string // Cron expression or descriptor such as "@every 5m"
+}
+
+// GenerateJob writes a background job and registers it in the jobs
+// registry. The first job also creates the registry, the scheduler
+// package, and cmd/worker, which runs the jobs with graceful shutdown.
+func GenerateJob(name string, options JobOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !jobNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid job name '%s' (use lowercase words separated by hyphens, e.g. cleanup-sessions)", name)
+	}
+	if options.Schedule == "" {
+		options.Schedule = DefaultJobSchedule
+	}
+	if _, err := cron.ParseStandard(options.Schedule); err != nil {
+		return exitcode.Errorf(exitcode.Validation, "invalid schedule '%s': %w\n\nUse cron syntax such as \"0 * * * *\" or a descriptor such as @hourly or \"@every 5m\"", options.Schedule, err)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	jobsDir, err := resolveDir(cfg, jobSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+	schedulerDir, err := resolveDir(cfg, schedulerSpec, "")
+	if err != nil {
+		return err
+	}
+
+	nameTitle := strcase.ToCamel(name)
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   nameTitle,
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(jobsDir),
+		Vars: map[string]any{
+			"schedule":         options.Schedule,
+			"jobsImport":       path.Join(cfg.ModuleName, jobsDir),
+			"jobsPackage":      packageNameFor(jobsDir),
+			"schedulerImport":  path.Join(cfg.ModuleName, schedulerDir),
+			"schedulerPackage": packageNameFor(schedulerDir),
+		},
+	}
+
+	logger.ComponentGenerationStart("job", name)
+
+	var written []string
+	write := func(template, target string, onConflict string) error {
+		fileData := data
+		fileData.PackageName = packageNameFor(path.Dir(target))
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/job", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         fileData,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// Shared files are created once and then belong to the project
+	shared := []struct{ template, target string }{
+		{"scheduler.go.tpl", path.Join(schedulerDir, "scheduler.go")},
+		{"jobs.go.tpl", path.Join(jobsDir, "jobs.go")},
+		{"worker.go.tpl", workerMain},
+	}
+	for _, file := range shared {
+		if existing, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			// The worker template's cmd/worker consumes a queue instead
+			if file.target == workerMain && !strings.Contains(string(existing), `"`+data.Vars["jobsImport"].(string)+`"`) {
+				logger.Warn("⚠️  %s does not run the jobs; start the scheduler there:", workerMain)
+				logger.Warn("   s := %s.New(logger); %s.Register(s); s.Start()", data.Vars["schedulerPackage"], data.Vars["jobsPackage"])
+			}
+			continue
+		}
+		if err := write(file.template, file.target, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	jobFile := path.Join(jobsDir, strcase.ToSnake(name)+".go")
+	if err := write("job.go.tpl", jobFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	registered, err := registerJob(filepath.Join(projectRoot, filepath.FromSlash(jobsDir), "jobs.go"), nameTitle)
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 && !registered {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, []string{"github.com/robfig/cron/v3"}); err != nil {
+		return err
+	}
+	if _, ok := cfg.Scripts["worker"]; !ok {
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", "worker"}, "go run ./"+path.Dir(workerMain)); err != nil {
+			logger.Warn("Could not add the worker script to goforge.yml: %v", err)
+		}
+	}
+
+	logger.ComponentGenerationComplete("job", name, filepath.Join(projectRoot, filepath.FromSlash(jobFile)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Implement %sJob.Run in %s", nameTitle, jobFile)
+	logger.Info("   2. Start the worker: goforge run worker (or go run ./%s)", path.Dir(workerMain))
+	logger.Info("   3. Change the schedule in %sSchedule (%s)", nameTitle, options.Schedule)
+
+	return nil
+}
+
+// registerJob adds the job to the registry above the goforge:jobs marker.
+// It reports whether the registry changed; a job already listed is left
+// alone, and a registry without the marker gets a warning instead.
+func registerJob(registry, nameTitle string) (bool, error) {
+	constructor := fmt.Sprintf("New%sJob()", nameTitle)
+	entry := fmt.Sprintf("{%sSchedule, %s},", nameTitle, constructor)
+	result, err := insertAtMarker(registry, jobsMarker, entry, constructor)
+	if err != nil {
+		return false, fmt.Errorf("could not update jobs registry: %w", err)
+	}
+
+	switch result {
+	case markerPresent:
+		logger.Info("✔️  %sJob is already registered", nameTitle)
+	case markerMissing:
+		logger.Warn("⚠️  %s has no '%s' marker; register the job yourself:", registry, jobsMarker)
+		logger.Warn("   %s", entry)
+	default:
+		logger.Debug("Registered %sJob in %s", nameTitle, registry)
+	}
+	return result == markerInserted, nil
+}
@@ -0,0 +1,132 @@
+package scaffold
+
+import (
+	"strings"
+)
+
+// Protected regions let users keep hand-written code across regeneration:
+//
+//	// goforge:keep custom-routes
+//	... anything here survives re-running the generator ...
+//	// goforge:end
+//
+// Files without "//" comments, such as Makefiles, mark them with "#".
+//
+// When a file is regenerated, the body of every named region in the
+// existing file replaces the body of the region with the same name in the
+// freshly rendered output. Regions that no longer exist in the template are
+// appended to the end of the file so no code is ever dropped silently.
+const (
+	keepMarker = "goforge:keep"
+	endMarker  = "goforge:end"
+)
+
+type keepRegion struct {
+	name string
+	body []string
+}
+
+// extractKeepRegions returns the protected regions of content in order.
+func extractKeepRegions(content string) []keepRegion {
+	var regions []keepRegion
+	var current *keepRegion
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case current == nil && isKeepStart(trimmed):
+			current = &keepRegion{name: keepRegionName(trimmed)}
+		case current != nil && isKeepEnd(trimmed):
+			regions = append(regions, *current)
+			current = nil
+		case current != nil:
+			current.body = append(current.body, line)
+		}
+	}
+
+	return regions
+}
+
+// applyKeepRegions copies protected region bodies from existing into
+// rendered.
+func applyKeepRegions(rendered, existing string) string {
+	regions := extractKeepRegions(existing)
+	if len(regions) == 0 {
+		return rendered
+	}
+
+	bodies := make(map[string][]string, len(regions))
+	for _, r := range regions {
+		bodies[r.name] = r.body
+	}
+
+	var out []string
+	used := make(map[string]bool)
+	inRegion := false
+
+	for _, line := range strings.Split(rendered, "\n") {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case !inRegion && isKeepStart(trimmed):
+			name := keepRegionName(trimmed)
+			out = append(out, line)
+			if body, ok := bodies[name]; ok {
+				out = append(out, body...)
+				used[name] = true
+				inRegion = true
+			}
+		case inRegion && isKeepEnd(trimmed):
+			out = append(out, line)
+			inRegion = false
+		case inRegion:
+			// Drop the template's default body; the kept body replaces it.
+		default:
+			out = append(out, line)
+		}
+	}
+
+	result := strings.Join(out, "\n")
+
+	// Preserve regions the new template no longer declares.
+	var orphans []string
+	for _, r := range regions {
+		if used[r.name] {
+			continue
+		}
+		orphans = append(orphans, "// "+keepMarker+" "+r.name)
+		orphans = append(orphans, r.body...)
+		orphans = append(orphans, "// "+endMarker)
+	}
+	if len(orphans) > 0 {
+		result = strings.TrimRight(result, "\n") + "\n\n" + strings.Join(orphans, "\n") + "\n"
+	}
+
+	return result
+}
+
+// commentText returns the text of a "//" or "#" line comment, so regions
+// work in Go files as well as in Makefiles and YAML.
+func commentText(trimmed string) string {
+	for _, prefix := range []string{"// ", "# "} {
+		if text, ok := strings.CutPrefix(trimmed, prefix); ok {
+			return text
+		}
+	}
+	return ""
+}
+
+func isKeepStart(trimmed string) bool {
+	return strings.HasPrefix(commentText(trimmed), keepMarker)
+}
+
+func isKeepEnd(trimmed string) bool {
+	return strings.HasPrefix(commentText(trimmed), endMarker)
+}
+
+func keepRegionName(trimmed string) string {
+	name := strings.TrimSpace(strings.TrimPrefix(commentText(trimmed), keepMarker))
+	if name == "" {
+		return "default"
+	}
+	return name
+}
@@ -0,0 +1,76 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/userconfig"
+)
+
+// Licenses lists the licenses of 'goforge new --license', the default
+// first.
+var Licenses = []string{"MIT", "Apache-2.0", "GPL-3.0", NoLicense}
+
+// NoLicense creates a project without a LICENSE file.
+const NoLicense = "none"
+
+// defaultLicense is used when neither --license nor the user config
+// chooses one.
+const defaultLicense = "MIT"
+
+// licenseTemplateDir holds the license texts, <license>.tpl each.
+const licenseTemplateDir = "templates/components/license"
+
+// NormalizeLicense returns the spelling in Licenses of name, which is
+// matched regardless of case.
+func NormalizeLicense(name string) (string, error) {
+	for _, license := range Licenses {
+		if strings.EqualFold(license, name) {
+			return license, nil
+		}
+	}
+	return "", fmt.Errorf("unknown license '%s' (use %s)", name, strings.Join(Licenses, ", "))
+}
+
+// DefaultLicense returns the license of projects created without
+// --license: license in the user config, or MIT.
+func DefaultLicense() string {
+	if cfg, err := userconfig.Load(); err == nil && cfg.License != "" {
+		return cfg.License
+	}
+	return defaultLicense
+}
+
+// DefaultAuthor returns the copyright holder of new projects: author in
+// the user config, or git's user.name. It is empty when neither is set.
+func DefaultAuthor() string {
+	if cfg, err := userconfig.Load(); err == nil && cfg.Author != "" {
+		return cfg.Author
+	}
+	out, err := exec.Command("git", "config", "--get", "user.name").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeLicense renders the LICENSE file of a new project, unless the
+// template brings its own.
+func (s *Scaffolder) writeLicense(options Options, data TemplateData) error {
+	if options.License == "" || options.License == NoLicense {
+		return nil
+	}
+	target := filepath.Join(options.DestPath, "LICENSE")
+	if _, err := os.Stat(target); err == nil {
+		return nil
+	}
+	return s.generateFile(FileGenerationTask{
+		TemplatePath: path.Join(licenseTemplateDir, options.License+".tpl"),
+		TargetPath:   target,
+		Data:         data,
+	})
+}
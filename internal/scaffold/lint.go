@@ -0,0 +1,34 @@
+package scaffold
+
+import (
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// LintConfigFile is the golangci-lint configuration 'goforge lint' writes
+// when the project has none.
+const LintConfigFile = ".golangci.yml"
+
+// WriteLintConfig writes the default golangci-lint configuration, with the
+// project's module as the local import prefix, to the project root. It
+// reports whether the file was written; an existing one is kept.
+func WriteLintConfig(projectRoot string, cfg *project.Config) (bool, error) {
+	s := NewScaffolder()
+	s.configure(cfg)
+
+	task := FileGenerationTask{
+		TemplatePath: "templates/components/lint/golangci.yml.tpl",
+		TargetPath:   filepath.Join(projectRoot, LintConfigFile),
+		Data: TemplateData{
+			ProjectName: cfg.ProjectName,
+			ModuleName:  cfg.ModuleName,
+			GoVersion:   cfg.GoVersion,
+		},
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return false, err
+	}
+	return s.writeGenerated(task, content, projectRoot, ConflictSkip)
+}
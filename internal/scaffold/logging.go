@@ -0,0 +1,16 @@
+package scaffold
+
+import "github.com/night-slayer18/goforge/internal/project"
+
+// Loggers lists the logging libraries of 'goforge new --logger', the
+// default first.
+var Loggers = []string{"slog", "zap", "zerolog"}
+
+// loggerVars makes the project's logging library available to component
+// templates as .Vars.logger, empty in projects without one, unless the
+// component declares a logger variable of its own.
+func loggerVars(cfg *project.Config, vars map[string]any) {
+	if _, ok := vars["logger"]; !ok {
+		vars["logger"] = cfg.Logger
+	}
+}
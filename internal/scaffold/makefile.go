@@ -0,0 +1,130 @@
+package scaffold
+
+import (
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// Formats of 'goforge generate makefile' and 'taskfile'.
+const (
+	FormatMake = "make"
+	FormatTask = "task"
+)
+
+// runnerTemplateDir holds the Makefile and Taskfile templates.
+const runnerTemplateDir = "templates/components/makefile"
+
+// runnerFiles maps the formats to their template and the file it renders.
+var runnerFiles = map[string]struct{ template, target string }{
+	FormatMake: {"Makefile.tpl", "Makefile"},
+	FormatTask: {"Taskfile.yml.tpl", "Taskfile.yml"},
+}
+
+// runnerTarget is a target of the generated Makefile or a task of the
+// Taskfile.
+type runnerTarget struct {
+	Name        string // Make target: the task name with other characters than [A-Za-z0-9_.-] as '-'
+	Task        string
+	Command     string // Arguments of goforge
+	Description string
+}
+
+// builtinTargets run goforge commands; scripts of the same name replace
+// them.
+var builtinTargets = []runnerTarget{
+	{Name: "build", Task: "build", Command: "build", Description: "Build the binary (goforge build)"},
+	{Name: "clean", Task: "clean", Command: "clean", Description: "Remove the build output (goforge clean)"},
+}
+
+// makeTargetChars matches the characters make targets are not written
+// with.
+var makeTargetChars = regexp.MustCompile(`[^A-Za-z0-9_.-]+`)
+
+// runnerTargets returns the targets for the built-in commands and the
+// scripts of goforge.yml, run through 'goforge run'.
+func runnerTargets(scripts map[string]string) []runnerTarget {
+	var targets []runnerTarget
+	taken := map[string]bool{}
+	for _, name := range slices.Sorted(maps.Keys(scripts)) {
+		command, _, _ := strings.Cut(strings.TrimSpace(scripts[name]), "\n")
+		target := runnerTarget{
+			Name:        makeTargetChars.ReplaceAllString(name, "-"),
+			Task:        name,
+			Command:     "run " + name,
+			Description: command,
+		}
+		if taken[target.Name] || target.Name == "help" {
+			continue
+		}
+		taken[target.Name] = true
+		targets = append(targets, target)
+	}
+	for i := len(builtinTargets) - 1; i >= 0; i-- {
+		if !taken[builtinTargets[i].Name] {
+			targets = append([]runnerTarget{builtinTargets[i]}, targets...)
+		}
+	}
+	return targets
+}
+
+// GenerateRunnerFile writes a Makefile (FormatMake) or Taskfile.yml
+// (FormatTask) whose targets delegate to goforge build, clean, and the
+// scripts of goforge.yml. Targets added between the goforge:keep markers
+// survive regeneration.
+func GenerateRunnerFile(format string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	file, ok := runnerFiles[format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s' (use %s or %s)", format, FormatMake, FormatTask)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	logger.ComponentGenerationStart(strings.ToLower(file.target), cfg.ProjectName)
+
+	task := FileGenerationTask{
+		TemplatePath: path.Join(runnerTemplateDir, file.template),
+		TargetPath:   filepath.Join(projectRoot, file.target),
+		Data: TemplateData{
+			ProjectName: cfg.ProjectName,
+			ModuleName:  cfg.ModuleName,
+			GoVersion:   cfg.GoVersion,
+			Vars:        map[string]any{"targets": runnerTargets(cfg.Scripts)},
+		},
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return err
+	}
+	if _, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationComplete(strings.ToLower(file.target), file.target, projectRoot)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	if format == FormatMake {
+		logger.Info("   1. List the targets: make help")
+	} else {
+		logger.Info("   1. List the tasks: task --list")
+	}
+	logger.Info("   2. Regenerate it after changing the scripts in goforge.yml")
+	return nil
+}
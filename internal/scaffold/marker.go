@@ -0,0 +1,155 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Results of insertAtMarker.
+const (
+	markerInserted = "inserted"
+	markerPresent  = "present"
+	markerMissing  = "missing"
+)
+
+// insertAtMarker adds line to file above the marker comment, indented like
+// the marker, so generators can keep registration lists up to date. Files
+// already containing key, e.g. the constructor the line calls, are left
+// alone.
+func insertAtMarker(file, marker, line, key string) (string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	content := string(data)
+	if strings.Contains(content, key) {
+		return markerPresent, nil
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, current := range lines {
+		if strings.TrimSpace(current) != marker {
+			continue
+		}
+		indent := current[:len(current)-len(strings.TrimLeft(current, " \t"))]
+		lines = append(lines[:i], append([]string{indent + line}, lines[i:]...)...)
+		if err := os.WriteFile(file, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+			return "", err
+		}
+		return markerInserted, nil
+	}
+	return markerMissing, nil
+}
+
+// addImports adds named imports (name -> path) missing from a Go file, so
+// a line inserted at a marker can use them, and gofmts the file. Imports
+// join the last import group when it imports from the same module and
+// start a new group otherwise, keeping the project's packages apart from
+// the standard library and third-party ones. Names matching the path's
+// last element are left out.
+func addImports(file string, imports map[string]string) error {
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, file, nil, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return err
+	}
+
+	existing := make(map[string]bool)
+	for _, spec := range parsed.Imports {
+		existing[strings.Trim(spec.Path.Value, `"`)] = true
+	}
+	var names []string
+	for name, importPath := range imports {
+		if !existing[importPath] {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return formatFile(file)
+	}
+	sort.Strings(names)
+
+	// Without a parenthesized import block, let astutil create one
+	var block *ast.GenDecl
+	for _, decl := range parsed.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT && gen.Lparen.IsValid() {
+			block = gen
+			break
+		}
+	}
+	if block == nil {
+		parsed, err = parser.ParseFile(fset, file, nil, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			if name == path.Base(imports[name]) {
+				astutil.AddImport(fset, parsed, imports[name])
+			} else {
+				astutil.AddNamedImport(fset, parsed, name, imports[name])
+			}
+		}
+		var buf bytes.Buffer
+		if err := format.Node(&buf, fset, parsed); err != nil {
+			return err
+		}
+		return os.WriteFile(file, buf.Bytes(), 0644)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	var lines strings.Builder
+	if n := len(block.Specs); n > 0 {
+		last := strings.Trim(block.Specs[n-1].(*ast.ImportSpec).Path.Value, `"`)
+		if firstElem(last) != firstElem(imports[names[0]]) {
+			lines.WriteString("\n")
+		}
+	}
+	for _, name := range names {
+		if name == path.Base(imports[name]) {
+			fmt.Fprintf(&lines, "\t%q\n", imports[name])
+		} else {
+			fmt.Fprintf(&lines, "\t%s %q\n", name, imports[name])
+		}
+	}
+	offset := fset.Position(block.Rparen).Offset
+	updated := string(data[:offset]) + lines.String() + string(data[offset:])
+
+	formatted, err := format.Source([]byte(updated))
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, formatted, 0644)
+}
+
+// formatFile gofmts a Go file, e.g. after lines were inserted at a marker.
+func formatFile(file string) error {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	formatted, err := format.Source(data)
+	if err != nil || bytes.Equal(formatted, data) {
+		return err
+	}
+	return os.WriteFile(file, formatted, 0644)
+}
+
+// firstElem returns the first element of an import path.
+func firstElem(importPath string) string {
+	elem, _, _ := strings.Cut(importPath, "/")
+	return elem
+}
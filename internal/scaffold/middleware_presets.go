@@ -0,0 +1,128 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// Middleware presets accepted by 'goforge generate middleware --preset'.
+const (
+	PresetCORS      = "cors"
+	PresetJWT       = "jwt"
+	PresetRateLimit = "ratelimit"
+	PresetRequestID = "requestid"
+	PresetRecovery  = "recovery"
+)
+
+// MiddlewarePresets lists the presets in the order they are documented.
+var MiddlewarePresets = []string{PresetCORS, PresetJWT, PresetRateLimit, PresetRequestID, PresetRecovery}
+
+// presetModules are the modules each preset imports besides gin.
+var presetModules = map[string][]string{
+	PresetJWT: {"github.com/golang-jwt/jwt/v5"},
+}
+
+// presetUsage is the constructor call shown after generating a preset.
+var presetUsage = map[string]string{
+	PresetCORS:      "router.Use(%[1]s.New%[2]sMiddleware(%[1]s.Default%[2]sConfig()).Handler())",
+	PresetJWT:       "auth, err := %[1]s.New%[2]sMiddleware(%[1]s.%[2]sConfig{Secret: []byte(os.Getenv(\"JWT_SECRET\"))})",
+	PresetRateLimit: "router.Use(%[1]s.New%[2]sMiddleware(%[1]s.Default%[2]sConfig()).Handler())",
+	PresetRequestID: "router.Use(%[1]s.New%[2]sMiddleware(%[1]s.Default%[2]sConfig()).Handler())",
+	PresetRecovery:  "router.Use(%[1]s.New%[2]sMiddleware(%[1]s.Default%[2]sConfig()).Handler())  // register first",
+}
+
+// ValidMiddlewarePreset reports whether preset is a known preset.
+func ValidMiddlewarePreset(preset string) bool {
+	for _, p := range MiddlewarePresets {
+		if p == preset {
+			return true
+		}
+	}
+	return false
+}
+
+// GenerateMiddlewarePreset writes a working middleware implementation and
+// its tests in place of the empty middleware skeleton. name defaults to
+// the preset and may be namespaced like any component name.
+func GenerateMiddlewarePreset(preset, name string, options GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !ValidMiddlewarePreset(preset) {
+		return fmt.Errorf("unknown middleware preset '%s' (use %s)", preset, strings.Join(MiddlewarePresets, ", "))
+	}
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if name == "" {
+		name = preset
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if err := requireGin(cfg, "goforge generate middleware --preset"); err != nil {
+		return err
+	}
+	s.configure(cfg)
+
+	custom, err := CustomComponents(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	task, name, err := s.planComponent(cfg, projectRoot, custom, "middleware", name, options)
+	if err != nil {
+		return reportInvalid(err, "invalid middleware")
+	}
+	task.Source = nil
+	task.TemplatePath = path.Join("templates/components/middleware", preset+".go.tpl")
+	testTask := task
+	testTask.TemplatePath = path.Join("templates/components/middleware", preset+"_test.go.tpl")
+	testTask.TargetPath = strings.TrimSuffix(task.TargetPath, ".go") + "_test.go"
+
+	logger.ComponentGenerationStart("middleware", name+" ("+preset+")")
+
+	var written []string
+	for _, t := range []FileGenerationTask{task, testTask} {
+		content, err := s.renderTemplate(t)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(t, content, projectRoot, options.OnConflict)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written = append(written, t.TargetPath)
+		}
+	}
+	if len(written) == 0 {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, append([]string{"github.com/gin-gonic/gin"}, presetModules[preset]...)); err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationComplete("middleware", name, task.TargetPath)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Register it: "+presetUsage[preset], task.Data.PackageName, task.Data.NameTitle)
+	if preset == PresetJWT {
+		logger.Info("   2. Protect a group: auth.Apply(router.Group(\"/api/v1\"))")
+		logger.Info("   3. Read the caller in handlers: %s.%sSubject(c)", task.Data.PackageName, task.Data.NameTitle)
+	} else {
+		logger.Info("   2. Adjust the %sConfig to your needs", task.Data.NameTitle)
+	}
+	logger.Info("   Run the generated tests with: go test ./%s", path.Dir(filepath.ToSlash(relativeTo(projectRoot, task.TargetPath))))
+
+	return nil
+}
@@ -0,0 +1,290 @@
+package scaffold
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Migration engines, chosen with migrations.engine in goforge.yml.
+const (
+	MigrationEngineGolangMigrate = "golang-migrate"
+	MigrationEngineGoose         = "goose"
+	MigrationEngineAtlas         = "atlas"
+)
+
+// migrationEngines maps each engine to its binary and the arguments of the
+// 'go install' installing it.
+var migrationEngines = map[string]struct {
+	binary  string
+	install []string
+}{
+	MigrationEngineGolangMigrate: {binary: "migrate", install: []string{"-tags", "postgres mysql sqlite3", "github.com/golang-migrate/migrate/v4/cmd/migrate@latest"}},
+	MigrationEngineGoose:         {binary: "goose", install: []string{"github.com/pressly/goose/v3/cmd/goose@latest"}},
+	MigrationEngineAtlas:         {binary: "atlas", install: []string{"ariga.io/atlas/cmd/atlas@latest"}},
+}
+
+// migrationsDir holds the migrations unless migrations.dir says otherwise;
+// sqlc reads them as the schema.
+const migrationsDir = "migrations"
+
+// gooseDrivers maps the schemes of database URLs to goose's drivers.
+var gooseDrivers = map[string]string{
+	"postgres":   "postgres",
+	"postgresql": "postgres",
+	"mysql":      "mysql",
+	"sqlite":     "sqlite3",
+	"sqlite3":    "sqlite3",
+	"sqlserver":  "sqlserver",
+	"clickhouse": "clickhouse",
+}
+
+// gooseDSN converts a database URL to the connection string goose's
+// driver takes: MySQL wants user:password@tcp(host)/db and SQLite a file
+// path, while the others read URLs.
+func gooseDSN(driver, databaseURL string) (string, error) {
+	switch driver {
+	case "sqlite3":
+		_, file, _ := strings.Cut(databaseURL, "://")
+		return file, nil
+	case "mysql":
+		u, err := url.Parse(databaseURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid database URL: %w", err)
+		}
+		dsn := "tcp(" + u.Host + ")" + u.Path
+		if u.User != nil {
+			dsn = u.User.String() + "@" + dsn
+		}
+		if u.RawQuery != "" {
+			dsn += "?" + u.RawQuery
+		}
+		return dsn, nil
+	}
+	return databaseURL, nil
+}
+
+// Migrations are the migration settings of a project, with the defaults
+// filled in.
+type Migrations struct {
+	Engine      string
+	Dir         string // relative to the project root, with forward slashes
+	Table       string // table recording the applied migrations; the engine's default when empty
+	DatabaseURL string
+	DevURL      string // atlas's dev database, which migrating down needs
+}
+
+// LoadMigrations returns the migration settings of goforge.yml.
+func LoadMigrations(cfg *project.Config) (Migrations, error) {
+	m := Migrations{Engine: MigrationEngineGolangMigrate, Dir: migrationsDir}
+	if c := cfg.Migrations; c != nil {
+		if c.Engine != "" {
+			m.Engine = c.Engine
+		}
+		if c.Dir != "" {
+			m.Dir = path.Clean(filepath.ToSlash(c.Dir))
+		}
+		m.Table, m.DatabaseURL, m.DevURL = c.Table, c.DatabaseURL, c.DevURL
+	}
+	if _, ok := migrationEngines[m.Engine]; !ok {
+		return m, fmt.Errorf("unknown migration engine '%s' in migrations.engine (use %s, %s, or %s)",
+			m.Engine, MigrationEngineGolangMigrate, MigrationEngineGoose, MigrationEngineAtlas)
+	}
+	return m, nil
+}
+
+// MigrationVersion returns the version of a migration created now.
+func MigrationVersion() string {
+	return time.Now().UTC().Format("20060102150405")
+}
+
+// MigrationFile is a file of a migration.
+type MigrationFile struct {
+	Path    string // relative to the project root, with forward slashes
+	Content string
+}
+
+// Files returns the files of a migration in the engine's format:
+// golang-migrate keeps up and down apart, goose puts both in one annotated
+// file, and atlas only takes the up statements, computing the way down
+// itself.
+func (m Migrations) Files(version, name, up, down string) []MigrationFile {
+	base := path.Join(m.Dir, version+"_"+name)
+	up, down = strings.TrimRight(up, "\n")+"\n", strings.TrimRight(down, "\n")+"\n"
+	switch m.Engine {
+	case MigrationEngineGoose:
+		return []MigrationFile{{Path: base + ".sql", Content: "-- +goose Up\n" + up + "\n-- +goose Down\n" + down}}
+	case MigrationEngineAtlas:
+		return []MigrationFile{{Path: base + ".sql", Content: up}}
+	}
+	return []MigrationFile{
+		{Path: base + ".up.sql", Content: up},
+		{Path: base + ".down.sql", Content: down},
+	}
+}
+
+// CreateMigration writes a new migration with the up and down SQL and
+// returns its files. The name is snake-cased, as in add_email_to_users.
+func CreateMigration(projectRoot string, m Migrations, name, up, down string) ([]string, error) {
+	name = strcase.ToSnake(name)
+	if name == "" {
+		return nil, fmt.Errorf("the migration needs a name, e.g. add_email_to_users")
+	}
+	var written []string
+	for _, file := range m.Files(MigrationVersion(), name, up, down) {
+		target := filepath.Join(projectRoot, filepath.FromSlash(file.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return written, err
+		}
+		if err := os.WriteFile(target, []byte(file.Content), 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+		logger.Info("📄 Created %s", file.Path)
+		written = append(written, file.Path)
+	}
+	if err := m.afterCreate(projectRoot); err != nil {
+		return written, err
+	}
+	return written, nil
+}
+
+// afterCreate brings the engine up to date with new migration files:
+// atlas checks them against the sums in atlas.sum.
+func (m Migrations) afterCreate(projectRoot string) error {
+	if m.Engine != MigrationEngineAtlas {
+		return nil
+	}
+	atlas, err := ensureMigrationTool(m.Engine)
+	if err != nil {
+		return fmt.Errorf("%w; update atlas.sum with 'atlas migrate hash' once it is installed", err)
+	}
+	return runner.ExecuteCommand(projectRoot, atlas, "migrate", "hash", "--dir", "file://"+m.Dir)
+}
+
+// MigrationActions are what RunMigrations does.
+var MigrationActions = []string{"up", "down", "status"}
+
+// RunMigrations applies the migrations up, rolls them back, or shows
+// their status with the engine's CLI, installing it when missing. steps
+// limits up and down; 0 applies every pending migration and rolls back
+// one.
+func RunMigrations(projectRoot string, m Migrations, action string, steps int) error {
+	if !slices.Contains(MigrationActions, action) {
+		return fmt.Errorf("unknown migration action '%s' (use %s)", action, strings.Join(MigrationActions, ", "))
+	}
+	if m.DatabaseURL == "" {
+		return fmt.Errorf("no database to migrate: set migrations.database_url in goforge.yml or DATABASE_URL")
+	}
+	invocations, err := m.commands(action, steps)
+	if err != nil {
+		return err
+	}
+	binary, err := ensureMigrationTool(m.Engine)
+	if err != nil {
+		return err
+	}
+	for _, args := range invocations {
+		opts := runner.DefaultOptions()
+		opts.Dir = projectRoot
+		if err := runner.ExecuteCommandWithOptions(binary, args, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commands returns the arguments of the engine's CLI for action, one
+// slice per invocation.
+func (m Migrations) commands(action string, steps int) ([][]string, error) {
+	if action == "down" && steps == 0 {
+		steps = 1
+	}
+	count := func() []string {
+		if steps == 0 {
+			return nil
+		}
+		return []string{strconv.Itoa(steps)}
+	}
+
+	switch m.Engine {
+	case MigrationEngineGoose:
+		scheme, _, _ := strings.Cut(m.DatabaseURL, "://")
+		driver, ok := gooseDrivers[scheme]
+		if !ok {
+			return nil, fmt.Errorf("goose has no driver for the database URL scheme '%s'", scheme)
+		}
+		dsn, err := gooseDSN(driver, m.DatabaseURL)
+		if err != nil {
+			return nil, err
+		}
+		base := []string{"-dir", m.Dir}
+		if m.Table != "" {
+			base = append(base, "-table", m.Table)
+		}
+		base = append(base, driver, dsn)
+		switch {
+		case action == "status":
+			return [][]string{append(base, "status")}, nil
+		case action == "up" && steps == 0:
+			return [][]string{append(base, "up")}, nil
+		}
+		// goose moves one migration at a time
+		command := "down"
+		if action == "up" {
+			command = "up-by-one"
+		}
+		var invocations [][]string
+		for range steps {
+			invocations = append(invocations, append(slices.Clone(base), command))
+		}
+		return invocations, nil
+
+	case MigrationEngineAtlas:
+		base := []string{"--dir", "file://" + m.Dir, "--url", m.DatabaseURL}
+		switch action {
+		case "status":
+			return [][]string{slices.Concat([]string{"migrate", "status"}, base)}, nil
+		case "up":
+			return [][]string{slices.Concat([]string{"migrate", "apply"}, base, count())}, nil
+		}
+		if m.DevURL == "" {
+			return nil, fmt.Errorf("atlas needs a dev database to migrate down: set migrations.dev_url in goforge.yml, e.g. docker://postgres/16/dev")
+		}
+		return [][]string{slices.Concat([]string{"migrate", "down"}, base, []string{"--dev-url", m.DevURL}, count())}, nil
+	}
+
+	database := m.DatabaseURL
+	if m.Table != "" {
+		u, err := url.Parse(database)
+		if err != nil {
+			return nil, fmt.Errorf("invalid database URL: %w", err)
+		}
+		query := u.Query()
+		query.Set("x-migrations-table", m.Table)
+		u.RawQuery = query.Encode()
+		database = u.String()
+	}
+	base := []string{"-path", m.Dir, "-database", database}
+	if action == "status" {
+		return [][]string{append(base, "version")}, nil
+	}
+	return [][]string{slices.Concat(base, []string{action}, count())}, nil
+}
+
+// ensureMigrationTool returns the path of the engine's CLI, installing it
+// if missing.
+func ensureMigrationTool(engine string) (string, error) {
+	tool := migrationEngines[engine]
+	return ensureGoTool(tool.binary, tool.install...)
+}
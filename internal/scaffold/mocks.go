@@ -0,0 +1,357 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Mock generators supported by 'goforge generate mock' and 'goforge mocks'.
+const (
+	MockToolMockgen = "mockgen"
+	MockToolMockery = "mockery"
+)
+
+// mockSpec places generated mocks; override it with layout.mock in
+// goforge.yml. Mocks are rewritten on every run, so the directory is
+// marked as generated.
+var mockSpec = ComponentSpec{Type: "mock", Description: "Mocks of port interfaces", Dir: "internal/mocks", Generated: true}
+
+// mockTools maps each tool to its install path and the module its mocks import.
+var mockTools = map[string]struct {
+	install string
+	runtime string
+}{
+	MockToolMockgen: {install: "go.uber.org/mock/mockgen@latest", runtime: "go.uber.org/mock"},
+	MockToolMockery: {install: "github.com/vektra/mockery/v2@latest", runtime: "github.com/stretchr/testify"},
+}
+
+// mockFileSuffix ends the name of every generated mock file.
+const mockFileSuffix = "_mock.go"
+
+// MockOptions configures mock generation.
+type MockOptions struct {
+	Tool string // mockgen or mockery; defaults to mocks.tool in goforge.yml, then mockgen
+	Path string // Ports directory, when the port was generated with --path
+}
+
+// mockGenerator generates mocks for the port files of one project.
+type mockGenerator struct {
+	cfg         *project.Config
+	projectRoot string
+	tool        string
+	toolPath    string
+	portsDir    string // project-relative
+	mocksDir    string // project-relative
+}
+
+// newMockGenerator loads the project and makes sure the mock tool is installed.
+func newMockGenerator(options MockOptions) (*mockGenerator, error) {
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return nil, fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+
+	tool := options.Tool
+	if tool == "" && cfg.Mocks != nil {
+		tool = cfg.Mocks.Tool
+	}
+	if tool == "" {
+		tool = MockToolMockgen
+	}
+	if _, ok := mockTools[tool]; !ok {
+		return nil, fmt.Errorf("unknown mock tool '%s' (use %s or %s)", tool, MockToolMockgen, MockToolMockery)
+	}
+
+	portSpec, err := resolveComponent(projectRoot, "port")
+	if err != nil {
+		return nil, err
+	}
+
+	toolPath, err := ensureMockTool(tool)
+	if err != nil {
+		return nil, err
+	}
+
+	return &mockGenerator{
+		cfg:         cfg,
+		projectRoot: projectRoot,
+		tool:        tool,
+		toolPath:    toolPath,
+		portsDir:    componentDir(cfg, portSpec, options.Path),
+		mocksDir:    componentDir(cfg, mockSpec, ""),
+	}, nil
+}
+
+// GenerateMock generates mocks for the interfaces of one port, given by
+// name (e.g. "user" or "admin/user") or by the path of its Go file.
+func GenerateMock(port string, options MockOptions) error {
+	g, err := newMockGenerator(options)
+	if err != nil {
+		return err
+	}
+
+	source, err := g.resolvePort(port)
+	if err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationStart("mock", port)
+	files, err := g.generate(source)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		logger.Warn("⚠️  %s declares no interfaces; nothing to mock", source)
+		return nil
+	}
+	if err := recordDependencies(g.cfg, g.projectRoot, []string{mockTools[g.tool].runtime}); err != nil {
+		return err
+	}
+
+	for _, file := range files {
+		logger.Success("✅ Generated %s", file)
+	}
+	return nil
+}
+
+// RegenerateMocks regenerates the mocks of every port and deletes mock
+// files whose port no longer exists. It returns the number of mock files.
+func RegenerateMocks(options MockOptions) (int, error) {
+	g, err := newMockGenerator(options)
+	if err != nil {
+		return 0, err
+	}
+
+	sources, err := g.portFiles()
+	if err != nil {
+		return 0, err
+	}
+
+	produced := make(map[string]bool)
+	for _, source := range sources {
+		files, err := g.generate(source)
+		if err != nil {
+			return 0, err
+		}
+		for _, file := range files {
+			produced[file] = true
+			logger.Info("🧪 %s", file)
+		}
+	}
+
+	if err := g.removeStale(produced); err != nil {
+		return 0, err
+	}
+	if len(produced) > 0 {
+		if err := recordDependencies(g.cfg, g.projectRoot, []string{mockTools[g.tool].runtime}); err != nil {
+			return 0, err
+		}
+	}
+	return len(produced), nil
+}
+
+// resolvePort finds the Go file of a port from its name or path.
+func (g *mockGenerator) resolvePort(port string) (string, error) {
+	if strings.HasSuffix(port, ".go") {
+		rel := filepath.ToSlash(filepath.Clean(port))
+		if filepath.IsAbs(port) {
+			rel = filepath.ToSlash(relativeTo(g.projectRoot, port))
+		}
+		if _, err := os.Stat(filepath.Join(g.projectRoot, filepath.FromSlash(rel))); err != nil {
+			return "", fmt.Errorf("port file not found: %s", port)
+		}
+		return rel, nil
+	}
+
+	spec, err := resolveComponent(g.projectRoot, "port")
+	if err != nil {
+		return "", err
+	}
+	namespaces, name := splitComponentName(port)
+	dir := path.Join(append([]string{g.portsDir}, namespaces...)...)
+	rel := path.Join(dir, fmt.Sprintf(spec.FileName, strcase.ToSnake(name)))
+	if _, err := os.Stat(filepath.Join(g.projectRoot, filepath.FromSlash(rel))); err != nil {
+		return "", fmt.Errorf("port '%s' not found at %s\n\nGenerate it with: goforge g port %s --mock", port, rel, port)
+	}
+	return rel, nil
+}
+
+// portFiles lists the non-test Go files under the ports directory.
+func (g *mockGenerator) portFiles() ([]string, error) {
+	root := filepath.Join(g.projectRoot, filepath.FromSlash(g.portsDir))
+	var files []string
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".go") && !strings.HasSuffix(p, "_test.go") {
+			files = append(files, filepath.ToSlash(relativeTo(g.projectRoot, p)))
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	sort.Strings(files)
+	return files, err
+}
+
+// generate writes the mocks for one port file and returns the mock files,
+// project-relative.
+func (g *mockGenerator) generate(source string) ([]string, error) {
+	interfaces, err := interfaceNames(filepath.Join(g.projectRoot, filepath.FromSlash(source)))
+	if err != nil {
+		return nil, err
+	}
+	if len(interfaces) == 0 {
+		return nil, nil
+	}
+
+	// internal/ports/admin/user_port.go -> internal/mocks/admin/user_port_mock.go
+	rel := strings.TrimPrefix(strings.TrimPrefix(source, g.portsDir), "/")
+	outDir := path.Join(g.mocksDir, path.Dir(rel))
+	pkg := packageNameFor(outDir)
+	base := strings.TrimSuffix(path.Base(rel), ".go")
+
+	if err := os.MkdirAll(filepath.Join(g.projectRoot, filepath.FromSlash(outDir)), os.ModePerm); err != nil {
+		return nil, err
+	}
+
+	switch g.tool {
+	case MockToolMockery:
+		var files []string
+		for _, name := range interfaces {
+			file := path.Join(outDir, base+"_"+strcase.ToSnake(name)+mockFileSuffix)
+			err := g.run("--dir", path.Dir(source), "--name", "^"+name+"$",
+				"--output", outDir, "--outpkg", pkg, "--filename", path.Base(file))
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+		}
+		return files, nil
+
+	default:
+		file := path.Join(outDir, base+mockFileSuffix)
+		if err := g.run("-source="+source, "-destination="+file, "-package="+pkg); err != nil {
+			return nil, err
+		}
+		return []string{file}, nil
+	}
+}
+
+// run invokes the mock tool in the project root.
+func (g *mockGenerator) run(args ...string) error {
+	output, err := runner.ExecuteCommandWithOutput(g.projectRoot, g.toolPath, args...)
+	if err != nil {
+		return fmt.Errorf("%s failed: %w", g.tool, err)
+	}
+	logger.Debug("%s", output)
+	return nil
+}
+
+// removeStale deletes generated mock files that were not produced by this run.
+func (g *mockGenerator) removeStale(produced map[string]bool) error {
+	root := filepath.Join(g.projectRoot, filepath.FromSlash(g.mocksDir))
+	err := filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel := filepath.ToSlash(relativeTo(g.projectRoot, p))
+		if d.IsDir() || !strings.HasSuffix(rel, mockFileSuffix) || produced[rel] {
+			return nil
+		}
+		logger.Info("🗑️  Removed stale mock %s", rel)
+		return os.Remove(p)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// interfaceNames returns the interface types declared in a Go file.
+func interfaceNames(file string) ([]string, error) {
+	parsed, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+
+	var names []string
+	for _, decl := range parsed.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, s := range gen.Specs {
+			if typeSpec, ok := s.(*ast.TypeSpec); ok {
+				if _, isInterface := typeSpec.Type.(*ast.InterfaceType); isInterface && typeSpec.TypeParams == nil {
+					names = append(names, typeSpec.Name.Name)
+				}
+			}
+		}
+	}
+	return names, nil
+}
+
+// ensureMockTool returns the path of a mock tool, installing it if missing.
+func ensureMockTool(tool string) (string, error) {
+	return ensureGoTool(tool, mockTools[tool].install)
+}
+
+// ensureGoTool returns the path of a Go-installable tool, installing it
+// with 'go install <install>' when it is not on PATH or in the Go bin
+// directory. Build flags such as -tags go before the package in install.
+func ensureGoTool(name string, install ...string) (string, error) {
+	if toolPath, ok := findGoTool(name); ok {
+		return toolPath, nil
+	}
+
+	logger.Info("📦 %s not found; installing %s...", name, install[len(install)-1])
+	if err := runner.ExecuteCommand("", "go", append([]string{"install"}, install...)...); err != nil {
+		return "", fmt.Errorf("failed to install %s: %w", name, err)
+	}
+	if toolPath, ok := findGoTool(name); ok {
+		return toolPath, nil
+	}
+	return "", fmt.Errorf("installed %s but could not find it; add $(go env GOPATH)/bin to your PATH", name)
+}
+
+// findGoTool looks for a binary on PATH, then in GOBIN or GOPATH/bin.
+func findGoTool(name string) (string, bool) {
+	if toolPath, err := exec.LookPath(name); err == nil {
+		return toolPath, true
+	}
+
+	var dirs []string
+	if gobin, err := runner.ExecuteCommandWithOutput("", "go", "env", "GOBIN"); err == nil && gobin != "" {
+		dirs = append(dirs, gobin)
+	}
+	if gopath, err := runner.ExecuteCommandWithOutput("", "go", "env", "GOPATH"); err == nil && gopath != "" {
+		dirs = append(dirs, filepath.Join(filepath.SplitList(gopath)[0], "bin"))
+	}
+	for _, dir := range dirs {
+		candidate := filepath.Join(dir, name)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, true
+		}
+		if _, err := os.Stat(candidate + ".exe"); err == nil {
+			return candidate + ".exe", true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,233 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// OIDC providers accepted by 'goforge generate oidc'.
+const (
+	ProviderKeycloak = "keycloak"
+	ProviderAuth0    = "auth0"
+	ProviderGoogle   = "google"
+	ProviderGeneric  = "generic"
+)
+
+// oidcAppConfig is the application config file the oidc keys are added to.
+const oidcAppConfig = "config/default.yml"
+
+// oidcSpec places the generated package; override it with layout.oidc in
+// goforge.yml or --path.
+var oidcSpec = ComponentSpec{Type: "oidc", Dir: "internal/platform/auth/oidc"}
+
+// defaultIssuers are placeholder issuer URLs for each provider.
+var defaultIssuers = map[string]string{
+	ProviderKeycloak: "http://localhost:8081/realms/%s",
+	ProviderAuth0:    "https://YOUR_TENANT.auth0.com/",
+	ProviderGoogle:   "https://accounts.google.com",
+}
+
+// OIDCOptions parameterizes the generated OpenID Connect login package.
+type OIDCOptions struct {
+	Provider string
+	Issuer   string // Defaults to a placeholder for the provider
+	ClientID string // Defaults to the project name
+	Prefix   string // Route prefix for login, callback, and logout
+}
+
+// GenerateOIDC writes an OpenID Connect login package: config loading,
+// encrypted cookie sessions, login/callback/logout handlers using the
+// authorization code flow with PKCE, and middleware requiring a session.
+// The oidc keys are added to config/default.yml and the required modules
+// recorded in goforge.yml.
+func GenerateOIDC(options OIDCOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if err := requireGin(cfg, "goforge generate oidc"); err != nil {
+		return err
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, oidcSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	if options.ClientID == "" {
+		options.ClientID = cfg.ProjectName
+	}
+	if options.Issuer == "" {
+		options.Issuer = defaultIssuers[options.Provider]
+		if strings.Contains(options.Issuer, "%s") {
+			options.Issuer = fmt.Sprintf(options.Issuer, cfg.ProjectName)
+		}
+	}
+
+	data := TemplateData{
+		Name:        "oidc",
+		NameTitle:   "OIDC",
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"provider": options.Provider,
+			"prefix":   options.Prefix,
+		},
+	}
+
+	logger.ComponentGenerationStart("oidc", dir)
+
+	var written []string
+	for _, file := range []string{"config", "session", "handler", "middleware"} {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/oidc", file+".go.tpl"),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(dir), file+".go"),
+			Data:         data,
+		}
+
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	configBlock := options.configBlock()
+	configState, err := addAppConfig(projectRoot, "oidc", configBlock)
+	if err != nil {
+		return err
+	}
+
+	modules := []string{"github.com/coreos/go-oidc/v3", "golang.org/x/oauth2", "github.com/gin-gonic/gin", "github.com/spf13/viper"}
+	if err := recordDependencies(cfg, projectRoot, modules); err != nil {
+		return err
+	}
+	s.registerProbe(cfg, projectRoot, ProbeOIDC)
+
+	logger.ComponentGenerationComplete("oidc", options.Provider, filepath.Join(projectRoot, filepath.FromSlash(dir)))
+
+	logger.Info("")
+	switch configState {
+	case configAdded:
+		logger.Info("⚙️  Added the oidc section to %s", oidcAppConfig)
+	case configPresent:
+		logger.Info("⚙️  %s already has an oidc section; left unchanged", oidcAppConfig)
+	default:
+		logger.Info("⚙️  Add this to your application config:\n\n%s", configBlock)
+	}
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Register %s as a redirect URI with your provider", "http://localhost:8080"+options.Prefix+"/callback")
+	logger.Info("   2. export OIDC_CLIENT_SECRET=... OIDC_SESSION_SECRET=$(openssl rand -base64 32)")
+	logger.Info("   3. Wire it up in main:")
+	logger.Info("        authCfg, err := %s.LoadConfig()", data.PackageName)
+	logger.Info("        auth, err := %s.New(ctx, authCfg)", data.PackageName)
+	logger.Info("        auth.RegisterRoutes(router)")
+	logger.Info("        router.Group(\"/app\", auth.RequireAuth())")
+
+	return nil
+}
+
+// validate checks the options and fills in defaults.
+func (o *OIDCOptions) validate() error {
+	if o.Provider == "" {
+		o.Provider = ProviderKeycloak
+	}
+	switch o.Provider {
+	case ProviderKeycloak, ProviderAuth0, ProviderGoogle:
+	case ProviderGeneric:
+		if o.Issuer == "" {
+			return fmt.Errorf("the generic provider needs --issuer")
+		}
+	default:
+		return fmt.Errorf("unknown provider '%s' (use %s, %s, %s or %s)", o.Provider, ProviderKeycloak, ProviderAuth0, ProviderGoogle, ProviderGeneric)
+	}
+
+	if o.Prefix == "" {
+		o.Prefix = "/auth"
+	}
+	o.Prefix = "/" + strings.Trim(o.Prefix, "/")
+	return nil
+}
+
+// configBlock renders the oidc section for config/default.yml.
+func (o OIDCOptions) configBlock() string {
+	return fmt.Sprintf(`# OpenID Connect login (goforge generate oidc).
+# Secrets come from OIDC_CLIENT_SECRET and OIDC_SESSION_SECRET.
+oidc:
+  provider: %q
+  issuer_url: %q
+  client_id: %q
+  client_secret: ""
+  redirect_url: "http://localhost:8080%s/callback"
+  post_logout_redirect_url: "http://localhost:8080/"
+  scopes: ["openid", "profile", "email"]
+  session:
+    secret: ""
+    cookie_name: "session"
+    ttl: "8h"
+    secure: false # true in production (HTTPS only)
+`, o.Provider, o.Issuer, o.ClientID, o.Prefix)
+}
+
+// Results of addAppConfig.
+const (
+	configAdded   = "added"
+	configPresent = "present"
+	configMissing = "missing"
+)
+
+// addAppConfig appends block to config/default.yml unless the file is
+// missing or already has the top-level key.
+func addAppConfig(projectRoot, key, block string) (string, error) {
+	configPath := filepath.Join(projectRoot, filepath.FromSlash(oidcAppConfig))
+	data, err := os.ReadFile(configPath)
+	if os.IsNotExist(err) {
+		return configMissing, nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var existing map[string]any
+	if err := yaml.Unmarshal(data, &existing); err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", oidcAppConfig, err)
+	}
+	if _, ok := existing[key]; ok {
+		return configPresent, nil
+	}
+
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, '\n')
+	data = append(data, block...)
+	if err := os.WriteFile(configPath, data, 0644); err != nil {
+		return "", err
+	}
+	return configAdded, nil
+}
@@ -0,0 +1,554 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIDoc is the part of an OpenAPI 3 document goforge generates code
+// from. JSON documents load too, JSON being a subset of YAML.
+type openAPIDoc struct {
+	OpenAPI string `yaml:"openapi"`
+	Swagger string `yaml:"swagger"`
+	Info    struct {
+		Title   string `yaml:"title"`
+		Version string `yaml:"version"`
+	} `yaml:"info"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      orderedMap[*openAPIPathItem] `yaml:"paths"`
+	Components openAPIComponents            `yaml:"components"`
+}
+
+type openAPIComponents struct {
+	Schemas       orderedMap[*openAPISchema]     `yaml:"schemas"`
+	Parameters    map[string]*openAPIParameter   `yaml:"parameters"`
+	RequestBodies map[string]*openAPIRequestBody `yaml:"requestBodies"`
+	Responses     map[string]*openAPIResponse    `yaml:"responses"`
+}
+
+type openAPIPathItem struct {
+	Parameters []*openAPIParameter `yaml:"parameters"`
+	Get        *openAPIOperation   `yaml:"get"`
+	Put        *openAPIOperation   `yaml:"put"`
+	Post       *openAPIOperation   `yaml:"post"`
+	Delete     *openAPIOperation   `yaml:"delete"`
+	Patch      *openAPIOperation   `yaml:"patch"`
+	Head       *openAPIOperation   `yaml:"head"`
+	Options    *openAPIOperation   `yaml:"options"`
+}
+
+// methodOperation is an operation with its HTTP method.
+type methodOperation struct {
+	Method    string
+	Operation *openAPIOperation
+}
+
+// operations returns the path's operations in the order they are
+// generated.
+func (p *openAPIPathItem) operations() []methodOperation {
+	var ops []methodOperation
+	for _, op := range []methodOperation{
+		{"GET", p.Get}, {"POST", p.Post}, {"PUT", p.Put}, {"PATCH", p.Patch},
+		{"DELETE", p.Delete}, {"HEAD", p.Head}, {"OPTIONS", p.Options},
+	} {
+		if op.Operation != nil {
+			ops = append(ops, op)
+		}
+	}
+	return ops
+}
+
+type openAPIOperation struct {
+	OperationID string                       `yaml:"operationId"`
+	Summary     string                       `yaml:"summary"`
+	Description string                       `yaml:"description"`
+	Tags        []string                     `yaml:"tags"`
+	Parameters  []*openAPIParameter          `yaml:"parameters"`
+	RequestBody *openAPIRequestBody          `yaml:"requestBody"`
+	Responses   orderedMap[*openAPIResponse] `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref         string         `yaml:"$ref"`
+	Name        string         `yaml:"name"`
+	In          string         `yaml:"in"`
+	Description string         `yaml:"description"`
+	Required    bool           `yaml:"required"`
+	Schema      *openAPISchema `yaml:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Ref         string                       `yaml:"$ref"`
+	Description string                       `yaml:"description"`
+	Required    bool                         `yaml:"required"`
+	Content     map[string]*openAPIMediaType `yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Ref         string                       `yaml:"$ref"`
+	Description string                       `yaml:"description"`
+	Content     map[string]*openAPIMediaType `yaml:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *openAPISchema `yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Ref                  string                     `yaml:"$ref"`
+	Type                 schemaType                 `yaml:"type"`
+	Format               string                     `yaml:"format"`
+	Description          string                     `yaml:"description"`
+	Properties           orderedMap[*openAPISchema] `yaml:"properties"`
+	Required             []string                   `yaml:"required"`
+	Items                *openAPISchema             `yaml:"items"`
+	AdditionalProperties *additionalProperties      `yaml:"additionalProperties"`
+	Enum                 []any                      `yaml:"enum"`
+	Nullable             bool                       `yaml:"nullable"`
+	AllOf                []*openAPISchema           `yaml:"allOf"`
+	OneOf                []*openAPISchema           `yaml:"oneOf"`
+	AnyOf                []*openAPISchema           `yaml:"anyOf"`
+}
+
+// schemaType is a schema's type, given as a string or, in OpenAPI 3.1, as
+// a list that may include "null".
+type schemaType struct {
+	Name     string
+	Nullable bool
+}
+
+func (t *schemaType) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.SequenceNode {
+		return node.Decode(&t.Name)
+	}
+	var types []string
+	if err := node.Decode(&types); err != nil {
+		return err
+	}
+	for _, name := range types {
+		if name == "null" {
+			t.Nullable = true
+		} else {
+			t.Name = name
+		}
+	}
+	return nil
+}
+
+// additionalProperties is either a boolean or the schema of map values.
+type additionalProperties struct {
+	Allowed bool
+	Schema  *openAPISchema
+}
+
+func (a *additionalProperties) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		return node.Decode(&a.Allowed)
+	}
+	a.Allowed = true
+	return node.Decode(&a.Schema)
+}
+
+// orderedMap is a YAML mapping that remembers the order of its keys, so
+// generated fields and routes follow the document. Extension keys (x-...)
+// are skipped.
+type orderedMap[T any] struct {
+	Keys   []string
+	Values map[string]T
+}
+
+func (m *orderedMap[T]) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("line %d: expected a mapping", node.Line)
+	}
+	m.Values = make(map[string]T, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		if strings.HasPrefix(key, "x-") {
+			continue
+		}
+		var value T
+		if err := node.Content[i+1].Decode(&value); err != nil {
+			return err
+		}
+		if _, ok := m.Values[key]; !ok {
+			m.Keys = append(m.Keys, key)
+		}
+		m.Values[key] = value
+	}
+	return nil
+}
+
+// loadOpenAPI reads an OpenAPI 3 document.
+func loadOpenAPI(file string) (*openAPIDoc, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	var doc openAPIDoc
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	if doc.Swagger != "" {
+		return nil, fmt.Errorf("%s is a Swagger %s document; convert it to OpenAPI 3 first (e.g. with swagger2openapi)", file, doc.Swagger)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, fmt.Errorf("%s is not an OpenAPI 3 document (missing 'openapi: 3.x')", file)
+	}
+	return &doc, nil
+}
+
+// refName returns the component a local reference such as
+// #/components/schemas/User points to.
+func refName(ref, section string) (string, error) {
+	prefix := "#/components/" + section + "/"
+	if !strings.HasPrefix(ref, prefix) {
+		return "", fmt.Errorf("unsupported reference '%s' (only %s... references are supported)", ref, prefix)
+	}
+	name := strings.TrimPrefix(ref, prefix)
+	return strings.NewReplacer("~1", "/", "~0", "~").Replace(name), nil
+}
+
+// parameter resolves a parameter reference.
+func (d *openAPIDoc) parameter(p *openAPIParameter) (*openAPIParameter, error) {
+	if p.Ref == "" {
+		return p, nil
+	}
+	name, err := refName(p.Ref, "parameters")
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := d.Components.Parameters[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("parameter '%s' is not defined in components", name)
+}
+
+// requestBody resolves a request body reference.
+func (d *openAPIDoc) requestBody(b *openAPIRequestBody) (*openAPIRequestBody, error) {
+	if b.Ref == "" {
+		return b, nil
+	}
+	name, err := refName(b.Ref, "requestBodies")
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := d.Components.RequestBodies[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("request body '%s' is not defined in components", name)
+}
+
+// response resolves a response reference.
+func (d *openAPIDoc) response(r *openAPIResponse) (*openAPIResponse, error) {
+	if r.Ref == "" {
+		return r, nil
+	}
+	name, err := refName(r.Ref, "responses")
+	if err != nil {
+		return nil, err
+	}
+	if resolved, ok := d.Components.Responses[name]; ok {
+		return resolved, nil
+	}
+	return nil, fmt.Errorf("response '%s' is not defined in components", name)
+}
+
+// jsonSchema returns the schema of the JSON content, or nil when there is
+// none.
+func jsonSchema(content map[string]*openAPIMediaType) *openAPISchema {
+	var types []string
+	for contentType := range content {
+		types = append(types, contentType)
+	}
+	sort.Strings(types)
+	for _, contentType := range types {
+		media := strings.TrimSpace(strings.Split(contentType, ";")[0])
+		if media == "application/json" || strings.HasSuffix(media, "+json") {
+			if schema := content[contentType].Schema; schema != nil {
+				return schema
+			}
+			return &openAPISchema{}
+		}
+	}
+	return nil
+}
+
+// goTypeDecl is a Go type declared for a schema.
+type goTypeDecl struct {
+	Name   string
+	Doc    string // full comment, one "// " line per line
+	Type   string // underlying type, when not a struct
+	Embeds []string
+	Fields []goTypeField
+	Consts []goTypeConst
+}
+
+type goTypeField struct {
+	Name string
+	Type string
+	Tag  string
+	Doc  string
+}
+
+type goTypeConst struct {
+	Name  string
+	Value string // quoted
+}
+
+// typeBuilder declares Go types for OpenAPI schemas: one per component
+// schema, and one per inline object, named after where it appears.
+type typeBuilder struct {
+	doc     *openAPIDoc
+	decls   []*goTypeDecl
+	taken   map[string]bool
+	binding bool // add gin binding rules to fields
+}
+
+func newTypeBuilder(doc *openAPIDoc) *typeBuilder {
+	b := &typeBuilder{doc: doc, taken: make(map[string]bool), binding: true}
+	for _, name := range doc.Components.Schemas.Keys {
+		b.taken[strcase.ToCamel(name)] = true
+	}
+	return b
+}
+
+// declareComponents declares a type for each component schema.
+func (b *typeBuilder) declareComponents() error {
+	for _, name := range b.doc.Components.Schemas.Keys {
+		schema := b.doc.Components.Schemas.Values[name]
+		if schema == nil {
+			schema = &openAPISchema{}
+		}
+		source := fmt.Sprintf("is the %s schema.", name)
+		if err := b.declare(strcase.ToCamel(name), source, schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// declare adds a named type for schema, documented with source and the
+// schema's description.
+func (b *typeBuilder) declare(name, source string, schema *openAPISchema) error {
+	b.taken[name] = true
+	decl := &goTypeDecl{Name: name, Doc: commentLines(name + " " + source + "\n" + schema.Description)}
+	b.decls = append(b.decls, decl)
+
+	if isObjectSchema(schema) {
+		return b.fillStruct(decl, schema)
+	}
+	goType, err := b.goType(schema, name+"Item")
+	if err != nil {
+		return err
+	}
+	decl.Type = goType
+	if goType == "string" {
+		for _, value := range schema.Enum {
+			if text, ok := value.(string); ok && text != "" {
+				decl.Consts = append(decl.Consts, goTypeConst{Name: name + strcase.ToCamel(text), Value: strconv.Quote(text)})
+			}
+		}
+	}
+	return nil
+}
+
+// fillStruct adds the fields of an object schema to decl; allOf parts
+// referring to other schemas are embedded.
+func (b *typeBuilder) fillStruct(decl *goTypeDecl, schema *openAPISchema) error {
+	for _, part := range schema.AllOf {
+		if part.Ref != "" {
+			name, err := refName(part.Ref, "schemas")
+			if err != nil {
+				return err
+			}
+			decl.Embeds = append(decl.Embeds, strcase.ToCamel(name))
+			continue
+		}
+		if err := b.fillStruct(decl, part); err != nil {
+			return err
+		}
+	}
+
+	required := make(map[string]bool, len(schema.Required))
+	for _, name := range schema.Required {
+		required[name] = true
+	}
+	for _, name := range schema.Properties.Keys {
+		property := schema.Properties.Values[name]
+		if property == nil {
+			property = &openAPISchema{}
+		}
+		fieldName := strcase.ToCamel(name)
+		goType, err := b.goType(property, decl.Name+fieldName)
+		if err != nil {
+			return fmt.Errorf("property %s: %w", name, err)
+		}
+		key := name
+		if !required[name] {
+			key += ",omitempty"
+		}
+		decl.Fields = append(decl.Fields, b.field(fieldName, goType, property, required[name], "json", key))
+	}
+	return nil
+}
+
+// field returns a struct field for a property or parameter, tagged with
+// key under tagName. Optional and nullable scalars become pointers, and
+// gin binding rules check required values and enums.
+func (b *typeBuilder) field(name, goType string, schema *openAPISchema, required bool, tagName, key string) goTypeField {
+	nullable := schema.Nullable || schema.Type.Nullable
+	if (!required || nullable) && !strings.HasPrefix(goType, "[]") && !strings.HasPrefix(goType, "map[") && goType != "any" {
+		goType = "*" + goType
+	}
+
+	var rules []string
+	// Zero numbers and false are valid values, so only check that strings,
+	// slices and maps are present
+	if required && !nullable && (goType == "string" || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[")) {
+		rules = append(rules, "required")
+	}
+	var values []string
+	for _, value := range schema.Enum {
+		text, ok := value.(string)
+		if !ok || text == "" || strings.ContainsAny(text, " ,|`\"") {
+			values = nil
+			break
+		}
+		values = append(values, text)
+	}
+	if len(values) > 0 && strings.TrimPrefix(goType, "*") == "string" {
+		if len(rules) == 0 {
+			rules = append(rules, "omitempty")
+		}
+		rules = append(rules, "oneof="+strings.Join(values, " "))
+	}
+
+	tag := fmt.Sprintf(`%s:"%s"`, tagName, key)
+	if len(rules) > 0 && b.binding {
+		tag += fmt.Sprintf(` binding:"%s"`, strings.Join(rules, ","))
+	}
+	return goTypeField{Name: name, Type: goType, Tag: tag, Doc: commentLines(schema.Description)}
+}
+
+// goType returns the Go type for schema, declaring a type named after hint
+// for inline objects.
+func (b *typeBuilder) goType(schema *openAPISchema, hint string) (string, error) {
+	if schema == nil {
+		return "any", nil
+	}
+	if schema.Ref != "" {
+		name, err := refName(schema.Ref, "schemas")
+		if err != nil {
+			return "", err
+		}
+		if _, ok := b.doc.Components.Schemas.Values[name]; !ok {
+			return "", fmt.Errorf("schema '%s' is not defined in components", name)
+		}
+		return strcase.ToCamel(name), nil
+	}
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return "any", nil
+	}
+	if isObjectSchema(schema) {
+		name := b.unique(hint)
+		return name, b.declare(name, "is generated from an inline schema.", schema)
+	}
+
+	switch schema.Type.Name {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return "time.Time", nil
+		case "byte", "binary":
+			return "[]byte", nil
+		}
+		return "string", nil
+	case "integer":
+		switch schema.Format {
+		case "int32":
+			return "int32", nil
+		case "int64":
+			return "int64", nil
+		}
+		return "int", nil
+	case "number":
+		if schema.Format == "float" {
+			return "float32", nil
+		}
+		return "float64", nil
+	case "boolean":
+		return "bool", nil
+	case "array":
+		item, err := b.goType(schema.Items, hint+"Item")
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	case "object":
+		if additional := schema.AdditionalProperties; additional != nil && additional.Schema != nil {
+			value, err := b.goType(additional.Schema, hint+"Value")
+			if err != nil {
+				return "", err
+			}
+			return "map[string]" + value, nil
+		}
+		return "map[string]any", nil
+	case "":
+		return "any", nil
+	}
+	return "", fmt.Errorf("unsupported type '%s'", schema.Type.Name)
+}
+
+// unique returns name, numbered when a type of that name exists.
+func (b *typeBuilder) unique(name string) string {
+	candidate := name
+	for i := 2; b.taken[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	b.taken[candidate] = true
+	return candidate
+}
+
+// usesTime reports whether any declared type refers to time.Time.
+func (b *typeBuilder) usesTime() bool {
+	for _, decl := range b.decls {
+		if strings.Contains(decl.Type, "time.") {
+			return true
+		}
+		for _, field := range decl.Fields {
+			if strings.Contains(field.Type, "time.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isObjectSchema reports whether schema describes a struct: an object
+// with properties, or a composition with allOf.
+func isObjectSchema(schema *openAPISchema) bool {
+	if schema.Type.Name != "" && schema.Type.Name != "object" {
+		return false
+	}
+	return len(schema.Properties.Keys) > 0 || len(schema.AllOf) > 0
+}
+
+// commentLines turns text into Go comment lines, dropping blank lines.
+func commentLines(text string) string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, "// "+line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,97 @@
+package scaffold
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// ORMs lists the data access styles of 'goforge new --orm', the default
+// first.
+var ORMs = []string{"pgx", "gorm", "sqlc", "ent"}
+
+// defaultORM is used by projects without an orm in goforge.yml.
+const defaultORM = "pgx"
+
+// entSchemaDir holds the ent schemas the client is generated from.
+const entSchemaDir = "ent/schema"
+
+// entRepositoryTemplate is the repository skeleton of ent projects.
+const entRepositoryTemplate = "templates/components/orm/ent/repository.go.tpl"
+
+// projectORM returns the project's data access style.
+func projectORM(cfg *project.Config) string {
+	if cfg.ORM == "" {
+		return defaultORM
+	}
+	return cfg.ORM
+}
+
+// DefaultRepositoryLayer returns the query layer 'goforge generate
+// repository' uses without --with: sqlc in projects created with
+// --orm sqlc, none otherwise.
+func DefaultRepositoryLayer() string {
+	cfg, _, err := project.LoadConfig()
+	if err != nil || projectORM(cfg) != RepositorySQLC {
+		return ""
+	}
+	return RepositorySQLC
+}
+
+// ormComponent swaps an embedded component's template for the one written
+// for the project's data access style, when there is one:
+// templates/components/orm/<orm>/<type>.go.tpl.
+func ormComponent(cfg *project.Config, spec ComponentSpec) ComponentSpec {
+	orm := projectORM(cfg)
+	if spec.Source != nil || orm == defaultORM {
+		return spec
+	}
+	template := path.Join("templates/components/orm", orm, spec.Type+".go.tpl")
+	if _, err := fs.Stat(templatesFS, template); err == nil {
+		spec.Template = template
+	}
+	return spec
+}
+
+// addEntSchema writes the schema of a repository generated from the ent
+// skeleton and regenerates the client it uses.
+func (s *Scaffolder) addEntSchema(projectRoot string, task FileGenerationTask) {
+	if task.TemplatePath != entRepositoryTemplate {
+		return
+	}
+	schema := task
+	schema.TemplatePath = "templates/components/orm/ent/schema.go.tpl"
+	schema.TargetPath = filepath.Join(projectRoot, filepath.FromSlash(entSchemaDir), filepath.Base(task.TargetPath))
+	schema.Source = nil
+
+	content, err := s.renderTemplate(schema)
+	if err != nil {
+		logger.Warn("⚠️  Could not render the ent schema: %v", err)
+		return
+	}
+	if _, err := s.writeGenerated(schema, content, projectRoot, ConflictSkip); err != nil {
+		logger.Warn("⚠️  Could not write the ent schema: %v", err)
+		return
+	}
+	if err := GenerateEntCode(projectRoot); err != nil {
+		logger.Warn("⚠️  Could not generate the ent client: %v", err)
+		logger.Info("💡 Generate it later with: goforge run ent:generate")
+	}
+}
+
+// HasEntSchema reports whether the project generates an ent client.
+func HasEntSchema(projectRoot string) bool {
+	_, err := os.Stat(filepath.Join(projectRoot, "ent", "generate.go"))
+	return err == nil
+}
+
+// GenerateEntCode generates the ent client from the project's schemas.
+func GenerateEntCode(projectRoot string) error {
+	logger.Info("⚙️  Running go generate ./ent...")
+	return runner.ExecuteCommand(projectRoot, "go", "generate", "./ent")
+}
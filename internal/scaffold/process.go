@@ -0,0 +1,153 @@
+package scaffold
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// processTemplateDir holds the templates of 'goforge generate systemd' and
+// 'procfile'.
+const processTemplateDir = "templates/components/process"
+
+// DefaultServiceEnv names the environment a systemd unit is generated for
+// unless told otherwise.
+const DefaultServiceEnv = "production"
+
+// SystemdOptions are the settings of the unit of 'goforge generate
+// systemd'.
+type SystemdOptions struct {
+	Env  string // environment, naming the environment file; DefaultServiceEnv when empty
+	User string // account the service runs as; the service name when empty
+}
+
+// processVars collects the variables the systemd and Procfile templates
+// share: the binary 'goforge build' writes and where 'goforge deploy'
+// with the ssh provider installs it.
+func processVars(cfg *project.Config, projectRoot string) map[string]any {
+	projectName := cmp.Or(cfg.ProjectName, filepath.Base(projectRoot))
+	binary, outputDir := projectName, "dist"
+	if build := cfg.Build; build != nil {
+		binary = cmp.Or(build.BinaryName, binary)
+		if build.OutputDir != "" {
+			outputDir = path.Clean(filepath.ToSlash(build.OutputDir))
+		}
+	}
+
+	dir, service := path.Join("/opt", projectName), projectName
+	if cfg.Deploy != nil && cfg.Deploy.SSH != nil {
+		dir = cmp.Or(cfg.Deploy.SSH.Dir, dir)
+		service = cmp.Or(cfg.Deploy.SSH.Service, service)
+	}
+
+	environment := map[string]string{}
+	if cfg.Docker != nil && cfg.Docker.Env != nil {
+		environment = cfg.Docker.Env
+	}
+	return map[string]any{
+		"binary":      binary,
+		"binaryPath":  path.Join(outputDir, binary),
+		"dir":         dir,
+		"service":     service,
+		"environment": environment,
+	}
+}
+
+// renderProcessFile renders a template of processTemplateDir into target,
+// relative to the project root.
+func (s *Scaffolder) renderProcessFile(cfg *project.Config, projectRoot, template, target string, vars map[string]any, onConflict string) error {
+	task := FileGenerationTask{
+		TemplatePath: path.Join(processTemplateDir, template),
+		TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+		Data: TemplateData{
+			ProjectName: cfg.ProjectName,
+			ModuleName:  cfg.ModuleName,
+			GoVersion:   cfg.GoVersion,
+			Vars:        vars,
+		},
+	}
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return err
+	}
+	_, err = s.writeGenerated(task, content, projectRoot, onConflict)
+	return err
+}
+
+// GenerateSystemd writes deploy/systemd/<service>.service, a unit running
+// the binary of the live release under the ssh provider's layout, with
+// the settings of the environment read from /etc/<service>/<env>.env and
+// the variables of docker.env.
+func GenerateSystemd(options SystemdOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+	env := cmp.Or(options.Env, DefaultServiceEnv)
+	if !variableNamePattern.MatchString(env) {
+		return exitcode.Errorf(exitcode.Validation, "invalid environment name '%s': use letters, digits, and underscores", env)
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	vars := processVars(cfg, projectRoot)
+	service := vars["service"].(string)
+	vars["env"] = env
+	vars["envFile"] = path.Join("/etc", service, env+".env")
+	vars["user"] = cmp.Or(options.User, service)
+	target := path.Join("deploy", "systemd", service+".service")
+
+	logger.ComponentGenerationStart("systemd", service)
+	if err := s.renderProcessFile(cfg, projectRoot, "service.tpl", target, vars, genOptions.OnConflict); err != nil {
+		return err
+	}
+	logger.ComponentGenerationComplete("systemd", target, projectRoot)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. On the server, create the user: sudo useradd --system --no-create-home %s", vars["user"])
+	logger.Info("   2. Put the settings of %s in %s", env, vars["envFile"])
+	logger.Info("   3. Install the unit as /etc/systemd/system/%s.service and run: sudo systemctl enable --now %s", service, service)
+	logger.Info("   4. Ship releases with 'goforge deploy', with deploy.provider: ssh in goforge.yml")
+	return nil
+}
+
+// GenerateProcfile writes a Procfile whose web process runs the binary
+// 'goforge build' writes, for foreman, honcho, Dokku, and Heroku-style
+// hosts.
+func GenerateProcfile(genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	vars := processVars(cfg, projectRoot)
+	logger.ComponentGenerationStart("procfile", cfg.ProjectName)
+	if err := s.renderProcessFile(cfg, projectRoot, "Procfile.tpl", "Procfile", vars, genOptions.OnConflict); err != nil {
+		return err
+	}
+	logger.ComponentGenerationComplete("procfile", "Procfile", projectRoot)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Build the binary: goforge build")
+	logger.Info("   2. Start the processes, reading .env: foreman start (or honcho start)")
+	return nil
+}
@@ -0,0 +1,108 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProfileReportFile is where 'goforge new --profile-create' writes its
+// report, relative to the new project.
+const ProfileReportFile = ".goforge/create-profile.json"
+
+// Profile records how long each phase of project creation takes. A nil
+// *Profile is valid and records nothing.
+type Profile struct {
+	mu     sync.Mutex
+	start  time.Time
+	Phases []PhaseTiming `json:"phases"`
+	Files  int           `json:"files"`
+	Total  time.Duration `json:"total_ns"`
+}
+
+// PhaseTiming is the duration of a single phase.
+type PhaseTiming struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration_ns"`
+	Err      string        `json:"error,omitempty"`
+}
+
+// NewProfile starts a profile.
+func NewProfile() *Profile {
+	return &Profile{start: time.Now()}
+}
+
+// Track runs fn and records its duration under name.
+func (p *Profile) Track(name string, fn func() error) error {
+	if p == nil {
+		return fn()
+	}
+
+	start := time.Now()
+	err := fn()
+
+	timing := PhaseTiming{Name: name, Duration: time.Since(start)}
+	if err != nil {
+		timing.Err = err.Error()
+	}
+
+	p.mu.Lock()
+	p.Phases = append(p.Phases, timing)
+	p.mu.Unlock()
+	return err
+}
+
+// setFiles records how many files the template produced.
+func (p *Profile) setFiles(n int) {
+	if p != nil {
+		p.Files = n
+	}
+}
+
+// Finish stops the clock.
+func (p *Profile) Finish() {
+	if p != nil {
+		p.Total = time.Since(p.start)
+	}
+}
+
+// Report formats the phases as an aligned table with their share of the total.
+func (p *Profile) Report() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-12s %10s %6s\n", "PHASE", "TIME", "SHARE")
+	for _, phase := range p.Phases {
+		share := 0.0
+		if p.Total > 0 {
+			share = float64(phase.Duration) / float64(p.Total) * 100
+		}
+		fmt.Fprintf(&b, "%-12s %10s %5.1f%%\n", phase.Name, roundDuration(phase.Duration), share)
+	}
+	fmt.Fprintf(&b, "%-12s %10s  (%d files)\n", "total", roundDuration(p.Total), p.Files)
+	return b.String()
+}
+
+// roundDuration keeps short durations readable without drowning long ones in digits.
+func roundDuration(d time.Duration) time.Duration {
+	if d < 10*time.Millisecond {
+		return d.Round(time.Microsecond)
+	}
+	return d.Round(time.Millisecond)
+}
+
+// WriteReport saves the profile as JSON inside the project.
+func (p *Profile) WriteReport(projectRoot string) (string, error) {
+	path := filepath.Join(projectRoot, filepath.FromSlash(ProfileReportFile))
+	if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return path, os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,130 @@
+package scaffold
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// protoSpec places .proto files; override it with layout.proto in goforge.yml.
+var protoSpec = ComponentSpec{Type: "proto", Dir: "api/proto"}
+
+// protoGenDir receives the Go code generated from the .proto files.
+const protoGenDir = "gen/proto"
+
+// protoPlugins are the protoc plugins generating Go messages and gRPC
+// stubs, with their install paths.
+var protoPlugins = []struct {
+	name    string
+	install string
+}{
+	{"protoc-gen-go", "google.golang.org/protobuf/cmd/protoc-gen-go@latest"},
+	{"protoc-gen-go-grpc", "google.golang.org/grpc/cmd/protoc-gen-go-grpc@latest"},
+}
+
+// bufInstall is the install path of buf, the default code generator.
+const bufInstall = "github.com/bufbuild/buf/cmd/buf@latest"
+
+// ProtoOptions configures 'goforge proto generate'.
+type ProtoOptions struct {
+	// Protoc generates with protoc instead of buf. protoc is not
+	// Go-installable, so it must already be on PATH.
+	Protoc bool
+}
+
+// GenerateProtoCode generates Go code for every .proto file in the
+// project and returns the number of files compiled.
+func GenerateProtoCode(options ProtoOptions) (int, error) {
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return 0, fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	return generateProto(cfg, projectRoot, options)
+}
+
+// generateProto runs buf (installing it and the plugins if needed) or
+// protoc over the project's .proto files.
+func generateProto(cfg *project.Config, projectRoot string, options ProtoOptions) (int, error) {
+	protoDir := componentDir(cfg, protoSpec, "")
+	files, err := protoFiles(projectRoot, protoDir)
+	if err != nil {
+		return 0, err
+	}
+	if len(files) == 0 {
+		return 0, fmt.Errorf("no .proto files in %s\n\nCreate a service with: goforge g grpc <name>", protoDir)
+	}
+
+	// The plugins are found through PATH, so add the Go bin directory
+	var pluginDirs []string
+	for _, plugin := range protoPlugins {
+		pluginPath, err := ensureGoTool(plugin.name, plugin.install)
+		if err != nil {
+			return 0, err
+		}
+		pluginDirs = append(pluginDirs, filepath.Dir(pluginPath))
+	}
+	opts := runner.DefaultOptions()
+	opts.Dir = projectRoot
+	opts.Env = append(os.Environ(), "PATH="+strings.Join(append(pluginDirs, os.Getenv("PATH")), string(os.PathListSeparator)))
+
+	if options.Protoc {
+		protoc, err := exec.LookPath("protoc")
+		if err != nil {
+			return 0, fmt.Errorf("protoc not found on PATH; install it from https://github.com/protocolbuffers/protobuf/releases or generate with buf (drop --protoc)")
+		}
+		if err := os.MkdirAll(filepath.Join(projectRoot, filepath.FromSlash(protoGenDir)), os.ModePerm); err != nil {
+			return 0, err
+		}
+		args := []string{
+			"-I", protoDir,
+			"--go_out=" + protoGenDir, "--go_opt=paths=source_relative",
+			"--go-grpc_out=" + protoGenDir, "--go-grpc_opt=paths=source_relative",
+		}
+		if err := runner.ExecuteCommandWithOptions(protoc, append(args, files...), opts); err != nil {
+			return 0, err
+		}
+		return len(files), nil
+	}
+
+	if _, err := os.Stat(filepath.Join(projectRoot, "buf.gen.yaml")); err != nil {
+		return 0, fmt.Errorf("buf.gen.yaml not found in the project root\n\nRecreate it with 'goforge g grpc <name>' or use --protoc")
+	}
+	buf, err := ensureGoTool("buf", bufInstall)
+	if err != nil {
+		return 0, err
+	}
+	if err := runner.ExecuteCommandWithOptions(buf, []string{"generate"}, opts); err != nil {
+		return 0, err
+	}
+	return len(files), nil
+}
+
+// protoFiles lists the .proto files under dir, project-relative.
+func protoFiles(projectRoot, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(projectRoot, filepath.FromSlash(dir)), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(p, ".proto") {
+			files = append(files, filepath.ToSlash(relativeTo(projectRoot, p)))
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	sort.Strings(files)
+	if err == nil {
+		logger.Debug("Found %d .proto file(s) in %s", len(files), dir)
+	}
+	return files, err
+}
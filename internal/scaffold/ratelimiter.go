@@ -0,0 +1,209 @@
+package scaffold
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Rate limiter strategies and backends accepted by 'goforge generate ratelimiter'.
+const (
+	StrategyTokenBucket   = "token-bucket"
+	StrategySlidingWindow = "sliding-window"
+
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+)
+
+// rateLimiterSpec places the generated package; override it with
+// layout.ratelimiter in goforge.yml or --path.
+var rateLimiterSpec = ComponentSpec{Type: "ratelimiter", Dir: "internal/platform/ratelimit"}
+
+// RateLimiterOptions parameterizes the generated rate limiting package.
+type RateLimiterOptions struct {
+	Strategy string
+	Backend  string
+	Rate     int // Requests allowed per Window
+	Window   time.Duration
+	Burst    int // Token bucket capacity; 0 means Rate
+}
+
+// GenerateRateLimiter writes the shared rate limiting package: the Limiter
+// port, an in-memory backend, an optional Redis backend, and Gin middleware
+// with per-route rules. Required modules are recorded in goforge.yml.
+func GenerateRateLimiter(options RateLimiterOptions, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if err := requireGin(cfg, "goforge generate ratelimiter"); err != nil {
+		return err
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, rateLimiterSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	burst := options.Burst
+	if burst <= 0 {
+		burst = options.Rate
+	}
+
+	strategyConst := "TokenBucket"
+	if options.Strategy == StrategySlidingWindow {
+		strategyConst = "SlidingWindow"
+	}
+
+	data := TemplateData{
+		Name:        "ratelimit",
+		NameTitle:   "RateLimit",
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"strategy":      options.Strategy,
+			"strategyConst": strategyConst,
+			"backend":       options.Backend,
+			"rate":          strconv.Itoa(options.Rate),
+			"window":        durationExpr(options.Window),
+			"burst":         strconv.Itoa(burst),
+		},
+	}
+
+	files := []string{"limiter", "memory", "middleware"}
+	modules := []string{"github.com/gin-gonic/gin"}
+	if options.Backend == BackendRedis {
+		files = append(files, "redis")
+		modules = append(modules, "github.com/redis/go-redis/v9")
+	}
+
+	logger.ComponentGenerationStart("ratelimiter", dir)
+
+	var written []string
+	for _, file := range files {
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/ratelimiter", file+".go.tpl"),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(dir), file+".go"),
+			Data:         data,
+		}
+
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, genOptions.OnConflict)
+		if err != nil {
+			return err
+		}
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, modules); err != nil {
+		return err
+	}
+	if options.Backend == BackendRedis {
+		s.registerProbe(cfg, projectRoot, ProbeRedis)
+	}
+
+	logger.ComponentGenerationComplete("ratelimiter", options.Strategy+"/"+options.Backend, filepath.Join(projectRoot, filepath.FromSlash(dir)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	if options.Backend == BackendRedis {
+		logger.Info("   1. Create the limiter: %s.NewRedis(redisClient, \"ratelimit:\")", data.PackageName)
+	} else {
+		logger.Info("   1. Create the limiter: %s.NewMemory()", data.PackageName)
+	}
+	logger.Info("   2. Register it: router.Use(%s.Middleware(limiter, %s.DefaultConfig(), nil))", data.PackageName, data.PackageName)
+	logger.Info("   3. Add per-route rules to Config.Routes, e.g. \"POST /api/v1/login\"")
+
+	return nil
+}
+
+// validate checks the options and fills in defaults.
+func (o *RateLimiterOptions) validate() error {
+	if o.Strategy == "" {
+		o.Strategy = StrategyTokenBucket
+	}
+	if o.Backend == "" {
+		o.Backend = BackendMemory
+	}
+
+	if o.Strategy != StrategyTokenBucket && o.Strategy != StrategySlidingWindow {
+		return fmt.Errorf("unknown strategy '%s' (use %s or %s)", o.Strategy, StrategyTokenBucket, StrategySlidingWindow)
+	}
+	if o.Backend != BackendMemory && o.Backend != BackendRedis {
+		return fmt.Errorf("unknown backend '%s' (use %s or %s)", o.Backend, BackendMemory, BackendRedis)
+	}
+	if o.Rate <= 0 {
+		return fmt.Errorf("rate must be greater than zero")
+	}
+	if o.Window <= 0 {
+		return fmt.Errorf("window must be greater than zero")
+	}
+	if o.Burst < 0 {
+		return fmt.Errorf("burst cannot be negative")
+	}
+	return nil
+}
+
+// recordDependencies adds modules missing from goforge.yml and fetches them.
+func recordDependencies(cfg *project.Config, projectRoot string, modules []string) error {
+	for _, module := range modules {
+		if _, ok := cfg.Dependencies[module]; ok {
+			continue
+		}
+
+		if err := project.SetConfigValue(projectRoot, []string{"dependencies", module}, "latest"); err != nil {
+			return fmt.Errorf("failed to update goforge.yml: %w", err)
+		}
+		logger.Debug("Recorded %s in goforge.yml", module)
+
+		if err := runner.InstallDependency(projectRoot, module); err != nil {
+			logger.Warn("Failed to install %s: %v", module, err)
+			logger.Info("💡 Install it later with: goforge add %s", module)
+		}
+	}
+	return nil
+}
+
+// durationExpr renders d as a Go expression, e.g. "time.Minute" or "30 * time.Second".
+func durationExpr(d time.Duration) string {
+	units := []struct {
+		size time.Duration
+		name string
+	}{
+		{time.Hour, "time.Hour"},
+		{time.Minute, "time.Minute"},
+		{time.Second, "time.Second"},
+		{time.Millisecond, "time.Millisecond"},
+	}
+	for _, unit := range units {
+		if d%unit.size == 0 {
+			if d == unit.size {
+				return unit.name
+			}
+			return fmt.Sprintf("%d * %s", d/unit.size, unit.name)
+		}
+	}
+	return fmt.Sprintf("%d * time.Nanosecond", d)
+}
@@ -0,0 +1,619 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/runner"
+	"gopkg.in/yaml.v3"
+)
+
+// Query layers a repository can be generated with.
+const (
+	RepositorySQLC     = "sqlc"
+	RepositorySquirrel = "squirrel"
+)
+
+// querySpec places the sqlc query files; override it with layout.queries
+// in goforge.yml.
+var querySpec = ComponentSpec{Type: "queries", Dir: "db/queries"}
+
+// sqlcConfig is the sqlc configuration file at the project root.
+const sqlcConfig = "sqlc.yaml"
+
+// sqlcPackage is the package sqlc generates into, next to the repositories.
+const sqlcPackage = "sqlcgen"
+
+// sqlcInstall is the install path of sqlc.
+const sqlcInstall = "github.com/sqlc-dev/sqlc/cmd/sqlc@latest"
+
+// sqlcConfigHeader explains the file to whoever opens it.
+const sqlcConfigHeader = `# sqlc configuration (https://docs.sqlc.dev). 'goforge g repository
+# <name> --with sqlc' adds query files to the queries directory; regenerate
+# the Go code with 'goforge run sqlc:generate'.
+`
+
+// createTablePattern finds the table a migration creates.
+var createTablePattern = regexp.MustCompile(`(?i)create\s+table\s+(?:if\s+not\s+exists\s+)?"?(\w+)"?`)
+
+// repoColumn is a model field stored in a column, as seen by the
+// repository templates.
+type repoColumn struct {
+	Field   string // domain field, e.g. CustomerId
+	Column  string // e.g. customer_id
+	SQLType string // column definition in the migration
+	Auto    bool   // set by the database: id, created_at, updated_at
+
+	// sqlc only
+	SQLCField string // sqlc's name for the column, e.g. CustomerID
+	FromRow   string // expression reading it from row
+	ToParam   string // expression passing it from the model
+}
+
+// columnType is how a Go field type is stored.
+type columnType struct {
+	sql     string // PostgreSQL type
+	sqlcGo  string // type sqlc generates for it, when the model's differs
+	pointer bool   // nullable
+}
+
+// columnTypes maps the field types the query layers handle to columns.
+// sqlc only supports these; squirrel scans anything pgx can.
+var columnTypes = map[string]columnType{
+	"string":     {sql: "TEXT"},
+	"bool":       {sql: "BOOLEAN"},
+	"int":        {sql: "BIGINT", sqlcGo: "int64"},
+	"int64":      {sql: "BIGINT"},
+	"int32":      {sql: "INTEGER"},
+	"int16":      {sql: "SMALLINT"},
+	"float64":    {sql: "DOUBLE PRECISION"},
+	"float32":    {sql: "REAL"},
+	"[]byte":     {sql: "BYTEA"},
+	"time.Time":  {sql: "TIMESTAMPTZ"},
+	"uuid.UUID":  {sql: "UUID"},
+	"*string":    {sql: "TEXT", pointer: true},
+	"*bool":      {sql: "BOOLEAN", pointer: true},
+	"*int64":     {sql: "BIGINT", pointer: true},
+	"*int32":     {sql: "INTEGER", pointer: true},
+	"*float64":   {sql: "DOUBLE PRECISION", pointer: true},
+	"*time.Time": {sql: "TIMESTAMPTZ", pointer: true},
+	"*uuid.UUID": {sql: "UUID", pointer: true},
+}
+
+// repoIdentifiers are names the repository templates use, which the
+// model variable must not shadow.
+var repoIdentifiers = map[string]bool{
+	"r": true, "ctx": true, "id": true, "limit": true, "offset": true, "err": true,
+	"query": true, "args": true, "rows": true, "row": true, "tag": true, "builder": true, "found": true,
+	"domain": true, "ports": true, "errors": true, "pgx": true, "pgxpool": true, "sq": true, "psql": true, sqlcPackage: true,
+}
+
+// ValidRepositoryLayer reports whether with names a query layer.
+func ValidRepositoryLayer(with string) bool {
+	return with == RepositorySQLC || with == RepositorySquirrel
+}
+
+// GenerateRepositoryWith writes a repository implementing the model's
+// port on a query layer: sqlc, with a query file, the sqlc configuration
+// and the generated code, or the squirrel query builder. Both read the
+// columns from the domain model and add a migration creating its table
+// when no migration does yet.
+func GenerateRepositoryWith(with, name string, options GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !ValidRepositoryLayer(with) {
+		return fmt.Errorf("unknown query layer '%s' (use sqlc or squirrel)", with)
+	}
+	if err := options.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+	migrations, err := LoadMigrations(cfg)
+	if err != nil {
+		return err
+	}
+
+	custom, err := CustomComponents(projectRoot)
+	if err != nil {
+		return err
+	}
+	task, name, err := s.planComponent(cfg, projectRoot, custom, "repository", name, options)
+	if err != nil {
+		return reportInvalid(err, "invalid repository")
+	}
+	task.Source = nil
+	task.TemplatePath = path.Join("templates/components/repository", with+".go.tpl")
+
+	nameTitle := task.Data.NameTitle
+	modelSpec, _ := LookupComponent("model")
+	modelDir := componentDir(cfg, modelSpec, "")
+	model, err := findModel(filepath.Join(projectRoot, filepath.FromSlash(modelDir)), nameTitle)
+	if err != nil {
+		return err
+	}
+	if model == nil {
+		return fmt.Errorf("no %s struct in %s; generate the model first with 'goforge g model %s'", nameTitle, modelDir, name)
+	}
+
+	variable := strcase.ToLowerCamel(name)
+	if token.IsKeyword(variable) || repoIdentifiers[variable] {
+		variable = "entity"
+	}
+	columns, err := repoColumns(model, with, variable)
+	if err != nil {
+		return fmt.Errorf("%s.%s: %w", path.Base(modelDir), nameTitle, err)
+	}
+
+	repoDir := filepath.ToSlash(relativeTo(projectRoot, filepath.Dir(task.TargetPath)))
+	table := s.pluralize(strcase.ToSnake(name))
+	queriesDir := componentDir(cfg, querySpec, "")
+	var writable, returning []repoColumn
+	hasUpdatedAt, orderBy := false, "id"
+	for _, column := range columns {
+		switch column.Column {
+		case "updated_at":
+			hasUpdatedAt = column.Auto
+		case "created_at":
+			if column.Auto {
+				orderBy = "created_at DESC"
+			}
+		}
+		if column.Auto {
+			returning = append(returning, column)
+		} else {
+			writable = append(writable, column)
+		}
+	}
+	var insertColumns, insertValues, updateSet []string
+	for i, column := range writable {
+		insertColumns = append(insertColumns, column.Column)
+		insertValues = append(insertValues, fmt.Sprintf("$%d", i+1))
+		updateSet = append(updateSet, fmt.Sprintf("%s = $%d", column.Column, i+2))
+	}
+	if hasUpdatedAt {
+		updateSet = append(updateSet, "updated_at = NOW()")
+	}
+	plural := s.pluralize(variable)
+	if plural == variable {
+		plural += "List"
+	}
+
+	task.Data.Vars = map[string]any{
+		"with":          with,
+		"table":         table,
+		"tableTitle":    strcase.ToCamel(table),
+		"label":         strings.ReplaceAll(name, "-", " "),
+		"variable":      variable,
+		"plural":        plural,
+		"columns":       columns,
+		"writable":      writable,
+		"returning":     returning,
+		"hasUpdatedAt":  hasUpdatedAt,
+		"orderBy":       orderBy,
+		"insertColumns": strings.Join(insertColumns, ", "),
+		"insertValues":  strings.Join(insertValues, ", "),
+		"updateSet":     strings.Join(updateSet, ", "),
+		"sqlcPackage":   sqlcPackage,
+		"queriesFile":   path.Join(queriesDir, table+".sql"),
+	}
+
+	logger.ComponentGenerationStart("repository", name)
+
+	var written []string
+	save := func(task FileGenerationTask, content []byte, onConflict string) error {
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+	write := func(task FileGenerationTask, onConflict string) error {
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		return save(task, content, onConflict)
+	}
+	companion := func(template, target string) FileGenerationTask {
+		companion := task
+		companion.TemplatePath = path.Join("templates/components/repository", template)
+		companion.TargetPath = filepath.Join(projectRoot, filepath.FromSlash(target))
+		return companion
+	}
+
+	migration, err := findMigration(projectRoot, migrations.Dir, table)
+	if err != nil {
+		return err
+	}
+	if migration == "" {
+		up, err := s.renderTemplate(companion("migration.up.sql.tpl", ""))
+		if err != nil {
+			return err
+		}
+		down, err := s.renderTemplate(companion("migration.down.sql.tpl", ""))
+		if err != nil {
+			return err
+		}
+		// The engine decides how the statements are split into files
+		for _, file := range migrations.Files(MigrationVersion(), "create_"+table, string(up), string(down)) {
+			if err := save(companion("", file.Path), []byte(file.Content), ConflictSkip); err != nil {
+				return err
+			}
+		}
+		if err := migrations.afterCreate(projectRoot); err != nil {
+			logger.Warn("⚠️  Could not update the %s migration checksums: %v", migrations.Engine, err)
+		}
+	} else {
+		logger.Info("✔️  %s already creates the %s table; check its columns match %s", migration, table, nameTitle)
+	}
+
+	switch with {
+	case RepositorySQLC:
+		target := path.Join(queriesDir, table+".sql")
+		if err := write(companion("queries.sql.tpl", target), options.OnConflict); err != nil {
+			return err
+		}
+		out, err := addSQLCConfig(projectRoot, migrations.Dir, queriesDir, path.Join(repoDir, sqlcPackage))
+		if err != nil {
+			return fmt.Errorf("could not update %s: %w", sqlcConfig, err)
+		}
+		task.Data.Vars["sqlcImport"] = path.Join(cfg.ModuleName, out)
+	case RepositorySquirrel:
+		target := path.Join(repoDir, "squirrel.go")
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(target))); os.IsNotExist(err) {
+			if err := write(companion("squirrel_builder.go.tpl", target), ConflictSkip); err != nil {
+				return err
+			}
+		}
+	}
+	if err := write(task, options.OnConflict); err != nil {
+		return err
+	}
+	if len(written) == 0 {
+		return nil
+	}
+
+	var goFiles []string
+	for _, file := range written {
+		if strings.HasSuffix(file, ".go") {
+			goFiles = append(goFiles, file)
+		}
+	}
+	s.runPostHooks(cfg, projectRoot, goFiles)
+	s.registerProbe(cfg, projectRoot, ProbePostgres)
+
+	modules := []string{"github.com/jackc/pgx/v5"}
+	for _, column := range columns {
+		if strings.Contains(column.SQLType, "UUID") {
+			modules = append(modules, "github.com/google/uuid")
+			break
+		}
+	}
+	generated := true
+	if with == RepositorySQLC {
+		if _, ok := cfg.Scripts["sqlc:generate"]; !ok {
+			if err := project.SetConfigValue(projectRoot, []string{"scripts", "sqlc:generate"}, "sqlc generate"); err != nil {
+				logger.Warn("Could not add the sqlc:generate script to goforge.yml: %v", err)
+			}
+		}
+		if err := generateSQLC(projectRoot); err != nil {
+			logger.Warn("⚠️  Could not generate the sqlc code: %v", err)
+			generated = false
+		}
+	} else {
+		modules = append(modules, "github.com/Masterminds/squirrel")
+	}
+	if err := recordDependencies(cfg, projectRoot, modules); err != nil {
+		return err
+	}
+
+	logger.ComponentGenerationComplete("repository", name, task.TargetPath)
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	step := 1
+	if migration == "" {
+		logger.Info("   %d. Review the migration creating %s in %s, then apply it: goforge migrate up", step, table, migrations.Dir)
+		step++
+	}
+	if with == RepositorySQLC {
+		if !generated {
+			logger.Info("   %d. Generate the sqlc code: goforge run sqlc:generate", step)
+			step++
+		}
+		logger.Info("   %d. Add queries to %s and regenerate with: goforge run sqlc:generate", step, path.Join(queriesDir, table+".sql"))
+	} else {
+		logger.Info("   %d. Add queries to %sRepository, building on its find method", step, nameTitle)
+	}
+	logger.Info("   %d. Wire it up: %s.New%sRepository(pool)", step+1, task.Data.PackageName, nameTitle)
+
+	return nil
+}
+
+// repoColumns maps the model's fields to columns. It needs an int64 ID,
+// which the repository port uses. sqlc only handles the types in
+// columnTypes; other fields are left out with a warning.
+func repoColumns(model *modelStruct, with, variable string) ([]repoColumn, error) {
+	var columns []repoColumn
+	hasID := false
+	for _, field := range model.fields.List {
+		if len(field.Names) == 0 {
+			continue
+		}
+		column := ""
+		if field.Tag != nil {
+			unquoted, _ := strconv.Unquote(field.Tag.Value)
+			tag := reflect.StructTag(unquoted).Get("db")
+			if tag == "-" {
+				continue
+			}
+			column, _, _ = strings.Cut(tag, ",")
+		}
+		goType := types.ExprString(field.Type)
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			c := repoColumn{Field: name.Name, Column: column}
+			if c.Column == "" {
+				c.Column = strcase.ToSnake(name.Name)
+			}
+
+			kind, supported := columnTypes[goType]
+			if !supported {
+				if with == RepositorySQLC {
+					logger.Warn("⚠️  %s is a %s, which the sqlc repository does not map; store it yourself", name.Name, goType)
+					continue
+				}
+				// The zero values of pointers, slices and maps are stored as NULL
+				kind = columnType{sql: fallbackColumnType(field.Type), pointer: true}
+				logger.Warn("⚠️  %s is a %s; check its column type (%s) in the migration", name.Name, goType, kind.sql)
+			}
+
+			c.SQLType = kind.sql
+			if !kind.pointer {
+				c.SQLType += " NOT NULL"
+			}
+			switch c.Column {
+			case "id":
+				if goType != "int64" {
+					return nil, fmt.Errorf("the ID field is a %s; the repository port needs an int64", goType)
+				}
+				hasID = true
+				c.SQLType, c.Auto = "BIGSERIAL PRIMARY KEY", true
+			case "created_at", "updated_at":
+				if goType == "time.Time" {
+					c.SQLType, c.Auto = "TIMESTAMPTZ NOT NULL DEFAULT NOW()", true
+				}
+			}
+
+			c.SQLCField = sqlcFieldName(c.Column)
+			c.FromRow = "row." + c.SQLCField
+			c.ToParam = variable + "." + c.Field
+			if kind.sqlcGo != "" {
+				c.FromRow = goType + "(" + c.FromRow + ")"
+				c.ToParam = kind.sqlcGo + "(" + c.ToParam + ")"
+			}
+			columns = append(columns, c)
+		}
+	}
+	if !hasID {
+		return nil, fmt.Errorf("no int64 ID field (column id), which the repository port needs")
+	}
+	return columns, nil
+}
+
+// fallbackColumnType guesses a column type for fields columnTypes does
+// not cover.
+func fallbackColumnType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	switch expr := expr.(type) {
+	case *ast.ArrayType:
+		if ident, ok := expr.Elt.(*ast.Ident); ok && ident.Name == "string" {
+			return "TEXT[]"
+		}
+		return "JSONB"
+	case *ast.MapType, *ast.StructType:
+		return "JSONB"
+	}
+	return "TEXT"
+}
+
+// sqlcFieldName returns the Go name sqlc gives a column: each word
+// capitalized, with "id" as ID.
+func sqlcFieldName(column string) string {
+	var b strings.Builder
+	for _, word := range strings.Split(column, "_") {
+		if word == "id" {
+			b.WriteString("ID")
+			continue
+		}
+		if word != "" {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// findMigration returns the up migration in dir creating table, or ""
+// when no migration does.
+func findMigration(projectRoot, dir, table string) (string, error) {
+	entries, err := os.ReadDir(filepath.Join(projectRoot, filepath.FromSlash(dir)))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasSuffix(entry.Name(), ".down.sql") || !strings.HasSuffix(entry.Name(), ".sql") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(projectRoot, filepath.FromSlash(dir), entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		for _, match := range createTablePattern.FindAllStringSubmatch(string(data), -1) {
+			if strings.EqualFold(match[1], table) {
+				return path.Join(dir, entry.Name()), nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// sqlcEntry is a package in sqlc.yaml.
+type sqlcEntry struct {
+	Engine  string `yaml:"engine"`
+	Schema  string `yaml:"schema"`
+	Queries string `yaml:"queries"`
+	Gen     struct {
+		Go sqlcGo `yaml:"go"`
+	} `yaml:"gen"`
+}
+
+type sqlcGo struct {
+	Package                  string         `yaml:"package"`
+	Out                      string         `yaml:"out"`
+	SQLPackage               string         `yaml:"sql_package"`
+	EmitPointersForNullTypes bool           `yaml:"emit_pointers_for_null_types"`
+	Overrides                []sqlcOverride `yaml:"overrides"`
+}
+
+type sqlcOverride struct {
+	DBType   string `yaml:"db_type"`
+	Nullable bool   `yaml:"nullable,omitempty"`
+	GoType   any    `yaml:"go_type"`
+}
+
+// sqlcGoType is the long form of an override's go_type.
+type sqlcGoType struct {
+	Import  string `yaml:"import"`
+	Type    string `yaml:"type"`
+	Pointer bool   `yaml:"pointer"`
+}
+
+// addSQLCConfig creates sqlc.yaml, or adds a package for the queries
+// directory to it, generating pgx code into out. Timestamps and UUIDs map
+// to time.Time and uuid.UUID, as in the domain models. It returns the
+// directory the queries are generated into, which an existing package may
+// have moved. sqlc reads the schema from the migrations in schemaDir.
+func addSQLCConfig(projectRoot, schemaDir, queriesDir, out string) (string, error) {
+	entry := sqlcEntry{Engine: "postgresql", Schema: schemaDir, Queries: queriesDir}
+	entry.Gen.Go = sqlcGo{
+		Package:                  sqlcPackage,
+		Out:                      out,
+		SQLPackage:               "pgx/v5",
+		EmitPointersForNullTypes: true,
+		Overrides: []sqlcOverride{
+			{DBType: "timestamptz", GoType: "time.Time"},
+			{DBType: "timestamptz", Nullable: true, GoType: sqlcGoType{Import: "time", Type: "Time", Pointer: true}},
+			{DBType: "uuid", GoType: "github.com/google/uuid.UUID"},
+			{DBType: "uuid", Nullable: true, GoType: sqlcGoType{Import: "github.com/google/uuid", Type: "UUID", Pointer: true}},
+		},
+	}
+
+	file := filepath.Join(projectRoot, sqlcConfig)
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		config := struct {
+			Version string      `yaml:"version"`
+			SQL     []sqlcEntry `yaml:"sql"`
+		}{"2", []sqlcEntry{entry}}
+		content, err := encodeYAML(config)
+		if err != nil {
+			return "", err
+		}
+		return out, os.WriteFile(file, append([]byte(sqlcConfigHeader), content...), 0644)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return "", fmt.Errorf("expected a mapping")
+	}
+	root := doc.Content[0]
+	var sql *yaml.Node
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "sql" {
+			sql = root.Content[i+1]
+		}
+	}
+	if sql == nil {
+		sql = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "sql"}, sql)
+	}
+	if sql.Kind != yaml.SequenceNode {
+		return "", fmt.Errorf("expected 'sql' to be a list")
+	}
+	for _, item := range sql.Content {
+		var existing sqlcEntry
+		if err := item.Decode(&existing); err != nil {
+			continue
+		}
+		if path.Clean(existing.Queries) == queriesDir && existing.Gen.Go.Out != "" {
+			return path.Clean(existing.Gen.Go.Out), nil
+		}
+	}
+
+	var node yaml.Node
+	if err := node.Encode(entry); err != nil {
+		return "", err
+	}
+	sql.Content = append(sql.Content, &node)
+	content, err := encodeYAML(&doc)
+	if err != nil {
+		return "", err
+	}
+	return out, os.WriteFile(file, content, 0644)
+}
+
+// encodeYAML marshals v with two-space indentation.
+func encodeYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// generateSQLC runs 'sqlc generate' in the project, installing sqlc if it
+// is missing.
+func generateSQLC(projectRoot string) error {
+	sqlc, err := ensureGoTool("sqlc", sqlcInstall)
+	if err != nil {
+		return err
+	}
+	logger.Info("⚙️  Running sqlc generate...")
+	return runner.ExecuteCommand(projectRoot, sqlc, "generate")
+}
@@ -2,18 +2,23 @@
 package scaffold
 
 import (
+	"cmp"
+	"bytes"
 	"embed"
 	"fmt"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 	"text/template"
 	"time"
 
 	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
 	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/packs"
 	"github.com/night-slayer18/goforge/internal/project"
 	"github.com/night-slayer18/goforge/internal/runner"
 	"github.com/night-slayer18/goforge/internal/validation"
@@ -31,6 +36,19 @@ type Options struct {
 	Template    string
 	SkipGit     bool
 	Verbose     bool  // Add this field
+	Profile     *Profile // Optional; records the duration of each creation phase
+	Vars        map[string]string // Values for the variables declared in the template's template.yml
+	License     string            // One of Licenses; "" or NoLicense writes no LICENSE file
+	Author      string            // Copyright holder in the LICENSE file and goforge.yml
+	Description string            // One-line summary in the README and goforge.yml, may be empty
+	Repository  string            // URL of the source repository, may be empty
+	CI          string            // One of CIProviders; "" or NoCI writes no pipeline
+	Docker      bool              // Write a Dockerfile, .dockerignore, and docker-compose.yml
+	Features    []string          // Overlays from Features rendered over the template
+	Force       bool              // Overwrite the files of an existing DestPath
+	SkipTidy    bool              // Leave 'go mod tidy' to the user
+	Offline     bool              // Skip every step needing the network: tidy and code generation
+	Version     string            // goforge release, recorded as the version of embedded templates
 }
 
 // TemplateData holds all dynamic values needed for file generation
@@ -38,9 +56,19 @@ type TemplateData struct {
 	ProjectName string
 	ModuleName  string
 	GoVersion   string
-	Name        string // For component generation
-	NameTitle   string // e.g., "User"
-	ModulePath  string // For component generation
+	Author      string            // Copyright holder, may be empty
+	License     string            // License of the project, empty without one
+	Description string            // One-line summary of the project, may be empty
+	Repository  string            // URL of the source repository, may be empty
+	Year        int               // Current year, for copyright notices
+	Name        string            // For component generation
+	NameTitle   string            // e.g., "User"
+	ModulePath  string            // For component generation
+	PackageName string            // Go package of the generated component
+	Imports     map[string]string // Component type -> import path, e.g. .Imports.port
+	Vars        map[string]any    // Template variables from template.yml or generator flags, e.g. .Vars.strategy
+	Fields      []Field           // Entity fields from 'generate --from', empty otherwise
+	Table       string            // Table storing the entity, the plural of Name unless set
 }
 
 // FileGenerationTask represents a single file to be generated
@@ -48,11 +76,14 @@ type FileGenerationTask struct {
 	TemplatePath string
 	TargetPath   string
 	Data         TemplateData
+	Source       fs.FS // Filesystem holding TemplatePath; defaults to the embedded templates
+	ProjectRoot  string // Set to snapshot the output, the base of later merges
 }
 
 // Scaffolder handles project and component generation
 type Scaffolder struct {
-	validator *validation.ProjectValidator
+	validator  *validation.ProjectValidator
+	skipFormat bool // Set by generate.format: false in goforge.yml
 }
 
 // NewScaffolder creates a new scaffolder instance
@@ -88,26 +119,92 @@ func (s *Scaffolder) CreateProject(options Options) error {
 		ProjectName: options.ProjectName,
 		ModuleName:  options.ModulePath,
 		GoVersion:   options.GoVersion,
+		Author:      options.Author,
+		Description: options.Description,
+		Repository:  options.Repository,
+		Year:        time.Now().Year(),
 	}
-
-	// Determine template root
-	templateRoot := fmt.Sprintf("templates/%s", options.Template)
-	
-	// Check if template exists
-	if !s.templateExists(templateRoot) {
-		return fmt.Errorf("template '%s' not found. Available templates: default, minimal", options.Template)
+	if options.License != NoLicense {
+		data.License = options.License
 	}
 
-	// Collect all files to generate
-	tasks, err := s.collectGenerationTasks(templateRoot, options.DestPath, data)
+	setLocalModule(options.ModulePath)
+	profile := options.Profile
+
+	// Determine template source and root, and collect all files to generate
+	var tasks []FileGenerationTask
+	err := profile.Track("collect", func() error {
+		source, templateRoot, err := s.resolveProjectTemplate(options.Template)
+		if err != nil {
+			return err
+		}
+
+		manifest, err := readManifest(source, templateRoot)
+		if err != nil {
+			return err
+		}
+		data.Vars, err = resolveVariables(manifest.Variables, options.Vars)
+		if err != nil {
+			return err
+		}
+
+		tasks, err = s.collectGenerationTasks(source, templateRoot, options.DestPath, data)
+		if err != nil {
+			return fmt.Errorf("failed to collect generation tasks: %w", err)
+		}
+		// The template's output is the base of 'goforge upgrade-template'
+		for i := range tasks {
+			tasks[i].ProjectRoot = options.DestPath
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("failed to collect generation tasks: %w", err)
+		return err
 	}
 
 	logger.Debug("Found %d files to generate", len(tasks))
+	profile.setFiles(len(tasks))
+
+	// Existing directories are only written over with --force
+	if err := s.checkConflicts(options, tasks); err != nil {
+		return err
+	}
+	onConflict := ConflictSkip
+	if options.Force {
+		onConflict = ConflictOverwrite
+	}
 
 	// Generate files with progress tracking
-	if err := s.generateFiles(tasks); err != nil {
+	err = profile.Track("render", func() error {
+		if err := s.generateFiles(tasks); err != nil {
+			return err
+		}
+		if err := s.applyFeatures(options.DestPath, options.Features, data); err != nil {
+			return err
+		}
+		if err := recordTemplate(options, data.Vars); err != nil {
+			return err
+		}
+		if err := s.writeLicense(options, data); err != nil {
+			return err
+		}
+		if options.CI != "" && options.CI != NoCI {
+			if _, err := s.writeCI(options.DestPath, options.CI, data, onConflict); err != nil {
+				return fmt.Errorf("failed to write the CI pipeline: %w", err)
+			}
+		}
+		if options.Docker {
+			cfg, err := project.LoadConfigFrom(options.DestPath)
+			if err != nil {
+				return err
+			}
+			if err := s.writeDocker(options.DestPath, cfg, onConflict); err != nil {
+				return fmt.Errorf("failed to write the Docker files: %w", err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
 		return fmt.Errorf("failed to generate files: %w", err)
 	}
 
@@ -121,10 +218,10 @@ func (s *Scaffolder) CreateProject(options Options) error {
 }
 
 // collectGenerationTasks walks the template directory and collects all files to generate
-func (s *Scaffolder) collectGenerationTasks(templateRoot, destPath string, data TemplateData) ([]FileGenerationTask, error) {
+func (s *Scaffolder) collectGenerationTasks(source fs.FS, templateRoot, destPath string, data TemplateData) ([]FileGenerationTask, error) {
 	var tasks []FileGenerationTask
 
-	err := fs.WalkDir(templatesFS, templateRoot, func(path string, d fs.DirEntry, err error) error {
+	err := fs.WalkDir(source, templateRoot, func(path string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
@@ -134,7 +231,7 @@ func (s *Scaffolder) collectGenerationTasks(templateRoot, destPath string, data
 			return err
 		}
 
-		if relativePath == "." {
+		if relativePath == "." || relativePath == TemplateManifestFile {
 			return nil
 		}
 
@@ -143,6 +240,15 @@ func (s *Scaffolder) collectGenerationTasks(templateRoot, destPath string, data
 			return nil
 		}
 
+		// Path elements may use template actions, e.g.
+		// pkg/{{toPackage .ProjectName}}/doc.go.tpl
+		if strings.Contains(relativePath, "{{") {
+			relativePath, err = s.renderPath(relativePath, data)
+			if err != nil {
+				return err
+			}
+		}
+
 		// Calculate target path
 		targetPath := filepath.Join(destPath, strings.TrimSuffix(relativePath, ".tpl"))
 
@@ -150,6 +256,7 @@ func (s *Scaffolder) collectGenerationTasks(templateRoot, destPath string, data
 			TemplatePath: path,
 			TargetPath:   targetPath,
 			Data:         data,
+			Source:       source,
 		})
 
 		return nil
@@ -158,6 +265,25 @@ func (s *Scaffolder) collectGenerationTasks(templateRoot, destPath string, data
 	return tasks, err
 }
 
+// renderPath executes the template actions in a template file's path.
+func (s *Scaffolder) renderPath(relativePath string, data TemplateData) (string, error) {
+	tmpl, err := template.New(relativePath).Funcs(s.getTemplateFunctions()).Parse(relativePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template path %s: %w", relativePath, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to render template path %s: %w", relativePath, err)
+	}
+	rendered := filepath.Clean(b.String())
+	for _, elem := range strings.Split(rendered, string(filepath.Separator)) {
+		if elem == "" || elem == "." || elem == ".." {
+			return "", fmt.Errorf("template path %s renders to an invalid path: %s", relativePath, rendered)
+		}
+	}
+	return rendered, nil
+}
+
 // generateFiles generates all files, potentially in parallel
 func (s *Scaffolder) generateFiles(tasks []FileGenerationTask) error {
 	logger.Debug("Generating %d files...", len(tasks))
@@ -231,15 +357,34 @@ func (s *Scaffolder) generateFilesParallel(tasks []FileGenerationTask) error {
 
 // generateFile generates a single file from a template
 func (s *Scaffolder) generateFile(task FileGenerationTask) error {
-	// Ensure parent directory exists
-	if err := os.MkdirAll(filepath.Dir(task.TargetPath), os.ModePerm); err != nil {
-		return fmt.Errorf("could not create parent directory for %s: %w", task.TargetPath, err)
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return err
+	}
+	// Templates wrapped in a condition, e.g. {{if eq .Vars.broker "nats"}},
+	// render to nothing when it does not hold and are left out
+	if len(bytes.TrimSpace(content)) == 0 {
+		logger.Debug("Skipping %s: the template rendered nothing", task.TargetPath)
+		return nil
+	}
+	if err := s.writeFile(task.TargetPath, content); err != nil {
+		return err
+	}
+	s.saveSnapshot(task.ProjectRoot, task.TargetPath, content)
+	return nil
+}
+
+// renderTemplate executes a task's template and returns the output
+func (s *Scaffolder) renderTemplate(task FileGenerationTask) ([]byte, error) {
+	source := task.Source
+	if source == nil {
+		source = templatesFS
 	}
 
 	// Read template content
-	tplContent, err := templatesFS.ReadFile(task.TemplatePath)
+	tplContent, err := fs.ReadFile(source, task.TemplatePath)
 	if err != nil {
-		return fmt.Errorf("could not read template file %s: %w", task.TemplatePath, err)
+		return nil, fmt.Errorf("could not read template file %s: %w", task.TemplatePath, err)
 	}
 
 	// Create template with custom functions
@@ -247,23 +392,19 @@ func (s *Scaffolder) generateFile(task FileGenerationTask) error {
 		Funcs(s.getTemplateFunctions()).
 		Parse(string(tplContent))
 	if err != nil {
-		return fmt.Errorf("could not parse template %s: %w", task.TemplatePath, err)
+		return nil, fmt.Errorf("could not parse template %s: %w", task.TemplatePath, err)
 	}
 
-	// Create target file
-	file, err := os.Create(task.TargetPath)
-	if err != nil {
-		return fmt.Errorf("could not create target file %s: %w", task.TargetPath, err)
-	}
-	defer file.Close()
-
 	// Execute template
-	if err := tmpl.Execute(file, task.Data); err != nil {
-		return fmt.Errorf("could not execute template %s: %w", task.TemplatePath, err)
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, task.Data); err != nil {
+		return nil, fmt.Errorf("could not execute template %s: %w", task.TemplatePath, err)
 	}
 
-	logger.FileCreated(task.TargetPath)
-	return nil
+	if !s.skipFormat && strings.HasSuffix(task.TargetPath, ".go") && len(bytes.TrimSpace(buf.Bytes())) > 0 {
+		return formatGo(task.TargetPath, buf.Bytes()), nil
+	}
+	return buf.Bytes(), nil
 }
 
 // getTemplateFunctions returns custom template functions
@@ -272,10 +413,13 @@ func (s *Scaffolder) getTemplateFunctions() template.FuncMap {
 		"toLower":    strings.ToLower,
 		"toUpper":    strings.ToUpper,
 		"toCamel":    strcase.ToCamel,
+		"toLowerCamel": strcase.ToLowerCamel,
 		"toSnake":    strcase.ToSnake,
 		"toKebab":    strcase.ToKebab,
 		"pluralize":  s.pluralize,
+		"toPackage":  packageNameFor,
 		"timestamp":  func() string { return time.Now().Format(time.RFC3339) },
+		"quote":      strconv.Quote,
 	}
 }
 
@@ -293,28 +437,100 @@ func (s *Scaffolder) pluralize(word string) string {
 }
 
 // templateExists checks if a template directory exists
-func (s *Scaffolder) templateExists(templateRoot string) bool {
-	_, err := fs.Stat(templatesFS, templateRoot)
+func (s *Scaffolder) templateExists(source fs.FS, templateRoot string) bool {
+	_, err := fs.Stat(source, templateRoot)
 	return err == nil
 }
 
+// isProjectTemplate reports whether the embedded templates directory name
+// holds a project template rather than component or feature templates.
+func isProjectTemplate(name string) bool {
+	return name != "components" && name != "features"
+}
+
+// embeddedProjectTemplates lists the names of the embedded project
+// templates.
+func embeddedProjectTemplates() []string {
+	var names []string
+	entries, _ := fs.ReadDir(templatesFS, "templates")
+	for _, entry := range entries {
+		if entry.IsDir() && isProjectTemplate(entry.Name()) {
+			names = append(names, entry.Name())
+		}
+	}
+	return names
+}
+
+// resolveProjectTemplate locates a project template. Plain names refer to
+// the embedded templates; "<pack>/<name>" refers to an installed template pack.
+func (s *Scaffolder) resolveProjectTemplate(name string) (fs.FS, string, error) {
+	if !strings.Contains(name, "/") {
+		templateRoot := fmt.Sprintf("templates/%s", name)
+		if !isProjectTemplate(name) || !s.templateExists(templatesFS, templateRoot) {
+			return nil, "", fmt.Errorf("template '%s' not found. Available templates: %s", name, strings.Join(embeddedProjectTemplates(), ", "))
+		}
+		return templatesFS, templateRoot, nil
+	}
+
+	pack, item, err := packs.Resolve(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	source := os.DirFS(pack.TemplatesDir())
+	if !s.templateExists(source, item) {
+		return nil, "", fmt.Errorf("template '%s' not found in pack '%s' (%s)", item, pack.Name, pack.Version)
+	}
+	return source, item, nil
+}
+
 // initializeProject runs post-scaffolding initialization commands
 func (s *Scaffolder) initializeProject(options Options) error {
+	profile := options.Profile
+
 	// Initialize Go module
 	logger.Debug("Initializing Go module: %s", options.ModulePath)
-	if err := runner.InitGoModule(options.DestPath, options.ModulePath); err != nil {
+	err := profile.Track("mod init", func() error {
+		if err := removeExistingModule(options); err != nil {
+			return err
+		}
+		return runner.InitGoModule(options.DestPath, options.ModulePath)
+	})
+	if err != nil {
 		return fmt.Errorf("failed to initialize go module: %w", err)
 	}
 
-	logger.Step(3, 4, "Installing dependencies...")
-	if err := runner.TidyGoModuleWithVerbose(options.DestPath, options.Verbose); err != nil {
-		return fmt.Errorf("failed to tidy go module: %w", err)
+	// The sqlc code is imported by the repositories, so it has to exist
+	// before tidy resolves the imports
+	if _, err := os.Stat(filepath.Join(options.DestPath, sqlcConfig)); err == nil && !options.Offline {
+		if err := generateSQLC(options.DestPath); err != nil {
+			logger.Warn("⚠️  Could not generate the sqlc code: %v", err)
+			logger.Info("💡 Generate it later with: goforge run sqlc:generate")
+		}
+	}
+
+	if options.SkipTidy || options.Offline {
+		logger.Step(3, 4, "Skipping dependency installation...")
+	} else {
+		logger.Step(3, 4, "Installing dependencies...")
+		err = profile.Track("tidy", func() error {
+			return runner.TidyGoModuleWithVerbose(options.DestPath, options.Verbose)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to tidy go module: %w", err)
+		}
+		if err := s.tidyWorkspaceModules(options); err != nil {
+			return err
+		}
 	}
 
 	// Initialize Git repository if not skipped
 	if !options.SkipGit {
 		logger.Step(4, 4, "Initializing Git repository...")
-		if err := runner.InitGitRepository(options.DestPath); err != nil {
+		err := profile.Track("git", func() error {
+			return runner.InitGitRepository(options.DestPath)
+		})
+		if err != nil {
 			logger.Warn("Failed to initialize Git repository: %v", err)
 			logger.Info("💡 You can initialize Git manually later with: git init")
 		} else {
@@ -327,20 +543,67 @@ func (s *Scaffolder) initializeProject(options Options) error {
 	return nil
 }
 
+// tidyWorkspaceModules tidies the other modules of a template with a
+// go.work file, such as the services of the workspace template.
+func (s *Scaffolder) tidyWorkspaceModules(options Options) error {
+	if _, err := os.Stat(filepath.Join(options.DestPath, "go.work")); err != nil {
+		return nil
+	}
+	ws, err := project.FindWorkspace(options.DestPath)
+	if err != nil {
+		return err
+	}
+	for _, dir := range ws.Modules {
+		if dir == "." {
+			continue
+		}
+		moduleDir := filepath.Join(options.DestPath, filepath.FromSlash(dir))
+		err := options.Profile.Track("tidy "+dir, func() error {
+			return runner.TidyGoModuleWithVerbose(moduleDir, options.Verbose)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to tidy go module in %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// GenerateOptions controls how a component is generated
+type GenerateOptions struct {
+	// OnConflict decides what happens when the target file already exists:
+	// prompt (default), skip, overwrite or merge.
+	OnConflict string
+
+	// Path overrides the output directory, relative to the project root.
+	Path string
+
+	// Vars holds values for the variables declared in the generator's manifest.
+	Vars map[string]string
+
+	// Fields are the entity fields from a batch spec, available to
+	// templates as {{.Fields}}.
+	Fields []Field
+
+	// Table names the entity's table for {{.Table}}, replacing the plural
+	// of the name.
+	Table string
+}
+
 // GenerateComponent scaffolds a single architectural component
+// Maintains backward compatibility
 func GenerateComponent(componentType, name string) error {
+	return GenerateComponentWithOptions(componentType, name, GenerateOptions{})
+}
+
+// GenerateComponentWithOptions scaffolds a single component with enhanced options
+func GenerateComponentWithOptions(componentType, name string, options GenerateOptions) error {
 	scaffolder := NewScaffolder()
-	return scaffolder.GenerateComponent(componentType, name)
+	return scaffolder.GenerateComponent(componentType, name, options)
 }
 
 // GenerateComponent generates a single component with enhanced validation
-func (s *Scaffolder) GenerateComponent(componentType, name string) error {
-	// Validate component name
-	if err := s.validator.ValidateComponentName(componentType, name); err != nil {
-		if validationErr, ok := err.(*validation.ValidationError); ok {
-			logger.ValidationError(validationErr.Field, validationErr.Value, validationErr.Message, validationErr.Suggestions)
-			return fmt.Errorf("invalid component name")
-		}
+func (s *Scaffolder) GenerateComponent(componentType, name string, options GenerateOptions) error {
+	if err := options.validate(); err != nil {
 		return err
 	}
 
@@ -349,57 +612,101 @@ func (s *Scaffolder) GenerateComponent(componentType, name string) error {
 	if err != nil {
 		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
 	}
+	s.configure(cfg)
 
-	logger.ComponentGenerationStart(componentType, name)
-
-	data := TemplateData{
-		Name:       name,
-		NameTitle:  strcase.ToCamel(name),
-		ModulePath: cfg.ModuleName,
+	custom, err := CustomComponents(projectRoot)
+	if err != nil {
+		return err
 	}
 
-	var templateFile, targetFile string
-	snakeName := strcase.ToSnake(name)
-
-	switch componentType {
-	case "handler":
-		templateFile = "templates/components/handler.go.tpl"
-		targetFile = filepath.Join(projectRoot, "internal/adapters/http/handler", fmt.Sprintf("%s_handler.go", snakeName))
-	case "service":
-		templateFile = "templates/components/service.go.tpl"
-		targetFile = filepath.Join(projectRoot, "internal/app/service", fmt.Sprintf("%s_service.go", snakeName))
-	case "repository":
-		templateFile = "templates/components/repository.go.tpl"
-		targetFile = filepath.Join(projectRoot, "internal/adapters/postgres", fmt.Sprintf("%s_repo.go", snakeName))
-	case "model":
-		templateFile = "templates/components/model.go.tpl"
-		targetFile = filepath.Join(projectRoot, "internal/domain", fmt.Sprintf("%s.go", snakeName))
-	case "middleware":
-		templateFile = "templates/components/middleware.go.tpl"
-		targetFile = filepath.Join(projectRoot, "internal/adapters/http/middleware", fmt.Sprintf("%s.go", snakeName))
-	case "port":
-		templateFile = "templates/components/port.go.tpl"
-		targetFile = filepath.Join(projectRoot, "internal/ports", fmt.Sprintf("%s_port.go", snakeName))
-	default:
-		return fmt.Errorf("unknown component type: %s\n\nAvailable types: handler, service, repository, model, middleware, port", componentType)
+	task, name, err := s.planComponent(cfg, projectRoot, custom, componentType, name, options)
+	if err != nil {
+		return reportInvalid(err, fmt.Sprintf("invalid %s", componentType))
 	}
 
-	task := FileGenerationTask{
-		TemplatePath: templateFile,
-		TargetPath:   targetFile,
-		Data:         data,
+	logger.ComponentGenerationStart(componentType, name)
+
+	content, err := s.renderTemplate(task)
+	if err != nil {
+		return err
 	}
 
-	if err := s.generateFile(task); err != nil {
+	written, err := s.writeGenerated(task, content, projectRoot, options.OnConflict)
+	if err != nil {
 		return err
 	}
+	if !written {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, []string{task.TargetPath})
+	if componentType == "repository" {
+		s.registerProbe(cfg, projectRoot, ProbePostgres)
+		s.addEntSchema(projectRoot, task)
+	}
 
-	logger.ComponentGenerationComplete(componentType, name, targetFile)
+	logger.ComponentGenerationComplete(componentType, name, task.TargetPath)
 	s.showComponentInstructions(componentType, name)
 
 	return nil
 }
 
+// planComponent validates a component name and works out which template
+// renders it where. It returns the task and the name without namespaces.
+func (s *Scaffolder) planComponent(cfg *project.Config, projectRoot string, custom []ComponentSpec, componentType, name string, options GenerateOptions) (FileGenerationTask, string, error) {
+	// Split "admin/user" into the namespace directories and the name, and
+	// report every invalid segment at once
+	namespaces, name := splitComponentName(name)
+	if err := s.validator.ValidateComponentPath(componentType, namespaces, name); err != nil {
+		return FileGenerationTask{}, "", err
+	}
+
+	spec, err := resolveComponent(projectRoot, componentType)
+	if err != nil {
+		return FileGenerationTask{}, "", err
+	}
+	spec = frameworkComponent(cfg, spec)
+	spec = ormComponent(cfg, spec)
+
+	dir, err := resolveDir(cfg, spec, options.Path, namespaces...)
+	if err != nil {
+		return FileGenerationTask{}, "", err
+	}
+
+	vars, err := resolveVariables(spec.Variables, options.Vars)
+	if err != nil {
+		return FileGenerationTask{}, "", err
+	}
+	loggerVars(cfg, vars)
+
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   strcase.ToCamel(name),
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Imports:     componentImports(cfg, custom...),
+		Vars:        vars,
+		Fields:      options.Fields,
+		Table:       cmp.Or(options.Table, s.pluralize(name)),
+	}
+
+	task := FileGenerationTask{
+		TemplatePath: spec.Template,
+		TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(dir), fmt.Sprintf(spec.FileName, strcase.ToSnake(name))),
+		Data:         data,
+		Source:       spec.templateSource(),
+	}
+	return task, name, nil
+}
+
+// reportInvalid logs the validation errors in err, if any, and replaces
+// them with summary so they are not printed twice.
+func reportInvalid(err error, summary string) error {
+	if validation.Report(err) {
+		return exitcode.Errorf(exitcode.Validation, "%s", summary)
+	}
+	return err
+}
+
 // showComponentInstructions shows helpful instructions after component generation
 func (s *Scaffolder) showComponentInstructions(componentType, name string) {
 	switch componentType {
@@ -0,0 +1,158 @@
+package scaffold
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/iancoleman/strcase"
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// seedersMarker is the line in the seeder registry above which new
+// seeders are added.
+const seedersMarker = "// goforge:seeders"
+
+// seederSpec places seeders and their registry; override it with
+// layout.seeder in goforge.yml.
+var seederSpec = ComponentSpec{Type: "seeder", Dir: "db/seeds"}
+
+// seedMain is the entry point running the seeders.
+const seedMain = "cmd/seed/main.go"
+
+// GenerateSeeder writes a seeder filling a development database with
+// sample data and adds it to the seeder registry. The first seeder also
+// creates the registry and cmd/seed, which runs the seeders.
+func GenerateSeeder(name string, genOptions GenerateOptions) error {
+	s := NewScaffolder()
+
+	if !eventNamePattern.MatchString(name) {
+		return exitcode.Errorf(exitcode.Validation, "invalid seeder name '%s' (use lowercase words separated by hyphens, e.g. users or demo-orders)", name)
+	}
+	if err := genOptions.validate(); err != nil {
+		return err
+	}
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	s.configure(cfg)
+
+	dir, err := resolveDir(cfg, seederSpec, genOptions.Path)
+	if err != nil {
+		return err
+	}
+
+	nameTitle := strcase.ToCamel(name)
+	data := TemplateData{
+		Name:        name,
+		NameTitle:   nameTitle,
+		ModulePath:  cfg.ModuleName,
+		PackageName: packageNameFor(dir),
+		Vars: map[string]any{
+			"table":        strcase.ToSnake(name),
+			"seedsImport":  path.Join(cfg.ModuleName, dir),
+			"seedsPackage": packageNameFor(dir),
+			"seedsDir":     dir,
+		},
+	}
+
+	logger.ComponentGenerationStart("seeder", name)
+
+	var written []string
+	write := func(template, target string, onConflict string) error {
+		fileData := data
+		fileData.PackageName = packageNameFor(path.Dir(target))
+		if target == seedMain {
+			fileData.PackageName = "main"
+		}
+		task := FileGenerationTask{
+			TemplatePath: path.Join("templates/components/seeder", template),
+			TargetPath:   filepath.Join(projectRoot, filepath.FromSlash(target)),
+			Data:         fileData,
+		}
+		content, err := s.renderTemplate(task)
+		if err != nil {
+			return err
+		}
+		ok, err := s.writeGenerated(task, content, projectRoot, onConflict)
+		if ok {
+			written = append(written, task.TargetPath)
+		}
+		return err
+	}
+
+	// The registry and the command are created once and then belong to
+	// the project
+	registry := path.Join(dir, "seeds.go")
+	shared := []struct{ template, target string }{
+		{"seeds.go.tpl", registry},
+		{"main.go.tpl", seedMain},
+	}
+	for _, file := range shared {
+		if _, err := os.Stat(filepath.Join(projectRoot, filepath.FromSlash(file.target))); err == nil {
+			continue
+		}
+		if err := write(file.template, file.target, ConflictSkip); err != nil {
+			return err
+		}
+	}
+
+	seederFile := path.Join(dir, strcase.ToSnake(name)+".go")
+	if err := write("seeder.go.tpl", seederFile, genOptions.OnConflict); err != nil {
+		return err
+	}
+
+	registered, err := registerSeeder(filepath.Join(projectRoot, filepath.FromSlash(registry)), name, nameTitle)
+	if err != nil {
+		return err
+	}
+	if len(written) == 0 && !registered {
+		return nil
+	}
+	s.runPostHooks(cfg, projectRoot, written)
+
+	if err := recordDependencies(cfg, projectRoot, []string{"github.com/jackc/pgx/v5", "github.com/spf13/viper"}); err != nil {
+		return err
+	}
+	if _, ok := cfg.Scripts["db:seed"]; !ok {
+		if err := project.SetConfigValue(projectRoot, []string{"scripts", "db:seed"}, "go run ./"+path.Dir(seedMain)); err != nil {
+			logger.Warn("Could not add the db:seed script to goforge.yml: %v", err)
+		}
+	}
+
+	logger.ComponentGenerationComplete("seeder", name, filepath.Join(projectRoot, filepath.FromSlash(seederFile)))
+
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Insert the seed data in Seed%s in %s", nameTitle, seederFile)
+	logger.Info("   2. Run the migrations (goforge migrate up), then seed the database: goforge run db:seed")
+	logger.Info("   3. Run only this seeder with: go run ./%s %s", path.Dir(seedMain), name)
+
+	return nil
+}
+
+// registerSeeder adds the seeder to the registry above the
+// goforge:seeders marker. It reports whether the registry changed.
+func registerSeeder(registry, name, nameTitle string) (bool, error) {
+	entry := fmt.Sprintf("{Name: %q, Run: Seed%s},", name, nameTitle)
+	result, err := insertAtMarker(registry, seedersMarker, entry, "Seed"+nameTitle+"}")
+	if err != nil {
+		return false, fmt.Errorf("could not update seeder registry: %w", err)
+	}
+
+	switch result {
+	case markerPresent:
+		logger.Info("✔️  Seed%s is already registered", nameTitle)
+	case markerMissing:
+		logger.Warn("⚠️  %s has no '%s' marker; register the seeder yourself:", registry, seedersMarker)
+		logger.Warn("   %s", entry)
+	default:
+		logger.Debug("Registered Seed%s in %s", nameTitle, registry)
+	}
+	return result == markerInserted, nil
+}
@@ -0,0 +1,43 @@
+package scaffold
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// templGenerate compiles the .templ components into Go, run with 'go run'
+// so the generator matches the runtime version in go.mod.
+const templGenerate = "go run github.com/a-h/templ/cmd/templ generate"
+
+// errFound stops a directory walk once it has found what it looks for.
+var errFound = errors.New("found")
+
+// HasTemplComponents reports whether the project has .templ files.
+func HasTemplComponents(projectRoot string) bool {
+	err := filepath.WalkDir(projectRoot, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if entry.IsDir() {
+			if path != projectRoot && (strings.HasPrefix(entry.Name(), ".") || entry.Name() == "vendor" || entry.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(entry.Name(), ".templ") {
+			return errFound
+		}
+		return nil
+	})
+	return errors.Is(err, errFound)
+}
+
+// GenerateTemplCode compiles the templ components of the project at
+// projectRoot into Go.
+func GenerateTemplCode(projectRoot string) error {
+	return runner.ExecuteScript(projectRoot, templGenerate)
+}
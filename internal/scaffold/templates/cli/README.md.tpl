@@ -0,0 +1,69 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This command-line tool was generated by [GoForge](https://github.com/night-slayer18/goforge).
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+
+### Project Layout
+
+- `cmd/cli/` — the entry point
+- `internal/cli/` — the commands: `root.go` holds the root command and config loading, `version.go` the `version` command
+
+### Running the Tool
+
+```bash
+go run ./cmd/cli --help
+go run ./cmd/cli version
+```
+
+### Configuration
+
+Settings are read, in increasing precedence, from:
+
+1. `config.yaml` in the user config directory (`~/.config/{{.ProjectName}}/` on Linux)
+2. `.{{.ProjectName}}.yaml` in the working directory
+3. The file passed with `--config`, which replaces both
+4. Environment variables prefixed with `{{.ProjectName | toSnake | toUpper}}_`, e.g. `{{.ProjectName | toSnake | toUpper}}_LOG_LEVEL` for `log-level`
+5. Command-line flags
+
+Read them with `viper.GetString("key")` and friends.
+
+### Adding Commands
+
+```bash
+goforge g command sync    # internal/cli/sync.go, registered on the root command
+```
+
+### Releasing
+
+`goforge build` compiles `./cmd/cli` into `dist/{{.ProjectName}}`, stamping the git tag, commit and build date set in `build.ldflags` in `goforge.yml`, which `{{.ProjectName}} version` prints:
+
+```bash
+git tag v0.1.0
+goforge build
+./dist/{{.ProjectName}} version
+```
+
+### Available Scripts
+
+-   `goforge run dev`: Runs the tool.
+-   `goforge run build`: Builds a versioned binary into `dist/`.
+-   `goforge run install`: Installs the tool into `$GOPATH/bin`.
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -0,0 +1,14 @@
+// Command cli is the {{.ProjectName}} command line.
+package main
+
+import (
+	"os"
+
+	"{{.ModuleName}}/internal/cli"
+)
+
+func main() {
+	if err := cli.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
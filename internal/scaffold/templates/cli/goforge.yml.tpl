@@ -0,0 +1,151 @@
+# GoForge project configuration
+project_name: "{{.ProjectName}}"
+module_path: "{{.ModuleName}}"
+go_version: "{{.GoVersion}}"
+
+# Project metadata
+description: {{quote .Description}}
+author: "{{.Author}}"
+license: "{{.License}}"
+{{- if .Repository}}
+repository: {{quote .Repository}}
+{{- end}}
+
+# Dependencies with version constraints
+dependencies:
+  github.com/spf13/cobra: "^1.9.0"
+  github.com/spf13/viper: "^1.19.0"
+
+# Development dependencies
+dev_dependencies:
+  github.com/stretchr/testify: "^1.10.0"
+  github.com/golang/mock: "^1.6.0"
+
+# Custom scripts for project automation
+scripts:
+  # Development
+  dev: "go run ./cmd/cli"
+  cli: "go run ./cmd/cli"
+  
+  # Building
+  build: "goforge build"
+  install: "go install ./cmd/cli"
+  
+  # Testing
+  test: "goforge test"
+  test:race: "goforge test --race"
+  test:all: "goforge test --coverage --race"
+  
+  # Code quality
+  lint: "goforge lint"
+  fmt: "goforge fmt"
+  vet: "go vet ./..."
+
+# Build configuration
+build:
+  # Output directory for build artifacts
+  output_dir: "dist"
+  
+  # Binary name (defaults to project name)
+  binary_name: "{{.ProjectName}}"
+
+  # Package compiled by 'goforge build'
+  main: "./cmd/cli"
+
+  # Linker flags; {version} (git describe), {commit} and {date} are filled in
+  # at build time and shown by '{{.ProjectName}} version'
+  ldflags: "-s -w -X {{.ModuleName}}/internal/cli.version={version} -X {{.ModuleName}}/internal/cli.commit={commit} -X {{.ModuleName}}/internal/cli.date={date}"
+    
+  # Cross-compilation targets
+  targets:
+    - os: "linux"
+      arch: "amd64"
+    - os: "linux"
+      arch: "arm64"
+    - os: "windows" 
+      arch: "amd64"
+    - os: "darwin"
+      arch: "amd64"
+    - os: "darwin"
+      arch: "arm64"
+
+# Development configuration
+dev:
+  # Files/directories to watch for changes
+  watch:
+    - "**/*.go"
+  
+  # Files/directories to ignore
+  ignore:
+    - "dist/**"
+    - "**/*_test.go"
+    - ".git/**"
+  
+  # Commands to run on file changes
+  on_change:
+    - "go fmt ./..."
+    - "go vet ./..."
+
+# Testing configuration
+test:
+  # Test timeout
+  timeout: "10m"
+  
+  # Coverage threshold (percentage)
+  coverage_threshold: 80
+
+# Code generation settings
+generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
+
+  # Extra commands run after generating; {files} is replaced by the new files
+  # post_hooks:
+  #   - "gofumpt -w {files}"
+
+  # Default component templates
+  templates:
+    handler: "templates/components/handler.go.tpl"
+    service: "templates/components/service.go.tpl"
+    repository: "templates/components/repository.go.tpl"
+    model: "templates/components/model.go.tpl"
+    middleware: "templates/components/middleware.go.tpl"
+
+# Mocks of port interfaces ('goforge generate mock', 'goforge mocks')
+mocks:
+  # mockgen (go.uber.org/mock) or mockery; installed on first use if missing
+  tool: "mockgen"
+
+  # Also generate mocks whenever 'goforge generate port' runs
+  auto: false
+
+# Output directories for generated components (relative to the project root).
+# Override any entry to match your project structure, or use
+# 'goforge generate <component> <name> --path <dir>' for a one-off location.
+layout:
+  handler: "internal/adapters/http/handler"
+  service: "internal/app/service"
+  repository: "internal/adapters/postgres"
+  model: "internal/domain"
+  middleware: "internal/adapters/http/middleware"
+  port: "internal/ports"
+  ratelimiter: "internal/platform/ratelimit"
+  oidc: "internal/platform/auth/oidc"
+  mock: "internal/mocks"
+  job: "internal/jobs"
+  scheduler: "internal/platform/scheduler"
+  health: "internal/platform/health"
+  event: "internal/events"
+  eventbus: "internal/platform/eventbus"
+  grpc: "internal/adapters/grpc"
+  proto: "api/proto"
+  graphql: "internal/adapters/graphql"
+  command: "internal/cli"
+  config: "internal/config"
+  enum: "internal/domain"
+  api: "internal/adapters/http/api"
+  docs: "internal/adapters/http/docs"
+  consumer: "internal/adapters/messaging"
+  seeder: "db/seeds"
+  fixture: "internal/testutil"
+  queries: "db/queries"
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// appName names the binary, its config file and its environment variables.
+const appName = "{{.ProjectName}}"
+
+// rootCmd is the base command; 'goforge g command' registers subcommands
+// in init below.
+var rootCmd = &cobra.Command{
+	Use:          appName,
+	Short:        appName + " command line",
+	SilenceUsage: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return loadConfig(cmd)
+	},
+}
+
+// Execute runs the command line with the process arguments.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+// loadConfig reads the file given with --config, or else config.yaml in the
+// user's config directory and .{{.ProjectName}}.yaml in the working directory,
+// the latter taking precedence. Environment variables prefixed with the
+// upper-cased app name override both, and flags override everything.
+func loadConfig(cmd *cobra.Command) error {
+	viper.SetEnvPrefix(strings.ToUpper(strings.ReplaceAll(appName, "-", "_")))
+	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_", ".", "_"))
+	viper.AutomaticEnv()
+	if err := viper.BindPFlags(cmd.Flags()); err != nil {
+		return err
+	}
+
+	if file, _ := cmd.Flags().GetString("config"); file != "" {
+		viper.SetConfigFile(file)
+		if err := viper.ReadInConfig(); err != nil {
+			return fmt.Errorf("failed to read config %s: %w", file, err)
+		}
+		return nil
+	}
+
+	var files []string
+	if dir, err := os.UserConfigDir(); err == nil {
+		files = append(files, filepath.Join(dir, appName, "config.yaml"))
+	}
+	files = append(files, "."+appName+".yaml")
+	for _, file := range files {
+		viper.SetConfigFile(file)
+		err := viper.MergeInConfig()
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to read config %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	rootCmd.PersistentFlags().String("config", "", "config file (default: "+appName+"/config.yaml in the user config directory)")
+	rootCmd.AddCommand(newVersionCmd())
+}
@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/spf13/cobra"
+)
+
+// Build information, set by 'goforge build' from build.ldflags in goforge.yml.
+var (
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+// newVersionCmd creates the 'version' command.
+func newVersionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Print the version",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			fmt.Fprintf(cmd.OutOrStdout(), "%s %s (commit %s, built %s, %s/%s)\n",
+				appName, version, commit, date, runtime.GOOS, runtime.GOARCH)
+		},
+	}
+}
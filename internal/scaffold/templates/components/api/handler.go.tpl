@@ -0,0 +1,16 @@
+package {{.PackageName}}
+
+// Handler implements the operations in {{.Vars.spec}}. 'goforge g api'
+// adds a method stub to the <tag>_handler.go files for each new operation.
+type Handler struct {
+	// Add the services the operations use, e.g. users *service.UserService
+}
+
+// NewHandler creates a Handler.
+func NewHandler() *Handler {
+	return &Handler{}
+}
+
+// Handler must implement every operation; when the spec changes, rerun
+// 'goforge g api' and the compiler points at the stubs to update.
+var _ Operations = (*Handler)(nil)
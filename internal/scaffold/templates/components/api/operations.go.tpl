@@ -0,0 +1,17 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+{{range .Vars.operations}}
+// {{.Handler}} handles {{.Method}} {{.Path}}.
+{{- if .Summary}}
+{{.Summary}}
+{{- end}}
+func (h *Handler) {{.Handler}}({{.Signature}}) {
+	// TODO: Implement {{.ID}}, then respond with {{if .Response}}c.JSON({{.Status}}, response) where response is a {{.Response}}{{else}}c.Status({{.Status}}){{end}}.
+	c.JSON(http.StatusNotImplemented, gin.H{"error": "{{.ID}} is not implemented"})
+}
+{{end}}
@@ -0,0 +1,66 @@
+// Code generated by goforge from {{.Vars.spec}}. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+{{- if .Vars.hasParams}}
+	"github.com/gin-gonic/gin/binding"
+{{- end}}
+)
+
+// Operations has a method per operation in {{.Vars.spec}}. Handler
+// implements it; parameters and request bodies arrive bound and validated.
+type Operations interface {
+{{- range .Vars.operations}}
+	// {{.Handler}} handles {{.Method}} {{.Path}}.
+	{{.Handler}}({{.Signature}})
+{{- end}}
+}
+
+// RegisterRoutes registers the operations in {{.Vars.spec}} on router,
+// answering 400 Bad Request when parameters or the request body are invalid.
+func RegisterRoutes(router gin.IRouter, ops Operations) {
+{{- range .Vars.operations}}
+	router.{{.Method}}("{{.GinPath}}", func(c *gin.Context) {
+{{- if .Params}}
+		var params {{.Params}}
+		if err := bindParams(c, &params); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+{{- end}}
+{{- if .Body}}
+		var body {{.Body}}
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+{{- end}}
+		ops.{{.Handler}}({{.Args}})
+	})
+{{- end}}
+}
+{{- if .Vars.hasParams}}
+
+// bindParams fills params from the path, query string and headers, then
+// validates it.
+func bindParams(c *gin.Context, params any) error {
+	path := make(map[string][]string, len(c.Params))
+	for _, param := range c.Params {
+		path[param.Key] = []string{param.Value}
+	}
+	if err := binding.MapFormWithTag(params, path, "uri"); err != nil {
+		return err
+	}
+	if err := binding.MapFormWithTag(params, c.Request.URL.Query(), "form"); err != nil {
+		return err
+	}
+	if err := binding.MapFormWithTag(params, c.Request.Header, "header"); err != nil {
+		return err
+	}
+	return binding.Validator.ValidateStruct(params)
+}
+{{- end}}
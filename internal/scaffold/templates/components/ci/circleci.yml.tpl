@@ -0,0 +1,39 @@
+# CI pipeline generated by GoForge: runs 'goforge check', the quality gate of
+# {{.ProjectName}} set by check.steps and the scripts in goforge.yml.
+version: 2.1
+
+jobs:
+  ci:
+    parameters:
+      go-version:
+        type: string
+    docker:
+      - image: golang:<< parameters.go-version >>
+    steps:
+      - checkout
+      - restore_cache:
+          keys:
+            - go-<< parameters.go-version >>-{{"{{"}} checksum "go.sum" }}
+      - run:
+          name: Install goforge
+          command: go install github.com/night-slayer18/goforge@latest
+      - run:
+          name: Check
+          command: goforge check
+      - save_cache:
+          key: go-<< parameters.go-version >>-{{"{{"}} checksum "go.sum" }}
+          paths:
+            - /go/pkg/mod
+            - /root/.cache/go-build
+            - /root/.cache/goforge
+
+workflows:
+  ci:
+    jobs:
+      - ci:
+          matrix:
+            parameters:
+              go-version:
+{{- range .Vars.goVersions}}
+                - "{{.}}"
+{{- end}}
@@ -0,0 +1,36 @@
+# CI pipeline generated by GoForge: runs 'goforge check', the quality gate of
+# {{.ProjectName}} set by check.steps and the scripts in goforge.yml.
+name: CI
+
+on:
+  push:
+    branches: [main]
+  pull_request:
+
+jobs:
+  ci:
+    name: Go ${{"{{"}} matrix.go-version }}
+    runs-on: ubuntu-latest
+    strategy:
+      fail-fast: false
+      matrix:
+        go-version:
+{{- range .Vars.goVersions}}
+          - "{{if eq . "latest"}}stable{{else}}{{.}}{{end}}"
+{{- end}}
+
+    steps:
+      - uses: actions/checkout@v4
+
+      - uses: actions/setup-go@v5
+        with:
+          go-version: ${{"{{"}} matrix.go-version }}
+          # Caches the module download and build caches, keyed on go.sum
+          cache: true
+          cache-dependency-path: "**/go.sum"
+
+      - name: Install goforge
+        run: go install github.com/night-slayer18/goforge@latest
+
+      - name: Check
+        run: goforge check
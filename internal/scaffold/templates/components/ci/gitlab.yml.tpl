@@ -0,0 +1,32 @@
+# CI pipeline generated by GoForge: runs 'goforge check', the quality gate of
+# {{.ProjectName}} set by check.steps and the scripts in goforge.yml.
+stages:
+  - ci
+
+variables:
+  # Keep the caches inside the project directory, where GitLab caches them
+  GOPATH: "$CI_PROJECT_DIR/.go"
+  GOCACHE: "$CI_PROJECT_DIR/.go/cache"
+
+ci:
+  stage: ci
+  image: golang:$GO_VERSION
+  parallel:
+    matrix:
+      - GO_VERSION:
+{{- range .Vars.goVersions}}
+          - "{{.}}"
+{{- end}}
+  cache:
+    key:
+      files:
+        - go.sum
+      prefix: go-$GO_VERSION
+    paths:
+      - .go/pkg/mod/
+      - .go/cache/
+  before_script:
+    - export PATH="$GOPATH/bin:$PATH"
+    - go install github.com/night-slayer18/goforge@latest
+  script:
+    - goforge check
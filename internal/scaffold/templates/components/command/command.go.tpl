@@ -0,0 +1,28 @@
+package {{.PackageName}}
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// {{.Vars.constructor}} creates the '{{.Name}}' command.
+func {{.Vars.constructor}}() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "{{.Name}}",
+		Short: "TODO: Describe what {{.Name}} does",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// goforge:keep run
+			fmt.Fprintln(cmd.OutOrStdout(), "{{.Name}} called")
+			return nil
+			// goforge:end
+		},
+	}
+
+	// goforge:keep flags
+	// Flags, e.g. cmd.Flags().Bool("dry-run", false, "Only report what would change")
+	// goforge:end
+
+	return cmd
+}
@@ -0,0 +1,14 @@
+// Command cli is the {{.Vars.binary}} command line.
+package main
+
+import (
+	"os"
+
+	"{{.Vars.commandImport}}"
+)
+
+func main() {
+	if err := {{.Vars.commandPackage}}.Execute(); err != nil {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,21 @@
+package {{.PackageName}}
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// rootCmd is the base command; 'goforge g command' registers subcommands
+// in init below.
+var rootCmd = &cobra.Command{
+	Use:          "{{.Vars.binary}}",
+	Short:        "{{.Vars.binary}} command line",
+	SilenceUsage: true,
+}
+
+// Execute runs the command line with the process arguments.
+func Execute() error {
+	return rootCmd.Execute()
+}
+
+func init() {
+}
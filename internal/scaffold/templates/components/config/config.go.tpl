@@ -0,0 +1,41 @@
+// Package {{.PackageName}} loads the application configuration from
+// config/default.yml and the environment. Each section has a typed struct
+// generated with 'goforge g config <section>'; environment variables
+// override the file, e.g. PAYMENT_API_KEY for payment.api_key.
+package {{.PackageName}}
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// sections register their defaults and environment variables with Load.
+var sections []func(v *viper.Viper)
+
+// Load reads config/default.yml and the environment into viper's global
+// instance. A missing file is not an error: the defaults and environment
+// variables still apply.
+func Load() error {
+	v := viper.GetViper()
+	v.SetConfigName("default")
+	v.SetConfigType("yml")
+	v.AddConfigPath("./config")
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	v.AutomaticEnv()
+
+	for _, register := range sections {
+		register(v)
+	}
+
+	if err := v.ReadInConfig(); err != nil {
+		var notFound viper.ConfigFileNotFoundError
+		if errors.As(err, &notFound) {
+			return nil
+		}
+		return fmt.Errorf("reading config: %w", err)
+	}
+	return nil
+}
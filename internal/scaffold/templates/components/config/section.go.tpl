@@ -0,0 +1,48 @@
+package {{.PackageName}}
+
+import (
+	"fmt"
+{{- if .Vars.usesTime}}
+	"time"
+{{- end}}
+
+	"github.com/spf13/viper"
+)
+
+// {{.NameTitle}}Config is the {{.Vars.section}} section of config/default.yml.
+// It is generated by 'goforge g config {{.Name}}': add keys to the section
+// (or pass --field) and run the command again to keep both in sync.
+type {{.NameTitle}}Config struct {
+{{- range .Vars.fields}}
+	{{.GoName}} {{.Type}} `mapstructure:"{{.Key}}"`
+{{- end}}
+}
+
+func init() {
+	sections = append(sections, func(v *viper.Viper) {
+{{- range .Vars.fields}}
+		v.SetDefault("{{$.Vars.section}}.{{.Key}}", {{.GoDefault}})
+{{- end}}
+{{range .Vars.fields}}
+		v.BindEnv("{{$.Vars.section}}.{{.Key}}", "{{.Env}}")
+{{- end}}
+	})
+}
+
+// {{.NameTitle}} returns the {{.Vars.section}} section; call Load first.
+func {{.NameTitle}}() ({{.NameTitle}}Config, error) {
+	// Unlike UnmarshalKey, Unmarshal applies environment variables set for
+	// the section's keys
+	var settings struct {
+		Section {{.NameTitle}}Config `mapstructure:"{{.Vars.section}}"`
+	}
+	if err := viper.Unmarshal(&settings); err != nil {
+		return settings.Section, fmt.Errorf("invalid {{.Vars.section}} config: %w", err)
+	}
+	return settings.Section, nil
+}
+
+// goforge:keep custom
+// Code between these markers is preserved when this file is regenerated,
+// e.g. a Validate method.
+// goforge:end
@@ -0,0 +1,46 @@
+package {{.PackageName}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"strings"
+)
+
+// Codec encodes and decodes the payloads of one content type.
+type Codec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// Codecs maps content types to their codec. Register codecs for other
+// content types, such as Avro or Protobuf, here.
+var Codecs = map[string]Codec{
+	"application/json": JSONCodec{},
+}
+
+// JSONCodec encodes payloads as JSON.
+type JSONCodec struct{}
+
+func (JSONCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }
+func (JSONCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) }
+
+// Decode decodes data into v with the codec of contentType. Types such as
+// application/cloudevents+json without a codec of their own use JSON.
+func Decode(contentType string, data []byte, v any) error {
+	media, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		media = contentType
+	}
+	codec, ok := Codecs[media]
+	if !ok && strings.HasSuffix(media, "+json") {
+		codec, ok = JSONCodec{}, true
+	}
+	if !ok {
+		return fmt.Errorf("no codec for content type %q", contentType)
+	}
+	if err := codec.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding %s payload: %w", media, err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package {{.PackageName}}
+
+import "log/slog"
+
+// Consumers handles the messages of the channels in {{.Vars.spec}}. Add
+// the dependencies the handlers need, such as services, here.
+type Consumers struct {
+	logger *slog.Logger
+}
+
+// NewConsumers returns the consumers.
+func NewConsumers(logger *slog.Logger) *Consumers {
+	return &Consumers{logger: logger}
+}
@@ -0,0 +1,14 @@
+package {{.PackageName}}
+
+import "context"
+{{with .Vars.channel}}
+// {{.Handler}} handles the messages of {{.Channel}}.
+{{- if .Summary}}
+{{.Summary}}
+{{- end}}
+func (c *Consumers) {{.Handler}}(ctx context.Context, msg {{.Message}}) error {
+	// TODO: implement; returning an error logs the message as failed
+	c.logger.InfoContext(ctx, "received message", "channel", {{printf "%q" .Channel}})
+	return nil
+}
+{{- end}}
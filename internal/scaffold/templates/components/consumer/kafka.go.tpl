@@ -0,0 +1,67 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// RunKafka consumes the topic of every subscription as part of the
+// consumer group until ctx is done. A message's offset is committed once
+// its handler returns; handler errors are logged, so a bad message does
+// not block its partition. It returns the first reader error.
+func RunKafka(ctx context.Context, brokers []string, group string, subscriptions []Subscription, logger *slog.Logger) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for _, sub := range subscriptions {
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers: brokers,
+			GroupID: group,
+			Topic:   sub.Channel,
+		})
+		wg.Add(1)
+		go func(sub Subscription, reader *kafka.Reader) {
+			defer wg.Done()
+			defer reader.Close()
+			for {
+				msg, err := reader.FetchMessage(ctx)
+				if err != nil {
+					if !errors.Is(err, context.Canceled) {
+						fail(fmt.Errorf("reading %s: %w", sub.Channel, err))
+					}
+					return
+				}
+				if err := sub.Handle(ctx, msg.Value); err != nil {
+					logger.ErrorContext(ctx, "failed to handle message",
+						"topic", msg.Topic, "partition", msg.Partition, "offset", msg.Offset, "error", err)
+				}
+				if err := reader.CommitMessages(ctx, msg); err != nil {
+					if !errors.Is(err, context.Canceled) {
+						fail(fmt.Errorf("committing %s: %w", sub.Channel, err))
+					}
+					return
+				}
+			}
+		}(sub, reader)
+	}
+
+	wg.Wait()
+	return firstErr
+}
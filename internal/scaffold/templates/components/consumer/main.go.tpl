@@ -0,0 +1,52 @@
+// Command consumer consumes the messages of the channels in
+// {{.Vars.spec}} until it receives SIGINT or SIGTERM, then lets the
+// handlers in progress finish.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/viper"
+
+	"{{.Vars.import}}"
+)
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.AutomaticEnv()
+{{- if eq .Vars.broker "nats"}}
+	viper.SetDefault("messaging.url", "nats://localhost:4222")
+	viper.SetDefault("messaging.queue", "{{.ProjectName}}")
+{{- else}}
+	viper.SetDefault("messaging.brokers", []string{"localhost:9092"})
+	viper.SetDefault("messaging.group", "{{.ProjectName}}")
+{{- end}}
+	if err := viper.ReadInConfig(); err != nil {
+		logger.Warn("could not read config, using defaults", "error", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	subscriptions := {{.Vars.package}}.Subscriptions({{.Vars.package}}.NewConsumers(logger))
+{{- if eq .Vars.broker "nats"}}
+	logger.Info("consuming", "broker", "nats", "subjects", len(subscriptions))
+	err := {{.Vars.package}}.RunNATS(ctx, viper.GetString("messaging.url"), viper.GetString("messaging.queue"), subscriptions, logger)
+{{- else}}
+	logger.Info("consuming", "broker", "kafka", "topics", len(subscriptions))
+	err := {{.Vars.package}}.RunKafka(ctx, viper.GetStringSlice("messaging.brokers"), viper.GetString("messaging.group"), subscriptions, logger)
+{{- end}}
+	if err != nil {
+		logger.Error("consumer failed", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("consumer stopped")
+}
@@ -0,0 +1,40 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/nats-io/nats.go"
+)
+
+// RunNATS subscribes to the subject of every subscription in the queue
+// group until ctx is done, then drains the connection so in-flight
+// messages finish. Handler errors are logged.
+func RunNATS(ctx context.Context, url, queue string, subscriptions []Subscription, logger *slog.Logger) error {
+	closed := make(chan struct{})
+	conn, err := nats.Connect(url, nats.Name(queue), nats.ClosedHandler(func(*nats.Conn) { close(closed) }))
+	if err != nil {
+		return fmt.Errorf("connecting to %s: %w", url, err)
+	}
+
+	for _, sub := range subscriptions {
+		handle := sub.Handle
+		if _, err := conn.QueueSubscribe(sub.Channel, queue, func(msg *nats.Msg) {
+			if err := handle(ctx, msg.Data); err != nil {
+				logger.ErrorContext(ctx, "failed to handle message", "subject", msg.Subject, "error", err)
+			}
+		}); err != nil {
+			conn.Close()
+			return fmt.Errorf("subscribing to %s: %w", sub.Channel, err)
+		}
+	}
+
+	<-ctx.Done()
+	if err := conn.Drain(); err != nil {
+		conn.Close()
+		return err
+	}
+	<-closed
+	return nil
+}
@@ -0,0 +1,32 @@
+// Code generated by goforge from {{.Vars.spec}}. DO NOT EDIT.
+
+package {{.PackageName}}
+
+import "context"
+
+// Subscription binds a channel to the handler of its messages.
+type Subscription struct {
+	Channel     string // {{if eq .Vars.broker "nats"}}subject{{else}}topic{{end}} to consume
+	ContentType string // content type of the payloads
+	Handle      func(ctx context.Context, data []byte) error
+}
+
+// Subscriptions returns the channels of {{.Vars.spec}} the application
+// consumes, each decoding its payload and passing it to c.
+func Subscriptions(c *Consumers) []Subscription {
+	return []Subscription{
+{{- range .Vars.channels}}
+		{
+			Channel:     {{printf "%q" .Subject}},
+			ContentType: {{printf "%q" .ContentType}},
+			Handle: func(ctx context.Context, data []byte) error {
+				var msg {{.Message}}
+				if err := Decode({{printf "%q" .ContentType}}, data, &msg); err != nil {
+					return err
+				}
+				return c.{{.Handler}}(ctx, msg)
+			},
+		},
+{{- end}}
+	}
+}
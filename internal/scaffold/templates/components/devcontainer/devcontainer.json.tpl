@@ -0,0 +1,33 @@
+// Dev container of {{.ProjectName}} generated by GoForge: the Go toolchain
+// and goforge{{if .Vars.compose}}, next to the project's services in docker-compose.yml{{end}}
+{
+  "name": "{{.ProjectName}}",
+{{- if .Vars.compose}}
+  "dockerComposeFile": "docker-compose.yml",
+  "service": "workspace",
+  "workspaceFolder": "/workspaces/{{.ProjectName}}",
+  "shutdownAction": "stopCompose",
+{{- else}}
+  "image": "{{.Vars.image}}",
+{{- end}}
+  "features": {
+    "ghcr.io/devcontainers/features/docker-outside-of-docker:1": {}
+  },
+{{- if .Vars.port}}
+  "forwardPorts": [{{.Vars.port}}],
+{{- end}}
+  "postCreateCommand": "go install github.com/night-slayer18/goforge@latest && go mod download",
+  "customizations": {
+    "vscode": {
+      "extensions": [
+{{- range $i, $extension := .Vars.extensions}}{{if $i}},{{end}}
+        "{{$extension}}"
+{{- end}}
+      ],
+      "settings": {
+        "go.toolsManagement.autoUpdate": true,
+        "go.useLanguageServer": true
+      }
+    }
+  }
+}
@@ -0,0 +1,77 @@
+{{- if .Vars.compose -}}
+# Services of the {{.ProjectName}} dev container generated by GoForge; the
+# editor runs in 'workspace'
+services:
+  workspace:
+    image: {{.Vars.image}}
+    command: sleep infinity
+    volumes:
+      - ..:/workspaces/{{.ProjectName}}:cached
+      - go-modules:/go/pkg/mod
+    environment:
+{{- if .Vars.database}}
+      DATABASE_HOST: "db"
+{{- end}}
+{{- if eq .Vars.database "postgres"}}
+      DATABASE_PORT: "5432"
+      DATABASE_USER: "postgres"
+      DATABASE_PASSWORD: "password"
+      DATABASE_DBNAME: "{{.ProjectName}}_db"
+{{- else if eq .Vars.database "mysql"}}
+      DATABASE_PORT: "3306"
+      DATABASE_USER: "app"
+      DATABASE_PASSWORD: "password"
+      DATABASE_DBNAME: "{{.ProjectName}}_db"
+{{- else if eq .Vars.database "mongodb"}}
+      DATABASE_PORT: "27017"
+      DATABASE_USER: "root"
+      DATABASE_PASSWORD: "password"
+      DATABASE_DBNAME: "{{.ProjectName}}_db"
+{{- end}}
+{{- if .Vars.redis}}
+      REDIS_ADDR: "redis:6379"
+{{- end}}
+{{- if eq .Vars.database "postgres"}}
+
+  db:
+    image: postgres:16-alpine
+    environment:
+      POSTGRES_USER: "postgres"
+      POSTGRES_PASSWORD: "password"
+      POSTGRES_DB: "{{.ProjectName}}_db"
+    volumes:
+      - db-data:/var/lib/postgresql/data
+{{- else if eq .Vars.database "mysql"}}
+
+  db:
+    image: mysql:8.4
+    environment:
+      MYSQL_DATABASE: "{{.ProjectName}}_db"
+      MYSQL_USER: "app"
+      MYSQL_PASSWORD: "password"
+      MYSQL_ROOT_PASSWORD: "password"
+    volumes:
+      - db-data:/var/lib/mysql
+{{- else if eq .Vars.database "mongodb"}}
+
+  db:
+    image: mongo:7
+    environment:
+      MONGO_INITDB_ROOT_USERNAME: "root"
+      MONGO_INITDB_ROOT_PASSWORD: "password"
+      MONGO_INITDB_DATABASE: "{{.ProjectName}}_db"
+    volumes:
+      - db-data:/data/db
+{{- end}}
+{{- if .Vars.redis}}
+
+  redis:
+    image: redis:7-alpine
+{{- end}}
+
+volumes:
+{{- if .Vars.database}}
+  db-data:
+{{- end}}
+  go-modules:
+{{- end}}
@@ -0,0 +1,7 @@
+{
+  "recommendations": [
+{{- range $i, $extension := .Vars.extensions}}{{if $i}},{{end}}
+    "{{$extension}}"
+{{- end}}
+  ]
+}
@@ -0,0 +1,41 @@
+# syntax=docker/dockerfile:1
+# Multi-stage image of {{.ProjectName}} generated by GoForge: the build stage
+# compiles a static binary, the runtime stage ships only the binary and its
+# assets.
+
+# --- Build stage ---
+FROM {{.Vars.baseImage}} AS build
+WORKDIR /src
+
+# Download the modules before copying the sources, so that the layer stays
+# cached until go.mod or go.sum change
+COPY go.mod go.sum* ./
+RUN --mount=type=cache,target=/go/pkg/mod go mod download
+
+COPY . .
+RUN --mount=type=cache,target=/go/pkg/mod \
+    --mount=type=cache,target=/root/.cache/go-build \
+    CGO_ENABLED=0 go build -trimpath -ldflags="-s -w"{{if .Vars.tags}} -tags {{.Vars.tags}}{{end}} -o /out/{{.Vars.binary}} {{.Vars.main}}
+
+# --- Runtime stage ---
+FROM {{.Vars.runtimeImage}}
+{{- if .Vars.alpine}}
+RUN apk add --no-cache ca-certificates tzdata
+{{- end}}
+WORKDIR /app
+
+COPY --from=build /out/{{.Vars.binary}} ./{{.Vars.binary}}
+{{- range .Vars.assets}}
+COPY {{.}} ./{{.}}
+{{- end}}
+{{- if .Vars.env}}
+{{range $key, $value := .Vars.env}}
+ENV {{$key}}={{printf "%q" $value}}
+{{- end}}
+{{- end}}
+{{- if .Vars.port}}
+
+EXPOSE {{.Vars.port}}
+{{- end}}
+
+ENTRYPOINT ["./{{.Vars.binary}}"]
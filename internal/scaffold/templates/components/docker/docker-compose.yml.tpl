@@ -0,0 +1,140 @@
+{{- define "environment"}}
+{{- if or .Vars.env .Vars.database .Vars.redis}}
+    environment:
+{{- range $key, $value := .Vars.env}}
+      {{$key}}: {{printf "%q" $value}}
+{{- end}}
+{{- if .Vars.database}}
+      DATABASE_HOST: "db"
+{{- end}}
+{{- if eq .Vars.database "postgres"}}
+      DATABASE_PORT: "5432"
+      DATABASE_USER: "postgres"
+      DATABASE_PASSWORD: "password"
+      DATABASE_DBNAME: "{{.ProjectName}}_db"
+{{- else if eq .Vars.database "mysql"}}
+      DATABASE_PORT: "3306"
+      DATABASE_USER: "app"
+      DATABASE_PASSWORD: "password"
+      DATABASE_DBNAME: "{{.ProjectName}}_db"
+{{- else if eq .Vars.database "mongodb"}}
+      DATABASE_PORT: "27017"
+      DATABASE_USER: "root"
+      DATABASE_PASSWORD: "password"
+      DATABASE_DBNAME: "{{.ProjectName}}_db"
+{{- end}}
+{{- if .Vars.redis}}
+      REDIS_ADDR: "redis:6379"
+{{- end}}
+{{- end}}
+{{- if or .Vars.database .Vars.redis}}
+    depends_on:
+{{- if .Vars.database}}
+      db:
+        condition: service_healthy
+{{- end}}
+{{- if .Vars.redis}}
+      redis:
+        condition: service_healthy
+{{- end}}
+{{- end}}
+{{- end -}}
+# Services of {{.ProjectName}} generated by GoForge:
+#   docker compose up --build              runs the image of the Dockerfile
+#   docker compose --profile dev up dev    runs the sources with hot reload
+services:
+  app:
+    build: .
+{{- if .Vars.port}}
+    ports:
+      - "{{.Vars.port}}:{{.Vars.port}}"
+{{- end}}
+{{- template "environment" .}}
+
+  # Rebuilds and restarts on every change through 'goforge watch'
+  dev:
+    image: golang:{{.Vars.goVersion}}
+    profiles: [dev]
+    working_dir: /src
+    command: sh -c "go install github.com/night-slayer18/goforge@latest && goforge watch dev"
+{{- if .Vars.port}}
+    ports:
+      - "{{.Vars.port}}:{{.Vars.port}}"
+{{- end}}
+    volumes:
+      - .:/src
+      - go-modules:/go/pkg/mod
+      - go-build:/root/.cache/go-build
+{{- template "environment" .}}
+{{- if eq .Vars.database "postgres"}}
+
+  db:
+    image: postgres:16-alpine
+    environment:
+      POSTGRES_USER: "postgres"
+      POSTGRES_PASSWORD: "password"
+      POSTGRES_DB: "{{.ProjectName}}_db"
+    ports:
+      - "5432:5432"
+    volumes:
+      - db-data:/var/lib/postgresql/data
+    healthcheck:
+      test: ["CMD-SHELL", "pg_isready -U postgres -d {{.ProjectName}}_db"]
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- else if eq .Vars.database "mysql"}}
+
+  db:
+    image: mysql:8.4
+    environment:
+      MYSQL_DATABASE: "{{.ProjectName}}_db"
+      MYSQL_USER: "app"
+      MYSQL_PASSWORD: "password"
+      MYSQL_ROOT_PASSWORD: "password"
+    ports:
+      - "3306:3306"
+    volumes:
+      - db-data:/var/lib/mysql
+    healthcheck:
+      test: ["CMD", "mysqladmin", "ping", "-h", "localhost"]
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- else if eq .Vars.database "mongodb"}}
+
+  db:
+    image: mongo:7
+    environment:
+      MONGO_INITDB_ROOT_USERNAME: "root"
+      MONGO_INITDB_ROOT_PASSWORD: "password"
+      MONGO_INITDB_DATABASE: "{{.ProjectName}}_db"
+    ports:
+      - "27017:27017"
+    volumes:
+      - db-data:/data/db
+    healthcheck:
+      test: ["CMD", "mongosh", "--quiet", "--eval", "db.adminCommand('ping')"]
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- end}}
+{{- if .Vars.redis}}
+
+  redis:
+    image: redis:7-alpine
+    ports:
+      - "6379:6379"
+    healthcheck:
+      test: ["CMD", "redis-cli", "ping"]
+      interval: 5s
+      timeout: 5s
+      retries: 10
+{{- end}}
+
+volumes:
+{{- if .Vars.database}}
+  db-data:
+{{- end}}
+  go-modules:
+  go-build:
@@ -0,0 +1,20 @@
+# Keeps the Docker build context small: only the sources and assets are
+# needed to build the image.
+.git
+.github
+.circleci
+.gitlab-ci.yml
+.goforge
+.idea
+.vscode
+.env
+.env.*
+dist
+tmp
+{{.Vars.binary}}
+*.test
+*.out
+cover.html
+Dockerfile
+.dockerignore
+docker-compose.yml
@@ -0,0 +1,72 @@
+# syntax=docker/dockerfile:1
+# Multi-stage image of {{.ProjectName}} generated by GoForge from goforge.yml:
+#   goforge g dockerfile --base {{.Vars.base}}
+# The build stage compiles {{if gt (len .Vars.entrypoints) 1}}the entrypoints{{else}}the binary{{end}} with Go {{.Vars.goVersion}}; the runtime stage
+# ships only {{if gt (len .Vars.entrypoints) 1}}them{{else}}it{{end}} and the assets, running as a non-root user.
+#
+#   docker build --build-arg VERSION=$(git describe --tags --always) -t {{.ProjectName}} .
+
+# --- Build stage ---
+{{- if .Vars.cgo}}
+FROM {{.Vars.builderImage}} AS build
+{{- else}}
+# Runs on the build machine's platform and cross-compiles for the target,
+# so multi-platform builds need no emulation
+FROM --platform=$BUILDPLATFORM {{.Vars.builderImage}} AS build
+ARG TARGETOS TARGETARCH
+{{- end}}
+WORKDIR /src
+
+# Download the modules before copying the sources, so that the layer stays
+# cached until go.mod or go.sum change
+COPY go.mod go.sum* ./
+RUN --mount=type=cache,target=/go/pkg/mod go mod download
+
+COPY . .
+ARG VERSION=dev
+ARG COMMIT=none
+ARG BUILD_DATE=unknown
+{{- range .Vars.entrypoints}}
+RUN --mount=type=cache,target=/go/pkg/mod \
+    --mount=type=cache,target=/root/.cache/go-build \
+    {{if $.Vars.cgo}}CGO_ENABLED=1{{else}}CGO_ENABLED=0 GOOS=$TARGETOS GOARCH=$TARGETARCH{{end}} \
+    go build -trimpath -ldflags="-s -w{{if $.Vars.ldflags}} {{$.Vars.ldflags}}{{end}}"{{if $.Vars.tags}} -tags {{$.Vars.tags}}{{end}} -o /out/{{.Binary}} {{.Package}}
+{{- end}}
+
+# --- Runtime stage ---
+FROM {{.Vars.runtimeImage}}
+{{- if eq .Vars.base "alpine"}}
+RUN apk add --no-cache ca-certificates tzdata \
+    && addgroup -S app && adduser -S -H -G app app
+{{- else if eq .Vars.base "debian"}}
+RUN apt-get update \
+    && apt-get install -y --no-install-recommends ca-certificates tzdata \
+    && rm -rf /var/lib/apt/lists/* \
+    && useradd --system --no-create-home --user-group app
+{{- end}}
+WORKDIR /app
+
+# Owned by root, so the application cannot change its own files
+COPY --from=build /out/ ./
+{{- range .Vars.assets}}
+COPY {{.}} ./{{.}}
+{{- end}}
+{{- if .Vars.env}}
+{{range $key, $value := .Vars.env}}
+ENV {{$key}}={{printf "%q" $value}}
+{{- end}}
+{{- end}}
+{{- if .Vars.port}}
+
+EXPOSE {{.Vars.port}}
+{{- end}}
+
+{{- if eq .Vars.base "distroless"}}
+
+# The nonroot user of the distroless image, uid 65532
+USER nonroot:nonroot
+{{- else}}
+
+USER app:app
+{{- end}}
+ENTRYPOINT ["/app/{{(index .Vars.entrypoints 0).Binary}}"]
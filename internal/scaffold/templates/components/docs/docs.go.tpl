@@ -0,0 +1,27 @@
+package {{.PackageName}}
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// spec is the OpenAPI document; regenerate it with 'goforge docs openapi --ui'.
+//
+//go:embed openapi.json
+var spec []byte
+
+// page shows the spec in Swagger UI, loaded from a CDN.
+const page = `{{.Vars.page}}`
+
+// RegisterRoutes serves Swagger UI at /docs and the spec at
+// /docs/openapi.json.
+func RegisterRoutes(router gin.IRouter) {
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte(page))
+	})
+	router.GET("/docs/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", spec)
+	})
+}
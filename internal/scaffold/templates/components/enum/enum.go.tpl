@@ -0,0 +1,85 @@
+{{- $type := .NameTitle -}}
+{{- $lower := toLowerCamel .Name -}}
+package {{.PackageName}}
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// {{$type}} is the {{.Vars.label}} enum, generated by 'goforge g enum {{.Name}}'.
+// The zero value is not a valid {{.Vars.label}}, so unset values are caught
+// by IsValid and when marshaling.
+type {{$type}} int
+
+// {{$type}} values.
+const (
+{{- range $i, $value := .Vars.values}}
+	{{$value.Const}}{{if eq $i 0}} {{$type}} = iota + 1{{end}}
+{{- end}}
+)
+
+var {{$lower}}Names = map[{{$type}}]string{
+{{- range .Vars.values}}
+	{{.Const}}: "{{.Name}}",
+{{- end}}
+}
+
+var {{$lower}}Values = map[string]{{$type}}{
+{{- range .Vars.values}}
+	"{{.Name}}": {{.Const}},
+{{- end}}
+}
+
+// {{$type}}Values returns every {{.Vars.label}} in declaration order.
+func {{$type}}Values() []{{$type}} {
+	return []{{$type}}{ {{- range $i, $value := .Vars.values}}{{if $i}}, {{end}}{{$value.Const}}{{end -}} }
+}
+
+// Parse{{$type}} returns the {{.Vars.label}} named name, ignoring case.
+func Parse{{$type}}(name string) ({{$type}}, error) {
+	if value, ok := {{$lower}}Values[strings.ToLower(name)]; ok {
+		return value, nil
+	}
+	return 0, fmt.Errorf("invalid {{.Vars.label}} %q (valid values: {{.Vars.list}})", name)
+}
+
+// IsValid reports whether s is one of the declared values.
+func (s {{$type}}) IsValid() bool {
+	_, ok := {{$lower}}Names[s]
+	return ok
+}
+
+// String returns the value's name, or {{$type}}(n) for undeclared values.
+func (s {{$type}}) String() string {
+	if name, ok := {{$lower}}Names[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("{{$type}}(%d)", int(s))
+}
+
+// MarshalJSON encodes the value as its name.
+func (s {{$type}}) MarshalJSON() ([]byte, error) {
+	if !s.IsValid() {
+		return nil, fmt.Errorf("cannot marshal invalid {{.Vars.label}} %d", int(s))
+	}
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a value from its name; null leaves it unchanged.
+func (s *{{$type}}) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("{{.Vars.label}} must be a string: %w", err)
+	}
+	value, err := Parse{{$type}}(name)
+	if err != nil {
+		return err
+	}
+	*s = value
+	return nil
+}
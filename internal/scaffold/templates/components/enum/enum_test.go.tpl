@@ -0,0 +1,66 @@
+{{- $type := .NameTitle -}}
+package {{.PackageName}}
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func Test{{$type}}RoundTrip(t *testing.T) {
+	for _, value := range {{$type}}Values() {
+		if !value.IsValid() {
+			t.Errorf("%v is not valid", value)
+		}
+
+		parsed, err := Parse{{$type}}(value.String())
+		if err != nil || parsed != value {
+			t.Errorf("Parse{{$type}}(%q) = %v, %v; want %v", value.String(), parsed, err, value)
+		}
+
+		data, err := json.Marshal(value)
+		if err != nil {
+			t.Fatalf("marshal %v: %v", value, err)
+		}
+		var decoded {{$type}}
+		if err := json.Unmarshal(data, &decoded); err != nil || decoded != value {
+			t.Errorf("unmarshal %s = %v, %v; want %v", data, decoded, err, value)
+		}
+	}
+}
+
+func Test{{$type}}String(t *testing.T) {
+	tests := []struct {
+		value {{$type}}
+		want  string
+	}{
+{{- range .Vars.values}}
+		{ {{- .Const}}, "{{.Name}}"},
+{{- end}}
+		{0, "{{$type}}(0)"},
+	}
+	for _, tt := range tests {
+		if got := tt.value.String(); got != tt.want {
+			t.Errorf("%d.String() = %q, want %q", int(tt.value), got, tt.want)
+		}
+	}
+}
+
+func Test{{$type}}Invalid(t *testing.T) {
+	var zero {{$type}}
+	if zero.IsValid() {
+		t.Error("the zero value is valid")
+	}
+	if _, err := json.Marshal(zero); err == nil {
+		t.Error("marshaling the zero value succeeded")
+	}
+	if _, err := Parse{{$type}}("not-a-{{.Name}}"); err == nil {
+		t.Error("parsing an unknown name succeeded")
+	}
+
+	var decoded {{$type}}
+	for _, input := range []string{`"not-a-{{.Name}}"`, `1`, `""`} {
+		if err := json.Unmarshal([]byte(input), &decoded); err == nil {
+			t.Errorf("unmarshaling %s succeeded", input)
+		}
+	}
+}
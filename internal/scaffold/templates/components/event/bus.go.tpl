@@ -0,0 +1,93 @@
+// Package {{.PackageName}} is an in-process event bus. Publishers and
+// listeners only share the event types, so features can react to each
+// other without depending on each other.
+package {{.PackageName}}
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+)
+
+// Event is something that happened in the application.
+type Event interface {
+	// EventName identifies the kind of event, e.g. "user.registered".
+	EventName() string
+}
+
+// Handler reacts to an event.
+type Handler func(ctx context.Context, event Event) error
+
+// Bus delivers published events to the handlers subscribed to their name.
+type Bus struct {
+	logger *slog.Logger
+
+	mu       sync.RWMutex
+	handlers map[string][]Handler
+
+	pending sync.WaitGroup
+}
+
+// New creates a bus that logs asynchronous delivery failures to logger
+// (slog.Default() if nil).
+func New(logger *slog.Logger) *Bus {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &Bus{logger: logger, handlers: make(map[string][]Handler)}
+}
+
+// Subscribe registers handler for the events called name.
+func (b *Bus) Subscribe(name string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[name] = append(b.handlers[name], handler)
+}
+
+// Publish delivers event to its handlers in subscription order and
+// returns their errors joined. A failing or panicking handler does not
+// stop the others.
+func (b *Bus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	handlers := b.handlers[event.EventName()]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, handler := range handlers {
+		if err := deliver(ctx, handler, event); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// PublishAsync delivers event in the background and logs handler errors,
+// for publishers that must not wait for listeners. Delivery outlives the
+// cancellation of ctx; call Wait before shutting down.
+func (b *Bus) PublishAsync(ctx context.Context, event Event) {
+	ctx = context.WithoutCancel(ctx)
+	b.pending.Add(1)
+	go func() {
+		defer b.pending.Done()
+		if err := b.Publish(ctx, event); err != nil {
+			b.logger.Error("event delivery failed", "event", event.EventName(), "error", err)
+		}
+	}()
+}
+
+// Wait blocks until every asynchronous delivery has finished.
+func (b *Bus) Wait() {
+	b.pending.Wait()
+}
+
+// deliver calls one handler, turning a panic into an error.
+func deliver(ctx context.Context, handler Handler, event Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%s listener panicked: %v", event.EventName(), recovered)
+		}
+	}()
+	return handler(ctx, event)
+}
@@ -0,0 +1,22 @@
+package {{.PackageName}}
+
+import (
+	"time"
+)
+
+// {{.NameTitle}}Name identifies {{.NameTitle}} events on the bus.
+const {{.NameTitle}}Name = "{{.Vars.eventName}}"
+
+// {{.NameTitle}} is published when {{.Vars.eventName}} happens.
+type {{.NameTitle}} struct {
+	OccurredAt time.Time
+
+	// TODO: Add the data listeners need
+	// Example:
+	// UserID string
+}
+
+// EventName identifies the event on the bus.
+func (e {{.NameTitle}}) EventName() string {
+	return {{.NameTitle}}Name
+}
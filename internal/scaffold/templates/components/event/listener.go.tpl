@@ -0,0 +1,34 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+
+	"{{.Vars.busImport}}"
+)
+
+// {{.Vars.listenerTitle}}Listener reacts to {{.NameTitle}} events.
+type {{.Vars.listenerTitle}}Listener struct {
+	// TODO: Add any dependencies here
+	// Example:
+	// mailer ports.Mailer
+}
+
+// New{{.Vars.listenerTitle}}Listener creates a new {{.Vars.listenerTitle}}Listener.
+func New{{.Vars.listenerTitle}}Listener( /* dependencies */ ) *{{.Vars.listenerTitle}}Listener {
+	return &{{.Vars.listenerTitle}}Listener{}
+}
+
+// Handle processes one {{.NameTitle}} event.
+func (l *{{.Vars.listenerTitle}}Listener) Handle(ctx context.Context, event {{.Vars.busPackage}}.Event) error {
+	e, ok := event.({{.NameTitle}})
+	if !ok {
+		return fmt.Errorf("{{.Vars.listenerTitle}}Listener: unexpected event %T", event)
+	}
+
+	// goforge:keep handle
+	// TODO: React to the event
+	_ = e
+	return nil
+	// goforge:end
+}
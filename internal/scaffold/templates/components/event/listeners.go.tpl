@@ -0,0 +1,13 @@
+// Package {{.PackageName}} defines the application's events and the
+// listeners reacting to them.
+package {{.PackageName}}
+
+import (
+	"{{.Vars.busImport}}"
+)
+
+// Register subscribes every listener to bus. 'goforge generate event'
+// adds new listeners above the goforge:listeners marker; keep it in place.
+func Register(bus *{{.Vars.busPackage}}.Bus) {
+	// goforge:listeners
+}
@@ -0,0 +1,47 @@
+{{- $type := .NameTitle -}}
+{{- $var := .Vars.variable -}}
+package {{.PackageName}}
+
+import (
+{{- if .Vars.usesFmt}}
+	"fmt"
+{{- end}}
+{{- range .Vars.imports}}
+	"{{.}}"
+{{- end}}
+
+	domain "{{.Imports.model}}"
+)
+
+// {{$type}}Builder builds domain.{{$type}} values for tests. It starts from
+// valid defaults, unique per builder, so tests only set the fields they
+// are about.
+type {{$type}}Builder struct {
+	{{$var}} domain.{{$type}}
+}
+
+// New{{$type}} returns a builder for a valid {{$type}}.
+func New{{$type}}() *{{$type}}Builder {
+{{- if .Vars.usesSequence}}
+	n := Sequence()
+{{- end}}
+	return &{{$type}}Builder{ {{- $var}}: domain.{{$type}}{
+{{- range .Vars.fields}}
+{{- if .Default}}
+		{{.Name}}: {{.Default}},
+{{- end}}
+{{- end}}
+	}}
+}
+{{range .Vars.fields}}
+// With{{.Name}} sets {{.Name}}.
+func (b *{{$type}}Builder) With{{.Name}}({{.Param}} {{.Type}}) *{{$type}}Builder {
+	b.{{$var}}.{{.Name}} = {{.Param}}
+	return b
+}
+{{end}}
+// Build returns a new {{$type}} with the values set so far.
+func (b *{{$type}}Builder) Build() *domain.{{$type}} {
+	{{$var}} := b.{{$var}}
+	return &{{$var}}
+}
@@ -0,0 +1,19 @@
+// Package {{.PackageName}} holds test helpers, such as the fixture builders
+// 'goforge generate fixture' creates.
+package {{.PackageName}}
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var sequence atomic.Int64
+
+// Sequence returns a number unique within the test binary, for values
+// that must not collide, such as IDs and emails.
+func Sequence() int64 {
+	return sequence.Add(1)
+}
+
+// Now is the time fixtures use, so tests do not depend on the clock.
+var Now = time.Date(2025, time.January, 1, 12, 0, 0, 0, time.UTC)
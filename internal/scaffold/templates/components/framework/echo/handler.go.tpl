@@ -0,0 +1,40 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	// service "{{.Imports.service}}" // TODO: Uncomment when service is created and wired up.
+)
+
+// {{.NameTitle}}Handler handles HTTP requests related to the {{.Name}} resource.
+type {{.NameTitle}}Handler struct {
+	// service *service.{{.NameTitle}}Service // TODO: Add service dependency.
+}
+
+// New{{.NameTitle}}Handler creates a new {{.NameTitle}}Handler.
+func New{{.NameTitle}}Handler(/* s *service.{{.NameTitle}}Service */) *{{.NameTitle}}Handler { // TODO: Inject service.
+	return &{{.NameTitle}}Handler{
+		// service: s,
+	}
+}
+
+// HandleSomething is an example handler method.
+// TODO: Rename and implement your handler logic, and update the annotations
+// 'goforge docs openapi' builds the API spec from.
+//
+//	@Summary	Example {{.Name}} endpoint
+//	@Tags		{{.Name | pluralize}}
+//	@Produce	json
+//	@Success	200	{object}	map[string]string
+//	@Router		/{{.Name | pluralize}} [get]
+func (h *{{.NameTitle}}Handler) HandleSomething(c echo.Context) error {
+	// 1. Parse request from c.Param, c.QueryParam, or c.Bind.
+	// 2. Call the service.
+	// 3. Write response.
+	return c.JSON(http.StatusOK, map[string]string{"message": "{{.NameTitle}} handler called"})
+}
+
+// goforge:keep custom
+// Code between these markers is preserved when this file is regenerated.
+// goforge:end
@@ -0,0 +1,81 @@
+package {{.PackageName}}
+
+import (
+{{- if eq .Vars.logger "slog"}}
+	"log/slog"
+{{- else if not (eq .Vars.logger "zap" "zerolog")}}
+	"log"
+{{- end}}
+	"time"
+
+	"github.com/labstack/echo/v4"
+{{- if eq .Vars.logger "zap"}}
+	"go.uber.org/zap"
+{{- else if eq .Vars.logger "zerolog"}}
+	"github.com/rs/zerolog/log"
+{{- end}}
+)
+
+// {{.NameTitle}}Middleware provides {{.Name}} functionality.
+type {{.NameTitle}}Middleware struct {
+	// TODO: Add any dependencies here
+	// Example:
+	// authService *service.AuthService
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware( /* dependencies */ ) *{{.NameTitle}}Middleware {
+	return &{{.NameTitle}}Middleware{
+		// Initialize dependencies
+	}
+}
+
+// Handler returns the Echo middleware function.
+func (m *{{.NameTitle}}Middleware) Handler() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+
+			// TODO: Implement your middleware logic here
+			// Example for authentication middleware:
+			// token := c.Request().Header.Get("Authorization")
+			// if token == "" {
+			//     return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Authorization header required"})
+			// }
+
+{{- if eq .Vars.logger "slog"}}
+
+			slog.Info("{{.NameTitle}} middleware processing request", "method", c.Request().Method, "path", c.Request().URL.Path)
+{{- else if eq .Vars.logger "zap"}}
+
+			zap.L().Info("{{.NameTitle}} middleware processing request", zap.String("method", c.Request().Method), zap.String("path", c.Request().URL.Path))
+{{- else if eq .Vars.logger "zerolog"}}
+
+			log.Info().Str("method", c.Request().Method).Str("path", c.Request().URL.Path).Msg("{{.NameTitle}} middleware processing request")
+{{- else}}
+
+			log.Printf("{{.NameTitle}} middleware processing request: %s %s", c.Request().Method, c.Request().URL.Path)
+{{- end}}
+
+			// Continue to next handler
+			err := next(c)
+
+			// Post-processing logic (optional)
+{{- if eq .Vars.logger "slog"}}
+			slog.Info("{{.NameTitle}} middleware completed", "duration", time.Since(start))
+{{- else if eq .Vars.logger "zap"}}
+			zap.L().Info("{{.NameTitle}} middleware completed", zap.Duration("duration", time.Since(start)))
+{{- else if eq .Vars.logger "zerolog"}}
+			log.Info().Dur("duration", time.Since(start)).Msg("{{.NameTitle}} middleware completed")
+{{- else}}
+			log.Printf("{{.NameTitle}} middleware completed in %v", time.Since(start))
+{{- end}}
+			return err
+		}
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a route group.
+func (m *{{.NameTitle}}Middleware) Apply(g *echo.Group) {
+	g.Use(m.Handler())
+}
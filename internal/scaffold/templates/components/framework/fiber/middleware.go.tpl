@@ -0,0 +1,78 @@
+package {{.PackageName}}
+
+import (
+{{- if eq .Vars.logger "slog"}}
+	"log/slog"
+{{- else if not (eq .Vars.logger "zap" "zerolog")}}
+	"log"
+{{- end}}
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+{{- if eq .Vars.logger "zap"}}
+	"go.uber.org/zap"
+{{- else if eq .Vars.logger "zerolog"}}
+	"github.com/rs/zerolog/log"
+{{- end}}
+)
+
+// {{.NameTitle}}Middleware provides {{.Name}} functionality.
+type {{.NameTitle}}Middleware struct {
+	// TODO: Add any dependencies here
+	// Example:
+	// authService *service.AuthService
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware( /* dependencies */ ) *{{.NameTitle}}Middleware {
+	return &{{.NameTitle}}Middleware{
+		// Initialize dependencies
+	}
+}
+
+// Handler returns the Fiber middleware handler.
+func (m *{{.NameTitle}}Middleware) Handler() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+
+		// TODO: Implement your middleware logic here
+		// Example for authentication middleware:
+		// if c.Get("Authorization") == "" {
+		//     return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{"error": "Authorization header required"})
+		// }
+
+{{- if eq .Vars.logger "slog"}}
+
+		slog.Info("{{.NameTitle}} middleware processing request", "method", c.Method(), "path", c.Path())
+{{- else if eq .Vars.logger "zap"}}
+
+		zap.L().Info("{{.NameTitle}} middleware processing request", zap.String("method", c.Method()), zap.String("path", c.Path()))
+{{- else if eq .Vars.logger "zerolog"}}
+
+		log.Info().Str("method", c.Method()).Str("path", c.Path()).Msg("{{.NameTitle}} middleware processing request")
+{{- else}}
+
+		log.Printf("{{.NameTitle}} middleware processing request: %s %s", c.Method(), c.Path())
+{{- end}}
+
+		// Continue to next handler
+		err := c.Next()
+
+		// Post-processing logic (optional)
+{{- if eq .Vars.logger "slog"}}
+		slog.Info("{{.NameTitle}} middleware completed", "duration", time.Since(start))
+{{- else if eq .Vars.logger "zap"}}
+		zap.L().Info("{{.NameTitle}} middleware completed", zap.Duration("duration", time.Since(start)))
+{{- else if eq .Vars.logger "zerolog"}}
+		log.Info().Dur("duration", time.Since(start)).Msg("{{.NameTitle}} middleware completed")
+{{- else}}
+		log.Printf("{{.NameTitle}} middleware completed in %v", time.Since(start))
+{{- end}}
+		return err
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a router or group.
+func (m *{{.NameTitle}}Middleware) Apply(router fiber.Router) {
+	router.Use(m.Handler())
+}
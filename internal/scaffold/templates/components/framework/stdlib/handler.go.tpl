@@ -0,0 +1,41 @@
+package {{.PackageName}}
+
+import (
+	"encoding/json"
+	"net/http"
+	// service "{{.Imports.service}}" // TODO: Uncomment when service is created and wired up.
+)
+
+// {{.NameTitle}}Handler handles HTTP requests related to the {{.Name}} resource.
+type {{.NameTitle}}Handler struct {
+	// service *service.{{.NameTitle}}Service // TODO: Add service dependency.
+}
+
+// New{{.NameTitle}}Handler creates a new {{.NameTitle}}Handler.
+func New{{.NameTitle}}Handler(/* s *service.{{.NameTitle}}Service */) *{{.NameTitle}}Handler { // TODO: Inject service.
+	return &{{.NameTitle}}Handler{
+		// service: s,
+	}
+}
+
+// HandleSomething is an example handler method.
+// TODO: Rename and implement your handler logic, and update the annotations
+// 'goforge docs openapi' builds the API spec from.
+//
+//	@Summary	Example {{.Name}} endpoint
+//	@Tags		{{.Name | pluralize}}
+//	@Produce	json
+//	@Success	200	{object}	map[string]string
+//	@Router		/{{.Name | pluralize}} [get]
+func (h *{{.NameTitle}}Handler) HandleSomething(w http.ResponseWriter, r *http.Request) {
+	// 1. Parse request from r.PathValue("id"), r.URL.Query(), or json.NewDecoder(r.Body).
+	// 2. Call the service.
+	// 3. Write response.
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(map[string]string{"message": "{{.NameTitle}} handler called"})
+}
+
+// goforge:keep custom
+// Code between these markers is preserved when this file is regenerated.
+// goforge:end
@@ -0,0 +1,81 @@
+package {{.PackageName}}
+
+import (
+{{- if eq .Vars.logger "slog"}}
+	"log/slog"
+{{- else if not (eq .Vars.logger "zap" "zerolog")}}
+	"log"
+{{- end}}
+	"net/http"
+	"time"
+{{- if eq .Vars.logger "zap"}}
+
+	"go.uber.org/zap"
+{{- else if eq .Vars.logger "zerolog"}}
+
+	"github.com/rs/zerolog/log"
+{{- end}}
+)
+
+// {{.NameTitle}}Middleware provides {{.Name}} functionality.
+type {{.NameTitle}}Middleware struct {
+	// TODO: Add any dependencies here
+	// Example:
+	// authService *service.AuthService
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware( /* dependencies */ ) *{{.NameTitle}}Middleware {
+	return &{{.NameTitle}}Middleware{
+		// Initialize dependencies
+	}
+}
+
+// Handler wraps next with the middleware, in the func(http.Handler)
+// http.Handler shape net/http middleware share.
+func (m *{{.NameTitle}}Middleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		// TODO: Implement your middleware logic here
+		// Example for authentication middleware:
+		// if r.Header.Get("Authorization") == "" {
+		//     http.Error(w, "Authorization header required", http.StatusUnauthorized)
+		//     return
+		// }
+
+{{- if eq .Vars.logger "slog"}}
+
+		slog.Info("{{.NameTitle}} middleware processing request", "method", r.Method, "path", r.URL.Path)
+{{- else if eq .Vars.logger "zap"}}
+
+		zap.L().Info("{{.NameTitle}} middleware processing request", zap.String("method", r.Method), zap.String("path", r.URL.Path))
+{{- else if eq .Vars.logger "zerolog"}}
+
+		log.Info().Str("method", r.Method).Str("path", r.URL.Path).Msg("{{.NameTitle}} middleware processing request")
+{{- else}}
+
+		log.Printf("{{.NameTitle}} middleware processing request: %s %s", r.Method, r.URL.Path)
+{{- end}}
+
+		// Continue to next handler
+		next.ServeHTTP(w, r)
+
+		// Post-processing logic (optional)
+{{- if eq .Vars.logger "slog"}}
+		slog.Info("{{.NameTitle}} middleware completed", "duration", time.Since(start))
+{{- else if eq .Vars.logger "zap"}}
+		zap.L().Info("{{.NameTitle}} middleware completed", zap.Duration("duration", time.Since(start)))
+{{- else if eq .Vars.logger "zerolog"}}
+		log.Info().Dur("duration", time.Since(start)).Msg("{{.NameTitle}} middleware completed")
+{{- else}}
+		log.Printf("{{.NameTitle}} middleware completed in %v", time.Since(start))
+{{- end}}
+	})
+}
+
+// Apply is a convenience method wrapping a whole mux, e.g.
+// server.Handler = m.Apply(mux).
+func (m *{{.NameTitle}}Middleware) Apply(h http.Handler) http.Handler {
+	return m.Handler(h)
+}
@@ -0,0 +1,37 @@
+# gqlgen configuration (https://gqlgen.com/config/), created by goforge.
+# Regenerate the GraphQL code after editing the schema with:
+#   goforge run graphql:generate
+schema:
+  - {{.Vars.schemaDir}}/*.graphqls
+
+# Generated executable schema
+exec:
+  package: generated
+  layout: single-file
+  filename: {{.Vars.generatedDir}}/generated.go
+
+# Generated models for types not bound to existing Go types
+model:
+  package: model
+  filename: {{.Vars.modelDir}}/models_gen.go
+
+# Resolvers, one file per schema file; implementations are kept when
+# the code is regenerated
+resolver:
+  package: {{.Vars.resolverPackage}}
+  layout: follow-schema
+  dir: {{.Vars.resolverDir}}
+  filename_template: "{name}.resolvers.go"
+
+# Bind GraphQL types to existing Go types with the same name, e.g. the
+# domain models:
+# autobind:
+#   - "{{.ModulePath}}/internal/domain"
+
+models:
+  ID:
+    model:
+      - github.com/99designs/gqlgen/graphql.ID
+      - github.com/99designs/gqlgen/graphql.Int
+      - github.com/99designs/gqlgen/graphql.Int64
+      - github.com/99designs/gqlgen/graphql.Int32
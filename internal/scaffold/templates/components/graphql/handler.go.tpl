@@ -0,0 +1,35 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+
+	"{{.Vars.generatedImport}}"
+)
+
+// NewHandler serves GraphQL queries and mutations over HTTP GET and POST,
+// caching parsed queries and supporting introspection and automatic
+// persisted queries.
+func NewHandler(resolver *Resolver) http.Handler {
+	srv := handler.New(generated.NewExecutableSchema(generated.Config{Resolvers: resolver}))
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{Cache: lru.New[string](100)})
+	return srv
+}
+
+// PlaygroundHandler serves the GraphQL playground, sending its queries to
+// endpoint.
+func PlaygroundHandler(endpoint string) http.Handler {
+	return playground.Handler("GraphQL playground", endpoint)
+}
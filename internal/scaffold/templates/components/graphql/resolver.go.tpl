@@ -0,0 +1,12 @@
+package {{.PackageName}}
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+// Resolver is the root resolver. gqlgen generates the code calling it from
+// the schema; the services the resolvers use are its fields, set where the
+// handler is created.
+type Resolver struct {
+	// goforge:resolvers
+}
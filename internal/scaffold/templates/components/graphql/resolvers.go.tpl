@@ -0,0 +1,53 @@
+{{- $field := toLowerCamel .Name -}}
+{{- $plural := toCamel (pluralize $field) -}}
+package {{.PackageName}}
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"fmt"
+
+	"{{.Vars.modelImport}}"
+)
+
+// Create{{.NameTitle}} is the resolver for the create{{.NameTitle}} field.
+func (r *mutationResolver) Create{{.NameTitle}}(ctx context.Context, input model.Create{{.NameTitle}}Input) (*model.{{.NameTitle}}, error) {
+	// goforge:keep create
+	{{- if .Vars.service}}
+	// TODO: Create the {{.Name}} with r.{{.Vars.service}}
+	{{- end}}
+	return nil, fmt.Errorf("not implemented: Create{{.NameTitle}} - create{{.NameTitle}}")
+	// goforge:end
+}
+
+// Delete{{.NameTitle}} is the resolver for the delete{{.NameTitle}} field.
+func (r *mutationResolver) Delete{{.NameTitle}}(ctx context.Context, id string) (bool, error) {
+	// goforge:keep delete
+	{{- if .Vars.service}}
+	// TODO: Delete the {{.Name}} with r.{{.Vars.service}}
+	{{- end}}
+	return false, fmt.Errorf("not implemented: Delete{{.NameTitle}} - delete{{.NameTitle}}")
+	// goforge:end
+}
+
+// {{.NameTitle}} is the resolver for the {{$field}} field.
+func (r *queryResolver) {{.NameTitle}}(ctx context.Context, id string) (*model.{{.NameTitle}}, error) {
+	// goforge:keep get
+	{{- if .Vars.service}}
+	// TODO: Load the {{.Name}} with r.{{.Vars.service}}
+	{{- end}}
+	return nil, fmt.Errorf("not implemented: {{.NameTitle}} - {{$field}}")
+	// goforge:end
+}
+
+// {{$plural}} is the resolver for the {{pluralize $field}} field.
+func (r *queryResolver) {{$plural}}(ctx context.Context) ([]*model.{{.NameTitle}}, error) {
+	// goforge:keep list
+	{{- if .Vars.service}}
+	// TODO: List the {{pluralize .Name}} with r.{{.Vars.service}}
+	{{- end}}
+	return nil, fmt.Errorf("not implemented: {{$plural}} - {{pluralize $field}}")
+	// goforge:end
+}
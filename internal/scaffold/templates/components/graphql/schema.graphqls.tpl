@@ -0,0 +1,5 @@
+# Root operation types. Each resource's schema file in this directory
+# extends them with its own fields.
+type Query
+
+type Mutation
@@ -0,0 +1,26 @@
+{{- $field := toLowerCamel .Name -}}
+{{- $plural := pluralize $field -}}
+# {{.NameTitle}} schema, created by 'goforge g resolver {{.Name}}'.
+
+type {{.NameTitle}} {
+  id: ID!
+  # TODO: Add the {{.Name}}'s fields
+  name: String!
+}
+
+input Create{{.NameTitle}}Input {
+  name: String!
+}
+
+extend type Query {
+  "Returns one {{.Name}} by ID, or null when it does not exist."
+  {{$field}}(id: ID!): {{.NameTitle}}
+  "Lists the {{pluralize .Name}}."
+  {{$plural}}: [{{.NameTitle}}!]!
+}
+
+extend type Mutation {
+  create{{.NameTitle}}(input: Create{{.NameTitle}}Input!): {{.NameTitle}}!
+  "Deletes a {{.Name}} and reports whether it existed."
+  delete{{.NameTitle}}(id: ID!): Boolean!
+}
@@ -0,0 +1,10 @@
+# Code generation for 'goforge proto generate' (or 'buf generate').
+# The plugins are installed with 'go install' when missing.
+version: v2
+plugins:
+  - local: protoc-gen-go
+    out: {{.Vars.genDir}}
+    opt: paths=source_relative
+  - local: protoc-gen-go-grpc
+    out: {{.Vars.genDir}}
+    opt: paths=source_relative
@@ -0,0 +1,85 @@
+// Command grpc serves the application's gRPC services, with the standard
+// health service and server reflection, until it receives SIGINT or
+// SIGTERM, then lets in-flight calls finish.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// shutdownTimeout is how long in-flight calls get to finish on shutdown.
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.AutomaticEnv()
+	viper.SetDefault("grpc.port", 9090)
+	if err := viper.ReadInConfig(); err != nil {
+		logger.Warn("could not read config, using defaults", "error", err)
+	}
+
+	server := grpc.NewServer()
+	registerServices(server)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	addr := fmt.Sprintf(":%d", viper.GetInt("grpc.port"))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Error("failed to listen", "addr", addr, "error", err)
+		os.Exit(1)
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Error("gRPC server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+	logger.Info("gRPC server listening", "addr", addr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	logger.Info("shutting down, waiting for in-flight calls", "timeout", shutdownTimeout)
+	healthServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		logger.Info("gRPC server stopped")
+	case <-time.After(shutdownTimeout):
+		logger.Warn("calls did not finish in time; stopping")
+		server.Stop()
+	}
+}
+
+// registerServices registers every gRPC service with server. 'goforge
+// generate grpc' adds services above the goforge:grpc marker; keep it in
+// place.
+func registerServices(server *grpc.Server) {
+	// goforge:grpc
+}
@@ -0,0 +1,52 @@
+package {{.PackageName}}
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	{{.Vars.goPackage}} "{{.Vars.genImport}}"
+)
+
+// {{.NameTitle}}Server implements {{.Vars.goPackage}}.{{.NameTitle}}ServiceServer.
+type {{.NameTitle}}Server struct {
+	{{.Vars.goPackage}}.Unimplemented{{.NameTitle}}ServiceServer
+
+	// TODO: Add any dependencies here
+	// Example:
+	// service *service.{{.NameTitle}}Service
+}
+
+// New{{.NameTitle}}Server creates a new {{.NameTitle}}Server.
+func New{{.NameTitle}}Server( /* dependencies */ ) *{{.NameTitle}}Server {
+	return &{{.NameTitle}}Server{}
+}
+
+// Get{{.NameTitle}} returns one {{toSnake .Name}} by ID.
+func (s *{{.NameTitle}}Server) Get{{.NameTitle}}(ctx context.Context, req *{{.Vars.goPackage}}.Get{{.NameTitle}}Request) (*{{.Vars.goPackage}}.Get{{.NameTitle}}Response, error) {
+	// goforge:keep get
+	return nil, status.Error(codes.Unimplemented, "Get{{.NameTitle}} is not implemented")
+	// goforge:end
+}
+
+// List{{pluralize .NameTitle}} returns a page of {{toSnake (pluralize .NameTitle)}}.
+func (s *{{.NameTitle}}Server) List{{pluralize .NameTitle}}(ctx context.Context, req *{{.Vars.goPackage}}.List{{pluralize .NameTitle}}Request) (*{{.Vars.goPackage}}.List{{pluralize .NameTitle}}Response, error) {
+	// goforge:keep list
+	return nil, status.Error(codes.Unimplemented, "List{{pluralize .NameTitle}} is not implemented")
+	// goforge:end
+}
+
+// Create{{.NameTitle}} stores a new {{toSnake .Name}}.
+func (s *{{.NameTitle}}Server) Create{{.NameTitle}}(ctx context.Context, req *{{.Vars.goPackage}}.Create{{.NameTitle}}Request) (*{{.Vars.goPackage}}.Create{{.NameTitle}}Response, error) {
+	// goforge:keep create
+	return nil, status.Error(codes.Unimplemented, "Create{{.NameTitle}} is not implemented")
+	// goforge:end
+}
+
+// Delete{{.NameTitle}} removes a {{toSnake .Name}} by ID.
+func (s *{{.NameTitle}}Server) Delete{{.NameTitle}}(ctx context.Context, req *{{.Vars.goPackage}}.Delete{{.NameTitle}}Request) (*{{.Vars.goPackage}}.Delete{{.NameTitle}}Response, error) {
+	// goforge:keep delete
+	return nil, status.Error(codes.Unimplemented, "Delete{{.NameTitle}} is not implemented")
+	// goforge:end
+}
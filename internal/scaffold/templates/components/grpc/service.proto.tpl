@@ -0,0 +1,50 @@
+syntax = "proto3";
+
+package {{.Vars.protoPackage}};
+
+option go_package = "{{.Vars.genImport}};{{.Vars.goPackage}}";
+
+// {{.NameTitle}}Service manages {{toSnake (pluralize .NameTitle)}}.
+service {{.NameTitle}}Service {
+  rpc Get{{.NameTitle}}(Get{{.NameTitle}}Request) returns (Get{{.NameTitle}}Response);
+  rpc List{{pluralize .NameTitle}}(List{{pluralize .NameTitle}}Request) returns (List{{pluralize .NameTitle}}Response);
+  rpc Create{{.NameTitle}}(Create{{.NameTitle}}Request) returns (Create{{.NameTitle}}Response);
+  rpc Delete{{.NameTitle}}(Delete{{.NameTitle}}Request) returns (Delete{{.NameTitle}}Response);
+}
+
+message {{.NameTitle}} {
+  string id = 1;
+  // TODO: Add fields
+}
+
+message Get{{.NameTitle}}Request {
+  string id = 1;
+}
+
+message Get{{.NameTitle}}Response {
+  {{.NameTitle}} {{toSnake .Name}} = 1;
+}
+
+message List{{pluralize .NameTitle}}Request {
+  int32 page_size = 1;
+  string page_token = 2;
+}
+
+message List{{pluralize .NameTitle}}Response {
+  repeated {{.NameTitle}} {{toSnake (pluralize .NameTitle)}} = 1;
+  string next_page_token = 2;
+}
+
+message Create{{.NameTitle}}Request {
+  {{.NameTitle}} {{toSnake .Name}} = 1;
+}
+
+message Create{{.NameTitle}}Response {
+  {{.NameTitle}} {{toSnake .Name}} = 1;
+}
+
+message Delete{{.NameTitle}}Request {
+  string id = 1;
+}
+
+message Delete{{.NameTitle}}Response {}
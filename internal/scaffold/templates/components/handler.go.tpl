@@ -1,10 +1,10 @@
-package handler
+package {{.PackageName}}
 
 import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	// "{{.ModulePath}}/internal/app/service" // TODO: Uncomment when service is created and wired up.
+	// service "{{.Imports.service}}" // TODO: Uncomment when service is created and wired up.
 )
 
 // {{.NameTitle}}Handler handles HTTP requests related to the {{.Name}} resource.
@@ -20,10 +20,21 @@ func New{{.NameTitle}}Handler(/* s *service.{{.NameTitle}}Service */) *{{.NameTi
 }
 
 // HandleSomething is an example handler method.
-// TODO: Rename and implement your handler logic.
+// TODO: Rename and implement your handler logic, and update the annotations
+// 'goforge docs openapi' builds the API spec from.
+//
+//	@Summary	Example {{.Name}} endpoint
+//	@Tags		{{.Name | pluralize}}
+//	@Produce	json
+//	@Success	200	{object}	map[string]string
+//	@Router		/{{.Name | pluralize}} [get]
 func (h *{{.NameTitle}}Handler) HandleSomething(c *gin.Context) {
 	// 1. Parse request from c.Param, c.Query, or c.ShouldBindJSON.
 	// 2. Call the service.
 	// 3. Write response.
 	c.JSON(http.StatusOK, gin.H{"message": "{{.NameTitle}} handler called"})
 }
+
+// goforge:keep custom
+// Code between these markers is preserved when this file is regenerated.
+// goforge:end
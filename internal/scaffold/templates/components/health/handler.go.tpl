@@ -0,0 +1,34 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// LiveHandler reports that the process is running. It checks no
+// dependencies, so a database outage does not get the service restarted.
+func LiveHandler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": StatusUp})
+	}
+}
+
+// ReadyHandler runs every probe in r and answers 200 when the service can
+// take traffic, or 503 with the failing probes otherwise.
+func ReadyHandler(r *Registry) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		report := r.Check(c.Request.Context())
+		status := http.StatusOK
+		if report.Status != StatusUp {
+			status = http.StatusServiceUnavailable
+		}
+		c.JSON(status, report)
+	}
+}
+
+// RegisterRoutes mounts GET /health/live and GET /health/ready.
+func RegisterRoutes(router gin.IRoutes, r *Registry) {
+	router.GET("/health/live", LiveHandler())
+	router.GET("/health/ready", ReadyHandler(r))
+}
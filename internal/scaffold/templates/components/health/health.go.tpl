@@ -0,0 +1,162 @@
+// Package {{.PackageName}} keeps a registry of named probes for the
+// application's external dependencies (databases, caches, APIs). The
+// readiness endpoint and cmd/healthcheck both run the probes registered
+// by RegisterProbes, so they always agree on what "healthy" means.
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Probe statuses.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// DefaultTimeout bounds each probe when the registry sets no timeout.
+const DefaultTimeout = 2 * time.Second
+
+// Probe checks one dependency. It returns nil when the dependency is usable.
+type Probe interface {
+	Check(ctx context.Context) error
+}
+
+// ProbeFunc adapts a function to a Probe.
+type ProbeFunc func(ctx context.Context) error
+
+// Check calls f(ctx).
+func (f ProbeFunc) Check(ctx context.Context) error {
+	return f(ctx)
+}
+
+// Result is the outcome of one probe.
+type Result struct {
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Critical   bool   `json:"critical"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report is the outcome of a set of probes. Its status is down when any
+// critical probe is down.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+type registration struct {
+	probe    Probe
+	critical bool
+}
+
+// Registry holds the named probes.
+type Registry struct {
+	timeout time.Duration
+
+	mu     sync.RWMutex
+	probes map[string]registration
+}
+
+// NewRegistry creates an empty registry that gives each probe timeout to
+// answer (DefaultTimeout if zero).
+func NewRegistry(timeout time.Duration) *Registry {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Registry{timeout: timeout, probes: make(map[string]registration)}
+}
+
+// Register adds a critical probe, replacing any probe with the same name.
+// A failing critical probe marks the whole report down.
+func (r *Registry) Register(name string, probe Probe) {
+	r.register(name, probe, true)
+}
+
+// RegisterOptional adds a probe that is reported but never marks the
+// whole report down, for dependencies the service can work without.
+func (r *Registry) RegisterOptional(name string, probe Probe) {
+	r.register(name, probe, false)
+}
+
+func (r *Registry) register(name string, probe Probe, critical bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.probes[name] = registration{probe: probe, critical: critical}
+}
+
+// Names returns the registered probe names, sorted.
+func (r *Registry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.probes))
+	for name := range r.probes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Check runs the named probes concurrently, or all of them when no names
+// are given. Unknown names are reported as down.
+func (r *Registry) Check(ctx context.Context, names ...string) Report {
+	if len(names) == 0 {
+		names = r.Names()
+	}
+
+	results := make([]Result, len(names))
+	var wg sync.WaitGroup
+	for i, name := range names {
+		r.mu.RLock()
+		reg, ok := r.probes[name]
+		r.mu.RUnlock()
+		if !ok {
+			results[i] = Result{Name: name, Status: StatusDown, Critical: true, Error: "unknown probe"}
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, name string, reg registration) {
+			defer wg.Done()
+			results[i] = r.run(ctx, name, reg)
+		}(i, name, reg)
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: results}
+	for _, result := range results {
+		if result.Status == StatusDown && result.Critical {
+			report.Status = StatusDown
+		}
+	}
+	return report
+}
+
+// run executes one probe with the registry timeout, treating a panic as
+// a failure.
+func (r *Registry) run(ctx context.Context, name string, reg registration) (result Result) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	start := time.Now()
+	result = Result{Name: name, Status: StatusUp, Critical: reg.critical}
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			result.Status = StatusDown
+			result.Error = fmt.Sprintf("probe panicked: %v", recovered)
+		}
+		result.DurationMS = time.Since(start).Milliseconds()
+	}()
+
+	if err := reg.probe.Check(ctx); err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+	return result
+}
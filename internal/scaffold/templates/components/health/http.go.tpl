@@ -0,0 +1,31 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// HTTPProbe checks an external API by requesting url. Any status below
+// 500 counts as up, since the API answered.
+func HTTPProbe(url string) Probe {
+	client := &http.Client{}
+	return ProbeFunc(func(ctx context.Context) error {
+		if url == "" {
+			return fmt.Errorf("no URL configured")
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("%s returned %s", url, resp.Status)
+		}
+		return nil
+	})
+}
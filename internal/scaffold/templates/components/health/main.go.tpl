@@ -0,0 +1,77 @@
+// Command healthcheck runs the dependency probes registered by
+// {{.Vars.healthPackage}}.RegisterProbes, the same ones behind /health/ready,
+// and exits non-zero when a critical probe fails.
+//
+//	go run ./cmd/healthcheck              # every probe
+//	go run ./cmd/healthcheck postgres     # selected probes
+//	go run ./cmd/healthcheck -json
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"{{.Vars.healthImport}}"
+)
+
+func main() {
+	asJSON := flag.Bool("json", false, "Print the report as JSON")
+	timeout := flag.Duration("timeout", {{.Vars.healthPackage}}.DefaultTimeout, "Time each probe may take")
+	list := flag.Bool("list", false, "List the registered probes and exit")
+	flag.Parse()
+
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.AutomaticEnv()
+	if err := viper.ReadInConfig(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading config file: %s\n", err)
+		os.Exit(2)
+	}
+
+	registry := {{.Vars.healthPackage}}.NewRegistry(*timeout)
+	{{.Vars.healthPackage}}.RegisterProbes(registry)
+
+	if *list {
+		for _, name := range registry.Names() {
+			fmt.Println(name)
+		}
+		return
+	}
+	if len(registry.Names()) == 0 {
+		fmt.Println("No probes registered; add them in {{.Vars.healthPackage}}.RegisterProbes")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout+time.Second)
+	defer cancel()
+	report := registry.Check(ctx, flag.Args()...)
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROBE\tSTATUS\tTIME\tERROR")
+		for _, result := range report.Checks {
+			status := result.Status
+			if !result.Critical {
+				status += " (optional)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%dms\t%s\n", result.Name, status, result.DurationMS, result.Error)
+		}
+		w.Flush()
+	}
+
+	if report.Status != {{.Vars.healthPackage}}.StatusUp {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,14 @@
+package {{.PackageName}}
+
+import (
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// OIDCFromConfig probes the discovery document of the identity provider
+// at oidc.issuer_url in the application config.
+func OIDCFromConfig() Probe {
+	issuer := strings.TrimSuffix(viper.GetString("oidc.issuer_url"), "/")
+	return HTTPProbe(issuer + "/.well-known/openid-configuration")
+}
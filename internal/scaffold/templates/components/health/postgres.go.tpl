@@ -0,0 +1,51 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/viper"
+)
+
+// PostgresFromConfig probes the database configured in the database
+// section of the application config.
+func PostgresFromConfig() Probe {
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		viper.GetString("database.host"),
+		viper.GetInt("database.port"),
+		viper.GetString("database.user"),
+		viper.GetString("database.password"),
+		viper.GetString("database.dbname"),
+		viper.GetString("database.sslmode"),
+	)
+	return PostgresProbe(dsn)
+}
+
+// PostgresProbe pings a PostgreSQL database. It opens a single-connection
+// pool on the first check and reuses it afterwards.
+func PostgresProbe(dsn string) Probe {
+	var mu sync.Mutex
+	var pool *pgxpool.Pool
+
+	return ProbeFunc(func(ctx context.Context) error {
+		mu.Lock()
+		if pool == nil {
+			cfg, err := pgxpool.ParseConfig(dsn)
+			if err != nil {
+				mu.Unlock()
+				return err
+			}
+			cfg.MaxConns = 1
+			if pool, err = pgxpool.NewWithConfig(context.Background(), cfg); err != nil {
+				mu.Unlock()
+				return err
+			}
+		}
+		p := pool
+		mu.Unlock()
+
+		return p.Ping(ctx)
+	})
+}
@@ -0,0 +1,8 @@
+package {{.PackageName}}
+
+// RegisterProbes registers a probe for each of the application's
+// dependencies. 'goforge generate' adds probes above the goforge:probes
+// marker as adapters are scaffolded; keep it in place.
+func RegisterProbes(r *Registry) {
+	// goforge:probes
+}
@@ -0,0 +1,23 @@
+package {{.PackageName}}
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// RedisFromConfig probes the Redis server at redis.addr in the
+// application config.
+func RedisFromConfig() Probe {
+	return RedisProbe(viper.GetString("redis.addr"))
+}
+
+// RedisProbe pings the Redis server at addr.
+func RedisProbe(addr string) Probe {
+	// Retries would only delay the report; the next check tries again
+	client := redis.NewClient(&redis.Options{Addr: addr, MaxRetries: -1})
+	return ProbeFunc(func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	})
+}
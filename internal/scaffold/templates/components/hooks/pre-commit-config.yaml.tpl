@@ -0,0 +1,29 @@
+# Hooks of {{.ProjectName}} for the pre-commit framework (https://pre-commit.com),
+# generated by GoForge. Install them with:
+#   pre-commit install
+default_install_hook_types: [pre-commit, pre-push]
+
+repos:
+  - repo: local
+    hooks:
+      - id: goforge-fmt
+        name: {{printf "%q" .Vars.fmt}}
+        entry: {{printf "%q" .Vars.fmt}}
+        language: system
+        types: [go]
+        pass_filenames: false
+      - id: goforge-lint
+        name: {{printf "%q" .Vars.lint}}
+        entry: {{printf "%q" .Vars.lint}}
+        language: system
+        types: [go]
+        pass_filenames: false
+      - id: goforge-test
+        name: {{printf "%q" .Vars.test}}
+        entry: {{printf "%q" .Vars.test}}
+        language: system
+        types: [go]
+        pass_filenames: false
+        stages: [pre-push]
+  # goforge:keep custom-hooks
+  # goforge:end
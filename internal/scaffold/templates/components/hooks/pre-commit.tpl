@@ -0,0 +1,16 @@
+#!/bin/sh
+# Managed by GoForge: regenerate with 'goforge g hooks'. Skip it once with
+# 'git commit --no-verify'.
+set -e
+
+# Formatting must not change what is about to be committed
+before=$(git diff --name-only)
+echo "goforge hooks: {{.Vars.fmt}}"
+{{.Vars.fmt}}
+if [ "$(git diff --name-only)" != "$before" ]; then
+  echo "goforge hooks: formatting changed files; review and stage them, then commit again" >&2
+  exit 1
+fi
+
+echo "goforge hooks: {{.Vars.lint}}"
+{{.Vars.lint}}
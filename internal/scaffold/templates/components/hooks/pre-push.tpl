@@ -0,0 +1,7 @@
+#!/bin/sh
+# Managed by GoForge: regenerate with 'goforge g hooks'. Skip it once with
+# 'git push --no-verify'.
+set -e
+
+echo "goforge hooks: {{.Vars.test}}"
+{{.Vars.test}}
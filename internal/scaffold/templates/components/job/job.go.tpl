@@ -0,0 +1,34 @@
+package {{.PackageName}}
+
+import (
+	"context"
+)
+
+// {{.NameTitle}}Schedule is when {{.NameTitle}}Job runs, in cron syntax.
+const {{.NameTitle}}Schedule = "{{.Vars.schedule}}"
+
+// {{.NameTitle}}Job implements the {{.Name}} background job.
+type {{.NameTitle}}Job struct {
+	// TODO: Add any dependencies here
+	// Example:
+	// sessions ports.SessionRepository
+}
+
+// New{{.NameTitle}}Job creates a new {{.NameTitle}}Job.
+func New{{.NameTitle}}Job( /* dependencies */ ) *{{.NameTitle}}Job {
+	return &{{.NameTitle}}Job{}
+}
+
+// Name identifies the job in logs.
+func (j *{{.NameTitle}}Job) Name() string {
+	return "{{.Name}}"
+}
+
+// Run performs one execution of the job. Return promptly once ctx is
+// cancelled so the worker can shut down.
+func (j *{{.NameTitle}}Job) Run(ctx context.Context) error {
+	// goforge:keep run
+	// TODO: Implement the job
+	return ctx.Err()
+	// goforge:end
+}
@@ -0,0 +1,24 @@
+// Package {{.PackageName}} contains the application's background jobs.
+package {{.PackageName}}
+
+import (
+	"{{.Vars.schedulerImport}}"
+)
+
+// Register schedules every job with s. 'goforge generate job' adds new
+// jobs above the goforge:jobs marker; keep it in place.
+func Register(s *{{.Vars.schedulerPackage}}.Scheduler) error {
+	jobs := []struct {
+		schedule string
+		job      {{.Vars.schedulerPackage}}.Job
+	}{
+		// goforge:jobs
+	}
+
+	for _, j := range jobs {
+		if err := s.Register(j.schedule, j.job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
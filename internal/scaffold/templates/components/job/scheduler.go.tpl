@@ -0,0 +1,107 @@
+// Package {{.PackageName}} runs background jobs on cron schedules.
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// Job is a unit of background work.
+type Job interface {
+	// Name identifies the job in logs.
+	Name() string
+
+	// Run performs one execution. ctx is cancelled when the scheduler
+	// stops and the job does not finish within the grace period.
+	Run(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs on their schedules. A job is skipped
+// while its previous run is still going, and panics are recovered.
+type Scheduler struct {
+	cron   *cron.Cron
+	logger *slog.Logger
+	ctx    context.Context
+	cancel context.CancelFunc
+	jobs   int
+}
+
+// New creates a scheduler that logs to logger (slog.Default() if nil).
+func New(logger *slog.Logger) *Scheduler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	cronLogger := slogAdapter{logger}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		cron: cron.New(cron.WithLogger(cronLogger), cron.WithChain(
+			cron.Recover(cronLogger),
+			cron.SkipIfStillRunning(cronLogger),
+		)),
+		logger: logger,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Register schedules job. spec uses standard cron syntax ("0 * * * *")
+// or a descriptor such as "@hourly" or "@every 5m".
+func (s *Scheduler) Register(spec string, job Job) error {
+	_, err := s.cron.AddFunc(spec, func() {
+		start := time.Now()
+		s.logger.Info("job started", "job", job.Name())
+		if err := job.Run(s.ctx); err != nil {
+			s.logger.Error("job failed", "job", job.Name(), "duration", time.Since(start), "error", err)
+			return
+		}
+		s.logger.Info("job finished", "job", job.Name(), "duration", time.Since(start))
+	})
+	if err != nil {
+		return fmt.Errorf("invalid schedule %q for job %s: %w", spec, job.Name(), err)
+	}
+	s.jobs++
+	return nil
+}
+
+// Len returns the number of registered jobs.
+func (s *Scheduler) Len() int {
+	return s.jobs
+}
+
+// Start runs the scheduler in the background.
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// Stop stops scheduling new runs and waits for running jobs to finish.
+// If ctx expires first, the jobs' context is cancelled and ctx.Err() is
+// returned.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	done := s.cron.Stop()
+	select {
+	case <-done.Done():
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		return ctx.Err()
+	}
+}
+
+// slogAdapter lets cron log through slog.
+type slogAdapter struct {
+	logger *slog.Logger
+}
+
+func (a slogAdapter) Info(msg string, keysAndValues ...any) {
+	a.logger.Debug("scheduler: "+msg, keysAndValues...)
+}
+
+func (a slogAdapter) Error(err error, msg string, keysAndValues ...any) {
+	a.logger.Error("scheduler: "+msg, append(keysAndValues, "error", err)...)
+}
@@ -0,0 +1,45 @@
+// Command worker runs the application's background jobs until it receives
+// SIGINT or SIGTERM, then waits for running jobs to finish.
+package main
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"{{.Vars.jobsImport}}"
+	"{{.Vars.schedulerImport}}"
+)
+
+// shutdownTimeout is how long running jobs get to finish on shutdown.
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	s := {{.Vars.schedulerPackage}}.New(logger)
+	if err := {{.Vars.jobsPackage}}.Register(s); err != nil {
+		logger.Error("failed to register jobs", "error", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s.Start()
+	logger.Info("worker started", "jobs", s.Len())
+
+	<-ctx.Done()
+	logger.Info("shutting down, waiting for running jobs", "timeout", shutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := s.Stop(shutdownCtx); err != nil {
+		logger.Error("jobs did not finish in time", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("worker stopped")
+}
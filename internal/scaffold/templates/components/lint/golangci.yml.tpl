@@ -0,0 +1,31 @@
+# golangci-lint configuration, written by 'goforge lint' and yours to
+# change: https://golangci-lint.run/usage/configuration/
+version: "2"
+
+linters:
+  # errcheck, govet, ineffassign, staticcheck, and unused
+  default: standard
+  enable:
+    - bodyclose
+    - errorlint
+    - gocritic
+    - misspell
+    - revive
+    - unconvert
+  exclusions:
+    generated: lax
+    presets:
+      - comments
+      - std-error-handling
+    paths:
+      - ".goforge"
+      - "dist"
+
+formatters:
+  enable:
+    - gofmt
+    - goimports
+  settings:
+    goimports:
+      local-prefixes:
+        - "{{.ModuleName}}"
@@ -0,0 +1,16 @@
+# Makefile of {{.ProjectName}} generated by GoForge. The targets delegate to
+# goforge and the scripts of goforge.yml; regenerate it after changing them:
+#   goforge g makefile
+GOFORGE ?= goforge
+
+.DEFAULT_GOAL := help
+.PHONY: help{{range .Vars.targets}} {{.Name}}{{end}}
+
+help: ## List the targets
+	@grep -E '^[a-zA-Z0-9_.-]+:.*## ' $(MAKEFILE_LIST) | awk 'BEGIN {FS = ":.*## "}; {printf "  %-20s %s\n", $$1, $$2}'
+{{range .Vars.targets}}
+{{.Name}}: ## {{.Description}}
+	$(GOFORGE) {{.Command}}
+{{end}}
+# goforge:keep custom-targets
+# goforge:end
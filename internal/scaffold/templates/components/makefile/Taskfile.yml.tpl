@@ -0,0 +1,19 @@
+# Taskfile of {{.ProjectName}} generated by GoForge. The tasks delegate to
+# goforge and the scripts of goforge.yml; regenerate it after changing them:
+#   goforge g taskfile
+version: "3"
+
+tasks:
+  default:
+    cmds:
+      - task --list
+{{- range .Vars.targets}}
+
+  {{printf "%q" .Task}}:
+    desc: {{printf "%q" .Description}}
+    cmds:
+      - goforge {{.Command}}
+{{- end}}
+
+  # goforge:keep custom-tasks
+  # goforge:end
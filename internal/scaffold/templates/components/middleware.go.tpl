@@ -1,11 +1,19 @@
-package middleware
+package {{.PackageName}}
 
 import (
-	"net/http"
+{{- if eq .Vars.logger "slog"}}
+	"log/slog"
+{{- else if not (eq .Vars.logger "zap" "zerolog")}}
+	"log"
+{{- end}}
 	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/night-slayer18/goforge/internal/logger"
+{{- if eq .Vars.logger "zap"}}
+	"go.uber.org/zap"
+{{- else if eq .Vars.logger "zerolog"}}
+	"github.com/rs/zerolog/log"
+{{- end}}
 )
 
 // {{.NameTitle}}Middleware provides {{.Name}} functionality.
@@ -36,14 +44,34 @@ func (m *{{.NameTitle}}Middleware) Handler() gin.HandlerFunc {
 		//     return
 		// }
 		
-		logger.Debug("{{.NameTitle}} middleware processing request: %s %s", c.Request.Method, c.Request.URL.Path)
+{{- if eq .Vars.logger "slog"}}
+
+		slog.Info("{{.NameTitle}} middleware processing request", "method", c.Request.Method, "path", c.Request.URL.Path)
+{{- else if eq .Vars.logger "zap"}}
+
+		zap.L().Info("{{.NameTitle}} middleware processing request", zap.String("method", c.Request.Method), zap.String("path", c.Request.URL.Path))
+{{- else if eq .Vars.logger "zerolog"}}
+
+		log.Info().Str("method", c.Request.Method).Str("path", c.Request.URL.Path).Msg("{{.NameTitle}} middleware processing request")
+{{- else}}
+
+		log.Printf("{{.NameTitle}} middleware processing request: %s %s", c.Request.Method, c.Request.URL.Path)
+{{- end}}
 		
 		// Continue to next handler
 		c.Next()
 		
 		// Post-processing logic (optional)
 		duration := time.Since(start)
-		logger.Debug("{{.NameTitle}} middleware completed in %v", duration)
+{{- if eq .Vars.logger "slog"}}
+		slog.Info("{{.NameTitle}} middleware completed", "duration", duration)
+{{- else if eq .Vars.logger "zap"}}
+		zap.L().Info("{{.NameTitle}} middleware completed", zap.Duration("duration", duration))
+{{- else if eq .Vars.logger "zerolog"}}
+		log.Info().Dur("duration", duration).Msg("{{.NameTitle}} middleware completed")
+{{- else}}
+		log.Printf("{{.NameTitle}} middleware completed in %v", duration)
+{{- end}}
 	})
 }
 
@@ -0,0 +1,127 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.NameTitle}}Config configures cross-origin resource sharing.
+type {{.NameTitle}}Config struct {
+	// AllowOrigins lists the origins allowed to call the API, e.g.
+	// "https://app.example.com". "*" allows any origin.
+	AllowOrigins []string
+
+	AllowMethods  []string
+	AllowHeaders  []string // Empty allows whatever the preflight asks for
+	ExposeHeaders []string
+
+	// AllowCredentials lets browsers send cookies and Authorization
+	// headers. With "*" the caller's origin is echoed back, since browsers
+	// reject a wildcard on credentialed requests.
+	AllowCredentials bool
+
+	// MaxAge is how long browsers may cache a preflight response.
+	MaxAge time.Duration
+}
+
+// Default{{.NameTitle}}Config allows any origin to use the common methods
+// without credentials.
+func Default{{.NameTitle}}Config() {{.NameTitle}}Config {
+	return {{.NameTitle}}Config{
+		AllowOrigins:  []string{"*"},
+		AllowMethods:  []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions},
+		AllowHeaders:  []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Request-ID"},
+		ExposeHeaders: []string{"X-Request-ID"},
+		MaxAge:        12 * time.Hour,
+	}
+}
+
+// {{.NameTitle}}Middleware answers CORS preflight requests and adds the
+// Access-Control-* headers to cross-origin responses.
+type {{.NameTitle}}Middleware struct {
+	cfg      {{.NameTitle}}Config
+	anyOrigin bool
+	origins  map[string]bool
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware(cfg {{.NameTitle}}Config) *{{.NameTitle}}Middleware {
+	m := &{{.NameTitle}}Middleware{cfg: cfg, origins: make(map[string]bool)}
+	for _, origin := range cfg.AllowOrigins {
+		if origin == "*" {
+			m.anyOrigin = true
+		}
+		m.origins[strings.ToLower(strings.TrimSuffix(origin, "/"))] = true
+	}
+	return m
+}
+
+// Handler returns the Gin middleware handler function. Register it with
+// router.Use so preflight requests reach it even for routes that have no
+// OPTIONS handler.
+func (m *{{.NameTitle}}Middleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		preflight := c.Request.Method == http.MethodOptions && c.GetHeader("Access-Control-Request-Method") != ""
+
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		header := c.Writer.Header()
+		header.Add("Vary", "Origin")
+
+		if !m.allowed(origin) {
+			if preflight {
+				c.AbortWithStatus(http.StatusForbidden)
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if m.anyOrigin && !m.cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			header.Set("Access-Control-Allow-Origin", origin)
+		}
+		if m.cfg.AllowCredentials {
+			header.Set("Access-Control-Allow-Credentials", "true")
+		}
+
+		if !preflight {
+			if len(m.cfg.ExposeHeaders) > 0 {
+				header.Set("Access-Control-Expose-Headers", strings.Join(m.cfg.ExposeHeaders, ", "))
+			}
+			c.Next()
+			return
+		}
+
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+		header.Set("Access-Control-Allow-Methods", strings.Join(m.cfg.AllowMethods, ", "))
+		if len(m.cfg.AllowHeaders) > 0 {
+			header.Set("Access-Control-Allow-Headers", strings.Join(m.cfg.AllowHeaders, ", "))
+		} else if requested := c.GetHeader("Access-Control-Request-Headers"); requested != "" {
+			header.Set("Access-Control-Allow-Headers", requested)
+		}
+		if m.cfg.MaxAge > 0 {
+			header.Set("Access-Control-Max-Age", strconv.Itoa(int(m.cfg.MaxAge.Seconds())))
+		}
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a router group.
+func (m *{{.NameTitle}}Middleware) Apply(rg *gin.RouterGroup) {
+	rg.Use(m.Handler())
+}
+
+func (m *{{.NameTitle}}Middleware) allowed(origin string) bool {
+	return m.anyOrigin || m.origins[strings.ToLower(strings.TrimSuffix(origin, "/"))]
+}
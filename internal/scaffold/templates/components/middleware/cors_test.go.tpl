@@ -0,0 +1,92 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func new{{.NameTitle}}TestRouter(cfg {{.NameTitle}}Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(New{{.NameTitle}}Middleware(cfg).Handler())
+	router.GET("/items", func(c *gin.Context) { c.String(http.StatusOK, "ok") })
+	return router
+}
+
+func Test{{.NameTitle}}Preflight(t *testing.T) {
+	cfg := Default{{.NameTitle}}Config()
+	cfg.AllowOrigins = []string{"https://app.example.com"}
+	router := new{{.NameTitle}}TestRouter(cfg)
+
+	req := httptest.NewRequest(http.MethodOptions, "/items", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	if rec.Header().Get("Access-Control-Allow-Methods") == "" {
+		t.Error("Access-Control-Allow-Methods is missing")
+	}
+}
+
+func Test{{.NameTitle}}SimpleRequest(t *testing.T) {
+	router := new{{.NameTitle}}TestRouter(Default{{.NameTitle}}Config())
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("Origin", "https://other.example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want *", got)
+	}
+}
+
+func Test{{.NameTitle}}RejectsUnknownOrigin(t *testing.T) {
+	cfg := Default{{.NameTitle}}Config()
+	cfg.AllowOrigins = []string{"https://app.example.com"}
+	router := new{{.NameTitle}}TestRouter(cfg)
+
+	req := httptest.NewRequest(http.MethodOptions, "/items", nil)
+	req.Header.Set("Origin", "https://evil.example.com")
+	req.Header.Set("Access-Control-Request-Method", http.MethodGet)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Access-Control-Allow-Origin = %q, want none", got)
+	}
+}
+
+func Test{{.NameTitle}}CredentialsEchoOrigin(t *testing.T) {
+	cfg := Default{{.NameTitle}}Config()
+	cfg.AllowCredentials = true
+	router := new{{.NameTitle}}TestRouter(cfg)
+
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Access-Control-Allow-Origin"); got != "https://app.example.com" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	if got := rec.Header().Get("Access-Control-Allow-Credentials"); got != "true" {
+		t.Errorf("Access-Control-Allow-Credentials = %q", got)
+	}
+}
@@ -0,0 +1,133 @@
+package {{.PackageName}}
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// {{.NameTitle}}ClaimsKey is the gin context key holding the verified claims.
+const {{.NameTitle}}ClaimsKey = "{{.Name}}.claims"
+
+// {{.NameTitle}}Config configures bearer token authentication.
+type {{.NameTitle}}Config struct {
+	// Secret verifies HMAC-signed tokens (HS256/384/512).
+	Secret []byte
+
+	// KeyFunc supplies verification keys instead of Secret, e.g. RSA or
+	// ECDSA public keys looked up by the token's "kid" header.
+	KeyFunc jwt.Keyfunc
+
+	// Methods lists the accepted signing algorithms (default HS256).
+	Methods []string
+
+	Issuer   string        // Required "iss", if set
+	Audience string        // Required "aud", if set
+	Leeway   time.Duration // Allowed clock skew for exp, nbf, and iat
+
+	// Optional lets requests without a token through unauthenticated;
+	// invalid tokens are still rejected.
+	Optional bool
+}
+
+// {{.NameTitle}}Middleware verifies "Authorization: Bearer <token>" headers
+// and stores the token's claims in the gin context.
+type {{.NameTitle}}Middleware struct {
+	cfg     {{.NameTitle}}Config
+	parser  *jwt.Parser
+	keyFunc jwt.Keyfunc
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware(cfg {{.NameTitle}}Config) (*{{.NameTitle}}Middleware, error) {
+	if len(cfg.Secret) == 0 && cfg.KeyFunc == nil {
+		return nil, errors.New("{{.Name}}: either Secret or KeyFunc must be set")
+	}
+	if len(cfg.Methods) == 0 {
+		cfg.Methods = []string{jwt.SigningMethodHS256.Alg()}
+	}
+
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods(cfg.Methods),
+		jwt.WithLeeway(cfg.Leeway),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		options = append(options, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		options = append(options, jwt.WithAudience(cfg.Audience))
+	}
+
+	keyFunc := cfg.KeyFunc
+	if keyFunc == nil {
+		keyFunc = func(*jwt.Token) (any, error) { return cfg.Secret, nil }
+	}
+
+	return &{{.NameTitle}}Middleware{cfg: cfg, parser: jwt.NewParser(options...), keyFunc: keyFunc}, nil
+}
+
+// Handler returns the Gin middleware handler function.
+func (m *{{.NameTitle}}Middleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		if header == "" {
+			if m.cfg.Optional {
+				c.Next()
+				return
+			}
+			m.unauthorized(c, "missing bearer token")
+			return
+		}
+
+		scheme, raw, ok := strings.Cut(header, " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(raw) == "" {
+			m.unauthorized(c, "malformed authorization header")
+			return
+		}
+
+		claims := jwt.MapClaims{}
+		token, err := m.parser.ParseWithClaims(strings.TrimSpace(raw), claims, m.keyFunc)
+		if err != nil || !token.Valid {
+			m.unauthorized(c, "invalid or expired token")
+			return
+		}
+
+		c.Set({{.NameTitle}}ClaimsKey, claims)
+		c.Next()
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a router group.
+func (m *{{.NameTitle}}Middleware) Apply(rg *gin.RouterGroup) {
+	rg.Use(m.Handler())
+}
+
+func (m *{{.NameTitle}}Middleware) unauthorized(c *gin.Context, reason string) {
+	c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+	c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": reason})
+}
+
+// {{.NameTitle}}Claims returns the claims verified for this request.
+func {{.NameTitle}}Claims(c *gin.Context) (jwt.MapClaims, bool) {
+	value, ok := c.Get({{.NameTitle}}ClaimsKey)
+	if !ok {
+		return nil, false
+	}
+	claims, ok := value.(jwt.MapClaims)
+	return claims, ok
+}
+
+// {{.NameTitle}}Subject returns the "sub" claim of the request's token.
+func {{.NameTitle}}Subject(c *gin.Context) string {
+	claims, ok := {{.NameTitle}}Claims(c)
+	if !ok {
+		return ""
+	}
+	subject, _ := claims.GetSubject()
+	return subject
+}
@@ -0,0 +1,92 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var {{toLowerCamel .Name}}TestSecret = []byte("test-secret")
+
+func new{{.NameTitle}}TestRouter(t *testing.T, cfg {{.NameTitle}}Config) *gin.Engine {
+	t.Helper()
+	m, err := New{{.NameTitle}}Middleware(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(m.Handler())
+	router.GET("/me", func(c *gin.Context) { c.String(http.StatusOK, {{.NameTitle}}Subject(c)) })
+	return router
+}
+
+func sign{{.NameTitle}}TestToken(t *testing.T, secret []byte, claims jwt.MapClaims) string {
+	t.Helper()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func Test{{.NameTitle}}(t *testing.T) {
+	router := new{{.NameTitle}}TestRouter(t, {{.NameTitle}}Config{Secret: {{toLowerCamel .Name}}TestSecret, Issuer: "test"})
+	valid := jwt.MapClaims{"sub": "user-1", "iss": "test", "exp": time.Now().Add(time.Hour).Unix()}
+
+	tests := []struct {
+		name   string
+		header string
+		status int
+	}{
+		{"valid token", "Bearer " + sign{{.NameTitle}}TestToken(t, {{toLowerCamel .Name}}TestSecret, valid), http.StatusOK},
+		{"missing header", "", http.StatusUnauthorized},
+		{"wrong scheme", "Basic dXNlcjpwYXNz", http.StatusUnauthorized},
+		{"wrong secret", "Bearer " + sign{{.NameTitle}}TestToken(t, []byte("other"), valid), http.StatusUnauthorized},
+		{"expired", "Bearer " + sign{{.NameTitle}}TestToken(t, {{toLowerCamel .Name}}TestSecret, jwt.MapClaims{
+			"sub": "user-1", "iss": "test", "exp": time.Now().Add(-time.Hour).Unix(),
+		}), http.StatusUnauthorized},
+		{"wrong issuer", "Bearer " + sign{{.NameTitle}}TestToken(t, {{toLowerCamel .Name}}TestSecret, jwt.MapClaims{
+			"sub": "user-1", "iss": "other", "exp": time.Now().Add(time.Hour).Unix(),
+		}), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.status)
+			}
+			if tt.status == http.StatusOK && rec.Body.String() != "user-1" {
+				t.Errorf("subject = %q, want user-1", rec.Body.String())
+			}
+		})
+	}
+}
+
+func Test{{.NameTitle}}Optional(t *testing.T) {
+	router := new{{.NameTitle}}TestRouter(t, {{.NameTitle}}Config{Secret: {{toLowerCamel .Name}}TestSecret, Optional: true})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/me", nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestNew{{.NameTitle}}MiddlewareRequiresKey(t *testing.T) {
+	if _, err := New{{.NameTitle}}Middleware({{.NameTitle}}Config{}); err == nil {
+		t.Fatal("expected an error without Secret or KeyFunc")
+	}
+}
@@ -0,0 +1,129 @@
+package {{.PackageName}}
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.NameTitle}}Config configures per-client rate limiting. Limits are kept
+// in memory, so each instance of the service counts separately; use
+// 'goforge g ratelimiter --backend redis' for limits shared across instances.
+type {{.NameTitle}}Config struct {
+	Rate   int           // Requests allowed per Window
+	Window time.Duration
+	Burst  int           // Requests allowed at once; 0 means Rate
+
+	// KeyFunc identifies the caller a limit applies to (default client IP).
+	KeyFunc func(c *gin.Context) string
+}
+
+// Default{{.NameTitle}}Config allows 100 requests per minute per client IP.
+func Default{{.NameTitle}}Config() {{.NameTitle}}Config {
+	return {{.NameTitle}}Config{Rate: 100, Window: time.Minute}
+}
+
+// {{toLowerCamel .Name}}Bucket is the token bucket of one caller.
+type {{toLowerCamel .Name}}Bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// {{.NameTitle}}Middleware rejects callers that exceed the configured rate
+// with 429 Too Many Requests, using a token bucket per caller.
+type {{.NameTitle}}Middleware struct {
+	cfg       {{.NameTitle}}Config
+	perSecond float64
+	burst     float64
+	now       func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*{{toLowerCamel .Name}}Bucket
+	lastSweep time.Time
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware(cfg {{.NameTitle}}Config) *{{.NameTitle}}Middleware {
+	if cfg.Rate <= 0 || cfg.Window <= 0 {
+		defaults := Default{{.NameTitle}}Config()
+		cfg.Rate, cfg.Window = defaults.Rate, defaults.Window
+	}
+	if cfg.Burst <= 0 {
+		cfg.Burst = cfg.Rate
+	}
+	if cfg.KeyFunc == nil {
+		cfg.KeyFunc = func(c *gin.Context) string { return c.ClientIP() }
+	}
+
+	return &{{.NameTitle}}Middleware{
+		cfg:       cfg,
+		perSecond: float64(cfg.Rate) / cfg.Window.Seconds(),
+		burst:     float64(cfg.Burst),
+		now:       time.Now,
+		buckets:   make(map[string]*{{toLowerCamel .Name}}Bucket),
+	}
+}
+
+// Handler returns the Gin middleware handler function.
+func (m *{{.NameTitle}}Middleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		allowed, remaining, retryAfter := m.take(m.cfg.KeyFunc(c))
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(m.cfg.Burst))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(remaining))
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a router group.
+func (m *{{.NameTitle}}Middleware) Apply(rg *gin.RouterGroup) {
+	rg.Use(m.Handler())
+}
+
+// take spends a token from key's bucket. It returns whether the request is
+// allowed, the tokens left, and how long until the next token otherwise.
+func (m *{{.NameTitle}}Middleware) take(key string) (bool, int, time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	bucket, ok := m.buckets[key]
+	if !ok {
+		bucket = &{{toLowerCamel .Name}}Bucket{tokens: m.burst, last: now}
+		m.buckets[key] = bucket
+	}
+	bucket.tokens = math.Min(m.burst, bucket.tokens+now.Sub(bucket.last).Seconds()*m.perSecond)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		wait := time.Duration((1 - bucket.tokens) / m.perSecond * float64(time.Second))
+		return false, 0, wait
+	}
+	bucket.tokens--
+	return true, int(bucket.tokens), 0
+}
+
+// sweep drops buckets that have refilled completely, at most once a window,
+// so memory does not grow with every client ever seen.
+func (m *{{.NameTitle}}Middleware) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < m.cfg.Window {
+		return
+	}
+	m.lastSweep = now
+	for key, bucket := range m.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*m.perSecond >= m.burst {
+			delete(m.buckets, key)
+		}
+	}
+}
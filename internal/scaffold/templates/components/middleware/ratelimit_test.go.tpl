@@ -0,0 +1,52 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Test{{.NameTitle}}(t *testing.T) {
+	m := New{{.NameTitle}}Middleware({{.NameTitle}}Config{Rate: 2, Window: time.Second})
+	now := time.Unix(1700000000, 0)
+	m.now = func() time.Time { return now }
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(m.Handler())
+	router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = ip + ":1234"
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	for i := 0; i < 2; i++ {
+		if rec := request("10.0.0.1"); rec.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want %d", i+1, rec.Code, http.StatusOK)
+		}
+	}
+
+	rec := request("10.0.0.1")
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After is missing")
+	}
+
+	if rec := request("10.0.0.2"); rec.Code != http.StatusOK {
+		t.Errorf("other client: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	now = now.Add(500 * time.Millisecond)
+	if rec := request("10.0.0.1"); rec.Code != http.StatusOK {
+		t.Errorf("after refill: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
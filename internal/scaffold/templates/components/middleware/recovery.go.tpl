@@ -0,0 +1,112 @@
+package {{.PackageName}}
+
+import (
+	"errors"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"runtime/debug"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.NameTitle}}Config configures panic recovery.
+type {{.NameTitle}}Config struct {
+	// Logger receives a record for every recovered panic (default slog.Default()).
+	Logger *slog.Logger
+
+	// StackTrace includes the goroutine stack in the log record.
+	StackTrace bool
+
+	// OnPanic, if set, is called after logging, e.g. to report to an
+	// error tracker.
+	OnPanic func(c *gin.Context, recovered any)
+}
+
+// Default{{.NameTitle}}Config logs panics with their stack trace.
+func Default{{.NameTitle}}Config() {{.NameTitle}}Config {
+	return {{.NameTitle}}Config{StackTrace: true}
+}
+
+// {{.NameTitle}}Middleware turns panics in later handlers into a logged
+// 500 Internal Server Error instead of a dropped connection.
+type {{.NameTitle}}Middleware struct {
+	cfg {{.NameTitle}}Config
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware(cfg {{.NameTitle}}Config) *{{.NameTitle}}Middleware {
+	if cfg.Logger == nil {
+		cfg.Logger = slog.Default()
+	}
+	return &{{.NameTitle}}Middleware{cfg: cfg}
+}
+
+// Handler returns the Gin middleware handler function. Register it first
+// so it covers every other middleware.
+func (m *{{.NameTitle}}Middleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// http.ErrAbortHandler is the documented way to abort a response.
+			if err, ok := recovered.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(recovered)
+			}
+
+			attrs := []any{
+				slog.Any("panic", recovered),
+				slog.String("method", c.Request.Method),
+				slog.String("path", c.Request.URL.Path),
+			}
+			if m.cfg.StackTrace {
+				attrs = append(attrs, slog.String("stack", string(debug.Stack())))
+			}
+			m.cfg.Logger.ErrorContext(c.Request.Context(), "panic recovered", attrs...)
+
+			if m.cfg.OnPanic != nil {
+				m.cfg.OnPanic(c, recovered)
+			}
+
+			// The client is gone; there is nobody to send a response to.
+			if brokenPipe{{.NameTitle}}(recovered) {
+				c.Abort()
+				return
+			}
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		}()
+		c.Next()
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a router group.
+func (m *{{.NameTitle}}Middleware) Apply(rg *gin.RouterGroup) {
+	rg.Use(m.Handler())
+}
+
+// brokenPipe{{.NameTitle}} reports whether a panic came from writing to a
+// closed connection.
+func brokenPipe{{.NameTitle}}(recovered any) bool {
+	err, ok := recovered.(error)
+	if !ok {
+		return false
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+	var syscallErr *os.SyscallError
+	if errors.As(opErr, &syscallErr) {
+		message := strings.ToLower(syscallErr.Error())
+		return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+	}
+	return false
+}
@@ -0,0 +1,46 @@
+package {{.PackageName}}
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func Test{{.NameTitle}}(t *testing.T) {
+	var logs bytes.Buffer
+	var reported any
+
+	cfg := Default{{.NameTitle}}Config()
+	cfg.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	cfg.OnPanic = func(c *gin.Context, recovered any) { reported = recovered }
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(New{{.NameTitle}}Middleware(cfg).Handler())
+	router.GET("/panic", func(c *gin.Context) { panic("boom") })
+	router.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/panic", nil))
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if reported != "boom" {
+		t.Errorf("OnPanic got %v, want boom", reported)
+	}
+	if !strings.Contains(logs.String(), "panic recovered") || !strings.Contains(logs.String(), "stack=") {
+		t.Errorf("log record missing panic or stack: %s", logs.String())
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ok", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("after a panic: status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
@@ -0,0 +1,103 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+// {{.NameTitle}}Key is the gin context key holding the request ID.
+const {{.NameTitle}}Key = "{{.Name}}"
+
+// {{toLowerCamel .Name}}ContextKey keys the request ID in a context.Context.
+type {{toLowerCamel .Name}}ContextKey struct{}
+
+// {{.NameTitle}}Config configures request ID propagation.
+type {{.NameTitle}}Config struct {
+	// Header carries the ID in requests and responses (default X-Request-ID).
+	Header string
+
+	// TrustIncoming reuses a well-formed ID sent by the client or a proxy
+	// instead of generating a new one.
+	TrustIncoming bool
+
+	// Generator creates new IDs (default 16 random bytes, hex encoded).
+	Generator func() string
+}
+
+// Default{{.NameTitle}}Config uses X-Request-ID and trusts incoming IDs.
+func Default{{.NameTitle}}Config() {{.NameTitle}}Config {
+	return {{.NameTitle}}Config{Header: "X-Request-ID", TrustIncoming: true}
+}
+
+// {{.NameTitle}}Middleware gives every request an ID, returns it in the
+// response header, and makes it available to handlers and to anything
+// receiving the request context.
+type {{.NameTitle}}Middleware struct {
+	cfg {{.NameTitle}}Config
+}
+
+// New{{.NameTitle}}Middleware creates a new {{.NameTitle}}Middleware.
+func New{{.NameTitle}}Middleware(cfg {{.NameTitle}}Config) *{{.NameTitle}}Middleware {
+	if cfg.Header == "" {
+		cfg.Header = "X-Request-ID"
+	}
+	if cfg.Generator == nil {
+		cfg.Generator = func() string {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			return hex.EncodeToString(b)
+		}
+	}
+	return &{{.NameTitle}}Middleware{cfg: cfg}
+}
+
+// Handler returns the Gin middleware handler function.
+func (m *{{.NameTitle}}Middleware) Handler() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(m.cfg.Header)
+		if !m.cfg.TrustIncoming || !valid{{.NameTitle}}(id) {
+			id = m.cfg.Generator()
+		}
+
+		c.Set({{.NameTitle}}Key, id)
+		c.Request = c.Request.WithContext(With{{.NameTitle}}(c.Request.Context(), id))
+		c.Header(m.cfg.Header, id)
+		c.Next()
+	}
+}
+
+// Apply is a convenience method to apply this middleware to a router group.
+func (m *{{.NameTitle}}Middleware) Apply(rg *gin.RouterGroup) {
+	rg.Use(m.Handler())
+}
+
+// With{{.NameTitle}} returns a copy of ctx carrying id.
+func With{{.NameTitle}}(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, {{toLowerCamel .Name}}ContextKey{}, id)
+}
+
+// {{.NameTitle}}FromContext returns the request ID stored in ctx, or "".
+func {{.NameTitle}}FromContext(ctx context.Context) string {
+	id, _ := ctx.Value({{toLowerCamel .Name}}ContextKey{}).(string)
+	return id
+}
+
+// valid{{.NameTitle}} accepts short IDs of letters, digits, and -_.: so
+// clients cannot inject arbitrary text into logs.
+func valid{{.NameTitle}}(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case r == '-', r == '_', r == '.', r == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
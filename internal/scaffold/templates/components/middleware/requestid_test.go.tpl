@@ -0,0 +1,64 @@
+package {{.PackageName}}
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func new{{.NameTitle}}TestRouter(cfg {{.NameTitle}}Config) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(New{{.NameTitle}}Middleware(cfg).Handler())
+	router.GET("/", func(c *gin.Context) {
+		c.String(http.StatusOK, {{.NameTitle}}FromContext(c.Request.Context()))
+	})
+	return router
+}
+
+func Test{{.NameTitle}}Generated(t *testing.T) {
+	router := new{{.NameTitle}}TestRouter(Default{{.NameTitle}}Config())
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	id := rec.Header().Get("X-Request-ID")
+	if len(id) != 32 {
+		t.Fatalf("X-Request-ID = %q, want 32 hex characters", id)
+	}
+	if rec.Body.String() != id {
+		t.Errorf("context ID = %q, want %q", rec.Body.String(), id)
+	}
+}
+
+func Test{{.NameTitle}}Incoming(t *testing.T) {
+	tests := []struct {
+		name     string
+		trust    bool
+		incoming string
+		kept     bool
+	}{
+		{"trusted", true, "abc-123", true},
+		{"untrusted", false, "abc-123", false},
+		{"malformed", true, "abc\n123", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default{{.NameTitle}}Config()
+			cfg.TrustIncoming = tt.trust
+			router := new{{.NameTitle}}TestRouter(cfg)
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("X-Request-ID", tt.incoming)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if got := rec.Header().Get("X-Request-ID") == tt.incoming; got != tt.kept {
+				t.Errorf("X-Request-ID = %q, incoming kept = %v, want %v", rec.Header().Get("X-Request-ID"), got, tt.kept)
+			}
+		})
+	}
+}
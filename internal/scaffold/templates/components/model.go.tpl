@@ -1,5 +1,5 @@
 // internal/scaffold/templates/components/model.go.tpl
-package domain
+package {{.PackageName}}
 
 import (
 	"time"
@@ -10,17 +10,24 @@ type {{.NameTitle}} struct {
 	ID        int64     `json:"id" db:"id"`
 	CreatedAt time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+{{- if .Fields}}
+
+{{- range .Fields}}
+	{{.GoName}} {{.Type}} `{{.Tag}}`
+{{- end}}
+{{- else}}
 	
 	// TODO: Add your domain-specific fields here
 	// Example:
 	// Name        string `json:"name" db:"name" validate:"required,min=1,max=100"`
 	// Email       string `json:"email" db:"email" validate:"required,email"`
 	// IsActive    bool   `json:"is_active" db:"is_active"`
+{{- end}}
 }
 
 // TableName returns the database table name for this entity.
 func ({{.Name}} *{{.NameTitle}}) TableName() string {
-	return "{{.Name | pluralize}}"
+	return "{{.Table}}"
 }
 
 // Validate performs domain-level validation on the {{.Name}} entity.
@@ -0,0 +1,96 @@
+package {{.PackageName}}
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+// Routes mounted by RegisterRoutes; RequireAuth redirects browsers to LoginPath.
+const (
+	RoutePrefix  = "{{.Vars.prefix}}"
+	LoginPath    = RoutePrefix + "/login"
+	CallbackPath = RoutePrefix + "/callback"
+	LogoutPath   = RoutePrefix + "/logout"
+)
+
+// Config holds the OpenID Connect client settings from the oidc section
+// of config/default.yml.
+type Config struct {
+	Provider              string // keycloak, auth0, google, or generic
+	IssuerURL             string
+	ClientID              string
+	ClientSecret          string
+	RedirectURL           string
+	PostLogoutRedirectURL string
+	Scopes                []string
+
+	SessionSecret string // Encrypts the session cookie; at least 32 characters
+	CookieName    string
+	SessionTTL    time.Duration
+	SecureCookies bool // Set in production so cookies are only sent over HTTPS
+}
+
+// LoadConfig reads the oidc section. The secrets fall back to the
+// OIDC_CLIENT_SECRET and OIDC_SESSION_SECRET environment variables so they
+// never have to be committed.
+func LoadConfig() (Config, error) {
+	cfg := Config{
+		Provider:              viper.GetString("oidc.provider"),
+		IssuerURL:             viper.GetString("oidc.issuer_url"),
+		ClientID:              viper.GetString("oidc.client_id"),
+		ClientSecret:          viper.GetString("oidc.client_secret"),
+		RedirectURL:           viper.GetString("oidc.redirect_url"),
+		PostLogoutRedirectURL: viper.GetString("oidc.post_logout_redirect_url"),
+		Scopes:                viper.GetStringSlice("oidc.scopes"),
+		SessionSecret:         viper.GetString("oidc.session.secret"),
+		CookieName:            viper.GetString("oidc.session.cookie_name"),
+		SessionTTL:            viper.GetDuration("oidc.session.ttl"),
+		SecureCookies:         viper.GetBool("oidc.session.secure"),
+	}
+
+	if cfg.ClientSecret == "" {
+		cfg.ClientSecret = os.Getenv("OIDC_CLIENT_SECRET")
+	}
+	if cfg.SessionSecret == "" {
+		cfg.SessionSecret = os.Getenv("OIDC_SESSION_SECRET")
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+	if cfg.CookieName == "" {
+		cfg.CookieName = "session"
+	}
+	if cfg.SessionTTL <= 0 {
+		cfg.SessionTTL = 8 * time.Hour
+	}
+	if cfg.PostLogoutRedirectURL == "" {
+		cfg.PostLogoutRedirectURL = "/"
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate reports missing or unsafe settings.
+func (c Config) Validate() error {
+	var missing []string
+	for key, value := range map[string]string{
+		"oidc.issuer_url":   c.IssuerURL,
+		"oidc.client_id":    c.ClientID,
+		"oidc.redirect_url": c.RedirectURL,
+	} {
+		if value == "" {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("oidc: missing configuration: %s", strings.Join(missing, ", "))
+	}
+	if len(c.SessionSecret) < 32 {
+		return fmt.Errorf("oidc: the session secret must be at least 32 characters (set OIDC_SESSION_SECRET)")
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+)
+
+// Authenticator signs users in with the authorization code flow and PKCE.
+type Authenticator struct {
+	cfg        Config
+	oauth2     oauth2.Config
+	verifier   *oidc.IDTokenVerifier
+	codec      *cookieCodec
+	endSession string // RP-initiated logout endpoint, if the provider has one
+}
+
+// New discovers the provider's endpoints from cfg.IssuerURL.
+func New(ctx context.Context, cfg Config) (*Authenticator, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	provider, err := oidc.NewProvider(ctx, cfg.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: discovery failed for %s: %w", cfg.IssuerURL, err)
+	}
+	var metadata struct {
+		EndSession string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&metadata); err != nil {
+		return nil, fmt.Errorf("oidc: invalid provider metadata: %w", err)
+	}
+
+	codec, err := newCookieCodec(cfg.SessionSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Authenticator{
+		cfg: cfg,
+		oauth2: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       cfg.Scopes,
+		},
+		verifier:   provider.Verifier(&oidc.Config{ClientID: cfg.ClientID}),
+		codec:      codec,
+		endSession: metadata.EndSession,
+	}, nil
+}
+
+// RegisterRoutes mounts the login, callback, and logout handlers.
+func (a *Authenticator) RegisterRoutes(router gin.IRouter) {
+	router.GET(LoginPath, a.Login)
+	router.GET(CallbackPath, a.Callback)
+	router.GET(LogoutPath, a.Logout)
+	router.POST(LogoutPath, a.Logout)
+}
+
+// Login redirects to the provider. The optional return_to query parameter
+// is where the user lands after signing in.
+func (a *Authenticator) Login(c *gin.Context) {
+	state := loginState{
+		State:     randomToken(),
+		Nonce:     randomToken(),
+		Verifier:  oauth2.GenerateVerifier(),
+		ReturnTo:  safeReturnTo(c.Query("return_to")),
+		ExpiresAt: time.Now().Add(stateLifetime),
+	}
+	value, err := a.codec.encode(stateCookie, state)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "could not start login"})
+		return
+	}
+	a.setCookie(c, stateCookie, value, stateLifetime)
+
+	c.Redirect(http.StatusFound, a.oauth2.AuthCodeURL(state.State,
+		oidc.Nonce(state.Nonce),
+		oauth2.S256ChallengeOption(state.Verifier),
+	))
+}
+
+// Callback completes the login: it checks the state, exchanges the code
+// with the PKCE verifier, verifies the ID token, and starts a session.
+func (a *Authenticator) Callback(c *gin.Context) {
+	if providerErr := c.Query("error"); providerErr != "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": providerErr, "description": c.Query("error_description")})
+		return
+	}
+
+	var state loginState
+	value, err := c.Cookie(stateCookie)
+	if err != nil || a.codec.decode(stateCookie, value, &state) != nil || time.Now().After(state.ExpiresAt) {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "login expired, please try again"})
+		return
+	}
+	a.clearCookie(c, stateCookie)
+
+	if subtle.ConstantTimeCompare([]byte(c.Query("state")), []byte(state.State)) != 1 {
+		c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "invalid state"})
+		return
+	}
+
+	ctx := c.Request.Context()
+	token, err := a.oauth2.Exchange(ctx, c.Query("code"), oauth2.VerifierOption(state.Verifier))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "code exchange failed"})
+		return
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "no id_token in token response"})
+		return
+	}
+	idToken, err := a.verifier.Verify(ctx, rawIDToken)
+	if err != nil || subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(state.Nonce)) != 1 {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid id_token"})
+		return
+	}
+
+	var claims struct {
+		Email string `json:"email"`
+		Name  string `json:"name"`
+	}
+	if err := idToken.Claims(&claims); err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid id_token claims"})
+		return
+	}
+
+	session := Session{
+		Subject:   idToken.Subject,
+		Email:     claims.Email,
+		Name:      claims.Name,
+		IDToken:   rawIDToken,
+		ExpiresAt: time.Now().Add(a.cfg.SessionTTL),
+	}
+	if err := a.saveSession(c, session); err != nil {
+		c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": "could not start session"})
+		return
+	}
+	c.Redirect(http.StatusFound, state.ReturnTo)
+}
+
+// Logout ends the local session and, when the provider supports it, the
+// provider session too.
+func (a *Authenticator) Logout(c *gin.Context) {
+	session, _ := a.loadSession(c)
+	a.clearCookie(c, a.cfg.CookieName)
+
+	target := a.cfg.PostLogoutRedirectURL
+	switch {
+	case a.endSession != "":
+		u, err := url.Parse(a.endSession)
+		if err != nil {
+			break
+		}
+		q := u.Query()
+		q.Set("client_id", a.cfg.ClientID)
+		q.Set("post_logout_redirect_uri", target)
+		if session != nil {
+			q.Set("id_token_hint", session.IDToken)
+		}
+		u.RawQuery = q.Encode()
+		target = u.String()
+	case a.cfg.Provider == "auth0":
+		// Auth0 tenants without RP-initiated logout use their own endpoint
+		q := url.Values{"client_id": {a.cfg.ClientID}, "returnTo": {target}}
+		target = strings.TrimSuffix(a.cfg.IssuerURL, "/") + "/v2/logout?" + q.Encode()
+	}
+	c.Redirect(http.StatusFound, target)
+}
+
+// randomToken returns 32 random bytes, base64url encoded.
+func randomToken() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err) // crypto/rand never fails on supported platforms
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// safeReturnTo only allows local paths, preventing open redirects.
+func safeReturnTo(target string) string {
+	if !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") || strings.HasPrefix(target, "/\\") {
+		return "/"
+	}
+	return target
+}
@@ -0,0 +1,138 @@
+package {{.PackageName}}
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Session is the signed-in user, kept encrypted in a cookie so no server
+// side storage is needed.
+type Session struct {
+	Subject   string    `json:"sub"`
+	Email     string    `json:"email,omitempty"`
+	Name      string    `json:"name,omitempty"`
+	IDToken   string    `json:"id_token"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+// ErrNoSession is returned when a request has no valid session.
+var ErrNoSession = errors.New("oidc: no valid session")
+
+// loginState survives the round trip to the provider in a short-lived cookie.
+type loginState struct {
+	State     string    `json:"state"`
+	Nonce     string    `json:"nonce"`
+	Verifier  string    `json:"verifier"` // PKCE code verifier
+	ReturnTo  string    `json:"return_to"`
+	ExpiresAt time.Time `json:"exp"`
+}
+
+const (
+	stateCookie   = "oidc_state"
+	stateLifetime = 10 * time.Minute
+	sessionKey    = "oidc.session"
+)
+
+// cookieCodec encrypts and authenticates cookie values with AES-GCM. The
+// cookie name is bound as additional data so values cannot be swapped.
+type cookieCodec struct {
+	aead cipher.AEAD
+}
+
+func newCookieCodec(secret string) (*cookieCodec, error) {
+	key := sha256.Sum256([]byte(secret))
+	block, err := aes.NewCipher(key[:])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &cookieCodec{aead: aead}, nil
+}
+
+func (cc *cookieCodec) encode(name string, v any) (string, error) {
+	plaintext, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, cc.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	sealed := cc.aead.Seal(nonce, nonce, plaintext, []byte(name))
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+func (cc *cookieCodec) decode(name, value string, v any) error {
+	sealed, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil || len(sealed) < cc.aead.NonceSize() {
+		return ErrNoSession
+	}
+	nonce, ciphertext := sealed[:cc.aead.NonceSize()], sealed[cc.aead.NonceSize():]
+	plaintext, err := cc.aead.Open(nil, nonce, ciphertext, []byte(name))
+	if err != nil {
+		return ErrNoSession
+	}
+	return json.Unmarshal(plaintext, v)
+}
+
+// saveSession stores the session in the session cookie.
+func (a *Authenticator) saveSession(c *gin.Context, session Session) error {
+	value, err := a.codec.encode(a.cfg.CookieName, session)
+	if err != nil {
+		return err
+	}
+	a.setCookie(c, a.cfg.CookieName, value, time.Until(session.ExpiresAt))
+	return nil
+}
+
+// loadSession reads and checks the session cookie.
+func (a *Authenticator) loadSession(c *gin.Context) (*Session, error) {
+	value, err := c.Cookie(a.cfg.CookieName)
+	if err != nil {
+		return nil, ErrNoSession
+	}
+	var session Session
+	if err := a.codec.decode(a.cfg.CookieName, value, &session); err != nil {
+		return nil, ErrNoSession
+	}
+	if time.Now().After(session.ExpiresAt) {
+		return nil, ErrNoSession
+	}
+	return &session, nil
+}
+
+func (a *Authenticator) setCookie(c *gin.Context, name, value string, maxAge time.Duration) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(maxAge.Seconds()),
+		HttpOnly: true,
+		Secure:   a.cfg.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (a *Authenticator) clearCookie(c *gin.Context, name string) {
+	http.SetCookie(c.Writer, &http.Cookie{
+		Name:     name,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   a.cfg.SecureCookies,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
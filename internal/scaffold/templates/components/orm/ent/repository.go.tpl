@@ -0,0 +1,88 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"errors"
+
+	"{{.ModulePath}}/ent"
+	domain "{{.Imports.model}}"
+	ports "{{.Imports.port}}"
+)
+
+// ensure {{.NameTitle}}Repository implements the port at compile time.
+var _ ports.{{.NameTitle}}Repository = (*{{.NameTitle}}Repository)(nil)
+
+// {{.NameTitle}}Repository handles database operations for {{.Name}} entities
+// through the ent client generated from ent/schema.
+type {{.NameTitle}}Repository struct {
+	client *ent.Client
+}
+
+// New{{.NameTitle}}Repository creates a new {{.NameTitle}}Repository.
+func New{{.NameTitle}}Repository(client *ent.Client) *{{.NameTitle}}Repository {
+	return &{{.NameTitle}}Repository{client: client}
+}
+
+// FindByID retrieves a {{.Name}} by ID.
+func (r *{{.NameTitle}}Repository) FindByID(ctx context.Context, id int64) (*domain.{{.NameTitle}}, error) {
+	row, err := r.client.{{.NameTitle}}.Get(ctx, int(id))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("{{.Name}} not found")
+		}
+		return nil, err
+	}
+	return to{{.NameTitle}}(row), nil
+}
+
+// Create inserts a new {{.Name}} into the database.
+func (r *{{.NameTitle}}Repository) Create(ctx context.Context, {{.Name}} *domain.{{.NameTitle}}) error {
+	row, err := r.client.{{.NameTitle}}.Create().Save(ctx)
+	if err != nil {
+		return err
+	}
+	*{{.Name}} = *to{{.NameTitle}}(row)
+	return nil
+}
+
+// Update modifies an existing {{.Name}} in the database.
+func (r *{{.NameTitle}}Repository) Update(ctx context.Context, {{.Name}} *domain.{{.NameTitle}}) error {
+	row, err := r.client.{{.NameTitle}}.UpdateOneID(int({{.Name}}.ID)).Save(ctx)
+	if err != nil {
+		return err
+	}
+	{{.Name}}.UpdatedAt = row.UpdatedAt
+	return nil
+}
+
+// Delete removes a {{.Name}} from the database.
+func (r *{{.NameTitle}}Repository) Delete(ctx context.Context, id int64) error {
+	return r.client.{{.NameTitle}}.DeleteOneID(int(id)).Exec(ctx)
+}
+
+// List retrieves multiple {{.Name | pluralize}} with pagination.
+func (r *{{.NameTitle}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.NameTitle}}, error) {
+	rows, err := r.client.{{.NameTitle}}.Query().
+		Order(ent.Desc("created_at")).
+		Limit(limit).
+		Offset(offset).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	{{.Name | pluralize}} := make([]*domain.{{.NameTitle}}, 0, len(rows))
+	for _, row := range rows {
+		{{.Name | pluralize}} = append({{.Name | pluralize}}, to{{.NameTitle}}(row))
+	}
+	return {{.Name | pluralize}}, nil
+}
+
+// to{{.NameTitle}} maps an ent row to the domain model.
+func to{{.NameTitle}}(row *ent.{{.NameTitle}}) *domain.{{.NameTitle}} {
+	return &domain.{{.NameTitle}}{
+		ID:        int64(row.ID),
+		CreatedAt: row.CreatedAt,
+		UpdatedAt: row.UpdatedAt,
+	}
+}
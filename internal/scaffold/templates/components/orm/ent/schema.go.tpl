@@ -0,0 +1,37 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/entsql"
+	"entgo.io/ent/schema"
+	"entgo.io/ent/schema/field"
+)
+
+// {{.NameTitle}} holds the schema of the {{.Table}} table; after
+// changing it, regenerate the client with 'goforge run ent:generate' and add
+// a migration.
+type {{.NameTitle}} struct {
+	ent.Schema
+}
+
+// Annotations of the {{.NameTitle}}.
+func ({{.NameTitle}}) Annotations() []schema.Annotation {
+	return []schema.Annotation{
+		entsql.Annotation{Table: "{{.Table}}"},
+	}
+}
+
+// Fields of the {{.NameTitle}}.
+func ({{.NameTitle}}) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").Default(time.Now).Immutable(),
+		field.Time("updated_at").Default(time.Now).UpdateDefault(time.Now),
+	}
+}
+
+// Edges of the {{.NameTitle}}.
+func ({{.NameTitle}}) Edges() []ent.Edge {
+	return nil
+}
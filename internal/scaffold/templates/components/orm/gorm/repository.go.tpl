@@ -0,0 +1,63 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	domain "{{.Imports.model}}"
+	ports "{{.Imports.port}}"
+)
+
+// ensure {{.NameTitle}}Repository implements the port at compile time.
+var _ ports.{{.NameTitle}}Repository = (*{{.NameTitle}}Repository)(nil)
+
+// {{.NameTitle}}Repository handles database operations for {{.Name}} entities.
+type {{.NameTitle}}Repository struct {
+	db *gorm.DB
+}
+
+// New{{.NameTitle}}Repository creates a new {{.NameTitle}}Repository.
+func New{{.NameTitle}}Repository(db *gorm.DB) *{{.NameTitle}}Repository {
+	return &{{.NameTitle}}Repository{db: db}
+}
+
+// FindByID retrieves a {{.Name}} by ID.
+func (r *{{.NameTitle}}Repository) FindByID(ctx context.Context, id int64) (*domain.{{.NameTitle}}, error) {
+	{{.Name}} := &domain.{{.NameTitle}}{}
+	err := r.db.WithContext(ctx).First({{.Name}}, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("{{.Name}} not found")
+		}
+		return nil, err
+	}
+	return {{.Name}}, nil
+}
+
+// Create inserts a new {{.Name}} into the database; GORM fills in the ID
+// and timestamps.
+func (r *{{.NameTitle}}Repository) Create(ctx context.Context, {{.Name}} *domain.{{.NameTitle}}) error {
+	return r.db.WithContext(ctx).Create({{.Name}}).Error
+}
+
+// Update modifies an existing {{.Name}} in the database.
+func (r *{{.NameTitle}}Repository) Update(ctx context.Context, {{.Name}} *domain.{{.NameTitle}}) error {
+	return r.db.WithContext(ctx).Save({{.Name}}).Error
+}
+
+// Delete removes a {{.Name}} from the database.
+func (r *{{.NameTitle}}Repository) Delete(ctx context.Context, id int64) error {
+	return r.db.WithContext(ctx).Delete(&domain.{{.NameTitle}}{}, id).Error
+}
+
+// List retrieves multiple {{.Name | pluralize}} with pagination.
+func (r *{{.NameTitle}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.NameTitle}}, error) {
+	var {{.Name | pluralize}} []*domain.{{.NameTitle}}
+	err := r.db.WithContext(ctx).
+		Order("created_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&{{.Name | pluralize}}).Error
+	return {{.Name | pluralize}}, err
+}
@@ -1,8 +1,8 @@
-package ports
+package {{.PackageName}}
 
 import (
 	"context"
-	"{{.ModulePath}}/internal/domain"
+	domain "{{.Imports.model}}"
 )
 
 // {{.NameTitle}}Repository defines the contract for {{.Name}} data access.
@@ -0,0 +1 @@
+web: {{.Vars.binaryPath}}
@@ -0,0 +1,39 @@
+# systemd unit of {{.ProjectName}} generated by GoForge:
+#   goforge g systemd --env {{.Vars.env}}
+# Install it on the server as /etc/systemd/system/{{.Vars.service}}.service, then:
+#   sudo systemctl daemon-reload && sudo systemctl enable --now {{.Vars.service}}
+# Each release lives in {{.Vars.dir}}/releases, with {{.Vars.dir}}/current
+# pointing at the live one, as 'goforge deploy' with the ssh provider
+# leaves them.
+[Unit]
+Description={{.ProjectName}} ({{.Vars.env}})
+After=network-online.target
+Wants=network-online.target
+
+[Service]
+Type=simple
+User={{.Vars.user}}
+Group={{.Vars.user}}
+WorkingDirectory={{.Vars.dir}}/current
+ExecStart={{.Vars.dir}}/current/{{.Vars.binary}}
+# Settings and secrets of the {{.Vars.env}} environment, as KEY=value lines
+EnvironmentFile=-{{.Vars.envFile}}
+{{- range $key, $value := .Vars.environment}}
+Environment={{printf "%q" (printf "%s=%s" $key $value)}}
+{{- end}}
+Restart=on-failure
+RestartSec=5
+TimeoutStopSec=30
+KillSignal=SIGTERM
+
+# Hardening
+NoNewPrivileges=true
+PrivateTmp=true
+ProtectSystem=full
+ProtectHome=true
+
+# goforge:keep service-settings
+# goforge:end
+
+[Install]
+WantedBy=multi-user.target
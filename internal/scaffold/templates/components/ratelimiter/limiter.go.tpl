@@ -0,0 +1,90 @@
+// Package {{.PackageName}} provides request rate limiting shared across the application.
+//
+// Generated by goforge (strategy: {{.Vars.strategy}}, backend: {{.Vars.backend}}).
+package {{.PackageName}}
+
+import (
+	"context"
+	"time"
+)
+
+// Strategy selects the limiting algorithm.
+type Strategy string
+
+const (
+	// TokenBucket refills Limit tokens per Window and allows bursts up to Burst.
+	TokenBucket Strategy = "token-bucket"
+
+	// SlidingWindow allows at most Limit requests in any rolling Window.
+	SlidingWindow Strategy = "sliding-window"
+)
+
+// Rule describes how many requests a caller may make.
+type Rule struct {
+	Strategy Strategy
+	Limit    int           // Requests allowed per Window
+	Window   time.Duration // Period the Limit applies to
+	Burst    int           // Token bucket capacity; defaults to Limit
+}
+
+// Result reports the outcome of a single Allow call.
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Limiter is the port implemented by every rate limiting backend.
+type Limiter interface {
+	// Allow records a request for key and reports whether it may proceed.
+	Allow(ctx context.Context, key string, rule Rule) (Result, error)
+}
+
+// Config holds the default rule and per-route overrides. Routes are keyed
+// by method and Gin route pattern, e.g. "POST /api/v1/login".
+type Config struct {
+	Default Rule
+	Routes  map[string]Rule
+}
+
+// DefaultConfig returns the limits chosen when this package was generated.
+func DefaultConfig() Config {
+	return Config{
+		Default: Rule{
+			Strategy: {{.Vars.strategyConst}},
+			Limit:    {{.Vars.rate}},
+			Window:   {{.Vars.window}},
+			Burst:    {{.Vars.burst}},
+		},
+		Routes: map[string]Rule{
+			// "POST /api/v1/login": {Strategy: SlidingWindow, Limit: 5, Window: time.Minute},
+		},
+	}
+}
+
+// ruleFor returns the rule for a route and the scope its counters live in.
+// Routes without an override share the default rule's counters.
+func (c Config) ruleFor(route string) (string, Rule) {
+	if rule, ok := c.Routes[route]; ok {
+		return route, rule.withDefaults()
+	}
+	return "*", c.Default.withDefaults()
+}
+
+// withDefaults fills in unset fields.
+func (r Rule) withDefaults() Rule {
+	if r.Strategy == "" {
+		r.Strategy = TokenBucket
+	}
+	if r.Limit <= 0 {
+		r.Limit = 1
+	}
+	if r.Window <= 0 {
+		r.Window = time.Second
+	}
+	if r.Burst <= 0 {
+		r.Burst = r.Limit
+	}
+	return r
+}
@@ -0,0 +1,127 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Memory is an in-process Limiter. Counters are local to each instance, so
+// use a shared backend when running more than one replica.
+type Memory struct {
+	mu        sync.Mutex
+	buckets   map[string]*bucket
+	windows   map[string]*window
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	idle   time.Duration
+}
+
+type window struct {
+	start    time.Time
+	current  int
+	previous int
+	size     time.Duration
+}
+
+// NewMemory creates an empty in-memory limiter.
+func NewMemory() *Memory {
+	return &Memory{
+		buckets: make(map[string]*bucket),
+		windows: make(map[string]*window),
+		now:     time.Now,
+	}
+}
+
+// Allow implements Limiter.
+func (m *Memory) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	rule = rule.withDefaults()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	if rule.Strategy == SlidingWindow {
+		return m.slidingWindow(key, rule, now), nil
+	}
+	return m.tokenBucket(key, rule, now), nil
+}
+
+// tokenBucket refills the key's bucket for the elapsed time and takes a token.
+func (m *Memory) tokenBucket(key string, rule Rule, now time.Time) Result {
+	perSecond := float64(rule.Limit) / rule.Window.Seconds()
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(rule.Burst), last: now}
+		m.buckets[key] = b
+	}
+	b.tokens = math.Min(float64(rule.Burst), b.tokens+now.Sub(b.last).Seconds()*perSecond)
+	b.last = now
+	b.idle = time.Duration(float64(rule.Burst) / perSecond * float64(time.Second))
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / perSecond
+		return Result{Limit: rule.Burst, RetryAfter: time.Duration(wait * float64(time.Second))}
+	}
+	b.tokens--
+	return Result{Allowed: true, Limit: rule.Burst, Remaining: int(b.tokens)}
+}
+
+// slidingWindow weights the previous fixed window by how much of it still
+// overlaps the rolling window, which approximates a request log in O(1) space.
+func (m *Memory) slidingWindow(key string, rule Rule, now time.Time) Result {
+	w, ok := m.windows[key]
+	if !ok {
+		w = &window{start: now.Truncate(rule.Window)}
+		m.windows[key] = w
+	}
+	w.size = rule.Window
+
+	if elapsed := now.Sub(w.start); elapsed >= rule.Window {
+		periods := int(elapsed / rule.Window)
+		w.previous = 0
+		if periods == 1 {
+			w.previous = w.current
+		}
+		w.current = 0
+		w.start = w.start.Add(time.Duration(periods) * rule.Window)
+	}
+
+	overlap := 1 - float64(now.Sub(w.start))/float64(rule.Window)
+	estimate := float64(w.previous)*overlap + float64(w.current)
+	if estimate+1 > float64(rule.Limit) {
+		return Result{Limit: rule.Limit, RetryAfter: w.start.Add(rule.Window).Sub(now)}
+	}
+
+	w.current++
+	return Result{Allowed: true, Limit: rule.Limit, Remaining: int(float64(rule.Limit) - estimate - 1)}
+}
+
+// sweep drops state for keys that have been idle long enough to be full or
+// expired again, so memory stays bounded by the number of active callers.
+func (m *Memory) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < time.Minute {
+		return
+	}
+	m.lastSweep = now
+
+	for key, b := range m.buckets {
+		if now.Sub(b.last) > b.idle {
+			delete(m.buckets, key)
+		}
+	}
+	for key, w := range m.windows {
+		if now.Sub(w.start) > 2*w.size {
+			delete(m.windows, key)
+		}
+	}
+}
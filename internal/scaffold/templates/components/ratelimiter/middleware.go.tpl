@@ -0,0 +1,48 @@
+package {{.PackageName}}
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// KeyFunc identifies the caller a limit applies to.
+type KeyFunc func(c *gin.Context) string
+
+// ClientIP limits each client IP address separately.
+func ClientIP(c *gin.Context) string {
+	return c.ClientIP()
+}
+
+// Middleware enforces cfg using limiter. Routes listed in cfg.Routes get
+// their own counters; all other routes share the default rule's counters.
+// If keyFunc is nil, callers are identified by client IP.
+func Middleware(limiter Limiter, cfg Config, keyFunc KeyFunc) gin.HandlerFunc {
+	if keyFunc == nil {
+		keyFunc = ClientIP
+	}
+
+	return func(c *gin.Context) {
+		scope, rule := cfg.ruleFor(c.Request.Method + " " + c.FullPath())
+
+		res, err := limiter.Allow(c.Request.Context(), scope+"|"+keyFunc(c), rule)
+		if err != nil {
+			// Fail open: an unavailable backend should not take the API down.
+			_ = c.Error(err)
+			c.Next()
+			return
+		}
+
+		c.Header("X-RateLimit-Limit", strconv.Itoa(res.Limit))
+		c.Header("X-RateLimit-Remaining", strconv.Itoa(res.Remaining))
+
+		if !res.Allowed {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(res.RetryAfter.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
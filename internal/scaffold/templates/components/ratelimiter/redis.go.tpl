@@ -0,0 +1,98 @@
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// tokenBucketScript refills and takes a token atomically.
+// KEYS[1] bucket; ARGV: tokens per ms, capacity, now (ms).
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local capacity = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or capacity
+local ts = tonumber(state[2]) or now
+tokens = math.min(capacity, tokens + (now - ts) * rate)
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(capacity / rate))
+return {allowed, math.floor(tokens)}
+`)
+
+// slidingWindowScript keeps a log of request timestamps in a sorted set.
+// KEYS[1] log; ARGV: window (ms), limit, now (ms), unique member.
+var slidingWindowScript = redis.NewScript(`
+local window = tonumber(ARGV[1])
+local limit = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], 0, now - window)
+local count = redis.call('ZCARD', KEYS[1])
+if count < limit then
+  redis.call('ZADD', KEYS[1], now, ARGV[4])
+  redis.call('PEXPIRE', KEYS[1], window)
+  return {1, limit - count - 1, 0}
+end
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+return {0, 0, tonumber(oldest[2]) + window - now}
+`)
+
+// Redis is a Limiter whose counters are shared by every instance using the
+// same Redis deployment.
+type Redis struct {
+	client redis.UniversalClient
+	prefix string
+	seq    atomic.Uint64
+}
+
+// NewRedis creates a Redis-backed limiter. Keys are stored under prefix.
+func NewRedis(client redis.UniversalClient, prefix string) *Redis {
+	if prefix == "" {
+		prefix = "ratelimit:"
+	}
+	return &Redis{client: client, prefix: prefix}
+}
+
+// Allow implements Limiter.
+func (r *Redis) Allow(ctx context.Context, key string, rule Rule) (Result, error) {
+	rule = rule.withDefaults()
+	now := time.Now().UnixMilli()
+
+	if rule.Strategy == SlidingWindow {
+		member := strconv.FormatInt(now, 10) + "-" + strconv.FormatUint(r.seq.Add(1), 10)
+		res, err := slidingWindowScript.Run(ctx, r.client, []string{r.prefix + "sw:" + key},
+			rule.Window.Milliseconds(), rule.Limit, now, member).Int64Slice()
+		if err != nil {
+			return Result{}, fmt.Errorf("rate limit check failed: %w", err)
+		}
+		return Result{
+			Allowed:    res[0] == 1,
+			Limit:      rule.Limit,
+			Remaining:  int(res[1]),
+			RetryAfter: time.Duration(res[2]) * time.Millisecond,
+		}, nil
+	}
+
+	perMilli := float64(rule.Limit) / float64(rule.Window.Milliseconds())
+	res, err := tokenBucketScript.Run(ctx, r.client, []string{r.prefix + "tb:" + key},
+		perMilli, rule.Burst, now).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("rate limit check failed: %w", err)
+	}
+
+	result := Result{Allowed: res[0] == 1, Limit: rule.Burst, Remaining: int(res[1])}
+	if !result.Allowed {
+		result.RetryAfter = time.Duration(float64(time.Millisecond) / perMilli)
+	}
+	return result, nil
+}
@@ -1,5 +1,5 @@
 // internal/scaffold/templates/components/repository.go.tpl
-package postgres
+package {{.PackageName}}
 
 import (
 	"context"
@@ -7,8 +7,8 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"{{.ModulePath}}/internal/domain"
-	"{{.ModulePath}}/internal/ports"
+	domain "{{.Imports.model}}"
+	ports "{{.Imports.port}}"
 )
 
 // ensure {{.NameTitle}}Repository implements the port at compile time.
@@ -27,7 +27,7 @@ func New{{.NameTitle}}Repository(pool *pgxpool.Pool) *{{.NameTitle}}Repository {
 // FindByID retrieves a {{.Name}} by ID.
 func (r *{{.NameTitle}}Repository) FindByID(ctx context.Context, id int64) (*domain.{{.NameTitle}}, error) {
 	{{.Name}} := &domain.{{.NameTitle}}{}
-	query := "SELECT id, created_at, updated_at FROM {{.Name | pluralize}} WHERE id = $1"
+	query := "SELECT id, created_at, updated_at FROM {{.Table}} WHERE id = $1"
 
 	err := r.pool.QueryRow(ctx, query, id).Scan(&{{.Name}}.ID, &{{.Name}}.CreatedAt, &{{.Name}}.UpdatedAt)
 	if err != nil {
@@ -41,7 +41,7 @@ func (r *{{.NameTitle}}Repository) FindByID(ctx context.Context, id int64) (*dom
 
 // Create inserts a new {{.Name}} into the database.
 func (r *{{.NameTitle}}Repository) Create(ctx context.Context, {{.Name}} *domain.{{.NameTitle}}) error {
-	query := `INSERT INTO {{.Name | pluralize}} (created_at, updated_at) 
+	query := `INSERT INTO {{.Table}} (created_at, updated_at) 
 			  VALUES (NOW(), NOW()) 
 			  RETURNING id, created_at, updated_at`
 	
@@ -51,7 +51,7 @@ func (r *{{.NameTitle}}Repository) Create(ctx context.Context, {{.Name}} *domain
 
 // Update modifies an existing {{.Name}} in the database.
 func (r *{{.NameTitle}}Repository) Update(ctx context.Context, {{.Name}} *domain.{{.NameTitle}}) error {
-	query := `UPDATE {{.Name | pluralize}} 
+	query := `UPDATE {{.Table}} 
 			  SET updated_at = NOW() 
 			  WHERE id = $1 
 			  RETURNING updated_at`
@@ -62,7 +62,7 @@ func (r *{{.NameTitle}}Repository) Update(ctx context.Context, {{.Name}} *domain
 
 // Delete removes a {{.Name}} from the database.
 func (r *{{.NameTitle}}Repository) Delete(ctx context.Context, id int64) error {
-	query := "DELETE FROM {{.Name | pluralize}} WHERE id = $1"
+	query := "DELETE FROM {{.Table}} WHERE id = $1"
 	_, err := r.pool.Exec(ctx, query, id)
 	return err
 }
@@ -70,7 +70,7 @@ func (r *{{.NameTitle}}Repository) Delete(ctx context.Context, id int64) error {
 // List retrieves multiple {{.Name | pluralize}} with pagination.
 func (r *{{.NameTitle}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{.NameTitle}}, error) {
 	query := `SELECT id, created_at, updated_at 
-			  FROM {{.Name | pluralize}} 
+			  FROM {{.Table}} 
 			  ORDER BY created_at DESC 
 			  LIMIT $1 OFFSET $2`
 	
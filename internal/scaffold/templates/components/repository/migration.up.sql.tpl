@@ -0,0 +1,8 @@
+-- Creates the {{.Vars.table}} table for domain.{{.NameTitle}}; generated by
+-- 'goforge generate repository {{.Name}} --with {{.Vars.with}}'.
+-- Review the column types and add indexes and constraints before applying it.
+CREATE TABLE IF NOT EXISTS {{.Vars.table}} (
+{{- range $i, $c := .Vars.columns}}{{if $i}},{{end}}
+    {{$c.Column}} {{$c.SQLType}}
+{{- end}}
+);
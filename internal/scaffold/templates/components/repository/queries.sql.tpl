@@ -0,0 +1,34 @@
+{{- $t := .NameTitle -}}
+{{- $table := .Vars.table -}}
+-- Queries of {{$t}}Repository. After changing them, regenerate the Go code
+-- with 'goforge run sqlc:generate'.
+
+-- name: Get{{$t}} :one
+SELECT * FROM {{$table}}
+WHERE id = $1 LIMIT 1;
+
+-- name: List{{.Vars.tableTitle}} :many
+SELECT * FROM {{$table}}
+ORDER BY {{.Vars.orderBy}}
+LIMIT $1 OFFSET $2;
+
+-- name: Create{{$t}} :one
+{{- if .Vars.writable}}
+INSERT INTO {{$table}} ({{.Vars.insertColumns}})
+VALUES ({{.Vars.insertValues}})
+{{- else}}
+INSERT INTO {{$table}} DEFAULT VALUES
+{{- end}}
+RETURNING *;
+{{- if .Vars.updateSet}}
+
+-- name: Update{{$t}} :one
+UPDATE {{$table}}
+SET {{.Vars.updateSet}}
+WHERE id = $1
+RETURNING *;
+{{- end}}
+
+-- name: Delete{{$t}} :exec
+DELETE FROM {{$table}}
+WHERE id = $1;
@@ -0,0 +1,125 @@
+{{- $t := .NameTitle -}}
+{{- $var := .Vars.variable -}}
+{{- $label := .Vars.label -}}
+package {{.PackageName}}
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "{{.Imports.model}}"
+	ports "{{.Imports.port}}"
+	"{{.Vars.sqlcImport}}"
+)
+
+// ensure {{$t}}Repository implements the port at compile time.
+var _ ports.{{$t}}Repository = (*{{$t}}Repository)(nil)
+
+// {{$t}}Repository stores {{$label}} entities with the queries sqlc generates
+// from {{.Vars.queriesFile}}.
+type {{$t}}Repository struct {
+	queries *{{.Vars.sqlcPackage}}.Queries
+}
+
+// New{{$t}}Repository creates a new {{$t}}Repository.
+func New{{$t}}Repository(pool *pgxpool.Pool) *{{$t}}Repository {
+	return &{{$t}}Repository{queries: {{.Vars.sqlcPackage}}.New(pool)}
+}
+
+// FindByID retrieves a {{$label}} by ID.
+func (r *{{$t}}Repository) FindByID(ctx context.Context, id int64) (*domain.{{$t}}, error) {
+	row, err := r.queries.Get{{$t}}(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("{{$label}} not found")
+		}
+		return nil, err
+	}
+	return to{{$t}}(row), nil
+}
+
+// Create inserts a new {{$label}} and reads back the columns the database sets.
+func (r *{{$t}}Repository) Create(ctx context.Context, {{$var}} *domain.{{$t}}) error {
+{{- if not .Vars.writable}}
+	row, err := r.queries.Create{{$t}}(ctx)
+{{- else if eq (len .Vars.writable) 1}}
+	row, err := r.queries.Create{{$t}}(ctx, {{(index .Vars.writable 0).ToParam}})
+{{- else}}
+	row, err := r.queries.Create{{$t}}(ctx, {{.Vars.sqlcPackage}}.Create{{$t}}Params{
+{{- range .Vars.writable}}
+		{{.SQLCField}}: {{.ToParam}},
+{{- end}}
+	})
+{{- end}}
+	if err != nil {
+		return err
+	}
+{{- range .Vars.returning}}
+	{{$var}}.{{.Field}} = {{.FromRow}}
+{{- end}}
+	return nil
+}
+
+// Update modifies an existing {{$label}}.
+func (r *{{$t}}Repository) Update(ctx context.Context, {{$var}} *domain.{{$t}}) error {
+{{- if not .Vars.updateSet}}
+	_, err := r.FindByID(ctx, {{$var}}.ID)
+	return err
+{{- else}}
+{{- if not .Vars.writable}}
+	row, err := r.queries.Update{{$t}}(ctx, {{$var}}.ID)
+{{- else}}
+	row, err := r.queries.Update{{$t}}(ctx, {{.Vars.sqlcPackage}}.Update{{$t}}Params{
+		ID: {{$var}}.ID,
+{{- range .Vars.writable}}
+		{{.SQLCField}}: {{.ToParam}},
+{{- end}}
+	})
+{{- end}}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("{{$label}} not found")
+		}
+		return err
+	}
+{{- range .Vars.returning}}
+{{- if eq .Column "updated_at"}}
+	{{$var}}.{{.Field}} = {{.FromRow}}
+{{- end}}
+{{- end}}
+	return nil
+{{- end}}
+}
+
+// Delete removes a {{$label}}.
+func (r *{{$t}}Repository) Delete(ctx context.Context, id int64) error {
+	return r.queries.Delete{{$t}}(ctx, id)
+}
+
+// List retrieves {{$label}} entities with pagination.
+func (r *{{$t}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{$t}}, error) {
+	rows, err := r.queries.List{{.Vars.tableTitle}}(ctx, {{.Vars.sqlcPackage}}.List{{.Vars.tableTitle}}Params{
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	{{.Vars.plural}} := make([]*domain.{{$t}}, 0, len(rows))
+	for _, row := range rows {
+		{{.Vars.plural}} = append({{.Vars.plural}}, to{{$t}}(row))
+	}
+	return {{.Vars.plural}}, nil
+}
+
+// to{{$t}} maps a {{.Vars.table}} row to the domain model.
+func to{{$t}}(row {{.Vars.sqlcPackage}}.{{$t}}) *domain.{{$t}} {
+	return &domain.{{$t}}{
+{{- range .Vars.columns}}
+		{{.Field}}: {{.FromRow}},
+{{- end}}
+	}
+}
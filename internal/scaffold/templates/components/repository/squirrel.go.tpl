@@ -0,0 +1,151 @@
+{{- $t := .NameTitle -}}
+{{- $var := .Vars.variable -}}
+{{- $label := .Vars.label -}}
+{{- $table := .Vars.table -}}
+package {{.PackageName}}
+
+import (
+	"context"
+	"errors"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	domain "{{.Imports.model}}"
+	ports "{{.Imports.port}}"
+)
+
+// ensure {{$t}}Repository implements the port at compile time.
+var _ ports.{{$t}}Repository = (*{{$t}}Repository)(nil)
+
+// {{$var}}Columns are the columns of {{$table}}, in the order find scans them.
+var {{$var}}Columns = []string{
+{{- range .Vars.columns}}
+	"{{.Column}}",
+{{- end}}
+}
+
+// {{$t}}Repository stores {{$label}} entities with queries built by squirrel.
+type {{$t}}Repository struct {
+	pool *pgxpool.Pool
+}
+
+// New{{$t}}Repository creates a new {{$t}}Repository.
+func New{{$t}}Repository(pool *pgxpool.Pool) *{{$t}}Repository {
+	return &{{$t}}Repository{pool: pool}
+}
+
+// FindByID retrieves a {{$label}} by ID.
+func (r *{{$t}}Repository) FindByID(ctx context.Context, id int64) (*domain.{{$t}}, error) {
+	found, err := r.find(ctx, psql.Select({{$var}}Columns...).From("{{$table}}").Where(sq.Eq{"id": id}).Limit(1))
+	if err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, errors.New("{{$label}} not found")
+	}
+	return found[0], nil
+}
+
+// Create inserts a new {{$label}} and reads back the columns the database sets.
+func (r *{{$t}}Repository) Create(ctx context.Context, {{$var}} *domain.{{$t}}) error {
+{{- if .Vars.writable}}
+	query, args, err := psql.Insert("{{$table}}").
+		Columns({{range $i, $c := .Vars.writable}}{{if $i}}, {{end}}"{{$c.Column}}"{{end}}).
+		Values({{range $i, $c := .Vars.writable}}{{if $i}}, {{end}}{{$var}}.{{$c.Field}}{{end}}).
+		Suffix("RETURNING {{range $i, $c := .Vars.returning}}{{if $i}}, {{end}}{{$c.Column}}{{end}}").
+		ToSql()
+	if err != nil {
+		return err
+	}
+{{- else}}
+	query := "INSERT INTO {{$table}} DEFAULT VALUES RETURNING {{range $i, $c := .Vars.returning}}{{if $i}}, {{end}}{{$c.Column}}{{end}}"
+	var args []any
+{{- end}}
+	return r.pool.QueryRow(ctx, query, args...).Scan({{range $i, $c := .Vars.returning}}{{if $i}}, {{end}}&{{$var}}.{{$c.Field}}{{end}})
+}
+
+// Update modifies an existing {{$label}}.
+func (r *{{$t}}Repository) Update(ctx context.Context, {{$var}} *domain.{{$t}}) error {
+{{- if not .Vars.updateSet}}
+	_, err := r.FindByID(ctx, {{$var}}.ID)
+	return err
+{{- else}}
+	query, args, err := psql.Update("{{$table}}").
+{{- range .Vars.writable}}
+		Set("{{.Column}}", {{$var}}.{{.Field}}).
+{{- end}}
+{{- if .Vars.hasUpdatedAt}}
+		Set("updated_at", sq.Expr("NOW()")).
+{{- end}}
+		Where(sq.Eq{"id": {{$var}}.ID}).
+{{- if .Vars.hasUpdatedAt}}
+		Suffix("RETURNING updated_at").
+{{- end}}
+		ToSql()
+	if err != nil {
+		return err
+	}
+{{- if .Vars.hasUpdatedAt}}
+
+	err = r.pool.QueryRow(ctx, query, args...).Scan(&{{$var}}.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return errors.New("{{$label}} not found")
+	}
+	return err
+{{- else}}
+
+	tag, err := r.pool.Exec(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return errors.New("{{$label}} not found")
+	}
+	return nil
+{{- end}}
+{{- end}}
+}
+
+// Delete removes a {{$label}}.
+func (r *{{$t}}Repository) Delete(ctx context.Context, id int64) error {
+	query, args, err := psql.Delete("{{$table}}").Where(sq.Eq{"id": id}).ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = r.pool.Exec(ctx, query, args...)
+	return err
+}
+
+// List retrieves {{$label}} entities with pagination.
+func (r *{{$t}}Repository) List(ctx context.Context, limit, offset int) ([]*domain.{{$t}}, error) {
+	return r.find(ctx, psql.Select({{$var}}Columns...).
+		From("{{$table}}").
+		OrderBy("{{.Vars.orderBy}}").
+		Limit(uint64(limit)).
+		Offset(uint64(offset)))
+}
+
+// find runs a select of {{$var}}Columns and scans the rows. Build further
+// queries on it, e.g. find(ctx, psql.Select({{$var}}Columns...).From("{{$table}}").Where(...)).
+func (r *{{$t}}Repository) find(ctx context.Context, builder sq.SelectBuilder) ([]*domain.{{$t}}, error) {
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var {{.Vars.plural}} []*domain.{{$t}}
+	for rows.Next() {
+		{{$var}} := &domain.{{$t}}{}
+		if err := rows.Scan({{range $i, $c := .Vars.columns}}{{if $i}}, {{end}}&{{$var}}.{{$c.Field}}{{end}}); err != nil {
+			return nil, err
+		}
+		{{.Vars.plural}} = append({{.Vars.plural}}, {{$var}})
+	}
+	return {{.Vars.plural}}, rows.Err()
+}
@@ -0,0 +1,8 @@
+package {{.PackageName}}
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+// psql builds the repositories' queries with PostgreSQL's $n placeholders.
+var psql = sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
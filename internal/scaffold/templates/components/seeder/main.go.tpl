@@ -0,0 +1,52 @@
+// Command seed fills the database configured in config/default.yml with
+// the sample data of the seeders in {{.Vars.seedsDir}}. Pass seeder names to
+// run only those, e.g. 'go run ./cmd/seed users'.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/viper"
+
+	"{{.Vars.seedsImport}}"
+)
+
+func main() {
+	logger := slog.New(slog.NewTextHandler(os.Stdout, nil))
+
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.AutomaticEnv()
+	if err := viper.ReadInConfig(); err != nil {
+		logger.Warn("could not read config, using the environment", "error", err)
+	}
+
+	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+		viper.GetString("database.host"),
+		viper.GetInt("database.port"),
+		viper.GetString("database.user"),
+		viper.GetString("database.password"),
+		viper.GetString("database.dbname"),
+		viper.GetString("database.sslmode"),
+	)
+
+	ctx := context.Background()
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		logger.Error("failed to connect to the database", "error", err)
+		os.Exit(1)
+	}
+
+	err = {{.Vars.seedsPackage}}.Run(ctx, pool, os.Args[1:]...)
+	pool.Close()
+	if err != nil {
+		logger.Error("seeding failed", "error", err)
+		os.Exit(1)
+	}
+	logger.Info("database seeded")
+}
@@ -0,0 +1,18 @@
+package {{.PackageName}}
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Seed{{.NameTitle}} inserts the {{.Name}} sample data.
+//
+// Seeders may run again on a seeded database, so insert idempotently,
+// e.g. with ON CONFLICT DO NOTHING.
+func Seed{{.NameTitle}}(ctx context.Context, tx pgx.Tx) error {
+	// TODO: insert the sample data, e.g.
+	// _, err := tx.Exec(ctx, `INSERT INTO {{.Vars.table}} (name) VALUES ($1) ON CONFLICT DO NOTHING`, "example")
+	// return err
+	return nil
+}
@@ -0,0 +1,55 @@
+// Package {{.PackageName}} holds the seeders filling a development
+// database with sample data. Run them with 'goforge run db:seed'.
+package {{.PackageName}}
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Seeder inserts one set of sample data.
+type Seeder struct {
+	Name string
+	Run  func(ctx context.Context, tx pgx.Tx) error
+}
+
+// All returns the seeders in the order they run. 'goforge generate seeder'
+// adds new seeders above the goforge:seeders marker; keep it in place.
+func All() []Seeder {
+	return []Seeder{
+		// goforge:seeders
+	}
+}
+
+// Run runs the named seeders, or all of them when no name is given, each
+// in its own transaction.
+func Run(ctx context.Context, pool *pgxpool.Pool, names ...string) error {
+	seeders := All()
+	if len(names) > 0 {
+		byName := make(map[string]Seeder, len(seeders))
+		for _, seeder := range seeders {
+			byName[seeder.Name] = seeder
+		}
+		seeders = nil
+		for _, name := range names {
+			seeder, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("unknown seeder %q", name)
+			}
+			seeders = append(seeders, seeder)
+		}
+	}
+
+	for _, seeder := range seeders {
+		err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			return seeder.Run(ctx, tx)
+		})
+		if err != nil {
+			return fmt.Errorf("seeder %s: %w", seeder.Name, err)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,7 @@
-package service
+package {{.PackageName}}
 
 import (
-	// "{{.ModulePath}}/internal/ports" // TODO: Uncomment when you add repository dependencies.
+	// ports "{{.Imports.port}}" // TODO: Uncomment when you add repository dependencies.
 )
 
 // {{.NameTitle}}Service provides application logic for the {{.Name}} resource.
@@ -28,3 +28,7 @@ func (s *{{.NameTitle}}Service) DoSomething(/* parameters like id, or a data str
 	// 3. Return the result or an error.
 	return nil
 }
+
+// goforge:keep custom
+// Code between these markers is preserved when this file is regenerated.
+// goforge:end
@@ -0,0 +1,80 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This event-driven API was generated by [GoForge](https://github.com/night-slayer18/goforge). It separates commands, which change state, from queries, which read it (CQRS), and connects the two with events published through a transactional outbox.
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+- PostgreSQL, configured in `config/default.yml`
+
+### How a Request Flows
+
+1. `POST /api/v1/orders` dispatches the `PlaceOrder` command on the **command bus**.
+2. Its handler builds the `Order` aggregate, which checks the business rules and records an `OrderPlaced` event.
+3. The repository saves the order and its events to the **outbox** table in one transaction, so an event exists exactly when its change does.
+4. The **outbox relay** publishes stored events to the **event bus**, where the **projection** writes the order into the `order_summaries` view.
+5. `GET /api/v1/orders/:id` asks the `GetOrder` query on the **query bus**, which reads the view.
+
+Views follow writes with a short delay (`outbox.poll_interval`), which is why placing an order answers `202 Accepted`. Events are delivered at least once, so projections are idempotent.
+
+### Project Layout
+
+- `internal/domain/` — the `Order` aggregate, its events, and `DecodeEvent`, which restores them from the outbox
+- `internal/app/command/` — the write side: commands and their handlers
+- `internal/app/query/` — the read side: queries and their handlers
+- `internal/app/projection/` — event subscribers maintaining the views
+- `internal/readmodel/` — the views the queries return
+- `internal/ports/` — the write repository and read model interfaces
+- `internal/platform/bus/` — the in-process command, query, and event buses
+- `internal/platform/outbox/` — the relay publishing stored events
+- `internal/adapters/postgres/` — the repository, read model, and outbox store
+- `internal/adapters/http/handler/` — the HTTP endpoints, dispatching commands and asking queries
+
+### Running the Application
+
+1.  **Create the tables:**
+    ```bash
+    goforge run db:migrate
+    ```
+
+2.  **Start the server:**
+    ```bash
+    goforge run dev
+    ```
+
+3.  **Place, read, and cancel an order:**
+    ```bash
+    curl -i -X POST localhost:8080/api/v1/orders -d '{"customer_id": "c1", "total": 1250}'
+    curl localhost:8080/api/v1/orders/<id>
+    curl localhost:8080/api/v1/orders?customer_id=c1
+    curl -X POST localhost:8080/api/v1/orders/<id>/cancel -d '{"reason": "changed my mind"}'
+    ```
+
+### Adding a Feature
+
+- **A command:** add a method recording a new event to the aggregate, the event to `events.go` (and to `decoders`), and a command with its handler in `internal/app/command`.
+- **A query:** add the query and its handler in `internal/app/query`, reading an existing view or a new one.
+- **A view:** add its type to `internal/readmodel`, a table in a migration, and a projection subscribing to the events that shape it. To fill a new view with past events, replay them from the `outbox` table.
+
+The relay retries a message that fails until it succeeds, holding back the ones after it; fix the subscriber or the message to let it continue.
+
+### Available Scripts
+
+-   `goforge run dev`: Starts the server.
+-   `goforge run db:migrate`: Applies the migrations in `migrations/`.
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"{{.ModuleName}}/internal/adapters/database"
+	"{{.ModuleName}}/internal/adapters/http/handler"
+	"{{.ModuleName}}/internal/adapters/postgres"
+	"{{.ModuleName}}/internal/app/command"
+	"{{.ModuleName}}/internal/app/projection"
+	"{{.ModuleName}}/internal/app/query"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/platform/bus"
+	"{{.ModuleName}}/internal/platform/outbox"
+)
+
+func main() {
+	// --- Configuration Setup ---
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // e.g. DATABASE_HOST overrides database.host
+	viper.AutomaticEnv()
+	if err := viper.ReadInConfig(); err != nil {
+		log.Fatalf("Error reading config file: %s", err)
+	}
+
+	port := viper.GetInt("server.port")
+	if port == 0 {
+		port = 8080 // Default port
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// --- Dependency Injection ---
+	// 1. Database Connection
+	dbPool := database.Connect()
+	defer dbPool.Close() // Ensure the connection pool is closed on exit
+
+	// 2. Buses
+	commands := bus.NewCommandBus()
+	queries := bus.NewQueryBus()
+	events := bus.NewEventBus()
+
+	// 3. Write side: command handlers storing aggregates and their events
+	command.NewHandlers(postgres.NewPostgresOrderRepository(dbPool)).Register(commands)
+
+	// 4. Read side: projections updating the views the queries read
+	summaries := postgres.NewPostgresOrderReadModel(dbPool)
+	projection.NewOrderSummaries(summaries).Register(events)
+	query.NewHandlers(summaries).Register(queries)
+
+	// 5. Outbox relay: publishes the stored events to the projections
+	relay := outbox.NewRelay(
+		postgres.NewPostgresOutboxStore(dbPool),
+		func(name string, payload []byte) (bus.Event, error) { return domain.DecodeEvent(name, payload) },
+		events,
+		viper.GetDuration("outbox.poll_interval"),
+		viper.GetInt("outbox.batch_size"),
+	)
+	relayDone := make(chan struct{})
+	go func() {
+		defer close(relayDone)
+		relay.Run(ctx)
+	}()
+
+	// 6. Handlers (Adapters)
+	orderHandler := handler.NewOrderHandler(commands, queries)
+
+	// --- Gin Router Setup ---
+	router := gin.Default()
+
+	api := router.Group("/api/v1")
+	{
+		orderRoutes := api.Group("/orders")
+		{
+			orderRoutes.POST("", orderHandler.PlaceOrder)
+			orderRoutes.GET("", orderHandler.ListOrders)
+			orderRoutes.GET("/:id", orderHandler.GetOrder)
+			orderRoutes.POST("/:id/cancel", orderHandler.CancelOrder)
+		}
+	}
+
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "UP"})
+	})
+
+	// --- Start Server ---
+	serverAddr := fmt.Sprintf(":%d", port)
+	server := &http.Server{Addr: serverAddr, Handler: router}
+	go func() {
+		fmt.Printf("🚀 Server starting on http://localhost%s\n", serverAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("❌ Could not start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown: %v", err)
+	}
+	<-relayDone
+}
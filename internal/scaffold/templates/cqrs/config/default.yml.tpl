@@ -0,0 +1,23 @@
+# Default application configuration.
+# These values can be overridden by environment variables.
+server:
+  host: "localhost"
+  port: 8080
+
+logging:
+  level: "debug" # Options: debug, info, warn, error
+
+# Database connection settings for PostgreSQL.
+database:
+  host: "localhost"
+  port: 5432
+  user: "postgres"
+  password: "password"
+  dbname: "{{.ProjectName}}_db"
+  sslmode: "disable" # Use "require" or "verify-full" in production.
+
+# Outbox relay: how often it publishes the events stored with each command,
+# and how many per batch.
+outbox:
+  poll_interval: "1s"
+  batch_size: 100
@@ -0,0 +1,119 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"{{.ModuleName}}/internal/app/command"
+	"{{.ModuleName}}/internal/app/query"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/platform/bus"
+	"{{.ModuleName}}/internal/readmodel"
+)
+
+// OrderHandler handles HTTP requests related to orders: writes go to the
+// command bus, reads to the query bus.
+type OrderHandler struct {
+	commands *bus.CommandBus
+	queries  *bus.QueryBus
+}
+
+// NewOrderHandler creates a new OrderHandler.
+func NewOrderHandler(commands *bus.CommandBus, queries *bus.QueryBus) *OrderHandler {
+	return &OrderHandler{commands: commands, queries: queries}
+}
+
+type placeOrderRequest struct {
+	CustomerID string `json:"customer_id" binding:"required"`
+	Total      int64  `json:"total" binding:"required"`
+}
+
+type cancelOrderRequest struct {
+	Reason string `json:"reason"`
+}
+
+// PlaceOrder handles the POST /api/v1/orders endpoint. It answers 202
+// Accepted with the order's location: the summary appears once the outbox
+// relay has published the order's events.
+func (h *OrderHandler) PlaceOrder(c *gin.Context) {
+	var req placeOrderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	cmd := command.PlaceOrder{OrderID: domain.NewOrderID(), CustomerID: req.CustomerID, Total: req.Total}
+	if err := h.commands.Dispatch(c.Request.Context(), cmd); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Header("Location", "/api/v1/orders/"+cmd.OrderID)
+	c.JSON(http.StatusAccepted, gin.H{"id": cmd.OrderID})
+}
+
+// CancelOrder handles the POST /api/v1/orders/:id/cancel endpoint.
+func (h *OrderHandler) CancelOrder(c *gin.Context) {
+	var req cancelOrderRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	cmd := command.CancelOrder{OrderID: c.Param("id"), Reason: req.Reason}
+	if err := h.commands.Dispatch(c.Request.Context(), cmd); err != nil {
+		respondError(c, err)
+		return
+	}
+	c.Status(http.StatusAccepted)
+}
+
+// GetOrder handles the GET /api/v1/orders/:id endpoint.
+func (h *OrderHandler) GetOrder(c *gin.Context) {
+	order, err := bus.Ask[*readmodel.OrderSummary](c.Request.Context(), h.queries, query.GetOrder{OrderID: c.Param("id")})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// ListOrders handles the GET /api/v1/orders?customer_id= endpoint.
+func (h *OrderHandler) ListOrders(c *gin.Context) {
+	customerID := c.Query("customer_id")
+	if customerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "customer_id is required"})
+		return
+	}
+	limit, _ := strconv.Atoi(c.Query("limit"))
+
+	orders, err := bus.Ask[[]readmodel.OrderSummary](c.Request.Context(), h.queries, query.ListOrders{CustomerID: customerID, Limit: limit})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, orders)
+}
+
+// respondError maps domain errors to HTTP status codes.
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalid):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrConflict):
+		status = http.StatusConflict
+	}
+	if status == http.StatusInternalServerError {
+		c.Error(err)
+		c.JSON(status, gin.H{"error": "internal error"})
+		return
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+	"{{.ModuleName}}/internal/readmodel"
+)
+
+const summaryColumns = `id, customer_id, total, status, placed_at, cancelled_at`
+
+// PostgresOrderReadModel stores the order summaries of the query side.
+type PostgresOrderReadModel struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresOrderReadModel creates a new order summary store.
+func NewPostgresOrderReadModel(db *pgxpool.Pool) ports.OrderReadModel {
+	return &PostgresOrderReadModel{db: db}
+}
+
+// Get returns a summary.
+func (r *PostgresOrderReadModel) Get(ctx context.Context, id string) (*readmodel.OrderSummary, error) {
+	rows, _ := r.db.Query(ctx, `SELECT `+summaryColumns+` FROM order_summaries WHERE id = $1`, id)
+	summary, err := pgx.CollectOneRow(rows, pgx.RowToAddrOfStructByPos[readmodel.OrderSummary])
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("order %s: %w", id, domain.ErrNotFound)
+	}
+	return summary, err
+}
+
+// ListByCustomer returns a customer's orders, newest first.
+func (r *PostgresOrderReadModel) ListByCustomer(ctx context.Context, customerID string, limit int) ([]readmodel.OrderSummary, error) {
+	rows, _ := r.db.Query(ctx,
+		`SELECT `+summaryColumns+` FROM order_summaries WHERE customer_id = $1 ORDER BY placed_at DESC LIMIT $2`,
+		customerID, limit)
+	return pgx.CollectRows(rows, pgx.RowToStructByPos[readmodel.OrderSummary])
+}
+
+// Insert adds a summary unless it exists.
+func (r *PostgresOrderReadModel) Insert(ctx context.Context, s readmodel.OrderSummary) error {
+	_, err := r.db.Exec(ctx,
+		`INSERT INTO order_summaries (id, customer_id, total, status, placed_at) VALUES ($1, $2, $3, $4, $5)
+		 ON CONFLICT (id) DO NOTHING`,
+		s.ID, s.CustomerID, s.Total, s.Status, s.PlacedAt)
+	return err
+}
+
+// MarkCancelled records the cancellation of an order.
+func (r *PostgresOrderReadModel) MarkCancelled(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.Exec(ctx,
+		`UPDATE order_summaries SET status = $2, cancelled_at = $3 WHERE id = $1 AND cancelled_at IS NULL`,
+		id, string(domain.OrderStatusCancelled), at)
+	return err
+}
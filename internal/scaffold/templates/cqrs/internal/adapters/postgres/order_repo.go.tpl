@@ -0,0 +1,87 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// PostgresOrderRepository stores orders and their events in the outbox.
+type PostgresOrderRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresOrderRepository creates a new order repository.
+func NewPostgresOrderRepository(db *pgxpool.Pool) ports.OrderRepository {
+	return &PostgresOrderRepository{db: db}
+}
+
+// Get loads an order.
+func (r *PostgresOrderRepository) Get(ctx context.Context, id string) (*domain.Order, error) {
+	var order domain.Order
+	err := r.db.QueryRow(ctx,
+		`SELECT id, customer_id, total, status, version FROM orders WHERE id = $1`, id,
+	).Scan(&order.ID, &order.CustomerID, &order.Total, &order.Status, &order.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("order %s: %w", id, domain.ErrNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// Save inserts or updates the order, checking its version, and adds its
+// events to the outbox in the same transaction.
+func (r *PostgresOrderRepository) Save(ctx context.Context, order *domain.Order) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var tag pgconn.CommandTag
+	if order.Version == 0 {
+		tag, err = tx.Exec(ctx,
+			`INSERT INTO orders (id, customer_id, total, status, version) VALUES ($1, $2, $3, $4, 1)
+			 ON CONFLICT (id) DO NOTHING`,
+			order.ID, order.CustomerID, order.Total, order.Status)
+	} else {
+		tag, err = tx.Exec(ctx,
+			`UPDATE orders SET status = $2, total = $3, version = version + 1 WHERE id = $1 AND version = $4`,
+			order.ID, order.Status, order.Total, order.Version)
+	}
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("order %s: %w", order.ID, domain.ErrConflict)
+	}
+
+	for _, event := range order.Events() {
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return fmt.Errorf("encode %s: %w", event.EventName(), err)
+		}
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO outbox (aggregate_id, name, payload) VALUES ($1, $2, $3)`,
+			order.ID, event.EventName(), payload); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	order.Version++
+	order.ClearEvents()
+	return nil
+}
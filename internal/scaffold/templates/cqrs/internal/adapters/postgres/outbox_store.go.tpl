@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"{{.ModuleName}}/internal/platform/outbox"
+)
+
+// PostgresOutboxStore reads the outbox table for the relay.
+type PostgresOutboxStore struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresOutboxStore creates a new outbox store.
+func NewPostgresOutboxStore(db *pgxpool.Pool) outbox.Store {
+	return &PostgresOutboxStore{db: db}
+}
+
+// Process locks a batch of unpublished messages, skipping those another
+// relay holds, and marks the ones fn accepts as published.
+func (s *PostgresOutboxStore) Process(ctx context.Context, limit int, fn func(context.Context, outbox.Message) error) (int, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	rows, _ := tx.Query(ctx,
+		`SELECT id, name, payload FROM outbox WHERE published_at IS NULL
+		 ORDER BY id LIMIT $1 FOR UPDATE SKIP LOCKED`, limit)
+	messages, err := pgx.CollectRows(rows, pgx.RowToStructByPos[outbox.Message])
+	if err != nil {
+		return 0, err
+	}
+
+	published := 0
+	var fnErr error
+	for _, msg := range messages {
+		if fnErr = fn(ctx, msg); fnErr != nil {
+			break
+		}
+		if _, err := tx.Exec(ctx, `UPDATE outbox SET published_at = NOW() WHERE id = $1`, msg.ID); err != nil {
+			return 0, err
+		}
+		published++
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return published, fnErr
+}
@@ -0,0 +1,69 @@
+// Package command is the write side: each command changes one aggregate
+// and returns only an error. What changed reaches the query side as
+// events, through the outbox.
+package command
+
+import (
+	"context"
+	"time"
+
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/platform/bus"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// PlaceOrder places an order under an ID chosen by the caller, so it can
+// query the order afterwards.
+type PlaceOrder struct {
+	OrderID    string
+	CustomerID string
+	Total      int64
+}
+
+func (PlaceOrder) CommandName() string { return "order.place" }
+
+// CancelOrder cancels a placed order.
+type CancelOrder struct {
+	OrderID string
+	Reason  string
+}
+
+func (CancelOrder) CommandName() string { return "order.cancel" }
+
+// Handlers handles the order commands.
+type Handlers struct {
+	orders ports.OrderRepository
+	now    func() time.Time
+}
+
+// NewHandlers returns the order command handlers.
+func NewHandlers(orders ports.OrderRepository) *Handlers {
+	return &Handlers{orders: orders, now: time.Now}
+}
+
+// Register registers the handlers on the command bus.
+func (h *Handlers) Register(commands *bus.CommandBus) {
+	bus.HandleCommand(commands, h.PlaceOrder)
+	bus.HandleCommand(commands, h.CancelOrder)
+}
+
+// PlaceOrder handles PlaceOrder.
+func (h *Handlers) PlaceOrder(ctx context.Context, cmd PlaceOrder) error {
+	order, err := domain.PlaceOrder(cmd.OrderID, cmd.CustomerID, cmd.Total, h.now().UTC())
+	if err != nil {
+		return err
+	}
+	return h.orders.Save(ctx, order)
+}
+
+// CancelOrder handles CancelOrder.
+func (h *Handlers) CancelOrder(ctx context.Context, cmd CancelOrder) error {
+	order, err := h.orders.Get(ctx, cmd.OrderID)
+	if err != nil {
+		return err
+	}
+	if err := order.Cancel(cmd.Reason, h.now().UTC()); err != nil {
+		return err
+	}
+	return h.orders.Save(ctx, order)
+}
@@ -0,0 +1,43 @@
+// Package projection keeps the read models up to date: each projection
+// subscribes to the events it needs and writes its view. Events can be
+// delivered more than once, so every update is idempotent.
+package projection
+
+import (
+	"context"
+
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/platform/bus"
+	"{{.ModuleName}}/internal/ports"
+	"{{.ModuleName}}/internal/readmodel"
+)
+
+// OrderSummaries projects the order events into order summaries.
+type OrderSummaries struct {
+	summaries ports.OrderReadModel
+}
+
+// NewOrderSummaries returns the order summary projection.
+func NewOrderSummaries(summaries ports.OrderReadModel) *OrderSummaries {
+	return &OrderSummaries{summaries: summaries}
+}
+
+// Register subscribes the projection to its events.
+func (p *OrderSummaries) Register(events *bus.EventBus) {
+	bus.Subscribe(events, p.onPlaced)
+	bus.Subscribe(events, p.onCancelled)
+}
+
+func (p *OrderSummaries) onPlaced(ctx context.Context, e domain.OrderPlaced) error {
+	return p.summaries.Insert(ctx, readmodel.OrderSummary{
+		ID:         e.OrderID,
+		CustomerID: e.CustomerID,
+		Total:      e.Total,
+		Status:     string(domain.OrderStatusPlaced),
+		PlacedAt:   e.PlacedAt,
+	})
+}
+
+func (p *OrderSummaries) onCancelled(ctx context.Context, e domain.OrderCancelled) error {
+	return p.summaries.MarkCancelled(ctx, e.OrderID, e.CancelledAt)
+}
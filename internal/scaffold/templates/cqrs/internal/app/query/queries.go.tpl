@@ -0,0 +1,57 @@
+// Package query is the read side: queries read the views the projections
+// maintain and never change state. Views follow the write side with a
+// short delay, the time the outbox relay takes to publish its events.
+package query
+
+import (
+	"context"
+
+	"{{.ModuleName}}/internal/platform/bus"
+	"{{.ModuleName}}/internal/ports"
+	"{{.ModuleName}}/internal/readmodel"
+)
+
+// GetOrder returns an order's summary.
+type GetOrder struct {
+	OrderID string
+}
+
+func (GetOrder) QueryName() string { return "order.get" }
+
+// ListOrders returns a customer's orders, newest first.
+type ListOrders struct {
+	CustomerID string
+	Limit      int
+}
+
+func (ListOrders) QueryName() string { return "order.list" }
+
+// Handlers answers the order queries.
+type Handlers struct {
+	orders ports.OrderReadModel
+}
+
+// NewHandlers returns the order query handlers.
+func NewHandlers(orders ports.OrderReadModel) *Handlers {
+	return &Handlers{orders: orders}
+}
+
+// Register registers the handlers on the query bus.
+func (h *Handlers) Register(queries *bus.QueryBus) {
+	bus.HandleQuery(queries, h.GetOrder)
+	bus.HandleQuery(queries, h.ListOrders)
+}
+
+// GetOrder answers GetOrder.
+func (h *Handlers) GetOrder(ctx context.Context, q GetOrder) (*readmodel.OrderSummary, error) {
+	return h.orders.Get(ctx, q.OrderID)
+}
+
+// ListOrders answers ListOrders.
+func (h *Handlers) ListOrders(ctx context.Context, q ListOrders) ([]readmodel.OrderSummary, error) {
+	limit := q.Limit
+	if limit <= 0 || limit > 100 {
+		limit = 20
+	}
+	return h.orders.ListByCustomer(ctx, q.CustomerID, limit)
+}
@@ -0,0 +1,14 @@
+package domain
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when an aggregate or view does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalid is returned when a command breaks a business rule.
+	ErrInvalid = errors.New("invalid")
+
+	// ErrConflict is returned when an aggregate changed since it was loaded.
+	ErrConflict = errors.New("conflict")
+)
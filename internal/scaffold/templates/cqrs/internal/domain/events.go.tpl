@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Event is something that happened to an aggregate. Events are stored in
+// the outbox as JSON under their name and published to the event bus,
+// whose subscribers update the read models.
+type Event interface {
+	EventName() string
+}
+
+// OrderPlaced is recorded when an order is placed.
+type OrderPlaced struct {
+	OrderID    string    `json:"order_id"`
+	CustomerID string    `json:"customer_id"`
+	Total      int64     `json:"total"`
+	PlacedAt   time.Time `json:"placed_at"`
+}
+
+func (OrderPlaced) EventName() string { return "order.placed" }
+
+// OrderCancelled is recorded when an order is cancelled.
+type OrderCancelled struct {
+	OrderID     string    `json:"order_id"`
+	Reason      string    `json:"reason"`
+	CancelledAt time.Time `json:"cancelled_at"`
+}
+
+func (OrderCancelled) EventName() string { return "order.cancelled" }
+
+// decoders restore each event type from its JSON; add new events here.
+var decoders = map[string]func([]byte) (Event, error){
+	OrderPlaced{}.EventName():    decode[OrderPlaced],
+	OrderCancelled{}.EventName(): decode[OrderCancelled],
+}
+
+// DecodeEvent restores an event from the outbox.
+func DecodeEvent(name string, payload []byte) (Event, error) {
+	decoder, ok := decoders[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown event %q", name)
+	}
+	event, err := decoder(payload)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", name, err)
+	}
+	return event, nil
+}
+
+func decode[E Event](payload []byte) (Event, error) {
+	var event E
+	err := json.Unmarshal(payload, &event)
+	return event, err
+}
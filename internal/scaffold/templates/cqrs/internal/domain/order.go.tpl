@@ -0,0 +1,75 @@
+package domain
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// OrderStatus is the lifecycle state of an order.
+type OrderStatus string
+
+const (
+	OrderStatusPlaced    OrderStatus = "placed"
+	OrderStatusCancelled OrderStatus = "cancelled"
+)
+
+// Order is the aggregate of the write side: commands change it through its
+// methods, which check the business rules and record what happened as
+// events. The repository stores the events in the outbox with the order.
+type Order struct {
+	ID         string
+	CustomerID string
+	Total      int64 // in cents
+	Status     OrderStatus
+	Version    int // incremented on every save, for optimistic locking
+
+	events []Event
+}
+
+// NewOrderID returns a random order ID.
+func NewOrderID() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// PlaceOrder creates an order, recording OrderPlaced.
+func PlaceOrder(id, customerID string, total int64, now time.Time) (*Order, error) {
+	if id == "" || customerID == "" {
+		return nil, fmt.Errorf("%w: order and customer IDs are required", ErrInvalid)
+	}
+	if total <= 0 {
+		return nil, fmt.Errorf("%w: total must be positive", ErrInvalid)
+	}
+
+	order := &Order{ID: id, CustomerID: customerID, Total: total, Status: OrderStatusPlaced}
+	order.record(OrderPlaced{OrderID: id, CustomerID: customerID, Total: total, PlacedAt: now})
+	return order, nil
+}
+
+// Cancel cancels the order, recording OrderCancelled.
+func (o *Order) Cancel(reason string, now time.Time) error {
+	if o.Status == OrderStatusCancelled {
+		return fmt.Errorf("%w: order %s is already cancelled", ErrInvalid, o.ID)
+	}
+
+	o.Status = OrderStatusCancelled
+	o.record(OrderCancelled{OrderID: o.ID, Reason: reason, CancelledAt: now})
+	return nil
+}
+
+// Events returns the events recorded since the order was loaded.
+func (o *Order) Events() []Event {
+	return o.events
+}
+
+// ClearEvents forgets the recorded events once they are stored.
+func (o *Order) ClearEvents() {
+	o.events = nil
+}
+
+func (o *Order) record(event Event) {
+	o.events = append(o.events, event)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPlaceOrder(t *testing.T) {
+	now := time.Now()
+	order, err := PlaceOrder("o1", "c1", 1250, now)
+	if err != nil {
+		t.Fatalf("PlaceOrder() error = %v", err)
+	}
+
+	events := order.Events()
+	if len(events) != 1 {
+		t.Fatalf("recorded %d events, want 1", len(events))
+	}
+	placed, ok := events[0].(OrderPlaced)
+	if !ok || placed.OrderID != "o1" || placed.Total != 1250 {
+		t.Errorf("recorded %#v, want OrderPlaced for o1", events[0])
+	}
+}
+
+func TestPlaceOrderRejectsNonPositiveTotal(t *testing.T) {
+	if _, err := PlaceOrder("o1", "c1", 0, time.Now()); !errors.Is(err, ErrInvalid) {
+		t.Errorf("PlaceOrder() error = %v, want ErrInvalid", err)
+	}
+}
+
+func TestCancelTwice(t *testing.T) {
+	order, _ := PlaceOrder("o1", "c1", 1250, time.Now())
+	order.ClearEvents()
+
+	if err := order.Cancel("changed my mind", time.Now()); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if err := order.Cancel("again", time.Now()); !errors.Is(err, ErrInvalid) {
+		t.Errorf("second Cancel() error = %v, want ErrInvalid", err)
+	}
+	if n := len(order.Events()); n != 1 {
+		t.Errorf("recorded %d events, want 1", n)
+	}
+}
+
+func TestDecodeEvent(t *testing.T) {
+	event, err := DecodeEvent("order.cancelled", []byte(`{"order_id": "o1", "reason": "late"}`))
+	if err != nil {
+		t.Fatalf("DecodeEvent() error = %v", err)
+	}
+	if cancelled, ok := event.(OrderCancelled); !ok || cancelled.Reason != "late" {
+		t.Errorf("DecodeEvent() = %#v, want OrderCancelled", event)
+	}
+}
@@ -0,0 +1,140 @@
+// Package bus is the in-process message bus: commands change state and go
+// to exactly one handler, queries read state and return a result, events
+// report what happened and go to every subscriber.
+package bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrNoHandler is returned for commands and queries nobody handles.
+var ErrNoHandler = errors.New("no handler")
+
+// Command asks the application to change state.
+type Command interface {
+	CommandName() string
+}
+
+// Query asks the application for data without changing state.
+type Query interface {
+	QueryName() string
+}
+
+// Event reports a change of state.
+type Event interface {
+	EventName() string
+}
+
+// CommandBus routes each command to its handler.
+type CommandBus struct {
+	handlers map[string]func(context.Context, Command) error
+}
+
+// NewCommandBus returns a command bus without handlers.
+func NewCommandBus() *CommandBus {
+	return &CommandBus{handlers: make(map[string]func(context.Context, Command) error)}
+}
+
+// HandleCommand registers the handler of the command type C.
+func HandleCommand[C Command](b *CommandBus, handler func(context.Context, C) error) {
+	var zero C
+	name := zero.CommandName()
+	if _, ok := b.handlers[name]; ok {
+		panic(fmt.Sprintf("bus: command %s is already handled", name))
+	}
+	b.handlers[name] = func(ctx context.Context, cmd Command) error {
+		return handler(ctx, cmd.(C))
+	}
+}
+
+// Dispatch runs the handler of cmd.
+func (b *CommandBus) Dispatch(ctx context.Context, cmd Command) error {
+	handler, ok := b.handlers[cmd.CommandName()]
+	if !ok {
+		return fmt.Errorf("%w for command %s", ErrNoHandler, cmd.CommandName())
+	}
+	return handler(ctx, cmd)
+}
+
+// QueryBus routes each query to its handler.
+type QueryBus struct {
+	handlers map[string]func(context.Context, Query) (any, error)
+}
+
+// NewQueryBus returns a query bus without handlers.
+func NewQueryBus() *QueryBus {
+	return &QueryBus{handlers: make(map[string]func(context.Context, Query) (any, error))}
+}
+
+// HandleQuery registers the handler of the query type Q, answering with R.
+func HandleQuery[Q Query, R any](b *QueryBus, handler func(context.Context, Q) (R, error)) {
+	var zero Q
+	name := zero.QueryName()
+	if _, ok := b.handlers[name]; ok {
+		panic(fmt.Sprintf("bus: query %s is already handled", name))
+	}
+	b.handlers[name] = func(ctx context.Context, q Query) (any, error) {
+		return handler(ctx, q.(Q))
+	}
+}
+
+// Ask runs the handler of q and returns its result as R.
+func Ask[R any](ctx context.Context, b *QueryBus, q Query) (R, error) {
+	var zero R
+	handler, ok := b.handlers[q.QueryName()]
+	if !ok {
+		return zero, fmt.Errorf("%w for query %s", ErrNoHandler, q.QueryName())
+	}
+	result, err := handler(ctx, q)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := result.(R)
+	if !ok {
+		return zero, fmt.Errorf("bus: query %s answers %T, not %T", q.QueryName(), result, zero)
+	}
+	return typed, nil
+}
+
+// EventBus delivers events to their subscribers, synchronously and in the
+// order they subscribed.
+type EventBus struct {
+	mu          sync.RWMutex
+	subscribers map[string][]func(context.Context, Event) error
+}
+
+// NewEventBus returns an event bus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{subscribers: make(map[string][]func(context.Context, Event) error)}
+}
+
+// Subscribe calls handler for every event of the type E.
+func Subscribe[E Event](b *EventBus, handler func(context.Context, E) error) {
+	var zero E
+	name := zero.EventName()
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[name] = append(b.subscribers[name], func(ctx context.Context, event Event) error {
+		return handler(ctx, event.(E))
+	})
+}
+
+// Publish delivers event to every subscriber, returning their errors
+// joined. Events are delivered at least once, so subscribers must be
+// idempotent.
+func (b *EventBus) Publish(ctx context.Context, event Event) error {
+	b.mu.RLock()
+	subscribers := b.subscribers[event.EventName()]
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := subscriber(ctx, event); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", event.EventName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,73 @@
+package bus
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type greet struct{ Name string }
+
+func (greet) CommandName() string { return "greet" }
+
+type count struct{}
+
+func (count) QueryName() string { return "count" }
+
+type greeted struct{ Name string }
+
+func (greeted) EventName() string { return "greeted" }
+
+func TestCommandBus(t *testing.T) {
+	b := NewCommandBus()
+	var got string
+	HandleCommand(b, func(ctx context.Context, cmd greet) error {
+		got = cmd.Name
+		return nil
+	})
+
+	if err := b.Dispatch(context.Background(), greet{Name: "Ada"}); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if got != "Ada" {
+		t.Errorf("handler got %q, want Ada", got)
+	}
+}
+
+func TestQueryBus(t *testing.T) {
+	b := NewQueryBus()
+	HandleQuery(b, func(ctx context.Context, q count) (int, error) { return 42, nil })
+
+	n, err := Ask[int](context.Background(), b, count{})
+	if err != nil || n != 42 {
+		t.Errorf("Ask() = %d, %v, want 42", n, err)
+	}
+	if _, err := Ask[string](context.Background(), b, count{}); err == nil {
+		t.Error("Ask() with the wrong result type succeeded")
+	}
+}
+
+func TestEventBus(t *testing.T) {
+	b := NewEventBus()
+	var calls int
+	for range 2 {
+		Subscribe(b, func(ctx context.Context, e greeted) error {
+			calls++
+			return nil
+		})
+	}
+
+	if err := b.Publish(context.Background(), greeted{Name: "Ada"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("subscribers called %d times, want 2", calls)
+	}
+}
+
+func TestNoHandler(t *testing.T) {
+	err := NewCommandBus().Dispatch(context.Background(), greet{})
+	if !errors.Is(err, ErrNoHandler) {
+		t.Errorf("Dispatch() error = %v, want ErrNoHandler", err)
+	}
+}
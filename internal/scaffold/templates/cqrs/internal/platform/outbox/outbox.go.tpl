@@ -0,0 +1,90 @@
+// Package outbox publishes the events stored with each command. Storing
+// events in the same transaction as the aggregate means they are never
+// lost or published for a change that was rolled back; the relay then
+// delivers them to the event bus at least once.
+package outbox
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"{{.ModuleName}}/internal/platform/bus"
+)
+
+// Message is an event waiting in the outbox.
+type Message struct {
+	ID      int64
+	Name    string
+	Payload []byte
+}
+
+// Store holds the outbox.
+type Store interface {
+	// Process passes up to limit unpublished messages, oldest first, to fn
+	// and marks those it accepts as published. It stops at the first
+	// message fn fails, leaving it for the next call, and returns how many
+	// were published. Concurrent relays get different messages.
+	Process(ctx context.Context, limit int, fn func(context.Context, Message) error) (int, error)
+}
+
+// Decoder restores an event from a message.
+type Decoder func(name string, payload []byte) (bus.Event, error)
+
+// Relay moves events from the outbox to the event bus.
+type Relay struct {
+	store    Store
+	decode   Decoder
+	events   *bus.EventBus
+	interval time.Duration
+	batch    int
+}
+
+// NewRelay returns a relay polling store every interval.
+func NewRelay(store Store, decode Decoder, events *bus.EventBus, interval time.Duration, batch int) *Relay {
+	if interval <= 0 {
+		interval = time.Second
+	}
+	if batch <= 0 {
+		batch = 100
+	}
+	return &Relay{store: store, decode: decode, events: events, interval: interval, batch: batch}
+}
+
+// Run publishes the outbox until ctx is done.
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+	for {
+		r.drain(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain publishes batches until the outbox is empty or a batch fails.
+func (r *Relay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.store.Process(ctx, r.batch, r.publish)
+		if err != nil {
+			log.Printf("outbox: %v", err)
+			return
+		}
+		if n < r.batch {
+			return
+		}
+	}
+}
+
+// publish delivers one message; a failure leaves it in the outbox for the
+// next poll.
+func (r *Relay) publish(ctx context.Context, msg Message) error {
+	event, err := r.decode(msg.Name, msg.Payload)
+	if err != nil {
+		return err
+	}
+	return r.events.Publish(ctx, event)
+}
@@ -0,0 +1,23 @@
+package ports
+
+import (
+	"context"
+	"time"
+
+	"{{.ModuleName}}/internal/readmodel"
+)
+
+// OrderReadModel stores the order summaries of the query side.
+type OrderReadModel interface {
+	// Get returns a summary, or domain.ErrNotFound.
+	Get(ctx context.Context, id string) (*readmodel.OrderSummary, error)
+
+	// ListByCustomer returns a customer's orders, newest first.
+	ListByCustomer(ctx context.Context, customerID string, limit int) ([]readmodel.OrderSummary, error)
+
+	// Insert adds a summary unless it exists.
+	Insert(ctx context.Context, summary readmodel.OrderSummary) error
+
+	// MarkCancelled records the cancellation of an order.
+	MarkCancelled(ctx context.Context, id string, at time.Time) error
+}
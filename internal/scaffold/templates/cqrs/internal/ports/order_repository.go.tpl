@@ -0,0 +1,18 @@
+package ports
+
+import (
+	"context"
+
+	"{{.ModuleName}}/internal/domain"
+)
+
+// OrderRepository stores the Order aggregate of the write side.
+type OrderRepository interface {
+	// Get loads an order, or returns domain.ErrNotFound.
+	Get(ctx context.Context, id string) (*domain.Order, error)
+
+	// Save stores the order and, in the same transaction, its recorded
+	// events in the outbox. It returns domain.ErrConflict when the order
+	// changed since it was loaded.
+	Save(ctx context.Context, order *domain.Order) error
+}
@@ -0,0 +1,16 @@
+// Package readmodel holds the views of the query side. Projections keep
+// them up to date from the events of the write side, shaped for the
+// queries that read them rather than for the business rules.
+package readmodel
+
+import "time"
+
+// OrderSummary is an order as listed to customers.
+type OrderSummary struct {
+	ID          string     `json:"id"`
+	CustomerID  string     `json:"customer_id"`
+	Total       int64      `json:"total"`
+	Status      string     `json:"status"`
+	PlacedAt    time.Time  `json:"placed_at"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+}
@@ -0,0 +1,32 @@
+-- Write side: the Order aggregate
+CREATE TABLE orders (
+    id TEXT PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    total BIGINT NOT NULL CHECK (total > 0),
+    status TEXT NOT NULL,
+    version INT NOT NULL
+);
+
+-- Events stored with each change, published by the outbox relay
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    aggregate_id TEXT NOT NULL,
+    name TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    published_at TIMESTAMPTZ
+);
+
+CREATE INDEX outbox_unpublished_idx ON outbox (id) WHERE published_at IS NULL;
+
+-- Read side: order summaries maintained by the projection
+CREATE TABLE order_summaries (
+    id TEXT PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    total BIGINT NOT NULL,
+    status TEXT NOT NULL,
+    placed_at TIMESTAMPTZ NOT NULL,
+    cancelled_at TIMESTAMPTZ
+);
+
+CREATE INDEX order_summaries_customer_idx ON order_summaries (customer_id, placed_at DESC);
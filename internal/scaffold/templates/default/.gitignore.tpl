@@ -23,3 +23,9 @@ Thumbs.db
 .env
 .env.*
 !.env.example
+
+# goforge command history
+.goforge/history
+
+# goforge profile captures
+.goforge/profiles/
@@ -1,10 +1,19 @@
 # {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
 
 This project was generated by [GoForge](https://github.com/night-slayer18/goforge).
 
 ## 🚀 Getting Started
 
-This project is structured using a clean architecture pattern to ensure separation of concerns and scalability.
+This project is structured using a clean architecture pattern to ensure separation of concerns and scalability. Its HTTP layer in `internal/adapters/http` uses {{if eq .Vars.framework "gin"}}[Gin](https://gin-gonic.com){{else if eq .Vars.framework "echo"}}[Echo](https://echo.labstack.com){{else if eq .Vars.framework "fiber"}}[Fiber](https://gofiber.io){{else if eq .Vars.framework "chi"}}[chi](https://go-chi.io){{else}}the standard library's `net/http`{{end}}, recorded as `framework` in `goforge.yml` so that `goforge generate handler` and `goforge generate middleware` write code for it.
+{{- if ne .Vars.framework "gin"}} The generators of ready-made HTTP code (middleware presets, `ratelimiter`, `oidc`, `api`, `healthcheck-client`, and the Swagger UI) write Gin code and are not available.{{end}}
+
+The repositories in `internal/adapters/postgres` reach PostgreSQL through {{if eq .Vars.orm "gorm"}}[GORM](https://gorm.io){{else if eq .Vars.orm "sqlc"}}code [sqlc](https://sqlc.dev) generates from the queries in `db/queries` (regenerate it with `goforge run sqlc:generate`){{else if eq .Vars.orm "ent"}}an [ent](https://entgo.io) client generated from the schemas in `ent/schema` (regenerate it with `goforge run ent:generate`){{else}}[pgx](https://github.com/jackc/pgx) and hand-written SQL{{end}}, recorded as `orm` in `goforge.yml` so that `goforge generate repository` follows it. The `users` table is created by the migrations in `migrations` (`goforge run db:migrate`).
+
+Logs are structured JSON written with {{if eq .Vars.logger "zap"}}[zap](https://github.com/uber-go/zap){{else if eq .Vars.logger "zerolog"}}[zerolog](https://github.com/rs/zerolog){{else}}the standard library's `log/slog`{{end}}: `internal/logger` sets it up at `logging.level` from `config/default.yml` and routes the standard `log` package through it, and `RequestLogger` in `internal/adapters/http/middleware` logs every request. It is recorded as `logger` in `goforge.yml`, so that `goforge generate middleware` logs with it too.
 
 ### Prerequisites
 
@@ -34,3 +43,9 @@ This project uses `goforge` to manage scripts, similar to `npm` scripts.
 -   `goforge run test`: Runs all tests in the project.
 
 You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -1,28 +1,84 @@
 package main
 
 import (
+{{- if eq .Vars.framework "echo"}}
+	"errors"
+{{- end}}
 	"fmt"
 	"log"
+	"strings"
+{{- if eq .Vars.logger "slog"}}
+	"log/slog"
+{{- end}}
+{{- if eq .Vars.framework "echo" "chi" "stdlib"}}
+	"net/http"
+{{- end}}
+{{- if eq .Vars.framework "chi" "stdlib"}}
+	"time"
+{{- end}}
+
+{{- if eq .Vars.framework "gin"}}
 
 	"github.com/gin-gonic/gin"
+{{- else if eq .Vars.framework "echo"}}
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+{{- else if eq .Vars.framework "fiber"}}
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/recover"
+{{- else if eq .Vars.framework "chi"}}
+
+	"github.com/go-chi/chi/v5"
+	chimw "github.com/go-chi/chi/v5/middleware"
+{{- end}}
 	"github.com/spf13/viper"
+{{- if eq .Vars.logger "zap"}}
+	"go.uber.org/zap"
+{{- end}}
 
 	// "{{.ModuleName}}/internal/adapters/database" // TODO: Uncomment when database is wired up
 	"{{.ModuleName}}/internal/adapters/http/handler"
+	"{{.ModuleName}}/internal/adapters/http/middleware"
 	// "{{.ModuleName}}/internal/adapters/postgres" // TODO: Uncomment when database is wired up
 	"{{.ModuleName}}/internal/app/service"
+	"{{.ModuleName}}/internal/logger"
 	"{{.ModuleName}}/internal/ports"
 )
 
+// API description for 'goforge docs openapi'.
+//
+//	@title			{{.ProjectName}} API
+//	@version		1.0
+//	@description	{{with .Description}}{{.}}{{else}}The {{.ProjectName}} HTTP API.{{end}}
+{{- if .Author}}
+//	@contact.name	{{.Author}}
+{{- end}}
+{{- if .Repository}}
+//	@contact.url	{{.Repository}}
+{{- end}}
+{{- if .License}}
+//	@license.name	{{.License}}
+{{- end}}
+//	@BasePath		/api/v1
 func main() {
 	// --- Configuration Setup ---
 	viper.SetConfigName("default")
 	viper.SetConfigType("yml")
 	viper.AddConfigPath("./config")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // e.g. DATABASE_HOST overrides database.host
+	viper.AutomaticEnv()
 	if err := viper.ReadInConfig(); err != nil {
 		log.Fatalf("Error reading config file: %s", err)
 	}
 
+	// --- Logging Setup ---
+	appLogger := logger.Setup(viper.GetString("logging.level"))
+{{- if eq .Vars.logger "zap"}}
+	defer appLogger.Sync() // Flush buffered entries on exit
+{{- end}}
+
 	port := viper.GetInt("server.port")
 	if port == 0 {
 		port = 8080 // Default port
@@ -35,11 +91,24 @@ func main() {
 	// The order of initialization is important to ensure that dependencies are available 
 	// ---------------------------------------------------------------
 	/* 1. Database Connection
+{{- if eq .Vars.orm "gorm"}}
+	db := database.Connect()
+
+	// 2. Repositories (Adapters)
+	userRepo := postgres.NewPostgresUserRepository(db)
+{{- else if eq .Vars.orm "ent"}}
+	client := database.Connect()
+	defer client.Close() // Ensure the client is closed on exit
+
+	// 2. Repositories (Adapters)
+	userRepo := postgres.NewPostgresUserRepository(client)
+{{- else}}
 	dbPool := database.Connect()
 	defer dbPool.Close() // Ensure the connection pool is closed on exit
 
 	// 2. Repositories (Adapters)
 	userRepo := postgres.NewPostgresUserRepository(dbPool)
+{{- end}}
 	*/
 
 	// In a real app, initialize a database connection here.
@@ -50,9 +119,11 @@ func main() {
 
 	// 4. Handlers (Adapters)
 	userHandler := handler.NewUserHandler(userService)
+{{- if eq .Vars.framework "gin"}}
 
 	// --- Gin Router Setup ---
-	router := gin.Default()
+	router := gin.New()
+	router.Use(middleware.RequestLogger(appLogger), gin.Recovery())
 
 	api := router.Group("/api/v1")
 	{
@@ -65,12 +136,93 @@ func main() {
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{"status": "UP"})
 	})
+{{- else if eq .Vars.framework "echo"}}
+
+	// --- Echo Router Setup ---
+	router := echo.New()
+	router.HideBanner = true
+	router.Use(middleware.RequestLogger(appLogger), echomw.Recover())
+
+	api := router.Group("/api/v1")
+	userRoutes := api.Group("/users")
+	userRoutes.GET("/:id", userHandler.GetUser)
+
+	router.GET("/health", func(c echo.Context) error {
+		return c.JSON(http.StatusOK, map[string]string{"status": "UP"})
+	})
+{{- else if eq .Vars.framework "fiber"}}
+
+	// --- Fiber Router Setup ---
+	app := fiber.New(fiber.Config{DisableStartupMessage: true})
+	app.Use(middleware.RequestLogger(appLogger), recover.New())
+
+	api := app.Group("/api/v1")
+	userRoutes := api.Group("/users")
+	userRoutes.Get("/:id", userHandler.GetUser)
+
+	app.Get("/health", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "UP"})
+	})
+{{- else if eq .Vars.framework "chi"}}
+
+	// --- chi Router Setup ---
+	router := chi.NewRouter()
+	router.Use(middleware.RequestLogger(appLogger), chimw.Recoverer)
+
+	router.Route("/api/v1", func(api chi.Router) {
+		api.Route("/users", func(userRoutes chi.Router) {
+			userRoutes.Get("/{id}", userHandler.GetUser)
+		})
+	})
+
+	router.Get("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"UP"}`))
+	})
+{{- else}}
+
+	// --- net/http Router Setup ---
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/users/{id}", userHandler.GetUser)
+
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"UP"}`))
+	})
+{{- end}}
 
 	// --- Start Server ---
 	serverAddr := fmt.Sprintf(":%d", port)
-	fmt.Printf("🚀 Server starting on http://localhost%s\n", serverAddr)
+{{- if eq .Vars.logger "zap"}}
+	appLogger.Info("🚀 Server starting", zap.String("url", "http://localhost"+serverAddr))
+{{- else if eq .Vars.logger "zerolog"}}
+	appLogger.Info().Str("url", "http://localhost"+serverAddr).Msg("🚀 Server starting")
+{{- else}}
+	appLogger.Info("🚀 Server starting", slog.String("url", "http://localhost"+serverAddr))
+{{- end}}
 
+	// log.Fatalf goes through appLogger as well, see logger.Setup
+{{- if eq .Vars.framework "gin"}}
 	if err := router.Run(serverAddr); err != nil {
 		log.Fatalf("❌ Could not start server: %v", err)
 	}
-}
\ No newline at end of file
+{{- else if eq .Vars.framework "echo"}}
+	if err := router.Start(serverAddr); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatalf("❌ Could not start server: %v", err)
+	}
+{{- else if eq .Vars.framework "fiber"}}
+	if err := app.Listen(serverAddr); err != nil {
+		log.Fatalf("❌ Could not start server: %v", err)
+	}
+{{- else if eq .Vars.framework "chi"}}
+	server := &http.Server{Addr: serverAddr, Handler: router, ReadHeaderTimeout: 5 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		log.Fatalf("❌ Could not start server: %v", err)
+	}
+{{- else}}
+	server := &http.Server{Addr: serverAddr, Handler: middleware.RequestLogger(appLogger)(mux), ReadHeaderTimeout: 5 * time.Second}
+	if err := server.ListenAndServe(); err != nil {
+		log.Fatalf("❌ Could not start server: %v", err)
+	}
+{{- end}}
+}
@@ -0,0 +1,22 @@
+{{- if eq .Vars.orm "sqlc" -}}
+-- Queries of PostgresUserRepository. After changing them, regenerate the Go
+-- code with 'goforge run sqlc:generate'.
+
+-- name: GetUser :one
+SELECT * FROM users
+WHERE id = $1 LIMIT 1;
+
+-- name: CreateUser :one
+INSERT INTO users (email, name)
+VALUES ($1, $2)
+RETURNING *;
+
+-- name: UpdateUser :exec
+UPDATE users
+SET email = $2, name = $3
+WHERE id = $1;
+
+-- name: DeleteUser :exec
+DELETE FROM users
+WHERE id = $1;
+{{- end}}
@@ -0,0 +1,6 @@
+{{- if eq .Vars.orm "ent" -}}
+// Package ent holds the client ent generates from the schemas in ./schema.
+package ent
+
+//go:generate go run entgo.io/ent/cmd/ent generate ./schema
+{{- end}}
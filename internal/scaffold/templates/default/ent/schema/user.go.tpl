@@ -0,0 +1,27 @@
+{{- if eq .Vars.orm "ent" -}}
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+)
+
+// User holds the schema of the users table; after changing it, regenerate
+// the client with 'goforge run ent:generate' and add a migration.
+type User struct {
+	ent.Schema
+}
+
+// Fields of the User.
+func (User) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("email").Unique(),
+		field.String("name"),
+	}
+}
+
+// Edges of the User.
+func (User) Edges() []ent.Edge {
+	return nil
+}
+{{- end}}
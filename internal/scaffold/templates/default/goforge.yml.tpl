@@ -3,16 +3,49 @@ project_name: "{{.ProjectName}}"
 module_path: "{{.ModuleName}}"
 go_version: "{{.GoVersion}}"
 
+# HTTP framework 'goforge generate handler' and 'middleware' write code for
+framework: "{{.Vars.framework}}"
+
+# Data access style 'goforge generate repository' writes code for
+orm: "{{.Vars.orm}}"
+
+# Logging library 'goforge generate middleware' writes code for
+logger: "{{.Vars.logger}}"
+
 # Project metadata
-description: "A Go application built with GoForge"
-author: ""
-license: "MIT"
+description: {{quote .Description}}
+author: "{{.Author}}"
+license: "{{.License}}"
+{{- if .Repository}}
+repository: {{quote .Repository}}
+{{- end}}
 
 # Dependencies with version constraints
 dependencies:
+{{- if eq .Vars.framework "gin"}}
   github.com/gin-gonic/gin: "^1.10.0"
+{{- else if eq .Vars.framework "echo"}}
+  github.com/labstack/echo/v4: "^4.12.0"
+{{- else if eq .Vars.framework "fiber"}}
+  github.com/gofiber/fiber/v2: "^2.52.0"
+{{- else if eq .Vars.framework "chi"}}
+  github.com/go-chi/chi/v5: "^5.1.0"
+{{- end}}
   github.com/spf13/viper: "^1.19.0"
+{{- if eq .Vars.orm "gorm"}}
+  gorm.io/gorm: "^1.25.0"
+  gorm.io/driver/postgres: "^1.5.0"
+{{- else}}
   github.com/jackc/pgx/v5: "^5.6.0"
+{{- end}}
+{{- if eq .Vars.orm "ent"}}
+  entgo.io/ent: "^0.14.0"
+{{- end}}
+{{- if eq .Vars.logger "zap"}}
+  go.uber.org/zap: "^1.27.0"
+{{- else if eq .Vars.logger "zerolog"}}
+  github.com/rs/zerolog: "^1.33.0"
+{{- end}}
 
 # Development dependencies
 dev_dependencies:
@@ -35,13 +68,19 @@ scripts:
   test:all: "goforge test --coverage --race"
   
   # Code quality
-  lint: "golangci-lint run"
-  fmt: "go fmt ./..."
+  lint: "goforge lint"
+  fmt: "goforge fmt"
   vet: "go vet ./..."
   
   # Database
-  db:migrate: "migrate -path ./migrations -database postgres://localhost/{{.ProjectName}}_db up"
-  db:rollback: "migrate -path ./migrations -database postgres://localhost/{{.ProjectName}}_db down 1"
+  db:migrate: "goforge migrate up"
+  db:rollback: "goforge migrate down"
+{{- if eq .Vars.orm "sqlc"}}
+  sqlc:generate: "sqlc generate"
+{{- else if eq .Vars.orm "ent"}}
+  ent:generate: "go generate ./ent"
+  ent:new: "go run entgo.io/ent/cmd/ent new --target ./ent/schema"
+{{- end}}
   
   # Deployment
   docker:build: "docker build -t {{.ProjectName}} ."
@@ -55,6 +94,11 @@ build:
   # Binary name (defaults to project name)
   binary_name: "{{.ProjectName}}"
   
+  # Package compiled by 'goforge build' (defaults to ./cmd/server) and linker
+  # flags; {version}, {commit} and {date} are filled in from git at build time
+  # main: "./cmd/server"
+  # ldflags: "-s -w -X main.version={version}"
+
   # Assets to copy to output directory
   assets:
     - "config/default.yml"
@@ -110,6 +154,13 @@ test:
 
 # Code generation settings
 generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
+
+  # Extra commands run after generating; {files} is replaced by the new files
+  # post_hooks:
+  #   - "gofumpt -w {files}"
+
   # Default component templates
   templates:
     handler: "templates/components/handler.go.tpl"
@@ -117,19 +168,50 @@ generate:
     repository: "templates/components/repository.go.tpl"
     model: "templates/components/model.go.tpl"
     middleware: "templates/components/middleware.go.tpl"
-  
-  # Output directories for generated components
-  output:
-    handler: "internal/adapters/http/handler"
-    service: "internal/app/service"
-    repository: "internal/adapters/postgres"
-    model: "internal/domain"
-    middleware: "internal/adapters/http/middleware"
+
+# Mocks of port interfaces ('goforge generate mock', 'goforge mocks')
+mocks:
+  # mockgen (go.uber.org/mock) or mockery; installed on first use if missing
+  tool: "mockgen"
+
+  # Also generate mocks whenever 'goforge generate port' runs
+  auto: false
+
+# Output directories for generated components (relative to the project root).
+# Override any entry to match your project structure, or use
+# 'goforge generate <component> <name> --path <dir>' for a one-off location.
+layout:
+  handler: "internal/adapters/http/handler"
+  service: "internal/app/service"
+  repository: "internal/adapters/postgres"
+  model: "internal/domain"
+  middleware: "internal/adapters/http/middleware"
+  port: "internal/ports"
+  ratelimiter: "internal/platform/ratelimit"
+  oidc: "internal/platform/auth/oidc"
+  mock: "internal/mocks"
+  job: "internal/jobs"
+  scheduler: "internal/platform/scheduler"
+  health: "internal/platform/health"
+  event: "internal/events"
+  eventbus: "internal/platform/eventbus"
+  grpc: "internal/adapters/grpc"
+  proto: "api/proto"
+  graphql: "internal/adapters/graphql"
+  command: "internal/cli"
+  config: "internal/config"
+  enum: "internal/domain"
+  api: "internal/adapters/http/api"
+  docs: "internal/adapters/http/docs"
+  consumer: "internal/adapters/messaging"
+  seeder: "db/seeds"
+  fixture: "internal/testutil"
+  queries: "db/queries"
 
 # Docker configuration
 docker:
   # Base image for multi-stage build
-  base_image: "golang:1.24-alpine"
+  base_image: "golang:{{.GoVersion}}-alpine"
   runtime_image: "alpine:latest"
   
   # Exposed port
@@ -137,11 +219,17 @@ docker:
   
   # Environment variables
   env:
+{{- if eq .Vars.framework "gin"}}
     GIN_MODE: "release"
-    LOG_LEVEL: "info"
+{{- end}}
+    LOGGING_LEVEL: "info"
 
 # Database migration settings
 migrations:
+  # Engine running the migrations and setting their file format:
+  # golang-migrate, goose, or atlas
+  engine: "golang-migrate"
+
   # Directory containing migration files
   dir: "migrations"
   
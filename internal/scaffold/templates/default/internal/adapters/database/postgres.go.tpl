@@ -2,19 +2,36 @@ package database
 
 import (
 	"context"
+{{- if eq .Vars.orm "ent"}}
+	"database/sql"
+{{- end}}
 	"fmt"
 	"log"
 	"time"
 
+{{- if eq .Vars.orm "gorm"}}
+
+	"github.com/spf13/viper"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+{{- else if eq .Vars.orm "ent"}}
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	_ "github.com/jackc/pgx/v5/stdlib" // Registers the "pgx" database/sql driver
+	"github.com/spf13/viper"
+
+	"{{.ModuleName}}/ent"
+{{- else}}
+
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/spf13/viper"
+{{- end}}
 )
 
-// Connect establishes a connection pool to the PostgreSQL database.
-// It reads connection details from the application's configuration.
-func Connect() *pgxpool.Pool {
-	// Construct the Data Source Name (DSN) from Viper config
-	dsn := fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
+// dsn builds the Data Source Name from the application's configuration.
+func dsn() string {
+	return fmt.Sprintf("host=%s port=%d user=%s password=%s dbname=%s sslmode=%s",
 		viper.GetString("database.host"),
 		viper.GetInt("database.port"),
 		viper.GetString("database.user"),
@@ -22,9 +39,60 @@ func Connect() *pgxpool.Pool {
 		viper.GetString("database.dbname"),
 		viper.GetString("database.sslmode"),
 	)
+}
+{{- if eq .Vars.orm "gorm"}}
 
+// Connect opens a GORM connection to the PostgreSQL database.
+// It reads connection details from the application's configuration.
+func Connect() *gorm.DB {
+	db, err := gorm.Open(postgres.Open(dsn()), &gorm.Config{})
+	if err != nil {
+		log.Fatalf("Unable to open database: %v\n", err)
+	}
+
+	// Ping the database to verify the connection
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Unable to get database handle: %v\n", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := sqlDB.PingContext(ctx); err != nil {
+		log.Fatalf("Unable to connect to database: %v\n", err)
+	}
+
+	log.Println("✅ Successfully connected to the database.")
+	return db
+}
+{{- else if eq .Vars.orm "ent"}}
+
+// Connect opens an ent client on the PostgreSQL database.
+// It reads connection details from the application's configuration.
+func Connect() *ent.Client {
+	db, err := sql.Open("pgx", dsn())
+	if err != nil {
+		log.Fatalf("Unable to open database: %v\n", err)
+	}
+
+	// Ping the database to verify the connection
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := db.PingContext(ctx); err != nil {
+		log.Fatalf("Unable to connect to database: %v\n", err)
+	}
+
+	log.Println("✅ Successfully connected to the database.")
+	return ent.NewClient(ent.Driver(entsql.OpenDB(dialect.Postgres, db)))
+}
+{{- else}}
+
+// Connect establishes a connection pool to the PostgreSQL database.
+// It reads connection details from the application's configuration.
+func Connect() *pgxpool.Pool {
 	// Create a new connection pool
-	pool, err := pgxpool.New(context.Background(), dsn)
+	pool, err := pgxpool.New(context.Background(), dsn())
 	if err != nil {
 		log.Fatalf("Unable to create connection pool: %v\n", err)
 	}
@@ -37,6 +105,7 @@ func Connect() *pgxpool.Pool {
 		log.Fatalf("Unable to connect to database: %v\n", err)
 	}
 
-	fmt.Println("✅ Successfully connected to the database.")
+	log.Println("✅ Successfully connected to the database.")
 	return pool
 }
+{{- end}}
@@ -0,0 +1,15 @@
+{{- if eq .Vars.framework "chi" "stdlib" -}}
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// respondJSON writes v as the JSON response body with the given status.
+func respondJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+{{- end}}
@@ -4,7 +4,19 @@ import (
 	"net/http"
 	"strconv"
 
+{{- if eq .Vars.framework "gin"}}
+
 	"github.com/gin-gonic/gin"
+{{- else if eq .Vars.framework "echo"}}
+
+	"github.com/labstack/echo/v4"
+{{- else if eq .Vars.framework "fiber"}}
+
+	"github.com/gofiber/fiber/v2"
+{{- else if eq .Vars.framework "chi"}}
+
+	"github.com/go-chi/chi/v5"
+{{- end}}
 	"{{.ModuleName}}/internal/app/service"
 )
 
@@ -21,6 +33,16 @@ func NewUserHandler(userService *service.UserService) *UserHandler {
 }
 
 // GetUser handles the GET /api/v1/users/:id endpoint.
+//
+//	@Summary	Get a user
+//	@Tags		users
+//	@Produce	json
+//	@Param		id	path		int	true	"User ID"
+//	@Success	200	{object}	domain.User
+//	@Failure	400	{object}	map[string]string
+//	@Failure	404	{object}	map[string]string
+//	@Router		/users/{id} [get]
+{{- if eq .Vars.framework "gin"}}
 func (h *UserHandler) GetUser(c *gin.Context) {
 	idStr := c.Param("id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -37,3 +59,55 @@ func (h *UserHandler) GetUser(c *gin.Context) {
 
 	c.JSON(http.StatusOK, user)
 }
+{{- else if eq .Vars.framework "echo"}}
+func (h *UserHandler) GetUser(c echo.Context) error {
+	idStr := c.Param("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
+	}
+
+	user, err := h.userService.GetUser(id)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+	}
+
+	return c.JSON(http.StatusOK, user)
+}
+{{- else if eq .Vars.framework "fiber"}}
+func (h *UserHandler) GetUser(c *fiber.Ctx) error {
+	idStr := c.Params("id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		return c.Status(http.StatusBadRequest).JSON(fiber.Map{"error": "Invalid user ID format"})
+	}
+
+	user, err := h.userService.GetUser(id)
+	if err != nil {
+		return c.Status(http.StatusNotFound).JSON(fiber.Map{"error": "User not found"})
+	}
+
+	return c.Status(http.StatusOK).JSON(user)
+}
+{{- else}}
+func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+{{- if eq .Vars.framework "chi"}}
+	idStr := chi.URLParam(r, "id")
+{{- else}}
+	idStr := r.PathValue("id")
+{{- end}}
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		respondJSON(w, http.StatusBadRequest, map[string]string{"error": "Invalid user ID format"})
+		return
+	}
+
+	user, err := h.userService.GetUser(id)
+	if err != nil {
+		respondJSON(w, http.StatusNotFound, map[string]string{"error": "User not found"})
+		return
+	}
+
+	respondJSON(w, http.StatusOK, user)
+}
+{{- end}}
@@ -0,0 +1,124 @@
+{{- $logger := "*slog.Logger" -}}
+{{- if eq .Vars.logger "zap"}}{{$logger = "*zap.Logger"}}{{else if eq .Vars.logger "zerolog"}}{{$logger = "zerolog.Logger"}}{{end -}}
+package middleware
+
+import (
+{{- if eq .Vars.logger "slog"}}
+	"log/slog"
+{{- end}}
+{{- if eq .Vars.framework "chi" "stdlib"}}
+	"net/http"
+{{- end}}
+	"time"
+{{- if or (eq .Vars.framework "gin" "echo" "fiber") (ne .Vars.logger "slog")}}
+{{end}}
+{{- if eq .Vars.framework "gin"}}
+	"github.com/gin-gonic/gin"
+{{- else if eq .Vars.framework "echo"}}
+	"github.com/labstack/echo/v4"
+{{- else if eq .Vars.framework "fiber"}}
+	"github.com/gofiber/fiber/v2"
+{{- end}}
+{{- if eq .Vars.logger "zap"}}
+	"go.uber.org/zap"
+{{- else if eq .Vars.logger "zerolog"}}
+	"github.com/rs/zerolog"
+{{- end}}
+)
+{{- if eq .Vars.framework "gin"}}
+
+// RequestLogger logs every request with its method, path, status, and
+// latency once it has been served.
+func RequestLogger(logger {{$logger}}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		logRequest(logger, c.Request.Method, c.Request.URL.Path, c.Writer.Status(), time.Since(start))
+	}
+}
+{{- else if eq .Vars.framework "echo"}}
+
+// RequestLogger logs every request with its method, path, status, and
+// latency once it has been served.
+func RequestLogger(logger {{$logger}}) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			if err := next(c); err != nil {
+				// Let the error handler write the response, so that its status is logged
+				c.Error(err)
+			}
+			logRequest(logger, c.Request().Method, c.Request().URL.Path, c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
+{{- else if eq .Vars.framework "fiber"}}
+
+// RequestLogger logs every request with its method, path, status, and
+// latency once it has been served.
+func RequestLogger(logger {{$logger}}) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		if err := c.Next(); err != nil {
+			// Let the error handler write the response, so that its status is logged
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		logRequest(logger, c.Method(), c.Path(), c.Response().StatusCode(), time.Since(start))
+		return nil
+	}
+}
+{{- else}}
+
+// RequestLogger logs every request with its method, path, status, and
+// latency once it has been served.
+func RequestLogger(logger {{$logger}}) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+			logRequest(logger, r.Method, r.URL.Path, recorder.status, time.Since(start))
+		})
+	}
+}
+
+// statusRecorder remembers the status code the handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+{{- end}}
+
+// logRequest writes the record of a served request.
+func logRequest(logger {{$logger}}, method, path string, status int, latency time.Duration) {
+{{- if eq .Vars.logger "zap"}}
+	logger.Info("request",
+		zap.String("method", method),
+		zap.String("path", path),
+		zap.Int("status", status),
+		zap.Duration("latency", latency),
+	)
+{{- else if eq .Vars.logger "zerolog"}}
+	logger.Info().
+		Str("method", method).
+		Str("path", path).
+		Int("status", status).
+		Dur("latency", latency).
+		Msg("request")
+{{- else}}
+	logger.Info("request",
+		slog.String("method", method),
+		slog.String("path", path),
+		slog.Int("status", status),
+		slog.Duration("latency", latency),
+	)
+{{- end}}
+}
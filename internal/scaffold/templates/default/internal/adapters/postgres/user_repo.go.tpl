@@ -1,3 +1,169 @@
+{{- if eq .Vars.orm "gorm" -}}
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// ensure PostgresUserRepository implements the port at compile time.
+var _ ports.UserRepository = (*PostgresUserRepository)(nil)
+
+type PostgresUserRepository struct {
+	db *gorm.DB
+}
+
+func NewPostgresUserRepository(db *gorm.DB) *PostgresUserRepository {
+	return &PostgresUserRepository{db: db}
+}
+
+func (r *PostgresUserRepository) FindByID(id int64) (*domain.User, error) {
+	user := &domain.User{}
+	err := r.db.WithContext(context.Background()).First(user, id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.New("user not found") // Or a custom error type
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *PostgresUserRepository) Create(user *domain.User) error {
+	return r.db.WithContext(context.Background()).Create(user).Error
+}
+
+func (r *PostgresUserRepository) Update(user *domain.User) error {
+	return r.db.WithContext(context.Background()).Save(user).Error
+}
+
+func (r *PostgresUserRepository) Delete(id int64) error {
+	return r.db.WithContext(context.Background()).Delete(&domain.User{}, id).Error
+}
+{{else if eq .Vars.orm "sqlc" -}}
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"{{.ModuleName}}/internal/adapters/postgres/sqlcgen"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// ensure PostgresUserRepository implements the port at compile time.
+var _ ports.UserRepository = (*PostgresUserRepository)(nil)
+
+// PostgresUserRepository runs the queries in db/queries/users.sql through
+// the code sqlc generates into sqlcgen.
+type PostgresUserRepository struct {
+	queries *sqlcgen.Queries
+}
+
+func NewPostgresUserRepository(pool *pgxpool.Pool) *PostgresUserRepository {
+	return &PostgresUserRepository{queries: sqlcgen.New(pool)}
+}
+
+func (r *PostgresUserRepository) FindByID(id int64) (*domain.User, error) {
+	row, err := r.queries.GetUser(context.Background(), id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found") // Or a custom error type
+		}
+		return nil, err
+	}
+	return &domain.User{ID: row.ID, Email: row.Email, Name: row.Name}, nil
+}
+
+func (r *PostgresUserRepository) Create(user *domain.User) error {
+	row, err := r.queries.CreateUser(context.Background(), sqlcgen.CreateUserParams{
+		Email: user.Email,
+		Name:  user.Name,
+	})
+	if err != nil {
+		return err
+	}
+	user.ID = row.ID
+	return nil
+}
+
+func (r *PostgresUserRepository) Update(user *domain.User) error {
+	return r.queries.UpdateUser(context.Background(), sqlcgen.UpdateUserParams{
+		ID:    user.ID,
+		Email: user.Email,
+		Name:  user.Name,
+	})
+}
+
+func (r *PostgresUserRepository) Delete(id int64) error {
+	return r.queries.DeleteUser(context.Background(), id)
+}
+{{else if eq .Vars.orm "ent" -}}
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"{{.ModuleName}}/ent"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// ensure PostgresUserRepository implements the port at compile time.
+var _ ports.UserRepository = (*PostgresUserRepository)(nil)
+
+// PostgresUserRepository stores users through the ent client generated
+// from ent/schema/user.go.
+type PostgresUserRepository struct {
+	client *ent.Client
+}
+
+func NewPostgresUserRepository(client *ent.Client) *PostgresUserRepository {
+	return &PostgresUserRepository{client: client}
+}
+
+func (r *PostgresUserRepository) FindByID(id int64) (*domain.User, error) {
+	row, err := r.client.User.Get(context.Background(), int(id))
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, errors.New("user not found") // Or a custom error type
+		}
+		return nil, err
+	}
+	return &domain.User{ID: int64(row.ID), Email: row.Email, Name: row.Name}, nil
+}
+
+func (r *PostgresUserRepository) Create(user *domain.User) error {
+	row, err := r.client.User.Create().
+		SetEmail(user.Email).
+		SetName(user.Name).
+		Save(context.Background())
+	if err != nil {
+		return err
+	}
+	user.ID = int64(row.ID)
+	return nil
+}
+
+func (r *PostgresUserRepository) Update(user *domain.User) error {
+	return r.client.User.UpdateOneID(int(user.ID)).
+		SetEmail(user.Email).
+		SetName(user.Name).
+		Exec(context.Background())
+}
+
+func (r *PostgresUserRepository) Delete(id int64) error {
+	return r.client.User.DeleteOneID(int(id)).Exec(context.Background())
+}
+{{else -}}
 package postgres
 
 import (
@@ -50,3 +216,4 @@ func (r *PostgresUserRepository) Delete(id int64) error {
     _, err := r.pool.Exec(context.Background(), query, id)
     return err
 }
+{{end -}}
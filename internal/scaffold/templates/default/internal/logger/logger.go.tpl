@@ -0,0 +1,81 @@
+{{- if eq .Vars.logger "zap" -}}
+// Package logger sets up the application's structured logger, built on
+// zap (https://github.com/uber-go/zap).
+package logger
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Setup creates a JSON logger at level (debug, info, warn, or error; info
+// when unknown), installs it as zap.L(), and routes the standard log
+// package through it.
+func Setup(level string) *zap.Logger {
+	lvl, err := zapcore.ParseLevel(level)
+	if err != nil {
+		lvl = zapcore.InfoLevel
+	}
+
+	config := zap.NewProductionConfig()
+	config.Level = zap.NewAtomicLevelAt(lvl)
+	logger := zap.Must(config.Build())
+
+	zap.ReplaceGlobals(logger)
+	zap.RedirectStdLog(logger)
+	return logger
+}
+{{else if eq .Vars.logger "zerolog" -}}
+// Package logger sets up the application's structured logger, built on
+// zerolog (https://github.com/rs/zerolog).
+package logger
+
+import (
+	stdlog "log"
+	"os"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// Setup creates a JSON logger writing to stdout at level (debug, info,
+// warn, or error; info when unknown), installs it as zerolog's log.Logger,
+// and routes the standard log package through it.
+func Setup(level string) zerolog.Logger {
+	lvl, err := zerolog.ParseLevel(level)
+	if err != nil || lvl == zerolog.NoLevel {
+		lvl = zerolog.InfoLevel
+	}
+
+	logger := zerolog.New(os.Stdout).Level(lvl).With().Timestamp().Logger()
+
+	log.Logger = logger
+	stdlog.SetFlags(0)
+	stdlog.SetOutput(logger)
+	return logger
+}
+{{else -}}
+// Package logger sets up the application's structured logger, built on
+// the standard library's log/slog.
+package logger
+
+import (
+	"log/slog"
+	"os"
+)
+
+// Setup creates a JSON logger writing to stdout at level (debug, info,
+// warn, or error; info when unknown) and installs it as slog.Default(),
+// which also routes the standard log package through it.
+func Setup(level string) *slog.Logger {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		lvl = slog.LevelInfo
+	}
+
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: lvl}))
+
+	slog.SetDefault(logger)
+	return logger
+}
+{{end -}}
@@ -0,0 +1,33 @@
+{{- if eq .Vars.orm "sqlc" -}}
+# sqlc configuration (https://docs.sqlc.dev). 'goforge g repository
+# <name> --with sqlc' adds query files to the queries directory; regenerate
+# the Go code with 'goforge run sqlc:generate'.
+version: "2"
+sql:
+  - engine: postgresql
+    schema: migrations
+    queries: db/queries
+    gen:
+      go:
+        package: sqlcgen
+        out: internal/adapters/postgres/sqlcgen
+        sql_package: pgx/v5
+        emit_pointers_for_null_types: true
+        overrides:
+          - db_type: timestamptz
+            go_type: time.Time
+          - db_type: timestamptz
+            nullable: true
+            go_type:
+              import: time
+              type: Time
+              pointer: true
+          - db_type: uuid
+            go_type: github.com/google/uuid.UUID
+          - db_type: uuid
+            nullable: true
+            go_type:
+              import: github.com/google/uuid
+              type: UUID
+              pointer: true
+{{- end}}
@@ -0,0 +1,9 @@
+{{- if eq .Vars.orm "ent" -}}
+//go:build tools
+
+// Package tools records the code generators run with 'go run', so that
+// go mod tidy keeps their dependencies in go.mod.
+package tools
+
+import _ "entgo.io/ent/cmd/ent"
+{{- end}}
@@ -0,0 +1,77 @@
+// Package auth issues and verifies the HS256-signed JSON Web Tokens that
+// authenticate API requests.
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/spf13/viper"
+)
+
+// Authenticator issues and verifies the tokens of one issuer.
+type Authenticator struct {
+	secret []byte
+	issuer string
+	ttl    time.Duration
+}
+
+// FromConfig creates an Authenticator from the auth section of the
+// application config.
+func FromConfig() *Authenticator {
+	return New([]byte(viper.GetString("auth.secret")), viper.GetString("auth.issuer"), viper.GetDuration("auth.ttl"))
+}
+
+// New creates an Authenticator signing with secret. Tokens expire after
+// ttl, 24 hours when 0.
+func New(secret []byte, issuer string, ttl time.Duration) *Authenticator {
+	if ttl <= 0 {
+		ttl = 24 * time.Hour
+	}
+	return &Authenticator{secret: secret, issuer: issuer, ttl: ttl}
+}
+
+// Issue returns a signed token for subject, e.g. a user ID.
+func (a *Authenticator) Issue(subject string) (string, error) {
+	now := time.Now()
+	claims := jwt.RegisteredClaims{
+		Subject:   subject,
+		Issuer:    a.issuer,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(a.ttl)),
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(a.secret)
+}
+
+// Verify checks the signature, issuer, and expiry of token and returns
+// its subject.
+func (a *Authenticator) Verify(token string) (string, error) {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if a.issuer != "" {
+		options = append(options, jwt.WithIssuer(a.issuer))
+	}
+
+	claims := &jwt.RegisteredClaims{}
+	keyFunc := func(*jwt.Token) (any, error) { return a.secret, nil }
+	if _, err := jwt.ParseWithClaims(token, claims, keyFunc, options...); err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	if claims.Subject == "" {
+		return "", errors.New("invalid token: no subject")
+	}
+	return claims.Subject, nil
+}
+
+// bearerToken extracts the token of an "Authorization: Bearer <token>"
+// header.
+func bearerToken(header string) (string, bool) {
+	token, ok := strings.CutPrefix(header, "Bearer ")
+	token = strings.TrimSpace(token)
+	return token, ok && token != ""
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+{{- if eq .Vars.framework "chi" "stdlib"}}
+	"context"
+{{- end}}
+	"net/http"
+{{- if eq .Vars.framework "gin"}}
+
+	"github.com/gin-gonic/gin"
+{{- else if eq .Vars.framework "echo"}}
+
+	"github.com/labstack/echo/v4"
+{{- else if eq .Vars.framework "fiber"}}
+
+	"github.com/gofiber/fiber/v2"
+{{- end}}
+)
+{{- if eq .Vars.framework "gin"}}
+
+// subjectKey is the gin context key holding the authenticated subject.
+const subjectKey = "auth.subject"
+
+// Middleware rejects requests without a valid bearer token with 401 and
+// keeps the subject of the token for Subject.
+func (a *Authenticator) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := bearerToken(c.GetHeader("Authorization"))
+		if !ok {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+			return
+		}
+		subject, err := a.Verify(token)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return
+		}
+		c.Set(subjectKey, subject)
+		c.Next()
+	}
+}
+
+// Subject returns the authenticated subject of the request, empty behind
+// no Middleware.
+func Subject(c *gin.Context) string {
+	return c.GetString(subjectKey)
+}
+{{- else if eq .Vars.framework "echo"}}
+
+// subjectKey is the echo context key holding the authenticated subject.
+const subjectKey = "auth.subject"
+
+// Middleware rejects requests without a valid bearer token with 401 and
+// keeps the subject of the token for Subject.
+func (a *Authenticator) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, ok := bearerToken(c.Request().Header.Get("Authorization"))
+			if !ok {
+				return echo.NewHTTPError(http.StatusUnauthorized, "missing bearer token")
+			}
+			subject, err := a.Verify(token)
+			if err != nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "invalid token")
+			}
+			c.Set(subjectKey, subject)
+			return next(c)
+		}
+	}
+}
+
+// Subject returns the authenticated subject of the request, empty behind
+// no Middleware.
+func Subject(c echo.Context) string {
+	subject, _ := c.Get(subjectKey).(string)
+	return subject
+}
+{{- else if eq .Vars.framework "fiber"}}
+
+// subjectKey is the fiber locals key holding the authenticated subject.
+const subjectKey = "auth.subject"
+
+// Middleware rejects requests without a valid bearer token with 401 and
+// keeps the subject of the token for Subject.
+func (a *Authenticator) Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, ok := bearerToken(c.Get(fiber.HeaderAuthorization))
+		if !ok {
+			return fiber.NewError(http.StatusUnauthorized, "missing bearer token")
+		}
+		subject, err := a.Verify(token)
+		if err != nil {
+			return fiber.NewError(http.StatusUnauthorized, "invalid token")
+		}
+		c.Locals(subjectKey, subject)
+		return c.Next()
+	}
+}
+
+// Subject returns the authenticated subject of the request, empty behind
+// no Middleware.
+func Subject(c *fiber.Ctx) string {
+	subject, _ := c.Locals(subjectKey).(string)
+	return subject
+}
+{{- else}}
+
+// subjectKey is the context key holding the authenticated subject.
+type subjectKey struct{}
+
+// Middleware rejects requests without a valid bearer token with 401 and
+// keeps the subject of the token for Subject.
+func (a *Authenticator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := bearerToken(r.Header.Get("Authorization"))
+		if !ok {
+			http.Error(w, "missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		subject, err := a.Verify(token)
+		if err != nil {
+			http.Error(w, "invalid token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), subjectKey{}, subject)))
+	})
+}
+
+// Subject returns the authenticated subject of the request, empty behind
+// no Middleware.
+func Subject(ctx context.Context) string {
+	subject, _ := ctx.Value(subjectKey{}).(string)
+	return subject
+}
+{{- end}}
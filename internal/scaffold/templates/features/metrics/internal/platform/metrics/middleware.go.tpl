@@ -0,0 +1,96 @@
+package metrics
+
+import (
+{{- if eq .Vars.framework "chi" "stdlib"}}
+	"net/http"
+{{- end}}
+	"time"
+{{- if eq .Vars.framework "gin"}}
+
+	"github.com/gin-gonic/gin"
+{{- else if eq .Vars.framework "echo"}}
+
+	"github.com/labstack/echo/v4"
+{{- else if eq .Vars.framework "fiber"}}
+
+	"github.com/gofiber/fiber/v2"
+{{- else if eq .Vars.framework "chi"}}
+
+	"github.com/go-chi/chi/v5"
+{{- end}}
+)
+{{- if eq .Vars.framework "gin"}}
+
+// Middleware records the duration of every request by its route pattern.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		Observe(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(start))
+	}
+}
+{{- else if eq .Vars.framework "echo"}}
+
+// Middleware records the duration of every request by its route pattern.
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+			if err != nil {
+				// Let the error handler write the response, so that its status is recorded
+				c.Error(err)
+			}
+			Observe(c.Request().Method, c.Path(), c.Response().Status, time.Since(start))
+			return nil
+		}
+	}
+}
+{{- else if eq .Vars.framework "fiber"}}
+
+// Middleware records the duration of every request by its route pattern.
+func Middleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+		if err != nil {
+			// Let the error handler write the response, so that its status is recorded
+			if err := c.App().ErrorHandler(c, err); err != nil {
+				_ = c.SendStatus(fiber.StatusInternalServerError)
+			}
+		}
+		Observe(c.Method(), c.Route().Path, c.Response().StatusCode(), time.Since(start))
+		return nil
+	}
+}
+{{- else}}
+
+// Middleware records the duration of every request by its route pattern.
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+{{- if eq .Vars.framework "chi"}}
+		route := ""
+		if routes := chi.RouteContext(r.Context()); routes != nil {
+			route = routes.RoutePattern()
+		}
+		Observe(r.Method, route, recorder.status, time.Since(start))
+{{- else}}
+		Observe(r.Method, r.Pattern, recorder.status, time.Since(start))
+{{- end}}
+	})
+}
+
+// statusRecorder remembers the status code the handler writes.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+{{- end}}
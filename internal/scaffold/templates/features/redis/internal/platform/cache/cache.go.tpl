@@ -0,0 +1,62 @@
+// Package cache stores JSON-encoded values in Redis.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/spf13/viper"
+)
+
+// ErrMiss is returned by Get for keys that are not cached.
+var ErrMiss = errors.New("cache: miss")
+
+// NewClient connects to the Redis server of the redis section of the
+// application config.
+func NewClient() *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     viper.GetString("redis.addr"),
+		Password: viper.GetString("redis.password"),
+		DB:       viper.GetInt("redis.db"),
+	})
+}
+
+// Cache stores values under a key prefix.
+type Cache struct {
+	client *redis.Client
+	prefix string
+}
+
+// New creates a Cache keeping its keys under prefix, e.g. "users:".
+func New(client *redis.Client, prefix string) *Cache {
+	return &Cache{client: client, prefix: prefix}
+}
+
+// Get decodes the value cached under key into dest, or returns ErrMiss.
+func (c *Cache) Get(ctx context.Context, key string, dest any) error {
+	data, err := c.client.Get(ctx, c.prefix+key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return ErrMiss
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// Set caches value under key for ttl; 0 keeps it until deleted.
+func (c *Cache) Set(ctx context.Context, key string, value any, ttl time.Duration) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return c.client.Set(ctx, c.prefix+key, data, ttl).Err()
+}
+
+// Delete removes key from the cache.
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	return c.client.Del(ctx, c.prefix+key).Err()
+}
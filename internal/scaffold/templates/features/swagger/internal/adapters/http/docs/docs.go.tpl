@@ -0,0 +1,95 @@
+// Package docs serves the OpenAPI spec of the API with Swagger UI.
+package docs
+
+import (
+	_ "embed"
+	"net/http"
+{{- if eq .Vars.framework "gin"}}
+
+	"github.com/gin-gonic/gin"
+{{- else if eq .Vars.framework "echo"}}
+
+	"github.com/labstack/echo/v4"
+{{- else if eq .Vars.framework "fiber"}}
+
+	"github.com/gofiber/fiber/v2"
+{{- else if eq .Vars.framework "chi"}}
+
+	"github.com/go-chi/chi/v5"
+{{- end}}
+)
+
+// spec is the OpenAPI document; regenerate it with 'goforge run docs:openapi'.
+//
+//go:embed openapi.json
+var spec []byte
+
+// page shows the spec in Swagger UI, loaded from a CDN.
+//
+//go:embed index.html
+var page []byte
+{{- if eq .Vars.framework "gin"}}
+
+// RegisterRoutes serves Swagger UI at /docs and the spec at
+// /docs/openapi.json.
+func RegisterRoutes(router gin.IRouter) {
+	router.GET("/docs", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", page)
+	})
+	router.GET("/docs/openapi.json", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", spec)
+	})
+}
+{{- else if eq .Vars.framework "echo"}}
+
+// RegisterRoutes serves Swagger UI at /docs and the spec at
+// /docs/openapi.json.
+func RegisterRoutes(e *echo.Echo) {
+	e.GET("/docs", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "text/html; charset=utf-8", page)
+	})
+	e.GET("/docs/openapi.json", func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "application/json", spec)
+	})
+}
+{{- else if eq .Vars.framework "fiber"}}
+
+// RegisterRoutes serves Swagger UI at /docs and the spec at
+// /docs/openapi.json.
+func RegisterRoutes(router fiber.Router) {
+	router.Get("/docs", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "text/html; charset=utf-8")
+		return c.Status(http.StatusOK).Send(page)
+	})
+	router.Get("/docs/openapi.json", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderContentType, "application/json")
+		return c.Status(http.StatusOK).Send(spec)
+	})
+}
+{{- else if eq .Vars.framework "chi"}}
+
+// RegisterRoutes serves Swagger UI at /docs and the spec at
+// /docs/openapi.json.
+func RegisterRoutes(router chi.Router) {
+	router.Get("/docs", serve("text/html; charset=utf-8", page))
+	router.Get("/docs/openapi.json", serve("application/json", spec))
+}
+{{- else}}
+
+// RegisterRoutes serves Swagger UI at /docs and the spec at
+// /docs/openapi.json.
+func RegisterRoutes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /docs", serve("text/html; charset=utf-8", page))
+	mux.HandleFunc("GET /docs/openapi.json", serve("application/json", spec))
+}
+{{- end}}
+{{- if eq .Vars.framework "chi" "stdlib"}}
+
+// serve writes content with its content type.
+func serve(contentType string, content []byte) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = w.Write(content)
+	}
+}
+{{- end}}
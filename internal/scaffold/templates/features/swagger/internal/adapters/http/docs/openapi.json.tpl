@@ -0,0 +1,26 @@
+{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "{{.ProjectName}} API",
+{{- if .Description}}
+    "description": {{quote .Description}},
+{{- end}}
+{{- if or .Author .Repository}}
+    "contact": {
+{{- if .Author}}
+      "name": {{quote .Author}}{{if .Repository}},{{end}}
+{{- end}}
+{{- if .Repository}}
+      "url": {{quote .Repository}}
+{{- end}}
+    },
+{{- end}}
+{{- if .License}}
+    "license": {
+      "name": {{quote .License}}
+    },
+{{- end}}
+    "version": "1.0"
+  },
+  "paths": {}
+}
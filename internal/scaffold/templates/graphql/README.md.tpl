@@ -0,0 +1,74 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This GraphQL API was generated by [GoForge](https://github.com/night-slayer18/goforge). It follows the same clean architecture as the default REST template, with GraphQL as the transport.
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+- PostgreSQL, configured in `config/default.yml`
+
+### Project Layout
+
+- `internal/domain/` — the entities, `User` and `Post`
+- `internal/ports/` — the repository interfaces
+- `internal/app/service/` — the use cases
+- `internal/adapters/postgres/` — the repositories
+- `internal/adapters/graphql/schema/` — the GraphQL schema, one file per resource
+- `internal/adapters/graphql/` — the resolvers, one `<name>.resolvers.go` per schema file
+- `internal/adapters/graphql/dataloader/` — per-request loaders batching the lookups of nested fields such as `Post.author`
+- `internal/adapters/graphql/generated/`, `model/` — generated by gqlgen; do not edit
+
+GraphQL types are bound to the domain models of the same name (`autobind` in `gqlgen.yml`), so resolvers return domain types; fields a model lacks get their own resolver.
+
+### Running the Application
+
+1.  **Create the tables:**
+    ```bash
+    goforge run db:migrate
+    ```
+
+2.  **Start the server:**
+    ```bash
+    goforge run dev
+    ```
+    Open the playground at http://localhost:8080/playground and try:
+    ```graphql
+    mutation { createUser(input: {email: "ada@example.com", name: "Ada"}) { id } }
+    query { posts { title author { name } } }
+    ```
+
+### Changing the Schema
+
+Edit the `.graphqls` files, then regenerate the code; implementations in the resolver files are kept:
+
+```bash
+goforge run graphql:generate
+```
+
+Add a resource with its schema file and resolvers:
+
+```bash
+goforge g resolver comment
+```
+
+### Available Scripts
+
+-   `goforge run dev`: Starts the server.
+-   `goforge run graphql:generate`: Regenerates the GraphQL code from the schema.
+-   `goforge run db:migrate`: Applies the migrations in `migrations/`.
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+
+	"{{.ModuleName}}/internal/adapters/database"
+	"{{.ModuleName}}/internal/adapters/graphql"
+	"{{.ModuleName}}/internal/adapters/postgres"
+	"{{.ModuleName}}/internal/app/service"
+)
+
+func main() {
+	// --- Configuration Setup ---
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // e.g. DATABASE_HOST overrides database.host
+	viper.AutomaticEnv()
+	if err := viper.ReadInConfig(); err != nil {
+		log.Fatalf("Error reading config file: %s", err)
+	}
+
+	port := viper.GetInt("server.port")
+	if port == 0 {
+		port = 8080 // Default port
+	}
+
+	// --- Dependency Injection ---
+	// 1. Database Connection
+	dbPool := database.Connect()
+	defer dbPool.Close() // Ensure the connection pool is closed on exit
+
+	// 2. Repositories (Adapters)
+	userRepo := postgres.NewPostgresUserRepository(dbPool)
+	postRepo := postgres.NewPostgresPostRepository(dbPool)
+
+	// 3. Services (Application Core)
+	userService := service.NewUserService(userRepo)
+	postService := service.NewPostService(postRepo, userRepo)
+
+	// 4. Resolvers (Adapters)
+	resolver := &graphql.Resolver{
+		UserService: userService,
+		PostService: postService,
+	}
+
+	// --- Gin Router Setup ---
+	router := gin.Default()
+
+	router.Any("/graphql", gin.WrapH(graphql.NewHandler(resolver)))
+	router.GET("/playground", gin.WrapH(graphql.PlaygroundHandler("/graphql")))
+
+	router.GET("/health", func(c *gin.Context) {
+		c.JSON(200, gin.H{"status": "UP"})
+	})
+
+	// --- Start Server ---
+	serverAddr := fmt.Sprintf(":%d", port)
+	fmt.Printf("🚀 Server starting on http://localhost%s (playground at /playground)\n", serverAddr)
+
+	if err := router.Run(serverAddr); err != nil {
+		log.Fatalf("❌ Could not start server: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+# gqlgen configuration (https://gqlgen.com/config/), created by goforge.
+# Regenerate the GraphQL code after editing the schema with:
+#   goforge run graphql:generate
+schema:
+  - internal/adapters/graphql/schema/*.graphqls
+
+# Generated executable schema
+exec:
+  package: generated
+  layout: single-file
+  filename: internal/adapters/graphql/generated/generated.go
+
+# Generated models for types not bound to existing Go types, such as inputs
+model:
+  package: model
+  filename: internal/adapters/graphql/model/models_gen.go
+
+# Resolvers, one file per schema file; implementations are kept when
+# the code is regenerated
+resolver:
+  package: graphql
+  layout: follow-schema
+  dir: internal/adapters/graphql
+  filename_template: "{name}.resolvers.go"
+
+# GraphQL types are bound to the domain models with the same name; fields
+# the models lack, such as Post.author, get resolvers
+autobind:
+  - "{{.ModuleName}}/internal/domain"
+
+models:
+  ID:
+    model:
+      - github.com/99designs/gqlgen/graphql.Int64
+      - github.com/99designs/gqlgen/graphql.ID
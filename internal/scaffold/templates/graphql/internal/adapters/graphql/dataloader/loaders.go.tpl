@@ -0,0 +1,74 @@
+// Package dataloader batches the lookups the resolvers of one request
+// make, so that resolving the author of every post in a list costs one
+// query instead of one per post.
+package dataloader
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/vikstrous/dataloadgen"
+
+	"{{.ModuleName}}/internal/app/service"
+	"{{.ModuleName}}/internal/domain"
+)
+
+// wait is how long a loader collects keys before fetching them.
+const wait = 2 * time.Millisecond
+
+type contextKey struct{}
+
+// Loaders holds the loaders of one request.
+type Loaders struct {
+	UserByID      *dataloadgen.Loader[int64, *domain.User]
+	PostsByAuthor *dataloadgen.Loader[int64, []*domain.Post]
+}
+
+// New creates the loaders for one request.
+func New(users *service.UserService, posts *service.PostService) *Loaders {
+	return &Loaders{
+		UserByID: dataloadgen.NewLoader(func(ctx context.Context, ids []int64) ([]*domain.User, []error) {
+			byID, err := users.GetUsers(ctx, ids)
+			if err != nil {
+				return nil, []error{err}
+			}
+			result := make([]*domain.User, len(ids))
+			errs := make([]error, len(ids))
+			for i, id := range ids {
+				if result[i] = byID[id]; result[i] == nil {
+					errs[i] = fmt.Errorf("user %d: %w", id, domain.ErrNotFound)
+				}
+			}
+			return result, errs
+		}, dataloadgen.WithWait(wait)),
+
+		PostsByAuthor: dataloadgen.NewLoader(func(ctx context.Context, authorIDs []int64) ([][]*domain.Post, []error) {
+			byAuthor, err := posts.ListPostsByAuthors(ctx, authorIDs)
+			if err != nil {
+				return nil, []error{err}
+			}
+			result := make([][]*domain.Post, len(authorIDs))
+			for i, id := range authorIDs {
+				// Authors without posts get an empty list, not null
+				result[i] = append([]*domain.Post{}, byAuthor[id]...)
+			}
+			return result, nil
+		}, dataloadgen.WithWait(wait)),
+	}
+}
+
+// Middleware gives every request fresh loaders, so nothing is cached
+// across requests.
+func Middleware(users *service.UserService, posts *service.PostService, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), contextKey{}, New(users, posts))
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// For returns the loaders of the request handling ctx.
+func For(ctx context.Context) *Loaders {
+	return ctx.Value(contextKey{}).(*Loaders)
+}
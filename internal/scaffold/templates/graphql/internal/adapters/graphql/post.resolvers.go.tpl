@@ -0,0 +1,33 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+
+	"{{.ModuleName}}/internal/adapters/graphql/dataloader"
+	"{{.ModuleName}}/internal/adapters/graphql/generated"
+	"{{.ModuleName}}/internal/adapters/graphql/model"
+	"{{.ModuleName}}/internal/domain"
+)
+
+// Author is the resolver for the author field.
+func (r *postResolver) Author(ctx context.Context, obj *domain.Post) (*domain.User, error) {
+	return dataloader.For(ctx).UserByID.Load(ctx, obj.AuthorID)
+}
+
+// CreatePost is the resolver for the createPost field.
+func (r *mutationResolver) CreatePost(ctx context.Context, input model.CreatePostInput) (*domain.Post, error) {
+	return r.PostService.CreatePost(ctx, input.AuthorID, input.Title, input.Body)
+}
+
+// Posts is the resolver for the posts field.
+func (r *queryResolver) Posts(ctx context.Context) ([]*domain.Post, error) {
+	return r.PostService.ListPosts(ctx)
+}
+
+// Post returns generated.PostResolver implementation.
+func (r *Resolver) Post() generated.PostResolver { return &postResolver{r} }
+
+type postResolver struct{ *Resolver }
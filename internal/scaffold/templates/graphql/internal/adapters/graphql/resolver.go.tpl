@@ -0,0 +1,18 @@
+package graphql
+
+import (
+	"{{.ModuleName}}/internal/app/service"
+)
+
+// This file will not be regenerated automatically.
+//
+// It serves as dependency injection for your app, add any dependencies you require here.
+
+// Resolver is the root resolver. gqlgen generates the code calling it from
+// the schema; the services the resolvers use are its fields, set where the
+// handler is created.
+type Resolver struct {
+	UserService *service.UserService
+	PostService *service.PostService
+	// goforge:resolvers
+}
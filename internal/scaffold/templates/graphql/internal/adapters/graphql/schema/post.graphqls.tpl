@@ -0,0 +1,22 @@
+type Post {
+  id: ID!
+  title: String!
+  body: String!
+  createdAt: Time!
+  author: User!
+}
+
+input CreatePostInput {
+  authorId: ID!
+  title: String!
+  body: String!
+}
+
+extend type Query {
+  "Lists the posts, newest first."
+  posts: [Post!]!
+}
+
+extend type Mutation {
+  createPost(input: CreatePostInput!): Post!
+}
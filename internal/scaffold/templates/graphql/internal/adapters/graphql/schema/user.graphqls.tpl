@@ -0,0 +1,23 @@
+type User {
+  id: ID!
+  email: String!
+  name: String!
+  "The user's posts, newest first."
+  posts: [Post!]!
+}
+
+input CreateUserInput {
+  email: String!
+  name: String!
+}
+
+extend type Query {
+  "Returns one user by ID, or null when it does not exist."
+  user(id: ID!): User
+  "Lists the users."
+  users: [User!]!
+}
+
+extend type Mutation {
+  createUser(input: CreateUserInput!): User!
+}
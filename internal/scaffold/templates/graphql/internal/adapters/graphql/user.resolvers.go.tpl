@@ -0,0 +1,43 @@
+package graphql
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+
+import (
+	"context"
+	"errors"
+
+	"{{.ModuleName}}/internal/adapters/graphql/dataloader"
+	"{{.ModuleName}}/internal/adapters/graphql/generated"
+	"{{.ModuleName}}/internal/adapters/graphql/model"
+	"{{.ModuleName}}/internal/domain"
+)
+
+// Posts is the resolver for the posts field.
+func (r *userResolver) Posts(ctx context.Context, obj *domain.User) ([]*domain.Post, error) {
+	return dataloader.For(ctx).PostsByAuthor.Load(ctx, obj.ID)
+}
+
+// CreateUser is the resolver for the createUser field.
+func (r *mutationResolver) CreateUser(ctx context.Context, input model.CreateUserInput) (*domain.User, error) {
+	return r.UserService.CreateUser(ctx, input.Email, input.Name)
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id int64) (*domain.User, error) {
+	user, err := r.UserService.GetUser(ctx, id)
+	if errors.Is(err, domain.ErrNotFound) {
+		return nil, nil
+	}
+	return user, err
+}
+
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context) ([]*domain.User, error) {
+	return r.UserService.ListUsers(ctx)
+}
+
+// User returns generated.UserResolver implementation.
+func (r *Resolver) User() generated.UserResolver { return &userResolver{r} }
+
+type userResolver struct{ *Resolver }
@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// ensure PostgresPostRepository implements the port at compile time.
+var _ ports.PostRepository = (*PostgresPostRepository)(nil)
+
+type PostgresPostRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresPostRepository(pool *pgxpool.Pool) *PostgresPostRepository {
+	return &PostgresPostRepository{pool: pool}
+}
+
+func (r *PostgresPostRepository) List(ctx context.Context) ([]*domain.Post, error) {
+	return r.query(ctx, "SELECT id, author_id, title, body, created_at FROM posts ORDER BY created_at DESC")
+}
+
+func (r *PostgresPostRepository) ListByAuthors(ctx context.Context, authorIDs []int64) ([]*domain.Post, error) {
+	return r.query(ctx, "SELECT id, author_id, title, body, created_at FROM posts WHERE author_id = ANY($1) ORDER BY created_at DESC", authorIDs)
+}
+
+func (r *PostgresPostRepository) Create(ctx context.Context, post *domain.Post) error {
+	query := "INSERT INTO posts (author_id, title, body) VALUES ($1, $2, $3) RETURNING id, created_at"
+	return r.pool.QueryRow(ctx, query, post.AuthorID, post.Title, post.Body).Scan(&post.ID, &post.CreatedAt)
+}
+
+// query runs a query selecting posts.
+func (r *PostgresPostRepository) query(ctx context.Context, query string, args ...any) ([]*domain.Post, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Post, error) {
+		post := &domain.Post{}
+		err := row.Scan(&post.ID, &post.AuthorID, &post.Title, &post.Body, &post.CreatedAt)
+		return post, err
+	})
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// ensure PostgresUserRepository implements the port at compile time.
+var _ ports.UserRepository = (*PostgresUserRepository)(nil)
+
+type PostgresUserRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewPostgresUserRepository(pool *pgxpool.Pool) *PostgresUserRepository {
+	return &PostgresUserRepository{pool: pool}
+}
+
+func (r *PostgresUserRepository) FindByID(ctx context.Context, id int64) (*domain.User, error) {
+	user := &domain.User{}
+	query := "SELECT id, email, name FROM users WHERE id = $1"
+
+	err := r.pool.QueryRow(ctx, query, id).Scan(&user.ID, &user.Email, &user.Name)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *PostgresUserRepository) FindByIDs(ctx context.Context, ids []int64) ([]*domain.User, error) {
+	return r.query(ctx, "SELECT id, email, name FROM users WHERE id = ANY($1)", ids)
+}
+
+func (r *PostgresUserRepository) List(ctx context.Context) ([]*domain.User, error) {
+	return r.query(ctx, "SELECT id, email, name FROM users ORDER BY id")
+}
+
+func (r *PostgresUserRepository) Create(ctx context.Context, user *domain.User) error {
+	query := "INSERT INTO users (email, name) VALUES ($1, $2) RETURNING id"
+	return r.pool.QueryRow(ctx, query, user.Email, user.Name).Scan(&user.ID)
+}
+
+// query runs a query selecting users.
+func (r *PostgresUserRepository) query(ctx context.Context, query string, args ...any) ([]*domain.User, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.User, error) {
+		user := &domain.User{}
+		err := row.Scan(&user.ID, &user.Email, &user.Name)
+		return user, err
+	})
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// PostService implements the application logic for posts.
+type PostService struct {
+	postRepo ports.PostRepository
+	userRepo ports.UserRepository
+}
+
+// NewPostService is a factory function that creates a new PostService.
+func NewPostService(postRepo ports.PostRepository, userRepo ports.UserRepository) *PostService {
+	return &PostService{
+		postRepo: postRepo,
+		userRepo: userRepo,
+	}
+}
+
+// ListPosts retrieves every post, newest first.
+func (s *PostService) ListPosts(ctx context.Context) ([]*domain.Post, error) {
+	return s.postRepo.List(ctx)
+}
+
+// ListPostsByAuthors retrieves the posts of several authors at once, keyed
+// by author ID.
+func (s *PostService) ListPostsByAuthors(ctx context.Context, authorIDs []int64) (map[int64][]*domain.Post, error) {
+	posts, err := s.postRepo.ListByAuthors(ctx, authorIDs)
+	if err != nil {
+		return nil, err
+	}
+	byAuthor := make(map[int64][]*domain.Post, len(authorIDs))
+	for _, post := range posts {
+		byAuthor[post.AuthorID] = append(byAuthor[post.AuthorID], post)
+	}
+	return byAuthor, nil
+}
+
+// CreatePost publishes a post by an existing user.
+func (s *PostService) CreatePost(ctx context.Context, authorID int64, title, body string) (*domain.Post, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, fmt.Errorf("%w: title is required", domain.ErrInvalid)
+	}
+	if _, err := s.userRepo.FindByID(ctx, authorID); err != nil {
+		return nil, fmt.Errorf("author %d: %w", authorID, err)
+	}
+
+	post := &domain.Post{AuthorID: authorID, Title: title, Body: body}
+	if err := s.postRepo.Create(ctx, post); err != nil {
+		return nil, err
+	}
+	return post, nil
+}
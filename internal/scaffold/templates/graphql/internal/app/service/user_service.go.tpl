@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"{{.ModuleName}}/internal/domain"
+	"{{.ModuleName}}/internal/ports"
+)
+
+// UserService implements the application logic for user management.
+type UserService struct {
+	userRepo ports.UserRepository
+}
+
+// NewUserService is a factory function that creates a new UserService.
+func NewUserService(userRepo ports.UserRepository) *UserService {
+	return &UserService{
+		userRepo: userRepo,
+	}
+}
+
+// GetUser is a use case that retrieves a user by their ID.
+func (s *UserService) GetUser(ctx context.Context, id int64) (*domain.User, error) {
+	return s.userRepo.FindByID(ctx, id)
+}
+
+// GetUsers retrieves several users at once, keyed by ID; IDs without a
+// user are left out.
+func (s *UserService) GetUsers(ctx context.Context, ids []int64) (map[int64]*domain.User, error) {
+	users, err := s.userRepo.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]*domain.User, len(users))
+	for _, user := range users {
+		byID[user.ID] = user
+	}
+	return byID, nil
+}
+
+// ListUsers retrieves every user.
+func (s *UserService) ListUsers(ctx context.Context) ([]*domain.User, error) {
+	return s.userRepo.List(ctx)
+}
+
+// CreateUser registers a user after checking the input.
+func (s *UserService) CreateUser(ctx context.Context, email, name string) (*domain.User, error) {
+	email, name = strings.TrimSpace(email), strings.TrimSpace(name)
+	if !strings.Contains(email, "@") {
+		return nil, fmt.Errorf("%w: email must be an email address", domain.ErrInvalid)
+	}
+	if name == "" {
+		return nil, fmt.Errorf("%w: name is required", domain.ErrInvalid)
+	}
+
+	user := &domain.User{Email: email, Name: name}
+	if err := s.userRepo.Create(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
@@ -0,0 +1,10 @@
+package domain
+
+import "errors"
+
+// Errors the application core returns, which the adapters translate for
+// their clients.
+var (
+	ErrNotFound = errors.New("not found")
+	ErrInvalid  = errors.New("invalid input")
+)
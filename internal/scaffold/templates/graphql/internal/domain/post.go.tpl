@@ -0,0 +1,12 @@
+package domain
+
+import "time"
+
+// Post is an article written by a user.
+type Post struct {
+	ID        int64     `json:"id"`
+	AuthorID  int64     `json:"author_id"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
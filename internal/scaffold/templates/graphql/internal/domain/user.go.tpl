@@ -0,0 +1,8 @@
+package domain
+
+// User represents a user entity within the system.
+type User struct {
+	ID    int64  `json:"id"`
+	Email string `json:"email"`
+	Name  string `json:"name"`
+}
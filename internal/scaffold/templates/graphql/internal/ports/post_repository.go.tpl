@@ -0,0 +1,15 @@
+package ports
+
+import (
+	"context"
+
+	"{{.ModuleName}}/internal/domain"
+)
+
+// PostRepository defines the contract for database operations on Post objects.
+type PostRepository interface {
+	List(ctx context.Context) ([]*domain.Post, error)
+	// ListByAuthors returns the posts of all the given authors, newest first.
+	ListByAuthors(ctx context.Context, authorIDs []int64) ([]*domain.Post, error)
+	Create(ctx context.Context, post *domain.Post) error
+}
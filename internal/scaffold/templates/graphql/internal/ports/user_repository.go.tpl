@@ -0,0 +1,17 @@
+package ports
+
+import (
+	"context"
+
+	"{{.ModuleName}}/internal/domain"
+)
+
+// UserRepository defines the contract for database operations on User objects.
+type UserRepository interface {
+	// FindByID returns domain.ErrNotFound when there is no such user.
+	FindByID(ctx context.Context, id int64) (*domain.User, error)
+	// FindByIDs returns the users that exist, in no particular order.
+	FindByIDs(ctx context.Context, ids []int64) ([]*domain.User, error)
+	List(ctx context.Context) ([]*domain.User, error)
+	Create(ctx context.Context, user *domain.User) error
+}
@@ -0,0 +1,7 @@
+//go:build tools
+
+// Package tools records the code generators run with 'go run', so that
+// go mod tidy keeps their dependencies in go.mod.
+package tools
+
+import _ "github.com/99designs/gqlgen"
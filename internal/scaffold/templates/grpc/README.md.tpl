@@ -0,0 +1,64 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This gRPC microservice was generated by [GoForge](https://github.com/night-slayer18/goforge).
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+
+`buf` and the `protoc-gen-go`/`protoc-gen-go-grpc` plugins are installed with `go install` the first time code is generated.
+
+### Project Layout
+
+- `api/proto/` — service definitions (`greeter/v1/greeter.proto` is an example)
+- `gen/proto/` — Go code generated from them; do not edit
+- `internal/adapters/grpc/` — service implementations
+- `internal/adapters/grpc/interceptor/` — logging and panic recovery interceptors
+- `cmd/server/` — the server, with the gRPC health service and server reflection
+- `cmd/client/` — an example client
+
+### Running the Service
+
+1.  **Generate the Go code from the .proto files** (after every change to them):
+    ```bash
+    goforge run proto:generate
+    ```
+
+2.  **Start the server** on the port in `config/default.yml` (9090):
+    ```bash
+    goforge run dev
+    ```
+
+3.  **Call it** with the example client, or any reflection-aware tool:
+    ```bash
+    goforge run client
+    grpcurl -plaintext -d '{"name": "Ada"}' localhost:9090 greeter.v1.GreeterService/SayHello
+    ```
+
+### Adding Services
+
+```bash
+goforge g grpc order    # api/proto/order/v1/order.proto, a server, registered in cmd/server
+```
+
+### Available Scripts
+
+-   `goforge run dev`: Starts the server.
+-   `goforge run proto:generate`: Regenerates the Go code in `gen/proto`.
+-   `goforge run proto:lint`: Lints the .proto files with buf.
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -0,0 +1,20 @@
+syntax = "proto3";
+
+package greeter.v1;
+
+option go_package = "{{.ModuleName}}/gen/proto/greeter/v1;greeterv1";
+
+// GreeterService is an example service; replace it with your own, or add
+// services next to it with 'goforge g grpc <name>'.
+service GreeterService {
+  // SayHello greets the caller by name.
+  rpc SayHello(SayHelloRequest) returns (SayHelloResponse);
+}
+
+message SayHelloRequest {
+  string name = 1;
+}
+
+message SayHelloResponse {
+  string message = 1;
+}
@@ -0,0 +1,10 @@
+# Protobuf module configuration for buf (https://buf.build).
+version: v2
+modules:
+  - path: api/proto
+lint:
+  use:
+    - STANDARD
+breaking:
+  use:
+    - FILE
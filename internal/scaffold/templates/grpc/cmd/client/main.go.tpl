@@ -0,0 +1,48 @@
+// Command client is an example client of the {{.ProjectName}} gRPC server:
+// it checks the server's health, then calls GreeterService.SayHello.
+//
+//	go run ./cmd/client -addr localhost:9090 -name Ada
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	greeterv1 "{{.ModuleName}}/gen/proto/greeter/v1"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:9090", "address of the gRPC server")
+	name := flag.String("name", "world", "name to greet")
+	flag.Parse()
+
+	// The example server listens without TLS; use credentials.NewTLS for
+	// servers behind TLS
+	conn, err := grpc.NewClient(*addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Fatalf("could not connect to %s: %v", *addr, err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	health, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	if err != nil {
+		log.Fatalf("health check failed: %v", err)
+	}
+	fmt.Printf("health: %s\n", health.GetStatus())
+
+	reply, err := greeterv1.NewGreeterServiceClient(conn).SayHello(ctx, &greeterv1.SayHelloRequest{Name: *name})
+	if err != nil {
+		log.Fatalf("SayHello failed: %v", err)
+	}
+	fmt.Println(reply.GetMessage())
+}
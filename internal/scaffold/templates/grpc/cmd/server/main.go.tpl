@@ -0,0 +1,109 @@
+// Command server serves the {{.ProjectName}} gRPC services, with the
+// standard health service and server reflection, until it receives SIGINT
+// or SIGTERM, then lets in-flight calls finish.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	grpcadapter "{{.ModuleName}}/internal/adapters/grpc"
+	"{{.ModuleName}}/internal/adapters/grpc/interceptor"
+	greeterv1 "{{.ModuleName}}/gen/proto/greeter/v1"
+)
+
+// shutdownTimeout is how long in-flight calls get to finish on shutdown.
+const shutdownTimeout = 30 * time.Second
+
+func main() {
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+	viper.SetDefault("grpc.port", 9090)
+	viper.SetDefault("logging.level", "info")
+	configErr := viper.ReadInConfig()
+
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(viper.GetString("logging.level"))); err != nil {
+		level = slog.LevelInfo
+	}
+	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: level}))
+	if configErr != nil {
+		logger.Warn("could not read config, using defaults", "error", configErr)
+	}
+
+	// Interceptors run in order for every call: recovery first, so panics
+	// in the others are caught too
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			interceptor.UnaryRecovery(logger),
+			interceptor.UnaryLogging(logger),
+		),
+		grpc.ChainStreamInterceptor(
+			interceptor.StreamRecovery(logger),
+			interceptor.StreamLogging(logger),
+		),
+	)
+	registerServices(server)
+
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	reflection.Register(server)
+
+	addr := fmt.Sprintf(":%d", viper.GetInt("grpc.port"))
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Error("failed to listen", "addr", addr, "error", err)
+		os.Exit(1)
+	}
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			logger.Error("gRPC server failed", "error", err)
+			os.Exit(1)
+		}
+	}()
+	logger.Info("gRPC server listening", "addr", addr)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+
+	logger.Info("shutting down, waiting for in-flight calls", "timeout", shutdownTimeout)
+	healthServer.Shutdown()
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		logger.Info("gRPC server stopped")
+	case <-time.After(shutdownTimeout):
+		logger.Warn("calls did not finish in time; stopping")
+		server.Stop()
+	}
+}
+
+// registerServices registers every gRPC service with server. 'goforge
+// generate grpc' adds services above the goforge:grpc marker; keep it in
+// place.
+func registerServices(server *grpc.Server) {
+	greeterv1.RegisterGreeterServiceServer(server, grpcadapter.NewGreeterServer())
+	// goforge:grpc
+}
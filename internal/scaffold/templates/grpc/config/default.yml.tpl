@@ -0,0 +1,7 @@
+# Default application configuration.
+# These values can be overridden by environment variables.
+grpc:
+  port: 9090
+
+logging:
+  level: "info" # Options: debug, info, warn, error
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	greeterv1 "{{.ModuleName}}/gen/proto/greeter/v1"
+)
+
+// GreeterServer implements greeterv1.GreeterServiceServer.
+type GreeterServer struct {
+	greeterv1.UnimplementedGreeterServiceServer
+}
+
+// NewGreeterServer creates a new GreeterServer.
+func NewGreeterServer() *GreeterServer {
+	return &GreeterServer{}
+}
+
+// SayHello greets the caller by name.
+func (s *GreeterServer) SayHello(ctx context.Context, req *greeterv1.SayHelloRequest) (*greeterv1.SayHelloResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name is required")
+	}
+	return &greeterv1.SayHelloResponse{Message: fmt.Sprintf("Hello, %s!", req.GetName())}, nil
+}
@@ -0,0 +1,34 @@
+# GoForge and Go build artifacts
+/{{.ProjectName}}
+/{{.ProjectName}}.exe
+/dist
+/vendor/
+go.work
+go.work.sum
+
+# Test binaries and coverage reports
+*.test
+*.out
+cover.html
+
+# IDE settings
+.idea/
+.vscode/
+
+# OS-specific files
+.DS_Store
+Thumbs.db
+
+# Environment variables
+.env
+.env.*
+!.env.example
+
+# SAM build output
+.aws-sam/
+
+# goforge command history
+.goforge/history
+
+# goforge profile captures
+.goforge/profiles/
@@ -0,0 +1,102 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+{{- if eq .Vars.provider "aws"}}
+
+This AWS Lambda function was generated by [GoForge](https://github.com/night-slayer18/goforge).
+{{- else}}
+
+This Google Cloud Function was generated by [GoForge](https://github.com/night-slayer18/goforge).
+{{- end}}
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+{{- if eq .Vars.provider "aws"}}
+- The [AWS SAM CLI](https://docs.aws.amazon.com/serverless-application-model/latest/developerguide/install-sam-cli.html) and Docker, to invoke the function locally and deploy it
+{{- else}}
+- The [gcloud CLI](https://cloud.google.com/sdk/docs/install), to deploy the function
+{{- end}}
+
+### Project Layout
+
+- `internal/handler/` — the function's logic, independent of the cloud: `Handle` takes a `Request` and returns a `Response`
+{{- if eq .Vars.provider "aws"}}
+- `cmd/lambda/` — the Lambda entry point, translating API Gateway HTTP API events to and from the handler
+- `template.yaml` — the AWS SAM template deploying the function behind `POST /hello`
+- `events/event.json` — a sample API Gateway event for local invocation
+{{- else}}
+- `function.go` — the Cloud Function, registered as `Handle`, translating HTTP requests to and from the handler
+- `cmd/local/` — serves the function locally with the Functions Framework
+{{- end}}
+
+{{- if eq .Vars.provider "aws"}}
+
+### Building
+
+`goforge build` cross-compiles `./cmd/lambda` for `linux/arm64` into the `bootstrap` binary the `provided.al2023` runtime runs, and packages it as `dist/{{.ProjectName}}.zip`. The settings are under `build` in `goforge.yml`; keep `GOARCH` in line with `Architectures` in `template.yaml`.
+
+```bash
+goforge build
+```
+
+### Running Locally
+
+```bash
+goforge run invoke    # one invocation with events/event.json
+goforge run dev       # a local API on http://localhost:3000
+curl -X POST http://localhost:3000/hello -d '{"name": "Ada"}'
+```
+
+### Deploying
+
+```bash
+goforge run deploy    # sam deploy --guided; later deploys reuse samconfig.toml
+```
+{{- else}}
+
+### Running Locally
+
+```bash
+goforge run dev       # http://localhost:8080
+goforge run invoke    # in another terminal
+```
+
+### Deploying
+
+Cloud Functions builds the module itself, so there is nothing to package:
+
+```bash
+goforge run deploy
+```
+
+Adjust `--runtime` and `--region` in the `deploy` script of `goforge.yml` as needed.
+{{- end}}
+
+### Available Scripts
+
+{{if eq .Vars.provider "aws" -}}
+-   `goforge run build`: Builds `dist/{{.ProjectName}}.zip`.
+-   `goforge run invoke`: Builds and invokes the function once with `events/event.json`.
+-   `goforge run dev`: Builds and serves the function behind a local API.
+-   `goforge run deploy`: Builds and deploys the function with SAM.
+{{else -}}
+-   `goforge run dev`: Serves the function locally.
+-   `goforge run invoke`: Calls the local function.
+-   `goforge run deploy`: Deploys the function with gcloud.
+{{end -}}
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
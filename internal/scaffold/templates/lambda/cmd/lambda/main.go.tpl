@@ -0,0 +1,66 @@
+{{- if eq .Vars.provider "aws" -}}
+// Command lambda runs the {{.ProjectName}} function on AWS Lambda behind an
+// API Gateway HTTP API. 'goforge build' compiles it into the bootstrap
+// binary of the provided.al2023 runtime.
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+
+	"{{.ModuleName}}/internal/handler"
+)
+
+func main() {
+	lambda.Start(handle)
+}
+
+// handle decodes the request body, calls the handler and encodes its
+// result. Unexpected errors are returned to Lambda, which answers 500.
+func handle(ctx context.Context, event events.APIGatewayV2HTTPRequest) (events.APIGatewayV2HTTPResponse, error) {
+	body := []byte(event.Body)
+	if event.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(event.Body)
+		if err != nil {
+			return respond(http.StatusBadRequest, errorBody("the body is not valid base64"))
+		}
+		body = decoded
+	}
+
+	var req handler.Request
+	if err := json.Unmarshal(body, &req); err != nil {
+		return respond(http.StatusBadRequest, errorBody("the body is not valid JSON"))
+	}
+	resp, err := handler.Handle(ctx, req)
+	if errors.Is(err, handler.ErrInvalid) {
+		return respond(http.StatusBadRequest, errorBody(err.Error()))
+	}
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+	return respond(http.StatusOK, resp)
+}
+
+// respond encodes body as the JSON response.
+func respond(status int, body any) (events.APIGatewayV2HTTPResponse, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return events.APIGatewayV2HTTPResponse{}, err
+	}
+	return events.APIGatewayV2HTTPResponse{
+		StatusCode: status,
+		Headers:    map[string]string{"Content-Type": "application/json"},
+		Body:       string(data),
+	}, nil
+}
+
+func errorBody(message string) map[string]string {
+	return map[string]string{"error": message}
+}
+{{- end}}
@@ -0,0 +1,31 @@
+{{- if eq .Vars.provider "gcp" -}}
+// Command local serves the {{.ProjectName}} function on localhost the way
+// Cloud Functions does, for development.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/GoogleCloudPlatform/functions-framework-go/funcframework"
+
+	// Registers the function
+	_ "{{.ModuleName}}"
+)
+
+func main() {
+	port := "8080"
+	if p := os.Getenv("PORT"); p != "" {
+		port = p
+	}
+	// Serve the function at the root, as Cloud Functions does
+	if os.Getenv("FUNCTION_TARGET") == "" {
+		os.Setenv("FUNCTION_TARGET", "Handle")
+	}
+
+	log.Printf("Serving the function on http://localhost:%s", port)
+	if err := funcframework.Start(port); err != nil {
+		log.Fatalf("funcframework.Start: %v", err)
+	}
+}
+{{- end}}
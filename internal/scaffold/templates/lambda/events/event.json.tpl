@@ -0,0 +1,24 @@
+{{- if eq .Vars.provider "aws" -}}
+{
+  "version": "2.0",
+  "routeKey": "POST /hello",
+  "rawPath": "/hello",
+  "rawQueryString": "",
+  "headers": {
+    "content-type": "application/json"
+  },
+  "requestContext": {
+    "http": {
+      "method": "POST",
+      "path": "/hello",
+      "protocol": "HTTP/1.1",
+      "sourceIp": "127.0.0.1",
+      "userAgent": "sam-local"
+    },
+    "routeKey": "POST /hello",
+    "stage": "$default"
+  },
+  "body": "{\"name\": \"Ada\"}",
+  "isBase64Encoded": false
+}
+{{- end}}
@@ -0,0 +1,59 @@
+{{- if eq .Vars.provider "gcp" -}}
+// Package function is the {{.ProjectName}} Cloud Function. Cloud Functions
+// builds the package at the module root and serves the function registered
+// in init under the entry point Handle.
+package function
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/GoogleCloudPlatform/functions-framework-go/functions"
+
+	"{{.ModuleName}}/internal/handler"
+)
+
+func init() {
+	functions.HTTP("Handle", Handle)
+}
+
+// Handle decodes the request body, calls the handler and encodes its
+// result.
+func Handle(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, errorBody("use POST"))
+		return
+	}
+
+	var req handler.Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody("the body is not valid JSON"))
+		return
+	}
+	resp, err := handler.Handle(r.Context(), req)
+	if errors.Is(err, handler.ErrInvalid) {
+		writeJSON(w, http.StatusBadRequest, errorBody(err.Error()))
+		return
+	}
+	if err != nil {
+		log.Printf("handle: %v", err)
+		writeJSON(w, http.StatusInternalServerError, errorBody("internal error"))
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("write response: %v", err)
+	}
+}
+
+func errorBody(message string) map[string]string {
+	return map[string]string{"error": message}
+}
+{{- end}}
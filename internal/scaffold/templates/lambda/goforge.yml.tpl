@@ -0,0 +1,122 @@
+# GoForge project configuration
+project_name: "{{.ProjectName}}"
+module_path: "{{.ModuleName}}"
+go_version: "{{.GoVersion}}"
+
+# Project metadata
+description: {{quote .Description}}
+author: "{{.Author}}"
+license: "{{.License}}"
+{{- if .Repository}}
+repository: {{quote .Repository}}
+{{- end}}
+
+# Dependencies with version constraints
+dependencies:
+{{- if eq .Vars.provider "aws"}}
+  github.com/aws/aws-lambda-go: "^1.47.0"
+{{- else}}
+  github.com/GoogleCloudPlatform/functions-framework-go: "^1.8.0"
+{{- end}}
+
+# Development dependencies
+dev_dependencies:
+  github.com/stretchr/testify: "^1.10.0"
+
+# Custom scripts for project automation
+scripts:
+{{- if eq .Vars.provider "aws"}}
+  # Development: invoke the function once with events/event.json, or serve
+  # it behind a local API Gateway on port 3000 (both need the SAM CLI and
+  # Docker)
+  invoke: "goforge build && sam local invoke Function -e events/event.json"
+  dev: "goforge build && sam local start-api"
+
+  # Building: dist/{{.ProjectName}}.zip, holding the bootstrap binary
+  build: "goforge build"
+
+  # Deployment
+  deploy: "goforge build && sam deploy --guided"
+{{- else}}
+  # Development: serve the function on port 8080 and call it
+  dev: "go run ./cmd/local"
+  invoke: "curl -s -X POST http://localhost:8080 -H 'Content-Type: application/json' -d '{\"name\": \"Ada\"}'"
+
+  # Building
+  build: "go build ./..."
+
+  # Deployment: Cloud Functions builds the source itself; match --runtime
+  # to go_version
+  deploy: "gcloud functions deploy {{.ProjectName}} --gen2 --runtime=go123 --region=us-central1 --source=. --entry-point=Handle --trigger-http"
+{{- end}}
+
+  # Testing
+  test: "goforge test"
+  test:race: "goforge test --race"
+
+  # Code quality
+  lint: "goforge lint"
+  fmt: "goforge fmt"
+  vet: "go vet ./..."
+{{- if eq .Vars.provider "aws"}}
+
+# Build configuration: 'goforge build' cross-compiles the bootstrap binary
+# of the provided.al2023 runtime and zips it for SAM
+build:
+  # Output directory for build artifacts
+  output_dir: "dist"
+
+  # The provided runtimes run the binary named bootstrap
+  binary_name: "bootstrap"
+
+  # Package compiled by 'goforge build'
+  main: "./cmd/lambda"
+
+  # Environment of the go build; GOARCH must match Architectures in
+  # template.yaml
+  env:
+    GOOS: "linux"
+    GOARCH: "arm64"
+    CGO_ENABLED: "0"
+
+  # Build tags; lambda.norpc drops the RPC mode the provided runtimes don't use
+  tags:
+    - "lambda.norpc"
+
+  # Linker flags
+  ldflags: "-s -w"
+
+  # Package the binary as dist/{{.ProjectName}}.zip, the CodeUri in template.yaml
+  archive: "zip"
+{{- end}}
+
+# Development configuration
+dev:
+  # Files/directories to watch for changes
+  watch:
+    - "**/*.go"
+
+  # Files/directories to ignore
+  ignore:
+    - "dist/**"
+    - ".aws-sam/**"
+    - "**/*_test.go"
+    - ".git/**"
+
+  # Commands to run on file changes
+  on_change:
+    - "go fmt ./..."
+    - "go vet ./..."
+
+# Testing configuration
+test:
+  # Test timeout
+  timeout: "10m"
+
+  # Coverage threshold (percentage)
+  coverage_threshold: 80
+
+# Code generation settings
+generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
@@ -0,0 +1,33 @@
+// Package handler holds the function's logic, independent of the cloud it
+// runs on; the entry points decode requests into it and encode its results.
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalid marks requests the function rejects; the entry points answer
+// them with 400 Bad Request.
+var ErrInvalid = errors.New("invalid request")
+
+// Request is the JSON body the function accepts.
+type Request struct {
+	Name string `json:"name"`
+}
+
+// Response is the JSON body the function returns.
+type Response struct {
+	Message string `json:"message"`
+}
+
+// Handle greets the caller.
+func Handle(ctx context.Context, req Request) (Response, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return Response{}, fmt.Errorf("%w: name is required", ErrInvalid)
+	}
+	return Response{Message: fmt.Sprintf("Hello, %s!", name)}, nil
+}
@@ -0,0 +1,24 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestHandle(t *testing.T) {
+	resp, err := Handle(context.Background(), Request{Name: "Ada"})
+	if err != nil {
+		t.Fatalf("Handle() error = %v", err)
+	}
+	if want := "Hello, Ada!"; resp.Message != want {
+		t.Errorf("Message = %q, want %q", resp.Message, want)
+	}
+}
+
+func TestHandleRejectsEmptyName(t *testing.T) {
+	_, err := Handle(context.Background(), Request{Name: "  "})
+	if !errors.Is(err, ErrInvalid) {
+		t.Errorf("Handle() error = %v, want ErrInvalid", err)
+	}
+}
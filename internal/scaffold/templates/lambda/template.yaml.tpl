@@ -0,0 +1,34 @@
+{{- if eq .Vars.provider "aws" -}}
+# AWS SAM template (https://docs.aws.amazon.com/serverless-application-model/).
+# Deploys the zip 'goforge build' writes to dist/, so build before running
+# sam: goforge run invoke, goforge run api, or goforge run deploy.
+AWSTemplateFormatVersion: "2010-09-09"
+Transform: AWS::Serverless-2016-10-31
+Description: {{.ProjectName}} function
+
+Globals:
+  Function:
+    Timeout: 10
+    MemorySize: 128
+
+Resources:
+  Function:
+    Type: AWS::Serverless::Function
+    Properties:
+      CodeUri: dist/{{.ProjectName}}.zip
+      Handler: bootstrap
+      Runtime: provided.al2023
+      Architectures:
+        - arm64
+      Events:
+        Hello:
+          Type: HttpApi
+          Properties:
+            Path: /hello
+            Method: post
+
+Outputs:
+  HelloUrl:
+    Description: URL of the function
+    Value: !Sub "https://${ServerlessHttpApi}.execute-api.${AWS::Region}.amazonaws.com/hello"
+{{- end}}
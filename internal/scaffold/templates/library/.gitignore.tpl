@@ -0,0 +1,28 @@
+# Go build artifacts
+/vendor/
+go.work
+go.work.sum
+
+# Test binaries and coverage reports
+*.test
+*.out
+cover.html
+
+# IDE settings
+.idea/
+.vscode/
+
+# OS-specific files
+.DS_Store
+Thumbs.db
+
+# Environment variables
+.env
+.env.*
+!.env.example
+
+# goforge command history
+.goforge/history
+
+# goforge profile captures
+.goforge/profiles/
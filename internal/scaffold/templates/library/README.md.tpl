@@ -0,0 +1,65 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This Go library was generated by [GoForge](https://github.com/night-slayer18/goforge).
+
+## Installation
+
+```bash
+go get {{.ModuleName}}
+```
+
+## Usage
+
+```go
+import "{{.ModuleName}}/pkg/{{.ProjectName | toPackage}}"
+
+msg := {{.ProjectName | toPackage}}.Greet("Ada") // "Hello, Ada!"
+```
+
+## 🚀 Development
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+
+### Project Layout
+
+- `pkg/{{.ProjectName | toPackage}}/` — the public package: `doc.go` holds the package documentation, `example_test.go` runnable examples shown in it
+- `internal/` — add packages here for code the library uses but doesn't export
+
+### Testing
+
+```bash
+goforge test          # unit tests and examples
+goforge run bench     # benchmarks
+goforge run doc       # the documentation as users will see it
+```
+
+### Releasing
+
+Record the changes in `CHANGELOG.md`, tag the version and push it; once the tag is public, `go get {{.ModuleName}}@v0.1.0` finds it through the Go module proxy.
+
+```bash
+git tag -a v0.1.0 -m "v0.1.0"
+goforge run release   # checks go.mod is tidy, vets, tests with -race, pushes the tag
+```
+
+### Available Scripts
+
+-   `goforge run test`: Runs all tests in the project.
+-   `goforge run lint`: Runs golangci-lint.
+-   `goforge run release:check`: Runs the release checks without pushing.
+-   `goforge run release`: Runs the release checks and pushes tags.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -0,0 +1,73 @@
+# GoForge project configuration
+project_name: "{{.ProjectName}}"
+module_path: "{{.ModuleName}}"
+go_version: "{{.GoVersion}}"
+
+# Project metadata
+description: {{quote .Description}}
+author: "{{.Author}}"
+license: "{{.License}}"
+{{- if .Repository}}
+repository: {{quote .Repository}}
+{{- end}}
+
+# Dependencies with version constraints
+dependencies: {}
+
+# Development dependencies
+dev_dependencies: {}
+
+# Custom scripts for project automation
+scripts:
+  # Building: a library has no binary, so just check that it compiles
+  build: "go build ./..."
+  doc: "go doc -all ./pkg/{{.ProjectName | toPackage}}"
+
+  # Testing
+  test: "goforge test"
+  test:race: "goforge test --race"
+  test:all: "goforge test --coverage --race"
+  bench: "goforge bench"
+
+  # Code quality
+  lint: "goforge lint"
+  fmt: "goforge fmt"
+  vet: "go vet ./..."
+
+  # Releasing: tag the version (git tag -a v0.1.0 -m "v0.1.0"), then check
+  # the module and push the tag; the Go module proxy serves tagged versions
+  release:check: "go mod tidy -diff && go vet ./... && go test -race ./..."
+  release: "go mod tidy -diff && go vet ./... && go test -race ./... && git push --follow-tags"
+
+# Development configuration
+dev:
+  # Files/directories to watch for changes
+  watch:
+    - "**/*.go"
+
+  # Files/directories to ignore
+  ignore:
+    - ".git/**"
+
+  # Commands to run on file changes
+  on_change:
+    - "go vet ./..."
+    - "go test ./..."
+
+# Testing configuration
+test:
+  # Test timeout
+  timeout: "10m"
+
+  # Coverage threshold (percentage)
+  coverage_threshold: 80
+
+# Code generation settings
+generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
+
+# Output directories for generated components (relative to the project root)
+layout:
+  mock: "internal/mocks"
+  fixture: "internal/testutil"
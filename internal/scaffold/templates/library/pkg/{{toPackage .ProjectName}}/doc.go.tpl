@@ -0,0 +1,10 @@
+// Package {{.ProjectName | toPackage}} is a Go library generated by GoForge.
+//
+// Describe here what the package is for and how to start using it; this
+// comment is the package's page on pkg.go.dev and in 'go doc'. A short
+// usage example:
+//
+//	msg := {{.ProjectName | toPackage}}.Greet("Ada")
+//
+// Runnable examples live in example_test.go.
+package {{.ProjectName | toPackage}}
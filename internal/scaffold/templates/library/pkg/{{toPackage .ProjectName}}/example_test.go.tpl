@@ -0,0 +1,17 @@
+package {{.ProjectName | toPackage}}_test
+
+import (
+	"fmt"
+
+	"{{.ModuleName}}/pkg/{{.ProjectName | toPackage}}"
+)
+
+// Examples are compiled and run by go test, which compares their output
+// with the Output comment, and shown in the package documentation.
+func ExampleGreet() {
+	fmt.Println({{.ProjectName | toPackage}}.Greet("Ada"))
+	fmt.Println({{.ProjectName | toPackage}}.Greet(""))
+	// Output:
+	// Hello, Ada!
+	// Hello, world!
+}
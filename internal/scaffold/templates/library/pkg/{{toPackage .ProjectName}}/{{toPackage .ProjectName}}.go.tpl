@@ -0,0 +1,12 @@
+package {{.ProjectName | toPackage}}
+
+import "strings"
+
+// Greet returns a greeting for name, or for the world when name is blank.
+func Greet(name string) string {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "world"
+	}
+	return "Hello, " + name + "!"
+}
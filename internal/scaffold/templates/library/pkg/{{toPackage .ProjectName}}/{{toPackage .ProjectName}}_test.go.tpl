@@ -0,0 +1,22 @@
+package {{.ProjectName | toPackage}}
+
+import "testing"
+
+func TestGreet(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "name", in: "Ada", want: "Hello, Ada!"},
+		{name: "trims spaces", in: "  Ada ", want: "Hello, Ada!"},
+		{name: "blank", in: " ", want: "Hello, world!"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Greet(tt.in); got != tt.want {
+				t.Errorf("Greet(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This web app was generated by [GoForge](https://github.com/night-slayer18/goforge). Its pages are rendered on the server from [templ](https://templ.guide) components, and [htmx](https://htmx.org) updates them in place by swapping in the HTML fragments the server returns, with no JavaScript to write.
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+
+### How a Page Updates
+
+1. The form on `/` posts to `POST /todos` with `hx-post`.
+2. The handler adds the item and renders the `TodoItem` component, which htmx appends to the list (`hx-swap="beforeend"`).
+3. The same response carries the emptied form and the item count, marked `hx-swap-oob`, so htmx replaces them wherever they are on the page.
+
+Every endpoint answers with HTML, so the handlers are tested like any other: see `internal/web/handler/handler_test.go`.
+
+### Project Layout
+
+- `internal/web/views/` — the templ components (`*.templ`) and the Go code generated from them (`*_templ.go`)
+- `internal/web/handler/` — the routes, rendering pages and fragments
+- `internal/web/assets/` — the static files under `static/`, embedded in the binary
+- `internal/todo/` — the example to-do list
+
+### Running the Application
+
+1.  **Start the server, restarting on changes:**
+    ```bash
+    goforge run dev:watch
+    ```
+
+2.  **Open** http://localhost:8080.
+
+In watch mode every change regenerates the views (`dev.on_change` in `goforge.yml`), restarts the server, and reloads the open pages (`dev.live_reload`). The live reload script is added to the layout only when the app runs under `goforge watch`.
+
+### Static Assets
+
+Files under `internal/web/assets/static/` are embedded in the binary, so it deploys on its own. Link them with `assets.Path("css/app.css")`, which adds a hash of the file's content to the URL (`/static/css/app.3f2a9c1b.css`): browsers cache these URLs for a year and fetch a file again only when it changes.
+
+htmx loads from a CDN. To serve it from the binary instead, run `goforge run assets:vendor`.
+
+### Available Scripts
+
+-   `goforge run dev:watch`: Starts the server and restarts it on changes.
+-   `goforge run templ:generate`: Compiles the `.templ` components into Go.
+-   `goforge run assets:vendor`: Downloads htmx into the static files.
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"{{.ModuleName}}/internal/todo"
+	"{{.ModuleName}}/internal/web/handler"
+)
+
+func main() {
+	// --- Configuration Setup ---
+	viper.SetConfigName("default")
+	viper.SetConfigType("yml")
+	viper.AddConfigPath("./config")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_")) // e.g. DATABASE_HOST overrides database.host
+	viper.AutomaticEnv()
+	if err := viper.ReadInConfig(); err != nil {
+		log.Fatalf("Error reading config file: %s", err)
+	}
+
+	port := viper.GetInt("server.port")
+	if port == 0 {
+		port = 8080 // Default port
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// --- Dependency Injection ---
+	todos := todo.NewStore()
+	pages := handler.New(todos)
+
+	// --- Start Server ---
+	serverAddr := fmt.Sprintf(":%d", port)
+	server := &http.Server{
+		Addr:              serverAddr,
+		Handler:           pages.Routes(),
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		fmt.Printf("🚀 Server starting on http://localhost%s\n", serverAddr)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("❌ Could not start server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Server shutdown: %v", err)
+	}
+}
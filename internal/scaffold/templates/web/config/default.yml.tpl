@@ -0,0 +1,8 @@
+# Default application configuration.
+# These values can be overridden by environment variables.
+server:
+  host: "localhost"
+  port: 8080
+
+logging:
+  level: "debug" # Options: debug, info, warn, error
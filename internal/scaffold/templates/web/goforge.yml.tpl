@@ -0,0 +1,153 @@
+# GoForge project configuration
+project_name: "{{.ProjectName}}"
+module_path: "{{.ModuleName}}"
+go_version: "{{.GoVersion}}"
+
+# Project metadata
+description: {{quote .Description}}
+author: "{{.Author}}"
+license: "{{.License}}"
+{{- if .Repository}}
+repository: {{quote .Repository}}
+{{- end}}
+
+# Dependencies with version constraints
+dependencies:
+  github.com/a-h/templ: "^0.3.0"
+  github.com/spf13/viper: "^1.19.0"
+
+# Development dependencies
+dev_dependencies:
+  github.com/stretchr/testify: "^1.10.0"
+
+# Custom scripts for project automation
+scripts:
+  # Development
+  dev: "go run ./cmd/server"
+  dev:watch: "goforge watch dev"
+  
+  # Building
+  build: "goforge build"
+  build:prod: "go build -ldflags='-w -s' -o dist/{{.ProjectName}} ./cmd/server"
+  
+  # Testing
+  test: "goforge test"
+  test:race: "goforge test --race"
+  test:all: "goforge test --coverage --race"
+  
+  # Code quality
+  lint: "goforge lint"
+  fmt: "goforge fmt && go run github.com/a-h/templ/cmd/templ fmt ."
+  vet: "go vet ./..."
+  
+  # Views: compile the .templ components into Go (*_templ.go)
+  templ:generate: "go run github.com/a-h/templ/cmd/templ generate"
+
+  # Assets: serve htmx from the binary instead of the CDN
+  assets:vendor: "curl -fsSL --create-dirs -o internal/web/assets/static/js/htmx.min.js https://unpkg.com/htmx.org@2.0.4/dist/htmx.min.js"
+  
+  # Deployment
+  docker:build: "docker build -t {{.ProjectName}} ."
+  docker:run: "docker run -p 8080:8080 {{.ProjectName}}"
+
+# Build configuration
+build:
+  # Output directory for build artifacts
+  output_dir: "dist"
+  
+  # Binary name (defaults to project name)
+  binary_name: "{{.ProjectName}}"
+  
+  # Package compiled by 'goforge build' (defaults to ./cmd/server) and linker
+  # flags; {version}, {commit} and {date} are filled in from git at build time
+  # main: "./cmd/server"
+  # ldflags: "-s -w -X main.version={version}"
+
+  # Assets to copy to output directory; the static files are embedded in
+  # the binary
+  assets:
+    - "config/default.yml"
+    
+  # Cross-compilation targets
+  targets:
+    - os: "linux"
+      arch: "amd64"
+    - os: "windows" 
+      arch: "amd64"
+    - os: "darwin"
+      arch: "amd64"
+    - os: "darwin"
+      arch: "arm64"
+
+# Development server configuration
+dev:
+  # Port for development server
+  port: 8080
+  
+  # Files/directories to watch for changes
+  watch:
+    - "**/*.go"
+    - "**/*.templ"
+    - "internal/web/assets/static/**"
+    - "config/**/*.yml"
+  
+  # Files/directories to ignore; the generated views change with their
+  # .templ files
+  ignore:
+    - "dist/**"
+    - "**/*_test.go"
+    - "**/*_templ.go"
+    - ".git/**"
+    - "node_modules/**"
+  
+  # Commands to run on file changes
+  on_change:
+    - "go run github.com/a-h/templ/cmd/templ generate"
+
+  # Reload the open pages once the server is back up
+  live_reload: true
+
+# Testing configuration
+test:
+  # Test timeout
+  timeout: "10m"
+  
+  # Coverage threshold (percentage)
+  coverage_threshold: 80
+
+# Code generation settings
+generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
+
+  # Extra commands run after generating; {files} is replaced by the new files
+  # post_hooks:
+  #   - "gofumpt -w {files}"
+
+# Output directories for generated components (relative to the project root).
+# Override any entry to match your project structure, or use
+# 'goforge generate <component> <name> --path <dir>' for a one-off location.
+layout:
+  handler: "internal/web/handler"
+  service: "internal/app/service"
+  repository: "internal/adapters/postgres"
+  model: "internal/domain"
+  middleware: "internal/web/middleware"
+  port: "internal/ports"
+  mock: "internal/mocks"
+  health: "internal/platform/health"
+  config: "internal/config"
+  fixture: "internal/testutil"
+
+# Docker configuration
+docker:
+  # Base image for multi-stage build
+  base_image: "golang:{{.GoVersion}}-alpine"
+  runtime_image: "alpine:latest"
+  
+  # Exposed port
+  port: 8080
+  
+  # Environment variables
+  env:
+    LOGGING_LEVEL: "info"
@@ -0,0 +1,97 @@
+// Package todo holds the to-do list behind the example pages.
+package todo
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrNotFound is returned for an ID that is not in the list.
+	ErrNotFound = errors.New("todo not found")
+
+	// ErrEmptyTitle is returned when adding a to-do without a title.
+	ErrEmptyTitle = errors.New("a todo needs a title")
+)
+
+// Todo is one item of the list.
+type Todo struct {
+	ID    int
+	Title string
+	Done  bool
+}
+
+// Store keeps the list in memory; replace it with a database-backed store
+// with the same methods to keep the items across restarts.
+type Store struct {
+	mu     sync.Mutex
+	nextID int
+	todos  []Todo
+}
+
+// NewStore returns an empty list.
+func NewStore() *Store {
+	return &Store{nextID: 1}
+}
+
+// List returns the items in the order they were added.
+func (s *Store) List() []Todo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Todo(nil), s.todos...)
+}
+
+// Add appends an item with the given title.
+func (s *Store) Add(title string) (Todo, error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return Todo{}, ErrEmptyTitle
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	todo := Todo{ID: s.nextID, Title: title}
+	s.nextID++
+	s.todos = append(s.todos, todo)
+	return todo, nil
+}
+
+// Toggle marks an item done, or not done again.
+func (s *Store) Toggle(id int) (Todo, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.todos {
+		if s.todos[i].ID == id {
+			s.todos[i].Done = !s.todos[i].Done
+			return s.todos[i], nil
+		}
+	}
+	return Todo{}, ErrNotFound
+}
+
+// Delete removes an item.
+func (s *Store) Delete(id int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.todos {
+		if s.todos[i].ID == id {
+			s.todos = append(s.todos[:i], s.todos[i+1:]...)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// Remaining counts the items not done yet.
+func (s *Store) Remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	remaining := 0
+	for _, todo := range s.todos {
+		if !todo.Done {
+			remaining++
+		}
+	}
+	return remaining
+}
@@ -0,0 +1,40 @@
+package todo
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestStore(t *testing.T) {
+	store := NewStore()
+
+	if _, err := store.Add("  "); !errors.Is(err, ErrEmptyTitle) {
+		t.Fatalf("Add(blank) error = %v, want ErrEmptyTitle", err)
+	}
+
+	milk, err := store.Add("Buy milk")
+	if err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if _, err := store.Add("Write tests"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	toggled, err := store.Toggle(milk.ID)
+	if err != nil || !toggled.Done {
+		t.Fatalf("Toggle() = %+v, %v; want a done todo", toggled, err)
+	}
+	if got := store.Remaining(); got != 1 {
+		t.Errorf("Remaining() = %d, want 1", got)
+	}
+
+	if err := store.Delete(milk.ID); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if err := store.Delete(milk.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Delete(deleted) error = %v, want ErrNotFound", err)
+	}
+	if got := len(store.List()); got != 1 {
+		t.Errorf("len(List()) = %d, want 1", got)
+	}
+}
@@ -0,0 +1,92 @@
+// Package assets serves the files under static/, embedded in the binary.
+// Each file is also served under a name holding a hash of its content, so
+// pages can link to it with Path and browsers cache it until it changes.
+package assets
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+)
+
+// Prefix is the URL path the static files are served under.
+const Prefix = "/static/"
+
+//go:embed static
+var embedded embed.FS
+
+var (
+	files, _ = fs.Sub(embedded, "static")
+
+	fingerprinted = map[string]string{} // css/app.css -> css/app.3f2a9c1b.css
+	originals     = map[string]string{} // css/app.3f2a9c1b.css -> css/app.css
+)
+
+func init() {
+	err := fs.WalkDir(files, ".", func(name string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		content, err := fs.ReadFile(files, name)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(content)
+		ext := path.Ext(name)
+		hashed := strings.TrimSuffix(name, ext) + "." + hex.EncodeToString(sum[:4]) + ext
+		fingerprinted[name] = hashed
+		originals[hashed] = name
+		return nil
+	})
+	if err != nil {
+		panic("assets: " + err.Error())
+	}
+}
+
+// Path returns the URL of a static file, e.g. "css/app.css", including the
+// hash of its content.
+func Path(name string) string {
+	if hashed, ok := fingerprinted[name]; ok {
+		return Prefix + hashed
+	}
+	return Prefix + name
+}
+
+// PathOr returns the URL of a static file, or fallback when there is no
+// such file, e.g. a CDN URL for a library that has not been vendored.
+func PathOr(name, fallback string) string {
+	if _, ok := fingerprinted[name]; ok {
+		return Path(name)
+	}
+	return fallback
+}
+
+// Handler serves the static files under Prefix. Fingerprinted URLs are
+// cached for a year; the plain names are revalidated on every request.
+func Handler() http.Handler {
+	fileServer := http.FileServerFS(files)
+	return http.StripPrefix(Prefix, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, ok := originals[r.URL.Path]
+		if !ok {
+			w.Header().Set("Cache-Control", "no-cache")
+			fileServer.ServeHTTP(w, r)
+			return
+		}
+
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		r = r.Clone(r.Context())
+		r.URL.Path = name
+		fileServer.ServeHTTP(w, r)
+	}))
+}
+
+// LiveReloadURL is the address of the live reload server of 'goforge watch'
+// when the app runs under it with dev.live_reload, and "" otherwise.
+func LiveReloadURL() string {
+	return os.Getenv("GOFORGE_LIVE_RELOAD")
+}
@@ -0,0 +1,110 @@
+// Package handler serves the pages and the htmx fragments that update them.
+package handler
+
+import (
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/a-h/templ"
+
+	"{{.ModuleName}}/internal/todo"
+	"{{.ModuleName}}/internal/web/assets"
+	"{{.ModuleName}}/internal/web/views"
+)
+
+// Handler serves the to-do list pages.
+type Handler struct {
+	todos *todo.Store
+}
+
+// New returns a Handler for the given store.
+func New(todos *todo.Store) *Handler {
+	return &Handler{todos: todos}
+}
+
+// Routes registers the pages, the fragments, and the static files.
+func (h *Handler) Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+assets.Prefix, assets.Handler())
+	mux.HandleFunc("GET /{$}", h.home)
+	mux.HandleFunc("POST /todos", h.addTodo)
+	mux.HandleFunc("PATCH /todos/{id}", h.toggleTodo)
+	mux.HandleFunc("DELETE /todos/{id}", h.deleteTodo)
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status":"UP"}`))
+	})
+	return mux
+}
+
+func (h *Handler) home(w http.ResponseWriter, r *http.Request) {
+	render(w, r, views.Home(h.todos.List(), h.todos.Remaining()))
+}
+
+// addTodo appends the new item to the list and empties the form. An
+// invalid title leaves the list alone and shows the problem in the form.
+func (h *Handler) addTodo(w http.ResponseWriter, r *http.Request) {
+	title := r.FormValue("title")
+	item, err := h.todos.Add(title)
+	if errors.Is(err, todo.ErrEmptyTitle) {
+		w.Header().Set("HX-Reswap", "none")
+		render(w, r, views.TodoForm(title, "Please enter a title."))
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	render(w, r, views.TodoItem(item), views.TodoForm("", ""), views.Remaining(h.todos.Remaining()))
+}
+
+// toggleTodo replaces the item with its new state.
+func (h *Handler) toggleTodo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid todo id", http.StatusBadRequest)
+		return
+	}
+	item, err := h.todos.Toggle(id)
+	if err != nil {
+		respondError(w, err)
+		return
+	}
+	render(w, r, views.TodoItem(item), views.Remaining(h.todos.Remaining()))
+}
+
+// deleteTodo answers with no item, which removes it from the list.
+func (h *Handler) deleteTodo(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "invalid todo id", http.StatusBadRequest)
+		return
+	}
+	if err := h.todos.Delete(id); err != nil {
+		respondError(w, err)
+		return
+	}
+	render(w, r, views.Remaining(h.todos.Remaining()))
+}
+
+// render writes the components one after the other: the first is swapped
+// into the request's target, the others are out-of-band fragments.
+func render(w http.ResponseWriter, r *http.Request, components ...templ.Component) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	for _, component := range components {
+		if err := component.Render(r.Context(), w); err != nil {
+			log.Printf("render %s: %v", r.URL.Path, err)
+			return
+		}
+	}
+}
+
+func respondError(w http.ResponseWriter, err error) {
+	if errors.Is(err, todo.ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
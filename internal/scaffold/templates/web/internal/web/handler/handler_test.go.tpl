@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"{{.ModuleName}}/internal/todo"
+)
+
+func TestAddTodo(t *testing.T) {
+	routes := New(todo.NewStore()).Routes()
+
+	form := url.Values{"title": {"Buy milk"}}
+	req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("POST /todos status = %d, want 200", rec.Code)
+	}
+	body := rec.Body.String()
+	for _, want := range []string{`id="todo-1"`, "Buy milk", "1 item left."} {
+		if !strings.Contains(body, want) {
+			t.Errorf("POST /todos body is missing %q:\n%s", want, body)
+		}
+	}
+}
+
+func TestAddTodoWithoutTitle(t *testing.T) {
+	routes := New(todo.NewStore()).Routes()
+
+	req := httptest.NewRequest(http.MethodPost, "/todos", strings.NewReader("title=+"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("HX-Reswap"); got != "none" {
+		t.Errorf("HX-Reswap = %q, want none", got)
+	}
+	if !strings.Contains(rec.Body.String(), "Please enter a title.") {
+		t.Errorf("body does not show the problem:\n%s", rec.Body.String())
+	}
+}
+
+func TestStaticAssetsAreFingerprinted(t *testing.T) {
+	routes := New(todo.NewStore()).Routes()
+
+	rec := httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	body := rec.Body.String()
+
+	start := strings.Index(body, "/static/css/app.")
+	if start < 0 {
+		t.Fatalf("page does not link the fingerprinted stylesheet:\n%s", body)
+	}
+	href := body[start : start+strings.Index(body[start:], `"`)]
+
+	rec = httptest.NewRecorder()
+	routes.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, href, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET %s status = %d, want 200", href, rec.Code)
+	}
+	if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, "immutable") {
+		t.Errorf("GET %s Cache-Control = %q, want immutable", href, got)
+	}
+}
@@ -0,0 +1,22 @@
+package views
+
+import "{{.ModuleName}}/internal/todo"
+
+// Home is the page with the to-do list.
+templ Home(todos []todo.Todo, remaining int) {
+	@Layout("Home") {
+		<header>
+			<h1>{{.ProjectName}}</h1>
+			<p>A to-do list rendered on the server with templ and updated in place with htmx.</p>
+		</header>
+		<main>
+			@TodoForm("", "")
+			<ul id="todo-list">
+				for _, item := range todos {
+					@TodoItem(item)
+				}
+			</ul>
+			@Remaining(remaining)
+		</main>
+	}
+}
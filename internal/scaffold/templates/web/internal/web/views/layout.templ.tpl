@@ -0,0 +1,27 @@
+package views
+
+import "{{.ModuleName}}/internal/web/assets"
+
+// htmxCDN loads htmx until it is vendored into static/js with
+// 'goforge run assets:vendor'.
+const htmxCDN = "https://unpkg.com/htmx.org@2.0.4/dist/htmx.min.js"
+
+// Layout is the HTML document around every page.
+templ Layout(title string) {
+	<!DOCTYPE html>
+	<html lang="en">
+		<head>
+			<meta charset="utf-8"/>
+			<meta name="viewport" content="width=device-width, initial-scale=1"/>
+			<title>{ title } · {{.ProjectName}}</title>
+			<link rel="stylesheet" href={ assets.Path("css/app.css") }/>
+			<script src={ assets.PathOr("js/htmx.min.js", htmxCDN) } defer></script>
+		</head>
+		<body>
+			{ children... }
+			if assets.LiveReloadURL() != "" {
+				<script src={ assets.LiveReloadURL() + "/livereload.js" }></script>
+			}
+		</body>
+	</html>
+}
@@ -0,0 +1,59 @@
+package views
+
+import (
+	"fmt"
+
+	"{{.ModuleName}}/internal/todo"
+)
+
+// The fragments below carry hx-swap-oob, so a response can update them
+// wherever they are on the page, next to the element it swaps.
+
+// TodoForm adds items to the end of the list. After a request it comes
+// back empty, or with the title and the problem to fix.
+templ TodoForm(title, problem string) {
+	<div id="todo-form" hx-swap-oob="true">
+		<form hx-post="/todos" hx-target="#todo-list" hx-swap="beforeend">
+			<input type="text" name="title" value={ title } placeholder="What needs doing?" aria-label="New todo" autofocus/>
+			<button type="submit">Add <span class="htmx-indicator">…</span></button>
+		</form>
+		<p class="error">{ problem }</p>
+	</div>
+}
+
+// TodoItem is one row of the list; its controls replace it with the
+// response to their request.
+templ TodoItem(item todo.Todo) {
+	<li id={ fmt.Sprintf("todo-%d", item.ID) } class={ templ.KV("done", item.Done) }>
+		<input
+			type="checkbox"
+			checked?={ item.Done }
+			aria-label="Done"
+			hx-patch={ fmt.Sprintf("/todos/%d", item.ID) }
+			hx-target="closest li"
+			hx-swap="outerHTML"
+		/>
+		<span class="title">{ item.Title }</span>
+		<button
+			class="link"
+			aria-label="Delete"
+			hx-delete={ fmt.Sprintf("/todos/%d", item.ID) }
+			hx-target="closest li"
+			hx-swap="outerHTML"
+		>✕</button>
+	</li>
+}
+
+// Remaining counts the items left to do.
+templ Remaining(count int) {
+	<p id="remaining" hx-swap-oob="true">
+		switch count {
+			case 0:
+				Nothing left to do.
+			case 1:
+				1 item left.
+			default:
+				{ fmt.Sprint(count) } items left.
+		}
+	</p>
+}
@@ -0,0 +1,65 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This background worker was generated by [GoForge](https://github.com/night-slayer18/goforge). It consumes messages from {{if eq .Vars.broker "kafka"}}Kafka{{else if eq .Vars.broker "rabbitmq"}}RabbitMQ{{else}}NATS{{end}} and has no HTTP API apart from its metrics.
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+{{- if eq .Vars.broker "kafka"}}
+- A Kafka broker, e.g. `docker run -p 9092:9092 apache/kafka`
+{{- else if eq .Vars.broker "rabbitmq"}}
+- A RabbitMQ server, e.g. `docker run -p 5672:5672 rabbitmq:3`
+{{- else}}
+- A NATS server, e.g. `docker run -p 4222:4222 nats`
+{{- end}}
+
+### Project Layout
+
+- `cmd/worker/` — the entry point: loads the config, connects, and shuts down gracefully on SIGINT/SIGTERM
+- `internal/worker/` — retries, dead-lettering, and the handlers (`handlers.go`)
+- `internal/platform/queue/` — the {{if eq .Vars.broker "kafka"}}Kafka{{else if eq .Vars.broker "rabbitmq"}}RabbitMQ{{else}}NATS{{end}} connection
+- `internal/platform/metrics/` — Prometheus metrics, served on `:9100/metrics` with a `/healthz` probe
+
+### Running the Worker
+
+```bash
+goforge run dev
+```
+
+Settings are in `config/default.yml` and can be overridden with environment variables, e.g. `BROKER_URL` or `WORKER_MAX_ATTEMPTS`.
+
+### Handling Messages
+
+Register a handler per topic in `internal/worker/handlers.go`. A handler that returns an error is retried `worker.max_attempts` times with exponential backoff starting at `worker.backoff`; a message still failing then is published to `<topic>.dlq` with `x-error`, `x-attempts`, and `x-original-topic` headers. Wrap errors that retrying cannot fix, such as undecodable payloads, in `worker.Permanent` to dead-letter them at once. Panics are recovered and count as failures.
+
+On shutdown, messages being handled get `worker.shutdown_timeout` to finish; pending retries are abandoned{{if eq .Vars.broker "nats"}}. Core NATS does not redeliver, so those messages are lost{{else}} and the messages left unacknowledged, so the broker delivers them again{{end}}.
+
+### Metrics
+
+| Metric | Description |
+|--------|-------------|
+| `worker_messages_total{topic,status}` | Messages handled; status is `processed`, `retried`, `dead_lettered`, or `failed` |
+| `worker_handle_duration_seconds{topic}` | Time spent on a message, retries included |
+| `worker_messages_in_flight` | Messages being handled |
+
+### Available Scripts
+
+-   `goforge run dev`: Starts the worker.
+-   `goforge run build`: Builds the worker into `dist/`.
+-   `goforge run metrics`: Prints the metrics of the running worker.
+-   `goforge run test`: Runs all tests in the project.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
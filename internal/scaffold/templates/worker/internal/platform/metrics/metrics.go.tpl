@@ -0,0 +1,67 @@
+// Package metrics exposes the worker's Prometheus metrics.
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Message outcomes, the status label of MessagesTotal.
+const (
+	StatusProcessed    = "processed"
+	StatusRetried      = "retried"
+	StatusDeadLettered = "dead_lettered"
+	StatusFailed       = "failed"
+)
+
+var (
+	// MessagesTotal counts handled messages by topic and outcome.
+	MessagesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "worker",
+		Name:      "messages_total",
+		Help:      "Messages handled, by topic and outcome.",
+	}, []string{"topic", "status"})
+
+	// HandleDuration measures how long handling a message takes,
+	// retries included.
+	HandleDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "worker",
+		Name:      "handle_duration_seconds",
+		Help:      "Time spent handling a message, retries included.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"topic"})
+
+	// InFlight is the number of messages being handled.
+	InFlight = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "worker",
+		Name:      "messages_in_flight",
+		Help:      "Messages being handled.",
+	})
+)
+
+// Serve serves /metrics and /healthz on addr until ctx is done.
+func Serve(ctx context.Context, addr string) error {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	server := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: 5 * time.Second}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		server.Shutdown(shutdownCtx)
+	}()
+	if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+{{- if eq .Vars.broker "kafka" -}}
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// kafkaBroker reads the topics as part of a consumer group.
+type kafkaBroker struct {
+	brokers []string
+	group   string
+	writer  *kafka.Writer
+}
+
+// New connects to the Kafka brokers in cfg.URL, a comma-separated list.
+func New(cfg Config) (Broker, error) {
+	brokers := strings.Split(cfg.URL, ",")
+	return &kafkaBroker{
+		brokers: brokers,
+		group:   cfg.Group,
+		writer: &kafka.Writer{
+			Addr:                   kafka.TCP(brokers...),
+			AllowAutoTopicCreation: true,
+		},
+	}, nil
+}
+
+// Consume reads each topic in its own goroutine. A message's offset is
+// committed once handle succeeds; on an error the reader stops, so the
+// message is read again after a restart. It returns the first error.
+func (b *kafkaBroker) Consume(ctx context.Context, topics []string, handle Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for _, topic := range topics {
+		reader := kafka.NewReader(kafka.ReaderConfig{
+			Brokers: b.brokers,
+			GroupID: b.group,
+			Topic:   topic,
+		})
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer reader.Close()
+			for {
+				msg, err := reader.FetchMessage(ctx)
+				if err != nil {
+					if !errors.Is(err, context.Canceled) {
+						fail(fmt.Errorf("reading %s: %w", topic, err))
+					}
+					return
+				}
+				headers := make(map[string]string, len(msg.Headers))
+				for _, header := range msg.Headers {
+					headers[header.Key] = string(header.Value)
+				}
+				if err := handle(ctx, Message{Topic: msg.Topic, Key: msg.Key, Body: msg.Value, Headers: headers}); err != nil {
+					if !errors.Is(err, context.Canceled) {
+						fail(fmt.Errorf("handling %s offset %d: %w", topic, msg.Offset, err))
+					}
+					return
+				}
+				// The handler finished, so commit even while shutting down
+				if err := reader.CommitMessages(context.WithoutCancel(ctx), msg); err != nil {
+					fail(fmt.Errorf("committing %s: %w", topic, err))
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// Publish writes a message to its topic, creating the topic if needed.
+func (b *kafkaBroker) Publish(ctx context.Context, msg Message) error {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+	}
+	return b.writer.WriteMessages(ctx, kafka.Message{Topic: msg.Topic, Key: msg.Key, Value: msg.Body, Headers: headers})
+}
+
+// Close flushes pending writes.
+func (b *kafkaBroker) Close() error {
+	return b.writer.Close()
+}
+{{- end}}
@@ -0,0 +1,87 @@
+{{- if eq .Vars.broker "nats" -}}
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nats.go"
+)
+
+// natsBroker subscribes to the topics as subjects in a queue group. Core
+// NATS does not redeliver, so a message whose handler fails is lost unless
+// the worker dead-letters it.
+type natsBroker struct {
+	conn   *nats.Conn
+	group  string
+	closed chan struct{}
+}
+
+// New connects to the NATS server at cfg.URL.
+func New(cfg Config) (Broker, error) {
+	closed := make(chan struct{})
+	conn, err := nats.Connect(cfg.URL, nats.Name(cfg.Group), nats.ClosedHandler(func(*nats.Conn) { close(closed) }))
+	if err != nil {
+		return nil, fmt.Errorf("connecting to NATS: %w", err)
+	}
+	return &natsBroker{conn: conn, group: cfg.Group, closed: closed}, nil
+}
+
+// Consume subscribes to every topic until ctx is done, then drains the
+// connection so the messages in progress finish. It returns the first
+// handler error.
+func (b *natsBroker) Consume(ctx context.Context, topics []string, handle Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for _, topic := range topics {
+		if _, err := b.conn.QueueSubscribe(topic, b.group, func(msg *nats.Msg) {
+			headers := make(map[string]string, len(msg.Header))
+			for key := range msg.Header {
+				headers[key] = msg.Header.Get(key)
+			}
+			if err := handle(ctx, Message{Topic: msg.Subject, Body: msg.Data, Headers: headers}); err != nil && !errors.Is(err, context.Canceled) {
+				fail(fmt.Errorf("handling %s: %w", msg.Subject, err))
+			}
+		}); err != nil {
+			return fmt.Errorf("subscribing to %s: %w", topic, err)
+		}
+	}
+
+	<-ctx.Done()
+	if err := b.conn.Drain(); err != nil {
+		return err
+	}
+	<-b.closed
+	return firstErr
+}
+
+// Publish sends a message to its subject.
+func (b *natsBroker) Publish(ctx context.Context, msg Message) error {
+	out := nats.NewMsg(msg.Topic)
+	out.Data = msg.Body
+	for key, value := range msg.Headers {
+		out.Header.Set(key, value)
+	}
+	return b.conn.PublishMsg(out)
+}
+
+// Close closes the connection unless Consume already drained it.
+func (b *natsBroker) Close() error {
+	b.conn.Close()
+	return nil
+}
+{{- end}}
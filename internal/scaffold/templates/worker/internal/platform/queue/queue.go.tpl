@@ -0,0 +1,36 @@
+// Package queue connects the worker to the message broker.
+package queue
+
+import "context"
+
+// Message is a message received from or published to the broker.
+type Message struct {
+	Topic   string
+	Key     []byte
+	Body    []byte
+	Headers map[string]string
+}
+
+// Handler processes a message. Once it returns nil the message is
+// acknowledged; an error leaves it unacknowledged, so the broker delivers it
+// again.
+type Handler func(ctx context.Context, msg Message) error
+
+// Broker consumes and publishes messages.
+type Broker interface {
+	// Consume calls handle for each message on the topics until ctx is
+	// done, then waits for the handlers in progress to return.
+	Consume(ctx context.Context, topics []string, handle Handler) error
+
+	// Publish sends a message to its topic.
+	Publish(ctx context.Context, msg Message) error
+
+	// Close releases the connection.
+	Close() error
+}
+
+// Config locates the broker.
+type Config struct {
+	URL   string // broker address
+	Group string // consumers sharing a group split the messages between them
+}
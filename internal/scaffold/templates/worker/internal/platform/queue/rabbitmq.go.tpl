@@ -0,0 +1,154 @@
+{{- if eq .Vars.broker "rabbitmq" -}}
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// prefetch is how many unacknowledged messages each queue hands out at once.
+const prefetch = 1
+
+// rabbitBroker consumes durable queues named after the topics.
+type rabbitBroker struct {
+	conn  *amqp.Connection
+	group string
+
+	mu      sync.Mutex // guards publish, channels are not safe for concurrent use
+	publish *amqp.Channel
+}
+
+// New connects to the RabbitMQ server at cfg.URL.
+func New(cfg Config) (Broker, error) {
+	conn, err := amqp.Dial(cfg.URL)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to RabbitMQ: %w", err)
+	}
+	publish, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("opening a channel: %w", err)
+	}
+	return &rabbitBroker{conn: conn, group: cfg.Group, publish: publish}, nil
+}
+
+// Consume declares a durable queue per topic and consumes each on its own
+// channel. A message is acknowledged once handle succeeds; on an error it
+// is requeued. It returns when ctx is done or a channel closes.
+func (b *rabbitBroker) Consume(ctx context.Context, topics []string, handle Handler) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	for _, topic := range topics {
+		ch, err := b.conn.Channel()
+		if err != nil {
+			fail(fmt.Errorf("opening a channel for %s: %w", topic, err))
+			break
+		}
+		deliveries, err := b.consume(ch, topic)
+		if err != nil {
+			ch.Close()
+			fail(err)
+			break
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer ch.Close()
+			for {
+				var delivery amqp.Delivery
+				var ok bool
+				select {
+				case <-ctx.Done():
+					return
+				case delivery, ok = <-deliveries:
+					if !ok {
+						fail(fmt.Errorf("the %s channel closed", topic))
+						return
+					}
+				}
+
+				headers := make(map[string]string, len(delivery.Headers))
+				for key, value := range delivery.Headers {
+					headers[key] = fmt.Sprint(value)
+				}
+				msg := Message{Topic: topic, Key: []byte(delivery.MessageId), Body: delivery.Body, Headers: headers}
+				if err := handle(ctx, msg); err != nil {
+					if nackErr := delivery.Nack(false, true); nackErr != nil {
+						fail(fmt.Errorf("requeueing from %s: %w", topic, nackErr))
+						return
+					}
+					if !errors.Is(err, context.Canceled) {
+						fail(fmt.Errorf("handling %s: %w", topic, err))
+					}
+					return
+				}
+				if err := delivery.Ack(false); err != nil {
+					fail(fmt.Errorf("acknowledging %s: %w", topic, err))
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	return firstErr
+}
+
+// consume declares the topic's queue and starts consuming it.
+func (b *rabbitBroker) consume(ch *amqp.Channel, topic string) (<-chan amqp.Delivery, error) {
+	if _, err := ch.QueueDeclare(topic, true, false, false, false, nil); err != nil {
+		return nil, fmt.Errorf("declaring queue %s: %w", topic, err)
+	}
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("setting the prefetch of %s: %w", topic, err)
+	}
+	deliveries, err := ch.Consume(topic, b.group, false, false, false, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consuming %s: %w", topic, err)
+	}
+	return deliveries, nil
+}
+
+// Publish sends a persistent message to the queue named after its topic,
+// declaring the queue if needed.
+func (b *rabbitBroker) Publish(ctx context.Context, msg Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, err := b.publish.QueueDeclare(msg.Topic, true, false, false, false, nil); err != nil {
+		return fmt.Errorf("declaring queue %s: %w", msg.Topic, err)
+	}
+	headers := make(amqp.Table, len(msg.Headers))
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	return b.publish.PublishWithContext(ctx, "", msg.Topic, false, false, amqp.Publishing{
+		DeliveryMode: amqp.Persistent,
+		MessageId:    string(msg.Key),
+		Headers:      headers,
+		Body:         msg.Body,
+	})
+}
+
+// Close closes the connection and its channels.
+func (b *rabbitBroker) Close() error {
+	return b.conn.Close()
+}
+{{- end}}
@@ -0,0 +1,33 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"{{.ModuleName}}/internal/platform/queue"
+)
+
+// Register routes each topic the worker consumes to its handler.
+func Register(w *Worker, logger *slog.Logger) {
+	w.Handle("{{.ProjectName}}.example", handleExample(logger))
+}
+
+// exampleMessage is the payload of the example topic.
+type exampleMessage struct {
+	ID string `json:"id"`
+}
+
+// handleExample processes messages of the example topic; replace it with
+// your own handlers. Return Permanent for errors retrying will not fix.
+func handleExample(logger *slog.Logger) queue.Handler {
+	return func(ctx context.Context, msg queue.Message) error {
+		var payload exampleMessage
+		if err := json.Unmarshal(msg.Body, &payload); err != nil {
+			return Permanent(fmt.Errorf("decoding message: %w", err))
+		}
+		logger.InfoContext(ctx, "processing message", "topic", msg.Topic, "id", payload.ID)
+		return nil
+	}
+}
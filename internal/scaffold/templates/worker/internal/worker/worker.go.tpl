@@ -0,0 +1,154 @@
+// Package worker dispatches the messages the worker consumes to their
+// handlers, retrying failures and dead-lettering what keeps failing.
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"{{.ModuleName}}/internal/platform/metrics"
+	"{{.ModuleName}}/internal/platform/queue"
+)
+
+// Headers added to dead-lettered messages.
+const (
+	HeaderError         = "x-error"
+	HeaderAttempts      = "x-attempts"
+	HeaderOriginalTopic = "x-original-topic"
+)
+
+// Options tune retries and dead-lettering.
+type Options struct {
+	MaxAttempts      int           // tries per message, at least 1
+	Backoff          time.Duration // wait before the first retry, doubled for each further one
+	DeadLetterSuffix string        // failed messages are published to <topic><suffix>
+}
+
+// Worker consumes the topics of its handlers from a broker.
+type Worker struct {
+	broker   queue.Broker
+	handlers map[string]queue.Handler
+	options  Options
+	logger   *slog.Logger
+}
+
+// New creates a worker consuming from broker.
+func New(broker queue.Broker, options Options, logger *slog.Logger) *Worker {
+	if options.MaxAttempts < 1 {
+		options.MaxAttempts = 1
+	}
+	return &Worker{broker: broker, handlers: make(map[string]queue.Handler), options: options, logger: logger}
+}
+
+// Handle routes the messages of topic to handler.
+func (w *Worker) Handle(topic string, handler queue.Handler) {
+	w.handlers[topic] = handler
+}
+
+// Run consumes the handlers' topics until ctx is done. Messages being
+// handled then are finished, but not retried.
+func (w *Worker) Run(ctx context.Context) error {
+	if len(w.handlers) == 0 {
+		return errors.New("no handlers registered")
+	}
+	topics := make([]string, 0, len(w.handlers))
+	for topic := range w.handlers {
+		topics = append(topics, topic)
+	}
+	w.logger.Info("consuming", "topics", topics)
+	return w.broker.Consume(ctx, topics, w.process)
+}
+
+// process handles a message, retrying with exponential backoff. A message
+// that fails every attempt, or with a Permanent error, is published to the
+// dead-letter topic and acknowledged.
+func (w *Worker) process(ctx context.Context, msg queue.Message) error {
+	handler, ok := w.handlers[msg.Topic]
+	if !ok {
+		return w.deadLetter(ctx, msg, fmt.Errorf("no handler for topic %s", msg.Topic), 0)
+	}
+
+	metrics.InFlight.Inc()
+	defer metrics.InFlight.Dec()
+	start := time.Now()
+	defer func() {
+		metrics.HandleDuration.WithLabelValues(msg.Topic).Observe(time.Since(start).Seconds())
+	}()
+
+	backoff := w.options.Backoff
+	var err error
+	for attempt := 1; attempt <= w.options.MaxAttempts; attempt++ {
+		// A handler that has started is allowed to finish during shutdown
+		err = safeHandle(context.WithoutCancel(ctx), handler, msg)
+		if err == nil {
+			metrics.MessagesTotal.WithLabelValues(msg.Topic, metrics.StatusProcessed).Inc()
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt == w.options.MaxAttempts {
+			return w.deadLetter(ctx, msg, err, attempt)
+		}
+
+		metrics.MessagesTotal.WithLabelValues(msg.Topic, metrics.StatusRetried).Inc()
+		w.logger.Warn("handler failed, retrying", "topic", msg.Topic, "attempt", attempt, "backoff", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+			// Left unacknowledged, the broker delivers it again later
+			metrics.MessagesTotal.WithLabelValues(msg.Topic, metrics.StatusFailed).Inc()
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return err
+}
+
+// deadLetter publishes a message that could not be handled to the
+// dead-letter topic, recording why in its headers.
+func (w *Worker) deadLetter(ctx context.Context, msg queue.Message, cause error, attempts int) error {
+	headers := make(map[string]string, len(msg.Headers)+3)
+	for key, value := range msg.Headers {
+		headers[key] = value
+	}
+	headers[HeaderError] = cause.Error()
+	headers[HeaderAttempts] = strconv.Itoa(attempts)
+	headers[HeaderOriginalTopic] = msg.Topic
+
+	topic := msg.Topic + w.options.DeadLetterSuffix
+	w.logger.Error("dead-lettering message", "topic", msg.Topic, "dead_letter_topic", topic, "attempts", attempts, "error", cause)
+	dead := queue.Message{Topic: topic, Key: msg.Key, Body: msg.Body, Headers: headers}
+	if err := w.broker.Publish(context.WithoutCancel(ctx), dead); err != nil {
+		metrics.MessagesTotal.WithLabelValues(msg.Topic, metrics.StatusFailed).Inc()
+		return fmt.Errorf("publishing to dead-letter topic %s: %w", topic, err)
+	}
+	metrics.MessagesTotal.WithLabelValues(msg.Topic, metrics.StatusDeadLettered).Inc()
+	return nil
+}
+
+// safeHandle runs handler, turning a panic into an error.
+func safeHandle(ctx context.Context, handler queue.Handler, msg queue.Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, msg)
+}
+
+// permanentError marks an error that retrying will not fix.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so the message is dead-lettered without being
+// retried, e.g. when it cannot be decoded.
+func Permanent(err error) error {
+	return &permanentError{err: err}
+}
@@ -0,0 +1,28 @@
+# GoForge and Go build artifacts
+/dist
+/services/*/dist
+/vendor/
+
+# Test binaries and coverage reports
+*.test
+*.out
+cover.html
+
+# IDE settings
+.idea/
+.vscode/
+
+# OS-specific files
+.DS_Store
+Thumbs.db
+
+# Environment variables
+.env
+.env.*
+!.env.example
+
+# goforge command history
+.goforge/history
+
+# goforge profile captures
+.goforge/profiles/
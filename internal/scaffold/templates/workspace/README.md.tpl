@@ -0,0 +1,59 @@
+# {{.ProjectName}}
+{{- if .Description}}
+
+{{.Description}}
+{{- end}}
+
+This Go monorepo was generated by [GoForge](https://github.com/night-slayer18/goforge).
+
+## 🚀 Getting Started
+
+### Prerequisites
+
+- Go (version {{.GoVersion}} or newer)
+- The `goforge` CLI tool
+
+### Project Layout
+
+- `go.work` — the workspace: the shared module at the root and one module per service
+- `pkg/` — packages shared by the services, in the `{{.ModuleName}}` module, e.g. `pkg/health`
+- `services/api/` — the api service, a module of its own (`{{.ModuleName}}/services/api`) with its own `goforge.yml`
+- `goforge.yml` — the workspace manifest: the list of services and the scripts spanning them
+
+### Running a Service
+
+```bash
+cd services/api
+goforge run dev               # http://localhost:8080/health
+```
+
+Service commands such as `goforge build` and `goforge generate` work in a service directory; from anywhere in the workspace, select the service with `--service` (`-w`):
+
+```bash
+goforge build --service api
+goforge g handler user -w api
+goforge build --all-services  # Every service
+```
+
+In a service, `goforge run <script>` falls back to the workspace's scripts, so `goforge run lint` works from anywhere.
+
+### Adding a Service
+
+1. Create it next to the others: `goforge new billing -m {{.ModuleName}}/services/billing` in `services/`
+2. Add it to `go.work` (`go work use ./services/billing`) and to `workspace.services` in `goforge.yml`
+3. To use the shared packages, require the root module in its `go.mod` with `replace {{.ModuleName}} => ../..`, as `services/api` does
+
+### Available Scripts
+
+-   `goforge run test`: Runs the tests of every module.
+-   `goforge run vet`: Vets every module.
+-   `goforge run lint`: Runs golangci-lint in every module.
+-   `goforge run tidy`: Tidies every module.
+
+You can find and add more scripts in the `goforge.yml` file.
+{{- if .License}}
+
+## License
+
+{{.License}}{{if .Author}}, © {{.Year}} {{.Author}}{{end}}. See [LICENSE](LICENSE).
+{{- end}}
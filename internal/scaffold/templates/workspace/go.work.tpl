@@ -0,0 +1,6 @@
+go {{.GoVersion}}
+
+use (
+	.
+	./services/api
+)
@@ -0,0 +1,51 @@
+# GoForge workspace configuration: the manifest of the monorepo. Each
+# service has its own goforge.yml; run service commands (build, generate,
+# watch) from its directory, or with 'goforge <command> --service <name>'.
+project_name: "{{.ProjectName}}"
+module_path: "{{.ModuleName}}"
+go_version: "{{.GoVersion}}"
+
+# Project metadata
+description: {{quote .Description}}
+author: "{{.Author}}"
+license: "{{.License}}"
+{{- if .Repository}}
+repository: {{quote .Repository}}
+{{- end}}
+
+# The services of the workspace, relative to this file; every module in
+# go.work with its own goforge.yml when empty
+workspace:
+  services:
+    - "services/api"
+
+# Dependencies of the shared module (pkg/)
+dependencies: {}
+
+# Scripts spanning the workspace. Services can run them too: 'goforge run
+# <script>' in a service falls back to these, run from the workspace root.
+scripts:
+  # Testing and code quality across every module in go.work; the module
+  # pattern, unlike ./..., also matches the services' packages
+  test: "go test {{.ModuleName}}/..."
+  test:race: "go test -race {{.ModuleName}}/..."
+  lint: "for d in . services/*/; do goforge -C \"$d\" lint || exit 1; done"
+  fmt: "for d in . services/*/; do goforge -C \"$d\" fmt || exit 1; done"
+  vet: "go vet {{.ModuleName}}/..."
+
+  # Workspace maintenance
+  sync: "go work sync"
+  tidy: "go mod tidy && for d in services/*/; do (cd \"$d\" && go mod tidy); done"
+
+# Testing configuration
+test:
+  # Test timeout
+  timeout: "10m"
+
+  # Coverage threshold (percentage)
+  coverage_threshold: 80
+
+# Code generation settings
+generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
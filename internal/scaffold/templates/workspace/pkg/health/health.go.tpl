@@ -0,0 +1,29 @@
+// Package health is shared by the services of the workspace: every service
+// mounts its handler at /health.
+package health
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Status is the body of a health check response.
+type Status struct {
+	Service string `json:"service"`
+	Status  string `json:"status"`
+	Uptime  string `json:"uptime"`
+}
+
+// Handler answers health checks for the named service.
+func Handler(service string) http.Handler {
+	started := time.Now()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Status{
+			Service: service,
+			Status:  "ok",
+			Uptime:  time.Since(started).Round(time.Second).String(),
+		})
+	})
+}
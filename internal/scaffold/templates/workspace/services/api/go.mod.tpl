@@ -0,0 +1,9 @@
+module {{.ModuleName}}/services/api
+
+go {{.GoVersion}}
+
+require {{.ModuleName}} v0.0.0
+
+// go.work resolves the shared module in the workspace; the replace keeps
+// the service building on its own, e.g. in 'go mod tidy' and Docker builds
+replace {{.ModuleName}} => ../..
@@ -0,0 +1,47 @@
+# GoForge project configuration of the api service. Scripts not defined
+# here are looked up in the workspace's goforge.yml.
+project_name: "api"
+module_path: "{{.ModuleName}}/services/api"
+go_version: "{{.GoVersion}}"
+
+# Project metadata
+description: "The api service of {{.ProjectName}}"
+
+# Dependencies with version constraints
+dependencies: {}
+
+# Custom scripts for project automation
+scripts:
+  # Development
+  dev: "go run ./cmd/server"
+
+  # Building
+  build: "goforge build"
+
+  # Testing
+  test: "go test ./..."
+
+# Build configuration
+build:
+  # Output directory for build artifacts
+  output_dir: "dist"
+
+  # Package compiled by 'goforge build'
+  main: "./cmd/server"
+
+# Development configuration
+dev:
+  # Files/directories to watch for changes; the shared packages too
+  watch:
+    - "**/*.go"
+    - "../../pkg/**/*.go"
+
+  # Files/directories to ignore
+  ignore:
+    - "dist/**"
+    - "**/*_test.go"
+
+# Code generation settings
+generate:
+  # Run generated Go files through goimports (gofmt + import fixing)
+  format: true
@@ -0,0 +1,26 @@
+// Package api holds the HTTP routes of the api service.
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"{{.ModuleName}}/pkg/health"
+)
+
+// Routes returns the service's HTTP handler.
+func Routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("GET /health", health.Handler("api"))
+	mux.HandleFunc("GET /hello", hello)
+	return mux
+}
+
+func hello(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		name = "world"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"message": "Hello, " + name + "!"})
+}
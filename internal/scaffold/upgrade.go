@@ -0,0 +1,264 @@
+package scaffold
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/diff"
+	"github.com/night-slayer18/goforge/internal/logger"
+	"github.com/night-slayer18/goforge/internal/packs"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// UpgradeOptions controls 'goforge upgrade-template'.
+type UpgradeOptions struct {
+	// Version is the goforge release, the version of the embedded
+	// templates.
+	Version string
+
+	// Patch prints the changes as a unified diff instead of applying them.
+	Patch bool
+}
+
+// Kinds of template upgrade changes.
+const (
+	upgradeAdded    = "added"
+	upgradeUpdated  = "updated"
+	upgradeMerged   = "merged"
+	upgradeConflict = "conflict"
+)
+
+// upgradeChange is a project file the latest template changes.
+type upgradeChange struct {
+	task     FileGenerationTask
+	kind     string
+	current  string // Content in the project, empty for added files
+	content  string // Content after the upgrade; the template's output for conflicts
+	rendered []byte // Template output, the next merge base
+}
+
+// templateVersion returns the version of the named project template: the
+// goforge release for embedded templates, the installed pack version
+// otherwise.
+func templateVersion(name, goforgeVersion string) string {
+	if !strings.Contains(name, "/") {
+		return goforgeVersion
+	}
+	pack, _, err := packs.Resolve(name)
+	if err != nil {
+		return ""
+	}
+	return pack.Version
+}
+
+// recordTemplate writes the template, its version, and its variables to
+// the goforge.yml of a new project. Templates without a goforge.yml
+// record nothing.
+func recordTemplate(options Options, vars map[string]any) error {
+	if _, err := os.Stat(filepath.Join(options.DestPath, "goforge.yml")); err != nil {
+		return nil
+	}
+	settings := map[string]string{"name": options.Template}
+	if version := templateVersion(options.Template, options.Version); version != "" {
+		settings["version"] = version
+	}
+	for _, key := range []string{"name", "version"} {
+		if value, ok := settings[key]; ok {
+			if err := project.SetConfigValue(options.DestPath, []string{"template", key}, value); err != nil {
+				return fmt.Errorf("failed to update goforge.yml: %w", err)
+			}
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(vars)) {
+		if err := project.SetConfigValue(options.DestPath, []string{"template", "vars", name}, fmt.Sprint(vars[name])); err != nil {
+			return fmt.Errorf("failed to update goforge.yml: %w", err)
+		}
+	}
+	return nil
+}
+
+// UpgradeTemplate brings the project in the current directory up to the
+// latest version of the template it was created from. Files left as the
+// template wrote them are replaced, files edited since are merged with
+// the template's changes, and files whose edits conflict with them are
+// only reported.
+func UpgradeTemplate(upgradeOptions UpgradeOptions) error {
+	s := NewScaffolder()
+
+	cfg, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return fmt.Errorf("command must be run from the root of a goforge project: %w", err)
+	}
+	if cfg.Template == nil || cfg.Template.Name == "" {
+		return fmt.Errorf("goforge.yml does not record the project's template\n\nAdd it for projects created before templates were recorded, e.g.:\n  template:\n    name: default")
+	}
+	s.configure(cfg)
+
+	name := cfg.Template.Name
+	source, templateRoot, err := s.resolveProjectTemplate(name)
+	if err != nil {
+		return err
+	}
+	manifest, err := readManifest(source, templateRoot)
+	if err != nil {
+		return err
+	}
+
+	// Variables the template no longer declares are dropped, new ones get
+	// their defaults
+	recorded := map[string]string{}
+	for _, variable := range manifest.Variables {
+		if value, ok := cfg.Template.Vars[variable.Name]; ok {
+			recorded[variable.Name] = value
+		}
+	}
+	vars, err := resolveVariables(manifest.Variables, recorded)
+	if err != nil {
+		return err
+	}
+
+	data := TemplateData{
+		ProjectName: cfg.ProjectName,
+		ModuleName:  cfg.ModuleName,
+		GoVersion:   cfg.GoVersion,
+		Author:      cfg.Author,
+		License:     cfg.License,
+		Description: cfg.Description,
+		Repository:  cfg.Repository,
+		Year:        time.Now().Year(),
+		Vars:        vars,
+	}
+	tasks, err := s.collectGenerationTasks(source, templateRoot, projectRoot, data)
+	if err != nil {
+		return fmt.Errorf("failed to collect the template files: %w", err)
+	}
+
+	changes, err := s.templateChanges(projectRoot, tasks)
+	if err != nil {
+		return err
+	}
+
+	// The patch alone goes to stdout, so it can be redirected to a file
+	if upgradeOptions.Patch {
+		for _, change := range changes {
+			rel := filepath.ToSlash(relativeTo(projectRoot, change.task.TargetPath))
+			from := "a/" + rel
+			if change.kind == upgradeAdded {
+				from = "/dev/null"
+			}
+			fmt.Print(diff.Unified(change.current, change.content, from, "b/"+rel, 3))
+		}
+		return nil
+	}
+
+	from, to := cfg.Template.Version, templateVersion(name, upgradeOptions.Version)
+	if from == "" {
+		from = "an unrecorded version"
+	}
+	logger.Info("⬆️  Upgrading from the %s template %s to %s", name, from, to)
+	if len(changes) == 0 {
+		logger.Success("✅ The project is up to date with the %s template", name)
+	}
+
+	var conflicts []string
+	counts := map[string]int{}
+	for _, change := range changes {
+		rel := relativeTo(projectRoot, change.task.TargetPath)
+		counts[change.kind]++
+		if change.kind == upgradeConflict {
+			conflicts = append(conflicts, rel)
+			continue
+		}
+		if err := s.writeFile(change.task.TargetPath, []byte(change.content)); err != nil {
+			return err
+		}
+		s.saveSnapshot(projectRoot, change.task.TargetPath, change.rendered)
+		logger.Info("♻️  %s %s", strings.ToUpper(change.kind[:1])+change.kind[1:], rel)
+	}
+
+	if err := project.SetConfigValue(projectRoot, []string{"template", "version"}, to); err != nil {
+		return fmt.Errorf("failed to update goforge.yml: %w", err)
+	}
+	for _, variable := range slices.Sorted(maps.Keys(vars)) {
+		if _, ok := cfg.Template.Vars[variable]; !ok {
+			if err := project.SetConfigValue(projectRoot, []string{"template", "vars", variable}, fmt.Sprint(vars[variable])); err != nil {
+				return fmt.Errorf("failed to update goforge.yml: %w", err)
+			}
+		}
+	}
+
+	if len(changes) > 0 {
+		logger.Success("✅ %d updated, %d added, %d merged, %d conflicting",
+			counts[upgradeUpdated], counts[upgradeAdded], counts[upgradeMerged], counts[upgradeConflict])
+	}
+	if len(conflicts) > 0 {
+		logger.Warn("⚠️  Your edits conflict with the template's changes in:")
+		for _, rel := range conflicts {
+			logger.Warn("   %s", rel)
+		}
+		logger.Info("💡 Review them with: goforge upgrade-template --patch")
+	}
+	logger.Info("")
+	logger.Info("📋 Next steps:")
+	logger.Info("   1. Review the changes: git diff")
+	logger.Info("   2. Update the dependencies: go mod tidy")
+	return nil
+}
+
+// templateChanges compares the project with the output of tasks. The
+// snapshots of the last rendering tell the user's edits from the
+// template's changes; goforge.yml, which goforge edits itself, is left
+// out.
+func (s *Scaffolder) templateChanges(projectRoot string, tasks []FileGenerationTask) ([]upgradeChange, error) {
+	var changes []upgradeChange
+	for _, task := range tasks {
+		if relativeTo(projectRoot, task.TargetPath) == "goforge.yml" {
+			continue
+		}
+		rendered, err := s.renderTemplate(task)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(rendered)) == 0 {
+			continue
+		}
+
+		change := upgradeChange{task: task, content: string(rendered), rendered: rendered}
+		base, hasBase := s.loadSnapshot(projectRoot, task.TargetPath)
+		existing, err := os.ReadFile(task.TargetPath)
+		switch {
+		case os.IsNotExist(err):
+			if hasBase {
+				// Deleted by the user
+				continue
+			}
+			change.kind = upgradeAdded
+		case err != nil:
+			return nil, fmt.Errorf("could not read %s: %w", task.TargetPath, err)
+		case string(existing) == string(rendered) || (hasBase && base == string(rendered)):
+			// Up to date, or only edited by the user
+			continue
+		case hasBase && string(existing) == base:
+			change.kind, change.current = upgradeUpdated, string(existing)
+		case hasBase:
+			merged, conflicts := diff.Merge3(base, string(existing), string(rendered))
+			change.current = string(existing)
+			if conflicts > 0 {
+				change.kind = upgradeConflict
+			} else {
+				change.kind, change.content = upgradeMerged, merged
+			}
+		default:
+			// Without a snapshot, edits cannot be told from template changes
+			change.kind, change.current = upgradeConflict, string(existing)
+		}
+		changes = append(changes, change)
+	}
+	return changes, nil
+}
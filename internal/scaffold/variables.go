@@ -0,0 +1,308 @@
+package scaffold
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/project"
+	"github.com/night-slayer18/goforge/internal/validation"
+	"gopkg.in/yaml.v3"
+)
+
+// TemplateManifestFile is the optional manifest at the root of a project
+// template directory. It is never copied into the generated project.
+const TemplateManifestFile = "template.yml"
+
+// Variable types accepted in template manifests.
+const (
+	VarString = "string"
+	VarBool   = "bool"
+	VarInt    = "int"
+	VarChoice = "choice"
+)
+
+// TemplateManifest describes a project template and the extra variables it
+// accepts beyond the built-in template data.
+type TemplateManifest struct {
+	Description string             `yaml:"description"`
+	Variables   []TemplateVariable `yaml:"variables"`
+}
+
+// TemplateVariable is a template parameter, available to the template as
+// {{.Vars.<name>}}.
+type TemplateVariable struct {
+	Name        string   `yaml:"name"`
+	Type        string   `yaml:"type"` // string (default), bool, int, or choice
+	Default     string   `yaml:"default"`
+	Prompt      string   `yaml:"prompt"`
+	Description string   `yaml:"description"`
+	Choices     []string `yaml:"choices"` // choice only
+	Required    bool     `yaml:"required"`
+}
+
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// Question returns the text shown when asking for the variable.
+func (v TemplateVariable) Question() string {
+	question := v.Prompt
+	if question == "" {
+		question = v.Name
+	}
+	switch v.Type {
+	case VarChoice:
+		question += fmt.Sprintf(" (%s)", strings.Join(v.Choices, "/"))
+	case VarBool:
+		question += " (y/n)"
+	}
+	return question
+}
+
+// Parse converts a raw value into the variable's type.
+func (v TemplateVariable) Parse(raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+	switch v.Type {
+	case "", VarString:
+		return raw, nil
+	case VarBool:
+		switch strings.ToLower(raw) {
+		case "y", "yes", "true", "1", "on":
+			return true, nil
+		case "n", "no", "false", "0", "off":
+			return false, nil
+		}
+		return nil, fmt.Errorf("%s must be yes or no, got '%s'", v.Name, raw)
+	case VarInt:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number, got '%s'", v.Name, raw)
+		}
+		return n, nil
+	case VarChoice:
+		for _, choice := range v.Choices {
+			if raw == choice {
+				return raw, nil
+			}
+		}
+		return nil, fmt.Errorf("%s must be one of %s, got '%s'", v.Name, strings.Join(v.Choices, ", "), raw)
+	default:
+		return nil, fmt.Errorf("%s has unknown type '%s'", v.Name, v.Type)
+	}
+}
+
+// Validate parses a raw value and discards the result; it suits prompt validators.
+func (v TemplateVariable) Validate(raw string) error {
+	_, err := v.Parse(raw)
+	return err
+}
+
+// check reports mistakes in the variable definition itself.
+func (v TemplateVariable) check() error {
+	if !variableNamePattern.MatchString(v.Name) {
+		return fmt.Errorf("invalid variable name '%s' (use letters, digits, and underscores)", v.Name)
+	}
+	switch v.Type {
+	case "", VarString, VarBool, VarInt:
+	case VarChoice:
+		if len(v.Choices) == 0 {
+			return fmt.Errorf("variable '%s' is a choice but lists no choices", v.Name)
+		}
+	default:
+		return fmt.Errorf("variable '%s' has unknown type '%s' (use string, bool, int, or choice)", v.Name, v.Type)
+	}
+	if v.Default != "" {
+		if err := v.Validate(v.Default); err != nil {
+			return fmt.Errorf("invalid default: %w", err)
+		}
+	}
+	return nil
+}
+
+// describe summarizes the variable for listings, e.g. ".Vars.db (choice, default postgres)".
+func (v TemplateVariable) describe() string {
+	kind := v.Type
+	if kind == "" {
+		kind = VarString
+	}
+	detail := kind
+	if v.Default != "" {
+		detail += ", default " + v.Default
+	} else if v.Required {
+		detail += ", required"
+	}
+	return fmt.Sprintf(".Vars.%s (%s)", v.Name, detail)
+}
+
+// parseManifest decodes and checks a manifest.
+func parseManifest(data []byte, name string) (*TemplateManifest, error) {
+	var manifest TemplateManifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	if err := checkVariables(manifest.Variables); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &manifest, nil
+}
+
+// checkVariables validates every definition and rejects duplicates.
+func checkVariables(vars []TemplateVariable) error {
+	seen := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		if err := v.check(); err != nil {
+			return err
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("variable '%s' is declared twice", v.Name)
+		}
+		seen[v.Name] = true
+	}
+	return nil
+}
+
+// readManifest loads root/template.yml from source; a missing manifest is empty.
+func readManifest(source fs.FS, root string) (*TemplateManifest, error) {
+	name := path.Join(root, TemplateManifestFile)
+	data, err := fs.ReadFile(source, name)
+	if errors.Is(err, fs.ErrNotExist) {
+		return &TemplateManifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	return parseManifest(data, name)
+}
+
+// ProjectTemplateManifest returns the manifest of a project template.
+func ProjectTemplateManifest(template string) (*TemplateManifest, error) {
+	s := NewScaffolder()
+	source, root, err := s.resolveProjectTemplate(template)
+	if err != nil {
+		return nil, err
+	}
+	return readManifest(source, root)
+}
+
+// ComponentVariables returns the variables a component generator accepts.
+// It must be called from inside a goforge project.
+func ComponentVariables(componentType string) ([]TemplateVariable, error) {
+	_, projectRoot, err := project.LoadConfig()
+	if err != nil {
+		return nil, nil
+	}
+	spec, err := resolveComponent(projectRoot, componentType)
+	if err != nil {
+		return nil, err
+	}
+	return spec.Variables, nil
+}
+
+// ParseVarFlags turns repeated --var key=value flags into a map.
+func ParseVarFlags(pairs []string) (map[string]string, error) {
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, exitcode.Errorf(exitcode.Validation, "invalid --var '%s' (expected key=value)", pair)
+		}
+		values[key] = value
+	}
+	return values, nil
+}
+
+// Variable returns the variable with the given name, if the manifest
+// declares one.
+func (m *TemplateManifest) Variable(name string) (TemplateVariable, bool) {
+	for _, v := range m.Variables {
+		if v.Name == name {
+			return v, true
+		}
+	}
+	return TemplateVariable{}, false
+}
+
+// CheckValues reports every provided value that the manifest's variables
+// reject, as validation.ValidationErrors.
+func (m *TemplateManifest) CheckValues(provided map[string]string) error {
+	_, err := resolveVariables(m.Variables, provided)
+	return err
+}
+
+// resolveVariables type-checks provided values and fills in defaults,
+// collecting every problem as validation.ValidationErrors.
+func resolveVariables(vars []TemplateVariable, provided map[string]string) (map[string]any, error) {
+	known := make(map[string]bool, len(vars))
+	for _, v := range vars {
+		known[v.Name] = true
+	}
+
+	var errs validation.ValidationErrors
+	var unknown []string
+	for key := range provided {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		var names []string
+		for _, v := range vars {
+			names = append(names, v.Name)
+		}
+		suggestion := "This template declares no variables"
+		if len(names) > 0 {
+			suggestion = "This template accepts: " + strings.Join(names, ", ")
+		}
+		errs = append(errs, &validation.ValidationError{
+			Field:       "vars",
+			Value:       strings.Join(unknown, ", "),
+			Message:     "unknown template variable(s)",
+			Suggestions: []string{suggestion},
+		})
+	}
+
+	values := make(map[string]any, len(vars))
+	for _, v := range vars {
+		raw, ok := provided[v.Name]
+		if !ok {
+			if v.Default == "" && v.Required {
+				errs = append(errs, &validation.ValidationError{
+					Field:       "vars." + v.Name,
+					Message:     fmt.Sprintf("template variable '%s' is required", v.Name),
+					Suggestions: []string{fmt.Sprintf("Set it with: --var %s=<value>", v.Name)},
+				})
+				continue
+			}
+			raw = v.Default
+			if raw == "" {
+				// Unset optional variables get their type's zero value
+				switch v.Type {
+				case VarBool:
+					raw = "false"
+				case VarInt:
+					raw = "0"
+				case VarChoice:
+					raw = v.Choices[0]
+				}
+			}
+		}
+
+		value, err := v.Parse(raw)
+		if err != nil {
+			errs = append(errs, &validation.ValidationError{Field: "vars." + v.Name, Value: raw, Message: err.Error()})
+			continue
+		}
+		values[v.Name] = value
+	}
+	if err := errs.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,289 @@
+// Package support builds the encrypted diagnostics bundles created by
+// 'goforge support-bundle'.
+//
+// Bundle format (version 1). A bundle file is:
+//
+//	"GFSBNDL1"                     8-byte magic
+//	mode                           1 byte: 1 = passphrase, 2 = recipient key
+//	salt (mode 1)                  16 bytes, PBKDF2-SHA256 with 600000 iterations
+//	ephemeral public key (mode 2)  32 bytes, X25519; key = HKDF-SHA256(shared,
+//	                               ephemeral||recipient, "goforge support-bundle v1")
+//	nonce                          12 bytes
+//	ciphertext                     AES-256-GCM, the bytes above as additional data
+//
+// The plaintext is a gzipped tar archive containing:
+//
+//	manifest.json         format version, creation time, and a SHA-256 per file
+//	environment.json      OS, Go toolchain, go env, tool versions, GOFORGE_* env
+//	project/goforge.yml   redacted project manifest
+//	project/go.mod        and go.work, when present
+//	timings/*.json        profiling reports from .goforge (e.g. create-profile.json)
+//	logs/*.log            the last 1 MiB of each log file under .goforge, redacted
+//	packs.yml             installed template packs, credentials removed
+//	plugins.json          goforge-<name> plugins found on PATH
+//
+// Files are only ever added to this layout; readers should ignore files
+// they do not know.
+package support
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/plugins"
+	"github.com/night-slayer18/goforge/internal/project"
+)
+
+// FormatVersion is the bundle layout version recorded in manifest.json.
+const FormatVersion = 1
+
+// maxLogBytes is how much of the end of each log file is kept.
+const maxLogBytes = 1 << 20
+
+// Manifest is manifest.json.
+type Manifest struct {
+	FormatVersion  int         `json:"format_version"`
+	Created        time.Time   `json:"created"`
+	GoforgeVersion string      `json:"goforge_version"`
+	ProjectRoot    string      `json:"project_root,omitempty"`
+	Files          []FileEntry `json:"files"`
+}
+
+// FileEntry describes one file in the bundle.
+type FileEntry struct {
+	Name   string `json:"name"`
+	Size   int    `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// Environment is environment.json.
+type Environment struct {
+	OS        string            `json:"os"`
+	Arch      string            `json:"arch"`
+	CPUs      int               `json:"cpus"`
+	GoVersion string            `json:"go_version,omitempty"`
+	GoEnv     map[string]string `json:"go_env,omitempty"`
+	Tools     map[string]string `json:"tools"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// goEnvKeys are the 'go env' values worth reporting.
+var goEnvKeys = []string{"GOOS", "GOARCH", "GOVERSION", "GOPATH", "GOMODCACHE", "GOPROXY", "GOPRIVATE", "GOFLAGS", "GOWORK", "CGO_ENABLED", "GOTOOLCHAIN"}
+
+// Bundle collects files before they are archived.
+type Bundle struct {
+	version     string
+	projectRoot string
+	files       map[string][]byte
+}
+
+// Collect gathers the diagnostics for projectRoot, which may be empty
+// when run outside a project.
+func Collect(version, projectRoot string) (*Bundle, error) {
+	b := &Bundle{version: version, projectRoot: projectRoot, files: make(map[string][]byte)}
+
+	if err := b.addJSON("environment.json", collectEnvironment()); err != nil {
+		return nil, err
+	}
+	if err := b.addJSON("plugins.json", plugins.Discover()); err != nil {
+		return nil, err
+	}
+	if data, err := readPacksRegistry(); err == nil {
+		b.files["packs.yml"] = redactURLs(data)
+	}
+
+	if projectRoot == "" {
+		return b, nil
+	}
+
+	configPath := project.ConfigPath(projectRoot)
+	if data, err := os.ReadFile(configPath); err == nil {
+		if project.FormatOf(configPath) == "yaml" {
+			data = RedactYAML(data)
+		} else {
+			data = RedactText(data)
+		}
+		b.files["project/"+filepath.Base(configPath)] = data
+	}
+	for _, name := range []string{"go.mod", "go.work"} {
+		if data, err := os.ReadFile(filepath.Join(projectRoot, name)); err == nil {
+			b.files["project/"+name] = data
+		}
+	}
+
+	goforgeDir := filepath.Join(projectRoot, ".goforge")
+	filepath.WalkDir(goforgeDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		switch {
+		case strings.HasSuffix(d.Name(), "-profile.json"):
+			if data, err := os.ReadFile(path); err == nil {
+				b.files["timings/"+d.Name()] = data
+			}
+		case strings.HasSuffix(d.Name(), ".log"):
+			if data, err := readTail(path, maxLogBytes); err == nil {
+				rel, _ := filepath.Rel(goforgeDir, path)
+				b.files["logs/"+strings.TrimPrefix(filepath.ToSlash(rel), "logs/")] = RedactText(data)
+			}
+		}
+		return nil
+	})
+	return b, nil
+}
+
+// Names returns the files in the bundle, sorted.
+func (b *Bundle) Names() []string {
+	names := make([]string, 0, len(b.files))
+	for name := range b.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Archive writes the files and manifest.json as a gzipped tar archive.
+func (b *Bundle) Archive() ([]byte, error) {
+	manifest := Manifest{
+		FormatVersion:  FormatVersion,
+		Created:        time.Now().UTC().Truncate(time.Second),
+		GoforgeVersion: b.version,
+		ProjectRoot:    b.projectRoot,
+	}
+	for _, name := range b.Names() {
+		sum := sha256.Sum256(b.files[name])
+		manifest.Files = append(manifest.Files, FileEntry{Name: name, Size: len(b.files[name]), SHA256: hex.EncodeToString(sum[:])})
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	write := func(name string, data []byte) error {
+		header := &tar.Header{Name: name, Mode: 0600, Size: int64(len(data)), ModTime: manifest.Created, Typeflag: tar.TypeReg}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+
+	if err := write("manifest.json", append(manifestData, '\n')); err != nil {
+		return nil, err
+	}
+	for _, name := range b.Names() {
+		if err := write(name, b.files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (b *Bundle) addJSON(name string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	b.files[name] = append(data, '\n')
+	return nil
+}
+
+// collectEnvironment describes the machine and toolchain. Missing tools
+// are reported as "not found" rather than failing the bundle.
+func collectEnvironment() Environment {
+	env := Environment{
+		OS:    runtime.GOOS,
+		Arch:  runtime.GOARCH,
+		CPUs:  runtime.NumCPU(),
+		Tools: make(map[string]string),
+		Env:   make(map[string]string),
+	}
+
+	if out, err := exec.Command("go", "version").Output(); err == nil {
+		env.GoVersion = strings.TrimSpace(string(out))
+	}
+	if out, err := exec.Command("go", append([]string{"env", "-json"}, goEnvKeys...)...).Output(); err == nil {
+		var goEnv map[string]string
+		if json.Unmarshal(out, &goEnv) == nil {
+			for key, value := range goEnv {
+				goEnv[key] = string(redactURLs([]byte(value)))
+			}
+			env.GoEnv = goEnv
+		}
+	}
+
+	for tool, args := range map[string][]string{
+		"git":    {"--version"},
+		"docker": {"--version"},
+		"air":    {"-v"},
+	} {
+		out, err := exec.Command(tool, args...).Output()
+		if err != nil {
+			env.Tools[tool] = "not found"
+			continue
+		}
+		env.Tools[tool] = strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	}
+
+	for _, kv := range os.Environ() {
+		key, value, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(key, "GOFORGE_") {
+			if sensitiveKey(key) {
+				value = Redacted
+			}
+			env.Env[key] = value
+		}
+	}
+	return env
+}
+
+// readPacksRegistry reads the installed packs list from the user config dir.
+func readPacksRegistry() ([]byte, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filepath.Join(dir, "goforge", "packs.yml"))
+}
+
+// readTail returns at most the last n bytes of a file.
+func readTail(path string, n int64) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	offset := int64(0)
+	if info.Size() > n {
+		offset = info.Size() - n
+	}
+	data := make([]byte, info.Size()-offset)
+	_, err = f.ReadAt(data, offset)
+	return data, err
+}
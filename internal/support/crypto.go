@@ -0,0 +1,215 @@
+package support
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Magic starts every bundle file.
+const Magic = "GFSBNDL1"
+
+// Encryption modes stored after the magic.
+const (
+	ModePassphrase byte = 1
+	ModeRecipient  byte = 2
+)
+
+// Key encodings produced by GenerateKeyPair.
+const (
+	PublicKeyPrefix  = "gfsb-pub-"
+	PrivateKeyPrefix = "gfsb-key-"
+)
+
+const (
+	pbkdf2Iterations = 600000
+	saltSize         = 16
+	hkdfInfo         = "goforge support-bundle v1"
+)
+
+// GenerateKeyPair creates an X25519 key pair for a support queue. The
+// public key is handed to developers; the private key stays with the team.
+func GenerateKeyPair() (public, private string, err error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return PublicKeyPrefix + base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()),
+		PrivateKeyPrefix + base64.StdEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// EncryptForRecipient seals plaintext so only the holder of the private
+// key matching recipient can read it.
+func EncryptForRecipient(plaintext []byte, recipient string) ([]byte, error) {
+	recipientKey, err := parsePublicKey(recipient)
+	if err != nil {
+		return nil, err
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	shared, err := ephemeral.ECDH(recipientKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(Magic), ModeRecipient)
+	header = append(header, ephemeral.PublicKey().Bytes()...)
+	key, err := hkdf.Key(sha256.New, shared, append(ephemeral.PublicKey().Bytes(), recipientKey.Bytes()...), hkdfInfo, 32)
+	if err != nil {
+		return nil, err
+	}
+	return seal(header, key, plaintext)
+}
+
+// EncryptWithPassphrase seals plaintext with a key derived from passphrase.
+func EncryptWithPassphrase(plaintext []byte, passphrase string) ([]byte, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is empty")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	header := append([]byte(Magic), ModePassphrase)
+	header = append(header, salt...)
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	return seal(header, key, plaintext)
+}
+
+// Decrypt opens a bundle with either a private key (gfsb-key-...) or a
+// passphrase, depending on how it was sealed.
+func Decrypt(data []byte, secret string) ([]byte, error) {
+	if len(data) < len(Magic)+1 || string(data[:len(Magic)]) != Magic {
+		return nil, errors.New("not a goforge support bundle")
+	}
+	mode := data[len(Magic)]
+	rest := data[len(Magic)+1:]
+
+	var key []byte
+	var headerLen int
+	switch mode {
+	case ModePassphrase:
+		if len(rest) < saltSize {
+			return nil, errors.New("bundle is truncated")
+		}
+		var err error
+		key, err = pbkdf2.Key(sha256.New, secret, rest[:saltSize], pbkdf2Iterations, 32)
+		if err != nil {
+			return nil, err
+		}
+		headerLen = len(Magic) + 1 + saltSize
+	case ModeRecipient:
+		if len(rest) < 32 {
+			return nil, errors.New("bundle is truncated")
+		}
+		private, err := parsePrivateKey(secret)
+		if err != nil {
+			return nil, err
+		}
+		ephemeral, err := ecdh.X25519().NewPublicKey(rest[:32])
+		if err != nil {
+			return nil, err
+		}
+		shared, err := private.ECDH(ephemeral)
+		if err != nil {
+			return nil, err
+		}
+		key, err = hkdf.Key(sha256.New, shared, append(ephemeral.Bytes(), private.PublicKey().Bytes()...), hkdfInfo, 32)
+		if err != nil {
+			return nil, err
+		}
+		headerLen = len(Magic) + 1 + 32
+	default:
+		return nil, fmt.Errorf("unsupported bundle encryption mode %d", mode)
+	}
+	return open(data[:headerLen], key, data[headerLen:])
+}
+
+// seal encrypts with AES-256-GCM and returns header || nonce || ciphertext.
+// The header is authenticated as additional data.
+func seal(header, key, plaintext []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.Write(header)
+	out.Write(nonce)
+	out.Write(gcm.Seal(nil, nonce, plaintext, header))
+	return out.Bytes(), nil
+}
+
+// open reverses seal for the bytes following the header.
+func open(header, key, body []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(body) < gcm.NonceSize() {
+		return nil, errors.New("bundle is truncated")
+	}
+	plaintext, err := gcm.Open(nil, body[:gcm.NonceSize()], body[gcm.NonceSize():], header)
+	if err != nil {
+		return nil, errors.New("failed to decrypt bundle (wrong key or passphrase, or the file is corrupted)")
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func parsePublicKey(encoded string) (*ecdh.PublicKey, error) {
+	raw, err := decodeKey(encoded, PublicKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recipient key: %w", err)
+	}
+	return ecdh.X25519().NewPublicKey(raw)
+}
+
+func parsePrivateKey(encoded string) (*ecdh.PrivateKey, error) {
+	raw, err := decodeKey(encoded, PrivateKeyPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+	return ecdh.X25519().NewPrivateKey(raw)
+}
+
+func decodeKey(encoded, prefix string) ([]byte, error) {
+	encoded = strings.TrimSpace(encoded)
+	if !strings.HasPrefix(encoded, prefix) {
+		return nil, fmt.Errorf("expected a key starting with %s", prefix)
+	}
+	raw, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(encoded, prefix))
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) != 32 {
+		return nil, fmt.Errorf("expected 32 bytes, got %d", len(raw))
+	}
+	return raw, nil
+}
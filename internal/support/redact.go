@@ -0,0 +1,81 @@
+package support
+
+import (
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Redacted replaces removed values.
+const Redacted = "[REDACTED]"
+
+var (
+	// sensitivePattern matches key names that usually hold secrets.
+	sensitivePattern = regexp.MustCompile(`(?i)(secret|passw(or)?d|pwd|token|api[_-]?key|private[_-]?key|access[_-]?key|credential|authorization|(^|[_.-])auth([_.-]|$)|dsn|cookie)`)
+
+	// assignmentPattern matches KEY=value and key: value pairs in free text.
+	assignmentPattern = regexp.MustCompile(`(?i)\b([A-Z0-9_.-]*(?:secret|passw(?:or)?d|pwd|token|api[_-]?key|private[_-]?key|access[_-]?key|credential|authorization|dsn)[A-Z0-9_.-]*)(\s*[=:]\s*)("[^"]*"|'[^']*'|\S+)`)
+
+	// userinfoPattern matches credentials embedded in URLs.
+	userinfoPattern = regexp.MustCompile(`([a-zA-Z][a-zA-Z0-9+.-]*://)[^/\s:@]+(:[^/\s@]*)?@`)
+
+	// bearerPattern matches authorization header values.
+	bearerPattern = regexp.MustCompile(`(?i)\b(bearer|basic)\s+[A-Za-z0-9._~+/=-]{8,}`)
+)
+
+// sensitiveKey reports whether a key name looks like it holds a secret.
+func sensitiveKey(key string) bool {
+	return sensitivePattern.MatchString(key)
+}
+
+// RedactText removes secrets from free-form text such as logs and scripts.
+func RedactText(data []byte) []byte {
+	data = redactURLs(data)
+	data = bearerPattern.ReplaceAll(data, []byte("$1 "+Redacted))
+	return assignmentPattern.ReplaceAll(data, []byte("${1}${2}"+Redacted))
+}
+
+// redactURLs strips user names and passwords from URLs.
+func redactURLs(data []byte) []byte {
+	return userinfoPattern.ReplaceAll(data, []byte("${1}"+Redacted+"@"))
+}
+
+// RedactYAML removes the values of sensitive keys from a YAML document and
+// scrubs every remaining string. Unparseable input is redacted as text.
+func RedactYAML(data []byte) []byte {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return RedactText(data)
+	}
+	redactNode(&doc, false)
+
+	out, err := yaml.Marshal(&doc)
+	if err != nil {
+		return RedactText(data)
+	}
+	return out
+}
+
+// redactNode walks a YAML tree; sensitive is set below a sensitive key.
+func redactNode(node *yaml.Node, sensitive bool) {
+	switch node.Kind {
+	case yaml.DocumentNode, yaml.SequenceNode:
+		for _, child := range node.Content {
+			redactNode(child, sensitive)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			redactNode(node.Content[i+1], sensitive || sensitiveKey(node.Content[i].Value))
+		}
+	case yaml.ScalarNode:
+		if sensitive {
+			node.Value = Redacted
+			node.Tag = "!!str"
+			node.Style = 0
+			return
+		}
+		if node.Tag == "!!str" || node.Tag == "" {
+			node.Value = string(RedactText([]byte(node.Value)))
+		}
+	}
+}
@@ -0,0 +1,219 @@
+// Package telemetry records anonymous usage statistics for users who opt
+// in with 'goforge telemetry on': which command ran, how long it took,
+// and the kind of failure, if any, along with the goforge version and
+// platform. Arguments, paths, project names, and error messages are never
+// recorded.
+//
+// Events are queued one JSON object per line in goforge/telemetry.jsonl
+// in the user cache directory and sent in batches in the background.
+// Only the most recent MaxQueued are kept.
+package telemetry
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+)
+
+// DisableEnv turns telemetry off when set to anything but "" or "0",
+// whatever the user config says. DO_NOT_TRACK does the same.
+const DisableEnv = "GOFORGE_NO_TELEMETRY"
+
+// EndpointEnv overrides the address events are sent to.
+const EndpointEnv = "GOFORGE_TELEMETRY_URL"
+
+// MaxQueued is how many events are kept until they are sent; older ones
+// are dropped.
+const MaxQueued = 500
+
+// timeout bounds sending so a slow network never holds up a command.
+const timeout = 2 * time.Second
+
+// endpoint is the address events are sent to, set at build time for
+// releases. Builds without one keep events queued and never send them.
+var endpoint = ""
+
+// Event is one recorded command.
+type Event struct {
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"` // Command path, e.g. "generate handler"
+	Outcome  string    `json:"outcome"` // "ok", or the kind of failure, see exitcode.Code
+	Duration int64     `json:"duration_ms"`
+	Version  string    `json:"version"`
+	OS       string    `json:"os"`
+	Arch     string    `json:"arch"`
+}
+
+// NewEvent returns the event of command, run by goforge version, with
+// its outcome and duration.
+func NewEvent(command, outcome, version string, duration time.Duration) Event {
+	return Event{
+		Time:     time.Now().UTC().Truncate(time.Second),
+		Command:  command,
+		Outcome:  outcome,
+		Duration: duration.Milliseconds(),
+		Version:  version,
+		OS:       runtime.GOOS,
+		Arch:     runtime.GOARCH,
+	}
+}
+
+// Disabled reports whether the environment turns telemetry off: by
+// DisableEnv or DO_NOT_TRACK.
+func Disabled() bool {
+	for _, name := range []string{DisableEnv, "DO_NOT_TRACK"} {
+		if value := os.Getenv(name); value != "" && value != "0" {
+			return true
+		}
+	}
+	return false
+}
+
+// Endpoint returns the address events are sent to, empty when they are
+// only queued.
+func Endpoint() string {
+	if url := os.Getenv(EndpointEnv); url != "" {
+		return url
+	}
+	return endpoint
+}
+
+// QueuePath returns the file events are queued in.
+func QueuePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goforge", "telemetry.jsonl"), nil
+}
+
+// Record adds event to the queue, dropping the oldest events beyond
+// MaxQueued.
+func Record(event Event) error {
+	file, err := QueuePath()
+	if err != nil {
+		return err
+	}
+	events, err := load(file)
+	if err != nil {
+		return err
+	}
+	events = append(events, event)
+	if len(events) > MaxQueued {
+		events = events[len(events)-MaxQueued:]
+	}
+	return save(file, events)
+}
+
+// Queued returns the events waiting to be sent, oldest first.
+func Queued() ([]Event, error) {
+	file, err := QueuePath()
+	if err != nil {
+		return nil, err
+	}
+	return load(file)
+}
+
+// Clear drops the queued events.
+func Clear() error {
+	file, err := QueuePath()
+	if err != nil {
+		return err
+	}
+	return save(file, nil)
+}
+
+// Flush sends the queued events to Endpoint as a JSON array and drops
+// them once it accepts them. It does nothing without an endpoint or
+// events; events recorded while it sends stay queued.
+func Flush() error {
+	url := Endpoint()
+	if url == "" {
+		return nil
+	}
+	events, err := Queued()
+	if err != nil || len(events) == 0 {
+		return err
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+
+	// Keep what was recorded in the meantime
+	file, err := QueuePath()
+	if err != nil {
+		return err
+	}
+	current, err := load(file)
+	if err != nil {
+		return err
+	}
+	sent := len(events)
+	if len(current) < sent {
+		sent = len(current)
+	}
+	return save(file, current[sent:])
+}
+
+// load reads the events queued in file. A missing file is an empty
+// queue; unreadable lines are skipped.
+func load(file string) ([]Event, error) {
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read telemetry queue: %w", err)
+	}
+
+	var events []Event
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+// save writes events to file, one per line, removing it when there are
+// none.
+func save(file string, events []Event) error {
+	if len(events) == 0 {
+		if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	for _, event := range events {
+		line, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return os.WriteFile(file, buf.Bytes(), 0644)
+}
@@ -0,0 +1,130 @@
+package testrun
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Graph is the import graph of a module's packages, for finding the
+// packages whose tests a change affects.
+type Graph struct {
+	byDir     map[string]string   // package directory to import path
+	importers map[string][]string // import path to the packages importing it
+	testers   map[string][]string // import path to the packages whose tests import it
+}
+
+// listedPackage is the part of 'go list -json' the graph is built from.
+type listedPackage struct {
+	ImportPath   string
+	Dir          string
+	Imports      []string
+	TestImports  []string
+	XTestImports []string
+}
+
+// LoadGraph lists the packages matching patterns, ./... when empty, in
+// dir and builds their import graph.
+func LoadGraph(dir string, patterns ...string) (*Graph, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	args := append([]string{"list", "-e", "-json=ImportPath,Dir,Imports,TestImports,XTestImports"}, patterns...)
+	cmd := exec.Command("go", args...)
+	cmd.Dir = dir
+	cmd.Env = runner.Environ()
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the packages: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	g := &Graph{byDir: map[string]string{}, importers: map[string][]string{}, testers: map[string][]string{}}
+	decoder := json.NewDecoder(bytes.NewReader(out))
+	for {
+		var pkg listedPackage
+		if err := decoder.Decode(&pkg); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to read the package list: %w", err)
+		}
+		g.byDir[pkg.Dir] = pkg.ImportPath
+		for _, imported := range pkg.Imports {
+			g.importers[imported] = append(g.importers[imported], pkg.ImportPath)
+		}
+		for _, imported := range slices.Concat(pkg.TestImports, pkg.XTestImports) {
+			g.testers[imported] = append(g.testers[imported], pkg.ImportPath)
+		}
+	}
+	return g, nil
+}
+
+// Packages returns the import paths of the graph's packages, sorted.
+func (g *Graph) Packages() []string {
+	return slices.Sorted(maps.Values(g.byDir))
+}
+
+// Package returns the import path of the package a file belongs to; files
+// under testdata belong to the package above it.
+func (g *Graph) Package(file string) (string, bool) {
+	dir := filepath.Dir(file)
+	if i := strings.Index(dir+string(filepath.Separator), string(filepath.Separator)+"testdata"+string(filepath.Separator)); i >= 0 {
+		dir = dir[:i]
+	}
+	path, ok := g.byDir[dir]
+	return path, ok
+}
+
+// Affected returns the packages whose tests the changed files affect,
+// sorted: the packages of the files, the packages importing those
+// directly or indirectly, and the packages whose tests import any of
+// them. A change to a test file only affects its own package.
+func (g *Graph) Affected(files []string) []string {
+	changed := map[string]bool{}
+	var queue []string
+	affected := map[string]bool{}
+	for _, file := range files {
+		pkg, ok := g.Package(file)
+		if !ok {
+			continue
+		}
+		if strings.HasSuffix(file, "_test.go") {
+			affected[pkg] = true
+			continue
+		}
+		if !changed[pkg] {
+			changed[pkg] = true
+			queue = append(queue, pkg)
+		}
+	}
+
+	// Packages built with a changed package change too
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		for _, importer := range g.importers[pkg] {
+			if !changed[importer] {
+				changed[importer] = true
+				queue = append(queue, importer)
+			}
+		}
+	}
+
+	for pkg := range changed {
+		affected[pkg] = true
+		for _, tester := range g.testers[pkg] {
+			affected[tester] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(affected))
+}
@@ -0,0 +1,51 @@
+package testrun
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Default files of the coverage report, relative to the project root.
+const (
+	CoverProfile = "coverage.out"
+	CoverHTML    = "coverage.html"
+)
+
+// totalLine matches the last line of 'go tool cover -func'.
+var totalLine = regexp.MustCompile(`(?m)^total:\s+\(statements\)\s+([0-9.]+)%`)
+
+// CoverageReport writes the HTML report of the coverage profile, both
+// relative to dir, and returns the coverage of all statements.
+func CoverageReport(dir, profile, html string) (float64, error) {
+	out, err := coverTool(dir, "-func="+profile)
+	if err != nil {
+		return 0, err
+	}
+	match := totalLine.FindStringSubmatch(out)
+	if match == nil {
+		return 0, fmt.Errorf("no total coverage in %s", profile)
+	}
+	total, _ := strconv.ParseFloat(match[1], 64)
+
+	if _, err := coverTool(dir, "-html="+profile, "-o", html); err != nil {
+		return total, err
+	}
+	return total, nil
+}
+
+// coverTool runs 'go tool cover' in dir and returns its output.
+func coverTool(dir string, args ...string) (string, error) {
+	cmd := exec.Command("go", append([]string{"tool", "cover"}, args...)...)
+	cmd.Dir = dir
+	cmd.Env = runner.Environ()
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("go tool cover %s failed: %s", strings.Join(args, " "), strings.TrimSpace(string(out)))
+	}
+	return string(out), nil
+}
@@ -0,0 +1,215 @@
+package testrun
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Formats of the report files CI systems read.
+const (
+	FormatJUnit = "junit"
+	FormatJSON  = "json"
+)
+
+// DefaultReportFile returns the report file of a format, relative to the
+// project root.
+func DefaultReportFile(format string) string {
+	if format == FormatJUnit {
+		return "test-report.xml"
+	}
+	return "test-report.json"
+}
+
+// WriteReport writes the report to path in format.
+func WriteReport(report *Report, format, path string) error {
+	var data []byte
+	var err error
+	switch format {
+	case FormatJUnit:
+		data, err = xml.MarshalIndent(junitReport(report), "", "  ")
+		data = append([]byte(xml.Header), data...)
+	case FormatJSON:
+		data, err = json.MarshalIndent(jsonReport(report), "", "  ")
+	default:
+		return fmt.Errorf("unknown report format '%s': use %s or %s", format, FormatJUnit, FormatJSON)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write the test report: %w", err)
+	}
+	return nil
+}
+
+// JUnit XML as read by Jenkins and GitLab: a suite per package, a case
+// per test.
+type (
+	junitSuites struct {
+		XMLName  xml.Name     `xml:"testsuites"`
+		Tests    int          `xml:"tests,attr"`
+		Failures int          `xml:"failures,attr"`
+		Errors   int          `xml:"errors,attr"`
+		Skipped  int          `xml:"skipped,attr"`
+		Time     string       `xml:"time,attr"`
+		Suites   []junitSuite `xml:"testsuite"`
+	}
+	junitSuite struct {
+		Name     string      `xml:"name,attr"`
+		Tests    int         `xml:"tests,attr"`
+		Failures int         `xml:"failures,attr"`
+		Errors   int         `xml:"errors,attr"`
+		Skipped  int         `xml:"skipped,attr"`
+		Time     string      `xml:"time,attr"`
+		Cases    []junitCase `xml:"testcase"`
+	}
+	junitCase struct {
+		Name      string        `xml:"name,attr"`
+		Classname string        `xml:"classname,attr"`
+		Time      string        `xml:"time,attr"`
+		Failure   *junitMessage `xml:"failure,omitempty"`
+		Error     *junitMessage `xml:"error,omitempty"`
+		Skipped   *junitMessage `xml:"skipped,omitempty"`
+	}
+	junitMessage struct {
+		Message string `xml:"message,attr"`
+		Text    string `xml:",cdata"`
+	}
+)
+
+// junitReport converts the report to JUnit XML. A package that failed
+// without a failing test, e.g. because it did not build, is an error case.
+func junitReport(report *Report) junitSuites {
+	suites := junitSuites{Time: fmt.Sprintf("%.3f", report.Elapsed.Seconds())}
+	for _, pkg := range report.Packages {
+		if pkg.NoTests {
+			continue
+		}
+		suite := junitSuite{Name: pkg.Path, Time: fmt.Sprintf("%.3f", pkg.Elapsed.Seconds())}
+		failedTest := false
+		for _, t := range report.Tests {
+			if t.Package != pkg.Path {
+				continue
+			}
+			c := junitCase{Name: t.Name, Classname: pkg.Path, Time: fmt.Sprintf("%.3f", t.Elapsed.Seconds())}
+			output := strings.Join(testOutput(t.Output), "\n")
+			switch t.Status {
+			case Fail:
+				c.Failure = &junitMessage{Message: "Failed", Text: output}
+				suite.Failures++
+				failedTest = true
+			case Skip:
+				c.Skipped = &junitMessage{Message: "Skipped", Text: output}
+				suite.Skipped++
+			}
+			suite.Cases = append(suite.Cases, c)
+			suite.Tests++
+		}
+		if pkg.Status == Fail && !failedTest {
+			suite.Cases = append(suite.Cases, junitCase{
+				Name:      "[build failed]",
+				Classname: pkg.Path,
+				Time:      "0.000",
+				Error:     &junitMessage{Message: "Package failed to build or run", Text: strings.Join(pkg.Output, "\n")},
+			})
+			suite.Tests++
+			suite.Errors++
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Errors += suite.Errors
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+	return suites
+}
+
+// The normalized JSON report: the packages with their tests, timings in
+// seconds, and the output of failed and skipped tests.
+type (
+	jsonSummary struct {
+		Passed   int       `json:"passed"`
+		Failed   int       `json:"failed"`
+		Skipped  int       `json:"skipped"`
+		Elapsed  float64   `json:"elapsed"`
+		Packages []jsonPkg `json:"packages"`
+	}
+	jsonPkg struct {
+		Path     string     `json:"path"`
+		Status   string     `json:"status"`
+		Cached   bool       `json:"cached,omitempty"`
+		Elapsed  float64    `json:"elapsed"`
+		Coverage *float64   `json:"coverage,omitempty"`
+		Output   []string   `json:"output,omitempty"`
+		Tests    []jsonTest `json:"tests"`
+	}
+	jsonTest struct {
+		Name    string   `json:"name"`
+		Status  string   `json:"status"`
+		Elapsed float64  `json:"elapsed"`
+		Output  []string `json:"output,omitempty"`
+	}
+)
+
+// JSONReport returns the report as FormatJSON writes it, for callers
+// encoding it themselves.
+func JSONReport(report *Report) any {
+	return jsonReport(report)
+}
+
+// jsonReport converts the report to the normalized JSON report.
+func jsonReport(report *Report) jsonSummary {
+	summary := jsonSummary{
+		Passed:   report.Count(Pass),
+		Failed:   report.Count(Fail),
+		Skipped:  report.Count(Skip),
+		Elapsed:  report.Elapsed.Seconds(),
+		Packages: []jsonPkg{},
+	}
+	for _, pkg := range report.Packages {
+		if pkg.NoTests {
+			continue
+		}
+		p := jsonPkg{Path: pkg.Path, Status: pkg.Status, Cached: pkg.Cached, Elapsed: pkg.Elapsed.Seconds(), Tests: []jsonTest{}}
+		if pkg.Coverage >= 0 {
+			coverage := pkg.Coverage
+			p.Coverage = &coverage
+		}
+		if pkg.Status == Fail {
+			p.Output = pkg.Output
+		}
+		for _, t := range report.Tests {
+			if t.Package != pkg.Path {
+				continue
+			}
+			test := jsonTest{Name: t.Name, Status: t.Status, Elapsed: t.Elapsed.Seconds()}
+			if t.Status != Pass {
+				test.Output = testOutput(t.Output)
+			}
+			p.Tests = append(p.Tests, test)
+		}
+		summary.Packages = append(summary.Packages, p)
+	}
+	return summary
+}
+
+// testOutput returns the output of a test without go test's "=== RUN"
+// and similar progress lines.
+func testOutput(lines []string) []string {
+	var output []string
+	for _, line := range lines {
+		if !strings.HasPrefix(strings.TrimSpace(line), "=== ") {
+			output = append(output, line)
+		}
+	}
+	return output
+}
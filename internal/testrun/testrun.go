@@ -0,0 +1,324 @@
+// Package testrun runs go test with -json and turns its event stream into
+// a report: the result of every package and test, with the output of the
+// failures, timings, and coverage.
+package testrun
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/night-slayer18/goforge/internal/runner"
+)
+
+// Results of packages and tests.
+const (
+	Pass = "pass"
+	Fail = "fail"
+	Skip = "skip"
+)
+
+// Options selects the tests to run and how.
+type Options struct {
+	Packages []string // package patterns, ./... when empty
+	Run      string   // regular expression selecting the tests, as go test -run
+	Race     bool
+	Cover    bool
+	Profile  string // coverage profile to write, relative to the directory of the run; implies Cover
+	Short    bool
+	NoCache  bool   // rerun tests whose results are cached
+	Timeout  string // e.g. "10m"
+
+	// OnOutput receives every output line of the tests as it arrives.
+	OnOutput func(line string)
+	// OnPackage is called when a package has finished.
+	OnPackage func(pkg *Package)
+}
+
+// Package is the result of a package.
+type Package struct {
+	Path     string
+	Status   string // Pass, Fail, or Skip
+	Cached   bool
+	NoTests  bool
+	Elapsed  time.Duration
+	Coverage float64  // percentage of statements; -1 when not measured
+	Output   []string // output outside of tests, e.g. build errors
+}
+
+// Test is the result of a test or subtest.
+type Test struct {
+	Package string
+	Name    string
+	Status  string // Pass, Fail, or Skip
+	Elapsed time.Duration
+	Output  []string
+}
+
+// Report is the result of a test run.
+type Report struct {
+	Packages []*Package
+	Tests    []*Test
+	Elapsed  time.Duration
+}
+
+// event is a line of go test -json, see 'go doc test2json'.
+type event struct {
+	Action      string
+	Package     string
+	Test        string
+	Elapsed     float64
+	Output      string
+	ImportPath  string // build-output events
+	FailedBuild string
+}
+
+// coverageLine matches the coverage go test prints per package.
+var coverageLine = regexp.MustCompile(`coverage: ([0-9.]+)% of statements`)
+
+// Args returns the arguments of go test for options.
+func Args(options Options) []string {
+	args := []string{"test", "-json"}
+	if options.Run != "" {
+		args = append(args, "-run", options.Run)
+	}
+	if options.Race {
+		args = append(args, "-race")
+	}
+	if options.Profile != "" {
+		args = append(args, "-coverprofile="+options.Profile)
+	} else if options.Cover {
+		args = append(args, "-cover")
+	}
+	if options.Short {
+		args = append(args, "-short")
+	}
+	if options.NoCache {
+		args = append(args, "-count=1")
+	}
+	if options.Timeout != "" {
+		args = append(args, "-timeout", options.Timeout)
+	}
+	if len(options.Packages) == 0 {
+		return append(args, "./...")
+	}
+	return append(args, options.Packages...)
+}
+
+// Run runs the tests in dir and returns their report. Failing tests are
+// not an error; the report tells. An error means go test could not run,
+// e.g. because no package matched.
+func Run(dir string, options Options) (*Report, error) {
+	start := time.Now()
+	cmd := exec.Command("go", Args(options)...)
+	cmd.Dir = dir
+	cmd.Env = runner.Environ()
+	var stderr bytes.Buffer
+	cmd.Stderr = io.MultiWriter(os.Stderr, &stderr)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("failed to run go test: %w", err)
+	}
+
+	report, parseErr := parse(stdout, options)
+	waitErr := cmd.Wait()
+	if parseErr != nil {
+		return nil, parseErr
+	}
+	report.Elapsed = time.Since(start)
+	if waitErr != nil && len(report.Packages) == 0 {
+		return nil, fmt.Errorf("go test failed: %s", strings.TrimSpace(stderr.String()))
+	}
+	return report, nil
+}
+
+// parse reads the events of go test -json into a report.
+func parse(r io.Reader, options Options) (*Report, error) {
+	report := &Report{}
+	packages := map[string]*Package{}
+	tests := map[string]*Test{}
+	buildOutput := map[string][]string{}
+
+	pkg := func(path string) *Package {
+		if p, ok := packages[path]; ok {
+			return p
+		}
+		p := &Package{Path: path, Coverage: -1}
+		packages[path] = p
+		report.Packages = append(report.Packages, p)
+		return p
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var e event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// Not an event, e.g. output of a test binary written to stdout
+			// before it started
+			if options.OnOutput != nil {
+				options.OnOutput(scanner.Text())
+			}
+			continue
+		}
+
+		if e.Action == "build-output" {
+			line := strings.TrimRight(e.Output, "\n")
+			buildOutput[e.ImportPath] = append(buildOutput[e.ImportPath], line)
+			if options.OnOutput != nil {
+				options.OnOutput(line)
+			}
+			continue
+		}
+		if e.Package == "" {
+			continue
+		}
+
+		if e.Test == "" {
+			p := pkg(e.Package)
+			switch e.Action {
+			case "output":
+				line := strings.TrimRight(e.Output, "\n")
+				if match := coverageLine.FindStringSubmatch(line); match != nil {
+					p.Coverage, _ = strconv.ParseFloat(match[1], 64)
+				}
+				if strings.HasSuffix(line, "(cached)") || strings.Contains(line, "\t(cached)") {
+					p.Cached = true
+				}
+				if strings.Contains(line, "[no test files]") {
+					p.NoTests = true
+				}
+				if isSummaryLine(line) {
+					continue
+				}
+				p.Output = append(p.Output, line)
+				if options.OnOutput != nil {
+					options.OnOutput(line)
+				}
+			case Pass, Fail, Skip:
+				p.Status = e.Action
+				p.Elapsed = seconds(e.Elapsed)
+				if e.FailedBuild != "" {
+					p.Output = slices.Concat(buildOutput[e.FailedBuild], p.Output)
+				}
+				if options.OnPackage != nil {
+					options.OnPackage(p)
+				}
+			}
+			continue
+		}
+
+		key := e.Package + "\x00" + e.Test
+		t, ok := tests[key]
+		if !ok {
+			t = &Test{Package: e.Package, Name: e.Test}
+			tests[key] = t
+			report.Tests = append(report.Tests, t)
+		}
+		switch e.Action {
+		case "output":
+			line := strings.TrimRight(e.Output, "\n")
+			t.Output = append(t.Output, line)
+			if options.OnOutput != nil {
+				options.OnOutput(line)
+			}
+		case Pass, Fail, Skip:
+			t.Status = e.Action
+			t.Elapsed = seconds(e.Elapsed)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the output of go test: %w", err)
+	}
+	return report, nil
+}
+
+// isSummaryLine reports whether line is go test's closing line of a
+// package, such as "ok  \texample.com/app\t0.01s" or "PASS".
+func isSummaryLine(line string) bool {
+	switch {
+	case line == "PASS", line == "FAIL":
+		return true
+	case strings.HasPrefix(line, "ok  \t"), strings.HasPrefix(line, "FAIL\t"), strings.HasPrefix(line, "?   \t"):
+		return true
+	case strings.HasPrefix(line, "coverage: "):
+		return true
+	}
+	return false
+}
+
+// seconds converts the elapsed seconds of an event.
+func seconds(elapsed float64) time.Duration {
+	return time.Duration(elapsed * float64(time.Second))
+}
+
+// Count returns the number of tests, subtests included, with the status.
+func (r *Report) Count(status string) int {
+	n := 0
+	for _, t := range r.Tests {
+		if t.Status == status {
+			n++
+		}
+	}
+	return n
+}
+
+// Failed reports whether a package or test failed.
+func (r *Report) Failed() bool {
+	for _, p := range r.Packages {
+		if p.Status == Fail {
+			return true
+		}
+	}
+	return r.Count(Fail) > 0
+}
+
+// Failures returns the failed tests, leaving out tests that only failed
+// because one of their subtests did.
+func (r *Report) Failures() []*Test {
+	var failures []*Test
+	for _, t := range r.Tests {
+		if t.Status != Fail {
+			continue
+		}
+		leaf := true
+		for _, other := range r.Tests {
+			if other.Status == Fail && other.Package == t.Package && strings.HasPrefix(other.Name, t.Name+"/") {
+				leaf = false
+				break
+			}
+		}
+		if leaf {
+			failures = append(failures, t)
+		}
+	}
+	return failures
+}
+
+// Slowest returns up to n of the top-level tests that took longest.
+func (r *Report) Slowest(n int) []*Test {
+	var tests []*Test
+	for _, t := range r.Tests {
+		if !strings.Contains(t.Name, "/") && t.Status != Skip && t.Elapsed > 0 {
+			tests = append(tests, t)
+		}
+	}
+	sort.SliceStable(tests, func(i, j int) bool { return tests[i].Elapsed > tests[j].Elapsed })
+	if len(tests) > n {
+		tests = tests[:n]
+	}
+	return tests
+}
@@ -0,0 +1,138 @@
+// Package updatecheck looks up the latest goforge release on the Go module
+// proxy, at most once a day, remembering the answer in the user cache.
+package updatecheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+// Module is goforge's module path, which releases are published under.
+const Module = "github.com/night-slayer18/goforge"
+
+// DisableEnv turns the check off when set to anything but "" or "0".
+const DisableEnv = "GOFORGE_NO_UPDATE_CHECK"
+
+// Interval is how long a looked-up release is trusted.
+const Interval = 24 * time.Hour
+
+// timeout bounds the lookup so a slow network never holds up a command.
+const timeout = 2 * time.Second
+
+// defaultProxy answers when GOPROXY names no proxy.
+const defaultProxy = "https://proxy.golang.org"
+
+// state is what the user cache remembers of the last lookup.
+type state struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Latest    string    `json:"latest,omitempty"`
+}
+
+// Disabled reports whether the environment turns the check off: by
+// DisableEnv, or on a CI system, where nobody reads the hint.
+func Disabled() bool {
+	if value := os.Getenv(DisableEnv); value != "" && value != "0" {
+		return true
+	}
+	return os.Getenv("CI") != ""
+}
+
+// Cached returns the latest release remembered from a lookup within
+// Interval; ok is false when a new lookup is due.
+func Cached() (latest string, ok bool) {
+	s, err := load()
+	if err != nil || time.Since(s.CheckedAt) > Interval {
+		return "", false
+	}
+	return s.Latest, true
+}
+
+// Lookup asks the module proxy for the latest release and remembers the
+// answer. A failed lookup is remembered too, so it is not retried before
+// Interval has passed.
+func Lookup() (string, error) {
+	latest, err := fetchLatest()
+	if saveErr := save(state{CheckedAt: time.Now(), Latest: latest}); err == nil {
+		err = saveErr
+	}
+	return latest, err
+}
+
+// Newer reports whether latest is a newer release than current. Builds
+// without a release version, such as "dev", are never out of date.
+func Newer(current, latest string) bool {
+	current = "v" + strings.TrimPrefix(current, "v")
+	return semver.IsValid(current) && semver.IsValid(latest) && semver.Compare(latest, current) > 0
+}
+
+// fetchLatest reads the latest version from the first proxy of GOPROXY.
+func fetchLatest() (string, error) {
+	proxy := defaultProxy
+	if proxies := strings.FieldsFunc(os.Getenv("GOPROXY"), func(r rune) bool { return r == ',' || r == '|' }); len(proxies) > 0 {
+		switch proxies[0] {
+		case "off", "direct":
+			return "", fmt.Errorf("GOPROXY=%s names no proxy to ask", os.Getenv("GOPROXY"))
+		}
+		proxy = proxies[0]
+	}
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Get(strings.TrimSuffix(proxy, "/") + "/" + Module + "/@latest")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s answered %s", proxy, resp.Status)
+	}
+	var info struct{ Version string }
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return "", fmt.Errorf("unreadable answer from %s: %w", proxy, err)
+	}
+	return info.Version, nil
+}
+
+// path returns the file remembering the last lookup.
+func path() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "goforge", "update-check.json"), nil
+}
+
+func load() (state, error) {
+	var s state
+	file, err := path()
+	if err != nil {
+		return s, err
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return s, err
+	}
+	err = json.Unmarshal(data, &s)
+	return s, err
+}
+
+func save(s state) error {
+	file, err := path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(file), 0755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(file, data, 0644)
+}
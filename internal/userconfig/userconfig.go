@@ -0,0 +1,191 @@
+// Package userconfig reads and edits the user's goforge defaults, kept in
+// goforge/config.yml in the user config directory
+// (~/.config/goforge/config.yml on Linux).
+package userconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/night-slayer18/goforge/internal/project"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds the user's defaults. Flags always take precedence over
+// them, and they over goforge's own defaults.
+type Config struct {
+	// Author is the copyright holder in the LICENSE of new projects.
+	Author string `yaml:"author"`
+
+	// License is the license of new projects.
+	License string `yaml:"license"`
+
+	// ModulePrefix makes the module path of 'goforge new app'
+	// <prefix>/app, e.g. github.com/myorg/app.
+	ModulePrefix string `yaml:"module_prefix"`
+
+	// Template is the project template of 'goforge new'.
+	Template string `yaml:"template"`
+
+	// SkipGit creates projects without a git repository.
+	SkipGit bool `yaml:"skip_git"`
+
+	// OnConflict is the conflict mode of 'goforge generate'.
+	OnConflict string `yaml:"on_conflict"`
+
+	// Color and Emoji turn colored output and emoji in log messages off
+	// when false.
+	Color *bool `yaml:"color"`
+	Emoji *bool `yaml:"emoji"`
+
+	// UpdateCheck turns the daily lookup of new goforge releases off when
+	// false.
+	UpdateCheck *bool `yaml:"update_check"`
+
+	// Telemetry sends anonymous usage statistics when true; it is off
+	// unless the user turns it on, see 'goforge telemetry'.
+	Telemetry bool `yaml:"telemetry"`
+}
+
+// Key is a setting of the user config.
+type Key struct {
+	Name        string
+	Description string
+	Bool        bool
+}
+
+// Keys lists the settings of the user config.
+var Keys = []Key{
+	{Name: "author", Description: "Copyright holder in the LICENSE of new projects"},
+	{Name: "license", Description: "License of new projects (MIT, Apache-2.0, GPL-3.0, none)"},
+	{Name: "module_prefix", Description: "Module path prefix of new projects, e.g. github.com/myorg"},
+	{Name: "template", Description: "Project template of 'goforge new'"},
+	{Name: "skip_git", Description: "Create projects without a git repository", Bool: true},
+	{Name: "on_conflict", Description: "Conflict mode of 'goforge generate' (prompt, skip, overwrite, merge)"},
+	{Name: "color", Description: "Colored output", Bool: true},
+	{Name: "emoji", Description: "Emoji in log messages", Bool: true},
+	{Name: "update_check", Description: "Daily check for new goforge releases", Bool: true},
+	{Name: "telemetry", Description: "Anonymous usage statistics ('goforge telemetry')", Bool: true},
+}
+
+// LookupKey returns the setting named name.
+func LookupKey(name string) (Key, error) {
+	for _, key := range Keys {
+		if key.Name == name {
+			return key, nil
+		}
+	}
+	names := make([]string, len(Keys))
+	for i, key := range Keys {
+		names[i] = key.Name
+	}
+	return Key{}, fmt.Errorf("unknown setting '%s' (use %s)", name, strings.Join(names, ", "))
+}
+
+// Path returns the location of the user config.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("could not determine user config directory: %w", err)
+	}
+	return filepath.Join(dir, "goforge", "config.yml"), nil
+}
+
+// Load reads the user config. A missing file is empty.
+func Load() (*Config, error) {
+	file, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	data, err := os.ReadFile(file)
+	if os.IsNotExist(err) {
+		return &cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", file, err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+	}
+	return &cfg, nil
+}
+
+// Get returns the value of the named setting, empty when it is unset.
+func (c *Config) Get(name string) string {
+	optional := func(value *bool) string {
+		if value == nil {
+			return ""
+		}
+		return strconv.FormatBool(*value)
+	}
+	switch name {
+	case "author":
+		return c.Author
+	case "license":
+		return c.License
+	case "module_prefix":
+		return c.ModulePrefix
+	case "template":
+		return c.Template
+	case "skip_git":
+		if !c.SkipGit {
+			return ""
+		}
+		return "true"
+	case "on_conflict":
+		return c.OnConflict
+	case "color":
+		return optional(c.Color)
+	case "emoji":
+		return optional(c.Emoji)
+	case "update_check":
+		return optional(c.UpdateCheck)
+	case "telemetry":
+		if !c.Telemetry {
+			return ""
+		}
+		return "true"
+	}
+	return ""
+}
+
+// Set writes value for the named setting, keeping the comments and other
+// settings of the file.
+func Set(name, value string) error {
+	key, err := LookupKey(name)
+	if err != nil {
+		return err
+	}
+	tag := "!!str"
+	if key.Bool {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be true or false, not '%s'", name, value)
+		}
+		value, tag = strconv.FormatBool(parsed), "!!bool"
+	}
+
+	file, err := Path()
+	if err != nil {
+		return err
+	}
+	return project.SetYAMLValue(file, []string{name}, value, tag)
+}
+
+// Unset removes the named setting, so its default applies again, and
+// reports whether it was set.
+func Unset(name string) (bool, error) {
+	if _, err := LookupKey(name); err != nil {
+		return false, err
+	}
+	file, err := Path()
+	if err != nil {
+		return false, err
+	}
+	return project.UnsetYAMLValue(file, []string{name})
+}
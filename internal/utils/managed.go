@@ -0,0 +1,41 @@
+package utils
+
+import (
+	"strings"
+)
+
+// Managed block markers delimit the section of a user-owned file (such as
+// .gitignore) that goforge is allowed to rewrite.
+const (
+	ManagedBlockStart = "# >>> goforge managed >>>"
+	ManagedBlockEnd   = "# <<< goforge managed <<<"
+)
+
+// ReplaceManagedBlock returns content with its goforge-managed block
+// replaced by lines. If no block exists yet, one is appended. Everything
+// outside the block is left untouched.
+func ReplaceManagedBlock(content string, lines []string) string {
+	block := ManagedBlockStart + "\n"
+	if len(lines) > 0 {
+		block += strings.Join(lines, "\n") + "\n"
+	}
+	block += ManagedBlockEnd + "\n"
+
+	start := strings.Index(content, ManagedBlockStart)
+	end := strings.Index(content, ManagedBlockEnd)
+	if start != -1 && end > start {
+		end += len(ManagedBlockEnd)
+		if end < len(content) && content[end] == '\n' {
+			end++
+		}
+		return content[:start] + block + content[end:]
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	if content != "" {
+		content += "\n"
+	}
+	return content + block
+}
@@ -1,6 +1,9 @@
 package utils
 
 import (
+	"slices"
+	"strings"
+
 	"github.com/iancoleman/strcase"
 )
 
@@ -21,3 +24,43 @@ func ToCamelCase(s string) string {
 func ToSnakeCase(s string) string {
 	return strcase.ToSnake(s)
 }
+
+// Closest returns the candidate a typo of name most likely meant: the one
+// fewest edits away, within a third of its length. It is empty when none
+// is that close.
+// Example: Closest("dve", []string{"build", "dev"}) -> "dev"
+func Closest(name string, candidates []string) string {
+	candidates = slices.Sorted(slices.Values(candidates)) // ties go to the first in order, not map order
+	best, bestDistance := "", max(1, len(name)/3)+1
+	for _, candidate := range candidates {
+		if distance := EditDistance(strings.ToLower(name), candidate); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// EditDistance is the Levenshtein distance between a and b, counting a
+// swap of neighbouring letters as one edit, as typos often are.
+// Example: EditDistance("bulid", "build") -> 1
+func EditDistance(a, b string) int {
+	prev2, prev, row := make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			row[j] = min(prev[j]+1, row[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				row[j] = min(row[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, row = prev, row, prev2
+	}
+	return prev[len(b)]
+}
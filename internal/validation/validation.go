@@ -2,10 +2,14 @@
 package validation
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
 	"unicode"
+
+	"github.com/night-slayer18/goforge/internal/exitcode"
+	"github.com/night-slayer18/goforge/internal/logger"
 )
 
 var (
@@ -44,6 +48,100 @@ func (e *ValidationError) Error() string {
 	return msg
 }
 
+// ExitCode makes goforge exit with the status of invalid input.
+func (e *ValidationError) ExitCode() int {
+	return int(exitcode.Validation)
+}
+
+// ValidationErrors collects every problem found in a set of inputs so they
+// can be reported together instead of failing on the first one.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	msg := fmt.Sprintf("%d validation errors:", len(e))
+	for _, err := range e {
+		msg += fmt.Sprintf("\n  - %s: %s", err.Field, err.Message)
+	}
+	return msg
+}
+
+// ExitCode makes goforge exit with the status of invalid input.
+func (e ValidationErrors) ExitCode() int {
+	return int(exitcode.Validation)
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As.
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Add appends err to the collection. Nil is ignored, collections are
+// flattened, and errors that are not validation errors are recorded
+// against field.
+func (e *ValidationErrors) Add(field string, err error) {
+	if err == nil {
+		return
+	}
+	var many ValidationErrors
+	var one *ValidationError
+	switch {
+	case errors.As(err, &many):
+		*e = append(*e, many...)
+	case errors.As(err, &one):
+		*e = append(*e, one)
+	default:
+		*e = append(*e, &ValidationError{Field: field, Message: err.Error()})
+	}
+}
+
+// Err returns the collection as an error, or nil when it is empty.
+func (e ValidationErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Collect returns the validation errors in err, whether it holds one or
+// many, and false when it holds none.
+func Collect(err error) (ValidationErrors, bool) {
+	var many ValidationErrors
+	if errors.As(err, &many) {
+		return many, true
+	}
+	var one *ValidationError
+	if errors.As(err, &one) {
+		return ValidationErrors{one}, true
+	}
+	return nil, false
+}
+
+// Report logs every validation error in err with its suggestions and
+// reports whether there were any.
+func Report(err error) bool {
+	errs, ok := Collect(err)
+	if !ok {
+		return false
+	}
+	if len(errs) > 1 {
+		logger.Error("❌ Found %d problems:", len(errs))
+	}
+	for i, e := range errs {
+		if i > 0 {
+			logger.Info("")
+		}
+		logger.ValidationError(e.Field, e.Value, e.Message, e.Suggestions)
+	}
+	return true
+}
+
 // ProjectValidator handles project-level validation
 type ProjectValidator struct{}
 
@@ -105,6 +203,15 @@ func (v *ProjectValidator) ValidateProjectName(name string) error {
 	return nil
 }
 
+// ValidateProject validates the name and module path of a new project
+// together, returning every problem found as ValidationErrors.
+func (v *ProjectValidator) ValidateProject(name, modulePath string) error {
+	var errs ValidationErrors
+	errs.Add("project_name", v.ValidateProjectName(name))
+	errs.Add("module_path", v.ValidateModulePath(modulePath))
+	return errs.Err()
+}
+
 // ValidateModulePath validates Go module paths
 func (v *ProjectValidator) ValidateModulePath(modulePath string) error {
 	if modulePath == "" {
@@ -165,6 +272,48 @@ func (v *ProjectValidator) ValidateComponentName(componentType, name string) err
 	return nil
 }
 
+// ValidateNamespace validates a sub-directory used to group components,
+// e.g. "admin" in "admin/user". It becomes a Go package name, so only
+// lowercase letters, digits, and underscores are allowed.
+func (v *ProjectValidator) ValidateNamespace(namespace string) error {
+	if namespace == "" {
+		return &ValidationError{
+			Field:   "namespace",
+			Value:   namespace,
+			Message: "namespace segments cannot be empty",
+			Suggestions: []string{
+				"Remove duplicate or trailing slashes, e.g. 'admin/user'",
+			},
+		}
+	}
+
+	if !isValidGoIdentifier(namespace) || strings.ToLower(namespace) != namespace || reservedNames[namespace] {
+		return &ValidationError{
+			Field:   "namespace",
+			Value:   namespace,
+			Message: "namespace must be a valid lowercase Go package name",
+			Suggestions: []string{
+				"Use lowercase letters and digits only",
+				fmt.Sprintf("Consider: %s", strings.ToLower(sanitizeIdentifier(namespace))),
+			},
+		}
+	}
+
+	return nil
+}
+
+// ValidateComponentPath validates the namespace segments and the name of a
+// component such as "admin/user", returning every problem found as
+// ValidationErrors.
+func (v *ProjectValidator) ValidateComponentPath(componentType string, namespaces []string, name string) error {
+	var errs ValidationErrors
+	for _, namespace := range namespaces {
+		errs.Add("namespace", v.ValidateNamespace(namespace))
+	}
+	errs.Add(fmt.Sprintf("%s_name", componentType), v.ValidateComponentName(componentType, name))
+	return errs.Err()
+}
+
 // Helper functions
 func suggestShorterName(name string) string {
 	if len(name) <= 20 {